@@ -12,6 +12,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/company"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/user"
@@ -24,11 +25,36 @@ import (
 func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore.Store, signatureService signatures.SignatureService, eventsService events.Service) {
 
 	api.CompanyAddCclaWhitelistRequestHandler = company.AddCclaWhitelistRequestHandlerFunc(
-		func(params company.AddCclaWhitelistRequestParams) middleware.Responder {
+		func(params company.AddCclaWhitelistRequestParams, claUser *user.CLAUser) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
 			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
-			requestID, err := service.AddCclaWhitelistRequest(ctx, params.CompanyID, params.ProjectID, params.Body)
+			f := logrus.Fields{
+				"functionName":   "CompanyAddCclaWhitelistRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"companyID":      params.CompanyID,
+				"projectID":      params.ProjectID,
+				"lfUsername":     claUser.LFUsername,
+			}
+
+			// The emails GitHub verified for the session are trusted like the identity provider email
+			session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
+			if err != nil {
+				log.WithFields(f).Warnf("error retrieving session from the session store, error: %+v", err)
+				return company.NewAddCclaWhitelistRequestBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+			if githubAccessToken, ok := session.Values["github_access_token"].(string); ok && githubAccessToken != "" {
+				githubEmails, emailErr := github.GetUserVerifiedEmails(ctx, githubAccessToken)
+				if emailErr != nil {
+					log.WithFields(f).Warnf("unable to load the GitHub verified emails of the session, error: %+v", emailErr)
+				}
+				claUser.VerifiedEmails = append(claUser.VerifiedEmails, githubEmails...)
+			}
+
+			requestID, err := service.AddCclaWhitelistRequest(ctx, claUser, params.CompanyID, params.ProjectID, params.Body)
 			if err != nil {
+				if err == ErrContributorNotFound {
+					return company.NewAddCclaWhitelistRequestUnauthorized().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
 				return company.NewAddCclaWhitelistRequestBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
 			}
 
@@ -36,7 +62,7 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 				EventType: events.CCLAApprovalListRequestCreated,
 				ProjectID: params.ProjectID,
 				CompanyID: params.CompanyID,
-				UserID:    params.Body.ContributorID,
+				UserID:    claUser.UserID,
 				EventData: &events.CCLAApprovalListRequestCreatedEventData{RequestID: requestID},
 			})
 
@@ -47,19 +73,12 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 		func(params company.ApproveCclaWhitelistRequestParams, claUser *user.CLAUser) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
 			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			// The service logs the decision event
 			err := service.ApproveCclaWhitelistRequest(ctx, claUser, params.CompanyID, params.ProjectID, params.RequestID)
 			if err != nil {
 				return company.NewApproveCclaWhitelistRequestBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
 			}
 
-			eventsService.LogEvent(&events.LogEventArgs{
-				EventType: events.CCLAApprovalListRequestApproved,
-				ProjectID: params.ProjectID,
-				CompanyID: params.CompanyID,
-				UserID:    claUser.UserID,
-				EventData: &events.CCLAApprovalListRequestApprovedEventData{RequestID: params.RequestID},
-			})
-
 			return company.NewApproveCclaWhitelistRequestOK().WithXRequestID(reqID)
 		})
 
@@ -67,22 +86,45 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 		func(params company.RejectCclaWhitelistRequestParams, claUser *user.CLAUser) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
 			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
-			err := service.RejectCclaWhitelistRequest(ctx, params.CompanyID, params.ProjectID, params.RequestID)
+			// The service logs the decision event
+			err := service.RejectCclaWhitelistRequest(ctx, claUser, params.CompanyID, params.ProjectID, params.RequestID, params.Body.Reason)
 			if err != nil {
 				return company.NewRejectCclaWhitelistRequestBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
 			}
 
-			eventsService.LogEvent(&events.LogEventArgs{
-				EventType: events.CCLAApprovalListRequestRejected,
-				ProjectID: params.ProjectID,
-				CompanyID: params.CompanyID,
-				UserID:    claUser.UserID,
-				EventData: &events.CCLAApprovalListRequestRejectedEventData{RequestID: params.RequestID},
-			})
-
 			return company.NewRejectCclaWhitelistRequestOK().WithXRequestID(reqID)
 		})
 
+	api.CompanyGetCclaApprovalListRequestRulesHandler = company.GetCclaApprovalListRequestRulesHandlerFunc(
+		func(params company.GetCclaApprovalListRequestRulesParams, claUser *user.CLAUser) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			rules, err := service.GetCclaApprovalListRequestRules(ctx, claUser, params.CompanyID)
+			if err != nil {
+				if err == ErrNotCompanyManager {
+					return company.NewGetCclaApprovalListRequestRulesForbidden().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return company.NewGetCclaApprovalListRequestRulesBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return company.NewGetCclaApprovalListRequestRulesOK().WithXRequestID(reqID).WithPayload(&models.CclaApprovalListRequestRules{Rules: rules})
+		})
+
+	api.CompanyUpdateCclaApprovalListRequestRulesHandler = company.UpdateCclaApprovalListRequestRulesHandlerFunc(
+		func(params company.UpdateCclaApprovalListRequestRulesParams, claUser *user.CLAUser) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			rules, err := service.UpdateCclaApprovalListRequestRules(ctx, claUser, params.CompanyID, params.Body.Rules)
+			if err != nil {
+				if err == ErrNotCompanyManager {
+					return company.NewUpdateCclaApprovalListRequestRulesForbidden().WithXRequestID(reqID).WithPayload(errorResponse(err))
+				}
+				return company.NewUpdateCclaApprovalListRequestRulesBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+			}
+
+			return company.NewUpdateCclaApprovalListRequestRulesOK().WithXRequestID(reqID).WithPayload(&models.CclaApprovalListRequestRules{Rules: rules})
+		})
+
 	api.CompanyListCclaWhitelistRequestsHandler = company.ListCclaWhitelistRequestsHandlerFunc(
 		func(params company.ListCclaWhitelistRequestsParams, claUser *user.CLAUser) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
@@ -40,6 +40,13 @@ func buildCclaWhitelistRequestsModels(results *dynamodb.QueryOutput) ([]models.C
 			UserID:             r.UserID,
 			UserName:           r.UserName,
 			Version:            r.Version,
+			Justification:      r.Justification,
+			PullRequestURL:     r.PullRequestURL,
+			EmailVerified:      r.EmailVerified,
+			DecidedBy:          r.DecidedBy,
+			DecisionReason:     r.DecisionReason,
+			AutoApproved:       r.AutoApproved,
+			AutoApprovalRule:   r.AutoApprovalRule,
 		})
 	}
 	return requests, nil
@@ -59,6 +66,11 @@ func addStringSliceAttribute(item map[string]*dynamodb.AttributeValue, key strin
 	}
 }
 
+// addBoolAttribute adds the specified attribute as a boolean
+func addBoolAttribute(item map[string]*dynamodb.AttributeValue, key string, value bool) {
+	item[key] = &dynamodb.AttributeValue{BOOL: aws.Bool(value)}
+}
+
 // addConditionToFilter - helper routine for adding a filter condition
 func addConditionToFilter(filter expression.ConditionBuilder, cond expression.ConditionBuilder, filterAdded *bool) expression.ConditionBuilder {
 	if !(*filterAdded) {
@@ -18,6 +18,13 @@ type CLARequestModel struct {
 	UserName           string   `dynamodbav:"user_name"`
 	UserGithubID       string   `dynamodbav:"user_github_id"`
 	UserGithubUsername string   `dynamodbav:"user_github_username"`
+	Justification      string   `dynamodbav:"justification"`
+	PullRequestURL     string   `dynamodbav:"pull_request_url"`
+	EmailVerified      bool     `dynamodbav:"email_verified"`
+	DecidedBy          string   `dynamodbav:"decided_by"`
+	DecisionReason     string   `dynamodbav:"decision_reason"`
+	AutoApproved       bool     `dynamodbav:"auto_approved"`
+	AutoApprovalRule   string   `dynamodbav:"auto_approval_rule"`
 	DateCreated        string   `dynamodbav:"date_created"`
 	DateModified       string   `dynamodbav:"date_modified"`
 	Version            string   `dynamodbav:"version"`
@@ -36,7 +43,30 @@ type CclaWhitelistRequest struct {
 	UserName           string   `dynamodbav:"user_name"`
 	UserGithubID       string   `dynamodbav:"user_github_id"`
 	UserGithubUsername string   `dynamodbav:"user_github_username"`
+	Justification      string   `dynamodbav:"justification"`
+	PullRequestURL     string   `dynamodbav:"pull_request_url"`
+	EmailVerified      bool     `dynamodbav:"email_verified"`
+	DecidedBy          string   `dynamodbav:"decided_by"`
+	DecisionReason     string   `dynamodbav:"decision_reason"`
+	AutoApproved       bool     `dynamodbav:"auto_approved"`
+	AutoApprovalRule   string   `dynamodbav:"auto_approval_rule"`
 	DateCreated        string   `dynamodbav:"date_created"`
 	DateModified       string   `dynamodbav:"date_modified"`
 	Version            string   `dynamodbav:"version"`
 }
+
+// RequestEvidence is the evidence supplied with the CCLA approval request for the CLA Managers
+type RequestEvidence struct {
+	Justification  string
+	PullRequestURL string
+	// EmailVerified is true if the request email is one of the verified emails of the contributor user record
+	EmailVerified bool
+}
+
+// RequestDecision is the decision on the CCLA approval request
+type RequestDecision struct {
+	DecidedBy        string
+	Reason           string
+	AutoApproved     bool
+	AutoApprovalRule string
+}
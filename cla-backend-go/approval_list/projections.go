@@ -20,6 +20,13 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("user_name"),
 		expression.Name("user_github_id"),
 		expression.Name("user_github_username"),
+		expression.Name("justification"),
+		expression.Name("pull_request_url"),
+		expression.Name("email_verified"),
+		expression.Name("decided_by"),
+		expression.Name("decision_reason"),
+		expression.Name("auto_approved"),
+		expression.Name("auto_approval_rule"),
 		expression.Name("date_created"),
 		expression.Name("date_modified"),
 		expression.Name("version"),
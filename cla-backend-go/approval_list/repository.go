@@ -6,6 +6,7 @@ package approval_list
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 
@@ -35,10 +36,10 @@ const (
 
 // IRepository interface defines the functions for the whitelist service
 type IRepository interface {
-	AddCclaWhitelistRequest(company *models.Company, project *models.ClaGroup, user *models.User, requesterName, requesterEmail string) (string, error)
+	AddCclaWhitelistRequest(company *models.Company, project *models.ClaGroup, user *models.User, requesterName, requesterEmail string, evidence RequestEvidence) (string, error)
 	GetCclaWhitelistRequest(requestID string) (*CLARequestModel, error)
-	ApproveCclaWhitelistRequest(requestID string) error
-	RejectCclaWhitelistRequest(requestID string) error
+	ApproveCclaWhitelistRequest(requestID string, decision RequestDecision) error
+	RejectCclaWhitelistRequest(requestID string, decision RequestDecision) error
 	ListCclaWhitelistRequest(companyID string, projectID, status, userID *string) (*models.CclaWhitelistRequestList, error)
 	GetRequestsByCLAGroup(claGroupID string) ([]CLARequestModel, error)
 	UpdateRequestsByCLAGroup(model *project.DBProjectModel) error
@@ -60,7 +61,7 @@ func NewRepository(awsSession *session.Session, stage string) IRepository {
 }
 
 // AddCclaWhitelistRequest adds the specified request
-func (repo repository) AddCclaWhitelistRequest(company *models.Company, project *models.ClaGroup, user *models.User, requesterName, requesterEmail string) (string, error) {
+func (repo repository) AddCclaWhitelistRequest(company *models.Company, project *models.ClaGroup, user *models.User, requesterName, requesterEmail string, evidence RequestEvidence) (string, error) {
 	f := logrus.Fields{
 		"functionName":   "AddCclaWhitelistRequest",
 		"requesterName":  requesterName,
@@ -90,6 +91,9 @@ func (repo repository) AddCclaWhitelistRequest(company *models.Company, project
 	addStringAttribute(input.Item, "user_name", requesterName)
 	addStringAttribute(input.Item, "user_github_id", user.GithubID)
 	addStringAttribute(input.Item, "user_github_username", user.GithubUsername)
+	addStringAttribute(input.Item, "justification", evidence.Justification)
+	addStringAttribute(input.Item, "pull_request_url", evidence.PullRequestURL)
+	addBoolAttribute(input.Item, "email_verified", evidence.EmailVerified)
 	addStringAttribute(input.Item, "date_created", currentTime)
 	addStringAttribute(input.Item, "date_modified", currentTime)
 	addStringAttribute(input.Item, "version", Version)
@@ -100,14 +104,7 @@ func (repo repository) AddCclaWhitelistRequest(company *models.Company, project
 		return status, err
 	}
 
-	// Load the new record - should be able to find it quickly
-	record, readErr := repo.ListCclaWhitelistRequest(company.CompanyID, &project.ProjectID, nil, &user.UserID)
-	if readErr != nil || record == nil || record.List == nil {
-		log.WithFields(f).Warnf("AddCclaWhitelistRequest - unable to read newly created invite record, error: %v", readErr)
-		return status, err
-	}
-
-	return record.List[0].RequestID, nil
+	return requestID.String(), nil
 }
 
 // GetCclaWhitelistRequest fetches the specified request by ID
@@ -142,78 +139,81 @@ func (repo repository) GetCclaWhitelistRequest(requestID string) (*CLARequestMod
 }
 
 // ApproveCclaWhitelistRequest approves the specified request
-func (repo repository) ApproveCclaWhitelistRequest(requestID string) error {
+func (repo repository) ApproveCclaWhitelistRequest(requestID string, decision RequestDecision) error {
+	return repo.updateRequestDecision(requestID, "approved", decision)
+}
+
+// RejectCclaWhitelistRequest rejects the specified request
+func (repo repository) RejectCclaWhitelistRequest(requestID string, decision RequestDecision) error {
+	return repo.updateRequestDecision(requestID, "rejected", decision)
+}
+
+// updateRequestDecision updates the status of the request and records who decided and why
+func (repo repository) updateRequestDecision(requestID, status string, decision RequestDecision) error {
 	f := logrus.Fields{
-		"functionName": "ApproveCclaWhitelistRequest",
+		"functionName": "updateRequestDecision",
 		"requestID":    requestID,
+		"status":       status,
+		"decidedBy":    decision.DecidedBy,
 	}
 
 	_, currentTime := utils.CurrentTime()
-	input := &dynamodb.UpdateItemInput{
-		Key: map[string]*dynamodb.AttributeValue{
-			"request_id": {
-				S: aws.String(requestID),
-			},
+	expressionAttributeNames := map[string]*string{
+		"#S": aws.String("request_status"),
+		"#M": aws.String("date_modified"),
+		"#A": aws.String("auto_approved"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":s": {
+			S: aws.String(status),
 		},
-		ExpressionAttributeNames: map[string]*string{
-			"#S": aws.String("request_status"),
-			"#M": aws.String("date_modified"),
+		":m": {
+			S: aws.String(currentTime),
 		},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":s": {
-				S: aws.String("approved"),
-			},
-			":m": {
-				S: aws.String(currentTime),
-			},
+		":a": {
+			BOOL: aws.Bool(decision.AutoApproved),
 		},
-		UpdateExpression: aws.String("SET #S = :s, #M = :m"),
-		TableName:        aws.String(repo.tableName),
 	}
-
-	_, err := repo.dynamoDBClient.UpdateItem(input)
-	if err != nil {
-		log.WithFields(f).WithError(err).Warnf("unable to update approval request with approved status, error: %v", err)
-		return err
+	updateExpression := "SET #S = :s, #M = :m, #A = :a"
+	var removeColumns []string
+
+	// DynamoDB does not accept the empty strings - remove the columns of the previous decision instead
+	for _, column := range []struct {
+		placeholder string
+		name        string
+		value       string
+	}{
+		{placeholder: "D", name: "decided_by", value: decision.DecidedBy},
+		{placeholder: "R", name: "decision_reason", value: decision.Reason},
+		{placeholder: "AR", name: "auto_approval_rule", value: decision.AutoApprovalRule},
+	} {
+		expressionAttributeNames["#"+column.placeholder] = aws.String(column.name)
+		if column.value == "" {
+			removeColumns = append(removeColumns, "#"+column.placeholder)
+			continue
+		}
+		expressionAttributeValues[":"+strings.ToLower(column.placeholder)] = &dynamodb.AttributeValue{S: aws.String(column.value)}
+		updateExpression = fmt.Sprintf("%s, #%s = :%s", updateExpression, column.placeholder, strings.ToLower(column.placeholder))
 	}
-
-	return nil
-}
-
-// RejectCclaWhitelistRequest rejects the specified request
-func (repo repository) RejectCclaWhitelistRequest(requestID string) error {
-	f := logrus.Fields{
-		"functionName": "RejectCclaWhitelistRequest",
-		"requestID":    requestID,
+	if len(removeColumns) > 0 {
+		updateExpression = fmt.Sprintf("%s REMOVE %s", updateExpression, strings.Join(removeColumns, ", "))
 	}
 
-	_, currentTime := utils.CurrentTime()
 	input := &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
 			"request_id": {
 				S: aws.String(requestID),
 			},
 		},
-		ExpressionAttributeNames: map[string]*string{
-			"#S": aws.String("request_status"),
-			"#M": aws.String("date_modified"),
-		},
-		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":s": {
-				S: aws.String("rejected"),
-			},
-			":m": {
-				S: aws.String(currentTime),
-			},
-		},
-		UpdateExpression: aws.String("SET #S = :s, #M = :m"),
-		TableName:        aws.String(repo.tableName),
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          aws.String(updateExpression),
+		TableName:                 aws.String(repo.tableName),
 	}
 
 	_, err := repo.dynamoDBClient.UpdateItem(input)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warnf("unable to update approval request with rejected status, error: %v",
-			err)
+		log.WithFields(f).WithError(err).Warnf("unable to update approval request with %s status, error: %v", status, err)
 		return err
 	}
 
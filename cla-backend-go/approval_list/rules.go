@@ -0,0 +1,87 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// auto-approval rule types of the CCLA approval requests
+const (
+	// RuleTypeVerifiedEmailApprovedDomain approves the verified emails on a domain of the CCLA domain approval list
+	RuleTypeVerifiedEmailApprovedDomain = "verifiedEmailApprovedDomain"
+	// RuleTypeVerifiedEmailDomain approves the verified emails on one of the domains of the rule
+	RuleTypeVerifiedEmailDomain = "verifiedEmailDomain"
+)
+
+// ValidateRequestRules returns an error if one of the auto-approval rules is invalid
+func ValidateRequestRules(rules []*models.CclaApprovalListRequestRule) error {
+	for _, rule := range rules {
+		if rule == nil {
+			return fmt.Errorf("invalid empty auto-approval rule")
+		}
+		switch utils.StringValue(rule.RuleType) {
+		case RuleTypeVerifiedEmailApprovedDomain:
+			if len(rule.Domains) > 0 {
+				return fmt.Errorf("the %s rule uses the domains of the CCLA domain approval list - domains are not supported", RuleTypeVerifiedEmailApprovedDomain)
+			}
+		case RuleTypeVerifiedEmailDomain:
+			if len(rule.Domains) == 0 {
+				return fmt.Errorf("the %s rule requires at least one domain", RuleTypeVerifiedEmailDomain)
+			}
+			for _, domain := range rule.Domains {
+				if err := signatures.ValidateDomainApprovalEntry(domain); err != nil {
+					return fmt.Errorf("invalid %s rule domain %s - %s", RuleTypeVerifiedEmailDomain, domain, err)
+				}
+			}
+		default:
+			return fmt.Errorf("invalid auto-approval rule type: %s", utils.StringValue(rule.RuleType))
+		}
+	}
+	return nil
+}
+
+// matchRequestRule returns the first auto-approval rule approving the request of the email for the CLA Group, nil if
+// the request requires a CLA Manager decision. Only the verified emails are approved automatically.
+func matchRequestRule(rules []*models.CclaApprovalListRequestRule, claGroupID, email string, evidence RequestEvidence, sig *models.Signature) *models.CclaApprovalListRequestRule {
+	if !evidence.EmailVerified || !utils.ValidEmail(email) {
+		return nil
+	}
+	for _, rule := range rules {
+		if rule == nil || (rule.ClaGroupID != "" && rule.ClaGroupID != claGroupID) {
+			continue
+		}
+		switch utils.StringValue(rule.RuleType) {
+		case RuleTypeVerifiedEmailApprovedDomain:
+			if sig != nil && signatures.IsEmailOnApprovedDomain(email, sig.DomainApprovalList) {
+				return rule
+			}
+		case RuleTypeVerifiedEmailDomain:
+			if signatures.IsEmailOnApprovedDomain(email, rule.Domains) {
+				return rule
+			}
+		}
+	}
+	return nil
+}
+
+// isVerifiedEmail returns true if the email is one of the emails the identity provider or GitHub verified for the
+// session of the contributor. The emails of the user record are not trusted, the user can update them.
+func isVerifiedEmail(verifiedEmails []string, email string) bool {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return false
+	}
+	for _, verifiedEmail := range verifiedEmails {
+		if strings.EqualFold(strings.TrimSpace(verifiedEmail), email) {
+			return true
+		}
+	}
+	return false
+}
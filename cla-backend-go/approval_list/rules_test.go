@@ -0,0 +1,49 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRequestRules(t *testing.T) {
+	assert.NoError(t, ValidateRequestRules(nil))
+	assert.NoError(t, ValidateRequestRules([]*models.CclaApprovalListRequestRule{
+		{RuleType: aws.String(RuleTypeVerifiedEmailApprovedDomain)},
+		{RuleType: aws.String(RuleTypeVerifiedEmailDomain), Domains: []string{"example.org", "*.example.org"}},
+	}))
+
+	assert.Error(t, ValidateRequestRules([]*models.CclaApprovalListRequestRule{{RuleType: aws.String("everyone")}}))
+	assert.Error(t, ValidateRequestRules([]*models.CclaApprovalListRequestRule{{RuleType: aws.String(RuleTypeVerifiedEmailDomain)}}), "missing domains")
+	assert.Error(t, ValidateRequestRules([]*models.CclaApprovalListRequestRule{{RuleType: aws.String(RuleTypeVerifiedEmailDomain), Domains: []string{"not a domain"}}}))
+	assert.Error(t, ValidateRequestRules([]*models.CclaApprovalListRequestRule{{RuleType: aws.String(RuleTypeVerifiedEmailApprovedDomain), Domains: []string{"example.org"}}}))
+}
+
+func TestMatchRequestRule(t *testing.T) {
+	sig := &models.Signature{DomainApprovalList: []string{"example.org"}}
+	approvedDomain := &models.CclaApprovalListRequestRule{RuleType: aws.String(RuleTypeVerifiedEmailApprovedDomain)}
+	ruleDomain := &models.CclaApprovalListRequestRule{RuleType: aws.String(RuleTypeVerifiedEmailDomain), ClaGroupID: "cla-group-1", Domains: []string{"*.example.com"}}
+	rules := []*models.CclaApprovalListRequestRule{approvedDomain, ruleDomain}
+	verified := RequestEvidence{EmailVerified: true}
+
+	assert.Equal(t, approvedDomain, matchRequestRule(rules, "cla-group-2", "jane.doe@example.org", verified, sig))
+	assert.Nil(t, matchRequestRule(rules, "cla-group-2", "jane.doe@example.org", RequestEvidence{}, sig), "the email is not verified")
+	assert.Nil(t, matchRequestRule(rules, "cla-group-2", "jane.doe@example.net", verified, sig))
+
+	assert.Equal(t, ruleDomain, matchRequestRule(rules, "cla-group-1", "jane.doe@dev.example.com", verified, sig))
+	assert.Nil(t, matchRequestRule(rules, "cla-group-2", "jane.doe@dev.example.com", verified, sig), "the rule applies to another CLA Group")
+}
+
+func TestIsVerifiedEmail(t *testing.T) {
+	verifiedEmails := []string{"jdoe@linuxfoundation.org", "Jane.Doe@example.org"}
+	assert.True(t, isVerifiedEmail(verifiedEmails, "jdoe@linuxfoundation.org"))
+	assert.True(t, isVerifiedEmail(verifiedEmails, " jane.doe@example.org"))
+	assert.False(t, isVerifiedEmail(verifiedEmails, "jane.doe@example.net"))
+	assert.False(t, isVerifiedEmail(verifiedEmails, ""))
+	assert.False(t, isVerifiedEmail(nil, "jane.doe@example.org"))
+}
@@ -8,12 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
 
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -31,6 +33,8 @@ import (
 // errors
 var (
 	ErrCclaApprovalRequestAlreadyExists = errors.New("approval request already exist")
+	ErrNotCompanyManager                = errors.New("user is not a manager of the company")
+	ErrContributorNotFound              = errors.New("no user record for the authenticated contributor")
 )
 
 // constants
@@ -40,11 +44,13 @@ const (
 
 // IService interface defines the service methods/functions
 type IService interface {
-	AddCclaWhitelistRequest(ctx context.Context, companyID string, claGroupID string, args models.CclaWhitelistRequestInput) (string, error)
+	AddCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, companyID string, claGroupID string, args models.CclaWhitelistRequestInput) (string, error)
 	ApproveCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, ClacompanyID, claGroupID, requestID string) error
-	RejectCclaWhitelistRequest(ctx context.Context, companyID, claGroupID, requestID string) error
+	RejectCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, companyID, claGroupID, requestID, reason string) error
 	ListCclaWhitelistRequest(companyID string, claGroupID, status *string) (*models.CclaWhitelistRequestList, error)
 	ListCclaWhitelistRequestByCompanyProjectUser(companyID string, claGroupID, status, userID *string) (*models.CclaWhitelistRequestList, error)
+	GetCclaApprovalListRequestRules(ctx context.Context, claUser *user.CLAUser, companyID string) ([]*models.CclaApprovalListRequestRule, error)
+	UpdateCclaApprovalListRequestRules(ctx context.Context, claUser *user.CLAUser, companyID string, rules []*models.CclaApprovalListRequestRule) ([]*models.CclaApprovalListRequestRule, error)
 }
 
 type service struct {
@@ -54,13 +60,15 @@ type service struct {
 	companyRepo                company.IRepository
 	projectRepo                project.ProjectRepository
 	signatureRepo              signatures.SignatureRepository
+	signatureService           signatures.SignatureService
+	eventsService              events.Service
 	projectsCLAGroupRepository projects_cla_groups.Repository
 	corpConsoleURL             string
 	httpClient                 *http.Client
 }
 
 // NewService creates a new whitelist service
-func NewService(repo IRepository, projectsCLAGroupRepository projects_cla_groups.Repository, projService project.Service, userRepo users.UserRepository, companyRepo company.IRepository, projectRepo project.ProjectRepository, signatureRepo signatures.SignatureRepository, corpConsoleURL string, httpClient *http.Client) IService {
+func NewService(repo IRepository, projectsCLAGroupRepository projects_cla_groups.Repository, projService project.Service, userRepo users.UserRepository, companyRepo company.IRepository, projectRepo project.ProjectRepository, signatureRepo signatures.SignatureRepository, signatureService signatures.SignatureService, eventsService events.Service, corpConsoleURL string, httpClient *http.Client) IService {
 	return service{
 		repo:                       repo,
 		projectsCLAGroupRepository: projectsCLAGroupRepository,
@@ -69,12 +77,22 @@ func NewService(repo IRepository, projectsCLAGroupRepository projects_cla_groups
 		companyRepo:                companyRepo,
 		projectRepo:                projectRepo,
 		signatureRepo:              signatureRepo,
+		signatureService:           signatureService,
+		eventsService:              eventsService,
 		corpConsoleURL:             corpConsoleURL,
 		httpClient:                 httpClient,
 	}
 }
 
-func (s service) AddCclaWhitelistRequest(ctx context.Context, companyID string, claGroupID string, args models.CclaWhitelistRequestInput) (string, error) {
+// AddCclaWhitelistRequest adds the CCLA approval request of the authenticated contributor, the contributor ID of the
+// input is ignored. Only the emails verified for the session of the contributor are approved automatically.
+func (s service) AddCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, companyID string, claGroupID string, args models.CclaWhitelistRequestInput) (string, error) {
+	if claUser == nil || claUser.UserID == "" {
+		log.Warnf("AddCclaWhitelistRequest - no user record for the authenticated user - unable to add the request for company: %s, project: %s",
+			companyID, claGroupID)
+		return "", ErrContributorNotFound
+	}
+	args.ContributorID = claUser.UserID
 	list, err := s.ListCclaWhitelistRequestByCompanyProjectUser(companyID, &claGroupID, nil, &args.ContributorID)
 	if err != nil {
 		log.Warnf("AddCclaWhitelistRequest - error looking up existing contributor invite requests for company: %s, project: %s, user by id: %s with name: %s, email: %s, error: %+v",
@@ -120,10 +138,21 @@ func (s service) AddCclaWhitelistRequest(ctx context.Context, companyID string,
 		return "", err
 	}
 
-	requestID, addErr := s.repo.AddCclaWhitelistRequest(companyModel, claGroupModel, userModel, args.ContributorName, args.ContributorEmail)
+	evidence := RequestEvidence{
+		Justification:  strings.TrimSpace(args.Justification),
+		PullRequestURL: strings.TrimSpace(args.PullRequestURL),
+		EmailVerified:  isVerifiedEmail(claUser.VerifiedEmails, args.ContributorEmail),
+	}
+	requestID, addErr := s.repo.AddCclaWhitelistRequest(companyModel, claGroupModel, userModel, args.ContributorName, args.ContributorEmail, evidence)
 	if addErr != nil {
 		log.Warnf("AddCclaWhitelistRequest - unable to add Approval Request for id: %s with name: %s, email: %s, error: %+v",
 			args.ContributorID, args.ContributorName, args.ContributorEmail, addErr)
+		return "", addErr
+	}
+
+	// The requests matching one of the auto-approval rules of the company don't need a CLA Manager decision
+	if s.autoApproveRequest(ctx, companyModel, claGroupModel, sig.Signatures[0], requestID, args.ContributorEmail, evidence) {
+		return requestID, nil
 	}
 
 	// Send the emails to the CLA managers for this CCLA Signature which includes the managers in the ACL list
@@ -142,7 +171,7 @@ func (s service) ApproveCclaWhitelistRequest(ctx context.Context, claUser *user.
 		"requestID":    requestID,
 		"Approver":     claUser.Name,
 	}
-	err := s.repo.ApproveCclaWhitelistRequest(requestID)
+	err := s.repo.ApproveCclaWhitelistRequest(requestID, RequestDecision{DecidedBy: claUser.LFUsername})
 	if err != nil {
 		log.WithFields(f).Warnf("ApproveCclaWhitelistRequest - problem updating approved list with 'approved' status for request: %s, error: %+v",
 			requestID, err)
@@ -209,14 +238,22 @@ func (s service) ApproveCclaWhitelistRequest(ctx context.Context, claUser *user.
 
 	// Send the email
 	s.sendRequestApprovedEmailToRecipient(ctx, s.projectService, s.projectsCLAGroupRepository, *claUser, companyModel, claGroupModel,
-		requestModel.UserName, requestModel.UserEmails[0], projectSFIDs)
+		requestModel.UserName, requestModel.UserEmails[0], s.recipientLanguage(requestModel.UserEmails[0]), projectSFIDs)
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.CCLAApprovalListRequestApproved,
+		ProjectID: claGroupID,
+		CompanyID: companyID,
+		UserID:    claUser.UserID,
+		EventData: &events.CCLAApprovalListRequestApprovedEventData{RequestID: requestID},
+	})
 
 	return nil
 }
 
 // RejectCclaWhitelistRequest is the handler for the decline CLA request
-func (s service) RejectCclaWhitelistRequest(ctx context.Context, companyID, claGroupID, requestID string) error {
-	err := s.repo.RejectCclaWhitelistRequest(requestID)
+func (s service) RejectCclaWhitelistRequest(ctx context.Context, claUser *user.CLAUser, companyID, claGroupID, requestID, reason string) error {
+	err := s.repo.RejectCclaWhitelistRequest(requestID, RequestDecision{DecidedBy: claUser.LFUsername, Reason: strings.TrimSpace(reason)})
 	if err != nil {
 		log.Warnf("RejectCclaWhitelistRequest - problem updating approved list with 'rejected' status for request: %s, error: %+v", requestID, err)
 		return err
@@ -257,7 +294,15 @@ func (s service) RejectCclaWhitelistRequest(ctx context.Context, companyID, claG
 	}
 
 	// Send the email
-	s.sendRequestRejectedEmailToRecipient(companyModel, claGroupModel, sig.Signatures[0], requestModel.UserName, requestModel.UserEmails[0])
+	s.sendRequestRejectedEmailToRecipient(companyModel, claGroupModel, sig.Signatures[0], requestModel.UserName, requestModel.UserEmails[0], s.recipientLanguage(requestModel.UserEmails[0]))
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.CCLAApprovalListRequestRejected,
+		ProjectID: claGroupID,
+		CompanyID: companyID,
+		UserID:    claUser.UserID,
+		EventData: &events.CCLAApprovalListRequestRejectedEventData{RequestID: requestID, Reason: strings.TrimSpace(reason)},
+	})
 
 	return nil
 }
@@ -272,6 +317,116 @@ func (s service) ListCclaWhitelistRequestByCompanyProjectUser(companyID string,
 	return s.repo.ListCclaWhitelistRequest(companyID, claGroupID, status, userID)
 }
 
+// GetCclaApprovalListRequestRules returns the auto-approval rules of the CCLA approval requests of the company
+func (s service) GetCclaApprovalListRequestRules(ctx context.Context, claUser *user.CLAUser, companyID string) ([]*models.CclaApprovalListRequestRule, error) {
+	if _, err := s.getManagedCompany(ctx, claUser, companyID); err != nil {
+		return nil, err
+	}
+	return s.companyRepo.GetCompanyApprovalListRequestRules(ctx, companyID)
+}
+
+// UpdateCclaApprovalListRequestRules replaces the auto-approval rules of the CCLA approval requests of the company
+func (s service) UpdateCclaApprovalListRequestRules(ctx context.Context, claUser *user.CLAUser, companyID string, rules []*models.CclaApprovalListRequestRule) ([]*models.CclaApprovalListRequestRule, error) {
+	f := logrus.Fields{
+		"functionName":   "UpdateCclaApprovalListRequestRules",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+	}
+	if _, err := s.getManagedCompany(ctx, claUser, companyID); err != nil {
+		return nil, err
+	}
+	if err := ValidateRequestRules(rules); err != nil {
+		log.WithFields(f).Warnf("invalid auto-approval rules, error: %+v", err)
+		return nil, err
+	}
+
+	err := s.companyRepo.UpdateCompanyApprovalListRequestRules(ctx, companyID, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var ruleTypes []string
+	for _, rule := range rules {
+		ruleTypes = append(ruleTypes, utils.StringValue(rule.RuleType))
+	}
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.CCLAApprovalListRequestRulesUpdated,
+		CompanyID: companyID,
+		UserID:    claUser.UserID,
+		EventData: &events.CCLAApprovalListRequestRulesUpdatedEventData{RuleTypes: ruleTypes},
+	})
+
+	return s.companyRepo.GetCompanyApprovalListRequestRules(ctx, companyID)
+}
+
+// getManagedCompany returns the company if the user is in the company ACL, ErrNotCompanyManager otherwise
+func (s service) getManagedCompany(ctx context.Context, claUser *user.CLAUser, companyID string) (*models.Company, error) {
+	companyModel, err := s.companyRepo.GetCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !utils.StringInSlice(claUser.LFUsername, companyModel.CompanyACL) {
+		return nil, ErrNotCompanyManager
+	}
+	return companyModel, nil
+}
+
+// autoApproveRequest approves the request if it matches one of the auto-approval rules of the company, the email is
+// added to the email approval list of the CCLA signature - returns true if the request was approved
+func (s service) autoApproveRequest(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, signature *models.Signature, requestID, email string, evidence RequestEvidence) bool {
+	f := logrus.Fields{
+		"functionName":   "autoApproveRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyModel.CompanyID,
+		"claGroupID":     claGroupModel.ProjectID,
+		"requestID":      requestID,
+	}
+
+	rules, err := s.companyRepo.GetCompanyApprovalListRequestRules(ctx, companyModel.CompanyID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the auto-approval rules - the request requires a CLA Manager decision, error: %+v", err)
+		return false
+	}
+	rule := matchRequestRule(rules, claGroupModel.ProjectID, email, evidence, signature)
+	if rule == nil {
+		return false
+	}
+	ruleType := utils.StringValue(rule.RuleType)
+	log.WithFields(f).Debugf("the request matches the auto-approval rule: %s", ruleType)
+
+	// The approval list update notifies the contributor and the CLA Managers
+	_, err = s.signatureService.UpdateApprovalListOnBehalfOf(ctx, signatures.AutoApprovalUsername, claGroupModel, companyModel, &models.ApprovalList{
+		AddEmailApprovalList: []string{strings.TrimSpace(email)},
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to add the email to the approval list - the request requires a CLA Manager decision, error: %+v", err)
+		return false
+	}
+
+	err = s.repo.ApproveCclaWhitelistRequest(requestID, RequestDecision{
+		DecidedBy:        signatures.AutoApprovalUsername,
+		AutoApproved:     true,
+		AutoApprovalRule: ruleType,
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to update the request with the approved status, error: %+v", err)
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.CCLAApprovalListRequestApproved,
+		ProjectID: claGroupModel.ProjectID,
+		CompanyID: companyModel.CompanyID,
+		UserModel: &models.User{LfUsername: signatures.AutoApprovalUsername, Username: signatures.AutoApprovalUsername},
+		EventData: &events.CCLAApprovalListRequestApprovedEventData{
+			RequestID:        requestID,
+			AutoApproved:     true,
+			AutoApprovalRule: ruleType,
+		},
+	})
+
+	return true
+}
+
 // sendRequestSentEmail sends emails to the CLA managers specified in the signature record
 func (s service) sendRequestSentEmail(companyModel *models.Company, claGroupModel *models.ClaGroup, signature *models.Signature, contributorName, contributorEmail, recipientName, recipientEmail, message string) {
 
@@ -279,12 +434,27 @@ func (s service) sendRequestSentEmail(companyModel *models.Company, claGroupMode
 	// CLA Manager Name/Email from a list, send this to this recipient (CLA Manager) - otherwise we will send to all
 	// CLA Managers on the Signature ACL
 	if recipientName != "" && recipientEmail != "" {
-		s.sendRequestEmailToRecipient(s.projectsCLAGroupRepository, companyModel, claGroupModel, contributorName, contributorEmail, recipientName, recipientEmail, message)
+		recipientUser, userErr := s.userRepo.GetUserByEmail(recipientEmail)
+		if _, notFound := userErr.(*utils.UserNotFound); userErr != nil && !notFound {
+			log.Warnf("unable to lookup the notification preferences of the recipient: %s, error: %+v", recipientEmail, userErr)
+		}
+		// the managers receiving a digest get the pending requests in the digest
+		if !users.NotifyImmediately(recipientUser, users.NotificationCategoryApprovalListRequests) {
+			return
+		}
+		var recipientLanguage string
+		if recipientUser != nil {
+			recipientLanguage = recipientUser.PreferredLanguage
+		}
+		s.sendRequestEmailToRecipient(s.projectsCLAGroupRepository, companyModel, claGroupModel, contributorName, contributorEmail, recipientName, recipientEmail, recipientLanguage, message)
 		return
 	}
 
 	// Send an email to each manager
-	for _, manager := range signature.SignatureACL {
+	for i, manager := range signature.SignatureACL {
+		if !users.NotifyImmediately(&signature.SignatureACL[i], users.NotificationCategoryApprovalListRequests) {
+			continue
+		}
 
 		// Need to determine which email...
 		var whichEmail = ""
@@ -300,37 +470,40 @@ func (s service) sendRequestSentEmail(companyModel *models.Company, claGroupMode
 			log.Warnf("unable to send email to manager: %+v - no email on file...", manager)
 		} else {
 			// Send the email
-			s.sendRequestEmailToRecipient(s.projectsCLAGroupRepository, companyModel, claGroupModel, contributorName, contributorEmail, manager.Username, whichEmail, message)
+			s.sendRequestEmailToRecipient(s.projectsCLAGroupRepository, companyModel, claGroupModel, contributorName, contributorEmail, manager.Username, whichEmail, manager.PreferredLanguage, message)
 		}
 	}
 }
 
 // sendRequestEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestEmailToRecipient(projectClaGroupRepository projects_cla_groups.Repository, companyModel *models.Company, claGroupModel *models.ClaGroup, contributorName, contributorEmail, recipientName, recipientAddress, message string) {
+func (s service) sendRequestEmailToRecipient(projectClaGroupRepository projects_cla_groups.Repository, companyModel *models.Company, claGroupModel *models.ClaGroup, contributorName, contributorEmail, recipientName, recipientAddress, recipientLanguage, message string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.RequestToAuthorizeTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			RecipientLanguage: recipientLanguage,
+		},
+		ContributorName:     contributorName,
+		ContributorEmail:    contributorEmail,
+		OptionalMessage:     message,
+		CorporateConsoleURL: s.corpConsoleURL,
+		CompanyID:           companyModel.CompanyID,
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Request to Authorize %s for %s", contributorName, projectName)
+	subject := emails.RenderSubject(emails.RequestToAuthorizeTemplateName,
+		fmt.Sprintf("EasyCLA: Request to Authorize %s for %s", contributorName, projectName), params)
 	recipients := []string{recipientAddress}
-	body, err := emails.RenderRequestToAuthorizeTemplate(projectClaGroupRepository, claGroupModel.Version, claGroupModel.ProjectExternalID,
-		emails.RequestToAuthorizeTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: recipientName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-			},
-			ContributorName:     contributorName,
-			ContributorEmail:    contributorEmail,
-			OptionalMessage:     message,
-			CorporateConsoleURL: s.corpConsoleURL,
-			CompanyID:           companyModel.CompanyID,
-		})
+	body, err := emails.RenderRequestToAuthorizeTemplate(projectClaGroupRepository, claGroupModel.Version, claGroupModel.ProjectExternalID, params)
 	if err != nil {
 		log.Warnf("rendering email template : %s failed : %v", emails.RequestToAuthorizeTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.RequestToAuthorizeTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -338,8 +511,20 @@ func (s service) sendRequestEmailToRecipient(projectClaGroupRepository projects_
 	}
 }
 
+// recipientLanguage returns the preferred language of the user of the email address, English when the user is unknown
+func (s service) recipientLanguage(email string) string {
+	recipientUser, userErr := s.userRepo.GetUserByEmail(email)
+	if userErr != nil || recipientUser == nil {
+		if _, notFound := userErr.(*utils.UserNotFound); userErr != nil && !notFound {
+			log.Warnf("unable to lookup the preferred language of the recipient: %s, error: %+v", email, userErr)
+		}
+		return ""
+	}
+	return recipientUser.PreferredLanguage
+}
+
 // sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestRejectedEmailToRecipient(companyModel *models.Company, claGroupModel *models.ClaGroup, signature *models.Signature, recipientName, recipientAddress string) {
+func (s service) sendRequestRejectedEmailToRecipient(companyModel *models.Company, claGroupModel *models.ClaGroup, signature *models.Signature, recipientName, recipientAddress, recipientLanguage string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
@@ -367,25 +552,29 @@ func (s service) sendRequestRejectedEmailToRecipient(companyModel *models.Compan
 		}
 	}
 
+	params := emails.ApprovalListRejectedTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			CLAManagers:       emailCLAManagerParams,
+			RecipientLanguage: recipientLanguage,
+		},
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Approval List Request Denied for Project %s", projectName)
+	subject := emails.RenderSubject(emails.ApprovalListRejectedTemplateName,
+		fmt.Sprintf("EasyCLA: Approval List Request Denied for Project %s", projectName), params)
 	recipients := []string{recipientAddress}
 	body, err := emails.RenderTemplate(claGroupModel.Version, emails.ApprovalListRejectedTemplateName,
 		emails.ApprovalListRejectedTemplate,
-		emails.ApprovalListRejectedTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: recipientName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-				CLAManagers:   emailCLAManagerParams,
-			},
-		},
+		params,
 	)
 	if err != nil {
 		log.Warnf("rendering email failed for : %s : %v", emails.ApprovalListRejectedTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.ApprovalListRejectedTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleContributor})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -393,7 +582,7 @@ func (s service) sendRequestRejectedEmailToRecipient(companyModel *models.Compan
 	}
 }
 
-func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, projectService project.Service, repository projects_cla_groups.Repository, claUser user.CLAUser, companyModel *models.Company, claGroupModel *models.ClaGroup, recipientName, recipientAddress string, projectSFIDs []string) {
+func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, projectService project.Service, repository projects_cla_groups.Repository, claUser user.CLAUser, companyModel *models.Company, claGroupModel *models.ClaGroup, recipientName, recipientAddress, recipientLanguage string, projectSFIDs []string) {
 
 	f := logrus.Fields{
 		"functionName":     "sendRequestApprovedEmailToRecipient",
@@ -406,8 +595,6 @@ func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, projec
 	}
 
 	companyName := companyModel.CompanyName
-	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Approved List Request Accepted for %s", companyName)
 	recipients := []string{recipientAddress}
 
 	approver := ""
@@ -419,21 +606,24 @@ func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, projec
 		approver = claUser.Emails[0]
 	}
 
-	body, err := emails.RenderApprovalListTemplate(
-		repository, projectService, projectSFIDs, emails.ApprovalListApprovedTemplateParams{
-			ApprovalTemplateParams: emails.ApprovalTemplateParams{
-				RecipientName: recipientName,
-				CompanyName:   companyName,
-				CLAGroupName:  claGroupModel.ProjectName,
-				Approver:      approver,
-			},
+	params := emails.ApprovalListApprovedTemplateParams{
+		ApprovalTemplateParams: emails.ApprovalTemplateParams{
+			RecipientName:     recipientName,
+			CompanyName:       companyName,
+			CLAGroupName:      claGroupModel.ProjectName,
+			Approver:          approver,
+			RecipientLanguage: recipientLanguage,
 		},
-	)
+	}
+	body, err := emails.RenderApprovalListTemplate(repository, projectService, projectSFIDs, &params)
 	if err != nil {
 		log.WithFields(f).Warnf("rendering email failed for : %s : %v", emails.ApprovalListApprovedTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	// subject string, body string, recipients []string
+	subject := emails.RenderSubject(emails.ApprovalListApprovedTemplateName,
+		fmt.Sprintf("EasyCLA: Approved List Request Accepted for %s", companyName), params)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.ApprovalListApprovedTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleContributor})
 	if err != nil {
 		log.WithFields(f).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
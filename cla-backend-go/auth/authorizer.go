@@ -116,13 +116,15 @@ func (a Authorizer) SecurityAuth(token string, scopes []string) (*user.CLAUser,
 		log.WithFields(f).WithError(err).Warnf("GetUserAndProfilesByLFID error fetching username: %s, error: %+v", username, err)
 		if err.Error() == "user not found" {
 			return &user.CLAUser{
-				Name:       name,
-				LFEmail:    email,
-				LFUsername: username,
+				Name:           name,
+				LFEmail:        email,
+				LFUsername:     username,
+				VerifiedEmails: []string{email},
 			}, nil
 		}
 		return nil, err
 	}
+	lfuser.VerifiedEmails = []string{email}
 
 	for _, scope := range scopes {
 		switch Scope(scope) {
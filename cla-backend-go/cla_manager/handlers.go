@@ -186,10 +186,14 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 		})
 
 		// Send email to each manager
-		for _, manager := range claManagers {
+		for i, manager := range claManagers {
+			// the managers receiving a digest get the pending requests in the digest
+			if !users.NotifyImmediately(&claManagers[i], users.NotificationCategoryCLAManagerRequests) {
+				continue
+			}
 			sendRequestAccessEmailToCLAManagers(companyModel, claGroupModel,
 				params.Body.UserName, params.Body.UserEmail,
-				manager.Username, manager.LfEmail)
+				manager.Username, manager.LfEmail, manager.PreferredLanguage)
 		}
 
 		return cla_manager.NewCreateCLAManagerRequestOK().WithXRequestID(reqID).WithPayload(request)
@@ -355,10 +359,14 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 			},
 		})
 
-		// Notify CLA Managers - send email to each manager
-		for _, manager := range claManagers {
+		// Notify CLA Managers - send email to each manager, the digests only list the pending requests so the
+		// decisions are sent immediately unless the manager turned the notifications off
+		for i, manager := range claManagers {
+			if users.NotificationsOff(&claManagers[i], users.NotificationCategoryCLAManagerRequests) {
+				continue
+			}
 			sendRequestApprovedEmailToCLAManagers(companyModel, claGroupModel, request.UserName, request.UserEmail,
-				manager.Username, manager.LfEmail)
+				manager.Username, manager.LfEmail, manager.PreferredLanguage)
 		}
 
 		// Notify the requester
@@ -457,10 +465,14 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 			},
 		})
 
-		// Notify CLA Managers - send email to each manager
-		for _, manager := range claManagers {
+		// Notify CLA Managers - send email to each manager, the decisions are sent immediately unless the manager
+		// turned the notifications off
+		for i, manager := range claManagers {
+			if users.NotificationsOff(&claManagers[i], users.NotificationCategoryCLAManagerRequests) {
+				continue
+			}
 			sendRequestDeniedEmailToCLAManagers(companyModel, claGroupModel, request.UserName, request.UserEmail,
-				manager.Username, manager.LfEmail)
+				manager.Username, manager.LfEmail, manager.PreferredLanguage)
 		}
 
 		// Notify the requester
@@ -882,30 +894,34 @@ func buildErrorMessageDeleteManager(errPrefix string, params cla_manager.DeleteC
 }
 
 // sendRequestAccessEmailToCLAManagers sends the request access email to the specified CLA Managers
-func sendRequestAccessEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, requesterName, requesterEmail, recipientName, recipientAddress string) {
+func sendRequestAccessEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, requesterName, requesterEmail, recipientName, recipientAddress, recipientLanguage string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.RequestAccessToCLAManagersTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			RecipientLanguage: recipientLanguage,
+		},
+		RequesterName:  requesterName,
+		RequesterEmail: requesterEmail,
+		CorporateURL:   utils.GetCorporateURL(claGroupModel.Version == utils.V2),
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Request for %s on %s", companyName, projectName)
+	subject := emails.RenderSubject(emails.RequestAccessToCLAManagersTemplateName,
+		fmt.Sprintf("EasyCLA: New CLA Manager Access Request for %s on %s", companyName, projectName), params)
 	recipients := []string{recipientAddress}
 	body, err := emails.RenderTemplate(claGroupModel.Version, emails.RequestAccessToCLAManagersTemplateName,
-		emails.RequestAccessToCLAManagersTemplate, emails.RequestAccessToCLAManagersTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: recipientName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-			},
-			RequesterName:  requesterName,
-			RequesterEmail: requesterEmail,
-			CorporateURL:   utils.GetCorporateURL(claGroupModel.Version == utils.V2),
-		})
+		emails.RequestAccessToCLAManagersTemplate, params)
 	if err != nil {
 		log.Warnf("rendering email template : %s failed : %v", emails.RequestAccessToCLAManagersTemplateName, err)
 		return
 	}
 
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.RequestAccessToCLAManagersTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -913,32 +929,36 @@ func sendRequestAccessEmailToCLAManagers(companyModel *models.Company, claGroupM
 	}
 }
 
-func sendRequestApprovedEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, requesterName, requesterEmail, recipientName, recipientAddress string) {
+func sendRequestApprovedEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, requesterName, requesterEmail, recipientName, recipientAddress, recipientLanguage string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.RequestApprovedToCLAManagersTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			RecipientLanguage: recipientLanguage,
+		},
+		RequesterName:  requesterName,
+		RequesterEmail: requesterEmail,
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Approval Notice for %s", projectName)
+	subject := emails.RenderSubject(emails.RequestApprovedToCLAManagersTemplateName,
+		fmt.Sprintf("EasyCLA: CLA Manager Access Approval Notice for %s", projectName), params)
 	recipients := []string{recipientAddress}
 	body, err := emails.RenderTemplate(
 		claGroupModel.Version,
 		emails.RequestApprovedToCLAManagersTemplateName,
 		emails.RequestApprovedToCLAManagersTemplate,
-		emails.RequestApprovedToCLAManagersTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: recipientName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-			},
-			RequesterName:  requesterName,
-			RequesterEmail: requesterEmail,
-		})
+		params)
 
 	if err != nil {
 		log.Warnf("rendering email template : %s failed : %v", emails.RequestApprovedToCLAManagersTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.RequestApprovedToCLAManagersTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -950,23 +970,26 @@ func sendRequestApprovedEmailToRequester(companyModel *models.Company, claGroupM
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.RequestApprovedToRequesterTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName: requesterName,
+			Project:       emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:   companyName,
+		},
+		CorporateURL: utils.GetCorporateURL(claGroupModel.Version == utils.V2),
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Approved for %s", projectName)
+	subject := emails.RenderSubject(emails.RequestApprovedToRequesterTemplateName,
+		fmt.Sprintf("EasyCLA: New CLA Manager Access Approved for %s", projectName), params)
 	recipients := []string{requesterEmail}
 	body, err := emails.RenderTemplate(claGroupModel.Version, emails.RequestApprovedToRequesterTemplateName,
-		emails.RequestApprovedToRequesterTemplate, emails.RequestApprovedToRequesterTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: requesterName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-			},
-			CorporateURL: utils.GetCorporateURL(claGroupModel.Version == utils.V2),
-		})
+		emails.RequestApprovedToRequesterTemplate, params)
 	if err != nil {
 		log.Warnf("email template : %s failed rendering : %s", emails.RequestApprovedToRequesterTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.RequestApprovedToRequesterTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleRequester})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -974,26 +997,30 @@ func sendRequestApprovedEmailToRequester(companyModel *models.Company, claGroupM
 	}
 }
 
-func sendRequestDeniedEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, requesterName, requesterEmail, recipientName, recipientAddress string) {
+func sendRequestDeniedEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, requesterName, requesterEmail, recipientName, recipientAddress, recipientLanguage string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.RequestDeniedToCLAManagersTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			RecipientLanguage: recipientLanguage,
+		},
+		RequesterName:  requesterName,
+		RequesterEmail: requesterEmail,
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Access Denied Notice for %s", projectName)
+	subject := emails.RenderSubject(emails.RequestDeniedToCLAManagersTemplateName,
+		fmt.Sprintf("EasyCLA: CLA Manager Access Denied Notice for %s", projectName), params)
 	recipients := []string{recipientAddress}
 	body, err := emails.RenderTemplate(
 		claGroupModel.Version,
 		emails.RequestDeniedToCLAManagersTemplateName,
 		emails.RequestDeniedToCLAManagersTemplate,
-		emails.RequestDeniedToCLAManagersTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: recipientName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-			},
-			RequesterName:  requesterName,
-			RequesterEmail: requesterEmail,
-		},
+		params,
 	)
 
 	if err != nil {
@@ -1001,7 +1028,7 @@ func sendRequestDeniedEmailToCLAManagers(companyModel *models.Company, claGroupM
 		return
 	}
 
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.RequestDeniedToCLAManagersTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -1013,25 +1040,28 @@ func sendRequestDeniedEmailToRequester(companyModel *models.Company, claGroupMod
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.RequestDeniedToRequesterTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName: requesterName,
+			Project:       emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:   companyName,
+		},
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: New CLA Manager Access Denied for %s", projectName)
+	subject := emails.RenderSubject(emails.RequestDeniedToRequesterTemplateName,
+		fmt.Sprintf("EasyCLA: New CLA Manager Access Denied for %s", projectName), params)
 	recipients := []string{requesterEmail}
 	body, err := emails.RenderTemplate(claGroupModel.Version, emails.RequestDeniedToRequesterTemplateName,
 		emails.RequestDeniedToRequesterTemplate,
-		emails.RequestDeniedToRequesterTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: requesterName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-			},
-		})
+		params)
 
 	if err != nil {
 		log.Warnf("email template rendering %s failed : %v", emails.RequestDeniedToRequesterTemplateName, err)
 		return
 	}
 
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.RequestDeniedToRequesterTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleRequester})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -233,10 +233,10 @@ func (s service) AddClaManager(ctx context.Context, companyID string, claGroupID
 	// Notify CLA Managers - send email to each manager
 	for _, manager := range claManagers {
 		sendClaManagerAddedEmailToCLAManagers(companyModel, claGroupModel, userModel.Username, userModel.LfEmail,
-			manager.Username, manager.LfEmail)
+			manager.Username, manager.LfEmail, manager.PreferredLanguage)
 	}
 	// Notify the added user
-	sendClaManagerAddedEmailToUser(companyModel, claGroupModel, userModel.Username, userModel.LfEmail, projectSFName)
+	sendClaManagerAddedEmailToUser(companyModel, claGroupModel, userModel.Username, userModel.LfEmail, userModel.PreferredLanguage, projectSFName)
 
 	// Send an event
 	s.eventsService.LogEvent(&events.LogEventArgs{
@@ -332,11 +332,11 @@ func (s service) RemoveClaManager(ctx context.Context, companyID string, claGrou
 	// Notify CLA Managers - send email to each manager
 	for _, manager := range claManagers {
 		sendClaManagerDeleteEmailToCLAManagers(companyModel, claGroupModel, userModel.LfUsername, userModel.LfEmail,
-			manager.Username, manager.LfEmail)
+			manager.Username, manager.LfEmail, manager.PreferredLanguage)
 	}
 
 	// Notify the removed manager
-	sendRemovedClaManagerEmailToRecipient(s.projectClaRepository, companyModel, claGroupModel, userModel.LfUsername, userModel.LfEmail, claManagers)
+	sendRemovedClaManagerEmailToRecipient(s.projectClaRepository, companyModel, claGroupModel, userModel.LfUsername, userModel.LfEmail, userModel.PreferredLanguage, claManagers)
 
 	// Send an event
 	s.eventsService.LogEvent(&events.LogEventArgs{
@@ -361,7 +361,7 @@ func (s service) RemoveClaManager(ctx context.Context, companyID string, claGrou
 	return updatedSignature, nil
 }
 
-func sendClaManagerAddedEmailToUser(companyModel *models.Company, claGroupModel *models.ClaGroup, requesterName, requesterEmail, projectSFName string) {
+func sendClaManagerAddedEmailToUser(companyModel *models.Company, claGroupModel *models.ClaGroup, requesterName, requesterEmail, requesterLanguage, projectSFName string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 	templateName := emails.ClaManagerAddedEToUserTemplate
@@ -370,29 +370,33 @@ func sendClaManagerAddedEmailToUser(companyModel *models.Company, claGroupModel
 		projectName = projectSFName
 	}
 
+	params := emails.ClaManagerAddedEToUserTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     requesterName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			CLAGroupName:      claGroupModel.ProjectName,
+			RecipientLanguage: requesterLanguage,
+		},
+		CorporateURL: utils.GetCorporateURL(claGroupModel.Version == utils.V2),
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Added as CLA Manager for Project :%s", projectName)
+	subject := emails.RenderSubject(emails.ClaManagerAddedEToUserTemplateName,
+		fmt.Sprintf("EasyCLA: Added as CLA Manager for Project :%s", projectName), params)
 	recipients := []string{requesterEmail}
 	body, err := emails.RenderTemplate(
 		claGroupModel.Version,
 		emails.ClaManagerAddedEToUserTemplateName,
 		templateName,
-		emails.ClaManagerAddedEToUserTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: requesterName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-				CLAGroupName:  claGroupModel.ProjectName,
-			},
-			CorporateURL: utils.GetCorporateURL(claGroupModel.Version == utils.V2),
-		},
+		params,
 	)
 	if err != nil {
 		log.Warnf("email template render : %s failed : %v", emails.ClaManagerAddedEToUserTemplateName, err)
 		return
 	}
 
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.ClaManagerAddedEToUserTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -400,31 +404,35 @@ func sendClaManagerAddedEmailToUser(companyModel *models.Company, claGroupModel
 	}
 }
 
-func sendClaManagerAddedEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, name, email, recipientName, recipientAddress string) {
+func sendClaManagerAddedEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, name, email, recipientName, recipientAddress, recipientLanguage string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.ClaManagerAddedToCLAManagersTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			RecipientLanguage: recipientLanguage,
+		},
+		Name:  name,
+		Email: email,
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Added Notice for %s", projectName)
+	subject := emails.RenderSubject(emails.ClaManagerAddedToCLAManagersTemplateName,
+		fmt.Sprintf("EasyCLA: CLA Manager Added Notice for %s", projectName), params)
 	recipients := []string{recipientAddress}
 	body, err := emails.RenderTemplate(claGroupModel.Version, emails.ClaManagerAddedToCLAManagersTemplateName,
 		emails.ClaManagerAddedToCLAManagersTemplate,
-		emails.ClaManagerAddedToCLAManagersTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: recipientName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-			},
-			Name:  name,
-			Email: email,
-		})
+		params)
 
 	if err != nil {
 		log.Warnf("email template render : %s failed : %v", emails.ClaManagerAddedToCLAManagersTemplate, err)
 		return
 	}
 
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.ClaManagerAddedToCLAManagersTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -433,7 +441,7 @@ func sendClaManagerAddedEmailToCLAManagers(companyModel *models.Company, claGrou
 }
 
 // sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient
-func sendRemovedClaManagerEmailToRecipient(projectsClaGroupRepository projects_cla_groups.Repository, companyModel *models.Company, claGroupModel *models.ClaGroup, recipientName, recipientAddress string, claManagers []models.User) {
+func sendRemovedClaManagerEmailToRecipient(projectsClaGroupRepository projects_cla_groups.Repository, companyModel *models.Company, claGroupModel *models.ClaGroup, recipientName, recipientAddress, recipientLanguage string, claManagers []models.User) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
@@ -480,12 +488,21 @@ func sendRemovedClaManagerEmailToRecipient(projectsClaGroupRepository projects_c
 	}
 
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Removed as CLA Manager for Project %s", projectName)
+	subject := emails.RenderSubject(emails.RemovedCLAManagerTemplateName,
+		fmt.Sprintf("EasyCLA: Removed as CLA Manager for Project %s", projectName),
+		emails.RemovedCLAManagerTemplateParams{CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			CLAManagers:       emailCLAManagerParams,
+			RecipientLanguage: recipientLanguage,
+		}})
 	recipients := []string{recipientAddress}
 	body, err := emails.RenderRemovedCLAManagerTemplate(
 		projectsClaGroupRepository,
 		claGroupModel.Version,
 		recipientName,
+		recipientLanguage,
 		companyName,
 		claGroupModel.ProjectExternalID,
 		emailCLAManagerParams)
@@ -495,7 +512,7 @@ func sendRemovedClaManagerEmailToRecipient(projectsClaGroupRepository projects_c
 		return
 	}
 
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.RemovedCLAManagerTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -503,32 +520,36 @@ func sendRemovedClaManagerEmailToRecipient(projectsClaGroupRepository projects_c
 	}
 }
 
-func sendClaManagerDeleteEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, name, email, recipientName, recipientAddress string) {
+func sendClaManagerDeleteEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, name, email, recipientName, recipientAddress, recipientLanguage string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.ClaManagerDeletedToCLAManagersTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			RecipientLanguage: recipientLanguage,
+		},
+		Name:  name,
+		Email: email,
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: CLA Manager Removed Notice for %s", projectName)
+	subject := emails.RenderSubject(emails.ClaManagerDeletedToCLAManagersTemplateName,
+		fmt.Sprintf("EasyCLA: CLA Manager Removed Notice for %s", projectName), params)
 	recipients := []string{recipientAddress}
 	body, err := emails.RenderTemplate(claGroupModel.Version,
 		emails.ClaManagerDeletedToCLAManagersTemplateName,
 		emails.ClaManagerDeletedToCLAManagersTemplate,
-		emails.ClaManagerDeletedToCLAManagersTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: recipientName,
-				Project:       emails.CLAProjectParams{ExternalProjectName: projectName},
-				CompanyName:   companyName,
-			},
-			Name:  name,
-			Email: email,
-		})
+		params)
 
 	if err != nil {
 		log.Warnf("email template render : %s failed : %v", emails.ClaManagerDeletedToCLAManagersTemplateName, err)
 		return
 	}
 
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.ClaManagerDeletedToCLAManagersTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -27,10 +27,13 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
+	v2GithubActivity "github.com/communitybridge/easycla/cla-backend-go/v2/github_activity"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/webhooks"
 
 	"github.com/communitybridge/easycla/cla-backend-go/token"
 
 	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
 	"github.com/communitybridge/easycla/cla-backend-go/github"
 	v2Company "github.com/communitybridge/easycla/cla-backend-go/v2/company"
 
@@ -89,9 +92,12 @@ func init() {
 	claManagerRequestsRepo := cla_manager.NewRepository(awsSession, stage)
 	approvalListRequestsRepo := approval_list.NewRepository(awsSession, stage)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
+	webhooksRepo := webhooks.NewRepository(awsSession, stage)
 
 	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
 	github.Init(configFile.GitHub.AppID, configFile.GitHub.AppPrivateKey, configFile.GitHub.AccessToken)
+	github.SetAPIBaseURL(configFile.GitHub.APIBaseURL)
+	emails.SetTemplateRegistry(emails.NewTemplateRegistry(emails.NewTemplateStore(awsSession, configFile), emails.DefaultTemplateCacheTTL))
 
 	user_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
 	project_service.InitClient(configFile.APIGatewayURL)
@@ -121,6 +127,9 @@ func init() {
 	v2CompanyService := v2Company.NewService(companyService, signaturesRepo, projectRepo, usersRepo, companyRepo, projectClaGroupRepo, eventsService)
 	organization_service.InitClient(configFile.APIGatewayURL, eventsService)
 	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, true)
+	autoEnableService := dynamo_events.NewAutoEnableService(repositoriesService, repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo, projectService, usersRepo)
+	githubActivityService := v2GithubActivity.NewService(repositoriesRepo, eventsService, autoEnableService, projectRepo, githubOrganizationsRepo, usersRepo, signaturesService, configFile.ClaV1ApiURL, configFile.CLALandingPage)
 	dynamoEventsService = dynamo_events.NewService(
 		stage,
 		signaturesRepo,
@@ -134,7 +143,10 @@ func init() {
 		repositoriesService,
 		gerritService,
 		claManagerRequestsRepo,
-		approvalListRequestsRepo)
+		approvalListRequestsRepo,
+		githubActivityService,
+		webhooks.NewService(webhooksRepo, configFile.CorporateConsoleV2URL),
+		signaturesService)
 }
 
 func handler(ctx context.Context, event events.DynamoDBEvent) {
@@ -11,6 +11,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/cmd/functional_tests/cla_manager"
 	"github.com/communitybridge/easycla/cla-backend-go/cmd/functional_tests/company"
 	"github.com/communitybridge/easycla/cla-backend-go/cmd/functional_tests/health"
+	"github.com/communitybridge/easycla/cla-backend-go/cmd/functional_tests/sign"
 	"github.com/communitybridge/easycla/cla-backend-go/cmd/functional_tests/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/cmd/functional_tests/template"
 
@@ -97,5 +98,10 @@ func main() {
 	approval_list.NewTestBehaviour(v2APIURL, auth0User1Config, auth0User2Config, auth0User3Config, auth0User4Config).RunAllTests()
 	cla_group.NewTestBehaviour(v2APIURL, auth0User5Config).RunAllTests()
 	repositories.NewTestBehaviour(v2APIURL, auth0User5Config).RunAllTests()
+
+	// The corporate signing tests need an API with the e-signature flow enabled and the fake e-signature provider
+	if os.Getenv("ESIGN_FAKE_PROVIDER") == "true" {
+		sign.NewTestBehaviour(v2APIURL, auth0User1Config, os.Getenv("ESIGN_PROJECT_SFID"), os.Getenv("ESIGN_COMPANY_SFID"), os.Getenv("ESIGN_WEBHOOK_SECRET")).RunAllTests()
+	}
 	frisby.Global.PrintReport()
 }
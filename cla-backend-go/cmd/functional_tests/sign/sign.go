@@ -0,0 +1,180 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/cmd/functional_tests/test_models"
+	"github.com/communitybridge/easycla/cla-backend-go/esign"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/verdverm/frisby"
+)
+
+var (
+	claManagerToken string
+)
+
+// TestBehaviour data model
+type TestBehaviour struct {
+	apiURL        string
+	auth0Config   test_models.Auth0Config
+	projectSFID   string
+	companySFID   string
+	webhookSecret string
+}
+
+// NewTestBehaviour creates a new test behavior model. The API must be a single standalone server with the
+// e-signature flow enabled and configured with the fake e-signature provider and auto sign enabled, the fake keeps
+// the envelopes in memory and signs them when the signature is completed. The provider callbacks are signed with
+// the webhook secret of the fake provider.
+func NewTestBehaviour(apiURL string, auth0Config test_models.Auth0Config, projectSFID, companySFID, webhookSecret string) *TestBehaviour {
+	return &TestBehaviour{
+		apiURL:        apiURL + "/v4",
+		auth0Config:   auth0Config,
+		projectSFID:   projectSFID,
+		companySFID:   companySFID,
+		webhookSecret: webhookSecret,
+	}
+}
+
+// buildCallbackHeaders returns the headers of the provider callback of the signature, signed with the webhook secret
+func (t *TestBehaviour) buildCallbackHeaders(signatureID, webhookSecret string) map[string]string {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return map[string]string{
+		"X-ESIGN-TIMESTAMP": timestamp,
+		"X-ESIGN-SIGNATURE": esign.SignWebhook(webhookSecret, timestamp, []byte(signatureID)),
+	}
+}
+
+func (t *TestBehaviour) buildHeaders() map[string]string {
+	return map[string]string{
+		"Authorization":   "Bearer " + claManagerToken,
+		"Content-Type":    "application/json",
+		"Accept-Encoding": "application/json",
+		"X-EMAIL":         t.auth0Config.Auth0Email,
+		"X-USERNAME":      t.auth0Config.Auth0UserName,
+	}
+}
+
+// RunGetCLAManagerToken acquires the Auth0 token
+func (t *TestBehaviour) RunGetCLAManagerToken() {
+	authTokenReqPayload := map[string]string{
+		"grant_type": "http://auth0.com/oauth/grant-type/password-realm",
+		"realm":      "Username-Password-Authentication",
+		"username":   t.auth0Config.Auth0UserName,
+		"password":   t.auth0Config.Auth0Password,
+		"client_id":  t.auth0Config.Auth0ClientID,
+		"audience":   "https://api-gw.dev.platform.linuxfoundation.org/",
+		"scope":      "access:api openid profile email",
+	}
+	frisby.Create(fmt.Sprintf("Sign - Get Token - CLA Manager - %s", t.auth0Config.Auth0UserName)).
+		Post("https://linuxfoundation-dev.auth0.com/oauth/token").
+		SetJson(authTokenReqPayload).
+		Send().
+		ExpectStatus(200).
+		ExpectJsonType("id_token", reflect.String).
+		AfterText(func(F *frisby.Frisby, text string, err error) {
+			var auth0Response test_models.Auth0Response
+			unmarshallErr := json.Unmarshal([]byte(text), &auth0Response)
+			if unmarshallErr != nil {
+				F.AddError(unmarshallErr.Error())
+			}
+			if auth0Response.IDToken == "" {
+				F.AddError("Auth0Response id_token is empty")
+			}
+			claManagerToken = auth0Response.IDToken
+		})
+}
+
+// RunRequestCorporateSignature requests the CCLA signature for the current user and returns the pending signature ID
+func (t *TestBehaviour) RunRequestCorporateSignature() string {
+	var signatureID string
+	frisby.Create("Sign - Request Corporate Signature - Fake E-Signature Provider").
+		Post(t.apiURL+"/request-corporate-signature").
+		SetHeaders(t.buildHeaders()).
+		SetJson(map[string]interface{}{
+			"project_sfid":  t.projectSFID,
+			"company_sfid":  t.companySFID,
+			"send_as_email": false,
+			"return_url":    "https://corporate.dev.lfcla.com",
+		}).
+		Send().
+		ExpectStatus(200).
+		ExpectJsonType("signature_id", reflect.String).
+		ExpectJsonType("sign_url", reflect.String).
+		AfterText(func(F *frisby.Frisby, text string, err error) {
+			var output models.CorporateSignatureOutput
+			unmarshallErr := json.Unmarshal([]byte(text), &output)
+			if unmarshallErr != nil {
+				F.AddError(unmarshallErr.Error())
+			}
+			signatureID = output.SignatureID
+		})
+
+	return signatureID
+}
+
+// RunCompleteCorporateSignature completes the pending signature, repeated provider callbacks succeed
+func (t *TestBehaviour) RunCompleteCorporateSignature(signatureID string) {
+	for _, attempt := range []string{"First Callback", "Repeated Callback"} {
+		frisby.Create(fmt.Sprintf("Sign - Complete Corporate Signature - %s - %s", attempt, signatureID)).
+			Post(fmt.Sprintf("%s/signed/corporate/%s", t.apiURL, signatureID)).
+			SetHeaders(t.buildCallbackHeaders(signatureID, t.webhookSecret)).
+			Send().
+			ExpectStatus(200).
+			ExpectJson("signature_id", signatureID)
+	}
+}
+
+// RunRequestCorporateSignatureAlreadySigned checks the company can not request a second CCLA signature
+func (t *TestBehaviour) RunRequestCorporateSignatureAlreadySigned() {
+	frisby.Create("Sign - Request Corporate Signature - Already Signed").
+		Post(t.apiURL + "/request-corporate-signature").
+		SetHeaders(t.buildHeaders()).
+		SetJson(map[string]interface{}{
+			"project_sfid":  t.projectSFID,
+			"company_sfid":  t.companySFID,
+			"send_as_email": false,
+			"return_url":    "https://corporate.dev.lfcla.com",
+		}).
+		Send().
+		ExpectStatus(400)
+}
+
+// RunCompleteCorporateSignatureNotFound checks unknown signatures are rejected
+func (t *TestBehaviour) RunCompleteCorporateSignatureNotFound() {
+	signatureID := "00000000-0000-0000-0000-000000000000"
+	frisby.Create("Sign - Complete Corporate Signature - Not Found").
+		Post(t.apiURL + "/signed/corporate/" + signatureID).
+		SetHeaders(t.buildCallbackHeaders(signatureID, t.webhookSecret)).
+		Send().
+		ExpectStatus(404)
+}
+
+// RunCompleteCorporateSignatureUnsigned checks the callbacks which are not signed by the provider are rejected
+func (t *TestBehaviour) RunCompleteCorporateSignatureUnsigned(signatureID string) {
+	frisby.Create("Sign - Complete Corporate Signature - Unsigned Callback").
+		Post(fmt.Sprintf("%s/signed/corporate/%s", t.apiURL, signatureID)).
+		SetHeaders(t.buildCallbackHeaders(signatureID, "not-the-webhook-secret")).
+		Send().
+		ExpectStatus(401)
+}
+
+// RunAllTests runs all the corporate signing tests, from the request to the signed CCLA
+func (t *TestBehaviour) RunAllTests() {
+	t.RunGetCLAManagerToken()
+	t.RunCompleteCorporateSignatureNotFound()
+	signatureID := t.RunRequestCorporateSignature()
+	if signatureID == "" {
+		return
+	}
+	t.RunCompleteCorporateSignatureUnsigned(signatureID)
+	t.RunCompleteCorporateSignature(signatureID)
+	t.RunRequestCorporateSignatureAlreadySigned()
+}
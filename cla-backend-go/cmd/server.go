@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
 	v2GithubActivity "github.com/communitybridge/easycla/cla-backend-go/v2/github_activity"
+	v2GitlabActivity "github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_activity"
 
 	"github.com/gofrs/uuid"
 
@@ -36,6 +38,7 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	v2GithubOrganizations "github.com/communitybridge/easycla/cla-backend-go/v2/github_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/metrics"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
@@ -54,6 +57,7 @@ import (
 	v2Version "github.com/communitybridge/easycla/cla-backend-go/v2/version"
 	"github.com/communitybridge/easycla/cla-backend-go/version"
 
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 
 	"github.com/communitybridge/easycla/cla-backend-go/project"
@@ -62,21 +66,27 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/membership"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/scim"
 	v2Signatures "github.com/communitybridge/easycla/cla-backend-go/v2/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/webhooks"
 
 	ini "github.com/communitybridge/easycla/cla-backend-go/init"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/communitybridge/easycla/cla-backend-go/auth"
 	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
-	"github.com/communitybridge/easycla/cla-backend-go/docraptor"
+	"github.com/communitybridge/easycla/cla-backend-go/esign"
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations"
 	v2RestAPI "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi"
 	v2Ops "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/github"
+	"github.com/communitybridge/easycla/cla-backend-go/gitlab"
 	"github.com/communitybridge/easycla/cla-backend-go/health"
+	"github.com/communitybridge/easycla/cla-backend-go/renderer"
 	"github.com/communitybridge/easycla/cla-backend-go/template"
 	"github.com/communitybridge/easycla/cla-backend-go/user"
 	v2ClaManager "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
@@ -210,9 +220,14 @@ func server(localMode bool) http.Handler {
 	api := operations.NewClaAPI(swaggerSpec)
 	v2API := v2Ops.NewEasyclaAPI(v2SwaggerSpec)
 
-	docraptorClient, err := docraptor.NewDocraptorClient(configFile.Docraptor.APIKey, configFile.Docraptor.TestMode)
+	pdfRenderer, err := renderer.NewPDFRenderer(configFile)
 	if err != nil {
-		log.WithFields(f).WithError(err).Panic("unable to setup docraptor client")
+		log.WithFields(f).WithError(err).Panic("unable to setup pdf renderer")
+	}
+
+	esignProvider, err := esign.NewProvider(configFile)
+	if err != nil {
+		log.WithFields(f).WithError(err).Panic("unable to setup e-signature provider")
 	}
 
 	authValidator, err := auth.NewAuthValidator(
@@ -224,6 +239,8 @@ func server(localMode bool) http.Handler {
 		logrus.Panic(err)
 	}
 	github.Init(configFile.GitHub.AppID, configFile.GitHub.AppPrivateKey, configFile.GitHub.AccessToken)
+	github.SetAPIBaseURL(configFile.GitHub.APIBaseURL)
+	gitlab.Init(configFile.GitLab.APIBaseURL, configFile.GitLab.AccessToken, configFile.GitLab.WebhookSecret)
 
 	// Our backend repository handlers
 	userRepo := user.NewDynamoRepository(awsSession, stage)
@@ -239,6 +256,8 @@ func server(localMode bool) http.Handler {
 	eventsRepo := events.NewRepository(awsSession, stage)
 	metricsRepo := metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, projectClaGroupRepo)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
+	gitlabOrganizationsRepo := gitlab_organizations.NewRepository(awsSession, stage)
+	webhooksRepo := webhooks.NewRepository(awsSession, stage)
 	claManagerReqRepo := cla_manager.NewRepository(awsSession, stage)
 
 	// Our service layer handlers
@@ -258,38 +277,58 @@ func server(localMode bool) http.Handler {
 
 	usersService := users.NewService(usersRepo, eventsService)
 	healthService := health.New(Version, Commit, Branch, BuildDate)
-	templateService := template.NewService(stage, templateRepo, docraptorClient, awsSession)
+	templateService := template.NewService(stage, templateRepo, pdfRenderer, awsSession)
 	v1ProjectService := project.NewService(projectRepo, repositoriesRepo, gerritRepo, projectClaGroupRepo, usersRepo)
 	v2ProjectService := v2Project.NewService(v1ProjectService, projectRepo, projectClaGroupRepo)
 	v1CompanyService := v1Company.NewService(v1CompanyRepo, configFile.CorporateConsoleURL, userRepo, usersService)
 	v2CompanyService := v2Company.NewService(v1CompanyService, signaturesRepo, projectRepo, usersRepo, v1CompanyRepo, projectClaGroupRepo, eventsService)
-	v2SignService := sign.NewService(configFile.ClaV1ApiURL, v1CompanyRepo, projectRepo, projectClaGroupRepo, v1CompanyService)
+	v2SignService := sign.NewService(configFile.ClaV1ApiURL, v1CompanyRepo, projectRepo, projectClaGroupRepo, v1CompanyService, signaturesRepo, usersService, esignProvider)
 	v1SignaturesService := signatures.NewService(signaturesRepo, v1CompanyService, usersService, eventsService, githubOrgValidation)
 	v2SignatureService := v2Signatures.NewService(awsSession, configFile.SignatureFilesBucket, v1ProjectService, v1CompanyService, v1SignaturesService, projectClaGroupRepo)
+	scimService := scim.NewService(v1CompanyRepo, projectRepo, v1SignaturesService)
+	membershipService := membership.NewService(projectRepo, usersService, v1SignaturesService, configFile.ContributorConsoleV2URL)
+	webhooksService := webhooks.NewService(webhooksRepo, configFile.CorporateConsoleV2URL)
 	v1ClaManagerService := cla_manager.NewService(claManagerReqRepo, projectClaGroupRepo, v1CompanyService, v1ProjectService, usersService, v1SignaturesService, eventsService, configFile.CorporateConsoleURL)
 	v1RepositoriesService := repositories.NewService(repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo)
-	v2RepositoriesService := v2Repositories.NewService(repositoriesRepo, projectClaGroupRepo, githubOrganizationsRepo)
+	githubInstallationIDLookup := func(ctx context.Context, organizationName string) (int64, error) {
+		githubOrg, err := githubOrganizationsRepo.GetGithubOrganization(ctx, organizationName)
+		if err != nil {
+			return 0, err
+		}
+		return githubOrg.OrganizationInstallationID, nil
+	}
+	// the team approval lists resolve the team memberships with the GitHub App installation of the organization
+	github.SetInstallationIDLookup(githubInstallationIDLookup)
+	forgeRegistry := forge.NewRegistry()
+	forgeRegistry.Register(utils.GitHubType, github.NewProviderFactory(githubInstallationIDLookup))
+	v2RepositoriesService := v2Repositories.NewService(repositoriesRepo, projectClaGroupRepo, githubOrganizationsRepo, forgeRegistry)
 	v2ClaManagerService := v2ClaManager.NewService(v1CompanyService, v1ProjectService, v1ClaManagerService, usersService, v1RepositoriesService, v2CompanyService, eventsService, projectClaGroupRepo)
-	v1ApprovalListService := approval_list.NewService(approvalListRepo, projectClaGroupRepo, v1ProjectService, usersRepo, v1CompanyRepo, projectRepo, signaturesRepo, configFile.CorporateConsoleV2URL, http.DefaultClient)
+	v1ApprovalListService := approval_list.NewService(approvalListRepo, projectClaGroupRepo, v1ProjectService, usersRepo, v1CompanyRepo, projectRepo, signaturesRepo, v1SignaturesService, eventsService, configFile.CorporateConsoleV2URL, http.DefaultClient)
 	authorizer := auth.NewAuthorizer(authValidator, userRepo)
 	v2MetricsService := metrics.NewService(metricsRepo, projectClaGroupRepo)
 	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, repositoriesRepo, projectClaGroupRepo)
 	v2GithubOrganizationsService := v2GithubOrganizations.NewService(githubOrganizationsRepo, repositoriesRepo, projectClaGroupRepo)
-	autoEnableService := dynamo_events.NewAutoEnableService(v1RepositoriesService, repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo, v1ProjectService)
-	v2GithubActivityService := v2GithubActivity.NewService(repositoriesRepo, eventsService, autoEnableService)
+	autoEnableService := dynamo_events.NewAutoEnableService(v1RepositoriesService, repositoriesRepo, githubOrganizationsRepo, projectClaGroupRepo, v1ProjectService, usersRepo)
+	gitlabOrganizationsService := gitlab_organizations.NewService(gitlabOrganizationsRepo, projectClaGroupRepo)
+	v2GitlabActivityService := v2GitlabActivity.NewService(gitlabOrganizationsRepo, usersRepo, v1SignaturesService, configFile.ContributorConsoleV2URL, configFile.CLALandingPage)
+	v2GithubActivityService := v2GithubActivity.NewService(repositoriesRepo, eventsService, autoEnableService, projectRepo, githubOrganizationsRepo, usersRepo, v1SignaturesService, configFile.ClaV1ApiURL, configFile.CLALandingPage)
 	gerritService := gerrits.NewService(gerritRepo, &gerrits.LFGroup{
 		LfBaseURL:    configFile.LFGroup.ClientURL,
 		ClientID:     configFile.LFGroup.ClientID,
 		ClientSecret: configFile.LFGroup.ClientSecret,
 		RefreshToken: configFile.LFGroup.RefreshToken,
 	})
+	gerritEnforcementService := gerrits.NewEnforcementService(gerritRepo, projectRepo, usersRepo, v1SignaturesService, configFile.ClaV1ApiURL)
 	v2ClaGroupService := cla_groups.NewService(v1ProjectService, templateService, projectClaGroupRepo, v1ClaManagerService, v1SignaturesService, metricsRepo, gerritService, v1RepositoriesService, eventsService)
 
 	sessionStore, err := dynastore.New(dynastore.Path("/"), dynastore.HTTPOnly(), dynastore.TableName(configFile.SessionStoreTableName), dynastore.DynamoDB(dynamodb.New(awsSession)))
 	if err != nil {
 		log.WithFields(f).WithError(err).Panic("unable to create new Dynastore session")
 	}
-	utils.SetSnsEmailSender(awsSession, configFile.SNSEventTopicARN, configFile.SenderEmailAddress)
+	if err = utils.SetEmailSenderFromConfig(awsSession, configFile); err != nil {
+		log.WithFields(f).WithError(err).Panic("unable to setup email sender")
+	}
+	emails.SetTemplateRegistry(emails.NewTemplateRegistry(emails.NewTemplateStore(awsSession, configFile), emails.DefaultTemplateCacheTTL))
 	utils.SetS3Storage(awsSession, configFile.SignatureFilesBucket)
 
 	// Setup security handlers
@@ -303,10 +342,10 @@ func server(localMode bool) http.Handler {
 	health.Configure(api, healthService)
 	v2Health.Configure(v2API, healthService)
 	template.Configure(api, templateService, eventsService)
-	v2Template.Configure(v2API, templateService, eventsService)
+	v2Template.Configure(v2API, templateService, eventsService, projectClaGroupRepo)
 	github.Configure(api, configFile.GitHub.ClientID, configFile.GitHub.ClientSecret, configFile.GitHub.AccessToken, sessionStore)
 	signatures.Configure(api, v1SignaturesService, sessionStore, eventsService)
-	v2Signatures.Configure(v2API, v1ProjectService, projectRepo, v1CompanyService, v1SignaturesService, sessionStore, eventsService, v2SignatureService, projectClaGroupRepo)
+	v2Signatures.Configure(v2API, v1ProjectService, projectRepo, v1CompanyService, v1SignaturesService, sessionStore, eventsService, v2SignatureService, projectClaGroupRepo, v2GithubActivityService)
 	approval_list.Configure(api, v1ApprovalListService, sessionStore, v1SignaturesService, eventsService)
 	v1Company.Configure(api, v1CompanyService, usersService, companyUserValidation, eventsService)
 	docs.Configure(api)
@@ -318,16 +357,22 @@ func server(localMode bool) http.Handler {
 	v2Metrics.Configure(v2API, v2MetricsService, v1CompanyRepo)
 	github_organizations.Configure(api, githubOrganizationsService, eventsService)
 	v2GithubOrganizations.Configure(v2API, v2GithubOrganizationsService, eventsService)
+	gitlab_organizations.Configure(v2API, gitlabOrganizationsService, eventsService)
 	repositories.Configure(api, v1RepositoriesService, eventsService)
 	v2Repositories.Configure(v2API, v2RepositoriesService, eventsService)
 	gerrits.Configure(api, gerritService, v1ProjectService, eventsService)
-	v2Gerrits.Configure(v2API, gerritService, v1ProjectService, eventsService, projectClaGroupRepo)
+	v2Gerrits.Configure(v2API, gerritService, gerritEnforcementService, v1ProjectService, eventsService, projectClaGroupRepo)
 	v2Company.Configure(v2API, v2CompanyService, projectClaGroupRepo, configFile.LFXPortalURL, configFile.CorporateConsoleURL)
 	cla_manager.Configure(api, v1ClaManagerService, v1CompanyService, v1ProjectService, usersService, v1SignaturesService, eventsService, configFile.CorporateConsoleURL)
 	v2ClaManager.Configure(v2API, v2ClaManagerService, v1CompanyService, configFile.LFXPortalURL, configFile.CorporateConsoleV2URL, projectClaGroupRepo, userRepo)
-	sign.Configure(v2API, v2SignService)
-	cla_groups.Configure(v2API, v2ClaGroupService, v1ProjectService, projectClaGroupRepo, eventsService)
+	sign.Configure(v2API, v2SignService, eventsService)
+	scim.Configure(v2API, scimService, v1CompanyService, eventsService)
+	cla_groups.Configure(v2API, v2ClaGroupService, v1ProjectService, projectClaGroupRepo, eventsService, membershipService)
+	membership.Configure(v2API, membershipService)
+	webhooks.Configure(v2API, webhooksService, v1CompanyService, eventsService)
+	webhooks.ConfigureChatChannels(v2API, webhooksService, v1ProjectService, v1CompanyService, eventsService)
 	v2GithubActivity.Configure(v2API, v2GithubActivityService)
+	v2GitlabActivity.Configure(v2API, v2GitlabActivityService)
 
 	userCreaterMiddleware := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -339,7 +384,7 @@ func server(localMode bool) http.Handler {
 	// The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
 	// The middleware executes after routing but before authentication, binding and validation
 	middlewareSetupfunc := func(handler http.Handler) http.Handler {
-		return setRequestIDHandler(responseLoggingMiddleware(userCreaterMiddleware(handler)))
+		return setRequestIDHandler(responseLoggingMiddleware(userCreaterMiddleware(scim.ContentTypeMiddleware(handler))))
 	}
 
 	v2API.CsvProducer = openapi_runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
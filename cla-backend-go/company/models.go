@@ -27,6 +27,13 @@ type DBModel struct {
 	Version           string   `dynamodbav:"version" json:"version"`
 }
 
+// ApprovalListRequestRule data model of the CCLA approval request auto-approval rules of the company
+type ApprovalListRequestRule struct {
+	RuleType   string   `dynamodbav:"rule_type" json:"rule_type"`
+	ClaGroupID string   `dynamodbav:"cla_group_id,omitempty" json:"cla_group_id"`
+	Domains    []string `dynamodbav:"domains,omitempty" json:"domains"`
+}
+
 // Invite data model
 type Invite struct {
 	CompanyInviteID    string `dynamodbav:"company_invite_id" json:"company_invite_id"`
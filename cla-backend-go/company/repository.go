@@ -52,6 +52,10 @@ type IRepository interface { //nolint
 	updateInviteRequestStatus(ctx context.Context, companyInviteID, status string) error
 
 	UpdateCompanyAccessList(ctx context.Context, companyID string, companyACL []string) error
+	UpdateCompanySCIMTokenHash(ctx context.Context, companyID, tokenHash string) error
+	GetCompanySCIMTokenHash(ctx context.Context, companyID string) (string, error)
+	UpdateCompanyApprovalListRequestRules(ctx context.Context, companyID string, rules []*models.CclaApprovalListRequestRule) error
+	GetCompanyApprovalListRequestRules(ctx context.Context, companyID string) ([]*models.CclaApprovalListRequestRule, error)
 }
 
 type repository struct {
@@ -1214,6 +1218,179 @@ func (repo repository) UpdateCompanyAccessList(ctx context.Context, companyID st
 	return nil
 }
 
+// UpdateCompanySCIMTokenHash sets the hash of the token authenticating the directory sync (SCIM) requests of the
+// company, an empty hash revokes the token
+func (repo repository) UpdateCompanySCIMTokenHash(ctx context.Context, companyID, tokenHash string) error {
+	f := logrus.Fields{
+		"functionName":   "company.repository.UpdateCompanySCIMTokenHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+	}
+	_, now := utils.CurrentTime()
+
+	input := &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#ID": aws.String("company_id"),
+			"#T":  aws.String("scim_token_hash"),
+			"#M":  aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		ConditionExpression: aws.String("attribute_exists(#ID)"),
+		UpdateExpression:    aws.String("SET #M = :m REMOVE #T"),
+	}
+	if tokenHash != "" {
+		input.ExpressionAttributeValues[":t"] = &dynamodb.AttributeValue{S: aws.String(tokenHash)}
+		input.UpdateExpression = aws.String("SET #T = :t, #M = :m")
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.WithFields(f).Warnf("error updating the company SCIM token, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetCompanySCIMTokenHash returns the hash of the token authenticating the directory sync (SCIM) requests of the
+// company, empty if no token was created
+func (repo repository) GetCompanySCIMTokenHash(ctx context.Context, companyID string) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "company.repository.GetCompanySCIMTokenHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+	}
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		ProjectionExpression: aws.String("scim_token_hash"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("error fetching the company SCIM token, error: %v", err)
+		return "", err
+	}
+
+	if value, ok := result.Item["scim_token_hash"]; ok && value.S != nil {
+		return *value.S, nil
+	}
+	return "", nil
+}
+
+// UpdateCompanyApprovalListRequestRules replaces the auto-approval rules of the CCLA approval requests of the
+// company, an empty list removes the rules
+func (repo repository) UpdateCompanyApprovalListRequestRules(ctx context.Context, companyID string, rules []*models.CclaApprovalListRequestRule) error {
+	f := logrus.Fields{
+		"functionName":   "company.repository.UpdateCompanyApprovalListRequestRules",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+	}
+	_, now := utils.CurrentTime()
+
+	input := &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#ID": aws.String("company_id"),
+			"#R":  aws.String("approval_list_request_rules"),
+			"#M":  aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		ConditionExpression: aws.String("attribute_exists(#ID)"),
+		UpdateExpression:    aws.String("SET #M = :m REMOVE #R"),
+	}
+	if len(rules) > 0 {
+		var dbRules []ApprovalListRequestRule
+		for _, rule := range rules {
+			dbRules = append(dbRules, ApprovalListRequestRule{
+				RuleType:   utils.StringValue(rule.RuleType),
+				ClaGroupID: rule.ClaGroupID,
+				Domains:    rule.Domains,
+			})
+		}
+		rulesAttribute, err := dynamodbattribute.MarshalList(dbRules)
+		if err != nil {
+			log.WithFields(f).Warnf("error marshalling the approval list request rules, error: %v", err)
+			return err
+		}
+		input.ExpressionAttributeValues[":r"] = &dynamodb.AttributeValue{L: rulesAttribute}
+		input.UpdateExpression = aws.String("SET #R = :r, #M = :m")
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.WithFields(f).Warnf("error updating the company approval list request rules, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetCompanyApprovalListRequestRules returns the auto-approval rules of the CCLA approval requests of the company
+func (repo repository) GetCompanyApprovalListRequestRules(ctx context.Context, companyID string) ([]*models.CclaApprovalListRequestRule, error) {
+	f := logrus.Fields{
+		"functionName":   "company.repository.GetCompanyApprovalListRequestRules",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+	}
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		ProjectionExpression: aws.String("approval_list_request_rules"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("error fetching the company approval list request rules, error: %v", err)
+		return nil, err
+	}
+
+	rules := []*models.CclaApprovalListRequestRule{}
+	value, ok := result.Item["approval_list_request_rules"]
+	if !ok || value.L == nil {
+		return rules, nil
+	}
+
+	var dbRules []ApprovalListRequestRule
+	err = dynamodbattribute.UnmarshalList(value.L, &dbRules)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling the company approval list request rules, error: %v", err)
+		return nil, err
+	}
+	for _, dbRule := range dbRules {
+		rules = append(rules, &models.CclaApprovalListRequestRule{
+			RuleType:   aws.String(dbRule.RuleType),
+			ClaGroupID: dbRule.ClaGroupID,
+			Domains:    dbRule.Domains,
+		})
+	}
+
+	return rules, nil
+}
+
 // CreateCompany creates a new company record
 func (repo repository) CreateCompany(ctx context.Context, in *models.Company) (*models.Company, error) {
 	f := logrus.Fields{
@@ -432,6 +432,9 @@ func (s service) AddUserToCompanyAccessList(ctx context.Context, companyID, lfid
 	return nil
 }
 
+// CompanyManagerAccessRequestTemplateName is the name of the email notifying the company managers of an access request
+const CompanyManagerAccessRequestTemplateName = "CompanyManagerAccessRequestTemplate"
+
 // sendRequestAccessEmail sends the request access email
 func (s service) sendRequestAccessEmail(ctx context.Context, companyModel *models.Company, requesterName, requesterEmail, recipientName, recipientAddress string) {
 	f := logrus.Fields{
@@ -464,7 +467,7 @@ company.You can choose to accept or deny the request.
 		recipientName, companyName, companyName, requestedUserInfo, utils.GetCorporateURL(false),
 		utils.GetEmailHelpContent(false), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: CompanyManagerAccessRequestTemplateName, RecipientRole: utils.EmailRecipientRoleCompanyManager})
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -472,6 +475,9 @@ company.You can choose to accept or deny the request.
 	}
 }
 
+// CompanyManagerAccessApprovedTemplateName is the name of the email notifying the requester of the approval of their access request
+const CompanyManagerAccessApprovedTemplateName = "CompanyManagerAccessApprovedTemplate"
+
 // sendRequestApprovedEmailToRecipient generates and sends an email to the specified recipient
 func (s service) sendRequestApprovedEmailToRecipient(ctx context.Context, companyModel *models.Company, recipientName, recipientAddress string) {
 	f := logrus.Fields{
@@ -501,7 +507,7 @@ Manager status.
 		recipientName, companyName, companyName, utils.GetCorporateURL(false),
 		utils.GetEmailHelpContent(false), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: CompanyManagerAccessApprovedTemplateName, RecipientRole: utils.EmailRecipientRoleRequester})
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -509,6 +515,9 @@ Manager status.
 	}
 }
 
+// CompanyManagerAccessDeniedTemplateName is the name of the email notifying the requester of the denial of their access request
+const CompanyManagerAccessDeniedTemplateName = "CompanyManagerAccessDeniedTemplate"
+
 // sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient
 func (s service) sendRequestRejectedEmailToRecipient(ctx context.Context, companyModel *models.Company, recipientName, recipientAddress string) {
 	f := logrus.Fields{
@@ -567,7 +576,7 @@ If you have further questions about this denial, please contact one of the exist
 		recipientName, companyName, companyName, companyManagerText,
 		utils.GetEmailHelpContent(false), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: CompanyManagerAccessDeniedTemplateName, RecipientRole: utils.EmailRecipientRoleRequester})
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -30,9 +30,21 @@ type Config struct {
 
 	// Docusign
 
+	// ESign selects the e-signature provider of the corporate signatures, the v1 API when not enabled
+	ESign ESign `json:"esign"`
+
 	// Docraptor
 	Docraptor Docraptor `json:"docraptor"`
 
+	// PDFRenderer selects the backend rendering the CLA templates, DocRaptor when not set
+	PDFRenderer PDFRenderer `json:"pdf_renderer"`
+
+	// EmailTransport selects the transport of the notification emails, SNS when not set
+	EmailTransport EmailTransport `json:"email_transport"`
+
+	// EmailTemplates locates the foundation and language overrides of the email templates
+	EmailTemplates EmailTemplates `json:"email_templates"`
+
 	// LF Identity
 
 	// AWS
@@ -41,6 +53,9 @@ type Config struct {
 	// GitHub Application
 	GitHub GitHub `json:"github"`
 
+	// GitLab instance
+	GitLab GitLab `json:"gitlab"`
+
 	// Dynamo Session Store
 	SessionStoreTableName string `json:"sessionStoreTableName"`
 
@@ -53,6 +68,9 @@ type Config struct {
 	CorporateConsoleURL   string `json:"corporateConsoleURL"`
 	CorporateConsoleV2URL string `json:"corporateConsoleV2URL"`
 
+	// ContributorConsoleV2URL is the host of the v2 contributor console, linked from the membership checks
+	ContributorConsoleV2URL string `json:"contributorConsoleV2URL"`
+
 	// SNSEventTopic the topic ARN for events
 	SNSEventTopicARN string `json:"snsEventTopicARN"`
 
@@ -71,6 +89,9 @@ type Config struct {
 	// LFXPortalURL is url of the LFX UI for the particular environment
 	LFXPortalURL string `json:"lfx_portal_url"`
 
+	// CLALandingPage is the EasyCLA landing page linked from the passing pull request checks
+	CLALandingPage string `json:"cla_landing_page"`
+
 	// MetricsReport has the transport config to send the metrics data
 	MetricsReport MetricsReport `json:"metrics_report"`
 }
@@ -97,6 +118,51 @@ type Docraptor struct {
 	TestMode bool   `json:"testMode"`
 }
 
+// PDFRenderer model
+type PDFRenderer struct {
+	// Type is one of docraptor, wkhtmltopdf or chromium
+	Type string `json:"type"`
+	// BinaryPath of the local renderer, looked up in the PATH when empty
+	BinaryPath string `json:"binary_path"`
+}
+
+// EmailTransport model
+type EmailTransport struct {
+	// Type is one of sns, smtp or file
+	Type string `json:"type"`
+	// SMTP server of the smtp transport
+	SMTP SMTP `json:"smtp"`
+	// Directory the file transport writes the .eml files to
+	Directory string `json:"directory"`
+}
+
+// EmailTemplates model
+type EmailTemplates struct {
+	// Directory of the overrides, the overrides are loaded from the signature files bucket when not set
+	Directory string `json:"directory"`
+}
+
+// SMTP model, the connection is upgraded with STARTTLS when the server supports it
+type SMTP struct {
+	Host string `json:"host"`
+	// Port defaults to 25
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ESign model
+type ESign struct {
+	// Enabled turns on the e-signature flow of the corporate signatures, the v1 API is used when it is off
+	Enabled bool `json:"enabled"`
+	// Type is empty to keep using the v1 API, or fake
+	Type string `json:"type"`
+	// WebhookSecret is the secret the provider signs its callbacks with
+	WebhookSecret string `json:"webhook_secret"`
+	// AutoSign completes the fake envelopes the first time they are checked
+	AutoSign bool `json:"auto_sign"`
+}
+
 // LFGroup contains LF LDAP group access information
 type LFGroup struct {
 	ClientURL    string `json:"client_url"`
@@ -117,12 +183,20 @@ type GitHub struct {
 	AccessToken                    string `json:"accessToken"`
 	AppID                          int    `json:"app_id"`
 	AppPrivateKey                  string `json:"app_private_key"`
+	APIBaseURL                     string `json:"api_base_url"`
 	TestOrganization               string `json:"test_organization"`
 	TestOrganizationInstallationID string `json:"test_organization_installation_id"`
 	TestRepository                 string `json:"test_repository"`
 	TestRepositoryID               string `json:"test_repository_id"`
 }
 
+// GitLab model
+type GitLab struct {
+	APIBaseURL    string `json:"api_base_url"`
+	AccessToken   string `json:"access_token"`
+	WebhookSecret string `json:"webhook_secret"`
+}
+
 // MetricsReport keeps the config needed to send the metrics data report
 type MetricsReport struct {
 	AwsSQSRegion   string `json:"aws_sqs_region"`
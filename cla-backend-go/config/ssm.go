@@ -69,8 +69,12 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		fmt.Sprintf("cla-gh-test-organization-installation-id-%s", stage),
 		fmt.Sprintf("cla-gh-test-repository-%s", stage),
 		fmt.Sprintf("cla-gh-test-repository-id-%s", stage),
+		fmt.Sprintf("cla-gitlab-api-url-%s", stage),
+		fmt.Sprintf("cla-gitlab-access-token-%s", stage),
+		fmt.Sprintf("cla-gitlab-webhook-secret-%s", stage),
 		fmt.Sprintf("cla-corporate-base-%s", stage),
 		fmt.Sprintf("cla-corporate-v2-base-%s", stage),
+		fmt.Sprintf("cla-contributor-v2-base-%s", stage),
 		fmt.Sprintf("cla-doc-raptor-api-key-%s", stage),
 		fmt.Sprintf("cla-session-store-table-%s", stage),
 		fmt.Sprintf("cla-ses-sender-email-address-%s", stage),
@@ -89,6 +93,7 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		fmt.Sprintf("cla-v1-api-url-%s", stage),
 		fmt.Sprintf("cla-acs-api-key-%s", stage),
 		fmt.Sprintf("cla-lfx-portal-url-%s", stage),
+		fmt.Sprintf("cla-landing-page-%s", stage),
 		fmt.Sprintf("cla-lfx-metrics-report-sqs-region-%s", stage),
 		fmt.Sprintf("cla-lfx-metrics-report-sqs-url-%s", stage),
 		fmt.Sprintf("cla-lfx-metrics-report-enabled-%s", stage),
@@ -144,6 +149,12 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.GitHub.TestRepository = resp.value
 		case fmt.Sprintf("cla-gh-test-repository-id-%s", stage):
 			config.GitHub.TestRepositoryID = resp.value
+		case fmt.Sprintf("cla-gitlab-api-url-%s", stage):
+			config.GitLab.APIBaseURL = resp.value
+		case fmt.Sprintf("cla-gitlab-access-token-%s", stage):
+			config.GitLab.AccessToken = resp.value
+		case fmt.Sprintf("cla-gitlab-webhook-secret-%s", stage):
+			config.GitLab.WebhookSecret = resp.value
 
 		case fmt.Sprintf("cla-corporate-base-%s", stage):
 			corporateConsoleURLValue := resp.value
@@ -153,6 +164,8 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.CorporateConsoleURL = corporateConsoleURLValue
 		case fmt.Sprintf("cla-corporate-v2-base-%s", stage):
 			config.CorporateConsoleV2URL = resp.value
+		case fmt.Sprintf("cla-contributor-v2-base-%s", stage):
+			config.ContributorConsoleV2URL = resp.value
 		case fmt.Sprintf("cla-doc-raptor-api-key-%s", stage):
 			config.Docraptor.APIKey = resp.value
 			// Docraptor adds a watermark for generated PDFs that have the test mode flag set to true
@@ -196,6 +209,8 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.AcsAPIKey = resp.value
 		case fmt.Sprintf("cla-lfx-portal-url-%s", stage):
 			config.LFXPortalURL = resp.value
+		case fmt.Sprintf("cla-landing-page-%s", stage):
+			config.CLALandingPage = resp.value
 		case fmt.Sprintf("cla-lfx-metrics-report-sqs-region-%s", stage):
 			config.MetricsReport.AwsSQSRegion = resp.value
 		case fmt.Sprintf("cla-lfx-metrics-report-sqs-url-%s", stage):
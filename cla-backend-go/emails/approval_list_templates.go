@@ -6,7 +6,6 @@ package emails
 import (
 	"strings"
 
-	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 )
@@ -34,6 +33,81 @@ If you have further questions about this denial, please contact one of the exist
 `
 )
 
+// ApprovalListUpdateToContributorTemplateParams is email params for ApprovalListUpdateToContributorTemplate
+type ApprovalListUpdateToContributorTemplateParams struct {
+	CLAManagerTemplateParams
+	UpdatedBy        string
+	AddRemove        string
+	ToFrom           string
+	AuthorizedString string
+}
+
+const (
+	// ApprovalListUpdateToContributorTemplateName is email template name for ApprovalListUpdateToContributorTemplate
+	ApprovalListUpdateToContributorTemplateName = "ApprovalListUpdateToContributorTemplate"
+	// ApprovalListUpdateToContributorTemplate is email template for the contributors added to or removed from the approval list
+	ApprovalListUpdateToContributorTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.Project.ExternalProjectName}}.</p>
+<p>You have been {{.AddRemove}} {{.ToFrom}} the Approval List of {{.CompanyName}} for {{.Project.ExternalProjectName}} by CLA Manager {{.UpdatedBy}}. This means that {{.AuthorizedString}} on behalf of {{.Project.ExternalProjectName}}.</p>
+<p>If you had previously submitted a pull request to EasyCLA Test Group that had failed, 
+you can now go back to it and follow the link to verify with your organization.</p>
+`
+)
+
+// EmployeeSignatureRevokedTemplateParams is email params for EmployeeSignatureRevokedTemplate
+type EmployeeSignatureRevokedTemplateParams struct {
+	CLAManagerTemplateParams
+	EffectiveDate string
+	Reason        string
+}
+
+const (
+	// EmployeeSignatureRevokedTemplateName is email template name for EmployeeSignatureRevokedTemplate
+	EmployeeSignatureRevokedTemplateName = "EmployeeSignatureRevokedTemplate"
+	// EmployeeSignatureRevokedTemplate is email template for the contributors whose employee acknowledgement was revoked
+	EmployeeSignatureRevokedTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.Project.ExternalProjectName}}.</p>
+<p>A CLA Manager of {{.CompanyName}} revoked your acknowledgement of the Corporate CLA of {{.Project.ExternalProjectName}}, effective {{.EffectiveDate}}. Reason: {{.Reason}}</p>
+<p>Your contributions to {{.Project.ExternalProjectName}} are no longer covered by the Corporate CLA of {{.CompanyName}}. To keep contributing, sign the
+Individual CLA or the acknowledgement of your new employer - the EasyCLA check on your next pull request will guide
+you through it.</p>
+`
+)
+
+// ApprovalListExpiryReminderEntryParams is an approval list entry listed in ApprovalListExpiryReminderTemplate
+type ApprovalListExpiryReminderEntryParams struct {
+	Type      string
+	Value     string
+	ExpiresOn string
+}
+
+// ApprovalListExpiryReminderTemplateParams is email params for ApprovalListExpiryReminderTemplate
+type ApprovalListExpiryReminderTemplateParams struct {
+	CLAManagerTemplateParams
+	Entries             []ApprovalListExpiryReminderEntryParams
+	CorporateConsoleURL string
+}
+
+const (
+	// ApprovalListExpiryReminderTemplateName is email template name for ApprovalListExpiryReminderTemplate
+	ApprovalListExpiryReminderTemplateName = "ApprovalListExpiryReminderTemplate"
+	// ApprovalListExpiryReminderTemplate is email template for the CLA Managers of the approval list entries about to expire
+	ApprovalListExpiryReminderTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.Project.ExternalProjectName}}.</p>
+<p>The following entries of the EasyCLA approval list for {{.CompanyName}} for project {{.Project.ExternalProjectName}} expire within a week:</p>
+<ul>
+	{{range .Entries}}
+		<li>{{.Type}} {{.Value}} expires on {{.ExpiresOn}}</li>
+	{{end}}
+</ul>
+<p>Once expired, the entries are automatically removed from the approval list. To keep an entry, add it again
+with a later expiry date from the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>.</p>
+`
+)
+
 // ApprovalListApprovedTemplateParams is email params for Approval
 type ApprovalListApprovedTemplateParams struct {
 	ApprovalTemplateParams
@@ -111,8 +185,8 @@ func (p ApprovalListApprovedTemplateParams) GetProjects() string {
 	return strings.Join(projectNames, ", ")
 }
 
-// RenderApprovalListTemplate renders RenderApprovalListTemplate
-func RenderApprovalListTemplate(repository projects_cla_groups.Repository, projectService project.Service, projectSFIDs []string, params ApprovalListApprovedTemplateParams) (string, error) {
+// RenderApprovalListTemplate renders RenderApprovalListTemplate, the params keep the prefilled projects for rendering the subject
+func RenderApprovalListTemplate(repository projects_cla_groups.Repository, projectService FoundationLevelChecker, projectSFIDs []string, params *ApprovalListApprovedTemplateParams) (string, error) {
 	// prefill the projects data
 	projects, err := PrefillCLAProjectParams(repository, projectService, projectSFIDs, "")
 	if err != nil {
@@ -122,5 +196,5 @@ func RenderApprovalListTemplate(repository projects_cla_groups.Repository, proje
 	params.Projects = projects
 
 	return RenderTemplate(utils.V2, ApprovalListApprovedTemplateName,
-		ApprovalListApprovedTemplate, params)
+		ApprovalListApprovedTemplate, *params)
 }
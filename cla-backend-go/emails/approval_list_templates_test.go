@@ -31,6 +31,47 @@ func TestApprovalListRejectedTemplate(t *testing.T) {
 	assert.Contains(t, result, "<li>LFUserName LFEmail</li>")
 }
 
+func TestEmployeeSignatureRevokedTemplate(t *testing.T) {
+	params := EmployeeSignatureRevokedTemplateParams{
+		CLAManagerTemplateParams: CLAManagerTemplateParams{
+			RecipientName: "JohnsContributor",
+			Project:       CLAProjectParams{ExternalProjectName: "JohnsProject"},
+			CompanyName:   "JohnsCompany",
+		},
+		EffectiveDate: "June 15, 2021",
+		Reason:        "<b>left the company</b>",
+	}
+
+	result, err := RenderTemplate(utils.V2, EmployeeSignatureRevokedTemplateName, EmployeeSignatureRevokedTemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello JohnsContributor")
+	assert.Contains(t, result, "A CLA Manager of JohnsCompany revoked your acknowledgement of the Corporate CLA of JohnsProject, effective June 15, 2021.")
+	assert.Contains(t, result, "Reason: &lt;b&gt;left the company&lt;/b&gt;")
+}
+
+func TestApprovalListExpiryReminderTemplate(t *testing.T) {
+	params := ApprovalListExpiryReminderTemplateParams{
+		CLAManagerTemplateParams: CLAManagerTemplateParams{
+			RecipientName: "JohnsClaManager",
+			Project:       CLAProjectParams{ExternalProjectName: "JohnsProject"},
+			CompanyName:   "Johns & <i>Company</i>",
+		},
+		Entries: []ApprovalListExpiryReminderEntryParams{
+			{Type: "email", Value: "<b>intern</b>@example.org", ExpiresOn: "2021-09-01T23:59:59Z"},
+			{Type: "domain", Value: "contractor.example.org", ExpiresOn: "2021-09-02T23:59:59Z"},
+		},
+		CorporateConsoleURL: "https://corporate.example.org",
+	}
+
+	result, err := RenderTemplate(utils.V2, ApprovalListExpiryReminderTemplateName, ApprovalListExpiryReminderTemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello JohnsClaManager")
+	assert.Contains(t, result, "approval list for Johns &amp; &lt;i&gt;Company&lt;/i&gt; for project JohnsProject expire within a week")
+	assert.Contains(t, result, "<li>email &lt;b&gt;intern&lt;/b&gt;@example.org expires on 2021-09-01T23:59:59Z</li>")
+	assert.Contains(t, result, "<li>domain contractor.example.org expires on 2021-09-02T23:59:59Z</li>")
+	assert.Contains(t, result, `<a href="https://corporate.example.org" target="_blank">EasyCLA Corporate Console</a>`)
+}
+
 func TestApprovalListApprovedTemplate(t *testing.T) {
 	params := ApprovalListApprovedTemplateParams{
 		ApprovalTemplateParams: ApprovalTemplateParams{
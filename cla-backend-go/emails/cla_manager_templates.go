@@ -29,11 +29,12 @@ const (
 )
 
 // RenderRemovedCLAManagerTemplate renders the RemovedCLAManagerTemplate
-func RenderRemovedCLAManagerTemplate(repository projects_cla_groups.Repository, claGroupModelVersion, recipientName, companyName, projectSFID string, claManagers []ClaManagerInfoParams) (string, error) {
+func RenderRemovedCLAManagerTemplate(repository projects_cla_groups.Repository, claGroupModelVersion, recipientName, recipientLanguage, companyName, projectSFID string, claManagers []ClaManagerInfoParams) (string, error) {
 	params := CLAManagerTemplateParams{
-		RecipientName: recipientName,
-		CompanyName:   companyName,
-		CLAManagers:   claManagers,
+		RecipientName:     recipientName,
+		CompanyName:       companyName,
+		CLAManagers:       claManagers,
+		RecipientLanguage: recipientLanguage,
 	}
 
 	err := PrefillCLAManagerTemplateParamsFromClaGroup(repository, projectSFID, &params)
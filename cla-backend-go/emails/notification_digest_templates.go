@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+// DigestCLAManagerRequest is a pending CLA Manager access request listed in the notification digest
+type DigestCLAManagerRequest struct {
+	CompanyName    string
+	CLAGroupName   string
+	RequesterName  string
+	RequesterEmail string
+	RequestedOn    string
+}
+
+// DigestApprovalListRequest is a pending approval list request listed in the notification digest
+type DigestApprovalListRequest struct {
+	CompanyName      string
+	CLAGroupName     string
+	ContributorName  string
+	ContributorEmail string
+	RequestedOn      string
+}
+
+// DigestRepositoryEvent is a repository event listed in the notification digest
+type DigestRepositoryEvent struct {
+	CLAGroupName string
+	Summary      string
+	EventTime    string
+}
+
+// NotificationDigestTemplateParams is email params for NotificationDigestTemplate
+type NotificationDigestTemplateParams struct {
+	RecipientName        string
+	Frequency            string
+	CLAManagerRequests   []DigestCLAManagerRequest
+	ApprovalListRequests []DigestApprovalListRequest
+	RepositoryEvents     []DigestRepositoryEvent
+	CorporateConsoleURL  string
+	// RecipientLanguage selects the language overrides of the template, English when empty
+	RecipientLanguage string
+}
+
+// TemplateRecipient selects the overrides of the template for the recipient language, the digest spans foundations
+func (p NotificationDigestTemplateParams) TemplateRecipient() TemplateRecipient {
+	return TemplateRecipient{Language: p.RecipientLanguage}
+}
+
+const (
+	// NotificationDigestTemplateName is email template name for NotificationDigestTemplate
+	NotificationDigestTemplateName = "NotificationDigestTemplate"
+	// NotificationDigestTemplate is email template for the daily and weekly notification digests
+	NotificationDigestTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is your {{.Frequency}} notification digest from EasyCLA.</p>
+{{- if .CLAManagerRequests}}
+<p>Pending CLA Manager access requests:</p>
+<ul>
+{{- range .CLAManagerRequests}}
+<li>{{.RequesterName}} ({{.RequesterEmail}}) for {{.CompanyName}} on {{.CLAGroupName}}, requested on {{.RequestedOn}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .ApprovalListRequests}}
+<p>Pending approval list requests:</p>
+<ul>
+{{- range .ApprovalListRequests}}
+<li>{{.ContributorName}} ({{.ContributorEmail}}) for {{.CompanyName}} on {{.CLAGroupName}}, requested on {{.RequestedOn}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .RepositoryEvents}}
+<p>Repositories added to your CLA Groups:</p>
+<ul>
+{{- range .RepositoryEvents}}
+<li>{{.CLAGroupName}}: {{.Summary}} ({{.EventTime}})</li>
+{{- end}}
+</ul>
+{{- end}}
+<p>Please review the pending requests in the <a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>.
+You can change how often you receive these notifications in your EasyCLA notification preferences.</p>
+`
+)
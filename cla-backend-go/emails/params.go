@@ -67,6 +67,8 @@ type CLAManagerTemplateParams struct {
 	// this is important for some of the email rendering knowing if claGroup has
 	// multiple children
 	ChildProjectCount int
+	// RecipientLanguage selects the language overrides of the template, English when empty
+	RecipientLanguage string
 }
 
 // ApprovalTemplateParams details approval fields for contributor
@@ -76,6 +78,32 @@ type ApprovalTemplateParams struct {
 	CLAGroupName  string
 	Approver      string
 	Projects      []CLAProjectParams
+	// RecipientLanguage selects the language overrides of the template, English when empty
+	RecipientLanguage string
+}
+
+// TemplateRecipient selects the overrides of the template for the foundation of the project and the recipient language
+func (claParams CLAManagerTemplateParams) TemplateRecipient() TemplateRecipient {
+	return TemplateRecipient{FoundationSFID: claParams.Project.FoundationSFID, Language: claParams.RecipientLanguage}
+}
+
+// TemplateRecipient selects the overrides of the template for the foundation of the projects and the recipient language
+func (approvalParams ApprovalTemplateParams) TemplateRecipient() TemplateRecipient {
+	return TemplateRecipient{FoundationSFID: projectsFoundationSFID(approvalParams.Projects), Language: approvalParams.RecipientLanguage}
+}
+
+// projectsFoundationSFID returns the foundation of the projects, empty when they don't share the same foundation
+func projectsFoundationSFID(projects []CLAProjectParams) string {
+	if len(projects) == 0 {
+		return ""
+	}
+	foundationSFID := projects[0].FoundationSFID
+	for _, project := range projects[1:] {
+		if project.FoundationSFID != foundationSFID {
+			return ""
+		}
+	}
+	return foundationSFID
 }
 
 // GetProjectNameOrFoundation returns if the foundationName is set it gets back
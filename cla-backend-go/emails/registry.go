@@ -0,0 +1,265 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// FooterTemplateName is the name of the override of the help and sign-off paragraphs appended to the emails
+	FooterTemplateName = "EmailFooterTemplate"
+
+	// SubjectTemplateSuffix is appended to the template name to form the file name of the override of its subject
+	SubjectTemplateSuffix = "Subject.txt"
+
+	// DefaultTemplateScope is the folder of the overrides applying to all the foundations
+	DefaultTemplateScope = "default"
+
+	// TemplatesS3Prefix is the folder of the overrides in the signature files bucket
+	TemplatesS3Prefix = "email-templates"
+
+	// DefaultTemplateCacheTTL is how long the registry keeps the loaded overrides, and the missing ones
+	DefaultTemplateCacheTTL = 10 * time.Minute
+)
+
+// TemplateStore loads the overrides of the email templates
+type TemplateStore interface {
+	// Load returns the template stored under the key, found is false when there is none
+	Load(key string) (template string, found bool, err error)
+}
+
+type directoryTemplateStore struct {
+	directory string
+}
+
+// NewDirectoryTemplateStore returns a store of overrides laid out as <directory>/<key>
+func NewDirectoryTemplateStore(directory string) TemplateStore {
+	return &directoryTemplateStore{directory: directory}
+}
+
+// Load reads the template file of the key
+func (s *directoryTemplateStore) Load(key string) (string, bool, error) {
+	content, err := ioutil.ReadFile(filepath.Join(s.directory, filepath.FromSlash(key)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return string(content), true, nil
+}
+
+type s3TemplateStore struct {
+	s3         *s3.S3
+	bucketName string
+	prefix     string
+}
+
+// NewS3TemplateStore returns a store of overrides laid out as <prefix>/<key> in the bucket
+func NewS3TemplateStore(awsSession *session.Session, bucketName, prefix string) TemplateStore {
+	return &s3TemplateStore{
+		s3:         s3.New(awsSession),
+		bucketName: bucketName,
+		prefix:     prefix,
+	}
+}
+
+// Load downloads the template object of the key
+func (s *s3TemplateStore) Load(key string) (string, bool, error) {
+	output, err := s.s3.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(path.Join(s.prefix, key)),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer output.Body.Close() // nolint
+
+	content, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		return "", false, err
+	}
+	return string(content), true, nil
+}
+
+// NewTemplateStore returns the store of overrides of the configuration, the email templates directory when set or
+// the email-templates folder of the signature files bucket
+func NewTemplateStore(awsSession *session.Session, cfg config.Config) TemplateStore {
+	if cfg.EmailTemplates.Directory != "" {
+		return NewDirectoryTemplateStore(cfg.EmailTemplates.Directory)
+	}
+	return NewS3TemplateStore(awsSession, cfg.SignatureFilesBucket, TemplatesS3Prefix)
+}
+
+// TemplateRecipient selects the overrides of the templates rendered for a recipient
+type TemplateRecipient struct {
+	FoundationSFID string
+	Language       string
+}
+
+// templateRecipientProvider is implemented by the template params knowing the foundation and the language of the recipient
+type templateRecipientProvider interface {
+	TemplateRecipient() TemplateRecipient
+}
+
+type cachedTemplate struct {
+	template string
+	found    bool
+	expires  time.Time
+}
+
+// TemplateRegistry resolves the overrides of the email templates per foundation and per language, the built-in
+// templates apply when there is no override
+type TemplateRegistry struct {
+	store TemplateStore
+	ttl   time.Duration
+	now   func() time.Time
+
+	lock  sync.Mutex
+	cache map[string]cachedTemplate
+}
+
+// NewTemplateRegistry returns a registry loading the overrides from the store, keeping them for the ttl
+func NewTemplateRegistry(store TemplateStore, ttl time.Duration) *TemplateRegistry {
+	return &TemplateRegistry{
+		store: store,
+		ttl:   ttl,
+		now:   time.Now,
+		cache: map[string]cachedTemplate{},
+	}
+}
+
+var templateRegistry *TemplateRegistry
+
+// SetTemplateRegistry sets the registry of the overrides used by RenderTemplate, only the built-in templates are
+// rendered when not set
+func SetTemplateRegistry(registry *TemplateRegistry) {
+	templateRegistry = registry
+}
+
+// languageFallbacks returns the language followed by its less specific tags, zh-Hans-CN, zh-Hans and zh
+func languageFallbacks(language string) []string {
+	var languages []string
+	for language != "" {
+		languages = append(languages, language)
+		i := strings.LastIndex(language, "-")
+		if i < 0 {
+			break
+		}
+		language = language[:i]
+	}
+	return languages
+}
+
+// templateOverrideKeys returns the keys of the overrides of the template from the most to the least specific: the
+// foundation overrides in the language of the recipient, the overrides of all the foundations in the language of
+// the recipient, then the foundation overrides without language
+func templateOverrideKeys(recipient TemplateRecipient, templateName string) []string {
+	return overrideKeys(recipient, templateName+".html")
+}
+
+// subjectOverrideKeys returns the keys of the overrides of the subject of the template, in the same order as the
+// overrides of the template
+func subjectOverrideKeys(recipient TemplateRecipient, templateName string) []string {
+	return overrideKeys(recipient, templateName+SubjectTemplateSuffix)
+}
+
+func overrideKeys(recipient TemplateRecipient, fileName string) []string {
+	languages := languageFallbacks(recipient.Language)
+
+	var keys []string
+	if recipient.FoundationSFID != "" {
+		for _, language := range languages {
+			keys = append(keys, path.Join(recipient.FoundationSFID, language, fileName))
+		}
+	}
+	for _, language := range languages {
+		keys = append(keys, path.Join(DefaultTemplateScope, language, fileName))
+	}
+	if recipient.FoundationSFID != "" {
+		keys = append(keys, path.Join(recipient.FoundationSFID, fileName))
+	}
+	return keys
+}
+
+// Lookup returns the most specific override of the template for the recipient, found is false when the built-in
+// template applies
+func (r *TemplateRegistry) Lookup(recipient TemplateRecipient, templateName string) (string, bool) {
+	return r.lookupKeys(templateOverrideKeys(recipient, templateName))
+}
+
+// LookupSubject returns the most specific override of the subject of the template for the recipient, found is false
+// when the built-in subject applies
+func (r *TemplateRegistry) LookupSubject(recipient TemplateRecipient, templateName string) (string, bool) {
+	return r.lookupKeys(subjectOverrideKeys(recipient, templateName))
+}
+
+func (r *TemplateRegistry) lookupKeys(keys []string) (string, bool) {
+	for _, key := range keys {
+		if template, found := r.load(key); found {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// load returns the override of the key from the cache or the store, the store errors are logged and handled as a
+// missing override so a failing store doesn't block the emails
+func (r *TemplateRegistry) load(key string) (string, bool) {
+	now := r.now()
+	r.lock.Lock()
+	cached, ok := r.cache[key]
+	r.lock.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.template, cached.found
+	}
+
+	template, found, err := r.store.Load(key)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName": "emails.TemplateRegistry.load",
+			"key":          key,
+		}).WithError(err).Warn("unable to load the email template override - using the built-in template")
+		found = false
+	}
+
+	r.lock.Lock()
+	r.cache[key] = cachedTemplate{template: template, found: found, expires: now.Add(r.ttl)}
+	r.lock.Unlock()
+	return template, found
+}
+
+// lookupTemplateOverride returns the override of the template from the registry, if any
+func lookupTemplateOverride(recipient TemplateRecipient, templateName string) (string, bool) {
+	if templateRegistry == nil {
+		return "", false
+	}
+	return templateRegistry.Lookup(recipient, templateName)
+}
+
+// lookupSubjectOverride returns the override of the subject of the template from the registry, if any
+func lookupSubjectOverride(recipient TemplateRecipient, templateName string) (string, bool) {
+	if templateRegistry == nil {
+		return "", false
+	}
+	return templateRegistry.LookupSubject(recipient, templateName)
+}
@@ -0,0 +1,173 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type mapTemplateStore struct {
+	templates map[string]string
+	loads     int
+	err       error
+}
+
+func (s *mapTemplateStore) Load(key string) (string, bool, error) {
+	s.loads++
+	if s.err != nil {
+		return "", false, s.err
+	}
+	template, found := s.templates[key]
+	return template, found, nil
+}
+
+func TestLanguageFallbacks(t *testing.T) {
+	assert.Equal(t, []string{"zh-Hans-CN", "zh-Hans", "zh"}, languageFallbacks("zh-Hans-CN"))
+	assert.Equal(t, []string{"fr"}, languageFallbacks("fr"))
+	assert.Empty(t, languageFallbacks(""))
+}
+
+func TestTemplateOverrideKeys(t *testing.T) {
+	assert.Equal(t, []string{
+		"a092M00001IV4RxQAL/pt-BR/RequestToAuthorizeTemplate.html",
+		"a092M00001IV4RxQAL/pt/RequestToAuthorizeTemplate.html",
+		"default/pt-BR/RequestToAuthorizeTemplate.html",
+		"default/pt/RequestToAuthorizeTemplate.html",
+		"a092M00001IV4RxQAL/RequestToAuthorizeTemplate.html",
+	}, templateOverrideKeys(TemplateRecipient{FoundationSFID: "a092M00001IV4RxQAL", Language: "pt-BR"}, "RequestToAuthorizeTemplate"))
+
+	assert.Equal(t, []string{"default/ja/RequestToAuthorizeTemplate.html"},
+		templateOverrideKeys(TemplateRecipient{Language: "ja"}, "RequestToAuthorizeTemplate"))
+	assert.Empty(t, templateOverrideKeys(TemplateRecipient{}, "RequestToAuthorizeTemplate"))
+}
+
+func TestTemplateRegistryLookup(t *testing.T) {
+	store := &mapTemplateStore{templates: map[string]string{
+		"foundation-1/de/ApprovalListRejectedTemplate.html": "<p>Hallo {{.RecipientName}},</p>",
+		"default/fr/ApprovalListRejectedTemplate.html":      "<p>Bonjour {{.RecipientName}},</p>",
+		"foundation-1/ApprovalListRejectedTemplate.html":    "<p>Hi {{.RecipientName}},</p>",
+	}}
+	registry := NewTemplateRegistry(store, time.Minute)
+
+	template, found := registry.Lookup(TemplateRecipient{FoundationSFID: "foundation-1", Language: "de-AT"}, ApprovalListRejectedTemplateName)
+	assert.True(t, found)
+	assert.Equal(t, "<p>Hallo {{.RecipientName}},</p>", template)
+
+	template, found = registry.Lookup(TemplateRecipient{FoundationSFID: "foundation-1", Language: "fr"}, ApprovalListRejectedTemplateName)
+	assert.True(t, found)
+	assert.Equal(t, "<p>Bonjour {{.RecipientName}},</p>", template)
+
+	template, found = registry.Lookup(TemplateRecipient{FoundationSFID: "foundation-1", Language: "es"}, ApprovalListRejectedTemplateName)
+	assert.True(t, found)
+	assert.Equal(t, "<p>Hi {{.RecipientName}},</p>", template)
+
+	_, found = registry.Lookup(TemplateRecipient{FoundationSFID: "foundation-2", Language: "es"}, ApprovalListRejectedTemplateName)
+	assert.False(t, found)
+}
+
+func TestTemplateRegistryCache(t *testing.T) {
+	store := &mapTemplateStore{templates: map[string]string{
+		"foundation-1/ApprovalListRejectedTemplate.html": "<p>Hi</p>",
+	}}
+	registry := NewTemplateRegistry(store, time.Minute)
+	now := time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC)
+	registry.now = func() time.Time { return now }
+
+	recipient := TemplateRecipient{FoundationSFID: "foundation-1"}
+	registry.Lookup(recipient, ApprovalListRejectedTemplateName)
+	registry.Lookup(recipient, ApprovalListRejectedTemplateName)
+	assert.Equal(t, 1, store.loads, "the override is loaded once until it expires")
+
+	now = now.Add(2 * time.Minute)
+	registry.Lookup(recipient, ApprovalListRejectedTemplateName)
+	assert.Equal(t, 2, store.loads)
+}
+
+func TestTemplateRegistryStoreError(t *testing.T) {
+	registry := NewTemplateRegistry(&mapTemplateStore{err: errors.New("access denied")}, time.Minute)
+	_, found := registry.Lookup(TemplateRecipient{FoundationSFID: "foundation-1", Language: "fr"}, ApprovalListRejectedTemplateName)
+	assert.False(t, found)
+}
+
+func TestRenderTemplateWithOverrides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "email-templates")
+	if err != nil {
+		t.Fatalf("creating temp dir failed : %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint
+
+	writeTemplate := func(key, content string) {
+		fileName := filepath.Join(dir, filepath.FromSlash(key))
+		if mkdirErr := os.MkdirAll(filepath.Dir(fileName), 0750); mkdirErr != nil {
+			t.Fatalf("creating template dir failed : %v", mkdirErr)
+		}
+		if writeErr := ioutil.WriteFile(fileName, []byte(content), 0600); writeErr != nil {
+			t.Fatalf("writing template failed : %v", writeErr)
+		}
+	}
+	writeTemplate("foundation-1/fr/ApprovalListRejectedTemplate.html", "<p>Bonjour {{.RecipientName}}, {{.Project.ExternalProjectName}}</p>")
+	writeTemplate("foundation-1/EmailFooterTemplate.html", "<p>The Foundation Team</p>")
+	writeTemplate("foundation-2/ApprovalListRejectedTemplate.html", "<p>{{.Broken</p>")
+
+	SetTemplateRegistry(NewTemplateRegistry(NewDirectoryTemplateStore(dir), time.Minute))
+	defer SetTemplateRegistry(nil)
+
+	params := ApprovalListRejectedTemplateParams{
+		CLAManagerTemplateParams: CLAManagerTemplateParams{
+			RecipientName:     "Jean",
+			Project:           CLAProjectParams{ExternalProjectName: "JeansProject", FoundationSFID: "foundation-1"},
+			CompanyName:       "JeansCompany",
+			RecipientLanguage: "fr-CA",
+		},
+	}
+	result, err := RenderTemplate(utils.V2, ApprovalListRejectedTemplateName, ApprovalListRejectedTemplate, params)
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>Bonjour Jean, JeansProject</p><p>The Foundation Team</p>", result)
+
+	// the built-in template applies to the other languages, with the footer override of the foundation
+	params.RecipientLanguage = "en"
+	result, err = RenderTemplate(utils.V2, ApprovalListRejectedTemplateName, ApprovalListRejectedTemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello Jean")
+	assert.Contains(t, result, "<p>The Foundation Team</p>")
+
+	// a broken override falls back to the built-in template and footer
+	params.Project.FoundationSFID = "foundation-2"
+	result, err = RenderTemplate(utils.V2, ApprovalListRejectedTemplateName, ApprovalListRejectedTemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello Jean")
+	assert.Contains(t, result, utils.GetEmailSignOffContent())
+}
+
+func TestRenderSubjectWithOverrides(t *testing.T) {
+	store := &mapTemplateStore{templates: map[string]string{
+		"foundation-1/fr/ApprovalListRejectedTemplateSubject.txt": "EasyCLA : Demande refusée pour {{.Project.ExternalProjectName}}\n",
+		"foundation-2/ApprovalListRejectedTemplateSubject.txt":    "{{.Broken",
+	}}
+	SetTemplateRegistry(NewTemplateRegistry(store, time.Minute))
+	defer SetTemplateRegistry(nil)
+
+	params := ApprovalListRejectedTemplateParams{
+		CLAManagerTemplateParams: CLAManagerTemplateParams{
+			Project:           CLAProjectParams{ExternalProjectName: "Project & Co", FoundationSFID: "foundation-1"},
+			RecipientLanguage: "fr-CA",
+		},
+	}
+	assert.Equal(t, "EasyCLA : Demande refusée pour Project & Co",
+		RenderSubject(ApprovalListRejectedTemplateName, "EasyCLA: Approval List Request Denied", params))
+
+	// the given subject applies to the other languages and when the override is broken
+	params.RecipientLanguage = "en"
+	assert.Equal(t, "EasyCLA: Approval List Request Denied", RenderSubject(ApprovalListRejectedTemplateName, "EasyCLA: Approval List Request Denied", params))
+	params.Project.FoundationSFID = "foundation-2"
+	assert.Equal(t, "EasyCLA: Approval List Request Denied", RenderSubject(ApprovalListRejectedTemplateName, "EasyCLA: Approval List Request Denied", params))
+}
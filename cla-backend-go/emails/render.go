@@ -6,12 +6,89 @@ package emails
 import (
 	"bytes"
 	"html/template"
+	"strings"
+	textTemplate "text/template"
 
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
 )
 
-// RenderTemplate renders the template for given template with given params
+// RenderTemplate renders the template for given template with given params. When the params know the foundation
+// and the language of the recipient, the overrides of the template registry replace the built-in template and footer.
 func RenderTemplate(claGroupVersion, templateName, templateStr string, params interface{}) (string, error) {
+	var recipient TemplateRecipient
+	if provider, ok := params.(templateRecipientProvider); ok {
+		recipient = provider.TemplateRecipient()
+	}
+	f := logrus.Fields{
+		"functionName":   "emails.RenderTemplate",
+		"templateName":   templateName,
+		"foundationSFID": recipient.FoundationSFID,
+		"language":       recipient.Language,
+	}
+
+	result, err := renderOverride(recipient, templateName, params)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to render the email template override - using the built-in template")
+	}
+	if err != nil || result == "" {
+		if result, err = executeTemplate(templateName, templateStr, params); err != nil {
+			return "", err
+		}
+	}
+
+	footer, err := renderOverride(recipient, FooterTemplateName, params)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to render the email footer override - using the built-in footer")
+	}
+	if err != nil || footer == "" {
+		footer = utils.GetEmailHelpContent(claGroupVersion == utils.V2) + utils.GetEmailSignOffContent()
+	}
+	return result + footer, nil
+}
+
+// RenderSubject returns the subject of the email of the template. When the params know the foundation and the
+// language of the recipient, the override of the subject in the template registry replaces the given subject.
+func RenderSubject(templateName, subject string, params interface{}) string {
+	var recipient TemplateRecipient
+	if provider, ok := params.(templateRecipientProvider); ok {
+		recipient = provider.TemplateRecipient()
+	}
+
+	override, found := lookupSubjectOverride(recipient, templateName)
+	if !found {
+		return subject
+	}
+
+	t, err := textTemplate.New(templateName + SubjectTemplateSuffix).Parse(override)
+	var tpl bytes.Buffer
+	if err == nil {
+		err = t.Execute(&tpl, params)
+	}
+	result := strings.TrimSpace(tpl.String())
+	if err != nil || result == "" {
+		log.WithFields(logrus.Fields{
+			"functionName":   "emails.RenderSubject",
+			"templateName":   templateName,
+			"foundationSFID": recipient.FoundationSFID,
+			"language":       recipient.Language,
+		}).WithError(err).Warn("unable to render the email subject override - using the built-in subject")
+		return subject
+	}
+	return result
+}
+
+// renderOverride renders the override of the template for the recipient, empty when there is none
+func renderOverride(recipient TemplateRecipient, templateName string, params interface{}) (string, error) {
+	override, found := lookupTemplateOverride(recipient, templateName)
+	if !found {
+		return "", nil
+	}
+	return executeTemplate(templateName, override, params)
+}
+
+func executeTemplate(templateName, templateStr string, params interface{}) (string, error) {
 	tmpl := template.New(templateName)
 	t, err := tmpl.Parse(templateStr)
 	if err != nil {
@@ -22,9 +99,5 @@ func RenderTemplate(claGroupVersion, templateName, templateStr string, params in
 	if err := t.Execute(&tpl, params); err != nil {
 		return "", err
 	}
-
-	result := tpl.String()
-	result = result + utils.GetEmailHelpContent(claGroupVersion == utils.V2)
-	result = result + utils.GetEmailSignOffContent()
-	return result, nil
+	return tpl.String(), nil
 }
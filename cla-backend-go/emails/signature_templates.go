@@ -0,0 +1,29 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+// ResignatureRequiredTemplateParams is email params for ResignatureRequiredTemplate
+type ResignatureRequiredTemplateParams struct {
+	CLAManagerTemplateParams
+	MajorVersion int
+	CLAName      string
+	Deadline     string
+	// CorporateConsoleURL is set for the CLA Managers of a CCLA, they re-sign it from the corporate console
+	CorporateConsoleURL string
+}
+
+const (
+	// ResignatureRequiredTemplateName is email template name for ResignatureRequiredTemplate
+	ResignatureRequiredTemplateName = "ResignatureRequiredTemplate"
+	// ResignatureRequiredTemplate is email template for the signers of a CLA which must be re-signed against its new version
+	ResignatureRequiredTemplate = `
+<p>Hello {{.RecipientName}},</p>
+<p>This is a notification email from EasyCLA regarding the project {{.Project.ExternalProjectName}}.</p>
+<p>Version {{.MajorVersion}} of the {{.CLAName}} CLA was published for {{.Project.ExternalProjectName}}. The signature on file was made against an older version, so
+{{if .CorporateConsoleURL}}a CLA Manager of {{.CompanyName}} needs to sign the new version of the Corporate CLA from the
+<a href="{{.CorporateConsoleURL}}" target="_blank">EasyCLA Corporate Console</a>{{else}}you need to sign the new version of the Individual CLA, the EasyCLA check on your next pull request will guide you through it{{end}}.</p>
+<p>The current signature remains valid until {{.Deadline}}. After this date, contributions covered by it will fail the
+EasyCLA check until the new version is signed.</p>
+`
+)
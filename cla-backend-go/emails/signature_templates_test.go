@@ -0,0 +1,40 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package emails
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResignatureRequiredTemplate(t *testing.T) {
+	params := ResignatureRequiredTemplateParams{
+		CLAManagerTemplateParams: CLAManagerTemplateParams{
+			RecipientName: "JohnsClaManager",
+			Project:       CLAProjectParams{ExternalProjectName: "Johns<Project>"},
+			CompanyName:   "Johns & <i>Company</i>",
+		},
+		MajorVersion:        2,
+		CLAName:             "Corporate",
+		Deadline:            "June 15, 2021 00:00 UTC",
+		CorporateConsoleURL: "https://corporate.example.org",
+	}
+
+	result, err := RenderTemplate(utils.V2, ResignatureRequiredTemplateName, ResignatureRequiredTemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "Hello JohnsClaManager")
+	assert.Contains(t, result, "Version 2 of the Corporate CLA was published for Johns&lt;Project&gt;.")
+	assert.Contains(t, result, "a CLA Manager of Johns &amp; &lt;i&gt;Company&lt;/i&gt; needs to sign the new version of the Corporate CLA")
+	assert.Contains(t, result, `<a href="https://corporate.example.org" target="_blank">EasyCLA Corporate Console</a>`)
+	assert.Contains(t, result, "remains valid until June 15, 2021 00:00 UTC.")
+
+	params.CLAName = "Individual"
+	params.CorporateConsoleURL = ""
+	result, err = RenderTemplate(utils.V2, ResignatureRequiredTemplateName, ResignatureRequiredTemplate, params)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "you need to sign the new version of the Individual CLA")
+	assert.NotContains(t, result, "Corporate Console")
+}
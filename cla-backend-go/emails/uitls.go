@@ -9,7 +9,6 @@ import (
 	"fmt"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
-	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 )
 
@@ -43,9 +42,15 @@ func PrefillCLAManagerTemplateParamsFromClaGroup(repository projects_cla_groups.
 	return nil
 }
 
+// FoundationLevelChecker tells whether a foundation has a CLA Group at the foundation level, implemented by the
+// project service
+type FoundationLevelChecker interface {
+	SignedAtFoundationLevel(ctx context.Context, foundationSFID string) (bool, error)
+}
+
 // PrefillCLAProjectParams for each supplied projectSFIDs gets the claGroup info + checks if the project is signed at
 // foundation level which is important for email rendering
-func PrefillCLAProjectParams(repository projects_cla_groups.Repository, projectService project.Service, projectSFIDs []string, corporateConsole string) ([]CLAProjectParams, error) {
+func PrefillCLAProjectParams(repository projects_cla_groups.Repository, projectService FoundationLevelChecker, projectSFIDs []string, corporateConsole string) ([]CLAProjectParams, error) {
 	if len(projectSFIDs) == 0 {
 		return nil, nil
 	}
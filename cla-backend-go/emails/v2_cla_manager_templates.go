@@ -6,8 +6,6 @@ package emails
 import (
 	"strings"
 
-	"github.com/communitybridge/easycla/cla-backend-go/project"
-
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 )
@@ -61,7 +59,7 @@ Either you or someone whom to designate from your company can login to this port
 )
 
 // RenderV2OrgAdminTemplate renders V2OrgAdminTemplate
-func RenderV2OrgAdminTemplate(repository projects_cla_groups.Repository, projectService project.Service, projectSFID string, params V2OrgAdminTemplateParams) (string, error) {
+func RenderV2OrgAdminTemplate(repository projects_cla_groups.Repository, projectService FoundationLevelChecker, projectSFID string, params V2OrgAdminTemplateParams) (string, error) {
 	if err := PrefillCLAManagerTemplateParamsFromClaGroup(repository, projectSFID, &params.CLAManagerTemplateParams); err != nil {
 		return "", err
 	}
@@ -84,6 +82,11 @@ type V2ContributorToOrgAdminTemplateParams struct {
 	CorporateConsole string
 }
 
+// TemplateRecipient selects the overrides of the template for the foundation of the projects and the recipient language
+func (p V2ContributorToOrgAdminTemplateParams) TemplateRecipient() TemplateRecipient {
+	return TemplateRecipient{FoundationSFID: projectsFoundationSFID(p.Projects), Language: p.RecipientLanguage}
+}
+
 const (
 	// V2ContributorToOrgAdminTemplateName is email template name for V2ContributorToOrgAdminTemplate
 	V2ContributorToOrgAdminTemplateName = "V2ContributorToOrgAdminTemplate"
@@ -99,7 +102,7 @@ const (
 )
 
 // RenderV2ContributorToOrgAdminTemplate renders V2ContributorToOrgAdminTemplate
-func RenderV2ContributorToOrgAdminTemplate(repository projects_cla_groups.Repository, projectService project.Service, projectSFIDs []string, params V2ContributorToOrgAdminTemplateParams) (string, error) {
+func RenderV2ContributorToOrgAdminTemplate(repository projects_cla_groups.Repository, projectService FoundationLevelChecker, projectSFIDs []string, params V2ContributorToOrgAdminTemplateParams) (string, error) {
 	// prefill the projects data
 	projects, err := PrefillCLAProjectParams(repository, projectService, projectSFIDs, params.CorporateConsole)
 	if err != nil {
@@ -139,7 +142,7 @@ Either you or someone whom to designate from your company can login to this port
 )
 
 // RenderV2CLAManagerDesigneeCorporateTemplate renders V2CLAManagerDesigneeCorporateTemplate
-func RenderV2CLAManagerDesigneeCorporateTemplate(repository projects_cla_groups.Repository, projectService project.Service, projectSFID string, params V2CLAManagerDesigneeCorporateTemplateParams) (string, error) {
+func RenderV2CLAManagerDesigneeCorporateTemplate(repository projects_cla_groups.Repository, projectService FoundationLevelChecker, projectSFID string, params V2CLAManagerDesigneeCorporateTemplateParams) (string, error) {
 	if err := PrefillCLAManagerTemplateParamsFromClaGroup(repository, projectSFID, &params.CLAManagerTemplateParams); err != nil {
 		return "", err
 	}
@@ -163,6 +166,13 @@ type V2ToCLAManagerDesigneeTemplateParams struct {
 	ContributorName  string
 	CorporateConsole string
 	CompanyName      string
+	// RecipientLanguage selects the language overrides of the template, English when empty
+	RecipientLanguage string
+}
+
+// TemplateRecipient selects the overrides of the template for the foundation of the projects and the recipient language
+func (p V2ToCLAManagerDesigneeTemplateParams) TemplateRecipient() TemplateRecipient {
+	return TemplateRecipient{FoundationSFID: projectsFoundationSFID(p.Projects), Language: p.RecipientLanguage}
 }
 
 // GetProjectsOrProject returns the single Project or comma separated projects if more than one
@@ -198,7 +208,7 @@ const (
 )
 
 // RenderV2ToCLAManagerDesigneeTemplate renders V2ToCLAManagerDesigneeTemplate
-func RenderV2ToCLAManagerDesigneeTemplate(repository projects_cla_groups.Repository, projectService project.Service, projectSFIDs []string, params V2ToCLAManagerDesigneeTemplateParams, template string, templateName string) (string, error) {
+func RenderV2ToCLAManagerDesigneeTemplate(repository projects_cla_groups.Repository, projectService FoundationLevelChecker, projectSFIDs []string, params V2ToCLAManagerDesigneeTemplateParams, template string, templateName string) (string, error) {
 	// prefill the projects data
 	projects, err := PrefillCLAProjectParams(repository, projectService, projectSFIDs, params.CorporateConsole)
 	if err != nil {
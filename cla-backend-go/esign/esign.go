@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package esign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+)
+
+// provider types selectable in the configuration
+const (
+	// TypeV1API keeps forwarding the corporate signature requests to the v1 API and DocuSign
+	TypeV1API = ""
+	TypeFake  = "fake"
+)
+
+// envelope statuses
+const (
+	StatusSent      = "sent"
+	StatusCompleted = "completed"
+	StatusDeclined  = "declined"
+	StatusVoided    = "voided"
+)
+
+// ErrEnvelopeNotFound is returned when the provider has no envelope with the specified ID
+var ErrEnvelopeNotFound = errors.New("envelope not found")
+
+// EnvelopeRequest is the document to sign and the signer it is routed to
+type EnvelopeRequest struct {
+	// SignatureID of the pending signature record, returned in the callbacks of the provider
+	SignatureID  string
+	DocumentName string
+	Document     []byte
+	SignerName   string
+	SignerEmail  string
+	// SendAsEmail sends the signing request to the signer by email, otherwise a signing URL is returned
+	SendAsEmail bool
+	// ReturnURL is where the signer is redirected once the document is signed
+	ReturnURL string
+}
+
+// Envelope is the signing request created by the provider
+type Envelope struct {
+	ID      string
+	SignURL string
+}
+
+// EnvelopeResult is the state of an envelope, the signed document is only set once completed
+type EnvelopeResult struct {
+	ID             string
+	Status         string
+	SignerName     string
+	SignerEmail    string
+	CompletedOn    string
+	SignedDocument []byte
+}
+
+// Provider collects signatures on documents through an e-signature service
+type Provider interface {
+	Name() string
+	CreateEnvelope(ctx context.Context, req *EnvelopeRequest) (*Envelope, error)
+	GetEnvelope(ctx context.Context, envelopeID string) (*EnvelopeResult, error)
+	// VoidEnvelope cancels an envelope which is not completed, the signer can no longer sign it
+	VoidEnvelope(ctx context.Context, envelopeID, reason string) error
+	// VerifyWebhook returns ErrInvalidWebhookSignature if the callback was not signed by the provider
+	VerifyWebhook(timestamp, signature string, payload []byte) error
+}
+
+// NewProvider returns the provider selected in the configuration, a nil provider means the v1 API handles the
+// corporate signatures. The e-signature flow is off unless it is enabled in the configuration.
+func NewProvider(cfg config.Config) (Provider, error) {
+	if !cfg.ESign.Enabled {
+		return nil, nil
+	}
+
+	switch cfg.ESign.Type {
+	case TypeV1API:
+		return nil, nil
+	case TypeFake:
+		if cfg.ESign.WebhookSecret == "" {
+			return nil, errors.New("the e-signature provider webhook secret is required")
+		}
+		return NewFakeProvider(cfg.ESign.AutoSign, cfg.ESign.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unsupported e-signature provider type : %s", cfg.ESign.Type)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package esign
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+)
+
+// FakeProvider keeps the envelopes in memory and lets the tests sign or decline them, no external service is used
+type FakeProvider struct {
+	autoSign      bool
+	webhookSecret string
+	lock          sync.Mutex
+	envelopes     map[string]*fakeEnvelope
+}
+
+type fakeEnvelope struct {
+	request *EnvelopeRequest
+	result  EnvelopeResult
+}
+
+// NewFakeProvider returns a fake provider, with auto sign the envelopes are signed the first time they are checked.
+// The callbacks are signed with the webhook secret like the ones of a real provider.
+func NewFakeProvider(autoSign bool, webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		autoSign:      autoSign,
+		webhookSecret: webhookSecret,
+		envelopes:     map[string]*fakeEnvelope{},
+	}
+}
+
+// Name of the provider
+func (p *FakeProvider) Name() string {
+	return TypeFake
+}
+
+// CreateEnvelope stores the signing request
+func (p *FakeProvider) CreateEnvelope(ctx context.Context, req *EnvelopeRequest) (*Envelope, error) {
+	envelopeID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.envelopes[envelopeID.String()] = &fakeEnvelope{
+		request: req,
+		result: EnvelopeResult{
+			ID:          envelopeID.String(),
+			Status:      StatusSent,
+			SignerName:  req.SignerName,
+			SignerEmail: req.SignerEmail,
+		},
+	}
+
+	envelope := &Envelope{ID: envelopeID.String()}
+	if !req.SendAsEmail {
+		envelope.SignURL = fmt.Sprintf("fake-esign://sign/%s", envelopeID.String())
+	}
+	return envelope, nil
+}
+
+// GetEnvelope returns the state of the envelope
+func (p *FakeProvider) GetEnvelope(ctx context.Context, envelopeID string) (*EnvelopeResult, error) {
+	if p.autoSign {
+		err := p.Sign(envelopeID)
+		if err != nil && err != ErrEnvelopeNotFound {
+			return nil, err
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	envelope, ok := p.envelopes[envelopeID]
+	if !ok {
+		return nil, ErrEnvelopeNotFound
+	}
+	result := envelope.result
+	return &result, nil
+}
+
+// Sign completes the envelope, the signed document is the original one
+func (p *FakeProvider) Sign(envelopeID string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	envelope, ok := p.envelopes[envelopeID]
+	if !ok {
+		return ErrEnvelopeNotFound
+	}
+	if envelope.result.Status != StatusSent {
+		return nil
+	}
+
+	_, now := utils.CurrentTime()
+	envelope.result.Status = StatusCompleted
+	envelope.result.CompletedOn = now
+	envelope.result.SignedDocument = envelope.request.Document
+	return nil
+}
+
+// VerifyWebhook checks the signature of the callback with the webhook secret
+func (p *FakeProvider) VerifyWebhook(timestamp, signature string, payload []byte) error {
+	return VerifyWebhook(p.webhookSecret, timestamp, signature, payload, time.Now())
+}
+
+// Decline marks the envelope as declined by the signer
+func (p *FakeProvider) Decline(envelopeID string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	envelope, ok := p.envelopes[envelopeID]
+	if !ok {
+		return ErrEnvelopeNotFound
+	}
+	if envelope.result.Status == StatusSent {
+		envelope.result.Status = StatusDeclined
+	}
+	return nil
+}
+
+// VoidEnvelope cancels the envelope if the signer did not sign or decline it yet
+func (p *FakeProvider) VoidEnvelope(ctx context.Context, envelopeID, reason string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	envelope, ok := p.envelopes[envelopeID]
+	if !ok {
+		return ErrEnvelopeNotFound
+	}
+	if envelope.result.Status == StatusSent {
+		envelope.result.Status = StatusVoided
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package esign
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeProviderSign(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider(false, "secret")
+
+	envelope, err := provider.CreateEnvelope(ctx, &EnvelopeRequest{
+		SignatureID: "signature-1",
+		Document:    []byte("ccla"),
+		SignerName:  "Derk Miyamoto",
+		SignerEmail: "derk@example.org",
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, envelope.SignURL, "signers which are not emailed are redirected to the sign url")
+
+	result, err := provider.GetEnvelope(ctx, envelope.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusSent, result.Status)
+	assert.Nil(t, result.SignedDocument)
+
+	assert.NoError(t, provider.Sign(envelope.ID))
+	result, err = provider.GetEnvelope(ctx, envelope.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, result.Status)
+	assert.Equal(t, []byte("ccla"), result.SignedDocument)
+	assert.NotEmpty(t, result.CompletedOn)
+
+	assert.NoError(t, provider.Decline(envelope.ID))
+	result, err = provider.GetEnvelope(ctx, envelope.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, result.Status, "a completed envelope can not be declined")
+}
+
+func TestFakeProviderVoid(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider(false, "secret")
+
+	envelope, err := provider.CreateEnvelope(ctx, &EnvelopeRequest{Document: []byte("ccla")})
+	assert.NoError(t, err)
+	assert.NoError(t, provider.VoidEnvelope(ctx, envelope.ID, "replaced"))
+	result, err := provider.GetEnvelope(ctx, envelope.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusVoided, result.Status)
+
+	assert.NoError(t, provider.Sign(envelope.ID))
+	result, err = provider.GetEnvelope(ctx, envelope.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusVoided, result.Status, "a voided envelope can not be signed")
+
+	assert.Equal(t, ErrEnvelopeNotFound, provider.VoidEnvelope(ctx, "unknown", "replaced"))
+}
+
+func TestFakeProviderAutoSign(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider(true, "secret")
+
+	envelope, err := provider.CreateEnvelope(ctx, &EnvelopeRequest{Document: []byte("ccla"), SendAsEmail: true})
+	assert.NoError(t, err)
+	assert.Empty(t, envelope.SignURL, "the signing request is emailed")
+
+	result, err := provider.GetEnvelope(ctx, envelope.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, result.Status)
+
+	_, err = provider.GetEnvelope(ctx, "unknown")
+	assert.Equal(t, ErrEnvelopeNotFound, err)
+}
@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package esign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// WebhookTolerance is how far the timestamp of a provider callback may be from the clock, the callbacks outside of
+// the tolerance are rejected so they can not be replayed
+const WebhookTolerance = 5 * time.Minute
+
+// ErrInvalidWebhookSignature is returned when a provider callback is not signed with the webhook secret
+var ErrInvalidWebhookSignature = errors.New("invalid e-signature provider webhook signature")
+
+// SignWebhook returns the signature of a provider callback, the hex encoded HMAC-SHA256 of the timestamp (Unix
+// seconds), a dot and the payload keyed with the webhook secret
+func SignWebhook(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + ".")) // nolint
+	mac.Write(payload)                 // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks the signature of a provider callback against the webhook secret, a missing secret rejects
+// every callback
+func VerifyWebhook(secret, timestamp, signature string, payload []byte, now time.Time) error {
+	if secret == "" || signature == "" {
+		return ErrInvalidWebhookSignature
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+	if delta := now.Sub(time.Unix(seconds, 0)); delta > WebhookTolerance || delta < -WebhookTolerance {
+		return ErrInvalidWebhookSignature
+	}
+	if !hmac.Equal([]byte(SignWebhook(secret, timestamp, payload)), []byte(signature)) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
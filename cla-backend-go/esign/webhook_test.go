@@ -0,0 +1,27 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package esign
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := SignWebhook("secret", timestamp, []byte("signature-1"))
+
+	assert.NoError(t, VerifyWebhook("secret", timestamp, signature, []byte("signature-1"), now))
+	assert.NoError(t, VerifyWebhook("secret", timestamp, signature, []byte("signature-1"), now.Add(WebhookTolerance)))
+
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook("other", timestamp, signature, []byte("signature-1"), now))
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook("secret", timestamp, signature, []byte("signature-2"), now), "the signature covers the payload")
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook("secret", timestamp, signature, []byte("signature-1"), now.Add(WebhookTolerance+time.Second)), "old callbacks are replays")
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook("secret", "yesterday", signature, []byte("signature-1"), now))
+	assert.Equal(t, ErrInvalidWebhookSignature, VerifyWebhook("", timestamp, SignWebhook("", timestamp, []byte("signature-1")), []byte("signature-1"), now))
+}
@@ -5,6 +5,7 @@ package events
 
 import (
 	"fmt"
+	"strings"
 )
 
 // EventData returns event data string which is used for event logging and containsPII field
@@ -58,6 +59,16 @@ type GerritDeletedEventData struct {
 	GerritRepositoryName string
 }
 
+// GerritHookTokenCreatedEventData . . .
+type GerritHookTokenCreatedEventData struct {
+	GerritRepositoryName string
+}
+
+// GerritHookTokenRevokedEventData . . .
+type GerritHookTokenRevokedEventData struct {
+	GerritRepositoryName string
+}
+
 // GitHubProjectDeletedEventData . . .
 type GitHubProjectDeletedEventData struct {
 	DeletedCount int
@@ -105,9 +116,73 @@ type CompanyACLUserAddedEventData struct {
 	UserLFID string
 }
 
+// CompanySCIMTokenCreatedEventData . . .
+type CompanySCIMTokenCreatedEventData struct{}
+
+// CompanySCIMTokenRevokedEventData . . .
+type CompanySCIMTokenRevokedEventData struct{}
+
+// WebhookCreatedEventData . . .
+type WebhookCreatedEventData struct {
+	WebhookID string
+	OwnerType string
+	OwnerID   string
+	URL       string
+}
+
+// WebhookUpdatedEventData . . .
+type WebhookUpdatedEventData struct {
+	WebhookID string
+	OwnerType string
+	OwnerID   string
+	URL       string
+	Enabled   bool
+}
+
+// WebhookDeletedEventData . . .
+type WebhookDeletedEventData struct {
+	WebhookID string
+	OwnerType string
+	OwnerID   string
+	URL       string
+}
+
+// ChatChannelCreatedEventData . . .
+type ChatChannelCreatedEventData struct {
+	ChannelID string
+	OwnerType string
+	OwnerID   string
+	Provider  string
+}
+
+// ChatChannelUpdatedEventData . . .
+type ChatChannelUpdatedEventData struct {
+	ChannelID string
+	OwnerType string
+	OwnerID   string
+	Provider  string
+	Enabled   bool
+}
+
+// ChatChannelDeletedEventData . . .
+type ChatChannelDeletedEventData struct {
+	ChannelID string
+	OwnerType string
+	OwnerID   string
+	Provider  string
+}
+
 // CLATemplateCreatedEventData . . .
 type CLATemplateCreatedEventData struct{}
 
+// CLATemplateUploadedEventData . . .
+type CLATemplateUploadedEventData struct {
+	TemplateID   string
+	TemplateName string
+	MajorVersion int64
+	MinorVersion int64
+}
+
 // GitHubOrganizationAddedEventData . . .
 type GitHubOrganizationAddedEventData struct {
 	GitHubOrganizationName  string
@@ -128,6 +203,26 @@ type GitHubOrganizationUpdatedEventData struct {
 	AutoEnabledClaGroupID  string
 }
 
+// GitLabOrganizationAddedEventData . . .
+type GitLabOrganizationAddedEventData struct {
+	GitLabOrganizationName string
+}
+
+// GitLabOrganizationDeletedEventData . . .
+type GitLabOrganizationDeletedEventData struct {
+	GitLabOrganizationName string
+}
+
+// GitLabProjectAddedEventData . . .
+type GitLabProjectAddedEventData struct {
+	GitLabProjectName string
+}
+
+// GitLabProjectDeletedEventData . . .
+type GitLabProjectDeletedEventData struct {
+	GitLabProjectName string
+}
+
 // CCLAApprovalListRequestCreatedEventData . . .
 type CCLAApprovalListRequestCreatedEventData struct {
 	RequestID string
@@ -135,12 +230,20 @@ type CCLAApprovalListRequestCreatedEventData struct {
 
 // CCLAApprovalListRequestApprovedEventData . . .
 type CCLAApprovalListRequestApprovedEventData struct {
-	RequestID string
+	RequestID        string
+	AutoApproved     bool
+	AutoApprovalRule string
 }
 
 // CCLAApprovalListRequestRejectedEventData . . .
 type CCLAApprovalListRequestRejectedEventData struct {
 	RequestID string
+	Reason    string
+}
+
+// CCLAApprovalListRequestRulesUpdatedEventData . . .
+type CCLAApprovalListRequestRulesUpdatedEventData struct {
+	RuleTypes []string
 }
 
 // CLAManagerCreatedEventData . . .
@@ -268,6 +371,36 @@ type CLAApprovalListRemoveGitHubOrgData struct {
 	ApprovalListGitHubOrg string
 }
 
+// CLAApprovalListAddGitHubTeamData . . .
+type CLAApprovalListAddGitHubTeamData struct {
+	UserName               string
+	UserEmail              string
+	UserLFID               string
+	ApprovalListGitHubTeam string
+}
+
+// CLAApprovalListRemoveGitHubTeamData . . .
+type CLAApprovalListRemoveGitHubTeamData struct {
+	UserName               string
+	UserEmail              string
+	UserLFID               string
+	ApprovalListGitHubTeam string
+}
+
+// CLAApprovalListImportData . . .
+type CLAApprovalListImportData struct {
+	UserName       string
+	UserEmail      string
+	UserLFID       string
+	AddedEntries   []string
+	RemovedEntries []string
+}
+
+// CLAApprovalListExpiredData . . .
+type CLAApprovalListExpiredData struct {
+	RemovedEntries []string
+}
+
 // ApprovalListGitHubOrganizationAddedEventData . . .
 type ApprovalListGitHubOrganizationAddedEventData struct {
 	GitHubOrganizationName string
@@ -278,6 +411,16 @@ type ApprovalListGitHubOrganizationDeletedEventData struct {
 	GitHubOrganizationName string
 }
 
+// ApprovalListGitHubTeamAddedEventData . . .
+type ApprovalListGitHubTeamAddedEventData struct {
+	GitHubTeamName string
+}
+
+// ApprovalListGitHubTeamDeletedEventData . . .
+type ApprovalListGitHubTeamDeletedEventData struct {
+	GitHubTeamName string
+}
+
 // ClaManagerAccessRequestAddedEventData . . .
 type ClaManagerAccessRequestAddedEventData struct {
 	ProjectName string
@@ -301,6 +444,36 @@ type CLAGroupUpdatedEventData struct {
 // CLAGroupDeletedEventData . . .
 type CLAGroupDeletedEventData struct{}
 
+// CLAGroupMajorVersionPublishedEventData . . .
+type CLAGroupMajorVersionPublishedEventData struct {
+	MajorVersion              int64
+	ResignDeadline            string
+	SignaturesRequiringResign int64
+}
+
+// CLAGroupMembershipCheckAPIKeyCreatedEventData . . .
+type CLAGroupMembershipCheckAPIKeyCreatedEventData struct{}
+
+// CLAGroupMembershipCheckAPIKeyRevokedEventData . . .
+type CLAGroupMembershipCheckAPIKeyRevokedEventData struct{}
+
+// IndividualSignatureSignedEventData . . .
+type IndividualSignatureSignedEventData struct {
+	SignatureID  string
+	SignMethod   string
+	MajorVersion string
+	MinorVersion string
+}
+
+// EmployeeSignatureRevokedEventData . . .
+type EmployeeSignatureRevokedEventData struct {
+	SignatureID    string
+	UserName       string
+	Reason         string
+	EffectiveDate  string
+	RemovedEntries []string
+}
+
 // ContributorNotifyCompanyAdminData . . .
 type ContributorNotifyCompanyAdminData struct {
 	AdminName  string
@@ -313,6 +486,11 @@ type ContributorNotifyCLADesignee struct {
 	DesigneeEmail string
 }
 
+// ContributorNotifyCLAManagersData event data of the approval requests sent by the contributors to the CLA Managers
+type ContributorNotifyCLAManagersData struct {
+	CLAManagerEmails []string
+}
+
 // ContributorAssignCLADesignee . . .
 type ContributorAssignCLADesignee struct {
 	DesigneeName  string
@@ -425,12 +603,75 @@ func (ed *CompanyACLUserAddedEventData) GetEventDetailsString(args *LogEventArgs
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *CompanySCIMTokenCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s created the directory sync (SCIM) token for Company: %s.",
+		args.userName, args.companyName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CompanySCIMTokenRevokedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s revoked the directory sync (SCIM) token for Company: %s.",
+		args.userName, args.companyName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *WebhookCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s registered the webhook: %s (%s) for the %s: %s.",
+		args.userName, ed.URL, ed.WebhookID, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *WebhookUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s updated the webhook: %s (%s) for the %s: %s, enabled: %t.",
+		args.userName, ed.URL, ed.WebhookID, ed.OwnerType, ed.OwnerID, ed.Enabled)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *WebhookDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s deleted the webhook: %s (%s) for the %s: %s.",
+		args.userName, ed.URL, ed.WebhookID, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *ChatChannelCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s registered the %s chat channel: %s for the %s: %s.",
+		args.userName, ed.Provider, ed.ChannelID, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *ChatChannelUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s updated the %s chat channel: %s for the %s: %s, enabled: %t.",
+		args.userName, ed.Provider, ed.ChannelID, ed.OwnerType, ed.OwnerID, ed.Enabled)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *ChatChannelDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s deleted the %s chat channel: %s for the %s: %s.",
+		args.userName, ed.Provider, ed.ChannelID, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *CLATemplateCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("PDF Templates created for Project: %s by: %s.", args.userName, args.projectName)
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *CLATemplateUploadedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Template: %s (%s) version %d.%d was uploaded for Project: %s by: %s.",
+		ed.TemplateName, ed.TemplateID, ed.MajorVersion, ed.MinorVersion, args.projectName, args.userName)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *GitHubOrganizationAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("GitHub Organization: %s was added with auto-enabled: %t, with branch protection enabled: %t",
@@ -460,10 +701,38 @@ func (ed *GitHubOrganizationUpdatedEventData) GetEventDetailsString(args *LogEve
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *GitLabOrganizationAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("GitLab Group: %s was added for the project %s by: %s.", ed.GitLabOrganizationName, args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *GitLabOrganizationDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("GitLab Group: %s was deleted for the project %s by: %s.", ed.GitLabOrganizationName, args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *GitLabProjectAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The GitLab project: %s was added for the project %s by the user %s.", ed.GitLabProjectName, args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *GitLabProjectDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The GitLab project: %s was deleted for the project %s by the user %s.", ed.GitLabProjectName, args.projectName, args.userName)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *CCLAApprovalListRequestApprovedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s approved a CCLA Approval Request for Project: %s and Company: %s with Request ID: %s.",
 		args.userName, args.projectName, args.companyName, ed.RequestID)
+	if ed.AutoApproved {
+		data = fmt.Sprintf("The auto-approval rule: %s approved a CCLA Approval Request for Project: %s and Company: %s with Request ID: %s.",
+			ed.AutoApprovalRule, args.projectName, args.companyName, ed.RequestID)
+	}
 	return data, true
 }
 
@@ -471,6 +740,16 @@ func (ed *CCLAApprovalListRequestApprovedEventData) GetEventDetailsString(args *
 func (ed *CCLAApprovalListRequestRejectedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s rejected a CCLA Approval Request for Project: %s, Company: %s with Request ID: %s.",
 		args.userName, args.projectName, args.companyName, ed.RequestID)
+	if ed.Reason != "" {
+		data = data + fmt.Sprintf(" Reason: %s.", ed.Reason)
+	}
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CCLAApprovalListRequestRulesUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s updated the CCLA Approval Request auto-approval rules for Company: %s, rules: [%s].",
+		args.userName, args.companyName, strings.Join(ed.RuleTypes, ", "))
 	return data, true
 }
 
@@ -572,6 +851,34 @@ func (ed *CLAApprovalListRemoveGitHubOrgData) GetEventDetailsString(args *LogEve
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListAddGitHubTeamData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s, Email: %s, LFID: %s added GitHub Team: %s to the approval list for Company: %s, Project: %s.",
+		ed.UserName, ed.UserEmail, ed.UserLFID, ed.ApprovalListGitHubTeam, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListRemoveGitHubTeamData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s, Email: %s, LFID: %s removed GitHub Team: %s from the approval list for Company: %s, Project: %s.",
+		ed.UserName, ed.UserEmail, ed.UserLFID, ed.ApprovalListGitHubTeam, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListImportData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s, Email: %s, LFID: %s imported the approval list CSV for Company: %s, Project: %s, added: [%s], removed: [%s].",
+		ed.UserName, ed.UserEmail, ed.UserLFID, args.companyName, args.projectName, strings.Join(ed.AddedEntries, ", "), strings.Join(ed.RemovedEntries, ", "))
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAApprovalListExpiredData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("EasyCLA removed the expired approval list entries for Company: %s, Project: %s, removed: [%s].",
+		args.companyName, args.projectName, strings.Join(ed.RemovedEntries, ", "))
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *CCLAApprovalListRequestCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s created a CCLA Approval Request for Project: %s, Company: %s with Request ID: %s.",
@@ -593,6 +900,20 @@ func (ed *ApprovalListGitHubOrganizationDeletedEventData) GetEventDetailsString(
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *ApprovalListGitHubTeamAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s added GitHub Team: %s to the approval list for Company: %s, Project: %s.",
+		args.userName, ed.GitHubTeamName, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *ApprovalListGitHubTeamDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s removed GitHub Team: %s from the approval list for Company: %s, Project: %s.",
+		args.userName, ed.GitHubTeamName, args.companyName, args.projectName)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *ClaManagerAccessRequestAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s has requested to be CLA Manager for Company %s, Project: %s.",
@@ -628,6 +949,39 @@ func (ed *CLAGroupDeletedEventData) GetEventDetailsString(args *LogEventArgs) (s
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *CLAGroupMajorVersionPublishedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Group ID: %s major version %d was published by: %s, %d signatures must be re-signed by %s.",
+		args.ProjectID, ed.MajorVersion, args.userName, ed.SignaturesRequiringResign, ed.ResignDeadline)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAGroupMembershipCheckAPIKeyCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Group ID: %s membership check API key was created by: %s.", args.ProjectID, args.userName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *CLAGroupMembershipCheckAPIKeyRevokedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Group ID: %s membership check API key was revoked by: %s.", args.ProjectID, args.userName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *IndividualSignatureSignedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Signature ID: %s for CLA Group ID: %s version %s.%s was signed by: %s using %s.",
+		ed.SignatureID, args.ProjectID, ed.MajorVersion, ed.MinorVersion, args.userName, ed.SignMethod)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *EmployeeSignatureRevokedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s revoked the employee acknowledgement signature ID: %s of %s for Company: %s, Project: %s, effective: %s, reason: %s, removed approval list entries: [%s].",
+		args.userName, ed.SignatureID, ed.UserName, args.companyName, args.projectName, ed.EffectiveDate, ed.Reason, strings.Join(ed.RemovedEntries, ", "))
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *GerritProjectDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("%d Gerrit Repositories were deleted due to CLA Group/Project: %s deletion.",
@@ -647,6 +1001,18 @@ func (ed *GerritDeletedEventData) GetEventDetailsString(args *LogEventArgs) (str
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *GerritHookTokenCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s created the enforcement hook token for Gerrit Repository: %s.", args.userName, ed.GerritRepositoryName)
+	return data, true
+}
+
+// GetEventDetailsString . . .
+func (ed *GerritHookTokenRevokedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s revoked the enforcement hook token for Gerrit Repository: %s.", args.userName, ed.GerritRepositoryName)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *GitHubProjectDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("%d GitHub Repositories were deleted due to CLA Group/Project: [%s] deletion.",
@@ -668,6 +1034,15 @@ func (ed *ContributorNotifyCompanyAdminData) GetEventDetailsString(args *LogEven
 	return data, true
 }
 
+// GetEventDetailsString . . .
+func (ed *ContributorNotifyCLAManagersData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s requested the approval of CLA Managers: %s for Project Name : %s, ID: %s and Company Name: %s, ID: %s.",
+		args.userName, strings.Join(ed.CLAManagerEmails, ", "),
+		args.projectName, args.ProjectID,
+		args.companyName, args.CompanyID)
+	return data, true
+}
+
 // GetEventDetailsString . . .
 func (ed *ContributorNotifyCLADesignee) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s notified CLA Designee: %s by Email: %s for Project Name : %s, ID: %s and Company Name: %s, ID: %s.",
@@ -796,12 +1171,75 @@ func (ed *CompanyACLUserAddedEventData) GetEventSummaryString(args *LogEventArgs
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *CompanySCIMTokenCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s created the directory sync token for Company: %s.",
+		args.userName, args.companyName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CompanySCIMTokenRevokedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s revoked the directory sync token for Company: %s.",
+		args.userName, args.companyName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *WebhookCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s registered the webhook: %s for the %s: %s.",
+		args.userName, ed.URL, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *WebhookUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s updated the webhook: %s for the %s: %s.",
+		args.userName, ed.URL, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *WebhookDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s deleted the webhook: %s for the %s: %s.",
+		args.userName, ed.URL, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *ChatChannelCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s registered a %s chat channel for the %s: %s.",
+		args.userName, ed.Provider, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *ChatChannelUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s updated a %s chat channel for the %s: %s.",
+		args.userName, ed.Provider, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *ChatChannelDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s deleted a %s chat channel for the %s: %s.",
+		args.userName, ed.Provider, ed.OwnerType, ed.OwnerID)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *CLATemplateCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("PDF templates were created for Project %s by: %s.", args.projectName, args.userName)
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *CLATemplateUploadedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Template %s version %d.%d was uploaded for Project %s by: %s.",
+		ed.TemplateName, ed.MajorVersion, ed.MinorVersion, args.projectName, args.userName)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *GitHubOrganizationAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("GitHub Organization: %s was added with auto-enabled: %t, branch protection enabled: %t",
@@ -831,10 +1269,38 @@ func (ed *GitHubOrganizationUpdatedEventData) GetEventSummaryString(args *LogEve
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *GitLabOrganizationAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("GitLab Group: %s was added to Project: %s by: %s.", ed.GitLabOrganizationName, args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *GitLabOrganizationDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("GitLab Group: %s was deleted from Project: %s by: %s.", ed.GitLabOrganizationName, args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *GitLabProjectAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("GitLab Project: %s was added to Project: %s by: %s.", ed.GitLabProjectName, args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *GitLabProjectDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("GitLab Project: %s was deleted from Project: %s by: %s.", ed.GitLabProjectName, args.projectName, args.userName)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *CCLAApprovalListRequestApprovedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s approved a CCLA Approval Request for Project: %s, Company: %s.",
 		args.userName, args.projectName, args.companyName)
+	if ed.AutoApproved {
+		data = fmt.Sprintf("A CCLA Approval Request for Project: %s, Company: %s was approved automatically.",
+			args.projectName, args.companyName)
+	}
 	return data, true
 }
 
@@ -845,6 +1311,13 @@ func (ed *CCLAApprovalListRequestRejectedEventData) GetEventSummaryString(args *
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *CCLAApprovalListRequestRulesUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("User: %s updated the CCLA Approval Request auto-approval rules for Company: %s.",
+		args.userName, args.companyName)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *CLAManagerRequestCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s added CLA Manager Request: %s for Company: %s, Project: %s.",
@@ -943,6 +1416,34 @@ func (ed *CLAApprovalListRemoveGitHubOrgData) GetEventSummaryString(args *LogEve
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListAddGitHubTeamData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s added GitHub Team: %s to the approval list for Company: %s, Project: %s.",
+		ed.UserName, ed.ApprovalListGitHubTeam, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListRemoveGitHubTeamData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s removed GitHub Team: %s from the approval list for Company: %s, Project: %s.",
+		ed.UserName, ed.ApprovalListGitHubTeam, args.companyName, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListImportData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s imported the approval list CSV for Company: %s, Project: %s, added %d and removed %d entries.",
+		ed.UserName, args.companyName, args.projectName, len(ed.AddedEntries), len(ed.RemovedEntries))
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAApprovalListExpiredData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("EasyCLA removed %d expired approval list entries for Company: %s, Project: %s.",
+		len(ed.RemovedEntries), args.companyName, args.projectName)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *CCLAApprovalListRequestCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s created a CCLA Approval Request for Project: %s, Company: %s.",
@@ -964,6 +1465,20 @@ func (ed *ApprovalListGitHubOrganizationDeletedEventData) GetEventSummaryString(
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *ApprovalListGitHubTeamAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s added GitHub Team: %s to the approval list for Project: %s, Company: %s.",
+		args.userName, ed.GitHubTeamName, args.projectName, args.companyName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *ApprovalListGitHubTeamDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s removed GitHub Team: %s from the approval list for Project: %s, Company: %s.",
+		args.userName, ed.GitHubTeamName, args.projectName, args.companyName)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *ClaManagerAccessRequestAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The user %s has requested to be CLA Manager for the project %s, the company %s.",
@@ -998,6 +1513,39 @@ func (ed *CLAGroupDeletedEventData) GetEventSummaryString(args *LogEventArgs) (s
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *CLAGroupMajorVersionPublishedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Version %d of the CLA Group %s documents was published by the user %s, %d signatures must be re-signed by %s.",
+		ed.MajorVersion, args.projectName, args.userName, ed.SignaturesRequiringResign, ed.ResignDeadline)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAGroupMembershipCheckAPIKeyCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The membership check API key of the CLA Group %s was created by the user %s.", args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *CLAGroupMembershipCheckAPIKeyRevokedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The membership check API key of the CLA Group %s was revoked by the user %s.", args.projectName, args.userName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *IndividualSignatureSignedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The user %s signed version %s.%s of the Individual CLA for the CLA Group %s.",
+		args.userName, ed.MajorVersion, ed.MinorVersion, args.projectName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *EmployeeSignatureRevokedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("CLA Manager: %s revoked the employee acknowledgement of %s for Company: %s, Project: %s, effective: %s.",
+		args.userName, ed.UserName, args.companyName, args.projectName, ed.EffectiveDate)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *GerritProjectDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("%d Gerrit repositories were deleted due to CLA Group/Project %s deletion.",
@@ -1017,6 +1565,18 @@ func (ed *GerritDeletedEventData) GetEventSummaryString(args *LogEventArgs) (str
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *GerritHookTokenCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The enforcement hook token of the Gerrit repository %s was created by %s.", ed.GerritRepositoryName, args.userName)
+	return data, true
+}
+
+// GetEventSummaryString . . .
+func (ed *GerritHookTokenRevokedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The enforcement hook token of the Gerrit repository %s was revoked by %s.", ed.GerritRepositoryName, args.userName)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *GitHubProjectDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("%d GitHub repositories were deleted due to CLA Group/project %s deletion.",
@@ -1038,6 +1598,13 @@ func (ed *ContributorNotifyCompanyAdminData) GetEventSummaryString(args *LogEven
 	return data, true
 }
 
+// GetEventSummaryString . . .
+func (ed *ContributorNotifyCLAManagersData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The user %s requested the approval of the CLA Managers of the company %s for the project: %s.",
+		args.userName, args.companyName, args.projectName)
+	return data, true
+}
+
 // GetEventSummaryString . . .
 func (ed *ContributorNotifyCLADesignee) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The user %s notified the CLA Designee %s by email %s for the project: %s and the company %s.",
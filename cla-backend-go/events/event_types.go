@@ -26,10 +26,11 @@ const (
 // events
 // naming convention : <resource>.<action>
 const (
-	CLATemplateCreated = "cla_template.created"
-	UserCreated        = "user.created"
-	UserUpdated        = "user.updated"
-	UserDeleted        = "user.deleted"
+	CLATemplateCreated  = "cla_template.created"
+	CLATemplateUploaded = "cla_template.uploaded"
+	UserCreated         = "user.created"
+	UserUpdated         = "user.updated"
+	UserDeleted         = "user.deleted"
 
 	RepositoryAdded                    = "repository.added"
 	RepositoryDisabled                 = "repository.disabled"
@@ -40,22 +41,44 @@ const (
 
 	GerritRepositoryAdded   = "gerrit_repository.added"
 	GerritRepositoryDeleted = "gerrit_repository.deleted"
+	GerritHookTokenCreated  = "gerrit_repository.hook_token_created"
+	GerritHookTokenRevoked  = "gerrit_repository.hook_token_revoked"
 
 	GitHubOrganizationAdded   = "github_organization.added"
 	GitHubOrganizationDeleted = "github_organization.deleted"
 	GitHubOrganizationUpdated = "github_organization.updated"
 
+	GitLabOrganizationAdded   = "gitlab_organization.added"
+	GitLabOrganizationDeleted = "gitlab_organization.deleted"
+	GitLabProjectAdded        = "gitlab_project.added"
+	GitLabProjectDeleted      = "gitlab_project.deleted"
+
 	CompanyACLUserAdded       = "company_acl.user_added"
 	CompanyACLRequestAdded    = "company_acl.request_added"
 	CompanyACLRequestApproved = "company_acl.request_approved"
 	CompanyACLRequestDenied   = "company_acl.request_denied"
 
-	CCLAApprovalListRequestCreated  = "ccla_approval_list_request.created"
-	CCLAApprovalListRequestApproved = "ccla_approval_list_request.approved"
-	CCLAApprovalListRequestRejected = "ccla_approval_list_request.rejected"
+	CompanySCIMTokenCreated = "company.scim_token_created"
+	CompanySCIMTokenRevoked = "company.scim_token_revoked"
+
+	WebhookCreated = "webhook.created"
+	WebhookUpdated = "webhook.updated"
+	WebhookDeleted = "webhook.deleted"
+
+	ChatChannelCreated = "chat_channel.created"
+	ChatChannelUpdated = "chat_channel.updated"
+	ChatChannelDeleted = "chat_channel.deleted"
+
+	CCLAApprovalListRequestCreated      = "ccla_approval_list_request.created"
+	CCLAApprovalListRequestApproved     = "ccla_approval_list_request.approved"
+	CCLAApprovalListRequestRejected     = "ccla_approval_list_request.rejected"
+	CCLAApprovalListRequestRulesUpdated = "ccla_approval_list_request.rules_updated"
 
 	ApprovalListGitHubOrganizationAdded   = "approval_list.github_organization_added"
 	ApprovalListGitHubOrganizationDeleted = "approval_list.github_organization_deleted"
+	ApprovalListGitHubTeamAdded           = "approval_list.github_team_added"
+	ApprovalListGitHubTeamDeleted         = "approval_list.github_team_deleted"
+	ApprovalListChanged                   = "approval_list.changed"
 
 	ClaManagerAccessRequestCreated  = "cla_manager.access_request_created"
 	ClaManagerAccessRequestApproved = "cla_manager.access_request_approved"
@@ -73,10 +96,20 @@ const (
 	CLAGroupUpdated = "cla_group.updated"
 	CLAGroupDeleted = "cla_group.deleted"
 
-	InvalidatedSignature = "signature.invalidated"
+	CLAGroupMajorVersionPublished = "cla_group.major_version_published"
+
+	CLAGroupMembershipCheckAPIKeyCreated = "cla_group.membership_check_api_key_created"
+	CLAGroupMembershipCheckAPIKeyRevoked = "cla_group.membership_check_api_key_revoked"
+
+	InvalidatedSignature      = "signature.invalidated"
+	IndividualSignatureSigned = "signature.individual_signed"
+	EmployeeSignatureSigned   = "signature.employee_signed"
+	CorporateSignatureSigned  = "signature.corporate_signed"
+	EmployeeSignatureRevoked  = "signature.employee_revoked"
 
 	ContributorNotifyCompanyAdminType = "contributor.notify_company_admin"
 	ContributorNotifyCLADesigneeType  = "contributor.notify_cla_designee"
+	ContributorNotifyCLAManagersType  = "contributor.notify_cla_managers"
 	ContributorAssignCLADesigneeType  = "contributor.assign_designee"
 	ConvertUserToContactType          = "lfx_user.convert_to_contact"
 	AssignUserRoleScopeType           = "lfx_org_service.assign_user_role_scope"
@@ -39,6 +39,14 @@ func (repo *mockRepository) GetClaGroupEvents(claGroupID string, nextKey *string
 	panic("implement me")
 }
 
+func (repo *mockRepository) GetSignatureApprovalListEvents(signatureID string) ([]*models.Event, error) {
+	panic("implement me")
+}
+
+func (repo *mockRepository) GetClaGroupEventsSince(claGroupID, eventType string, since time.Time) ([]*models.Event, error) {
+	panic("implement me")
+}
+
 var events []*models.Event
 
 // NewMockRepository creates a new instance of the mock event repository
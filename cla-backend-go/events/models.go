@@ -28,6 +28,33 @@ type Event struct {
 	EventSFProjectName     string `dynamodbav:"event_sf_project_name"`
 	EventProjectSFID       string `dynamodbav:"event_project_sfid"`
 	EventCompanySFID       string `dynamodbav:"event_company_sfid"`
+	EventSignatureID       string `dynamodbav:"event_signature_id"`
+	// ApprovalListsAdded and ApprovalListsRemoved are the change record of the approval list changed events, the
+	// entries added to and removed from the approval lists of the signature by the update
+	ApprovalListsAdded   *ApprovalListSnapshot `dynamodbav:"event_approval_lists_added"`
+	ApprovalListsRemoved *ApprovalListSnapshot `dynamodbav:"event_approval_lists_removed"`
+}
+
+// ApprovalListSnapshot data model of the approval lists of a signature
+type ApprovalListSnapshot struct {
+	EmailApprovalList          []string `dynamodbav:"email_approval_list"`
+	DomainApprovalList         []string `dynamodbav:"domain_approval_list"`
+	GithubUsernameApprovalList []string `dynamodbav:"github_username_approval_list"`
+	GithubOrgApprovalList      []string `dynamodbav:"github_org_approval_list"`
+	GithubTeamApprovalList     []string `dynamodbav:"github_team_approval_list"`
+}
+
+func (s *ApprovalListSnapshot) toModel() *models.ApprovalListSnapshot {
+	if s == nil {
+		return nil
+	}
+	return &models.ApprovalListSnapshot{
+		EmailApprovalList:          s.EmailApprovalList,
+		DomainApprovalList:         s.DomainApprovalList,
+		GithubUsernameApprovalList: s.GithubUsernameApprovalList,
+		GithubOrgApprovalList:      s.GithubOrgApprovalList,
+		GithubTeamApprovalList:     s.GithubTeamApprovalList,
+	}
 }
 
 // DBUser data model
@@ -66,7 +93,10 @@ func (e *Event) toEvent() *models.Event { //nolint
 		EventProjectSFID:       e.EventProjectSFID,
 		EventProjectSFName:     e.EventSFProjectName,
 		EventCompanySFID:       e.EventCompanySFID,
+		EventSignatureID:       e.EventSignatureID,
 	}
+	event.ApprovalListsAdded = e.ApprovalListsAdded.toModel()
+	event.ApprovalListsRemoved = e.ApprovalListsRemoved.toModel()
 	// Disregard Company details for ICLA event
 	if event.EventType != IndividualSignedEvent {
 		event.EventCompanyID = e.EventCompanyID
@@ -44,6 +44,7 @@ const (
 	CompanyIDEventTypeIndex             = "company-id-event-type-index"
 	EventFoundationSFIDEpochIndex       = "event-foundation-sfid-event-time-epoch-index"
 	EventProjectIDEpochIndex            = "event-project-id-event-time-epoch-index"
+	EventSignatureIDEpochIndex          = "event-signature-id-event-time-epoch-index"
 )
 
 // constants
@@ -64,6 +65,8 @@ type Repository interface {
 	GetCompanyEvents(companyID, eventType string, nextKey *string, paramPageSize *int64, all bool) (*models.EventList, error)
 	GetFoundationEvents(foundationSFID string, nextKey *string, paramPageSize *int64, all bool, searchTerm *string) (*models.EventList, error)
 	GetClaGroupEvents(claGroupID string, nextKey *string, paramPageSize *int64, all bool, searchTerm *string) (*models.EventList, error)
+	GetSignatureApprovalListEvents(signatureID string) ([]*models.Event, error)
+	GetClaGroupEventsSince(claGroupID, eventType string, since time.Time) ([]*models.Event, error)
 }
 
 // repository data model
@@ -129,6 +132,27 @@ func (repo *repository) CreateEvent(event *models.Event) error {
 		companyIDexternalProjectID := fmt.Sprintf("%s#%s", event.EventCompanyID, event.EventProjectExternalID)
 		addAttribute(input.Item, "company_id_external_project_id", companyIDexternalProjectID)
 	}
+	addAttribute(input.Item, "event_signature_id", event.EventSignatureID)
+	for name, snapshot := range map[string]*models.ApprovalListSnapshot{
+		"event_approval_lists_added":   event.ApprovalListsAdded,
+		"event_approval_lists_removed": event.ApprovalListsRemoved,
+	} {
+		if snapshot == nil {
+			continue
+		}
+		approvalLists, marshalErr := dynamodbattribute.Marshal(ApprovalListSnapshot{
+			EmailApprovalList:          snapshot.EmailApprovalList,
+			DomainApprovalList:         snapshot.DomainApprovalList,
+			GithubUsernameApprovalList: snapshot.GithubUsernameApprovalList,
+			GithubOrgApprovalList:      snapshot.GithubOrgApprovalList,
+			GithubTeamApprovalList:     snapshot.GithubTeamApprovalList,
+		})
+		if marshalErr != nil {
+			log.Warnf("Unable to encode the approval lists of the event, error: %v", marshalErr)
+			return marshalErr
+		}
+		input.Item[name] = approvalLists
+	}
 
 	_, err = repo.dynamoDBClient.PutItem(input)
 	if err != nil {
@@ -509,6 +533,31 @@ func (repo *repository) GetClaGroupEvents(claGroupID string, nextKey *string, pa
 	return repo.queryEventsTable(EventProjectIDEpochIndex, keyCondition, nil, nextKey, paramPageSize, all, searchTerm)
 }
 
+// GetSignatureApprovalListEvents returns the approval list changed events of the signature which record the entries
+// added to and removed from the approval lists by the update, most recent first
+func (repo *repository) GetSignatureApprovalListEvents(signatureID string) ([]*models.Event, error) {
+	keyCondition := expression.Key("event_signature_id").Equal(expression.Value(signatureID))
+	filter := expression.Name("event_type").Equal(expression.Value(ApprovalListChanged))
+	eventList, err := repo.queryEventsTable(EventSignatureIDEpochIndex, keyCondition, &filter, nil, nil, ReturnAllEvents, nil)
+	if err != nil {
+		return nil, err
+	}
+	return eventList.Events, nil
+}
+
+// GetClaGroupEventsSince returns the events of the type logged for the cla-group since the specified time, most
+// recent first
+func (repo *repository) GetClaGroupEventsSince(claGroupID, eventType string, since time.Time) ([]*models.Event, error) {
+	keyCondition := expression.Key("event_project_id").Equal(expression.Value(claGroupID)).
+		And(expression.Key("event_time_epoch").GreaterThanEqual(expression.Value(since.Unix())))
+	filter := expression.Name("event_type").Equal(expression.Value(eventType))
+	eventList, err := repo.queryEventsTable(EventProjectIDEpochIndex, keyCondition, &filter, nil, nil, ReturnAllEvents, nil)
+	if err != nil {
+		return nil, err
+	}
+	return eventList.Events, nil
+}
+
 // toString encodes the map as a string
 func toString(in map[string]*dynamodb.AttributeValue) (string, error) {
 	if len(in) == 0 {
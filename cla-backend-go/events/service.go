@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
@@ -33,6 +34,8 @@ type Service interface {
 	GetCompanyFoundationEvents(companySFID, companyID, foundationSFID string, nextKey *string, paramPageSize *int64, all bool) (*models.EventList, error)
 	GetCompanyClaGroupEvents(companySFID, companyID, claGroupID string, nextKey *string, paramPageSize *int64, all bool) (*models.EventList, error)
 	GetCompanyEvents(companyID, eventType string, nextKey *string, paramPageSize *int64, all bool) (*models.EventList, error)
+	GetSignatureApprovalListEvents(signatureID string) ([]*models.Event, error)
+	GetClaGroupEventsSince(claGroupID, eventType string, since time.Time) ([]*models.Event, error)
 }
 
 // CombinedRepo contains the various methods of other repositories
@@ -104,6 +107,18 @@ func (s *service) GetCompanyEvents(companyID, eventType string, nextKey *string,
 	return s.repo.GetCompanyEvents(companyID, eventType, nextKey, paramPageSize, all)
 }
 
+// GetSignatureApprovalListEvents returns the approval list changed events of the signature which record the entries
+// added to and removed from the approval lists by the update, most recent first
+func (s *service) GetSignatureApprovalListEvents(signatureID string) ([]*models.Event, error) {
+	return s.repo.GetSignatureApprovalListEvents(signatureID)
+}
+
+// GetClaGroupEventsSince returns the events of the type logged for the cla-group since the specified time, most
+// recent first
+func (s *service) GetClaGroupEventsSince(claGroupID, eventType string, since time.Time) ([]*models.Event, error) {
+	return s.repo.GetClaGroupEventsSince(claGroupID, eventType, since)
+}
+
 // LogEventArgs is argument to LogEvent function
 // EventType, EventData are compulsory.
 // One of LfUsername, UserID must be present
@@ -121,6 +136,9 @@ type LogEventArgs struct {
 	userName          string
 	projectName       string
 	companyName       string
+
+	// SignatureID is the signature of the approval list update events
+	SignatureID string
 }
 
 func (s *service) loadCompany(ctx context.Context, args *LogEventArgs) error {
@@ -242,6 +260,7 @@ func (s *service) LogEvent(args *LogEventArgs) {
 		UserID:                 args.UserID,
 		UserName:               args.userName,
 		LfUsername:             args.LfUsername,
+		EventSignatureID:       args.SignatureID,
 	}
 	err = s.repo.CreateEvent(&event)
 	if err != nil {
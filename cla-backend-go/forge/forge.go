@@ -0,0 +1,97 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package forge
+
+import (
+	"context"
+	"errors"
+)
+
+// errors
+var (
+	// ErrProviderNotRegistered is returned when no provider is registered for the repository type
+	ErrProviderNotRegistered = errors.New("git forge provider not registered")
+	// ErrRepositoryNotFound is returned when the git forge does not know the repository
+	ErrRepositoryNotFound = errors.New("repository not found")
+)
+
+// commit status states understood by all the providers, each provider maps them to its own values
+const (
+	StatusStateSuccess = "success"
+	StatusStateFailure = "failure"
+	StatusStatePending = "pending"
+)
+
+// webhook event types
+const (
+	EventTypePullRequest = "pull_request"
+	EventTypeOther       = "other"
+)
+
+// Repository is the git forge independent view of a repository
+type Repository struct {
+	ExternalID    string
+	Name          string
+	FullName      string
+	Owner         string
+	URL           string
+	DefaultBranch string
+}
+
+// BranchProtection holds the protection settings of a branch, Enabled is false when the branch is not protected
+type BranchProtection struct {
+	Enabled        bool
+	EnforceAdmin   bool
+	RequiredChecks []string
+}
+
+// CommitStatus is a status posted on a commit
+type CommitStatus struct {
+	State       string
+	Context     string
+	Description string
+	TargetURL   string
+}
+
+// Event is the git forge independent view of a webhook event. Raw holds the provider specific payload
+// for the events which have no generic representation.
+type Event struct {
+	Type              string
+	Action            string
+	Repository        *Repository
+	PullRequestNumber int
+	HeadSHA           string
+	Raw               interface{}
+}
+
+// WebhookParser parses the webhook payloads sent by a git forge
+type WebhookParser interface {
+	ParseWebhook(eventType string, payload []byte) (*Event, error)
+}
+
+// StatusPoster posts the commit statuses of the CLA check, implemented by each git forge EasyCLA runs the check on
+type StatusPoster interface {
+	// PostStatus creates a commit status on the commit
+	PostStatus(ctx context.Context, owner, repositoryName, sha string, status *CommitStatus) error
+}
+
+// Provider is implemented by each git forge EasyCLA can enforce the CLA on
+type Provider interface {
+	WebhookParser
+	StatusPoster
+	// ListRepositories returns the repositories of the organization
+	ListRepositories(ctx context.Context, organizationName string) ([]*Repository, error)
+	// GetRepository returns the repository by its git forge id
+	GetRepository(ctx context.Context, externalID string) (*Repository, error)
+	// GetRepositoryByName returns the repository of the organization, including its owner and default branch
+	GetRepositoryByName(ctx context.Context, organizationName, repositoryName string) (*Repository, error)
+	// GetBranchProtection returns the protection settings of the branch
+	GetBranchProtection(ctx context.Context, owner, repositoryName, branchName string) (*BranchProtection, error)
+	// SetRequiredChecks enables the branch protection and adds or removes the required status checks,
+	// the checks which are not listed are left untouched
+	SetRequiredChecks(ctx context.Context, owner, repositoryName, branchName string, enforceAdmin bool, enableChecks, disableChecks []string) error
+}
+
+// ProviderFactory returns a provider authenticated for the organization
+type ProviderFactory func(ctx context.Context, organizationName string) (Provider, error)
@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Registry holds the provider factories keyed by repository type, e.g. github
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]ProviderFactory
+}
+
+// NewRegistry creates an empty provider registry
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: map[string]ProviderFactory{},
+	}
+}
+
+// Register adds the provider factory of the repository type, replacing any previous one
+func (r *Registry) Register(repositoryType string, factory ProviderFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[strings.ToLower(repositoryType)] = factory
+}
+
+// IsRegistered returns true if a provider is registered for the repository type
+func (r *Registry) IsRegistered(repositoryType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.factories[strings.ToLower(repositoryType)]
+	return ok
+}
+
+// Provider returns the provider of the repository type authenticated for the organization
+func (r *Registry) Provider(ctx context.Context, repositoryType, organizationName string) (Provider, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[strings.ToLower(repositoryType)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s : %w", repositoryType, ErrProviderNotRegistered)
+	}
+	return factory(ctx, organizationName)
+}
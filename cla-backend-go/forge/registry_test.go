@@ -0,0 +1,35 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package forge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProvider struct {
+	Provider
+	organizationName string
+}
+
+func TestRegistryProvider(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("Gitea", func(ctx context.Context, organizationName string) (Provider, error) {
+		return &fakeProvider{organizationName: organizationName}, nil
+	})
+
+	assert.True(t, registry.IsRegistered("gitea"))
+	assert.False(t, registry.IsRegistered("github"))
+
+	provider, err := registry.Provider(context.Background(), "gitea", "sandbox")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "sandbox", provider.(*fakeProvider).organizationName)
+	}
+
+	_, err = registry.Provider(context.Background(), "github", "sandbox")
+	assert.True(t, errors.Is(err, ErrProviderNotRegistered))
+}
@@ -0,0 +1,279 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// contract types used by the gerrit agreement redirect endpoint
+const (
+	ContractTypeIndividual = "individual"
+	ContractTypeCorporate  = "corporate"
+)
+
+// enforcement decision reasons
+const (
+	ReasonAuthorized         = "contributor is authorized under a signed CLA"
+	ReasonUserNotFound       = "no EasyCLA user record found for the contributor"
+	ReasonNotSigned          = "contributor has no signed ICLA and is not authorized under a CCLA"
+	ReasonAffiliationMissing = "contributor is on the CCLA approval list but has not confirmed their affiliation"
+)
+
+// projectCacheTTL is how long the enforcement check keeps the project list of a gerrit host
+const projectCacheTTL = 5 * time.Minute
+
+// enforcement check errors
+var (
+	// ErrMissingContributor is returned when the check has neither an email nor a username
+	ErrMissingContributor = errors.New("email or username required")
+	// ErrGerritProjectNotFound is returned when the checked project is not a project of the gerrit instance
+	ErrGerritProjectNotFound = errors.New("project not found on the gerrit instance")
+)
+
+// UserRepo contains the user lookups used by the enforcement check
+type UserRepo interface {
+	GetUserByEmail(userEmail string) (*models.User, error)
+	GetUserByLFUserName(lfUserName string) (*models.User, error)
+}
+
+// SignatureService reports whether the user is authorized to contribute to the CLA group
+type SignatureService interface {
+	HasUserSigned(ctx context.Context, user *models.User, claGroupID string) (bool, bool, error)
+}
+
+// CLAGroupRepo loads the CLA group of the gerrit instance
+type CLAGroupRepo interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error)
+}
+
+// ContributorCheck is the contributor a gerrit hook asks about
+type ContributorCheck struct {
+	GerritID string
+	Project  string
+	Email    string
+	Username string
+}
+
+// EnforcementResult is the allow/deny decision of the enforcement check
+type EnforcementResult struct {
+	Allowed    bool
+	Reason     string
+	SignURL    string
+	ClaGroupID string
+	UserID     string
+}
+
+// EnforcementService checks the CLA status of the contributors uploading changes to a gerrit instance
+type EnforcementService interface {
+	ValidateHookToken(ctx context.Context, gerritID, token string) bool
+	CheckContributor(ctx context.Context, check *ContributorCheck) (*EnforcementResult, error)
+}
+
+type cachedProjects struct {
+	projects map[string]GerritRepoInfo
+	expires  time.Time
+}
+
+type enforcementService struct {
+	repo             Repository
+	claGroupRepo     CLAGroupRepo
+	usersRepo        UserRepo
+	signatureService SignatureService
+	claV1ApiURL      string
+
+	listRepos func(ctx context.Context, gerritHost string) (map[string]GerritRepoInfo, error)
+	now       func() time.Time
+
+	lock     sync.Mutex
+	projects map[string]cachedProjects
+}
+
+// NewEnforcementService creates a new gerrit enforcement service
+func NewEnforcementService(repo Repository, claGroupRepo CLAGroupRepo, usersRepo UserRepo, signatureService SignatureService, claV1ApiURL string) EnforcementService {
+	return &enforcementService{
+		repo:             repo,
+		claGroupRepo:     claGroupRepo,
+		usersRepo:        usersRepo,
+		signatureService: signatureService,
+		claV1ApiURL:      claV1ApiURL,
+		listRepos:        listGerritRepos,
+		now:              time.Now,
+		projects:         map[string]cachedProjects{},
+	}
+}
+
+// ValidateHookToken returns true if the token matches the hook token of the gerrit instance, a gerrit instance
+// without a hook token rejects every check
+func (s *enforcementService) ValidateHookToken(ctx context.Context, gerritID, token string) bool {
+	f := logrus.Fields{
+		"functionName":   "gerrits.ValidateHookToken",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritID":       gerritID,
+	}
+
+	tokenHash, err := s.repo.GetGerritHookTokenHash(ctx, gerritID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the hook token of the gerrit instance")
+		return false
+	}
+	return utils.TokenMatchesHash(token, tokenHash)
+}
+
+// CheckContributor returns whether the contributor is allowed to upload to the gerrit instance. A denied
+// contributor gets the URL of the agreement they need to sign.
+func (s *enforcementService) CheckContributor(ctx context.Context, check *ContributorCheck) (*EnforcementResult, error) {
+	f := logrus.Fields{
+		"functionName":   "gerrits.CheckContributor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritID":       check.GerritID,
+		"project":        check.Project,
+		"email":          check.Email,
+		"username":       check.Username,
+	}
+
+	if strings.TrimSpace(check.Email) == "" && strings.TrimSpace(check.Username) == "" {
+		return nil, ErrMissingContributor
+	}
+
+	gerrit, err := s.repo.GetGerrit(ctx, check.GerritID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load gerrit instance")
+		return nil, err
+	}
+	f["claGroupID"] = gerrit.ProjectID
+
+	known, err := s.isGerritProject(ctx, gerrit, check.Project)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the projects of the gerrit instance")
+		return nil, err
+	}
+	if !known {
+		log.WithFields(f).Warn("project is not a project of the gerrit instance, rejecting the check")
+		return nil, ErrGerritProjectNotFound
+	}
+
+	claGroupModel, err := s.claGroupRepo.GetCLAGroupByID(ctx, gerrit.ProjectID, false)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load CLA group of gerrit instance")
+		return nil, err
+	}
+
+	result := &EnforcementResult{
+		ClaGroupID: gerrit.ProjectID,
+	}
+
+	userModel, err := s.getContributor(check)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup contributor")
+		return nil, err
+	}
+	if userModel == nil {
+		log.WithFields(f).Debug("contributor not found, denying")
+		result.Reason = ReasonUserNotFound
+		result.SignURL = s.signURL(check.GerritID, defaultContractType(claGroupModel))
+		return result, nil
+	}
+	result.UserID = userModel.UserID
+
+	signed, affiliated, err := s.signatureService.HasUserSigned(ctx, userModel, gerrit.ProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to check contributor signatures")
+		return nil, err
+	}
+
+	switch {
+	case signed:
+		result.Allowed = true
+		result.Reason = ReasonAuthorized
+	case affiliated:
+		result.Reason = ReasonAffiliationMissing
+		result.SignURL = s.signURL(check.GerritID, ContractTypeCorporate)
+	default:
+		result.Reason = ReasonNotSigned
+		result.SignURL = s.signURL(check.GerritID, defaultContractType(claGroupModel))
+	}
+
+	log.WithFields(f).Debugf("contributor allowed: %t - %s", result.Allowed, result.Reason)
+	return result, nil
+}
+
+// isGerritProject returns true if the project is one of the projects of the gerrit instance. The project list of
+// the gerrit host is kept for projectCacheTTL, a new project may be rejected until the list is reloaded.
+func (s *enforcementService) isGerritProject(ctx context.Context, gerrit *models.Gerrit, project string) (bool, error) {
+	project = strings.TrimSpace(project)
+	if project == "" {
+		return false, nil
+	}
+
+	gerritHost, err := extractGerritHost(gerrit.GerritURL.String(), logrus.Fields{
+		"functionName":   "gerrits.isGerritProject",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritID":       gerrit.GerritID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	now := s.now()
+	s.lock.Lock()
+	cached, ok := s.projects[gerritHost]
+	s.lock.Unlock()
+	if !ok || !now.Before(cached.expires) {
+		projects, listErr := s.listRepos(ctx, gerritHost)
+		if listErr != nil {
+			return false, listErr
+		}
+		cached = cachedProjects{projects: projects, expires: now.Add(projectCacheTTL)}
+		s.lock.Lock()
+		s.projects[gerritHost] = cached
+		s.lock.Unlock()
+	}
+
+	_, found := cached.projects[project]
+	return found, nil
+}
+
+// getContributor looks up the user by email, then by LF username. Returns nil if the user does not exist.
+func (s *enforcementService) getContributor(check *ContributorCheck) (*models.User, error) {
+	if email := strings.TrimSpace(check.Email); email != "" {
+		userModel, err := s.usersRepo.GetUserByEmail(email)
+		if err != nil {
+			if _, ok := err.(*utils.UserNotFound); !ok {
+				return nil, err
+			}
+		}
+		if userModel != nil {
+			return userModel, nil
+		}
+	}
+
+	if username := strings.TrimSpace(check.Username); username != "" {
+		return s.usersRepo.GetUserByLFUserName(username)
+	}
+	return nil, nil
+}
+
+// signURL returns the gerrit agreement redirect URL of the contract type
+func (s *enforcementService) signURL(gerritID, contractType string) string {
+	return fmt.Sprintf("%s/v2/gerrit/%s/%s/agreementUrl.html", strings.TrimSuffix(s.claV1ApiURL, "/"), gerritID, contractType)
+}
+
+// defaultContractType is the individual agreement, unless the CLA group only accepts corporate agreements
+func defaultContractType(claGroupModel *models.ClaGroup) string {
+	if !claGroupModel.ProjectICLAEnabled && claGroupModel.ProjectCCLAEnabled {
+		return ContractTypeCorporate
+	}
+	return ContractTypeIndividual
+}
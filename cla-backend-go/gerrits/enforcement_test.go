@@ -0,0 +1,202 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrits
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testGerritID   = "3e0a6fa4-6a0c-4b16-b8ce-5d2f8c4d7a01"
+	testClaGroupID = "b1e86e26-d8c8-4fd8-9f8d-5c723d5dac9f"
+	testHookToken  = "2b1f0e7c9d4a"
+)
+
+// fakeGerritRepo returns the test gerrit instance, the embedded interface panics on any other call
+type fakeGerritRepo struct {
+	Repository
+}
+
+func (fakeGerritRepo) GetGerrit(ctx context.Context, gerritID string) (*models.Gerrit, error) {
+	if gerritID != testGerritID {
+		return nil, ErrGerritNotFound
+	}
+	return &models.Gerrit{GerritID: testGerritID, GerritURL: strfmt.URI("https://gerrit.example.org/r"), ProjectID: testClaGroupID}, nil
+}
+
+func (fakeGerritRepo) GetGerritHookTokenHash(ctx context.Context, gerritID string) (string, error) {
+	if gerritID != testGerritID {
+		return "", nil
+	}
+	return utils.HashToken(testHookToken), nil
+}
+
+// fakeListRepos counts the project listings of the test gerrit host
+type fakeListRepos struct {
+	calls int
+}
+
+func (l *fakeListRepos) listRepos(ctx context.Context, gerritHost string) (map[string]GerritRepoInfo, error) {
+	l.calls++
+	if gerritHost != "gerrit.example.org" {
+		return nil, errors.New("unknown gerrit host")
+	}
+	return map[string]GerritRepoInfo{"releng/builder": {ID: "releng%2Fbuilder", State: "ACTIVE"}}, nil
+}
+
+// newTestEnforcementService returns the enforcement service listing the projects with the fake
+func newTestEnforcementService(claGroupRepo CLAGroupRepo, usersRepo UserRepo, signatureService SignatureService, claV1ApiURL string, repos *fakeListRepos) EnforcementService {
+	service := NewEnforcementService(fakeGerritRepo{}, claGroupRepo, usersRepo, signatureService, claV1ApiURL).(*enforcementService)
+	service.listRepos = repos.listRepos
+	return service
+}
+
+type fakeCLAGroupRepo struct {
+	iclaEnabled bool
+}
+
+func (r fakeCLAGroupRepo) GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error) {
+	return &models.ClaGroup{ProjectID: claGroupID, ProjectICLAEnabled: r.iclaEnabled, ProjectCCLAEnabled: true}, nil
+}
+
+type fakeUserRepo struct {
+	byEmail    map[string]*models.User
+	byUsername map[string]*models.User
+}
+
+func (r fakeUserRepo) GetUserByEmail(userEmail string) (*models.User, error) {
+	if user, ok := r.byEmail[userEmail]; ok {
+		return user, nil
+	}
+	return nil, &utils.UserNotFound{UserEmail: userEmail}
+}
+
+func (r fakeUserRepo) GetUserByLFUserName(lfUserName string) (*models.User, error) {
+	return r.byUsername[lfUserName], nil
+}
+
+// fakeSignatureService reports the signed and affiliated state keyed by user id
+type fakeSignatureService struct {
+	signed     map[string]bool
+	affiliated map[string]bool
+}
+
+func (s fakeSignatureService) HasUserSigned(ctx context.Context, user *models.User, claGroupID string) (bool, bool, error) {
+	return s.signed[user.UserID], s.affiliated[user.UserID], nil
+}
+
+func TestCheckContributor(t *testing.T) {
+	usersRepo := fakeUserRepo{
+		byEmail:    map[string]*models.User{"alice@example.org": {UserID: "user-alice"}},
+		byUsername: map[string]*models.User{"bob": {UserID: "user-bob"}, "carol": {UserID: "user-carol"}},
+	}
+	signatureService := fakeSignatureService{
+		signed:     map[string]bool{"user-alice": true},
+		affiliated: map[string]bool{"user-carol": true},
+	}
+	signURL := func(contractType string) string {
+		return "https://api.example.org/v2/gerrit/" + testGerritID + "/" + contractType + "/agreementUrl.html"
+	}
+
+	testCases := []struct {
+		name        string
+		check       ContributorCheck
+		iclaEnabled bool
+		expected    EnforcementResult
+	}{
+		{
+			name:        "signed contributor by email",
+			check:       ContributorCheck{Email: "alice@example.org", Username: "unknown"},
+			iclaEnabled: true,
+			expected:    EnforcementResult{Allowed: true, Reason: ReasonAuthorized, ClaGroupID: testClaGroupID, UserID: "user-alice"},
+		},
+		{
+			name:        "unsigned contributor by username",
+			check:       ContributorCheck{Email: "bob@example.org", Username: "bob"},
+			iclaEnabled: true,
+			expected:    EnforcementResult{Reason: ReasonNotSigned, SignURL: signURL(ContractTypeIndividual), ClaGroupID: testClaGroupID, UserID: "user-bob"},
+		},
+		{
+			name:        "approved contributor without affiliation",
+			check:       ContributorCheck{Username: "carol"},
+			iclaEnabled: true,
+			expected:    EnforcementResult{Reason: ReasonAffiliationMissing, SignURL: signURL(ContractTypeCorporate), ClaGroupID: testClaGroupID, UserID: "user-carol"},
+		},
+		{
+			name:     "unknown contributor on a CCLA only CLA group",
+			check:    ContributorCheck{Email: "dave@example.org"},
+			expected: EnforcementResult{Reason: ReasonUserNotFound, SignURL: signURL(ContractTypeCorporate), ClaGroupID: testClaGroupID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newTestEnforcementService(fakeCLAGroupRepo{iclaEnabled: tc.iclaEnabled}, usersRepo, signatureService, "https://api.example.org/", &fakeListRepos{})
+			tc.check.GerritID = testGerritID
+			tc.check.Project = "releng/builder"
+
+			result, err := service.CheckContributor(context.Background(), &tc.check)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, *result)
+			}
+		})
+	}
+}
+
+func TestCheckContributorErrors(t *testing.T) {
+	service := newTestEnforcementService(fakeCLAGroupRepo{}, fakeUserRepo{}, fakeSignatureService{}, "", &fakeListRepos{})
+
+	_, err := service.CheckContributor(context.Background(), &ContributorCheck{GerritID: testGerritID, Project: "releng/builder"})
+	assert.Equal(t, ErrMissingContributor, err)
+
+	_, err = service.CheckContributor(context.Background(), &ContributorCheck{GerritID: "unknown", Project: "releng/builder", Email: "alice@example.org"})
+	assert.Equal(t, ErrGerritNotFound, err)
+
+	_, err = service.CheckContributor(context.Background(), &ContributorCheck{GerritID: testGerritID, Project: "other/project", Email: "alice@example.org"})
+	assert.Equal(t, ErrGerritProjectNotFound, err)
+
+	_, err = service.CheckContributor(context.Background(), &ContributorCheck{GerritID: testGerritID, Email: "alice@example.org"})
+	assert.Equal(t, ErrGerritProjectNotFound, err)
+}
+
+func TestIsGerritProjectCachesTheProjectList(t *testing.T) {
+	repos := &fakeListRepos{}
+	service := newTestEnforcementService(fakeCLAGroupRepo{}, fakeUserRepo{}, fakeSignatureService{}, "", repos).(*enforcementService)
+	now := time.Date(2021, 6, 11, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	gerrit, err := fakeGerritRepo{}.GetGerrit(context.Background(), testGerritID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, project := range []string{"releng/builder", "other/project", " releng/builder "} {
+		found, err := service.isGerritProject(context.Background(), gerrit, project)
+		assert.NoError(t, err)
+		assert.Equal(t, project != "other/project", found, project)
+	}
+	assert.Equal(t, 1, repos.calls)
+
+	now = now.Add(projectCacheTTL)
+	_, err = service.isGerritProject(context.Background(), gerrit, "releng/builder")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, repos.calls)
+}
+
+func TestValidateHookToken(t *testing.T) {
+	service := newTestEnforcementService(fakeCLAGroupRepo{}, fakeUserRepo{}, fakeSignatureService{}, "", &fakeListRepos{})
+	ctx := context.Background()
+
+	assert.True(t, service.ValidateHookToken(ctx, testGerritID, testHookToken))
+	assert.False(t, service.ValidateHookToken(ctx, testGerritID, "other"))
+	assert.False(t, service.ValidateHookToken(ctx, testGerritID, ""))
+	assert.False(t, service.ValidateHookToken(ctx, "unknown", testHookToken))
+}
@@ -41,6 +41,8 @@ type Repository interface {
 	GetClaGroupGerrits(ctx context.Context, projectID string, projectSFID *string) (*models.GerritList, error)
 	ExistsByName(ctx context.Context, gerritName string) ([]*models.Gerrit, error)
 	DeleteGerrit(ctx context.Context, gerritID string) error
+	UpdateGerritHookTokenHash(ctx context.Context, gerritID, tokenHash string) error
+	GetGerritHookTokenHash(ctx context.Context, gerritID string) (string, error)
 }
 
 // NewRepository create new Repository
@@ -345,6 +347,79 @@ func (repo *repo) DeleteGerrit(ctx context.Context, gerritID string) error {
 	return nil
 }
 
+// UpdateGerritHookTokenHash sets the hash of the token authenticating the enforcement hooks of the gerrit instance,
+// an empty hash revokes the token
+func (repo *repo) UpdateGerritHookTokenHash(ctx context.Context, gerritID, tokenHash string) error {
+	f := logrus.Fields{
+		"functionName":   "gerrits.UpdateGerritHookTokenHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritID":       gerritID,
+	}
+	_, now := utils.CurrentTime()
+
+	input := &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#ID": aws.String("gerrit_id"),
+			"#T":  aws.String("hook_token_hash"),
+			"#M":  aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"gerrit_id": {
+				S: aws.String(gerritID),
+			},
+		},
+		ConditionExpression: aws.String("attribute_exists(#ID)"),
+		UpdateExpression:    aws.String("SET #M = :m REMOVE #T"),
+	}
+	if tokenHash != "" {
+		input.ExpressionAttributeValues[":t"] = &dynamodb.AttributeValue{S: aws.String(tokenHash)}
+		input.UpdateExpression = aws.String("SET #T = :t, #M = :m")
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("error updating the hook token of gerrit repository : %s", gerritID)
+		return err
+	}
+
+	return nil
+}
+
+// GetGerritHookTokenHash returns the hash of the token authenticating the enforcement hooks of the gerrit instance,
+// empty if no token was created
+func (repo *repo) GetGerritHookTokenHash(ctx context.Context, gerritID string) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "gerrits.GetGerritHookTokenHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritID":       gerritID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"gerrit_id": {
+				S: aws.String(gerritID),
+			},
+		},
+		TableName:            aws.String(repo.tableName),
+		ProjectionExpression: aws.String("hook_token_hash"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("error getting the hook token of gerrit repository : %s", gerritID)
+		return "", err
+	}
+
+	if value, ok := result.Item["hook_token_hash"]; ok && value.S != nil {
+		return *value.S, nil
+	}
+	return "", nil
+}
+
 func (repo *repo) ExistsByName(ctx context.Context, gerritName string) ([]*models.Gerrit, error) {
 	f := logrus.Fields{
 		"functionName":   "gerrits.AddGerrit",
@@ -32,6 +32,8 @@ type Service interface {
 	GetGerritRepos(ctx context.Context, gerritName string) (*models.GerritRepoList, error)
 	DeleteClaGroupGerrits(ctx context.Context, claGroupID string) (int, error)
 	DeleteGerrit(ctx context.Context, gerritID string) error
+	CreateHookToken(ctx context.Context, gerritID string) (string, error)
+	RevokeHookToken(ctx context.Context, gerritID string) error
 }
 
 type service struct {
@@ -262,6 +264,25 @@ func (s service) DeleteGerrit(ctx context.Context, gerritID string) error {
 	return s.repo.DeleteGerrit(ctx, gerritID)
 }
 
+// CreateHookToken creates a new token authenticating the enforcement hooks of the gerrit instance, replacing the
+// previous token. Only the hash of the token is stored, the token is returned once.
+func (s service) CreateHookToken(ctx context.Context, gerritID string) (string, error) {
+	token, err := utils.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.repo.UpdateGerritHookTokenHash(ctx, gerritID, utils.HashToken(token)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeHookToken revokes the token authenticating the enforcement hooks of the gerrit instance
+func (s service) RevokeHookToken(ctx context.Context, gerritID string) error {
+	return s.repo.UpdateGerritHookTokenHash(ctx, gerritID, "")
+}
+
 // convertModel is a helper function to create a GerritRepoList response model
 func convertModel(responseModel map[string]GerritRepoInfo, serverInfo *ServerInfo) *models.GerritRepoList {
 	var gerritRepos []*models.GerritRepo
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/shurcooL/githubv4"
@@ -77,7 +79,39 @@ func NewGithubAppClient(installationID int64) (*github.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return github.NewClient(&http.Client{Transport: itr}), nil
+	if getAPIBaseURL() == "" {
+		return github.NewClient(&http.Client{Transport: itr}), nil
+	}
+
+	baseURL, err := url.Parse(strings.TrimSuffix(getAPIBaseURL(), "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+	itr.BaseURL = strings.TrimSuffix(baseURL.String(), "/")
+	client := github.NewClient(&http.Client{Transport: itr})
+	client.BaseURL = baseURL
+	return client, nil
+}
+
+// newGithubAppJWTClient creates a new github client authenticated as the GitHub App itself, rather than one of its
+// installations
+func newGithubAppJWTClient() (*github.Client, error) {
+	atr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, int64(getGithubAppID()), []byte(getGithubAppPrivateKey()))
+	if err != nil {
+		return nil, err
+	}
+	if getAPIBaseURL() == "" {
+		return github.NewClient(&http.Client{Transport: atr}), nil
+	}
+
+	baseURL, err := url.Parse(strings.TrimSuffix(getAPIBaseURL(), "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+	atr.BaseURL = strings.TrimSuffix(baseURL.String(), "/")
+	client := github.NewClient(&http.Client{Transport: atr})
+	client.BaseURL = baseURL
+	return client, nil
 }
 
 // NewGithubV4AppClient creates a new github v4 client from the supplied installationID
@@ -0,0 +1,200 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
+	"github.com/google/go-github/v33/github"
+)
+
+// InstallationIDLookup resolves the GitHub App installation ID of a GitHub organization
+type InstallationIDLookup func(ctx context.Context, organizationName string) (int64, error)
+
+// forgeProvider implements forge.Provider on top of the GitHub App installation of an organization
+type forgeProvider struct {
+	WebhookParser
+	client           *github.Client
+	branchProtection *BranchProtectionRepository
+}
+
+// NewProviderFactory returns the forge.ProviderFactory creating GitHub providers for the organization installation
+func NewProviderFactory(lookup InstallationIDLookup) forge.ProviderFactory {
+	return func(ctx context.Context, organizationName string) (forge.Provider, error) {
+		installationID, err := lookup(ctx, organizationName)
+		if err != nil {
+			return nil, err
+		}
+		if installationID == 0 {
+			return nil, fmt.Errorf("github organization : %s has no installation id", organizationName)
+		}
+		return NewForgeProvider(installationID)
+	}
+}
+
+// NewForgeProvider creates the GitHub forge.Provider of the installation
+func NewForgeProvider(installationID int64) (forge.Provider, error) {
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		return nil, err
+	}
+	return &forgeProvider{
+		client:           client,
+		branchProtection: NewBranchProtectionRepository(client.Repositories, EnableNonBlockingLimiter()),
+	}, nil
+}
+
+// WebhookParser implements forge.WebhookParser for the GitHub webhook payloads
+type WebhookParser struct{}
+
+// ParseWebhook parses the GitHub webhook payload, the go-github event is available as the Raw field
+func (WebhookParser) ParseWebhook(eventType string, payload []byte) (*forge.Event, error) {
+	event, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequestEvent, ok := event.(*github.PullRequestEvent)
+	if !ok {
+		return &forge.Event{Type: forge.EventTypeOther, Raw: event}, nil
+	}
+	return &forge.Event{
+		Type:              forge.EventTypePullRequest,
+		Action:            pullRequestEvent.GetAction(),
+		Repository:        toForgeRepository(pullRequestEvent.GetRepo()),
+		PullRequestNumber: pullRequestEvent.GetNumber(),
+		HeadSHA:           pullRequestEvent.GetPullRequest().GetHead().GetSHA(),
+		Raw:               event,
+	}, nil
+}
+
+func (p *forgeProvider) ListRepositories(ctx context.Context, organizationName string) ([]*forge.Repository, error) {
+	var repositories []*forge.Repository
+	opts := &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		repos, resp, err := p.client.Repositories.ListByOrg(ctx, organizationName, opts)
+		if err != nil {
+			_, wErr := checkAndWrapForKnownErrors(resp, err)
+			return nil, wErr
+		}
+		for _, repo := range repos {
+			repositories = append(repositories, toForgeRepository(repo))
+		}
+		if resp.NextPage == 0 {
+			return repositories, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (p *forgeProvider) GetRepository(ctx context.Context, externalID string) (*forge.Repository, error) {
+	id, err := strconv.ParseInt(externalID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid github repository id : %s", externalID)
+	}
+	repo, resp, err := p.client.Repositories.GetByID(ctx, id)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, forge.ErrRepositoryNotFound
+		}
+		_, wErr := checkAndWrapForKnownErrors(resp, err)
+		return nil, wErr
+	}
+	return toForgeRepository(repo), nil
+}
+
+func (p *forgeProvider) GetRepositoryByName(ctx context.Context, organizationName, repositoryName string) (*forge.Repository, error) {
+	repositoryName = CleanGithubRepoName(repositoryName)
+	owner, err := p.branchProtection.GetOwnerName(ctx, organizationName, repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	if owner == "" {
+		return nil, forge.ErrRepositoryNotFound
+	}
+
+	repo, resp, err := p.client.Repositories.Get(ctx, owner, repositoryName)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, forge.ErrRepositoryNotFound
+		}
+		_, wErr := checkAndWrapForKnownErrors(resp, err)
+		return nil, wErr
+	}
+	return toForgeRepository(repo), nil
+}
+
+func (p *forgeProvider) GetBranchProtection(ctx context.Context, owner, repositoryName, branchName string) (*forge.BranchProtection, error) {
+	protection, err := p.branchProtection.GetProtectedBranch(ctx, owner, repositoryName, branchName)
+	if err != nil {
+		if errors.Is(err, ErrBranchNotProtected) {
+			return &forge.BranchProtection{}, nil
+		}
+		return nil, err
+	}
+	return toForgeBranchProtection(protection), nil
+}
+
+func (p *forgeProvider) SetRequiredChecks(ctx context.Context, owner, repositoryName, branchName string, enforceAdmin bool, enableChecks, disableChecks []string) error {
+	return p.branchProtection.EnableBranchProtection(ctx, owner, repositoryName, branchName, enforceAdmin, enableChecks, disableChecks)
+}
+
+func (p *forgeProvider) PostStatus(ctx context.Context, owner, repositoryName, sha string, status *forge.CommitStatus) error {
+	return createCommitStatus(ctx, p.client, owner, repositoryName, sha, status)
+}
+
+// createCommitStatus posts the forge commit status on the GitHub commit
+func createCommitStatus(ctx context.Context, client *github.Client, owner, repo, sha string, status *forge.CommitStatus) error {
+	repoStatus := &github.RepoStatus{
+		State:       github.String(status.State),
+		Description: github.String(status.Description),
+		Context:     github.String(status.Context),
+	}
+	if status.TargetURL != "" {
+		repoStatus.TargetURL = github.String(status.TargetURL)
+	}
+
+	_, resp, err := client.Repositories.CreateStatus(ctx, owner, repo, sha, repoStatus)
+	if err != nil {
+		_, wErr := checkAndWrapForKnownErrors(resp, err)
+		return wErr
+	}
+	return nil
+}
+
+func toForgeRepository(repo *github.Repository) *forge.Repository {
+	if repo == nil {
+		return nil
+	}
+	result := &forge.Repository{
+		Name:          repo.GetName(),
+		FullName:      repo.GetFullName(),
+		Owner:         repo.GetOwner().GetLogin(),
+		URL:           repo.GetHTMLURL(),
+		DefaultBranch: repo.GetDefaultBranch(),
+	}
+	if repo.GetID() != 0 {
+		result.ExternalID = strconv.FormatInt(repo.GetID(), 10)
+	}
+	if result.DefaultBranch == "" {
+		result.DefaultBranch = defaultBranchName
+	}
+	return result
+}
+
+func toForgeBranchProtection(protection *github.Protection) *forge.BranchProtection {
+	result := &forge.BranchProtection{
+		Enabled:      true,
+		EnforceAdmin: IsEnforceAdminEnabled(protection),
+	}
+	if protection.RequiredStatusChecks != nil {
+		result.RequiredChecks = append(result.RequiredChecks, protection.RequiredStatusChecks.Contexts...)
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookParserPullRequest(t *testing.T) {
+	payload := []byte(`{
+		"action": "synchronize",
+		"number": 7,
+		"pull_request": {"head": {"sha": "sha2"}},
+		"repository": {"id": 1001, "name": "hello", "full_name": "octo/hello", "owner": {"login": "octo"}}
+	}`)
+
+	event, err := WebhookParser{}.ParseWebhook("pull_request", payload)
+	if assert.NoError(t, err) {
+		assert.Equal(t, forge.EventTypePullRequest, event.Type)
+		assert.Equal(t, "synchronize", event.Action)
+		assert.Equal(t, 7, event.PullRequestNumber)
+		assert.Equal(t, "sha2", event.HeadSHA)
+		assert.Equal(t, &forge.Repository{ExternalID: "1001", Name: "hello", FullName: "octo/hello", Owner: "octo", DefaultBranch: defaultBranchName}, event.Repository)
+		assert.IsType(t, &github.PullRequestEvent{}, event.Raw)
+	}
+}
+
+func TestWebhookParserOtherEvent(t *testing.T) {
+	event, err := WebhookParser{}.ParseWebhook("repository", []byte(`{"action": "created"}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, forge.EventTypeOther, event.Type)
+		assert.IsType(t, &github.RepositoryEvent{}, event.Raw)
+	}
+
+	_, err = WebhookParser{}.ParseWebhook("unknown_event", []byte(`{}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,411 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+	"sync"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/google/go-github/v33/github"
+)
+
+// constants
+const (
+	// StatusContext is the name of the commit status posted by EasyCLA - it matches the required branch protection check
+	StatusContext = "EasyCLA"
+
+	// StatusDescriptionSigned is the commit status description when all the authors are authorized
+	StatusDescriptionSigned = "EasyCLA check passed. You are authorized to contribute."
+	// StatusDescriptionMissing is the commit status description when one or more authors are not authorized
+	StatusDescriptionMissing = "Missing CLA Authorization."
+
+	supportURL    = "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
+	githubHelpURL = "https://help.github.com/en/github/committing-changes-to-your-project/why-are-my-commits-linked-to-the-wrong-user"
+
+	commentSignedText        = "The committers listed above are authorized under a signed CLA."
+	commentMissingText       = "is not authorized under a signed CLA"
+	commentAffiliationText   = "they must confirm their affiliation"
+	commentMissingUserIDText = "is missing the User's ID"
+)
+
+// UserCommitSummary data model holding the commit author details of a pull request commit
+type UserCommitSummary struct {
+	SHA          string
+	CommitAuthor *github.User
+	AuthorName   string
+	AuthorEmail  string
+	Affiliated   bool
+	Authorized   bool
+}
+
+// GetCommitAuthorID returns the GitHub user ID of the commit author, or an empty string if not available
+func (u UserCommitSummary) GetCommitAuthorID() string {
+	if u.CommitAuthor != nil && u.CommitAuthor.ID != nil {
+		return fmt.Sprintf("%d", *u.CommitAuthor.ID)
+	}
+	return ""
+}
+
+// GetCommitAuthorUsername returns the GitHub login of the commit author, or an empty string if not available
+func (u UserCommitSummary) GetCommitAuthorUsername() string {
+	if u.CommitAuthor != nil && u.CommitAuthor.Login != nil {
+		return *u.CommitAuthor.Login
+	}
+	return ""
+}
+
+// IsValid returns true if the commit is linked to a GitHub user
+func (u UserCommitSummary) IsValid() bool {
+	return u.GetCommitAuthorID() != ""
+}
+
+// GetDisplayText returns the author name used in the pull request comment
+func (u UserCommitSummary) GetDisplayText() string {
+	if u.GetCommitAuthorUsername() != "" {
+		return u.GetCommitAuthorUsername()
+	}
+	if u.AuthorName != "" {
+		return u.AuthorName
+	}
+	return "Unknown"
+}
+
+// GetPullRequestCommitAuthors returns the commit author summary for each commit of the pull request along with
+// the SHA of the latest commit
+func GetPullRequestCommitAuthors(ctx context.Context, installationID int64, owner, repo string, pullRequestID int) ([]*UserCommitSummary, string, error) {
+	f := logrus.Fields{
+		"functionName":   "GetPullRequestCommitAuthors",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"installationID": installationID,
+		"owner":          owner,
+		"repo":           repo,
+		"pullRequestID":  pullRequestID,
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create github app client")
+		return nil, "", err
+	}
+
+	var userCommitSummary []*UserCommitSummary
+	var latestSHA string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		commits, resp, listErr := client.PullRequests.ListCommits(ctx, owner, repo, pullRequestID, opts)
+		if listErr != nil {
+			log.WithFields(f).WithError(listErr).Warn("unable to list pull request commits")
+			_, wErr := checkAndWrapForKnownErrors(resp, listErr)
+			return nil, "", wErr
+		}
+
+		for _, commit := range commits {
+			summary := &UserCommitSummary{
+				SHA:          commit.GetSHA(),
+				CommitAuthor: commit.Author,
+			}
+			if commit.Commit != nil && commit.Commit.Author != nil {
+				summary.AuthorName = commit.Commit.Author.GetName()
+				summary.AuthorEmail = commit.Commit.Author.GetEmail()
+			}
+			userCommitSummary = append(userCommitSummary, summary)
+			latestSHA = commit.GetSHA()
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	log.WithFields(f).Debugf("found %d commits, latest SHA: %s", len(userCommitSummary), latestSHA)
+	return userCommitSummary, latestSHA, nil
+}
+
+// GetFullSignURL returns the URL the contributor follows to start the signing workflow for the pull request
+func GetFullSignURL(apiBaseURL string, installationID int64, repositoryID int64, pullRequestID int, claGroupVersion string) string {
+	version := "1"
+	if claGroupVersion == utils.V2 {
+		version = "2"
+	}
+	return fmt.Sprintf("%s/v2/repository-provider/github/sign/%d/%d/%d/#/?version=%s",
+		strings.TrimSuffix(apiBaseURL, "/"), installationID, repositoryID, pullRequestID, version)
+}
+
+// AssembleCLAComment builds the pull request comment body listing the authorized and missing commit authors
+func AssembleCLAComment(signURL string, signed, missing []*UserCommitSummary) string {
+	var sb strings.Builder
+
+	if len(signed) > 0 {
+		sb.WriteString("<ul>")
+		for _, author := range groupByAuthor(signed) {
+			sb.WriteString(fmt.Sprintf("<li>:white_check_mark: %s (%s)</li>", escapeCommentText(author.name), strings.Join(author.commits, ", ")))
+		}
+		sb.WriteString("</ul>")
+	}
+
+	if len(missing) == 0 {
+		return sb.String() + commentSignedText
+	}
+
+	sb.WriteString("<ul>")
+	for _, author := range groupByAuthor(missing) {
+		commits := strings.Join(author.commits, ", ")
+		switch {
+		case !author.valid:
+			sb.WriteString(fmt.Sprintf("<li>:x: The commit (%s) %s, preventing the EasyCLA check. "+
+				"<a href='%s' target='_blank'>Consult GitHub Help</a> to resolve. "+
+				"For further assistance with EasyCLA, <a href='%s' target='_blank'>please submit a support request ticket</a>.</li>",
+				commits, commentMissingUserIDText, githubHelpURL, supportURL))
+		case author.affiliated:
+			sb.WriteString(fmt.Sprintf("<li>%s (%s) is authorized, but %s with their company. "+
+				"Start the authorization process <a href='%s' target='_blank'>by clicking here</a>, click \"Corporate\", "+
+				"select the appropriate company from the list, then confirm your affiliation on the page that appears. "+
+				"For further assistance with EasyCLA, <a href='%s' target='_blank'>please submit a support request ticket</a>.</li>",
+				escapeCommentText(author.name), commits, commentAffiliationText, signURL, supportURL))
+		default:
+			sb.WriteString(fmt.Sprintf("<li><a href='%s' target='_blank'>:x:</a> - %s The commit (%s) %s. "+
+				"<a href='%s' target='_blank'>Please click here to be authorized</a>. "+
+				"For further assistance with EasyCLA, <a href='%s' target='_blank'>please submit a support request ticket</a>.</li>",
+				signURL, escapeCommentText(author.name), commits, commentMissingText, signURL, supportURL))
+		}
+	}
+	sb.WriteString("</ul>")
+
+	return sb.String()
+}
+
+// escapeCommentText escapes the user-controlled text of the comment, such as the commit author name, like the
+// html/template email templates do so it can't inject markup or links into the comment, the line breaks are
+// collapsed as a blank line would end the HTML block and render the rest of the text as markdown
+func escapeCommentText(text string) string {
+	return template.HTMLEscapeString(strings.Join(strings.Fields(text), " "))
+}
+
+// isCLAComment returns true if the comment body was generated by AssembleCLAComment
+func isCLAComment(body string) bool {
+	return strings.Contains(body, commentSignedText) ||
+		strings.Contains(body, commentMissingText) ||
+		strings.Contains(body, commentAffiliationText) ||
+		strings.Contains(body, commentMissingUserIDText)
+}
+
+// isAppComment returns true if the comment was posted by the bot user of the GitHub App
+func isAppComment(comment *github.IssueComment, botLogin string) bool {
+	user := comment.GetUser()
+	return user.GetType() == "Bot" && botLogin != "" && strings.EqualFold(user.GetLogin(), botLogin)
+}
+
+// appBotLogin caches the login of the bot user of the GitHub App, which does not change
+var appBotLogin = struct {
+	sync.Mutex
+	appID int
+	login string
+}{}
+
+// getAppBotLogin returns the login of the bot user the GitHub App comments as, the app slug followed by [bot]
+func getAppBotLogin(ctx context.Context) (string, error) {
+	appBotLogin.Lock()
+	defer appBotLogin.Unlock()
+	if appBotLogin.login != "" && appBotLogin.appID == getGithubAppID() {
+		return appBotLogin.login, nil
+	}
+
+	client, err := newGithubAppJWTClient()
+	if err != nil {
+		return "", err
+	}
+	app, resp, err := client.Apps.Get(ctx, "")
+	if err != nil {
+		_, wErr := checkAndWrapForKnownErrors(resp, err)
+		return "", wErr
+	}
+	if app.GetSlug() == "" {
+		return "", errors.New("github app has no slug")
+	}
+	appBotLogin.appID = getGithubAppID()
+	appBotLogin.login = app.GetSlug() + "[bot]"
+	return appBotLogin.login, nil
+}
+
+type authorCommits struct {
+	name       string
+	valid      bool
+	affiliated bool
+	commits    []string
+}
+
+// groupByAuthor groups the commit summaries by author, preserving the commit order
+func groupByAuthor(summaries []*UserCommitSummary) []*authorCommits {
+	var authors []*authorCommits
+	authorMap := map[string]*authorCommits{}
+	for _, summary := range summaries {
+		key := summary.GetDisplayText()
+		if !summary.IsValid() {
+			key = ""
+		}
+		author, ok := authorMap[key]
+		if !ok {
+			author = &authorCommits{
+				name:       summary.GetDisplayText(),
+				valid:      summary.IsValid(),
+				affiliated: summary.Affiliated,
+			}
+			authorMap[key] = author
+			authors = append(authors, author)
+		}
+		author.commits = append(author.commits, summary.SHA)
+	}
+	return authors
+}
+
+// UpdatePullRequestComment creates or updates the EasyCLA pull request comment. A comment is only created when one or
+// more authors are missing authorization, an existing comment is always updated with the latest result.
+func UpdatePullRequestComment(ctx context.Context, installationID int64, owner, repo string, pullRequestID int, signed, missing []*UserCommitSummary, signURL string) error {
+	f := logrus.Fields{
+		"functionName":   "UpdatePullRequestComment",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"installationID": installationID,
+		"owner":          owner,
+		"repo":           repo,
+		"pullRequestID":  pullRequestID,
+		"signed":         len(signed),
+		"missing":        len(missing),
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create github app client")
+		return err
+	}
+
+	comment := AssembleCLAComment(signURL, signed, missing)
+	existingComment, err := getCLAComment(ctx, client, owner, repo, pullRequestID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list pull request comments")
+		return err
+	}
+
+	if existingComment != nil {
+		log.WithFields(f).Debugf("updating existing comment: %d", existingComment.GetID())
+		_, resp, editErr := client.Issues.EditComment(ctx, owner, repo, existingComment.GetID(), &github.IssueComment{Body: &comment})
+		if editErr != nil {
+			log.WithFields(f).WithError(editErr).Warn("unable to update pull request comment")
+			_, wErr := checkAndWrapForKnownErrors(resp, editErr)
+			return wErr
+		}
+	} else if len(missing) > 0 {
+		log.WithFields(f).Debug("creating pull request comment")
+		_, resp, createErr := client.Issues.CreateComment(ctx, owner, repo, pullRequestID, &github.IssueComment{Body: &comment})
+		if createErr != nil {
+			log.WithFields(f).WithError(createErr).Warn("unable to create pull request comment")
+			_, wErr := checkAndWrapForKnownErrors(resp, createErr)
+			return wErr
+		}
+	}
+
+	return nil
+}
+
+// getCLAComment returns the previous EasyCLA comment on the pull request, if any
+func getCLAComment(ctx context.Context, client *github.Client, owner, repo string, pullRequestID int) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var botLogin string
+	for {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, pullRequestID, opts)
+		if err != nil {
+			_, wErr := checkAndWrapForKnownErrors(resp, err)
+			return nil, wErr
+		}
+		for _, comment := range comments {
+			if !isCLAComment(comment.GetBody()) {
+				continue
+			}
+			// a contributor or maintainer comment may quote the CLA comment, only the comment of the app is updated
+			if botLogin == "" {
+				botLogin, err = getAppBotLogin(ctx)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if isAppComment(comment, botLogin) {
+				return comment, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetOpenPullRequests returns the open pull requests of the repository
+func GetOpenPullRequests(ctx context.Context, installationID int64, owner, repo string) ([]*github.PullRequest, error) {
+	f := logrus.Fields{
+		"functionName":   "GetOpenPullRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"installationID": installationID,
+		"owner":          owner,
+		"repo":           repo,
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create github app client")
+		return nil, err
+	}
+
+	var pullRequests []*github.PullRequest
+	opts := &github.PullRequestListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		pulls, resp, listErr := client.PullRequests.List(ctx, owner, repo, opts)
+		if listErr != nil {
+			log.WithFields(f).WithError(listErr).Warn("unable to list open pull requests")
+			_, wErr := checkAndWrapForKnownErrors(resp, listErr)
+			return nil, wErr
+		}
+		pullRequests = append(pullRequests, pulls...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return pullRequests, nil
+}
+
+// GetCommitStatusState returns the state of the EasyCLA commit status of the specified ref, or an empty string
+// if EasyCLA has not posted a status yet
+func GetCommitStatusState(ctx context.Context, installationID int64, owner, repo, ref string) (string, error) {
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		return "", err
+	}
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		combinedStatus, resp, statusErr := client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+		if statusErr != nil {
+			_, wErr := checkAndWrapForKnownErrors(resp, statusErr)
+			return "", wErr
+		}
+		for _, status := range combinedStatus.Statuses {
+			if status.GetContext() == StatusContext {
+				return status.GetState(), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return "", nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssembleCLACommentEscapesAuthorName(t *testing.T) {
+	author := &github.User{ID: github.Int64(1), Login: github.String("")}
+	missing := []*UserCommitSummary{{SHA: "abc1234", CommitAuthor: author, AuthorName: "<a href='https://example.org'>evil</a>\n\n[sign](https://example.org)"}}
+
+	comment := AssembleCLAComment("https://example.org/sign", nil, missing)
+	assert.Contains(t, comment, "&lt;a href=&#39;https://example.org&#39;&gt;evil&lt;/a&gt; [sign](https://example.org)")
+	assert.NotContains(t, comment, "<a href='https://example.org'>")
+	assert.NotContains(t, comment, "\n")
+}
+
+func TestGetCLACommentSkipsCommentsQuotingTheCLAComment(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating app key failed : %v", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	quoted := "> " + commentMissingText + "\nWhy is my commit not authorized?"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&github.App{Slug: github.String("easycla")}) // nolint
+	})
+	mux.HandleFunc("/repos/octo/hello/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*github.IssueComment{ // nolint
+			{ID: github.Int64(10), Body: github.String(quoted), User: &github.User{Login: github.String("alice"), Type: github.String("User")}},
+			{ID: github.Int64(11), Body: github.String(quoted), User: &github.User{Login: github.String("other-app[bot]"), Type: github.String("Bot")}},
+			{ID: github.Int64(12), Body: github.String(commentMissingText), User: &github.User{Login: github.String("easycla[bot]"), Type: github.String("Bot")}},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	Init(1, string(keyPEM), "")
+	SetAPIBaseURL(server.URL)
+	defer SetAPIBaseURL("")
+
+	client, err := newGithubAppJWTClient()
+	if !assert.NoError(t, err) {
+		return
+	}
+	comment, err := getCLAComment(context.Background(), client, "octo", "hello", 1)
+	if assert.NoError(t, err) && assert.NotNil(t, comment) {
+		assert.Equal(t, int64(12), comment.GetID())
+	}
+}
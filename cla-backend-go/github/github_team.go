@@ -0,0 +1,153 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/google/go-github/v33/github"
+	"github.com/sirupsen/logrus"
+)
+
+// teamMembershipCacheTTL is the duration the team memberships are cached for
+const teamMembershipCacheTTL = 10 * time.Minute
+
+var installationIDLookup InstallationIDLookup
+var teamMemberships = newTeamMembershipCache(teamMembershipCacheTTL)
+
+// SetInstallationIDLookup sets the lookup of the GitHub App installation of the organizations, the installation
+// client is used to resolve the team memberships
+func SetInstallationIDLookup(lookup InstallationIDLookup) {
+	installationIDLookup = lookup
+}
+
+// Teams is the subset of the go-github TeamsService used to resolve the team memberships
+type Teams interface {
+	GetTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Membership, *github.Response, error)
+}
+
+// ParseTeamID splits the organization/team-slug team identifier of the approval lists
+func ParseTeamID(teamID string) (string, string, error) {
+	if msg, valid := utils.ValidGitHubTeam(teamID); !valid {
+		return "", "", fmt.Errorf("%s", msg)
+	}
+	parts := strings.Split(strings.TrimSpace(teamID), "/")
+	return parts[0], parts[1], nil
+}
+
+// IsUserTeamMember returns true if the GitHub user is an active member of the organization team. The membership is
+// resolved with the GitHub App installation of the organization and cached for a few minutes.
+func IsUserTeamMember(ctx context.Context, organizationName, teamSlug, githubUsername string) (bool, error) {
+	f := logrus.Fields{
+		"functionName":     "IsUserTeamMember",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"teamSlug":         teamSlug,
+		"githubUsername":   githubUsername,
+	}
+
+	if member, found := teamMemberships.get(organizationName, teamSlug, githubUsername, time.Now()); found {
+		return member, nil
+	}
+
+	if installationIDLookup == nil {
+		return false, fmt.Errorf("unable to resolve the members of github team : %s/%s - no installation lookup", organizationName, teamSlug)
+	}
+	installationID, err := installationIDLookup(ctx, organizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the github app installation of the organization")
+		return false, err
+	}
+	if installationID == 0 {
+		return false, fmt.Errorf("github organization : %s has no installation id", organizationName)
+	}
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the github app client")
+		return false, err
+	}
+
+	member, err := getTeamMembership(ctx, client.Teams, organizationName, teamSlug, githubUsername)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the github team membership")
+		return false, err
+	}
+	teamMemberships.set(organizationName, teamSlug, githubUsername, member, time.Now())
+	return member, nil
+}
+
+// getTeamMembership returns true if the membership of the user in the team is active, the pending invitations
+// are not members yet
+func getTeamMembership(ctx context.Context, teams Teams, organizationName, teamSlug, githubUsername string) (bool, error) {
+	membership, resp, err := teams.GetTeamMembershipBySlug(ctx, organizationName, teamSlug, githubUsername)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		_, wErr := checkAndWrapForKnownErrors(resp, err)
+		return false, wErr
+	}
+	return membership.GetState() == "active", nil
+}
+
+// teamMembershipCache caches the team memberships of the users, the entries expire after the TTL
+type teamMembershipCache struct {
+	sync.Mutex
+	ttl     time.Duration
+	entries map[string]teamMembershipCacheEntry
+}
+
+type teamMembershipCacheEntry struct {
+	member  bool
+	expires time.Time
+}
+
+func newTeamMembershipCache(ttl time.Duration) *teamMembershipCache {
+	return &teamMembershipCache{
+		ttl:     ttl,
+		entries: map[string]teamMembershipCacheEntry{},
+	}
+}
+
+// teamMembershipKey returns the cache key of the membership, the GitHub names are case insensitive
+func teamMembershipKey(organizationName, teamSlug, githubUsername string) string {
+	return strings.ToLower(organizationName + "/" + teamSlug + "/" + githubUsername)
+}
+
+func (c *teamMembershipCache) get(organizationName, teamSlug, githubUsername string, now time.Time) (bool, bool) {
+	c.Lock()
+	defer c.Unlock()
+	key := teamMembershipKey(organizationName, teamSlug, githubUsername)
+	entry, found := c.entries[key]
+	if !found {
+		return false, false
+	}
+	if now.After(entry.expires) {
+		delete(c.entries, key)
+		return false, false
+	}
+	return entry.member, true
+}
+
+func (c *teamMembershipCache) set(organizationName, teamSlug, githubUsername string, member bool, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	// drop the expired entries so the cache does not grow unbounded in the long running server
+	for key, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[teamMembershipKey(organizationName, teamSlug, githubUsername)] = teamMembershipCacheEntry{
+		member:  member,
+		expires: now.Add(c.ttl),
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTeams serves the team memberships keyed by org/slug/user
+type fakeTeams struct {
+	memberships map[string]string
+}
+
+func (f fakeTeams) GetTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Membership, *github.Response, error) {
+	state, ok := f.memberships[org+"/"+slug+"/"+user]
+	if !ok {
+		resp := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+		return nil, resp, errors.New("not found")
+	}
+	return &github.Membership{State: github.String(state)}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+}
+
+func TestGetTeamMembership(t *testing.T) {
+	ctx := context.Background()
+	teams := fakeTeams{memberships: map[string]string{
+		"cncf/maintainers/octocat": "active",
+		"cncf/maintainers/invited": "pending",
+	}}
+
+	member, err := getTeamMembership(ctx, teams, "cncf", "maintainers", "octocat")
+	assert.NoError(t, err)
+	assert.True(t, member)
+
+	member, err = getTeamMembership(ctx, teams, "cncf", "maintainers", "invited")
+	assert.NoError(t, err)
+	assert.False(t, member, "a pending invitation is not a membership")
+
+	member, err = getTeamMembership(ctx, teams, "cncf", "maintainers", "stranger")
+	assert.NoError(t, err, "not a member")
+	assert.False(t, member)
+}
+
+func TestTeamMembershipCache(t *testing.T) {
+	now := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+	cache := newTeamMembershipCache(10 * time.Minute)
+
+	_, found := cache.get("cncf", "maintainers", "octocat", now)
+	assert.False(t, found)
+
+	cache.set("cncf", "maintainers", "octocat", true, now)
+	member, found := cache.get("CNCF", "maintainers", "OctoCat", now.Add(5*time.Minute))
+	assert.True(t, found, "the GitHub names are case insensitive")
+	assert.True(t, member)
+
+	_, found = cache.get("cncf", "maintainers", "octocat", now.Add(11*time.Minute))
+	assert.False(t, found, "expired")
+	assert.Empty(t, cache.entries)
+}
+
+func TestParseTeamID(t *testing.T) {
+	org, slug, err := ParseTeamID(" cncf/sig-release ")
+	assert.NoError(t, err)
+	assert.Equal(t, "cncf", org)
+	assert.Equal(t, "sig-release", slug)
+
+	_, _, err = ParseTeamID("cncf")
+	assert.Error(t, err)
+}
@@ -23,3 +23,53 @@ func GetUserDetails(user string) (*github.User, error) {
 	}
 	return userResp, nil
 }
+
+// GetUserVerifiedEmails returns the emails GitHub verified for the user of the OAuth access token
+func GetUserVerifiedEmails(ctx context.Context, accessToken string) ([]string, error) {
+	client := NewGithubOauthClientWithAccessToken(accessToken)
+	var verifiedEmails []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		emails, resp, err := client.Users.ListEmails(ctx, opts)
+		if err != nil {
+			logging.Warnf("GetUserVerifiedEmails failed, error = %s\n", err.Error())
+			_, wErr := checkAndWrapForKnownErrors(resp, err)
+			return nil, wErr
+		}
+		for _, email := range emails {
+			if email.GetVerified() && email.GetEmail() != "" {
+				verifiedEmails = append(verifiedEmails, email.GetEmail())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return verifiedEmails, nil
+}
+
+// GetUserOrganizations returns the names of the public github organizations the user is a member of
+func GetUserOrganizations(ctx context.Context, user string) ([]string, error) {
+	client := NewGithubOauthClient()
+	var orgNames []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		orgs, resp, err := client.Organizations.List(ctx, user, opts)
+		if err != nil {
+			logging.Warnf("GetUserOrganizations failed for user : %s, error = %s\n", user, err.Error())
+			_, wErr := checkAndWrapForKnownErrors(resp, err)
+			return nil, wErr
+		}
+		for _, org := range orgs {
+			if org.Login != nil {
+				orgNames = append(orgNames, *org.Login)
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return orgNames, nil
+}
@@ -6,6 +6,7 @@ package github
 var githubAppPrivateKey string
 var githubAppID int
 var secretAccessToken string
+var apiBaseURL string
 
 // Init initializes the required github variables
 func Init(ghAppID int, ghAppPrivateKey string, secAccessToken string) {
@@ -14,6 +15,12 @@ func Init(ghAppID int, ghAppPrivateKey string, secAccessToken string) {
 	secretAccessToken = secAccessToken
 }
 
+// SetAPIBaseURL overrides the GitHub API base URL used by the GitHub App clients - useful for
+// pointing the backend at a local GitHub stub. An empty value uses the public GitHub API.
+func SetAPIBaseURL(baseURL string) {
+	apiBaseURL = baseURL
+}
+
 func getGithubAppPrivateKey() string {
 	return githubAppPrivateKey
 }
@@ -25,3 +32,7 @@ func getGithubAppID() int {
 func getSecretAccessToken() string {
 	return secretAccessToken
 }
+
+func getAPIBaseURL() string {
+	return apiBaseURL
+}
@@ -0,0 +1,156 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// PerPage is the page size used when listing GitLab resources
+	PerPage = 100
+)
+
+var (
+	// ErrNotConfigured is returned when the GitLab API URL or access token is not set
+	ErrNotConfigured = errors.New("gitlab integration is not configured")
+	// ErrAccessDenied is returned whenever gitlab return 403 or 401
+	ErrAccessDenied = errors.New("access denied")
+	// ErrNotFound is returned whenever gitlab return 404
+	ErrNotFound = errors.New("not found")
+)
+
+// ErrorResponse is the error returned by GitLab for a non successful response
+type ErrorResponse struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("gitlab returned status %d : %s", e.StatusCode, e.Message)
+}
+
+// Unwrap maps the response status code to the known errors
+func (e *ErrorResponse) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAccessDenied
+	case http.StatusNotFound:
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Client is a minimal GitLab REST API (v4) client authenticated with the EasyCLA access token
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitlabClient creates a new gitlab client from the configured API URL and access token
+func NewGitlabClient() (*Client, error) {
+	if getAPIBaseURL() == "" || getAccessToken() == "" {
+		return nil, ErrNotConfigured
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(getAPIBaseURL(), "/"),
+		token:      getAccessToken(),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// ValidateWebhookToken returns true if the X-Gitlab-Token header value matches the configured webhook secret
+func ValidateWebhookToken(token string) bool {
+	secret := getWebhookSecret()
+	if secret == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
+
+// do sends the request to the GitLab API and decodes the JSON response into out, if provided.
+// The returned string is the next page number, empty when on the last page.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}) (string, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u = u + "?" + query.Encode()
+	}
+
+	var reqBody *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return "", err
+		}
+		reqBody = bytes.NewReader(payload)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errBody struct {
+			Message interface{} `json:"message"`
+			Error   string      `json:"error"`
+		}
+		msg := strings.TrimSpace(string(respBody))
+		if json.Unmarshal(respBody, &errBody) == nil {
+			if errBody.Message != nil {
+				msg = fmt.Sprintf("%v", errBody.Message)
+			} else if errBody.Error != "" {
+				msg = errBody.Error
+			}
+		}
+		return "", &ErrorResponse{StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return "", fmt.Errorf("decoding gitlab response failed : %w", err)
+		}
+	}
+
+	return resp.Header.Get("X-Next-Page"), nil
+}
+
+func pageQuery(page string) url.Values {
+	query := url.Values{}
+	query.Set("per_page", strconv.Itoa(PerPage))
+	if page != "" {
+		query.Set("page", page)
+	}
+	return query
+}
@@ -0,0 +1,70 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// GetGroup returns the GitLab group by its full path, e.g. my-foundation/my-sub-group
+func GetGroup(ctx context.Context, groupFullPath string) (*Group, error) {
+	f := logrus.Fields{
+		"functionName":   "GetGroup",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"groupFullPath":  groupFullPath,
+	}
+
+	client, err := NewGitlabClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var group Group
+	_, err = client.do(ctx, http.MethodGet, fmt.Sprintf("/groups/%s", url.PathEscape(groupFullPath)), nil, nil, &group)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to fetch gitlab group")
+		return nil, err
+	}
+	return &group, nil
+}
+
+// GetProject returns the GitLab project by its ID
+func GetProject(ctx context.Context, projectID int64) (*Project, error) {
+	f := logrus.Fields{
+		"functionName":   "GetProject",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectID":      projectID,
+	}
+
+	client, err := NewGitlabClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	_, err = client.do(ctx, http.MethodGet, fmt.Sprintf("/projects/%d", projectID), nil, nil, &project)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to fetch gitlab project")
+		return nil, err
+	}
+	return &project, nil
+}
+
+// IsProjectInGroup returns true if the project belongs to the group or one of its sub-groups
+func IsProjectInGroup(project *Project, groupFullPath string) bool {
+	if project == nil || project.Namespace == nil {
+		return false
+	}
+	namespace := strings.ToLower(project.Namespace.FullPath)
+	group := strings.ToLower(groupFullPath)
+	return namespace == group || strings.HasPrefix(namespace, group+"/")
+}
@@ -0,0 +1,116 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// constants
+const (
+	// StatusContext is the name of the commit status posted by EasyCLA
+	StatusContext = "EasyCLA"
+
+	// StatusSuccess is the commit status state when all the authors are authorized
+	StatusSuccess = "success"
+	// StatusFailed is the commit status state when one or more authors are not authorized
+	StatusFailed = "failed"
+
+	// StatusDescriptionSigned is the commit status description when all the authors are authorized
+	StatusDescriptionSigned = "EasyCLA check passed. You are authorized to contribute."
+	// StatusDescriptionMissing is the commit status description when one or more authors are not authorized
+	StatusDescriptionMissing = "Missing CLA Authorization."
+)
+
+// GetMergeRequestCommits returns the commits of the merge request
+func GetMergeRequestCommits(ctx context.Context, projectID int64, mergeRequestIID int) ([]*Commit, error) {
+	f := logrus.Fields{
+		"functionName":    "GetMergeRequestCommits",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"projectID":       projectID,
+		"mergeRequestIID": mergeRequestIID,
+	}
+
+	client, err := NewGitlabClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*Commit
+	page := ""
+	for {
+		var pageCommits []*Commit
+		nextPage, err := client.do(ctx, http.MethodGet, fmt.Sprintf("/projects/%d/merge_requests/%d/commits", projectID, mergeRequestIID),
+			pageQuery(page), nil, &pageCommits)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to list merge request commits")
+			return nil, err
+		}
+		commits = append(commits, pageCommits...)
+		if nextPage == "" {
+			break
+		}
+		page = nextPage
+	}
+
+	log.WithFields(f).Debugf("found %d commits", len(commits))
+	return commits, nil
+}
+
+// StatusPoster implements forge.StatusPoster for the GitLab projects. GitLab addresses the projects by ID or by
+// path: the owner is the namespace of the project, empty when the repository name is the project ID.
+type StatusPoster struct{}
+
+// PostStatus posts the EasyCLA commit status on the specified commit of the project
+func (StatusPoster) PostStatus(ctx context.Context, owner, repositoryName, sha string, status *forge.CommitStatus) error {
+	f := logrus.Fields{
+		"functionName":   "PostStatus",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"owner":          owner,
+		"repositoryName": repositoryName,
+		"sha":            sha,
+		"state":          status.State,
+	}
+
+	client, err := NewGitlabClient()
+	if err != nil {
+		return err
+	}
+
+	projectID := repositoryName
+	if owner != "" {
+		projectID = path.Join(owner, repositoryName)
+	}
+	commitStatus := &CommitStatus{
+		State:       toStatusState(status.State),
+		Name:        status.Context,
+		Description: status.Description,
+		TargetURL:   status.TargetURL,
+	}
+	_, err = client.do(ctx, http.MethodPost, fmt.Sprintf("/projects/%s/statuses/%s", url.PathEscape(projectID), sha), nil, commitStatus, nil)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to post commit status")
+		return err
+	}
+
+	log.WithFields(f).Debug("posted commit status")
+	return nil
+}
+
+// toStatusState maps the forge commit status state to the GitLab one
+func toStatusState(state string) string {
+	if state == forge.StatusStateFailure {
+		return StatusFailed
+	}
+	return state
+}
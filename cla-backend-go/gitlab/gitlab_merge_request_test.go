@@ -0,0 +1,113 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGitlabStub(t *testing.T, handler http.Handler) func() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	Init(server.URL+"/api/v4", "test-token", "test-secret")
+	return func() {
+		Init("", "", "")
+		server.Close()
+	}
+}
+
+func TestGetMergeRequestCommits(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/12/merge_requests/3/commits", func(w http.ResponseWriter, r *http.Request) {
+		var commits []*Commit
+		if r.URL.Query().Get("page") == "2" {
+			commits = []*Commit{{ID: "sha2", AuthorName: "bob", AuthorEmail: "bob@example.org"}}
+		} else {
+			w.Header().Set("X-Next-Page", "2")
+			commits = []*Commit{{ID: "sha1", AuthorName: "alice", AuthorEmail: "alice@example.org"}}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(commits))
+	})
+	teardown := setupGitlabStub(t, mux)
+	defer teardown()
+
+	commits, err := GetMergeRequestCommits(context.Background(), 12, 3)
+	assert.NoError(t, err)
+	if assert.Len(t, commits, 2) {
+		assert.Equal(t, "sha1", commits[0].ID)
+		assert.Equal(t, "bob@example.org", commits[1].AuthorEmail)
+	}
+}
+
+func TestStatusPosterPostStatus(t *testing.T) {
+	var posted CommitStatus
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/12/statuses/sha1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(posted))
+	})
+	teardown := setupGitlabStub(t, mux)
+	defer teardown()
+
+	err := StatusPoster{}.PostStatus(context.Background(), "", "12", "sha1", &forge.CommitStatus{
+		State:       forge.StatusStateFailure,
+		Context:     StatusContext,
+		Description: StatusDescriptionMissing,
+		TargetURL:   "https://easycla.example.org",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusContext, posted.Name)
+	assert.Equal(t, StatusFailed, posted.State)
+	assert.Equal(t, "https://easycla.example.org", posted.TargetURL)
+}
+
+func TestGetGroupNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/groups/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"404 Group Not Found"}`))
+	})
+	teardown := setupGitlabStub(t, mux)
+	defer teardown()
+
+	_, err := GetGroup(context.Background(), "foundation/missing")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestNotConfigured(t *testing.T) {
+	Init("", "", "")
+	_, err := GetProject(context.Background(), 12)
+	assert.Equal(t, ErrNotConfigured, err)
+}
+
+func TestValidateWebhookToken(t *testing.T) {
+	Init("", "", "test-secret")
+	defer Init("", "", "")
+	assert.True(t, ValidateWebhookToken("test-secret"))
+	assert.False(t, ValidateWebhookToken("wrong"))
+	assert.False(t, ValidateWebhookToken(""))
+}
+
+func TestIsProjectInGroup(t *testing.T) {
+	project := &Project{Namespace: &Namespace{FullPath: "Foundation/Sub"}}
+	assert.True(t, IsProjectInGroup(project, "foundation"))
+	assert.True(t, IsProjectInGroup(project, "foundation/sub"))
+	assert.False(t, IsProjectInGroup(project, "found"))
+	assert.False(t, IsProjectInGroup(&Project{}, "foundation"))
+}
@@ -0,0 +1,43 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// GetUserByEmail returns the GitLab user owning the email, ErrNotFound if no user matches. The search only
+// matches the private emails of the users when the access token belongs to an administrator of the instance.
+func GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	f := logrus.Fields{
+		"functionName":   "GetUserByEmail",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"email":          email,
+	}
+
+	client, err := NewGitlabClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*User
+	_, err = client.do(ctx, http.MethodGet, "/users", url.Values{"search": {email}}, nil, &users)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to search gitlab users")
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, ErrNotFound
+	}
+	if len(users) > 1 {
+		log.WithFields(f).Warnf("found %d gitlab users for the email when we should find 0 or 1", len(users))
+	}
+	return users[0], nil
+}
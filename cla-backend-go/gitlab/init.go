@@ -0,0 +1,27 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+var apiBaseURL string
+var accessToken string
+var webhookSecret string
+
+// Init initializes the required gitlab variables
+func Init(glAPIBaseURL string, glAccessToken string, glWebhookSecret string) {
+	apiBaseURL = glAPIBaseURL
+	accessToken = glAccessToken
+	webhookSecret = glWebhookSecret
+}
+
+func getAPIBaseURL() string {
+	return apiBaseURL
+}
+
+func getAccessToken() string {
+	return accessToken
+}
+
+func getWebhookSecret() string {
+	return webhookSecret
+}
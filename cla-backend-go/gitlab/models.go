@@ -0,0 +1,79 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+// Group is the GitLab group (or sub-group) model
+type Group struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+	WebURL   string `json:"web_url"`
+}
+
+// Namespace is the group or user namespace a GitLab project belongs to
+type Namespace struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	FullPath string `json:"full_path"`
+}
+
+// Project is the GitLab project model
+type Project struct {
+	ID                int64      `json:"id"`
+	Name              string     `json:"name"`
+	PathWithNamespace string     `json:"path_with_namespace"`
+	WebURL            string     `json:"web_url"`
+	Namespace         *Namespace `json:"namespace,omitempty"`
+}
+
+// User is the GitLab user model
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// Commit is the GitLab commit model as returned by the merge request commits API
+type Commit struct {
+	ID          string `json:"id"`
+	ShortID     string `json:"short_id"`
+	Title       string `json:"title"`
+	AuthorName  string `json:"author_name"`
+	AuthorEmail string `json:"author_email"`
+}
+
+// CommitStatus is the GitLab external commit status model
+type CommitStatus struct {
+	ID          int64  `json:"id,omitempty"`
+	SHA         string `json:"sha,omitempty"`
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	TargetURL   string `json:"target_url,omitempty"`
+}
+
+// MergeRequestEvent is the payload of the GitLab merge request webhook (X-Gitlab-Event: Merge Request Hook)
+type MergeRequestEvent struct {
+	ObjectKind       string                 `json:"object_kind"`
+	Project          Project                `json:"project"`
+	ObjectAttributes MergeRequestAttributes `json:"object_attributes"`
+}
+
+// MergeRequestAttributes holds the merge request details of the merge request webhook
+type MergeRequestAttributes struct {
+	ID              int64  `json:"id"`
+	IID             int    `json:"iid"`
+	Title           string `json:"title"`
+	State           string `json:"state"`
+	Action          string `json:"action"`
+	URL             string `json:"url"`
+	SourceProjectID int64  `json:"source_project_id"`
+	TargetProjectID int64  `json:"target_project_id"`
+	LastCommit      struct {
+		ID string `json:"id"`
+	} `json:"last_commit"`
+}
@@ -0,0 +1,362 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_manager"
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	projectOps "github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/project"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// WeeklyDigestDay is the day the weekly digests are sent, the daily digests are sent every day
+const WeeklyDigestDay = time.Monday
+
+// claGroupsPageSize is the number of CLA Groups loaded per page while collecting the digests
+const claGroupsPageSize = 100
+
+// DigestService sends the notification digests to the users preferring a digest over the immediate notifications
+type DigestService interface {
+	SendDigests(ctx context.Context, frequency string, now time.Time) (int, error)
+}
+
+type digestService struct {
+	projectRepo      project.ProjectRepository
+	claManagerRepo   cla_manager.IRepository
+	approvalListRepo approval_list.IRepository
+	signatureRepo    signatures.SignatureRepository
+	usersRepo        users.UserRepository
+	eventsRepo       events.Repository
+}
+
+// NewDigestService creates a new notification digest service
+func NewDigestService(projectRepo project.ProjectRepository, claManagerRepo cla_manager.IRepository, approvalListRepo approval_list.IRepository,
+	signatureRepo signatures.SignatureRepository, usersRepo users.UserRepository, eventsRepo events.Repository) DigestService {
+	return &digestService{
+		projectRepo:      projectRepo,
+		claManagerRepo:   claManagerRepo,
+		approvalListRepo: approvalListRepo,
+		signatureRepo:    signatureRepo,
+		usersRepo:        usersRepo,
+		eventsRepo:       eventsRepo,
+	}
+}
+
+// DueDigestFrequencies returns the digest frequencies to send at the specified time, the daily digests every day and
+// the weekly digests on the WeeklyDigestDay
+func DueDigestFrequencies(now time.Time) []string {
+	frequencies := []string{users.NotificationFrequencyDaily}
+	if now.UTC().Weekday() == WeeklyDigestDay {
+		frequencies = append(frequencies, users.NotificationFrequencyWeekly)
+	}
+	return frequencies
+}
+
+// digestPeriod returns how far back the events of the digest go
+func digestPeriod(frequency string) time.Duration {
+	if frequency == users.NotificationFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// digest is the content of the digest email of a recipient
+type digest struct {
+	recipient *models.User
+	email     string
+	params    emails.NotificationDigestTemplateParams
+	// v1 is true when the digest refers to a v1 CLA Group, the digest links to the v1 corporate console
+	v1 bool
+}
+
+// digests collects the digests of the recipients, keyed by email
+type digests struct {
+	frequency string
+	byEmail   map[string]*digest
+}
+
+func newDigests(frequency string) *digests {
+	return &digests{frequency: frequency, byEmail: map[string]*digest{}}
+}
+
+// digestRecipientEmail returns the email address the digest of the user is sent to
+func digestRecipientEmail(user *models.User) string {
+	if user.LfEmail != "" {
+		return user.LfEmail
+	}
+	for _, email := range user.Emails {
+		if email != "" && !strings.Contains(email, "noreply.github.com") {
+			return email
+		}
+	}
+	return ""
+}
+
+// digestFor returns the digest of the user when the user receives the notifications of the category in the digest
+// being collected, nil otherwise
+func (d *digests) digestFor(user *models.User, category string, claGroup *models.ClaGroup) *digest {
+	if user == nil || users.NotificationFrequency(user, category) != d.frequency {
+		return nil
+	}
+	email := digestRecipientEmail(user)
+	if email == "" {
+		return nil
+	}
+
+	recipientDigest, ok := d.byEmail[email]
+	if !ok {
+		recipientName := user.Username
+		if recipientName == "" {
+			recipientName = user.LfUsername
+		}
+		recipientDigest = &digest{
+			recipient: user,
+			email:     email,
+			params: emails.NotificationDigestTemplateParams{
+				RecipientName:     recipientName,
+				Frequency:         d.frequency,
+				RecipientLanguage: user.PreferredLanguage,
+			},
+		}
+		d.byEmail[email] = recipientDigest
+	}
+	if claGroup.Version != utils.V2 {
+		recipientDigest.v1 = true
+	}
+	return recipientDigest
+}
+
+// sorted returns the collected digests ordered by recipient email
+func (d *digests) sorted() []*digest {
+	var list []*digest
+	for _, recipientDigest := range d.byEmail {
+		list = append(list, recipientDigest)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].email < list[j].email })
+	return list
+}
+
+// SendDigests sends the digests of the specified frequency, returns the number of digests sent
+func (s *digestService) SendDigests(ctx context.Context, frequency string, now time.Time) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "notifications.SendDigests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"frequency":      frequency,
+	}
+
+	collected := newDigests(frequency)
+	since := now.Add(-digestPeriod(frequency))
+	var nextKey *string
+	for {
+		claGroups, err := s.projectRepo.GetCLAGroups(ctx, &projectOps.GetProjectsParams{
+			NextKey:  nextKey,
+			PageSize: aws.Int64(claGroupsPageSize),
+		})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the CLA Groups")
+			return 0, err
+		}
+
+		for i := range claGroups.Projects {
+			s.collectClaGroupDigests(ctx, &claGroups.Projects[i], since, collected)
+		}
+
+		if claGroups.LastKeyScanned == "" {
+			break
+		}
+		nextKey = aws.String(claGroups.LastKeyScanned)
+	}
+
+	sent := 0
+	for _, recipientDigest := range collected.sorted() {
+		if err := sendDigest(recipientDigest); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to send the digest to: %s", recipientDigest.email)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// collectClaGroupDigests adds the pending requests and the repository events of the CLA Group to the digests, the
+// errors are logged so a failing CLA Group doesn't block the other digests
+func (s *digestService) collectClaGroupDigests(ctx context.Context, claGroup *models.ClaGroup, since time.Time, collected *digests) {
+	f := logrus.Fields{
+		"functionName":   "notifications.collectClaGroupDigests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroup.ProjectID,
+		"frequency":      collected.frequency,
+	}
+
+	// the CLA Managers of the companies, loaded once per company
+	companyManagers := map[string][]models.User{}
+	managers := func(companyID string) []models.User {
+		if claManagers, ok := companyManagers[companyID]; ok {
+			return claManagers
+		}
+		signed, approved := true, true
+		sig, err := s.signatureRepo.GetProjectCompanySignature(ctx, companyID, claGroup.ProjectID, &signed, &approved, nil, nil)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to load the corporate signature of the company: %s", companyID)
+		}
+		var claManagers []models.User
+		if sig != nil {
+			claManagers = sig.SignatureACL
+		}
+		companyManagers[companyID] = claManagers
+		return claManagers
+	}
+
+	claManagerRequests, err := s.claManagerRepo.GetRequestsByCLAGroup(claGroup.ProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Manager requests")
+	}
+	for _, request := range claManagerRequests {
+		if request.Status != approval_list.StatusPending {
+			continue
+		}
+		claManagers := managers(request.CompanyID)
+		for i := range claManagers {
+			if recipientDigest := collected.digestFor(&claManagers[i], users.NotificationCategoryCLAManagerRequests, claGroup); recipientDigest != nil {
+				recipientDigest.params.CLAManagerRequests = append(recipientDigest.params.CLAManagerRequests, emails.DigestCLAManagerRequest{
+					CompanyName:    request.CompanyName,
+					CLAGroupName:   claGroup.ProjectName,
+					RequesterName:  request.UserName,
+					RequesterEmail: request.UserEmail,
+					RequestedOn:    request.Created,
+				})
+			}
+		}
+	}
+
+	approvalListRequests, err := s.approvalListRepo.GetRequestsByCLAGroup(claGroup.ProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the approval list requests")
+	}
+	for _, request := range approvalListRequests {
+		if request.RequestStatus != approval_list.StatusPending {
+			continue
+		}
+		var contributorEmail string
+		if len(request.UserEmails) > 0 {
+			contributorEmail = request.UserEmails[0]
+		}
+		claManagers := managers(request.CompanyID)
+		for i := range claManagers {
+			if recipientDigest := collected.digestFor(&claManagers[i], users.NotificationCategoryApprovalListRequests, claGroup); recipientDigest != nil {
+				recipientDigest.params.ApprovalListRequests = append(recipientDigest.params.ApprovalListRequests, emails.DigestApprovalListRequest{
+					CompanyName:      request.CompanyName,
+					CLAGroupName:     claGroup.ProjectName,
+					ContributorName:  request.UserName,
+					ContributorEmail: contributorEmail,
+					RequestedOn:      request.DateCreated,
+				})
+			}
+		}
+	}
+
+	// the v2 approval requests are not stored as requests, they are logged when the contributor notifies the CLA Managers
+	notifyEvents, err := s.eventsRepo.GetClaGroupEventsSince(claGroup.ProjectID, events.ContributorNotifyCLAManagersType, since)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the approval request events")
+	}
+	for _, event := range notifyEvents {
+		var contributorEmail string
+		if event.UserID != "" {
+			if contributor, userErr := s.usersRepo.GetUser(event.UserID); userErr == nil && contributor != nil {
+				contributorEmail = digestRecipientEmail(contributor)
+			}
+		}
+		claManagers := managers(event.EventCompanyID)
+		for i := range claManagers {
+			if recipientDigest := collected.digestFor(&claManagers[i], users.NotificationCategoryApprovalListRequests, claGroup); recipientDigest != nil {
+				recipientDigest.params.ApprovalListRequests = append(recipientDigest.params.ApprovalListRequests, emails.DigestApprovalListRequest{
+					CompanyName:      event.EventCompanyName,
+					CLAGroupName:     claGroup.ProjectName,
+					ContributorName:  event.UserName,
+					ContributorEmail: contributorEmail,
+					RequestedOn:      event.EventTime,
+				})
+			}
+		}
+	}
+
+	if len(claGroup.ProjectACL) == 0 {
+		return
+	}
+	repositoryEvents, err := s.eventsRepo.GetClaGroupEventsSince(claGroup.ProjectID, events.RepositoryAdded, since)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the repository events")
+		return
+	}
+	if len(repositoryEvents) == 0 {
+		return
+	}
+	for _, lfUsername := range claGroup.ProjectACL {
+		projectManager, userErr := s.usersRepo.GetUserByLFUserName(lfUsername)
+		if userErr != nil {
+			log.WithFields(f).WithError(userErr).Warnf("unable to load the project manager: %s", lfUsername)
+			continue
+		}
+		recipientDigest := collected.digestFor(projectManager, users.NotificationCategoryRepositoryEvents, claGroup)
+		if recipientDigest == nil {
+			continue
+		}
+		for _, event := range repositoryEvents {
+			summary := event.EventSummary
+			if summary == "" {
+				summary = event.EventData
+			}
+			recipientDigest.params.RepositoryEvents = append(recipientDigest.params.RepositoryEvents, emails.DigestRepositoryEvent{
+				CLAGroupName: claGroup.ProjectName,
+				Summary:      summary,
+				EventTime:    event.EventTime,
+			})
+		}
+	}
+}
+
+// sendDigest renders and sends the digest email
+func sendDigest(recipientDigest *digest) error {
+	claGroupVersion := utils.V2
+	if recipientDigest.v1 {
+		claGroupVersion = utils.V1
+	}
+	recipientDigest.params.CorporateConsoleURL = utils.GetCorporateURL(claGroupVersion == utils.V2)
+
+	body, err := emails.RenderTemplate(claGroupVersion, emails.NotificationDigestTemplateName, emails.NotificationDigestTemplate, recipientDigest.params)
+	if err != nil {
+		return err
+	}
+	subject := emails.RenderSubject(emails.NotificationDigestTemplateName,
+		fmt.Sprintf("EasyCLA: Your %s notification digest", recipientDigest.params.Frequency), recipientDigest.params)
+	return utils.SendEmail(subject, body, []string{recipientDigest.email}, utils.EmailMetadata{
+		TemplateName:  emails.NotificationDigestTemplateName,
+		RecipientRole: digestRecipientRole(recipientDigest),
+	})
+}
+
+// digestRecipientRole returns the role of the digest recipient, the project managers only receive the repository
+// events
+func digestRecipientRole(recipientDigest *digest) string {
+	if len(recipientDigest.params.CLAManagerRequests) == 0 && len(recipientDigest.params.ApprovalListRequests) == 0 {
+		return utils.EmailRecipientRoleProjectManager
+	}
+	return utils.EmailRecipientRoleCLAManager
+}
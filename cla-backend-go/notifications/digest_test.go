@@ -0,0 +1,185 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
+	"github.com/communitybridge/easycla/cla-backend-go/cla_manager"
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	projectOps "github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/project"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProjectRepo struct {
+	project.ProjectRepository
+	claGroups []models.ClaGroup
+}
+
+func (r *fakeProjectRepo) GetCLAGroups(ctx context.Context, params *projectOps.GetProjectsParams) (*models.ClaGroups, error) {
+	return &models.ClaGroups{Projects: r.claGroups}, nil
+}
+
+type fakeCLAManagerRepo struct {
+	cla_manager.IRepository
+	requests []cla_manager.CLAManagerRequest
+}
+
+func (r *fakeCLAManagerRepo) GetRequestsByCLAGroup(claGroupID string) ([]cla_manager.CLAManagerRequest, error) {
+	var requests []cla_manager.CLAManagerRequest
+	for _, request := range r.requests {
+		if request.ProjectID == claGroupID {
+			requests = append(requests, request)
+		}
+	}
+	return requests, nil
+}
+
+type fakeApprovalListRepo struct {
+	approval_list.IRepository
+	requests []approval_list.CLARequestModel
+}
+
+func (r *fakeApprovalListRepo) GetRequestsByCLAGroup(claGroupID string) ([]approval_list.CLARequestModel, error) {
+	var requests []approval_list.CLARequestModel
+	for _, request := range r.requests {
+		if request.ProjectID == claGroupID {
+			requests = append(requests, request)
+		}
+	}
+	return requests, nil
+}
+
+type fakeSignatureRepo struct {
+	signatures.SignatureRepository
+	claManagers map[string][]models.User
+}
+
+func (r *fakeSignatureRepo) GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*models.Signature, error) {
+	return &models.Signature{SignatureACL: r.claManagers[companyID]}, nil
+}
+
+type fakeUsersRepo struct {
+	users.UserRepository
+	users map[string]*models.User
+}
+
+func (r *fakeUsersRepo) GetUserByLFUserName(lfUserName string) (*models.User, error) {
+	return r.users[lfUserName], nil
+}
+
+func (r *fakeUsersRepo) GetUser(userID string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.UserID == userID {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeEventsRepo struct {
+	events.Repository
+	events []*models.Event
+}
+
+func (r *fakeEventsRepo) GetClaGroupEventsSince(claGroupID, eventType string, since time.Time) ([]*models.Event, error) {
+	var claGroupEvents []*models.Event
+	for _, event := range r.events {
+		if event.EventProjectID == claGroupID && event.EventType == eventType && event.EventTimeEpoch >= since.Unix() {
+			claGroupEvents = append(claGroupEvents, event)
+		}
+	}
+	return claGroupEvents, nil
+}
+
+func TestDueDigestFrequencies(t *testing.T) {
+	assert.Equal(t, []string{users.NotificationFrequencyDaily, users.NotificationFrequencyWeekly},
+		DueDigestFrequencies(time.Date(2021, 6, 14, 8, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{users.NotificationFrequencyDaily},
+		DueDigestFrequencies(time.Date(2021, 6, 15, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestSendDigests(t *testing.T) {
+	previous := utils.GetEmailSender()
+	defer utils.SetEmailSender(previous)
+	mock := &utils.MockEmailSender{}
+	utils.SetEmailSender(mock)
+
+	now := time.Date(2021, 6, 15, 8, 0, 0, 0, time.UTC)
+	dailyManager := models.User{
+		LfUsername:              "daily",
+		Username:                "Daily Manager",
+		LfEmail:                 "daily@example.org",
+		NotificationPreferences: &models.NotificationPreferences{ClaManagerRequests: users.NotificationFrequencyDaily, ApprovalListRequests: users.NotificationFrequencyDaily},
+	}
+	immediateManager := models.User{LfUsername: "immediate", LfEmail: "immediate@example.org"}
+	weeklyProjectManager := &models.User{
+		LfUsername:              "weekly",
+		LfEmail:                 "weekly@example.org",
+		NotificationPreferences: &models.NotificationPreferences{RepositoryEvents: users.NotificationFrequencyWeekly},
+	}
+
+	service := NewDigestService(
+		&fakeProjectRepo{claGroups: []models.ClaGroup{
+			{ProjectID: "cla-group-1", ProjectName: "Project One", Version: utils.V2, ProjectACL: []string{"weekly"}},
+			{ProjectID: "cla-group-2", ProjectName: "Project Two", Version: utils.V2},
+		}},
+		&fakeCLAManagerRepo{requests: []cla_manager.CLAManagerRequest{
+			{ProjectID: "cla-group-1", CompanyID: "company-1", CompanyName: "Acme", UserName: "Jane", UserEmail: "jane@acme.org", Status: "pending"},
+			{ProjectID: "cla-group-1", CompanyID: "company-1", CompanyName: "Acme", UserName: "John", UserEmail: "john@acme.org", Status: "approved"},
+		}},
+		&fakeApprovalListRepo{requests: []approval_list.CLARequestModel{
+			{ProjectID: "cla-group-2", CompanyID: "company-1", CompanyName: "Acme", UserName: "Bob", UserEmails: []string{"bob@acme.org"}, RequestStatus: "pending"},
+		}},
+		&fakeSignatureRepo{claManagers: map[string][]models.User{"company-1": {dailyManager, immediateManager}}},
+		&fakeUsersRepo{users: map[string]*models.User{
+			"weekly": weeklyProjectManager,
+			"carol":  {UserID: "user-carol", LfUsername: "carol", LfEmail: "carol@acme.org"},
+		}},
+		&fakeEventsRepo{events: []*models.Event{
+			{EventProjectID: "cla-group-1", EventType: events.RepositoryAdded, EventTimeEpoch: now.Add(-72 * time.Hour).Unix(), EventSummary: "GitHub Repository: acme/widgets was added to Project: Project One by: bot."},
+			// a v2 approval request, the daily manager was not emailed when the contributor notified the CLA Managers
+			{EventProjectID: "cla-group-2", EventType: events.ContributorNotifyCLAManagersType, EventTimeEpoch: now.Add(-2 * time.Hour).Unix(),
+				EventCompanyID: "company-1", EventCompanyName: "Acme", UserID: "user-carol", UserName: "Carol"},
+			{EventProjectID: "cla-group-2", EventType: events.ContributorNotifyCLAManagersType, EventTimeEpoch: now.Add(-48 * time.Hour).Unix(),
+				EventCompanyID: "company-1", EventCompanyName: "Acme", UserID: "user-carol", UserName: "Dave"},
+		}},
+	)
+
+	sent, err := service.SendDigests(context.Background(), users.NotificationFrequencyDaily, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	sentEmails := mock.Emails()
+	if assert.Len(t, sentEmails, 1) {
+		assert.Equal(t, []string{"daily@example.org"}, sentEmails[0].Recipients)
+		assert.Equal(t, emails.NotificationDigestTemplateName, sentEmails[0].Metadata.TemplateName)
+		assert.Contains(t, sentEmails[0].Body, "Hello Daily Manager")
+		assert.Contains(t, sentEmails[0].Body, "Jane (jane@acme.org) for Acme on Project One")
+		assert.NotContains(t, sentEmails[0].Body, "John")
+		assert.Contains(t, sentEmails[0].Body, "Bob (bob@acme.org) for Acme on Project Two")
+		assert.Contains(t, sentEmails[0].Body, "Carol (carol@acme.org) for Acme on Project Two")
+		assert.NotContains(t, sentEmails[0].Body, "Dave", "the approval requests older than the digest period are not listed")
+		assert.NotContains(t, sentEmails[0].Body, "acme/widgets")
+	}
+
+	sent, err = service.SendDigests(context.Background(), users.NotificationFrequencyWeekly, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	sentEmails = mock.Emails()
+	if assert.Len(t, sentEmails, 2) {
+		assert.Equal(t, []string{"weekly@example.org"}, sentEmails[1].Recipients)
+		assert.Equal(t, utils.EmailRecipientRoleProjectManager, sentEmails[1].Metadata.RecipientRole)
+		assert.Contains(t, sentEmails[1].Body, "Project One: GitHub Repository: acme/widgets was added")
+		assert.NotContains(t, sentEmails[1].Body, "Jane")
+	}
+}
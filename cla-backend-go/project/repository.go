@@ -57,6 +57,8 @@ type ProjectRepository interface { //nolint
 	GetClaGroupsByFoundationSFID(ctx context.Context, foundationSFID string, loadRepoDetails bool) (*models.ClaGroups, error)
 	GetClaGroupByProjectSFID(ctx context.Context, projectSFID string, loadRepoDetails bool) (*models.ClaGroup, error)
 	UpdateRootCLAGroupRepositoriesCount(ctx context.Context, claGroupID string, diff int64, reset bool) error
+	UpdateCLAGroupMembershipCheckAPIKeyHash(ctx context.Context, claGroupID, keyHash string) error
+	GetCLAGroupMembershipCheckAPIKeyHash(ctx context.Context, claGroupID string) (string, error)
 }
 
 // NewRepository creates instance of project repository
@@ -803,6 +805,78 @@ func (repo *repo) UpdateRootCLAGroupRepositoriesCount(ctx context.Context, claGr
 	return err
 }
 
+// UpdateCLAGroupMembershipCheckAPIKeyHash sets the hash of the API key authenticating the membership checks of the
+// CI systems for the CLA Group, an empty hash revokes the API key
+func (repo *repo) UpdateCLAGroupMembershipCheckAPIKeyHash(ctx context.Context, claGroupID, keyHash string) error {
+	f := logrus.Fields{
+		"functionName":   "project.repository.UpdateCLAGroupMembershipCheckAPIKeyHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+	_, now := utils.CurrentTime()
+
+	input := &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#ID": aws.String("project_id"),
+			"#K":  aws.String("membership_check_api_key_hash"),
+			"#M":  aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		TableName: aws.String(repo.claGroupTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+		ConditionExpression: aws.String("attribute_exists(#ID)"),
+		UpdateExpression:    aws.String("SET #M = :m REMOVE #K"),
+	}
+	if keyHash != "" {
+		input.ExpressionAttributeValues[":k"] = &dynamodb.AttributeValue{S: aws.String(keyHash)}
+		input.UpdateExpression = aws.String("SET #K = :k, #M = :m")
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the membership check API key")
+		return err
+	}
+
+	return nil
+}
+
+// GetCLAGroupMembershipCheckAPIKeyHash returns the hash of the API key authenticating the membership checks of the
+// CI systems for the CLA Group, empty if no API key was created
+func (repo *repo) GetCLAGroupMembershipCheckAPIKeyHash(ctx context.Context, claGroupID string) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "project.repository.GetCLAGroupMembershipCheckAPIKeyHash",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.claGroupTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+		ProjectionExpression: aws.String("membership_check_api_key_hash"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the membership check API key")
+		return "", err
+	}
+
+	if value, ok := result.Item["membership_check_api_key_hash"]; ok && value.S != nil {
+		return *value.S, nil
+	}
+	return "", nil
+}
+
 // buildCLAGroupModels converts the database response model into an API response data model
 func (repo *repo) buildCLAGroupModels(ctx context.Context, results []map[string]*dynamodb.AttributeValue, loadRepoDetails bool) ([]models.ClaGroup, error) {
 	var projects []models.ClaGroup
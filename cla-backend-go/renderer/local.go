@@ -0,0 +1,157 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+const (
+	defaultRenderTimeout = 60 * time.Second
+)
+
+// LocalRenderer renders the PDF with a wkhtmltopdf or headless chromium subprocess, no external service is involved
+type LocalRenderer struct {
+	rendererType string
+	binaryPath   string
+	timeout      time.Duration
+}
+
+// NewLocalRenderer creates a local renderer of the type, the binary is looked up in the PATH when binaryPath is empty
+func NewLocalRenderer(rendererType, binaryPath string) (*LocalRenderer, error) {
+	if binaryPath == "" {
+		binaryPath = rendererType
+	}
+
+	var err error
+	switch rendererType {
+	case TypeWkhtmltopdf:
+		binaryPath, err = exec.LookPath(binaryPath)
+	case TypeChromium:
+		binaryPath, err = lookupChromium(binaryPath)
+	default:
+		return nil, fmt.Errorf("unsupported local pdf renderer type : %s", rendererType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s binary not found : %w", rendererType, err)
+	}
+
+	return &LocalRenderer{
+		rendererType: rendererType,
+		binaryPath:   binaryPath,
+		timeout:      defaultRenderTimeout,
+	}, nil
+}
+
+// lookupChromium resolves the chromium binary, trying the common chrome binary names when the default is missing
+func lookupChromium(binaryPath string) (string, error) {
+	path, err := exec.LookPath(binaryPath)
+	if err == nil || binaryPath != TypeChromium {
+		return path, err
+	}
+	for _, name := range []string{"chromium-browser", "google-chrome", "google-chrome-stable"} {
+		if path, lookErr := exec.LookPath(name); lookErr == nil {
+			return path, nil
+		}
+	}
+	return "", err
+}
+
+// CreatePDF accepts an HTML document and returns a PDF
+func (r *LocalRenderer) CreatePDF(html string, claType string) (io.ReadCloser, error) {
+	f := logrus.Fields{
+		"functionName": "renderer.LocalRenderer.CreatePDF",
+		"claType":      claType,
+		"rendererType": r.rendererType,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// the renderers load the resources of the document through the proxy, which only connects to the public hosts
+	deadline, _ := ctx.Deadline()
+	proxy, err := startFetchProxy(deadline)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to start the pdf renderer proxy")
+		return nil, err
+	}
+	defer func() {
+		if closeErr := proxy.Close(); closeErr != nil {
+			log.WithFields(f).WithError(closeErr).Warn("unable to stop the pdf renderer proxy")
+		}
+	}()
+
+	log.WithFields(f).Debug("Generating PDF using local renderer...")
+	var pdf []byte
+	if r.rendererType == TypeChromium {
+		pdf, err = r.renderChromium(ctx, html, proxy.URL())
+	} else {
+		pdf, err = r.renderWkhtmltopdf(ctx, html, proxy.URL())
+	}
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem generating PDF with local renderer")
+		return nil, err
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(pdf)), nil
+}
+
+// renderWkhtmltopdf pipes the HTML document through wkhtmltopdf
+func (r *LocalRenderer) renderWkhtmltopdf(ctx context.Context, html, proxyURL string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	// the document may not run scripts nor read the files of the renderer host, and loads its resources through the proxy
+	cmd := exec.CommandContext(ctx, r.binaryPath, "--quiet", "--encoding", "utf-8", // nolint
+		"--disable-local-file-access", "--disable-javascript", "--proxy", proxyURL, "-", "-")
+	cmd.Stdin = bytes.NewBufferString(html)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("wkhtmltopdf failed : %v : %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// renderChromium prints the HTML document to PDF with headless chromium, which only works with files
+func (r *LocalRenderer) renderChromium(ctx context.Context, html, proxyURL string) ([]byte, error) {
+	dir, err := ioutil.TempDir("", "easycla-pdf")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(dir); removeErr != nil {
+			log.Warnf("unable to remove temporary pdf render directory: %s, error: %v", dir, removeErr)
+		}
+	}()
+
+	htmlFile := filepath.Join(dir, "document.html")
+	pdfFile := filepath.Join(dir, "document.pdf")
+	if err = ioutil.WriteFile(htmlFile, []byte(html), 0600); err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+	// chromium keeps its sandbox, does not run the scripts of the document and loads its resources through the proxy,
+	// the loopback addresses included
+	cmd := exec.CommandContext(ctx, r.binaryPath, "--headless", "--disable-gpu", "--blink-settings=scriptEnabled=false", // nolint
+		"--proxy-server="+proxyURL, "--proxy-bypass-list=<-loopback>",
+		"--print-to-pdf-no-header", "--print-to-pdf="+pdfFile, "file://"+htmlFile)
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("chromium failed : %v : %s", err, stderr.String())
+	}
+
+	return ioutil.ReadFile(filepath.Clean(pdfFile))
+}
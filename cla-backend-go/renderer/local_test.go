@@ -0,0 +1,138 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// the fake binaries write the HTML document as the PDF so the tests can check what was rendered
+const (
+	fakeWkhtmltopdf = "#!/bin/sh\ncat\n"
+	fakeChromium    = `#!/bin/sh
+for arg in "$@"; do
+  case "$arg" in
+    --print-to-pdf=*) out="${arg#--print-to-pdf=}" ;;
+    file://*) in="${arg#file://}" ;;
+  esac
+done
+cp "$in" "$out"
+`
+)
+
+// the fake binaries write their arguments as the PDF so the tests can check how the renderers are run
+const (
+	fakeWkhtmltopdfArgs = "#!/bin/sh\necho \"$@\"\n"
+	fakeChromiumArgs    = `#!/bin/sh
+for arg in "$@"; do
+  case "$arg" in
+    --print-to-pdf=*) out="${arg#--print-to-pdf=}" ;;
+  esac
+done
+echo "$@" > "$out"
+`
+)
+
+func writeFakeBinary(t *testing.T, dir, name, script string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(script), 0700); err != nil { // nolint
+		t.Fatalf("writing fake binary failed : %v", err)
+	}
+	return path
+}
+
+func TestLocalRendererCreatePDF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "renderer-test")
+	if err != nil {
+		t.Fatalf("creating temp dir failed : %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint
+
+	testCases := []struct {
+		rendererType string
+		script       string
+	}{
+		{rendererType: TypeWkhtmltopdf, script: fakeWkhtmltopdf},
+		{rendererType: TypeChromium, script: fakeChromium},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rendererType, func(t *testing.T) {
+			binaryPath := writeFakeBinary(t, dir, tc.rendererType, tc.script)
+			pdfRenderer, err := NewPDFRenderer(config.Config{PDFRenderer: config.PDFRenderer{Type: tc.rendererType, BinaryPath: binaryPath}})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			pdf, err := pdfRenderer.CreatePDF("<html><body>Individual CLA</body></html>", "icla")
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer pdf.Close() // nolint
+			content, err := ioutil.ReadAll(pdf)
+			assert.NoError(t, err)
+			assert.Equal(t, "<html><body>Individual CLA</body></html>", string(content))
+		})
+	}
+}
+
+func TestNewPDFRenderer(t *testing.T) {
+	_, err := NewPDFRenderer(config.Config{})
+	assert.Error(t, err, "docraptor requires an api key")
+
+	pdfRenderer, err := NewPDFRenderer(config.Config{Docraptor: config.Docraptor{APIKey: "key"}})
+	assert.NoError(t, err)
+	assert.NotNil(t, pdfRenderer)
+
+	_, err = NewPDFRenderer(config.Config{PDFRenderer: config.PDFRenderer{Type: "unknown"}})
+	assert.Error(t, err)
+
+	_, err = NewPDFRenderer(config.Config{PDFRenderer: config.PDFRenderer{Type: TypeWkhtmltopdf, BinaryPath: "/does/not/exist"}})
+	assert.Error(t, err)
+}
+
+func TestLocalRendererRestrictsTheDocument(t *testing.T) {
+	dir, err := ioutil.TempDir("", "renderer-test")
+	if err != nil {
+		t.Fatalf("creating temp dir failed : %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint
+
+	testCases := []struct {
+		rendererType string
+		script       string
+		expected     []string
+	}{
+		{rendererType: TypeWkhtmltopdf, script: fakeWkhtmltopdfArgs, expected: []string{"--disable-local-file-access", "--disable-javascript", "--proxy http://127.0.0.1:"}},
+		{rendererType: TypeChromium, script: fakeChromiumArgs, expected: []string{"--headless", "--blink-settings=scriptEnabled=false", "--proxy-server=http://127.0.0.1:", "--proxy-bypass-list=<-loopback>"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rendererType, func(t *testing.T) {
+			binaryPath := writeFakeBinary(t, dir, tc.rendererType+"-args", tc.script)
+			pdfRenderer, err := NewLocalRenderer(tc.rendererType, binaryPath)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			pdf, err := pdfRenderer.CreatePDF("<html><body>Individual CLA</body></html>", "icla")
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer pdf.Close() // nolint
+			content, err := ioutil.ReadAll(pdf)
+			assert.NoError(t, err)
+			for _, arg := range tc.expected {
+				assert.Contains(t, string(content), arg)
+			}
+			assert.NotContains(t, string(content), "--no-sandbox")
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+const (
+	// proxyDialTimeout is how long the proxy waits to connect to the host of a resource
+	proxyDialTimeout = 10 * time.Second
+	// httpsPort is the only port the proxy tunnels to
+	httpsPort = "443"
+)
+
+// fetchProxy is the HTTP proxy the local renderers load the resources of the documents through. It only tunnels the
+// https connections and refuses to connect to the addresses which are not public, whatever the host name of the
+// resource resolves to, so a template cannot reach the hosts of the renderer network.
+type fetchProxy struct {
+	listener net.Listener
+	server   *http.Server
+	dialer   *net.Dialer
+	deadline time.Time
+}
+
+// startFetchProxy starts the proxy on a loopback port, the tunnels it opens are closed at the deadline
+func startFetchProxy(deadline time.Time) (*fetchProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &fetchProxy{
+		listener: listener,
+		dialer:   &net.Dialer{Timeout: proxyDialTimeout, Control: utils.PublicDialControl},
+		deadline: deadline,
+	}
+	p.server = &http.Server{Handler: p, ReadHeaderTimeout: proxyDialTimeout}
+	go func() {
+		if serveErr := p.server.Serve(listener); serveErr != nil && serveErr != http.ErrServerClosed {
+			log.Warnf("pdf renderer proxy stopped, error: %v", serveErr)
+		}
+	}()
+	return p, nil
+}
+
+// URL returns the URL the renderers are configured with
+func (p *fetchProxy) URL() string {
+	return "http://" + p.listener.Addr().String()
+}
+
+// Close stops the proxy
+func (p *fetchProxy) Close() error {
+	return p.server.Close()
+}
+
+// ServeHTTP tunnels the CONNECT requests to the port 443 of the public hosts, the other requests are refused
+func (p *fetchProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodConnect {
+		http.Error(w, "only https resources may be loaded", http.StatusForbidden)
+		return
+	}
+	host, port, err := net.SplitHostPort(r.Host)
+	if err != nil || port != httpsPort || !utils.IsPublicHost(host) {
+		http.Error(w, "only https resources of public hosts may be loaded", http.StatusForbidden)
+		return
+	}
+
+	upstream, err := p.dialer.DialContext(r.Context(), "tcp", r.Host)
+	if err != nil {
+		log.Debugf("pdf renderer proxy refused to connect to: %s, error: %v", r.Host, err)
+		http.Error(w, "unable to connect to the resource host", http.StatusBadGateway)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close() // nolint
+		http.Error(w, "tunnels are not supported", http.StatusInternalServerError)
+		return
+	}
+	client, _, err := hijacker.Hijack()
+	if err != nil {
+		upstream.Close() // nolint
+		return
+	}
+	defer client.Close()   // nolint
+	defer upstream.Close() // nolint
+
+	if _, err = io.WriteString(client, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+		return
+	}
+	client.SetDeadline(p.deadline)   // nolint
+	upstream.SetDeadline(p.deadline) // nolint
+	go func() {
+		io.Copy(upstream, client) // nolint
+		upstream.Close()          // nolint
+	}()
+	io.Copy(client, upstream) // nolint
+}
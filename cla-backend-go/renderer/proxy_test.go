@@ -0,0 +1,57 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchProxyRefusesNonPublicHosts(t *testing.T) {
+	proxy, err := startFetchProxy(time.Now().Add(time.Minute))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer proxy.Close() // nolint
+
+	proxyURL, err := url.Parse(proxy.URL())
+	if !assert.NoError(t, err) {
+		return
+	}
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}, Timeout: 10 * time.Second}
+
+	for _, resourceURL := range []string{
+		"http://example.org/logo.png",
+		"https://169.254.169.254/latest/meta-data/",
+		"https://127.0.0.1/logo.png",
+		"https://localhost/logo.png",
+		"https://example.org:8443/logo.png",
+	} {
+		resp, err := client.Get(resourceURL) // nolint
+		if err == nil {
+			resp.Body.Close() // nolint
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode, resourceURL)
+			continue
+		}
+		assert.Contains(t, err.Error(), "Forbidden", resourceURL)
+	}
+}
+
+func TestFetchProxyDialerRefusesNonPublicAddresses(t *testing.T) {
+	proxy, err := startFetchProxy(time.Now().Add(time.Minute))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer proxy.Close() // nolint
+
+	// the proxy listens on the loopback address, as a host name resolving to it would
+	_, err = proxy.dialer.Dial("tcp", proxy.listener.Addr().String())
+	assert.True(t, errors.Is(err, utils.ErrNonPublicAddress))
+}
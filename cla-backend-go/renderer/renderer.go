@@ -0,0 +1,40 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/docraptor"
+)
+
+// renderer types selectable in the configuration
+const (
+	TypeDocraptor   = "docraptor"
+	TypeWkhtmltopdf = "wkhtmltopdf"
+	TypeChromium    = "chromium"
+)
+
+// PDFRenderer converts an HTML document into a PDF
+type PDFRenderer interface {
+	CreatePDF(html string, claType string) (io.ReadCloser, error)
+}
+
+// NewPDFRenderer returns the renderer selected in the configuration, DocRaptor is the default
+func NewPDFRenderer(cfg config.Config) (PDFRenderer, error) {
+	switch cfg.PDFRenderer.Type {
+	case "", TypeDocraptor:
+		docraptorClient, err := docraptor.NewDocraptorClient(cfg.Docraptor.APIKey, cfg.Docraptor.TestMode)
+		if err != nil {
+			return nil, err
+		}
+		return docraptorClient, nil
+	case TypeWkhtmltopdf, TypeChromium:
+		return NewLocalRenderer(cfg.PDFRenderer.Type, cfg.PDFRenderer.BinaryPath)
+	default:
+		return nil, fmt.Errorf("unsupported pdf renderer type : %s", cfg.PDFRenderer.Type)
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+)
+
+// getUserEmails returns the union of the LF email and the other email addresses of the user
+func getUserEmails(user *models.User) []string {
+	var emails []string
+	if user.LfEmail != "" {
+		emails = append(emails, strings.TrimSpace(user.LfEmail))
+	}
+	for _, email := range user.Emails {
+		if strings.TrimSpace(email) != "" {
+			emails = append(emails, strings.TrimSpace(email))
+		}
+	}
+	return emails
+}
+
+// approvalMatcher matches email addresses against a wildcard or regular expression approval list entry
+type approvalMatcher struct {
+	re *regexp.Regexp
+	// domainOnly is set when the expression only matches the domain of the email address
+	domainOnly bool
+}
+
+// matches returns true if the email address matches the approval list entry - case insensitive
+func (m approvalMatcher) matches(email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if m.domainOnly {
+		at := strings.LastIndex(email, "@")
+		if at < 0 {
+			return false
+		}
+		email = email[at+1:]
+	}
+	return m.re.MatchString(email)
+}
+
+// buildEmailMatcher converts a wildcard (e.g. *@corp.example.com) or 'regex:' email approval list entry into
+// a matcher of the whole email address
+func buildEmailMatcher(entry string) (*approvalMatcher, error) {
+	entry = strings.TrimSpace(entry)
+	if strings.HasPrefix(entry, ApprovalListRegexPrefix) {
+		re, err := compileApprovalRegex(strings.TrimPrefix(entry, ApprovalListRegexPrefix), true)
+		if err != nil {
+			return nil, err
+		}
+		return &approvalMatcher{re: re}, nil
+	}
+
+	entry = strings.ToLower(entry)
+	at := strings.LastIndex(entry, "@")
+	if at < 0 {
+		return nil, fmt.Errorf("invalid email pattern %s", entry)
+	}
+	local := strings.ReplaceAll(regexp.QuoteMeta(entry[:at]), `\*`, `[^@]*`)
+	domain, err := domainExpression(entry[at+1:])
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(`^` + local + `@` + domain + `$`)
+	if err != nil {
+		return nil, err
+	}
+	return &approvalMatcher{re: re}, nil
+}
+
+// isEmailApproved returns true if one of the emails is in the email approval list or matches one of the
+// wildcard or regular expression entries - case insensitive
+func isEmailApproved(emails []string, emailApprovalList []string) bool {
+	for _, approvedEmail := range emailApprovalList {
+		if !IsApprovalListPattern(approvedEmail) {
+			for _, email := range emails {
+				if strings.EqualFold(strings.TrimSpace(email), strings.TrimSpace(approvedEmail)) {
+					return true
+				}
+			}
+			continue
+		}
+
+		matcher, err := buildEmailMatcher(approvedEmail)
+		if err != nil {
+			continue
+		}
+		for _, email := range emails {
+			if matcher.matches(email) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// domainExpression converts a domain approval list entry into a regular expression matching the domain of an
+// email address. A naked domain (e.g. example.com) only matches that domain. A '*.', '.' or '*' prefix also
+// matches sub-domains, the prefix must be followed by a registrable domain - the entries stored before the
+// validation of the patterns may hold a bare wildcard matching any domain.
+func domainExpression(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if !strings.HasPrefix(domain, "*") && !strings.HasPrefix(domain, ".") {
+		return regexp.QuoteMeta(domain), nil
+	}
+
+	suffix, err := wildcardDomain(domain)
+	if err != nil {
+		return "", err
+	}
+	if err = validateRegistrableDomain(domain, suffix); err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(domain, "*") && !strings.HasPrefix(domain, "*.") {
+		return `.*` + regexp.QuoteMeta(suffix), nil
+	}
+	return `(.*\.)?` + regexp.QuoteMeta(suffix), nil
+}
+
+// buildDomainPattern converts a domain approval list entry into a regular expression matching an email address
+func buildDomainPattern(domain string) (*regexp.Regexp, error) {
+	expression, err := domainExpression(domain)
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile(`^.*@` + expression + `$`)
+}
+
+// buildDomainMatcher converts a domain approval list entry into a matcher of an email address, the 'regex:'
+// entries are matched against the domain of the email address
+func buildDomainMatcher(entry string) (*approvalMatcher, error) {
+	entry = strings.TrimSpace(entry)
+	if strings.HasPrefix(entry, ApprovalListRegexPrefix) {
+		re, err := compileApprovalRegex(strings.TrimPrefix(entry, ApprovalListRegexPrefix), false)
+		if err != nil {
+			return nil, err
+		}
+		return &approvalMatcher{re: re, domainOnly: true}, nil
+	}
+
+	re, err := buildDomainPattern(entry)
+	if err != nil {
+		return nil, err
+	}
+	return &approvalMatcher{re: re}, nil
+}
+
+// isDomainApproved returns true if one of the emails matches one of the domain approval list entries
+func isDomainApproved(emails []string, domainApprovalList []string) bool {
+	for _, domain := range domainApprovalList {
+		matcher, err := buildDomainMatcher(domain)
+		if err != nil {
+			continue
+		}
+		for _, email := range emails {
+			if matcher.matches(email) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsEmailOnApprovedDomain returns true if the email matches one of the domain approval list entries, the entries
+// support the wildcard and regular expression patterns
+func IsEmailOnApprovedDomain(email string, domainApprovalList []string) bool {
+	return isDomainApproved([]string{email}, domainApprovalList)
+}
+
+// isValueApproved returns true if the value is in the approval list - case insensitive
+func isValueApproved(value string, approvalList []string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	for _, approvedValue := range approvalList {
+		if strings.EqualFold(value, strings.TrimSpace(approvedValue)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGitHubOrgApproved returns true if one of the user's GitHub organizations is in the GitHub org approval list
+func isGitHubOrgApproved(userOrganizations []string, githubOrgApprovalList []string) bool {
+	for _, org := range userOrganizations {
+		if isValueApproved(org, githubOrgApprovalList) {
+			return true
+		}
+	}
+	return false
+}
+
+// teamMembershipLookup returns true if the GitHub user is an active member of the organization team
+type teamMembershipLookup func(ctx context.Context, organizationName, teamSlug, githubUsername string) (bool, error)
+
+// isGitHubTeamApproved returns true if the user is a member of one of the teams of the GitHub team approval list.
+// The teams which can't be resolved are skipped, the first lookup error is returned if no team matched.
+func isGitHubTeamApproved(ctx context.Context, githubUsername string, githubTeamApprovalList []string, isTeamMember teamMembershipLookup) (bool, error) {
+	var lookupErr error
+	for _, team := range githubTeamApprovalList {
+		parts := strings.SplitN(strings.TrimSpace(team), "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		member, err := isTeamMember(ctx, parts[0], parts[1], githubUsername)
+		if err != nil {
+			if lookupErr == nil {
+				lookupErr = fmt.Errorf("unable to lookup the members of github team %s - %w", team, err)
+			}
+			continue
+		}
+		if member {
+			return true, nil
+		}
+	}
+	return false, lookupErr
+}
+
+// userOrganizationsLookup returns the GitHub organizations of the user
+type userOrganizationsLookup func(ctx context.Context, githubUsername string) ([]string, error)
+
+// matchApprovalList returns the type of the first approval list of the CCLA signature covering the user, empty if
+// the user is not on any of the approval lists
+func matchApprovalList(ctx context.Context, user *models.User, cclaSignature *models.Signature, userOrganizations userOrganizationsLookup, isTeamMember teamMembershipLookup) (string, error) {
+	emails := getUserEmails(user)
+	if isEmailApproved(emails, cclaSignature.EmailApprovalList) {
+		return ApprovalListTypeEmail, nil
+	}
+	if isDomainApproved(emails, cclaSignature.DomainApprovalList) {
+		return ApprovalListTypeDomain, nil
+	}
+
+	// the GitHub approval lists require the GitHub username of the user
+	if user.GithubUsername == "" {
+		return "", nil
+	}
+	if isValueApproved(user.GithubUsername, cclaSignature.GithubUsernameApprovalList) {
+		return ApprovalListTypeGitHubUsername, nil
+	}
+
+	if len(cclaSignature.GithubOrgApprovalList) > 0 {
+		organizations, err := userOrganizations(ctx, user.GithubUsername)
+		if err != nil {
+			return "", fmt.Errorf("unable to lookup the github organizations of the user %s - %w", user.GithubUsername, err)
+		}
+		if isGitHubOrgApproved(organizations, cclaSignature.GithubOrgApprovalList) {
+			return ApprovalListTypeGitHubOrg, nil
+		}
+	}
+
+	if len(cclaSignature.GithubTeamApprovalList) > 0 {
+		approved, err := isGitHubTeamApproved(ctx, user.GithubUsername, cclaSignature.GithubTeamApprovalList, isTeamMember)
+		if approved {
+			return ApprovalListTypeGitHubTeam, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,157 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserEmails(t *testing.T) {
+	user := &models.User{
+		LfEmail: " user@linuxfoundation.org ",
+		Emails:  []string{"user@example.org", " "},
+	}
+	assert.Equal(t, []string{"user@linuxfoundation.org", "user@example.org"}, getUserEmails(user))
+}
+
+func TestIsEmailApproved(t *testing.T) {
+	approvalList := []string{"Jane.Doe@Example.org"}
+	assert.True(t, isEmailApproved([]string{"other@example.org", "jane.doe@example.org"}, approvalList))
+	assert.False(t, isEmailApproved([]string{"john.doe@example.org"}, approvalList))
+	assert.False(t, isEmailApproved([]string{"jane.doe@example.org"}, nil))
+}
+
+func TestIsEmailApprovedPatterns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		email    string
+		entry    string
+		expected bool
+	}{
+		{name: "wildcard local part", email: "jane.doe@corp.example.com", entry: "*@corp.example.com", expected: true},
+		{name: "wildcard local part rejects other domain", email: "jane.doe@example.com", entry: "*@corp.example.com", expected: false},
+		{name: "wildcard prefix", email: "Build-42@Example.com", entry: "build-*@example.com", expected: true},
+		{name: "wildcard prefix rejects other user", email: "jane.doe@example.com", entry: "build-*@example.com", expected: false},
+		{name: "wildcard sub-domain", email: "jane.doe@emea.example.com", entry: "*@*.example.com", expected: true},
+		{name: "wildcard sub-domain rejects look-alike", email: "jane.doe@badexample.com", entry: "*@*.example.com", expected: false},
+		{name: "regex", email: "build-42@example.com", entry: `regex:^build-[0-9]+@example\.com$`, expected: true},
+		{name: "regex rejects non matching", email: "build-x@example.com", entry: `regex:^build-[0-9]+@example\.com$`, expected: false},
+		{name: "regex too broad is ignored", email: "jane.doe@example.com", entry: `regex:^.*$`, expected: false},
+		{name: "bare wildcard domain is ignored", email: "jane.doe@example.com", entry: "*@*", expected: false},
+		{name: "wildcard public suffix is ignored", email: "jane.doe@example.com", entry: "*@*.com", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isEmailApproved([]string{tc.email}, []string{tc.entry}))
+		})
+	}
+}
+
+func TestIsDomainApproved(t *testing.T) {
+	testCases := []struct {
+		name     string
+		email    string
+		domain   string
+		expected bool
+	}{
+		{name: "naked domain match", email: "user@example.org", domain: "example.org", expected: true},
+		{name: "naked domain case insensitive", email: "User@Example.org", domain: "EXAMPLE.ORG", expected: true},
+		{name: "naked domain rejects sub-domain", email: "user@dev.example.org", domain: "example.org", expected: false},
+		{name: "naked domain dot is not a wildcard", email: "user@exampleXorg", domain: "example.org", expected: false},
+		{name: "star dot prefix matches sub-domain", email: "user@dev.example.org", domain: "*.example.org", expected: true},
+		{name: "star dot prefix matches domain", email: "user@example.org", domain: "*.example.org", expected: true},
+		{name: "dot prefix matches sub-domain", email: "user@dev.example.org", domain: ".example.org", expected: true},
+		{name: "star prefix matches suffix", email: "user@myexample.org", domain: "*example.org", expected: true},
+		{name: "different domain", email: "user@example.com", domain: "example.org", expected: false},
+		{name: "regex matches sub-domain", email: "user@emea.corp.example.com", domain: `regex:^(emea|apac)\.corp\.example\.com$`, expected: true},
+		{name: "regex case insensitive", email: "User@APAC.corp.example.com", domain: `regex:^(emea|apac)\.corp\.example\.com$`, expected: true},
+		{name: "regex rejects other sub-domain", email: "user@amer.corp.example.com", domain: `regex:^(emea|apac)\.corp\.example\.com$`, expected: false},
+		{name: "regex is matched against the domain", email: "emea.corp.example.com@evil.org", domain: `regex:^(emea|apac)\.corp\.example\.com$`, expected: false},
+		{name: "regex too broad is ignored", email: "user@example.com", domain: `regex:^.*\.com$`, expected: false},
+		{name: "bare wildcard is ignored", email: "user@example.com", domain: "*", expected: false},
+		{name: "wildcard without label is ignored", email: "user@example.com.", domain: "*.", expected: false},
+		{name: "dot without label is ignored", email: "user@example.com.", domain: ".", expected: false},
+		{name: "wildcard public suffix is ignored", email: "user@example.com", domain: "*.com", expected: false},
+		{name: "double wildcard is ignored", email: "user@dev.example.com", domain: "*.*.example.com", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isDomainApproved([]string{tc.email}, []string{tc.domain}))
+		})
+	}
+}
+
+func TestIsGitHubApproved(t *testing.T) {
+	assert.True(t, isValueApproved("Octocat", []string{"octocat"}))
+	assert.False(t, isValueApproved("", []string{""}))
+	assert.True(t, isGitHubOrgApproved([]string{"kubernetes", "CNCF"}, []string{"cncf"}))
+	assert.False(t, isGitHubOrgApproved([]string{"kubernetes"}, []string{"cncf"}))
+}
+
+func TestIsGitHubTeamApproved(t *testing.T) {
+	ctx := context.Background()
+	var lookups []string
+	isTeamMember := func(ctx context.Context, organizationName, teamSlug, githubUsername string) (bool, error) {
+		lookups = append(lookups, organizationName+"/"+teamSlug)
+		switch organizationName {
+		case "uninstalled":
+			return false, errors.New("no installation id")
+		case "cncf":
+			return teamSlug == "maintainers" && githubUsername == "octocat", nil
+		}
+		return false, nil
+	}
+
+	approved, err := isGitHubTeamApproved(ctx, "octocat", []string{"uninstalled/team", "malformed", "cncf/sig-release", "cncf/maintainers", "cncf/other"}, isTeamMember)
+	assert.NoError(t, err, "a team matched")
+	assert.True(t, approved)
+	assert.Equal(t, []string{"uninstalled/team", "cncf/sig-release", "cncf/maintainers"}, lookups, "stops at the first team matched")
+
+	approved, err = isGitHubTeamApproved(ctx, "stranger", []string{"uninstalled/team", "cncf/maintainers"}, isTeamMember)
+	assert.Error(t, err, "the lookup error is reported when no team matched")
+	assert.False(t, approved)
+
+	approved, err = isGitHubTeamApproved(ctx, "stranger", []string{"cncf/maintainers"}, isTeamMember)
+	assert.NoError(t, err)
+	assert.False(t, approved)
+}
+
+func TestDomainExpression(t *testing.T) {
+	testCases := []struct {
+		domain     string
+		expression string
+		valid      bool
+	}{
+		{domain: "Example.org", expression: `example\.org`, valid: true},
+		{domain: "*.example.org", expression: `(.*\.)?example\.org`, valid: true},
+		{domain: ".example.org", expression: `(.*\.)?example\.org`, valid: true},
+		{domain: "*example.org", expression: `.*example\.org`, valid: true},
+		{domain: "*"},
+		{domain: "*."},
+		{domain: "."},
+		{domain: "*.."},
+		{domain: "*.*.example.org"},
+		{domain: "*.com"},
+		{domain: "*co.uk"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.domain, func(t *testing.T) {
+			expression, err := domainExpression(tc.domain)
+			if tc.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expression, expression)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// approval list CSV entry types
+const (
+	ApprovalListTypeEmail          = "email"
+	ApprovalListTypeDomain         = "domain"
+	ApprovalListTypeGitHubUsername = "githubUsername"
+	ApprovalListTypeGitHubOrg      = "githubOrg"
+	ApprovalListTypeGitHubTeam     = "githubTeam"
+)
+
+// approval list CSV entry actions
+const (
+	ApprovalListActionAdd    = "add"
+	ApprovalListActionRemove = "remove"
+)
+
+// approvalListCSVHeader is the header row of the approval list CSV documents, the expiresOn column is optional
+var approvalListCSVHeader = []string{"type", "value", "action", "expiresOn"}
+
+// approvalListCSVEntry is a row of an imported approval list CSV document
+type approvalListCSVEntry struct {
+	line      int
+	entryType string
+	value     string
+	action    string
+	expiresOn string
+}
+
+// normalizeApprovalListType returns the approval list type matching the CSV type value - case insensitive
+func normalizeApprovalListType(value string) (string, bool) {
+	for _, entryType := range []string{ApprovalListTypeEmail, ApprovalListTypeDomain, ApprovalListTypeGitHubUsername, ApprovalListTypeGitHubOrg, ApprovalListTypeGitHubTeam} {
+		if strings.EqualFold(strings.TrimSpace(value), entryType) {
+			return entryType, true
+		}
+	}
+	return "", false
+}
+
+// validateApprovalListCSVEntry validates the value of an approval list CSV entry, the values added are validated like
+// the values of the approval list update, the values removed only need to be well-formed
+func validateApprovalListCSVEntry(entry approvalListCSVEntry) error {
+	switch entry.entryType {
+	case ApprovalListTypeEmail:
+		if entry.action == ApprovalListActionRemove && (utils.ValidEmail(entry.value) || IsApprovalListPattern(entry.value)) {
+			return nil
+		}
+		return ValidateEmailApprovalEntry(entry.value)
+	case ApprovalListTypeDomain:
+		if _, valid := utils.ValidDomain(entry.value, true); entry.action == ApprovalListActionRemove && (valid || IsApprovalListPattern(entry.value)) {
+			return nil
+		}
+		return ValidateDomainApprovalEntry(entry.value)
+	case ApprovalListTypeGitHubUsername:
+		if msg, valid := utils.ValidGitHubUsername(entry.value); !valid {
+			return fmt.Errorf("invalid GitHub username %s - %s", entry.value, msg)
+		}
+	case ApprovalListTypeGitHubOrg:
+		if msg, valid := utils.ValidGitHubOrg(entry.value); !valid {
+			return fmt.Errorf("invalid GitHub organization %s - %s", entry.value, msg)
+		}
+	case ApprovalListTypeGitHubTeam:
+		if msg, valid := utils.ValidGitHubTeam(entry.value); !valid {
+			return fmt.Errorf("invalid GitHub team %s - %s", entry.value, msg)
+		}
+	}
+	return nil
+}
+
+// parseApprovalListCSV parses and validates the rows of an approval list CSV document. Each row holds the type,
+// the value, the action (add or remove) and optionally the expiry date of an entry, the header row is optional.
+// All the invalid rows are reported in the returned error.
+func parseApprovalListCSV(data []byte, now time.Time) ([]approvalListCSVEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []approvalListCSVEntry
+	var listOfErrors []string
+	seen := map[string]approvalListCSVEntry{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, NewBadRequestError(fmt.Sprintf("invalid approval list CSV - %s", err))
+		}
+		if len(record) < len(approvalListCSVHeader)-1 || len(record) > len(approvalListCSVHeader) {
+			listOfErrors = append(listOfErrors, fmt.Sprintf("line %d: expecting the columns: %s", line, strings.Join(approvalListCSVHeader, ", ")))
+			continue
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), approvalListCSVHeader[0]) {
+			continue
+		}
+
+		entryType, valid := normalizeApprovalListType(record[0])
+		if !valid {
+			listOfErrors = append(listOfErrors, fmt.Sprintf("line %d: invalid type %s, expecting one of: %s, %s, %s, %s, %s", line, record[0],
+				ApprovalListTypeEmail, ApprovalListTypeDomain, ApprovalListTypeGitHubUsername, ApprovalListTypeGitHubOrg, ApprovalListTypeGitHubTeam))
+			continue
+		}
+		entry := approvalListCSVEntry{
+			line:      line,
+			entryType: entryType,
+			value:     strings.TrimSpace(record[1]),
+			action:    strings.ToLower(strings.TrimSpace(record[2])),
+		}
+		if entry.action != ApprovalListActionAdd && entry.action != ApprovalListActionRemove {
+			listOfErrors = append(listOfErrors, fmt.Sprintf("line %d: invalid action %s, expecting %s or %s", line, record[2], ApprovalListActionAdd, ApprovalListActionRemove))
+			continue
+		}
+		if err := validateApprovalListCSVEntry(entry); err != nil {
+			listOfErrors = append(listOfErrors, fmt.Sprintf("line %d: %s", line, err))
+			continue
+		}
+		if len(record) == len(approvalListCSVHeader) && strings.TrimSpace(record[3]) != "" {
+			if entry.action == ApprovalListActionRemove {
+				listOfErrors = append(listOfErrors, fmt.Sprintf("line %d: an expiry date can only be set on the entries added", line))
+				continue
+			}
+			expiresOn, err := ParseApprovalListExpiry(record[3], now)
+			if err != nil {
+				listOfErrors = append(listOfErrors, fmt.Sprintf("line %d: %s", line, err))
+				continue
+			}
+			entry.expiresOn = expiresOn
+		}
+
+		key := approvalListEntryKey(entry.entryType, entry.value)
+		if previous, ok := seen[key]; ok {
+			if previous.action != entry.action {
+				listOfErrors = append(listOfErrors, fmt.Sprintf("line %d: %s %s is both added and removed, see line %d", line, entry.entryType, entry.value, previous.line))
+			}
+			continue
+		}
+		seen[key] = entry
+		entries = append(entries, entry)
+	}
+
+	if len(listOfErrors) > 0 {
+		return nil, NewBadRequestError(strings.Join(listOfErrors, ", "))
+	}
+	if len(entries) == 0 {
+		return nil, NewBadRequestError("the approval list CSV has no entries")
+	}
+	return entries, nil
+}
+
+// applyApprovalListEntries returns the effective changes of the CSV entries against the approval lists of the
+// signature and the resulting approval lists. Adding a value already in the list, or removing a value which is
+// not in the list, is not a change.
+func applyApprovalListEntries(sig *models.Signature, entries []approvalListCSVEntry) (*models.ApprovalList, *ApprovalLists) {
+	changes := &models.ApprovalList{}
+	lists := signatureApprovalLists(sig)
+
+	for _, entry := range entries {
+		var list *[]string
+		var added, removed *[]string
+		switch entry.entryType {
+		case ApprovalListTypeEmail:
+			list, added, removed = &lists.Emails, &changes.AddEmailApprovalList, &changes.RemoveEmailApprovalList
+		case ApprovalListTypeDomain:
+			list, added, removed = &lists.Domains, &changes.AddDomainApprovalList, &changes.RemoveDomainApprovalList
+		case ApprovalListTypeGitHubUsername:
+			list, added, removed = &lists.GitHubUsernames, &changes.AddGithubUsernameApprovalList, &changes.RemoveGithubUsernameApprovalList
+		case ApprovalListTypeGitHubOrg:
+			list, added, removed = &lists.GitHubOrgs, &changes.AddGithubOrgApprovalList, &changes.RemoveGithubOrgApprovalList
+		case ApprovalListTypeGitHubTeam:
+			list, added, removed = &lists.GitHubTeams, &changes.AddGithubTeamApprovalList, &changes.RemoveGithubTeamApprovalList
+		default:
+			continue
+		}
+
+		stored, exists := findApprovalListValue(entry.value, *list)
+		switch {
+		case entry.action == ApprovalListActionAdd && !exists:
+			*list = append(*list, entry.value)
+			*added = append(*added, entry.value)
+		case entry.action == ApprovalListActionRemove && exists:
+			*list = utils.RemoveItemsFromList(*list, []string{stored})
+			*removed = append(*removed, stored)
+		}
+	}
+
+	return changes, lists
+}
+
+// findApprovalListValue returns the value of the approval list matching the value - case insensitive, like the
+// approval list checks
+func findApprovalListValue(value string, approvalList []string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, approvedValue := range approvalList {
+		if strings.EqualFold(value, strings.TrimSpace(approvedValue)) {
+			return approvedValue, true
+		}
+	}
+	return "", false
+}
+
+// csvApprovalListEntries returns the metadata of the entries added by the approval list CSV document. The values
+// already in the approval lists of the signature without metadata are skipped unless an expiry date is set, so
+// that importing an exported document does not attribute the existing entries to the importer.
+func csvApprovalListEntries(sig *models.Signature, entries []approvalListCSVEntry, addedBy, addedOn string) []*models.ApprovalListEntry {
+	existing := signatureApprovalLists(sig)
+	known := map[string]bool{}
+	for _, entry := range sig.ApprovalListEntries {
+		known[approvalListEntryKey(entry.Type, entry.Value)] = true
+	}
+
+	var added []*models.ApprovalListEntry
+	for _, entry := range entries {
+		if entry.action != ApprovalListActionAdd {
+			continue
+		}
+		value := entry.value
+		if values := approvalListValues(existing, entry.entryType); values != nil {
+			stored, exists := findApprovalListValue(entry.value, *values)
+			if exists {
+				value = stored
+			}
+			if exists && entry.expiresOn == "" && !known[approvalListEntryKey(entry.entryType, stored)] {
+				continue
+			}
+		}
+		added = append(added, &models.ApprovalListEntry{
+			Type:      entry.entryType,
+			Value:     value,
+			ExpiresOn: entry.expiresOn,
+			AddedBy:   addedBy,
+			AddedOn:   addedOn,
+		})
+	}
+	return added
+}
+
+// hasApprovalListChanges returns true if the approval list holds at least one value to add or remove
+func hasApprovalListChanges(changes *models.ApprovalList) bool {
+	return len(changes.AddEmailApprovalList) > 0 || len(changes.RemoveEmailApprovalList) > 0 ||
+		len(changes.AddDomainApprovalList) > 0 || len(changes.RemoveDomainApprovalList) > 0 ||
+		len(changes.AddGithubUsernameApprovalList) > 0 || len(changes.RemoveGithubUsernameApprovalList) > 0 ||
+		len(changes.AddGithubOrgApprovalList) > 0 || len(changes.RemoveGithubOrgApprovalList) > 0 ||
+		len(changes.AddGithubTeamApprovalList) > 0 || len(changes.RemoveGithubTeamApprovalList) > 0
+}
+
+// approvalListChangeEntries returns the values added and removed by the approval list changes, prefixed by their type
+func approvalListChangeEntries(changes *models.ApprovalList) ([]string, []string) {
+	var added, removed []string
+	for _, list := range []struct {
+		entryType string
+		added     []string
+		removed   []string
+	}{
+		{entryType: ApprovalListTypeEmail, added: changes.AddEmailApprovalList, removed: changes.RemoveEmailApprovalList},
+		{entryType: ApprovalListTypeDomain, added: changes.AddDomainApprovalList, removed: changes.RemoveDomainApprovalList},
+		{entryType: ApprovalListTypeGitHubUsername, added: changes.AddGithubUsernameApprovalList, removed: changes.RemoveGithubUsernameApprovalList},
+		{entryType: ApprovalListTypeGitHubOrg, added: changes.AddGithubOrgApprovalList, removed: changes.RemoveGithubOrgApprovalList},
+		{entryType: ApprovalListTypeGitHubTeam, added: changes.AddGithubTeamApprovalList, removed: changes.RemoveGithubTeamApprovalList},
+	} {
+		for _, value := range list.added {
+			added = append(added, list.entryType+" "+value)
+		}
+		for _, value := range list.removed {
+			removed = append(removed, list.entryType+" "+value)
+		}
+	}
+	return added, removed
+}
+
+// approvalListCSV returns the approval lists of the signature, along with the expiry date of the entries, as a CSV
+// document, the document can be edited and imported back as is
+func approvalListCSV(sig *models.Signature) ([]byte, error) {
+	expiries := map[string]string{}
+	for _, entry := range sig.ApprovalListEntries {
+		expiries[approvalListEntryKey(entry.Type, entry.Value)] = entry.ExpiresOn
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	records := [][]string{approvalListCSVHeader}
+	for _, list := range []struct {
+		entryType string
+		values    []string
+	}{
+		{entryType: ApprovalListTypeEmail, values: sig.EmailApprovalList},
+		{entryType: ApprovalListTypeDomain, values: sig.DomainApprovalList},
+		{entryType: ApprovalListTypeGitHubUsername, values: sig.GithubUsernameApprovalList},
+		{entryType: ApprovalListTypeGitHubOrg, values: sig.GithubOrgApprovalList},
+		{entryType: ApprovalListTypeGitHubTeam, values: sig.GithubTeamApprovalList},
+	} {
+		for _, value := range list.values {
+			records = append(records, []string{list.entryType, value, ApprovalListActionAdd, expiries[approvalListEntryKey(list.entryType, value)]})
+		}
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,133 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApprovalListCSV(t *testing.T) {
+	entries, err := parseApprovalListCSV([]byte("type,value,action\nemail, jane.doe@example.org ,add\nDomain,*.corp.example.org,ADD\ngithubUsername,octocat,remove\ngithubOrg,cncf,add\nemail,jane.doe@example.org,add\n"), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, []approvalListCSVEntry{
+		{line: 2, entryType: ApprovalListTypeEmail, value: "jane.doe@example.org", action: ApprovalListActionAdd},
+		{line: 3, entryType: ApprovalListTypeDomain, value: "*.corp.example.org", action: ApprovalListActionAdd},
+		{line: 4, entryType: ApprovalListTypeGitHubUsername, value: "octocat", action: ApprovalListActionRemove},
+		{line: 5, entryType: ApprovalListTypeGitHubOrg, value: "cncf", action: ApprovalListActionAdd},
+	}, entries, "the header row is optional and duplicate rows are ignored")
+
+	entries, err = parseApprovalListCSV([]byte("email,jane.doe@example.org,add"), time.Now())
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestParseApprovalListCSVErrors(t *testing.T) {
+	_, err := parseApprovalListCSV([]byte("type,value,action\nphone,555-0100,add\nemail,jane.doe,add\ndomain,*.com,add\nemail,john.doe@example.org,update\ngithubOrg,cncf,add\ngithubOrg,cncf,remove\n"), time.Now())
+	assert.Error(t, err)
+	assert.IsType(t, &BadRequestError{}, err)
+	assert.Contains(t, err.Error(), "line 2: invalid type phone")
+	assert.Contains(t, err.Error(), "line 3: invalid email jane.doe")
+	assert.Contains(t, err.Error(), "line 4: domain pattern *.com is too broad")
+	assert.Contains(t, err.Error(), "line 5: invalid action update")
+	assert.Contains(t, err.Error(), "line 7: githubOrg cncf is both added and removed, see line 6")
+
+	_, err = parseApprovalListCSV([]byte("email,jane.doe@example.org\n"), time.Now())
+	assert.Error(t, err, "each row has three columns")
+
+	_, err = parseApprovalListCSV([]byte("type,value,action\n"), time.Now())
+	assert.Error(t, err, "no entries")
+
+	_, err = parseApprovalListCSV([]byte("domain,*.com,remove\n"), time.Now())
+	assert.NoError(t, err, "existing entries can be removed")
+
+	now := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	entries, err := parseApprovalListCSV([]byte("email,intern@example.org,add,2021-09-01\n"), now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-09-01T23:59:59Z", entries[0].expiresOn)
+
+	_, err = parseApprovalListCSV([]byte("email,intern@example.org,add,2021-07-01\nemail,jane.doe@example.org,remove,2021-09-01\n"), now)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 1: expiry date 2021-07-01 is in the past")
+	assert.Contains(t, err.Error(), "line 2: an expiry date can only be set on the entries added")
+}
+
+func TestApplyApprovalListEntries(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"jane.doe@example.org", "john.doe@example.org"},
+		DomainApprovalList:         []string{"example.com"},
+		GithubUsernameApprovalList: []string{"octocat"},
+	}
+	entries := []approvalListCSVEntry{
+		{entryType: ApprovalListTypeEmail, value: "jane.doe@example.org", action: ApprovalListActionAdd},
+		{entryType: ApprovalListTypeEmail, value: "john.doe@example.org", action: ApprovalListActionRemove},
+		{entryType: ApprovalListTypeDomain, value: "*.corp.example.org", action: ApprovalListActionAdd},
+		{entryType: ApprovalListTypeGitHubUsername, value: "hubot", action: ApprovalListActionRemove},
+		{entryType: ApprovalListTypeGitHubOrg, value: "cncf", action: ApprovalListActionAdd},
+	}
+
+	changes, lists := applyApprovalListEntries(sig, entries)
+	assert.True(t, hasApprovalListChanges(changes))
+	assert.Empty(t, changes.AddEmailApprovalList, "jane.doe is already approved")
+	assert.Equal(t, []string{"john.doe@example.org"}, changes.RemoveEmailApprovalList)
+	assert.Equal(t, []string{"*.corp.example.org"}, changes.AddDomainApprovalList)
+	assert.Empty(t, changes.RemoveGithubUsernameApprovalList, "hubot is not in the list")
+	assert.Equal(t, []string{"cncf"}, changes.AddGithubOrgApprovalList)
+
+	assert.Equal(t, []string{"jane.doe@example.org"}, lists.Emails)
+	assert.Equal(t, []string{"example.com", "*.corp.example.org"}, lists.Domains)
+	assert.Equal(t, []string{"octocat"}, lists.GitHubUsernames)
+	assert.Equal(t, []string{"cncf"}, lists.GitHubOrgs)
+	assert.Equal(t, []string{"jane.doe@example.org", "john.doe@example.org"}, sig.EmailApprovalList, "the signature is not modified")
+
+	added, removed := approvalListChangeEntries(changes)
+	assert.Equal(t, []string{"domain *.corp.example.org", "githubOrg cncf"}, added)
+	assert.Equal(t, []string{"email john.doe@example.org"}, removed)
+
+	changes, _ = applyApprovalListEntries(sig, entries[:1])
+	assert.False(t, hasApprovalListChanges(changes))
+}
+
+func TestApplyApprovalListEntriesIgnoresCase(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"dev@corp.com", "ops@corp.com"},
+		GithubUsernameApprovalList: []string{"OctoCat"},
+	}
+	entries := []approvalListCSVEntry{
+		{entryType: ApprovalListTypeEmail, value: "Dev@Corp.com", action: ApprovalListActionAdd},
+		{entryType: ApprovalListTypeEmail, value: "OPS@corp.com", action: ApprovalListActionRemove},
+		{entryType: ApprovalListTypeGitHubUsername, value: "octocat", action: ApprovalListActionRemove},
+	}
+
+	changes, lists := applyApprovalListEntries(sig, entries)
+	assert.Empty(t, changes.AddEmailApprovalList, "dev@corp.com is already approved")
+	assert.Equal(t, []string{"ops@corp.com"}, changes.RemoveEmailApprovalList, "the stored value is removed")
+	assert.Equal(t, []string{"OctoCat"}, changes.RemoveGithubUsernameApprovalList)
+	assert.Equal(t, []string{"dev@corp.com"}, lists.Emails)
+	assert.Empty(t, lists.GitHubUsernames)
+}
+
+func TestApprovalListCSVRoundTrip(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:     []string{"jane.doe@example.org"},
+		DomainApprovalList:    []string{"regex:^(emea|apac)\\.example\\.com$"},
+		GithubOrgApprovalList: []string{"cncf"},
+		ApprovalListEntries: []*models.ApprovalListEntry{
+			{Type: ApprovalListTypeEmail, Value: "jane.doe@example.org", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+		},
+	}
+	data, err := approvalListCSV(sig)
+	assert.NoError(t, err)
+	assert.Equal(t, "type,value,action,expiresOn\nemail,jane.doe@example.org,add,2021-09-01T23:59:59Z\ndomain,regex:^(emea|apac)\\.example\\.com$,add,\ngithubOrg,cncf,add,\n", string(data))
+
+	entries, err := parseApprovalListCSV(data, time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	changes, lists := applyApprovalListEntries(sig, entries)
+	assert.False(t, hasApprovalListChanges(changes), "importing the export is a no-op")
+	lists.Entries = mergeApprovalListEntries(sig.ApprovalListEntries, csvApprovalListEntries(sig, entries, "another-manager", "2021-08-01T00:00:00Z"), lists)
+	assert.True(t, approvalListEntriesEqual(sig.ApprovalListEntries, lists.Entries), "the existing entries are not attributed to the importer")
+}
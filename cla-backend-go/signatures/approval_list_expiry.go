@@ -0,0 +1,403 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ApprovalListExpiryReminderWindow is how long before the expiry of approval list entries the CLA Managers are reminded
+const ApprovalListExpiryReminderWindow = 7 * 24 * time.Hour
+
+// ParseApprovalListExpiry parses the expiry date of approval list entries, either a date (YYYY-MM-DD) which expires
+// at the end of the day UTC or an RFC3339 date/time. The expiry must be in the future. Returns the expiry as an
+// RFC3339 UTC string, or an empty string if no expiry is specified.
+func ParseApprovalListExpiry(value string, now time.Time) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	expiresOn, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		date, dateErr := time.Parse("2006-01-02", value)
+		if dateErr != nil {
+			return "", fmt.Errorf("invalid expiry date %s, expecting a date such as 2021-09-01 or an RFC3339 date/time", value)
+		}
+		expiresOn = date.Add(24*time.Hour - time.Second)
+	}
+	if !expiresOn.After(now) {
+		return "", fmt.Errorf("expiry date %s is in the past", value)
+	}
+	return expiresOn.UTC().Format(time.RFC3339), nil
+}
+
+// approvalListEntryExpired returns true if the approval list entry has an expiry date which is not after now
+func approvalListEntryExpired(entry *models.ApprovalListEntry, now time.Time) bool {
+	if entry.ExpiresOn == "" {
+		return false
+	}
+	expiresOn, err := time.Parse(time.RFC3339, entry.ExpiresOn)
+	return err == nil && !expiresOn.After(now)
+}
+
+// approvalListEntryKey returns the key identifying the approval list entry
+func approvalListEntryKey(entryType, value string) string {
+	return entryType + "#" + value
+}
+
+// approvalListValues returns the values of the approval list of the specified entry type
+func approvalListValues(lists *ApprovalLists, entryType string) *[]string {
+	switch entryType {
+	case ApprovalListTypeEmail:
+		return &lists.Emails
+	case ApprovalListTypeDomain:
+		return &lists.Domains
+	case ApprovalListTypeGitHubUsername:
+		return &lists.GitHubUsernames
+	case ApprovalListTypeGitHubOrg:
+		return &lists.GitHubOrgs
+	case ApprovalListTypeGitHubTeam:
+		return &lists.GitHubTeams
+	}
+	return nil
+}
+
+// signatureApprovalLists returns a copy of the approval lists and entry metadata of the signature
+func signatureApprovalLists(sig *models.Signature) *ApprovalLists {
+	return &ApprovalLists{
+		Emails:          append([]string{}, sig.EmailApprovalList...),
+		Domains:         append([]string{}, sig.DomainApprovalList...),
+		GitHubUsernames: append([]string{}, sig.GithubUsernameApprovalList...),
+		GitHubOrgs:      append([]string{}, sig.GithubOrgApprovalList...),
+		GitHubTeams:     append([]string{}, sig.GithubTeamApprovalList...),
+		Entries:         copyApprovalListEntries(sig.ApprovalListEntries),
+	}
+}
+
+// copyApprovalListEntries returns a copy of the approval list entry metadata, so the updated metadata does not change
+// the loaded metadata the updates are conditioned on
+func copyApprovalListEntries(entries []*models.ApprovalListEntry) []*models.ApprovalListEntry {
+	if entries == nil {
+		return nil
+	}
+	copied := make([]*models.ApprovalListEntry, 0, len(entries))
+	for _, entry := range entries {
+		entryCopy := *entry
+		copied = append(copied, &entryCopy)
+	}
+	return copied
+}
+
+// updatedApprovalLists returns the approval lists of the signature with the values of the approval list update added
+// and removed, the values are trimmed and not duplicated
+func updatedApprovalLists(sig *models.Signature, params *models.ApprovalList) *ApprovalLists {
+	return &ApprovalLists{
+		Emails:          updatedApprovalList(sig.EmailApprovalList, params.AddEmailApprovalList, params.RemoveEmailApprovalList),
+		Domains:         updatedApprovalList(sig.DomainApprovalList, params.AddDomainApprovalList, params.RemoveDomainApprovalList),
+		GitHubUsernames: updatedApprovalList(sig.GithubUsernameApprovalList, params.AddGithubUsernameApprovalList, params.RemoveGithubUsernameApprovalList),
+		GitHubOrgs:      updatedApprovalList(sig.GithubOrgApprovalList, params.AddGithubOrgApprovalList, params.RemoveGithubOrgApprovalList),
+		GitHubTeams:     updatedApprovalList(sig.GithubTeamApprovalList, params.AddGithubTeamApprovalList, params.RemoveGithubTeamApprovalList),
+	}
+}
+
+// updatedApprovalList returns the existing values with the values added and removed
+func updatedApprovalList(existing, add, remove []string) []string {
+	var updated []string
+	for _, value := range append(append([]string{}, existing...), add...) {
+		value = strings.TrimSpace(value)
+		if !utils.StringInSlice(value, updated) {
+			updated = append(updated, value)
+		}
+	}
+	return utils.RemoveItemsFromList(updated, remove)
+}
+
+// addedApprovalListEntries returns the metadata of the values added by the approval list update, without an expiry
+// date the added values only clear the expiry date of the values already in the approval lists
+func addedApprovalListEntries(params *models.ApprovalList, addedBy, addedOn, expiresOn string) []*models.ApprovalListEntry {
+	var entries []*models.ApprovalListEntry
+	for _, list := range []struct {
+		entryType string
+		values    []string
+	}{
+		{entryType: ApprovalListTypeEmail, values: params.AddEmailApprovalList},
+		{entryType: ApprovalListTypeDomain, values: params.AddDomainApprovalList},
+		{entryType: ApprovalListTypeGitHubUsername, values: params.AddGithubUsernameApprovalList},
+		{entryType: ApprovalListTypeGitHubOrg, values: params.AddGithubOrgApprovalList},
+		{entryType: ApprovalListTypeGitHubTeam, values: params.AddGithubTeamApprovalList},
+	} {
+		for _, value := range list.values {
+			entries = append(entries, &models.ApprovalListEntry{
+				Type:      list.entryType,
+				Value:     strings.TrimSpace(value),
+				ExpiresOn: expiresOn,
+				AddedBy:   addedBy,
+				AddedOn:   addedOn,
+			})
+		}
+	}
+	return entries
+}
+
+// mergeApprovalListEntries returns the metadata of the approval list entries after an update. Only the entries with
+// an expiry date keep their metadata, the metadata of the values no longer in the approval lists or which no longer
+// expire is dropped. A value added again keeps its original added by/on metadata and takes the new expiry date, the
+// expiry reminder is sent again if the expiry date changed.
+func mergeApprovalListEntries(existing, added []*models.ApprovalListEntry, lists *ApprovalLists) []*models.ApprovalListEntry {
+	var merged []*models.ApprovalListEntry
+	index := map[string]int{}
+	for _, entry := range append(append([]*models.ApprovalListEntry{}, existing...), added...) {
+		values := approvalListValues(lists, entry.Type)
+		if values == nil || !utils.StringInSlice(entry.Value, *values) {
+			continue
+		}
+
+		key := approvalListEntryKey(entry.Type, entry.Value)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			copied := *entry
+			merged = append(merged, &copied)
+			continue
+		}
+		if merged[i].ExpiresOn != entry.ExpiresOn {
+			merged[i].ExpiresOn = entry.ExpiresOn
+			merged[i].ReminderSentOn = ""
+		}
+		if merged[i].AddedBy == "" {
+			merged[i].AddedBy, merged[i].AddedOn = entry.AddedBy, entry.AddedOn
+		}
+	}
+
+	var expiring []*models.ApprovalListEntry
+	for _, entry := range merged {
+		if entry.ExpiresOn != "" {
+			expiring = append(expiring, entry)
+		}
+	}
+	return expiring
+}
+
+// approvalListEntriesEqual returns true if both lists hold the same approval list entry metadata
+func approvalListEntriesEqual(a, b []*models.ApprovalListEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if *a[i] != *b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// expireApprovalListEntries returns the approval list entries of the signature expired at the specified time and
+// the approval lists of the signature without them
+func expireApprovalListEntries(sig *models.Signature, now time.Time) ([]*models.ApprovalListEntry, *ApprovalLists) {
+	lists := signatureApprovalLists(sig)
+	entries := lists.Entries
+	lists.Entries = nil
+
+	var expired []*models.ApprovalListEntry
+	for _, entry := range entries {
+		if !approvalListEntryExpired(entry, now) {
+			lists.Entries = append(lists.Entries, entry)
+			continue
+		}
+		if values := approvalListValues(lists, entry.Type); values != nil {
+			*values = utils.RemoveItemsFromList(*values, []string{entry.Value})
+		}
+		expired = append(expired, entry)
+	}
+	return expired, lists
+}
+
+// approvalListEntriesToRemind returns the approval list entries expiring within the reminder window for which the
+// CLA Managers were not reminded yet
+func approvalListEntriesToRemind(entries []*models.ApprovalListEntry, now time.Time) []*models.ApprovalListEntry {
+	var remind []*models.ApprovalListEntry
+	for _, entry := range entries {
+		if entry.ExpiresOn == "" || entry.ReminderSentOn != "" || approvalListEntryExpired(entry, now) {
+			continue
+		}
+		if approvalListEntryExpired(entry, now.Add(ApprovalListExpiryReminderWindow)) {
+			remind = append(remind, entry)
+		}
+	}
+	return remind
+}
+
+// CLAGroupRepository is the subset of the CLA Group repository used to describe the CLA Group in the notifications
+type CLAGroupRepository interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error)
+}
+
+// ApprovalListExpiryService removes the expired approval list entries and reminds the CLA Managers of the entries
+// about to expire, and applies the employee acknowledgement revocations once effective
+type ApprovalListExpiryService interface {
+	ExpireApprovalListEntries(ctx context.Context, now time.Time) (int, int, error)
+	ApplyEmployeeSignatureRevocations(ctx context.Context, now time.Time) (int, error)
+}
+
+type approvalListExpiryService struct {
+	repo          SignatureRepository
+	claGroupRepo  CLAGroupRepository
+	eventsService events.Service
+}
+
+// NewApprovalListExpiryService creates a new approval list expiry service
+func NewApprovalListExpiryService(repo SignatureRepository, claGroupRepo CLAGroupRepository, eventsService events.Service) ApprovalListExpiryService {
+	return &approvalListExpiryService{
+		repo:          repo,
+		claGroupRepo:  claGroupRepo,
+		eventsService: eventsService,
+	}
+}
+
+// ExpireApprovalListEntries removes the approval list entries expired at the specified time from the CCLA
+// signatures, logging an approval list update event for each signature, then reminds the CLA Managers of the
+// entries expiring within a week. Returns the number of entries removed and the number of entries reminded.
+func (s *approvalListExpiryService) ExpireApprovalListEntries(ctx context.Context, now time.Time) (int, int, error) {
+	f := logrus.Fields{
+		"functionName":   "ExpireApprovalListEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"now":            now,
+	}
+
+	sigs, err := s.repo.GetSignaturesWithApprovalListEntries(ctx)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signatures with approval list entries")
+		return 0, 0, err
+	}
+
+	expiredCount, remindedCount := 0, 0
+	for _, sig := range sigs {
+		expired, lists := expireApprovalListEntries(sig, now)
+		if len(expired) > 0 {
+			updatedSig, updateErr := s.repo.ReplaceApprovalLists(ctx, sig, lists)
+			if updateErr != nil {
+				// The next run picks the signature up again
+				log.WithFields(f).WithError(updateErr).Warnf("unable to remove the expired approval list entries of signature: %s", sig.SignatureID)
+				continue
+			}
+			expiredCount += len(expired)
+			s.logExpiredEntries(sig, expired)
+			sig = updatedSig
+		}
+
+		// The reminder is set on the copy of the metadata, the update is conditioned on the loaded metadata
+		lists := signatureApprovalLists(sig)
+		remind := approvalListEntriesToRemind(lists.Entries, now)
+		if len(remind) == 0 {
+			continue
+		}
+		_, sentOn := utils.CurrentTime()
+		for _, entry := range remind {
+			entry.ReminderSentOn = sentOn
+		}
+		// The reminder is recorded before it is sent, so the CLA Managers are not reminded again on the next run
+		if _, updateErr := s.repo.ReplaceApprovalLists(ctx, sig, lists); updateErr != nil {
+			log.WithFields(f).WithError(updateErr).Warnf("unable to record the expiry reminder of signature: %s", sig.SignatureID)
+			continue
+		}
+		s.sendExpiryReminderEmail(ctx, sig, remind)
+		remindedCount += len(remind)
+	}
+
+	log.WithFields(f).Debugf("removed %d expired approval list entries, reminded %d approval list entries", expiredCount, remindedCount)
+	return expiredCount, remindedCount, nil
+}
+
+// ApplyEmployeeSignatureRevocations revokes the employee acknowledgements whose pending revocation is effective at the
+// specified time. Returns the number of acknowledgements revoked.
+func (s *approvalListExpiryService) ApplyEmployeeSignatureRevocations(ctx context.Context, now time.Time) (int, error) {
+	return s.repo.ApplyDueEmployeeSignatureRevocations(ctx, now.UTC().Format(time.RFC3339))
+}
+
+// logExpiredEntries logs the approval list update event of the entries removed from the signature once expired
+func (s *approvalListExpiryService) logExpiredEntries(sig *models.Signature, expired []*models.ApprovalListEntry) {
+	var removed []string
+	for _, entry := range expired {
+		removed = append(removed, entry.Type+" "+entry.Value)
+	}
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:   events.ClaApprovalListUpdated,
+		ProjectID:   sig.ProjectID,
+		CompanyID:   sig.SignatureReferenceID,
+		LfUsername:  SystemUsername,
+		SignatureID: sig.SignatureID,
+		EventData: &events.CLAApprovalListExpiredData{
+			RemovedEntries: removed,
+		},
+	})
+}
+
+// sendExpiryReminderEmail reminds the CLA Managers of the signature of the approval list entries about to expire
+func (s *approvalListExpiryService) sendExpiryReminderEmail(ctx context.Context, sig *models.Signature, entries []*models.ApprovalListEntry) {
+	f := logrus.Fields{
+		"functionName":   "sendExpiryReminderEmail",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+		"claGroupID":     sig.ProjectID,
+		"companyName":    sig.CompanyName,
+	}
+
+	claGroupModel, err := s.claGroupRepo.GetCLAGroupByID(ctx, sig.ProjectID, false)
+	if err != nil || claGroupModel == nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the CLA Group of the signature - skipping email")
+		return
+	}
+
+	params := emails.ApprovalListExpiryReminderTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			Project:     emails.CLAProjectParams{ExternalProjectName: claGroupModel.ProjectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName: sig.CompanyName,
+		},
+		CorporateConsoleURL: utils.GetCorporateURL(claGroupModel.Version == utils.V2),
+	}
+	for _, entry := range entries {
+		params.Entries = append(params.Entries, emails.ApprovalListExpiryReminderEntryParams{Type: entry.Type, Value: entry.Value, ExpiresOn: entry.ExpiresOn})
+	}
+
+	sent := 0
+	for i := range sig.SignatureACL {
+		email := getBestEmail(&sig.SignatureACL[i])
+		if email == "" {
+			continue
+		}
+		sent++
+
+		// each CLA Manager reads the email in their preferred language
+		params.RecipientName = sig.SignatureACL[i].Username
+		params.RecipientLanguage = sig.SignatureACL[i].PreferredLanguage
+		subject := emails.RenderSubject(emails.ApprovalListExpiryReminderTemplateName,
+			fmt.Sprintf("EasyCLA: Approval List entries expiring for %s on %s", sig.CompanyName, claGroupModel.ProjectName), params)
+		body, err := emails.RenderTemplate(claGroupModel.Version, emails.ApprovalListExpiryReminderTemplateName, emails.ApprovalListExpiryReminderTemplate, params)
+		if err != nil {
+			log.WithFields(f).Warnf("rendering email template : %s failed : %v", emails.ApprovalListExpiryReminderTemplateName, err)
+			return
+		}
+
+		err = utils.SendEmail(subject, body, []string{email}, utils.EmailMetadata{TemplateName: emails.ApprovalListExpiryReminderTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
+		if err != nil {
+			log.WithFields(f).Warnf("problem sending email with subject: %s to recipient: %s, error: %+v", subject, email, err)
+		} else {
+			log.WithFields(f).Debugf("sent email with subject: %s to recipient: %s", subject, email)
+		}
+	}
+	if sent == 0 {
+		log.WithFields(f).Warn("no CLA Manager email address found for the signature - skipping email")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApprovalListExpiry(t *testing.T) {
+	now := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	expiresOn, err := ParseApprovalListExpiry("", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "", expiresOn, "no expiry")
+
+	expiresOn, err = ParseApprovalListExpiry("2021-09-01", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-09-01T23:59:59Z", expiresOn, "a date expires at the end of the day")
+
+	expiresOn, err = ParseApprovalListExpiry("2021-09-01T10:00:00+02:00", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-09-01T08:00:00Z", expiresOn)
+
+	_, err = ParseApprovalListExpiry("2021-08-01T11:00:00Z", now)
+	assert.Error(t, err, "in the past")
+
+	_, err = ParseApprovalListExpiry("next month", now)
+	assert.Error(t, err)
+}
+
+func TestMergeApprovalListEntries(t *testing.T) {
+	existing := []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "intern@example.org", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager", AddedOn: "2021-06-01T00:00:00Z", ReminderSentOn: "2021-08-25T00:00:00Z"},
+		{Type: ApprovalListTypeGitHubUsername, Value: "contractor", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+	}
+	params := &models.ApprovalList{
+		AddEmailApprovalList:     []string{"intern@example.org"},
+		AddGithubOrgApprovalList: []string{"cncf"},
+	}
+	added := addedApprovalListEntries(params, "another-manager", "2021-08-26T00:00:00Z", "2021-12-31T23:59:59Z")
+	assert.Len(t, added, 2)
+
+	lists := &ApprovalLists{
+		Emails:     []string{"intern@example.org", "jane.doe@example.org"},
+		GitHubOrgs: []string{"cncf"},
+	}
+	merged := mergeApprovalListEntries(existing, added, lists)
+	assert.Equal(t, []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "intern@example.org", ExpiresOn: "2021-12-31T23:59:59Z", AddedBy: "cla-manager", AddedOn: "2021-06-01T00:00:00Z"},
+		{Type: ApprovalListTypeGitHubOrg, Value: "cncf", ExpiresOn: "2021-12-31T23:59:59Z", AddedBy: "another-manager", AddedOn: "2021-08-26T00:00:00Z"},
+	}, merged, "the expiry is extended and reminded again, the metadata of removed values is dropped")
+	assert.Equal(t, "2021-08-25T00:00:00Z", existing[0].ReminderSentOn, "the existing entries are not modified")
+
+	assert.True(t, approvalListEntriesEqual(merged, mergeApprovalListEntries(merged, nil, lists)))
+	assert.False(t, approvalListEntriesEqual(existing, merged))
+}
+
+func TestUpdatedApprovalLists(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"intern@example.org", "jane.doe@example.org"},
+		GithubUsernameApprovalList: []string{"contractor"},
+		ApprovalListEntries: []*models.ApprovalListEntry{
+			{Type: ApprovalListTypeEmail, Value: "intern@example.org", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+			{Type: ApprovalListTypeGitHubUsername, Value: "contractor", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+		},
+	}
+	params := &models.ApprovalList{
+		AddEmailApprovalList:             []string{" john.doe@example.org", "jane.doe@example.org"},
+		RemoveGithubUsernameApprovalList: []string{"contractor"},
+		AddDomainApprovalList:            []string{"example.org"},
+	}
+
+	lists := updatedApprovalLists(sig, params)
+	assert.Equal(t, []string{"intern@example.org", "jane.doe@example.org", "john.doe@example.org"}, lists.Emails)
+	assert.Equal(t, []string{"example.org"}, lists.Domains)
+	assert.Empty(t, lists.GitHubUsernames)
+	assert.Equal(t, []string{"contractor"}, sig.GithubUsernameApprovalList, "the signature is not modified")
+
+	lists.Entries = mergeApprovalListEntries(sig.ApprovalListEntries, addedApprovalListEntries(params, "cla-manager", "2021-08-26T00:00:00Z", ""), lists)
+	assert.Equal(t, []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "intern@example.org", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+	}, lists.Entries, "the metadata of the removed entry is dropped in the same update, the values added without expiry have none")
+}
+
+func TestMergeApprovalListEntriesStoresOnlyTheExpiringEntries(t *testing.T) {
+	existing := []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "intern@example.org", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+		{Type: ApprovalListTypeEmail, Value: "contractor@example.org", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+		// stored before only the expiring entries kept their metadata
+		{Type: ApprovalListTypeEmail, Value: "jane.doe@example.org", AddedBy: "cla-manager"},
+	}
+	lists := &ApprovalLists{Emails: []string{"intern@example.org", "contractor@example.org", "jane.doe@example.org"}}
+
+	// adding a value again without an expiry date makes it permanent
+	added := addedApprovalListEntries(&models.ApprovalList{AddEmailApprovalList: []string{"intern@example.org"}}, "another-manager", "2021-08-26T00:00:00Z", "")
+	assert.Equal(t, []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "contractor@example.org", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+	}, mergeApprovalListEntries(existing, added, lists))
+
+	// removing a value prunes its metadata
+	lists.Emails = []string{"intern@example.org", "jane.doe@example.org"}
+	assert.Equal(t, []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "intern@example.org", ExpiresOn: "2021-09-01T23:59:59Z", AddedBy: "cla-manager"},
+	}, mergeApprovalListEntries(existing, nil, lists))
+}
+
+func TestExpireApprovalListEntries(t *testing.T) {
+	now := time.Date(2021, 8, 26, 0, 0, 0, 0, time.UTC)
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"intern@example.org", "jane.doe@example.org"},
+		GithubUsernameApprovalList: []string{"contractor", "octocat"},
+		ApprovalListEntries: []*models.ApprovalListEntry{
+			{Type: ApprovalListTypeEmail, Value: "intern@example.org", ExpiresOn: "2021-08-25T23:59:59Z"},
+			{Type: ApprovalListTypeEmail, Value: "jane.doe@example.org", AddedBy: "cla-manager"},
+			{Type: ApprovalListTypeGitHubUsername, Value: "contractor", ExpiresOn: "2021-09-01T23:59:59Z"},
+		},
+	}
+
+	expired, lists := expireApprovalListEntries(sig, now)
+	assert.Equal(t, []*models.ApprovalListEntry{sig.ApprovalListEntries[0]}, expired)
+	assert.Equal(t, []string{"jane.doe@example.org"}, lists.Emails)
+	assert.Equal(t, []string{"contractor", "octocat"}, lists.GitHubUsernames)
+	assert.Equal(t, sig.ApprovalListEntries[1:], lists.Entries)
+	assert.Len(t, sig.EmailApprovalList, 2, "the signature is not modified")
+
+	remind := approvalListEntriesToRemind(lists.Entries, now)
+	assert.Equal(t, []*models.ApprovalListEntry{sig.ApprovalListEntries[2]}, remind, "contractor expires within a week")
+	assert.Empty(t, approvalListEntriesToRemind(lists.Entries, now.Add(-7*24*time.Hour)), "not within a week yet")
+
+	remind[0].ReminderSentOn = "2021-08-26T00:00:00Z"
+	assert.Empty(t, approvalListEntriesToRemind(lists.Entries, now), "reminded once")
+	assert.Empty(t, sig.ApprovalListEntries[2].ReminderSentOn, "the loaded metadata is not modified")
+}
+
+// fakeExpiryRepository stores one signature and fails the approval list updates conditioned on stale metadata, like
+// the conditional update of the repository
+type fakeExpiryRepository struct {
+	SignatureRepository
+	stored *models.Signature
+}
+
+func (r *fakeExpiryRepository) GetSignaturesWithApprovalListEntries(ctx context.Context) ([]*models.Signature, error) {
+	sig := *r.stored
+	sig.ApprovalListEntries = copyApprovalListEntries(r.stored.ApprovalListEntries)
+	return []*models.Signature{&sig}, nil
+}
+
+func (r *fakeExpiryRepository) ReplaceApprovalLists(ctx context.Context, sig *models.Signature, lists *ApprovalLists) (*models.Signature, error) {
+	if !approvalListEntriesEqual(sig.ApprovalListEntries, r.stored.ApprovalListEntries) {
+		return nil, ErrApprovalListModified
+	}
+	r.stored.ApprovalListEntries = copyApprovalListEntries(lists.Entries)
+	return r.stored, nil
+}
+
+type fakeExpiryCLAGroupRepository struct{}
+
+func (r fakeExpiryCLAGroupRepository) GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error) {
+	return nil, errors.New("not found")
+}
+
+func TestExpireApprovalListEntriesRecordsTheReminder(t *testing.T) {
+	now := time.Now().UTC()
+	repo := &fakeExpiryRepository{stored: &models.Signature{
+		SignatureID:                "sig-1",
+		GithubUsernameApprovalList: []string{"contractor"},
+		ApprovalListEntries: []*models.ApprovalListEntry{
+			{Type: ApprovalListTypeGitHubUsername, Value: "contractor", ExpiresOn: now.Add(48 * time.Hour).Format(time.RFC3339)},
+		},
+	}}
+	service := NewApprovalListExpiryService(repo, fakeExpiryCLAGroupRepository{}, nil)
+
+	expired, reminded, err := service.ExpireApprovalListEntries(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expired)
+	assert.Equal(t, 1, reminded)
+	assert.NotEmpty(t, repo.stored.ApprovalListEntries[0].ReminderSentOn, "the reminder is recorded")
+
+	_, reminded, err = service.ExpireApprovalListEntries(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, reminded, "reminded once")
+}
@@ -0,0 +1,127 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// ParseApprovalListHistoryTime parses the point in time of the approval list history, either a date (YYYY-MM-DD)
+// which means the end of the day UTC or an RFC3339 date/time. Returns now if no time is specified.
+func ParseApprovalListHistoryTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return now.UTC(), nil
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		date, dateErr := time.Parse("2006-01-02", value)
+		if dateErr != nil {
+			return time.Time{}, fmt.Errorf("invalid time %s, expecting a date such as 2021-06-01 or an RFC3339 date/time", value)
+		}
+		at = date.Add(24*time.Hour - time.Second)
+	}
+	return at.UTC(), nil
+}
+
+// ApprovalListSnapshotOf returns a copy of the approval lists of the signature - nil if the signature is nil
+func ApprovalListSnapshotOf(sig *models.Signature) *models.ApprovalListSnapshot {
+	if sig == nil {
+		return nil
+	}
+	return &models.ApprovalListSnapshot{
+		EmailApprovalList:          append([]string{}, sig.EmailApprovalList...),
+		DomainApprovalList:         append([]string{}, sig.DomainApprovalList...),
+		GithubUsernameApprovalList: append([]string{}, sig.GithubUsernameApprovalList...),
+		GithubOrgApprovalList:      append([]string{}, sig.GithubOrgApprovalList...),
+		GithubTeamApprovalList:     append([]string{}, sig.GithubTeamApprovalList...),
+	}
+}
+
+// buildApprovalListHistory reconstructs the approval lists of the signature at the specified time. Each change record
+// holds the entries added and removed by its update, as recorded by the signatures table stream: the approval lists
+// at a point in time are the current approval lists of the signature with the later updates undone, latest first.
+func buildApprovalListHistory(sig *models.Signature, records []*models.Event, at time.Time) *models.ApprovalListHistory {
+	sorted := make([]*models.Event, 0, len(records))
+	for _, record := range records {
+		if record != nil && (record.ApprovalListsAdded != nil || record.ApprovalListsRemoved != nil) {
+			sorted = append(sorted, record)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EventTimeEpoch < sorted[j].EventTimeEpoch
+	})
+
+	history := &models.ApprovalListHistory{
+		SignatureID:   sig.SignatureID,
+		At:            utils.TimeToString(at),
+		ApprovalLists: ApprovalListSnapshotOf(sig),
+		Changes:       []*models.ApprovalListChangeRecord{},
+	}
+	for i := len(sorted) - 1; i >= 0 && sorted[i].EventTimeEpoch > at.Unix(); i-- {
+		undoApprovalListChange(history.ApprovalLists, sorted[i])
+	}
+	for _, record := range sorted {
+		if record.EventTimeEpoch > at.Unix() {
+			break
+		}
+		history.Changes = append(history.Changes, &models.ApprovalListChangeRecord{
+			EventID:      record.EventID,
+			EventType:    record.EventType,
+			EventTime:    record.EventTime,
+			LfUsername:   record.LfUsername,
+			EventSummary: record.EventSummary,
+			Added:        approvalListSnapshotEntries(record.ApprovalListsAdded),
+			Removed:      approvalListSnapshotEntries(record.ApprovalListsRemoved),
+		})
+	}
+	return history
+}
+
+// approvalListSnapshotEntries returns the approval list entries of the snapshot
+func approvalListSnapshotEntries(snapshot *models.ApprovalListSnapshot) []*models.ApprovalListEntry {
+	if snapshot == nil {
+		return nil
+	}
+	var entries []*models.ApprovalListEntry
+	for _, list := range []struct {
+		entryType string
+		values    []string
+	}{
+		{entryType: ApprovalListTypeEmail, values: snapshot.EmailApprovalList},
+		{entryType: ApprovalListTypeDomain, values: snapshot.DomainApprovalList},
+		{entryType: ApprovalListTypeGitHubUsername, values: snapshot.GithubUsernameApprovalList},
+		{entryType: ApprovalListTypeGitHubOrg, values: snapshot.GithubOrgApprovalList},
+		{entryType: ApprovalListTypeGitHubTeam, values: snapshot.GithubTeamApprovalList},
+	} {
+		for _, value := range list.values {
+			entries = append(entries, &models.ApprovalListEntry{Type: list.entryType, Value: value})
+		}
+	}
+	return entries
+}
+
+// undoApprovalListChange reverts the approval list update of the change record on the approval lists: the added
+// entries are removed and the removed entries are added back
+func undoApprovalListChange(lists *models.ApprovalListSnapshot, record *models.Event) {
+	added, removed := record.ApprovalListsAdded, record.ApprovalListsRemoved
+	if added == nil {
+		added = &models.ApprovalListSnapshot{}
+	}
+	if removed == nil {
+		removed = &models.ApprovalListSnapshot{}
+	}
+	lists.EmailApprovalList = updatedApprovalList(lists.EmailApprovalList, removed.EmailApprovalList, added.EmailApprovalList)
+	lists.DomainApprovalList = updatedApprovalList(lists.DomainApprovalList, removed.DomainApprovalList, added.DomainApprovalList)
+	lists.GithubUsernameApprovalList = updatedApprovalList(lists.GithubUsernameApprovalList, removed.GithubUsernameApprovalList, added.GithubUsernameApprovalList)
+	lists.GithubOrgApprovalList = updatedApprovalList(lists.GithubOrgApprovalList, removed.GithubOrgApprovalList, added.GithubOrgApprovalList)
+	lists.GithubTeamApprovalList = updatedApprovalList(lists.GithubTeamApprovalList, removed.GithubTeamApprovalList, added.GithubTeamApprovalList)
+}
@@ -0,0 +1,73 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApprovalListHistoryTime(t *testing.T) {
+	now := time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	at, err := ParseApprovalListHistoryTime("", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now, at)
+
+	at, err = ParseApprovalListHistoryTime("2021-06-01", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 6, 1, 23, 59, 59, 0, time.UTC), at)
+
+	at, err = ParseApprovalListHistoryTime("2021-06-01T12:00:00+02:00", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC), at)
+
+	_, err = ParseApprovalListHistoryTime("yesterday", now)
+	assert.Error(t, err)
+}
+
+func TestBuildApprovalListHistory(t *testing.T) {
+	sig := &models.Signature{
+		SignatureID:        "signature-1",
+		EmailApprovalList:  []string{"jane.doe@example.org"},
+		DomainApprovalList: []string{"example.com"},
+	}
+	day := func(d int) time.Time { return time.Date(2021, 6, d, 12, 0, 0, 0, time.UTC) }
+	// day 1: example.org added, day 3: jane added, day 5: example.org replaced by example.com
+	records := []*models.Event{
+		{EventID: "event-5", EventTimeEpoch: day(5).Unix(), LfUsername: "jdoe",
+			ApprovalListsAdded:   &models.ApprovalListSnapshot{DomainApprovalList: []string{"example.com"}},
+			ApprovalListsRemoved: &models.ApprovalListSnapshot{DomainApprovalList: []string{"example.org"}}},
+		{EventID: "event-1", EventTimeEpoch: day(1).Unix(), ApprovalListsAdded: &models.ApprovalListSnapshot{DomainApprovalList: []string{"example.org"}}},
+		{EventID: "event-2", EventTimeEpoch: day(2).Unix()},
+		{EventID: "event-3", EventTimeEpoch: day(3).Unix(), ApprovalListsAdded: &models.ApprovalListSnapshot{EmailApprovalList: []string{"jane.doe@example.org"}}},
+	}
+
+	history := buildApprovalListHistory(sig, records, day(4))
+	assert.Equal(t, "signature-1", history.SignatureID)
+	assert.Equal(t, []string{"jane.doe@example.org"}, history.ApprovalLists.EmailApprovalList)
+	assert.Equal(t, []string{"example.org"}, history.ApprovalLists.DomainApprovalList)
+	if assert.Len(t, history.Changes, 2, "the events without a change record are ignored") {
+		assert.Equal(t, "event-1", history.Changes[0].EventID)
+		assert.Equal(t, []*models.ApprovalListEntry{{Type: ApprovalListTypeDomain, Value: "example.org"}}, history.Changes[0].Added)
+		assert.Equal(t, "event-3", history.Changes[1].EventID)
+		assert.Equal(t, []*models.ApprovalListEntry{{Type: ApprovalListTypeEmail, Value: "jane.doe@example.org"}}, history.Changes[1].Added)
+	}
+
+	history = buildApprovalListHistory(sig, records, day(6))
+	assert.Equal(t, []string{"example.com"}, history.ApprovalLists.DomainApprovalList)
+	if assert.Len(t, history.Changes, 3) {
+		assert.Equal(t, "jdoe", history.Changes[2].LfUsername)
+		assert.Equal(t, []*models.ApprovalListEntry{{Type: ApprovalListTypeDomain, Value: "example.com"}}, history.Changes[2].Added)
+		assert.Equal(t, []*models.ApprovalListEntry{{Type: ApprovalListTypeDomain, Value: "example.org"}}, history.Changes[2].Removed)
+	}
+
+	history = buildApprovalListHistory(sig, records, day(1).Add(-time.Second))
+	assert.Empty(t, history.ApprovalLists.EmailApprovalList)
+	assert.Empty(t, history.ApprovalLists.DomainApprovalList)
+	assert.Empty(t, history.Changes)
+}
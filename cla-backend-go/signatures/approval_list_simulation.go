@@ -0,0 +1,258 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// the sources of the contributors evaluated by the approval list simulation
+const (
+	SimulationSourceCorporateContributor = "corporateContributor"
+	SimulationSourcePullRequestAuthor    = "pullRequestAuthor"
+)
+
+// PullRequestAuthorsLoader returns the EasyCLA user records of the authors of the open pull requests of the CLA Group
+type PullRequestAuthorsLoader func(ctx context.Context, claGroupID string) ([]*models.User, error)
+
+// simulationCandidate is a contributor evaluated by the approval list simulation
+type simulationCandidate struct {
+	user    *models.User
+	sources []string
+}
+
+// proposedApprovalLists returns the approval lists of the signature once the approval list update is applied, the
+// signature is left untouched
+func proposedApprovalLists(sig *models.Signature, params *models.ApprovalList) *ApprovalLists {
+	return &ApprovalLists{
+		Emails:          applyApprovalListUpdate(sig.EmailApprovalList, params.AddEmailApprovalList, params.RemoveEmailApprovalList),
+		Domains:         applyApprovalListUpdate(sig.DomainApprovalList, params.AddDomainApprovalList, params.RemoveDomainApprovalList),
+		GitHubUsernames: applyApprovalListUpdate(sig.GithubUsernameApprovalList, params.AddGithubUsernameApprovalList, params.RemoveGithubUsernameApprovalList),
+		GitHubOrgs:      applyApprovalListUpdate(sig.GithubOrgApprovalList, params.AddGithubOrgApprovalList, params.RemoveGithubOrgApprovalList),
+		GitHubTeams:     applyApprovalListUpdate(sig.GithubTeamApprovalList, params.AddGithubTeamApprovalList, params.RemoveGithubTeamApprovalList),
+	}
+}
+
+// applyApprovalListUpdate returns the values of the approval list once the entries are added and removed - same
+// rules as the approval list update of the repository
+func applyApprovalListUpdate(existingList, addEntries, removeEntries []string) []string {
+	var updatedList []string
+	for _, value := range append(append([]string{}, existingList...), addEntries...) {
+		if !utils.StringInSlice(strings.TrimSpace(value), updatedList) {
+			updatedList = append(updatedList, strings.TrimSpace(value))
+		}
+	}
+	return utils.RemoveItemsFromList(updatedList, removeEntries)
+}
+
+// buildSimulationCandidates merges the corporate contributors and the pull request authors into a single list of
+// contributors, a contributor found in both is evaluated once with the emails of both records
+func buildSimulationCandidates(contributors []*models.CorporateContributor, pullRequestAuthors []*models.User) []*simulationCandidate {
+	var candidates []*simulationCandidate
+	index := map[string]*simulationCandidate{}
+	add := func(user *models.User, source string) {
+		var keys []string
+		if user.GithubUsername != "" {
+			keys = append(keys, "github:"+strings.ToLower(user.GithubUsername))
+		}
+		if user.LfUsername != "" {
+			keys = append(keys, "lf:"+strings.ToLower(user.LfUsername))
+		}
+		if len(keys) == 0 {
+			for _, email := range getUserEmails(user) {
+				keys = append(keys, "email:"+strings.ToLower(email))
+			}
+		}
+		if len(keys) == 0 {
+			return
+		}
+
+		for _, key := range keys {
+			existing, ok := index[key]
+			if !ok {
+				continue
+			}
+			for _, email := range getUserEmails(user) {
+				if !isValueApproved(email, getUserEmails(existing.user)) {
+					existing.user.Emails = append(existing.user.Emails, email)
+				}
+			}
+			if existing.user.GithubUsername == "" {
+				existing.user.GithubUsername = user.GithubUsername
+			}
+			if existing.user.LfUsername == "" {
+				existing.user.LfUsername = user.LfUsername
+			}
+			if !utils.StringInSlice(source, existing.sources) {
+				existing.sources = append(existing.sources, source)
+			}
+			for _, k := range keys {
+				index[k] = existing
+			}
+			return
+		}
+
+		candidate := &simulationCandidate{user: user, sources: []string{source}}
+		candidates = append(candidates, candidate)
+		for _, key := range keys {
+			index[key] = candidate
+		}
+	}
+
+	for _, contributor := range contributors {
+		add(&models.User{
+			Username:       contributor.Name,
+			LfUsername:     contributor.LinuxFoundationID,
+			LfEmail:        contributor.Email,
+			GithubUsername: contributor.GithubID,
+		}, SimulationSourceCorporateContributor)
+	}
+	for _, author := range pullRequestAuthors {
+		if author == nil {
+			continue
+		}
+		copied := *author
+		copied.Emails = append([]string{}, author.Emails...)
+		add(&copied, SimulationSourcePullRequestAuthor)
+	}
+	return candidates
+}
+
+// coverageEvaluator evaluates the approval list coverage of the contributors. The GitHub organization and team
+// memberships are looked up once per contributor so that the current and the proposed approval lists are evaluated
+// against the same memberships. A failed lookup is logged and counted as not a member.
+type coverageEvaluator struct {
+	userOrganizations func(ctx context.Context, githubUsername string) ([]string, error)
+	isTeamMember      teamMembershipLookup
+	organizations     map[string][]string
+	teamMemberships   map[string]bool
+}
+
+// newCoverageEvaluator creates a coverage evaluator using the GitHub lookup functions
+func newCoverageEvaluator(userOrganizations func(ctx context.Context, githubUsername string) ([]string, error), isTeamMember teamMembershipLookup) *coverageEvaluator {
+	return &coverageEvaluator{
+		userOrganizations: userOrganizations,
+		isTeamMember:      isTeamMember,
+		organizations:     map[string][]string{},
+		teamMemberships:   map[string]bool{},
+	}
+}
+
+// isCovered returns true if the user is covered by one of the approval lists
+func (e *coverageEvaluator) isCovered(ctx context.Context, user *models.User, lists *ApprovalLists) bool {
+	emails := getUserEmails(user)
+	if isEmailApproved(emails, lists.Emails) || isDomainApproved(emails, lists.Domains) ||
+		isValueApproved(user.GithubUsername, lists.GitHubUsernames) {
+		return true
+	}
+	if user.GithubUsername == "" {
+		return false
+	}
+
+	if len(lists.GitHubOrgs) > 0 && isGitHubOrgApproved(e.getOrganizations(ctx, user.GithubUsername), lists.GitHubOrgs) {
+		return true
+	}
+	for _, team := range lists.GitHubTeams {
+		if e.isMemberOfTeam(ctx, user.GithubUsername, team) {
+			return true
+		}
+	}
+	return false
+}
+
+// getOrganizations returns the cached GitHub organizations of the user
+func (e *coverageEvaluator) getOrganizations(ctx context.Context, githubUsername string) []string {
+	key := strings.ToLower(githubUsername)
+	if orgs, ok := e.organizations[key]; ok {
+		return orgs
+	}
+	orgs, err := e.userOrganizations(ctx, githubUsername)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "getOrganizations",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		}).WithError(err).Warnf("unable to lookup github organizations for the user: %s", githubUsername)
+	}
+	e.organizations[key] = orgs
+	return orgs
+}
+
+// isMemberOfTeam returns the cached GitHub team membership of the user
+func (e *coverageEvaluator) isMemberOfTeam(ctx context.Context, githubUsername, team string) bool {
+	key := strings.ToLower(githubUsername + "#" + strings.TrimSpace(team))
+	if member, ok := e.teamMemberships[key]; ok {
+		return member
+	}
+	member, err := isGitHubTeamApproved(ctx, githubUsername, []string{team}, e.isTeamMember)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "isMemberOfTeam",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		}).WithError(err).Warnf("unable to lookup github team %s membership for the user: %s", team, githubUsername)
+	}
+	e.teamMemberships[key] = member
+	return member
+}
+
+// simulateApprovalListCoverage returns the contributors whose coverage differs between the current and the proposed
+// approval lists
+func simulateApprovalListCoverage(ctx context.Context, current, proposed *ApprovalLists, candidates []*simulationCandidate, evaluator *coverageEvaluator) *models.ApprovalListSimulation {
+	simulation := &models.ApprovalListSimulation{
+		EvaluatedCount: int64(len(candidates)),
+		Gained:         []*models.ApprovalListCoverageChange{},
+		Lost:           []*models.ApprovalListCoverageChange{},
+	}
+	for _, candidate := range candidates {
+		before := evaluator.isCovered(ctx, candidate.user, current)
+		after := evaluator.isCovered(ctx, candidate.user, proposed)
+		if before == after {
+			continue
+		}
+
+		var change *models.ApprovalListCoverageChange
+		if after && !candidate.isCorporateContributor() {
+			// a pull request author who is neither a corporate contributor nor approved yet may not work for the
+			// company - only the email domain matched by the proposed approval lists is disclosed
+			change = &models.ApprovalListCoverageChange{
+				Email:    redactEmail(getBestEmail(candidate.user)),
+				Sources:  candidate.sources,
+				Redacted: true,
+			}
+		} else {
+			change = &models.ApprovalListCoverageChange{
+				Name:           candidate.user.Username,
+				LfUsername:     candidate.user.LfUsername,
+				GithubUsername: candidate.user.GithubUsername,
+				Email:          getBestEmail(candidate.user),
+				Sources:        candidate.sources,
+			}
+		}
+		if after {
+			simulation.Gained = append(simulation.Gained, change)
+		} else {
+			simulation.Lost = append(simulation.Lost, change)
+		}
+	}
+	return simulation
+}
+
+// isCorporateContributor returns true if the candidate is a corporate contributor of the CCLA
+func (c *simulationCandidate) isCorporateContributor() bool {
+	return utils.StringInSlice(SimulationSourceCorporateContributor, c.sources)
+}
+
+// redactEmail returns the domain part of the email address
+func redactEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return "*" + email[at:]
+}
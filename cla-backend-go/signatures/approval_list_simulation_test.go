@@ -0,0 +1,139 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProposedApprovalLists(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:     []string{"jane.doe@example.org"},
+		DomainApprovalList:    []string{"example.org", "example.com"},
+		GithubOrgApprovalList: []string{"octo"},
+	}
+	lists := proposedApprovalLists(sig, &models.ApprovalList{
+		AddEmailApprovalList:      []string{" john.doe@example.net", "jane.doe@example.org"},
+		RemoveDomainApprovalList:  []string{"example.com"},
+		AddGithubTeamApprovalList: []string{"octo/maintainers"},
+	})
+
+	assert.Equal(t, []string{"jane.doe@example.org", "john.doe@example.net"}, lists.Emails)
+	assert.Equal(t, []string{"example.org"}, lists.Domains)
+	assert.Equal(t, []string{"octo"}, lists.GitHubOrgs)
+	assert.Equal(t, []string{"octo/maintainers"}, lists.GitHubTeams)
+	assert.Equal(t, []string{"example.org", "example.com"}, sig.DomainApprovalList, "the signature is left untouched")
+}
+
+func TestBuildSimulationCandidates(t *testing.T) {
+	candidates := buildSimulationCandidates([]*models.CorporateContributor{
+		{Name: "Jane Doe", LinuxFoundationID: "jdoe", GithubID: "janedoe", Email: "jane.doe@example.org"},
+		{Name: "John Doe", Email: "john.doe@example.org"},
+	}, []*models.User{
+		{Username: "Jane Doe", GithubUsername: "JaneDoe", Emails: []string{"jane@users.example.net"}},
+		{Username: "Octo Cat", GithubUsername: "octocat"},
+		nil,
+	})
+
+	if assert.Len(t, candidates, 3) {
+		assert.Equal(t, []string{SimulationSourceCorporateContributor, SimulationSourcePullRequestAuthor}, candidates[0].sources)
+		assert.Equal(t, []string{"jane.doe@example.org", "jane@users.example.net"}, getUserEmails(candidates[0].user))
+		assert.Equal(t, []string{SimulationSourceCorporateContributor}, candidates[1].sources)
+		assert.Equal(t, "octocat", candidates[2].user.GithubUsername)
+		assert.Equal(t, []string{SimulationSourcePullRequestAuthor}, candidates[2].sources)
+	}
+}
+
+func TestSimulateApprovalListCoverage(t *testing.T) {
+	sig := &models.Signature{
+		DomainApprovalList:         []string{"example.org"},
+		GithubUsernameApprovalList: []string{"octocat"},
+		GithubOrgApprovalList:      []string{"octo"},
+	}
+	candidates := buildSimulationCandidates([]*models.CorporateContributor{
+		{Name: "Jane Doe", GithubID: "janedoe", Email: "jane.doe@example.org"},
+		{Name: "John Doe", GithubID: "johndoe", Email: "john.doe@example.net"},
+	}, []*models.User{
+		{Username: "Octo Cat", GithubUsername: "octocat"},
+		{Username: "Mona Lisa", GithubUsername: "monalisa", LfEmail: "mona@example.net"},
+		{Username: "Hubot", GithubUsername: "hubot"},
+	})
+
+	orgLookups := 0
+	evaluator := newCoverageEvaluator(func(ctx context.Context, githubUsername string) ([]string, error) {
+		orgLookups++
+		if githubUsername == "hubot" {
+			return nil, errors.New("rate limited")
+		}
+		if githubUsername == "monalisa" {
+			return []string{"octo"}, nil
+		}
+		return nil, nil
+	}, func(ctx context.Context, organizationName, teamSlug, githubUsername string) (bool, error) {
+		return githubUsername == "johndoe" && teamSlug == "maintainers", nil
+	})
+
+	params := &models.ApprovalList{
+		RemoveDomainApprovalList:         []string{"example.org"},
+		RemoveGithubOrgApprovalList:      []string{"octo"},
+		AddGithubTeamApprovalList:        []string{"octo/maintainers"},
+		RemoveGithubUsernameApprovalList: []string{"someone-else"},
+	}
+	simulation := simulateApprovalListCoverage(context.Background(), signatureApprovalLists(sig), proposedApprovalLists(sig, params), candidates, evaluator)
+
+	assert.Equal(t, int64(5), simulation.EvaluatedCount)
+	if assert.Len(t, simulation.Gained, 1) {
+		assert.Equal(t, "johndoe", simulation.Gained[0].GithubUsername)
+		assert.Equal(t, "john.doe@example.net", simulation.Gained[0].Email)
+		assert.Equal(t, []string{SimulationSourceCorporateContributor}, simulation.Gained[0].Sources)
+	}
+	if assert.Len(t, simulation.Lost, 2) {
+		assert.Equal(t, "janedoe", simulation.Lost[0].GithubUsername)
+		assert.Equal(t, "monalisa", simulation.Lost[1].GithubUsername)
+		assert.Equal(t, []string{SimulationSourcePullRequestAuthor}, simulation.Lost[1].Sources)
+	}
+	// the organizations are looked up once per contributor, octocat is approved by username
+	assert.Equal(t, 3, orgLookups)
+}
+
+func TestSimulateApprovalListCoverageRedactsPullRequestAuthors(t *testing.T) {
+	sig := &models.Signature{GithubUsernameApprovalList: []string{"octocat"}}
+	candidates := buildSimulationCandidates([]*models.CorporateContributor{
+		{Name: "Jane Doe", GithubID: "janedoe", Email: "jane.doe@competitor.com"},
+	}, []*models.User{
+		{Username: "Mona Lisa", GithubUsername: "monalisa", LfEmail: "mona@competitor.com"},
+		{Username: "Octo Cat", GithubUsername: "octocat", LfEmail: "octocat@example.org"},
+	})
+	evaluator := newCoverageEvaluator(func(ctx context.Context, githubUsername string) ([]string, error) {
+		return nil, nil
+	}, nil)
+
+	params := &models.ApprovalList{
+		AddDomainApprovalList:            []string{"competitor.com"},
+		RemoveGithubUsernameApprovalList: []string{"octocat"},
+	}
+	simulation := simulateApprovalListCoverage(context.Background(), signatureApprovalLists(sig), proposedApprovalLists(sig, params), candidates, evaluator)
+
+	if assert.Len(t, simulation.Gained, 2) {
+		// the corporate contributor is disclosed
+		assert.Equal(t, "janedoe", simulation.Gained[0].GithubUsername)
+		assert.False(t, simulation.Gained[0].Redacted)
+		// the pull request author who is not approved yet is reduced to the email domain
+		assert.True(t, simulation.Gained[1].Redacted)
+		assert.Equal(t, "*@competitor.com", simulation.Gained[1].Email)
+		assert.Empty(t, simulation.Gained[1].Name)
+		assert.Empty(t, simulation.Gained[1].GithubUsername)
+		assert.Empty(t, simulation.Gained[1].LfUsername)
+	}
+	// the pull request author approved by the company is disclosed
+	if assert.Len(t, simulation.Lost, 1) {
+		assert.Equal(t, "octocat", simulation.Lost[0].GithubUsername)
+		assert.False(t, simulation.Lost[0].Redacted)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"golang.org/x/net/publicsuffix"
+)
+
+// ApprovalListRegexPrefix is the prefix of the email and domain approval list entries holding a regular expression
+const ApprovalListRegexPrefix = "regex:"
+
+// maxApprovalPatternLength is the maximum length of a regular expression approval list entry
+const maxApprovalPatternLength = 255
+
+// IsApprovalListPattern returns true if the approval list entry is a wildcard or regular expression pattern instead of a literal value
+func IsApprovalListPattern(entry string) bool {
+	entry = strings.TrimSpace(entry)
+	return strings.HasPrefix(entry, ApprovalListRegexPrefix) || strings.HasPrefix(entry, ".") || strings.Contains(entry, "*")
+}
+
+// ValidateDomainApprovalEntry validates a domain approval list entry. A literal domain, a wildcard sub-domain
+// (e.g. *.corp.example.com) or an anchored regular expression matching the domain of the email address
+// (e.g. regex:^(emea|apac)\.corp\.example\.com$) is accepted. Patterns which can match a whole public suffix,
+// such as *.com or *.co.uk, are rejected.
+func ValidateDomainApprovalEntry(entry string) error {
+	entry = strings.TrimSpace(entry)
+	if strings.HasPrefix(entry, ApprovalListRegexPrefix) {
+		_, err := compileApprovalRegex(strings.TrimPrefix(entry, ApprovalListRegexPrefix), false)
+		return err
+	}
+
+	domain, err := wildcardDomain(entry)
+	if err != nil {
+		return err
+	}
+	if msg, valid := utils.ValidDomain(domain, false); !valid {
+		return fmt.Errorf("invalid domain %s - %s", entry, msg)
+	}
+	return validateRegistrableDomain(entry, domain)
+}
+
+// ValidateEmailApprovalEntry validates an email approval list entry. A literal email address, an email address
+// with '*' wildcards (e.g. *@corp.example.com or build-*@*.example.com) or an anchored regular expression
+// matching the whole email address (e.g. regex:^build-[0-9]+@example\.com$) is accepted. The domain of the
+// pattern is subject to the same guardrails as the domain approval list entries.
+func ValidateEmailApprovalEntry(entry string) error {
+	entry = strings.TrimSpace(entry)
+	if strings.HasPrefix(entry, ApprovalListRegexPrefix) {
+		_, err := compileApprovalRegex(strings.TrimPrefix(entry, ApprovalListRegexPrefix), true)
+		return err
+	}
+	if !strings.Contains(entry, "*") {
+		if !utils.ValidEmail(entry) {
+			return fmt.Errorf("invalid email %s", entry)
+		}
+		return nil
+	}
+
+	if strings.Count(entry, "@") != 1 {
+		return fmt.Errorf("email pattern %s must contain a single @", entry)
+	}
+	at := strings.Index(entry, "@")
+	local, domain := entry[:at], entry[at+1:]
+	if local == "" || !utils.ValidEmail(strings.ReplaceAll(local, "*", "x")+"@example.org") {
+		return fmt.Errorf("invalid email pattern %s", entry)
+	}
+	if err := ValidateDomainApprovalEntry(domain); err != nil {
+		return fmt.Errorf("invalid email pattern %s - %s", entry, err)
+	}
+	return nil
+}
+
+// ValidateApprovalList validates the email and domain entries added to the approval list, the entries removed
+// from the approval list are not checked so that existing entries can always be removed
+func ValidateApprovalList(params *models.ApprovalList) error {
+	if params == nil {
+		return nil
+	}
+	var listOfErrors []string
+	for _, email := range params.AddEmailApprovalList {
+		if err := ValidateEmailApprovalEntry(email); err != nil {
+			listOfErrors = append(listOfErrors, err.Error())
+		}
+	}
+	for _, domain := range params.AddDomainApprovalList {
+		if err := ValidateDomainApprovalEntry(domain); err != nil {
+			listOfErrors = append(listOfErrors, err.Error())
+		}
+	}
+	if len(listOfErrors) > 0 {
+		return errors.New(strings.Join(listOfErrors, ", "))
+	}
+	return nil
+}
+
+// wildcardDomain returns the domain of the domain approval list entry without its '*.', '*' or '.' wildcard prefix.
+// A bare wildcard, or a wildcard not followed by at least one domain label, is rejected as it would match any domain.
+func wildcardDomain(entry string) (string, error) {
+	domain := strings.ToLower(strings.TrimSpace(entry))
+	for _, prefix := range []string{"*.", "*", "."} {
+		if strings.HasPrefix(domain, prefix) {
+			domain = strings.TrimPrefix(domain, prefix)
+			break
+		}
+	}
+	if domain == "" || strings.HasPrefix(domain, ".") || strings.HasPrefix(domain, "*") {
+		return "", fmt.Errorf("domain pattern %s is too broad - a domain label is required after the wildcard", entry)
+	}
+	return domain, nil
+}
+
+// validateRegistrableDomain returns an error if the domain of the pattern is a public suffix, such as com or
+// co.uk, as the pattern would approve the addresses of any organization
+func validateRegistrableDomain(entry, domain string) error {
+	if _, err := publicsuffix.EffectiveTLDPlusOne(domain); err != nil {
+		return fmt.Errorf("domain pattern %s is too broad - %s is a public suffix", entry, domain)
+	}
+	return nil
+}
+
+// compileApprovalRegex compiles the regular expression of an approval list entry. The expression must be anchored
+// with ^ and $ and must end with a literal domain, e.g. \.example\.com or @example\.com, which is checked against
+// the public suffix list. The expression is matched case insensitive.
+func compileApprovalRegex(expr string, email bool) (*regexp.Regexp, error) {
+	expr = strings.TrimSpace(expr)
+	if len(expr) > maxApprovalPatternLength {
+		return nil, fmt.Errorf("regular expression length is %d, can't exceed %d", len(expr), maxApprovalPatternLength)
+	}
+	re, err := regexp.Compile("(?i)" + expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %s - %s", expr, err)
+	}
+
+	tree, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %s - %s", expr, err)
+	}
+	if tree.Op != syntax.OpConcat || len(tree.Sub) < 3 ||
+		tree.Sub[0].Op != syntax.OpBeginText || tree.Sub[len(tree.Sub)-1].Op != syntax.OpEndText {
+		return nil, fmt.Errorf("regular expression %s must start with ^ and end with $", expr)
+	}
+	last := tree.Sub[len(tree.Sub)-2]
+	if last.Op != syntax.OpLiteral {
+		return nil, fmt.Errorf("regular expression %s must end with a literal domain such as \\.example\\.com", expr)
+	}
+
+	suffix := strings.ToLower(string(last.Rune))
+	var domain string
+	switch {
+	case strings.Contains(suffix, "@"):
+		domain = suffix[strings.LastIndex(suffix, "@")+1:]
+	case strings.HasPrefix(suffix, "."):
+		domain = strings.TrimPrefix(suffix, ".")
+	case !email && len(tree.Sub) == 3:
+		domain = suffix
+	default:
+		return nil, fmt.Errorf("regular expression %s must end with a literal domain such as \\.example\\.com", expr)
+	}
+	if msg, valid := utils.ValidDomain(domain, false); !valid {
+		return nil, fmt.Errorf("invalid domain %s in regular expression %s - %s", domain, expr, msg)
+	}
+	if err := validateRegistrableDomain(expr, domain); err != nil {
+		return nil, err
+	}
+	return re, nil
+}
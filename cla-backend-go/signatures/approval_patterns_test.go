@@ -0,0 +1,101 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDomainApprovalEntry(t *testing.T) {
+	testCases := []struct {
+		name  string
+		entry string
+		valid bool
+	}{
+		{name: "naked domain", entry: "example.org", valid: true},
+		{name: "wildcard sub-domain", entry: "*.corp.example.com", valid: true},
+		{name: "dot prefix", entry: ".example.org", valid: true},
+		{name: "wildcard public suffix", entry: "*.com", valid: false},
+		{name: "wildcard multi-label public suffix", entry: "*.co.uk", valid: false},
+		{name: "wildcard registrable domain under multi-label public suffix", entry: "*.example.co.uk", valid: true},
+		{name: "wildcard only", entry: "*", valid: false},
+		{name: "wildcard dot only", entry: "*.", valid: false},
+		{name: "dot only", entry: ".", valid: false},
+		{name: "wildcard without label", entry: "*..example.com", valid: false},
+		{name: "double wildcard", entry: "*.*.example.com", valid: false},
+		{name: "wildcard prefix", entry: "*example.org", valid: true},
+		{name: "public suffix", entry: "com", valid: false},
+		{name: "wildcard in the middle", entry: "emea.*.example.com", valid: false},
+		{name: "regex", entry: `regex:^(emea|apac)\.corp\.example\.com$`, valid: true},
+		{name: "regex literal domain", entry: `regex:^example\.com$`, valid: true},
+		{name: "regex case insensitive flag", entry: `regex:(?i)^[a-z]+\.example\.com$`, valid: true},
+		{name: "regex not anchored", entry: `regex:[a-z]+\.example\.com`, valid: false},
+		{name: "regex top level alternation", entry: `regex:^a\.example\.com$|^.*$`, valid: false},
+		{name: "regex public suffix", entry: `regex:^.*\.com$`, valid: false},
+		{name: "regex without label boundary", entry: `regex:^.*example\.com$`, valid: false},
+		{name: "regex unescaped dot", entry: `regex:^.*\.example.com$`, valid: false},
+		{name: "regex does not compile", entry: `regex:^(a\.example\.com$`, valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDomainApprovalEntry(tc.entry)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateEmailApprovalEntry(t *testing.T) {
+	testCases := []struct {
+		name  string
+		entry string
+		valid bool
+	}{
+		{name: "literal email", entry: "jane.doe@example.org", valid: true},
+		{name: "invalid email", entry: "jane.doe", valid: false},
+		{name: "wildcard local part", entry: "*@corp.example.com", valid: true},
+		{name: "wildcard local part and sub-domain", entry: "build-*@*.example.com", valid: true},
+		{name: "wildcard public suffix", entry: "*@*.com", valid: false},
+		{name: "wildcard domain", entry: "*@*", valid: false},
+		{name: "wildcard without domain", entry: "build-*", valid: false},
+		{name: "regex", entry: `regex:^build-[0-9]+@example\.com$`, valid: true},
+		{name: "regex sub-domain", entry: `regex:^[a-z.]+@[a-z]+\.example\.com$`, valid: true},
+		{name: "regex public suffix", entry: `regex:^.*@.*\.org$`, valid: false},
+		{name: "regex without domain", entry: `regex:^build-[0-9]+$`, valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateEmailApprovalEntry(tc.entry)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateApprovalList(t *testing.T) {
+	assert.NoError(t, ValidateApprovalList(&models.ApprovalList{
+		AddDomainApprovalList:    []string{"*.corp.example.com"},
+		AddEmailApprovalList:     []string{"*@example.org"},
+		RemoveDomainApprovalList: []string{"*.com"},
+	}), "removed entries are not checked")
+
+	err := ValidateApprovalList(&models.ApprovalList{
+		AddDomainApprovalList: []string{"*.com", "example.org"},
+		AddEmailApprovalList:  []string{"*@*.net"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "*.com")
+	assert.Contains(t, err.Error(), "*@*.net")
+}
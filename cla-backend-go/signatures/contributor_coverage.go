@@ -0,0 +1,148 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// the actions a contributor is missing to be covered by a CLA
+const (
+	// MissingActionSignICLA - the contributor needs to sign the ICLA
+	MissingActionSignICLA = "signIcla"
+	// MissingActionConfirmAffiliation - the contributor is on a CCLA approval list and needs to acknowledge the CCLA
+	MissingActionConfirmAffiliation = "confirmAffiliation"
+	// MissingActionRequestApproval - the company of the contributor signed the CCLA, the contributor needs to be
+	// added to one of its approval lists
+	MissingActionRequestApproval = "requestApproval"
+	// MissingActionCompanySignCCLA - the company of the contributor needs to sign the CCLA
+	MissingActionCompanySignCCLA = "companySignCcla"
+)
+
+// ContributorCoverage is the CLA coverage of a contributor for a CLA Group
+type ContributorCoverage struct {
+	Covered bool
+	// SignatureType is the type of the signature covering the contributor, or of the CCLA approving the contributor
+	SignatureType    string
+	SignatureID      string
+	CompanyID        string
+	CompanyName      string
+	ApprovalListType string
+	// MissingAction is the action the contributor needs to take to be covered, empty if covered
+	MissingAction string
+}
+
+// GetContributorCoverage evaluates the ICLA, the company CCLA approval lists and the employee acknowledgement of the
+// contributor for the CLA Group, as the CLA check of the pull requests does. The contributor may not have a user
+// record, in which case the contributor is not covered.
+func (s service) GetContributorCoverage(ctx context.Context, user *models.User, claGroupModel *models.ClaGroup) (*ContributorCoverage, error) {
+	coverage, err := s.evaluateCoverage(ctx, user, claGroupModel.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !coverage.Covered && coverage.MissingAction == "" {
+		coverage.MissingAction = missingCoverageAction(claGroupModel)
+	}
+	return coverage, nil
+}
+
+// evaluateCoverage evaluates the ICLA of the user, then the CCLA of the company of the user along with the employee
+// acknowledgement. The missing action is only set when the company of the user signed the CCLA.
+func (s service) evaluateCoverage(ctx context.Context, user *models.User, claGroupID string) (*ContributorCoverage, error) {
+	f := logrus.Fields{
+		"functionName":   "evaluateCoverage",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         user.UserID,
+		"claGroupID":     claGroupID,
+		"companyID":      user.CompanyID,
+	}
+
+	if user.UserID == "" {
+		log.WithFields(f).Debug("contributor has no user record")
+		return &ContributorCoverage{}, nil
+	}
+
+	iclaSignature, err := s.repo.GetIndividualSignature(ctx, claGroupID, user.UserID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem looking up ICLA signature for user")
+		return nil, err
+	}
+	now := time.Now()
+	if iclaSignature != nil {
+		if !resignatureExpired(iclaSignature, now) {
+			log.WithFields(f).Debugf("user has signed an ICLA: %s", iclaSignature.SignatureID)
+			return &ContributorCoverage{
+				Covered:       true,
+				SignatureType: utils.ClaTypeICLA,
+				SignatureID:   iclaSignature.SignatureID,
+			}, nil
+		}
+		log.WithFields(f).Debugf("user ICLA: %s was not re-signed by the deadline: %s", iclaSignature.SignatureID, iclaSignature.ResignDeadline)
+	}
+
+	if user.CompanyID == "" {
+		log.WithFields(f).Debug("user has no ICLA and is not associated with a company")
+		return &ContributorCoverage{}, nil
+	}
+
+	cclaSignature, err := s.repo.GetCorporateSignature(ctx, claGroupID, user.CompanyID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem looking up CCLA signature for company")
+		return nil, err
+	}
+	if cclaSignature == nil {
+		log.WithFields(f).Debug("company has not signed a CCLA for this CLA Group")
+		return &ContributorCoverage{}, nil
+	}
+	if resignatureExpired(cclaSignature, now) {
+		log.WithFields(f).Debugf("company CCLA: %s was not re-signed by the deadline: %s", cclaSignature.SignatureID, cclaSignature.ResignDeadline)
+		return &ContributorCoverage{}, nil
+	}
+
+	coverage := &ContributorCoverage{
+		SignatureType: utils.ClaTypeCCLA,
+		SignatureID:   cclaSignature.SignatureID,
+		CompanyID:     cclaSignature.SignatureReferenceID,
+		CompanyName:   cclaSignature.CompanyName,
+		MissingAction: MissingActionRequestApproval,
+	}
+	coverage.ApprovalListType, err = matchApprovalList(ctx, user, cclaSignature, github.GetUserOrganizations, github.IsUserTeamMember)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to check the approval lists for the user")
+		return nil, err
+	}
+	if coverage.ApprovalListType == "" {
+		log.WithFields(f).Debug("user is not on the approval list of the company CCLA")
+		return coverage, nil
+	}
+
+	eclaSignature, err := s.repo.GetEmployeeSignature(ctx, claGroupID, user.CompanyID, user.UserID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem looking up employee acknowledgement signature for user")
+		return nil, err
+	}
+	if eclaSignature == nil {
+		log.WithFields(f).Debug("user is approved but has not confirmed their affiliation with the company")
+		coverage.MissingAction = MissingActionConfirmAffiliation
+		return coverage, nil
+	}
+
+	coverage.Covered, coverage.MissingAction = true, ""
+	return coverage, nil
+}
+
+// missingCoverageAction returns the action of a contributor whose company did not sign the CCLA
+func missingCoverageAction(claGroupModel *models.ClaGroup) string {
+	if claGroupModel.ProjectICLAEnabled {
+		return MissingActionSignICLA
+	}
+	return MissingActionCompanySignCCLA
+}
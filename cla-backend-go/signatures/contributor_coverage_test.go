@@ -0,0 +1,60 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingCoverageAction(t *testing.T) {
+	iclaGroup := &models.ClaGroup{ProjectICLAEnabled: true, ProjectCCLAEnabled: true}
+	cclaOnlyGroup := &models.ClaGroup{ProjectCCLAEnabled: true}
+
+	assert.Equal(t, MissingActionSignICLA, missingCoverageAction(iclaGroup))
+	assert.Equal(t, MissingActionCompanySignCCLA, missingCoverageAction(cclaOnlyGroup))
+}
+
+func TestMatchApprovalList(t *testing.T) {
+	ctx := context.Background()
+	organizations := func(ctx context.Context, githubUsername string) ([]string, error) {
+		return []string{"acme"}, nil
+	}
+	isTeamMember := func(ctx context.Context, organizationName, teamSlug, githubUsername string) (bool, error) {
+		return organizationName == "acme" && teamSlug == "devs", nil
+	}
+	user := &models.User{LfEmail: "jane.doe@example.org", GithubUsername: "janedoe"}
+
+	tests := []struct {
+		name      string
+		user      *models.User
+		signature *models.Signature
+		expected  string
+	}{
+		{"email", user, &models.Signature{EmailApprovalList: []string{"jane.doe@example.org"}, DomainApprovalList: []string{"example.org"}}, ApprovalListTypeEmail},
+		{"domain", user, &models.Signature{DomainApprovalList: []string{"example.org"}}, ApprovalListTypeDomain},
+		{"github username", user, &models.Signature{GithubUsernameApprovalList: []string{"JaneDoe"}}, ApprovalListTypeGitHubUsername},
+		{"github org", user, &models.Signature{GithubOrgApprovalList: []string{"acme"}}, ApprovalListTypeGitHubOrg},
+		{"github team", user, &models.Signature{GithubTeamApprovalList: []string{"acme/devs"}}, ApprovalListTypeGitHubTeam},
+		{"not approved", user, &models.Signature{DomainApprovalList: []string{"example.com"}, GithubOrgApprovalList: []string{"other"}}, ""},
+		{"github lists without username", &models.User{LfEmail: "jane.doe@example.org"}, &models.Signature{GithubOrgApprovalList: []string{"acme"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listType, err := matchApprovalList(ctx, tt.user, tt.signature, organizations, isTeamMember)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, listType)
+		})
+	}
+
+	failingOrganizations := func(ctx context.Context, githubUsername string) ([]string, error) {
+		return nil, errors.New("rate limited")
+	}
+	_, err := matchApprovalList(ctx, user, &models.Signature{GithubOrgApprovalList: []string{"acme"}}, failingOrganizations, isTeamMember)
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ package signatures
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"sync"
 
@@ -69,6 +70,12 @@ func (repo repository) buildProjectSignatureModels(ctx context.Context, results
 			SignatureApproved:           dbSignature.SignatureApproved,
 			SignatureMajorVersion:       dbSignature.SignatureDocumentMajorVersion,
 			SignatureMinorVersion:       dbSignature.SignatureDocumentMinorVersion,
+			ResignMajorVersion:          dbSignature.SignatureResignMajorVersion,
+			ResignDeadline:              dbSignature.SignatureResignDeadline,
+			RevokedOn:                   dbSignature.SignatureRevokedOn,
+			RevokedBy:                   dbSignature.SignatureRevokedBy,
+			RevocationReason:            dbSignature.SignatureRevocationReason,
+			RevocationEffectiveDate:     dbSignature.SignatureRevocationDate,
 			Version:                     dbSignature.SignatureDocumentMajorVersion + "." + dbSignature.SignatureDocumentMinorVersion,
 			SignatureReferenceType:      dbSignature.SignatureReferenceType,
 			ProjectID:                   dbSignature.SignatureProjectID,
@@ -78,6 +85,8 @@ func (repo repository) buildProjectSignatureModels(ctx context.Context, results
 			DomainApprovalList:          dbSignature.DomainWhitelist,
 			GithubUsernameApprovalList:  dbSignature.GitHubWhitelist,
 			GithubOrgApprovalList:       dbSignature.GitHubOrgWhitelist,
+			GithubTeamApprovalList:      dbSignature.GitHubTeamApprovalList,
+			ApprovalListEntries:         approvalListEntryModels(dbSignature.ApprovalListMetadata),
 			UserName:                    dbSignature.UserName,
 			UserLFID:                    dbSignature.UserLFUsername,
 			UserGHID:                    dbSignature.UserGithubUsername,
@@ -277,55 +286,62 @@ func buildResponse(items []*dynamodb.AttributeValue) []models.GithubOrg {
 	return orgs
 }
 
-// buildApprovalAttributeList builds the updated approval list based on the added and removed values
-func buildApprovalAttributeList(ctx context.Context, existingList, addEntries, removeEntries []string) *dynamodb.AttributeValue {
-	f := logrus.Fields{
-		"functionName":   "buildApprovalAttributeList",
-		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
-	}
-	var updatedList []string
-	log.WithFields(f).Debugf("buildApprovalAttributeList - existing: %+v, add entries: %+v, remove entries: %+v",
-		existingList, addEntries, removeEntries)
-
-	// Add the existing entries to our response
-	for _, value := range existingList {
-		// No duplicates allowed
-		if !utils.StringInSlice(value, updatedList) {
-			log.WithFields(f).Debugf("buildApprovalAttributeList - adding existing entry: %s", value)
-			updatedList = append(updatedList, strings.TrimSpace(value))
-		} else {
-			log.WithFields(f).Debugf("buildApprovalAttributeList - skipping existing entry: %s", value)
-		}
-	}
-
-	// For all the new values...
-	for _, value := range addEntries {
-		// No duplicates allowed
-		if !utils.StringInSlice(value, updatedList) {
-			log.WithFields(f).Debugf("buildApprovalAttributeList - adding new entry: %s", value)
-			updatedList = append(updatedList, strings.TrimSpace(value))
-		} else {
-			log.WithFields(f).Debugf("buildApprovalAttributeList - skipping new entry: %s", value)
-		}
+func buildTeamResponse(teamIDs []string) []models.GithubTeam {
+	// Convert to a response model, sorted by the team ID
+	teams := []models.GithubTeam{}
+	for _, teamID := range teamIDs {
+		selected := true
+		teams = append(teams, models.GithubTeam{
+			ID:       aws.String(teamID),
+			Selected: &selected,
+		})
 	}
+	sort.Slice(teams, func(i, j int) bool {
+		return strings.ToLower(*teams[i].ID) < strings.ToLower(*teams[j].ID)
+	})
 
-	// Remove the items
-	log.WithFields(f).Debugf("buildApprovalAttributeList - before: %+v - removing entries: %+v", updatedList, removeEntries)
-	updatedList = utils.RemoveItemsFromList(updatedList, removeEntries)
-	log.WithFields(f).Debugf("buildApprovalAttributeList - after: %+v - removing entries: %+v", updatedList, removeEntries)
+	return teams
+}
 
-	// Remove any duplicates - shouldn't have any if checked before adding
-	log.WithFields(f).Debugf("buildApprovalAttributeList - before: %+v - removing duplicates", updatedList)
-	updatedList = utils.RemoveDuplicates(updatedList)
-	log.WithFields(f).Debugf("buildApprovalAttributeList - after: %+v - removing duplicates", updatedList)
+// approvalListAttribute converts the approval list values, as is, into a DynamoDB list attribute value
+func approvalListAttribute(values []string) *dynamodb.AttributeValue {
+	var list []*dynamodb.AttributeValue
+	for _, value := range values {
+		list = append(list, &dynamodb.AttributeValue{S: aws.String(value)})
+	}
+	return &dynamodb.AttributeValue{L: list}
+}
 
-	// Convert to the response type
-	var responseList []*dynamodb.AttributeValue
-	for _, value := range updatedList {
-		responseList = append(responseList, &dynamodb.AttributeValue{S: aws.String(value)})
+// approvalListEntryModels converts the approval list entry database models into the response models
+func approvalListEntryModels(items []ItemApprovalListEntry) []*models.ApprovalListEntry {
+	var entries []*models.ApprovalListEntry
+	for _, item := range items {
+		entries = append(entries, &models.ApprovalListEntry{
+			Type:           item.Type,
+			Value:          item.Value,
+			ExpiresOn:      item.ExpiresOn,
+			AddedBy:        item.AddedBy,
+			AddedOn:        item.AddedOn,
+			ReminderSentOn: item.ReminderSentOn,
+		})
 	}
+	return entries
+}
 
-	return &dynamodb.AttributeValue{L: responseList}
+// approvalListMetadataAttribute converts the approval list entries into the approval list metadata attribute value
+func approvalListMetadataAttribute(entries []*models.ApprovalListEntry) (*dynamodb.AttributeValue, error) {
+	items := make([]ItemApprovalListEntry, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, ItemApprovalListEntry{
+			Type:           entry.Type,
+			Value:          entry.Value,
+			ExpiresOn:      entry.ExpiresOn,
+			AddedBy:        entry.AddedBy,
+			AddedOn:        entry.AddedOn,
+			ReminderSentOn: entry.ReminderSentOn,
+		})
+	}
+	return dynamodbattribute.Marshal(items)
 }
 
 // buildCompanyIDList is a helper function to convert the DB response models into a simple list of company IDs
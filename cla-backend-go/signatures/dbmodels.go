@@ -5,34 +5,53 @@ package signatures
 
 // ItemSignature database model
 type ItemSignature struct {
-	SignatureID                   string   `json:"signature_id"`
-	DateCreated                   string   `json:"date_created"`
-	DateModified                  string   `json:"date_modified"`
-	SignatureApproved             bool     `json:"signature_approved"`
-	SignatureSigned               bool     `json:"signature_signed"`
-	SignatureDocumentMajorVersion string   `json:"signature_document_major_version"`
-	SignatureDocumentMinorVersion string   `json:"signature_document_minor_version"`
-	SignatureReferenceID          string   `json:"signature_reference_id"`
-	SignatureReferenceName        string   `json:"signature_reference_name"`
-	SignatureReferenceNameLower   string   `json:"signature_reference_name_lower"`
-	SignatureProjectID            string   `json:"signature_project_id"`
-	SignatureReferenceType        string   `json:"signature_reference_type"`
-	SignatureType                 string   `json:"signature_type"`
-	SignatureUserCompanyID        string   `json:"signature_user_ccla_company_id"`
-	EmailWhitelist                []string `json:"email_whitelist"`
-	DomainWhitelist               []string `json:"domain_whitelist"`
-	GitHubWhitelist               []string `json:"github_whitelist"`
-	GitHubOrgWhitelist            []string `json:"github_org_whitelist"`
-	SignatureACL                  []string `json:"signature_acl"`
-	UserGithubUsername            string   `json:"user_github_username"`
-	UserLFUsername                string   `json:"user_lf_username"`
-	UserName                      string   `json:"user_name"`
-	UserEmail                     string   `json:"user_email"`
-	SigtypeSignedApprovedID       string   `json:"sigtype_signed_approved_id"`
-	SignedOn                      string   `json:"signed_on"`
-	SignatoryName                 string   `json:"signatory_name"`
-	UserDocusignName              string   `json:"user_docusign_name"`
-	UserDocusignDateSigned        string   `json:"user_docusign_date_signed"`
+	SignatureID                   string                  `json:"signature_id"`
+	DateCreated                   string                  `json:"date_created"`
+	DateModified                  string                  `json:"date_modified"`
+	SignatureApproved             bool                    `json:"signature_approved"`
+	SignatureSigned               bool                    `json:"signature_signed"`
+	SignatureDocumentMajorVersion string                  `json:"signature_document_major_version"`
+	SignatureDocumentMinorVersion string                  `json:"signature_document_minor_version"`
+	SignatureResignMajorVersion   string                  `json:"signature_resign_major_version"`
+	SignatureResignDeadline       string                  `json:"signature_resign_deadline"`
+	SignatureReplacedBy           string                  `json:"signature_replaced_by"`
+	SignatureRevokedOn            string                  `json:"signature_revoked_on"`
+	SignatureRevokedBy            string                  `json:"signature_revoked_by"`
+	SignatureRevocationReason     string                  `json:"signature_revocation_reason"`
+	SignatureRevocationDate       string                  `json:"signature_revocation_effective_date"`
+	SignatureReferenceID          string                  `json:"signature_reference_id"`
+	SignatureReferenceName        string                  `json:"signature_reference_name"`
+	SignatureReferenceNameLower   string                  `json:"signature_reference_name_lower"`
+	SignatureProjectID            string                  `json:"signature_project_id"`
+	SignatureReferenceType        string                  `json:"signature_reference_type"`
+	SignatureType                 string                  `json:"signature_type"`
+	SignatureUserCompanyID        string                  `json:"signature_user_ccla_company_id"`
+	EmailWhitelist                []string                `json:"email_whitelist"`
+	DomainWhitelist               []string                `json:"domain_whitelist"`
+	GitHubWhitelist               []string                `json:"github_whitelist"`
+	GitHubOrgWhitelist            []string                `json:"github_org_whitelist"`
+	GitHubTeamApprovalList        []string                `json:"github_team_approval_list"`
+	ApprovalListMetadata          []ItemApprovalListEntry `json:"approval_list_metadata"`
+	SignatureACL                  []string                `json:"signature_acl"`
+	UserGithubUsername            string                  `json:"user_github_username"`
+	UserLFUsername                string                  `json:"user_lf_username"`
+	UserName                      string                  `json:"user_name"`
+	UserEmail                     string                  `json:"user_email"`
+	SigtypeSignedApprovedID       string                  `json:"sigtype_signed_approved_id"`
+	SignedOn                      string                  `json:"signed_on"`
+	SignatoryName                 string                  `json:"signatory_name"`
+	UserDocusignName              string                  `json:"user_docusign_name"`
+	UserDocusignDateSigned        string                  `json:"user_docusign_date_signed"`
+}
+
+// ItemApprovalListEntry is the database model of the metadata of an approval list entry
+type ItemApprovalListEntry struct {
+	Type           string `json:"type"`
+	Value          string `json:"value"`
+	ExpiresOn      string `json:"expires_on,omitempty"`
+	AddedBy        string `json:"added_by,omitempty"`
+	AddedOn        string `json:"added_on,omitempty"`
+	ReminderSentOn string `json:"reminder_sent_on,omitempty"`
 }
 
 // DBManagersModel is a database model for only the ACL/Manager column
@@ -46,3 +65,60 @@ type DBSignatureUsersModel struct {
 	SignatureID string `json:"signature_id"`
 	UserID      string `json:"signature_reference_id"`
 }
+
+// ItemIndividualSignature is the database model written for the ICLAs signed natively, empty attributes are
+// omitted so the record matches the individual signature queries (no signature_user_ccla_company_id)
+type ItemIndividualSignature struct {
+	SignatureID                   string `json:"signature_id"`
+	DateCreated                   string `json:"date_created"`
+	DateModified                  string `json:"date_modified"`
+	SignatureApproved             bool   `json:"signature_approved"`
+	SignatureSigned               bool   `json:"signature_signed"`
+	SignatureDocumentMajorVersion string `json:"signature_document_major_version"`
+	SignatureDocumentMinorVersion string `json:"signature_document_minor_version"`
+	SignatureReferenceID          string `json:"signature_reference_id"`
+	SignatureReferenceName        string `json:"signature_reference_name,omitempty"`
+	SignatureReferenceNameLower   string `json:"signature_reference_name_lower,omitempty"`
+	SignatureProjectID            string `json:"signature_project_id"`
+	SignatureReferenceType        string `json:"signature_reference_type"`
+	SignatureType                 string `json:"signature_type"`
+	UserGithubUsername            string `json:"user_github_username,omitempty"`
+	UserLFUsername                string `json:"user_lf_username,omitempty"`
+	UserName                      string `json:"user_name,omitempty"`
+	UserEmail                     string `json:"user_email,omitempty"`
+	SigtypeSignedApprovedID       string `json:"sigtype_signed_approved_id"`
+	SignedOn                      string `json:"signed_on"`
+	SignatoryName                 string `json:"signatory_name"`
+	SignatureSignMethod           string `json:"signature_sign_method"`
+	SignatureClientIP             string `json:"signature_client_ip,omitempty"`
+	SignatureDocumentHash         string `json:"signature_document_hash"`
+	Version                       string `json:"version"`
+}
+
+// ItemCorporateSignature is the database model of the CCLAs signed through an e-signature provider, the record is
+// created pending when the signature is requested and marked signed once the provider completes the envelope
+type ItemCorporateSignature struct {
+	SignatureID                   string   `json:"signature_id"`
+	DateCreated                   string   `json:"date_created"`
+	DateModified                  string   `json:"date_modified"`
+	SignatureApproved             bool     `json:"signature_approved"`
+	SignatureSigned               bool     `json:"signature_signed"`
+	SignatureDocumentMajorVersion string   `json:"signature_document_major_version"`
+	SignatureDocumentMinorVersion string   `json:"signature_document_minor_version"`
+	SignatureReferenceID          string   `json:"signature_reference_id"`
+	SignatureReferenceName        string   `json:"signature_reference_name,omitempty"`
+	SignatureReferenceNameLower   string   `json:"signature_reference_name_lower,omitempty"`
+	SignatureProjectID            string   `json:"signature_project_id"`
+	SignatureReferenceType        string   `json:"signature_reference_type"`
+	SignatureType                 string   `json:"signature_type"`
+	SignatureACL                  []string `json:"signature_acl,omitempty"`
+	SigtypeSignedApprovedID       string   `json:"sigtype_signed_approved_id"`
+	SignedOn                      string   `json:"signed_on,omitempty"`
+	SignatoryName                 string   `json:"signatory_name,omitempty"`
+	SignatoryEmail                string   `json:"signatory_email,omitempty"`
+	SignatureReturnURL            string   `json:"signature_return_url,omitempty"`
+	SignatureSignURL              string   `json:"signature_sign_url,omitempty"`
+	SignatureEnvelopeID           string   `json:"signature_envelope_id"`
+	SignatureSignMethod           string   `json:"signature_sign_method"`
+	Version                       string   `json:"version"`
+}
@@ -0,0 +1,268 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ParseRevocationEffectiveDate parses the effective date of an employee acknowledgement revocation, either a date
+// (YYYY-MM-DD) effective at the start of the day UTC or an RFC3339 date/time. Defaults to now, a future effective
+// date schedules the revocation. Returns the effective date as an RFC3339 UTC string.
+func ParseRevocationEffectiveDate(value string, now time.Time) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return now.UTC().Format(time.RFC3339), nil
+	}
+
+	effectiveDate, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		date, dateErr := time.Parse("2006-01-02", value)
+		if dateErr != nil {
+			return "", fmt.Errorf("invalid effective date %s, expecting a date such as 2021-06-11 or an RFC3339 date/time", value)
+		}
+		effectiveDate = date
+	}
+	return effectiveDate.UTC().Format(time.RFC3339), nil
+}
+
+// revocationApprovalListRemovals returns the approval list update removing the email addresses and the GitHub
+// username of the contributor from the approval lists of the CCLA signature, nil if none of them is on the lists.
+// The domain, GitHub organization and GitHub team entries cover other contributors and are not modified.
+func revocationApprovalListRemovals(cclaSignature *models.Signature, user *models.User) *models.ApprovalList {
+	removals := &models.ApprovalList{}
+	for _, email := range getUserEmails(user) {
+		for _, entry := range cclaSignature.EmailApprovalList {
+			if strings.EqualFold(strings.TrimSpace(entry), email) && !isValueApproved(entry, removals.RemoveEmailApprovalList) {
+				removals.RemoveEmailApprovalList = append(removals.RemoveEmailApprovalList, entry)
+			}
+		}
+	}
+	if user.GithubUsername != "" {
+		for _, entry := range cclaSignature.GithubUsernameApprovalList {
+			if strings.EqualFold(strings.TrimSpace(entry), user.GithubUsername) {
+				removals.RemoveGithubUsernameApprovalList = append(removals.RemoveGithubUsernameApprovalList, entry)
+			}
+		}
+	}
+
+	if len(removals.RemoveEmailApprovalList) == 0 && len(removals.RemoveGithubUsernameApprovalList) == 0 {
+		return nil
+	}
+	return removals
+}
+
+// revocationApprovalListEntries returns the metadata expiring the approval list entries removed by the revocation on
+// its effective date. An entry which already expires before the effective date keeps its expiry, the revocation never
+// extends the approval of the contributor.
+func revocationApprovalListEntries(removals *models.ApprovalList, existing []*models.ApprovalListEntry, effectiveDate string) []*models.ApprovalListEntry {
+	effectiveOn, parseErr := time.Parse(time.RFC3339, effectiveDate)
+	expiries := map[string]string{}
+	for _, entry := range existing {
+		if parseErr == nil && approvalListEntryExpired(entry, effectiveOn) {
+			expiries[approvalListEntryKey(entry.Type, entry.Value)] = entry.ExpiresOn
+		}
+	}
+	expiresOn := func(entryType, value string) string {
+		if expiry, ok := expiries[approvalListEntryKey(entryType, value)]; ok {
+			return expiry
+		}
+		return effectiveDate
+	}
+
+	var entries []*models.ApprovalListEntry
+	for _, email := range removals.RemoveEmailApprovalList {
+		entries = append(entries, &models.ApprovalListEntry{Type: ApprovalListTypeEmail, Value: email, ExpiresOn: expiresOn(ApprovalListTypeEmail, email)})
+	}
+	for _, githubUsername := range removals.RemoveGithubUsernameApprovalList {
+		entries = append(entries, &models.ApprovalListEntry{Type: ApprovalListTypeGitHubUsername, Value: githubUsername, ExpiresOn: expiresOn(ApprovalListTypeGitHubUsername, githubUsername)})
+	}
+	return entries
+}
+
+// RevokeEmployeeSignature revokes the employee acknowledgement of the contributor on behalf of a CLA Manager of the
+// company CCLA. The acknowledgement is kept as inactive along with the details of the revocation. Optionally removes
+// the email addresses and the GitHub username of the contributor from the approval lists, in the same write as the
+// revocation. A revocation with a future effective date is recorded as pending: the acknowledgement is revoked and the
+// approval list entries expire on the effective date. The contributor is notified.
+func (s service) RevokeEmployeeSignature(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, userID, reason, effectiveDate string, removeApprovalListEntries bool) (*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":              "RevokeEmployeeSignature",
+		utils.XREQUESTID:            ctx.Value(utils.XREQUESTID),
+		"claGroupID":                claGroupModel.ProjectID,
+		"companyID":                 companyModel.CompanyID,
+		"userID":                    userID,
+		"effectiveDate":             effectiveDate,
+		"removeApprovalListEntries": removeApprovalListEntries,
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, NewBadRequestError("the reason of the revocation is required")
+	}
+	effectiveOn, err := ParseRevocationEffectiveDate(effectiveDate, time.Now())
+	if err != nil {
+		return nil, NewBadRequestError(err.Error())
+	}
+
+	cclaSignature, claManagerModel, err := s.getCLAManagerSignature(ctx, authUser, claGroupModel, companyModel, claGroupModel.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	eclaSignature, err := s.repo.GetEmployeeSignature(ctx, claGroupModel.ProjectID, companyModel.CompanyID, userID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem looking up the employee acknowledgement of the user")
+		return nil, err
+	}
+	if eclaSignature == nil {
+		return nil, ErrEmployeeSignatureNotFound
+	}
+
+	userModel, err := s.usersService.GetUser(userID)
+	if err != nil || userModel == nil {
+		// the acknowledgement is revoked regardless, only the approval list entries and the notification need the user
+		log.WithFields(f).WithError(err).Warn("unable to lookup the user of the employee acknowledgement")
+		userModel = nil
+	}
+
+	now, revokedOn := utils.CurrentTime()
+	revocation := &EmployeeSignatureRevocation{
+		RevokedBy:     authUser.UserName,
+		RevokedOn:     revokedOn,
+		Reason:        reason,
+		EffectiveDate: effectiveOn,
+		Pending:       effectiveOn > now.UTC().Format(time.RFC3339),
+	}
+
+	// The approval list entries of the contributor are removed now, or expire on the effective date of a pending
+	// revocation, in the same transaction as the revocation
+	var removals *models.ApprovalList
+	var lists *ApprovalLists
+	if removeApprovalListEntries && userModel != nil {
+		removals = revocationApprovalListRemovals(cclaSignature, userModel)
+	}
+	if removals != nil {
+		if revocation.Pending {
+			lists = signatureApprovalLists(cclaSignature)
+			lists.Entries = mergeApprovalListEntries(cclaSignature.ApprovalListEntries, revocationApprovalListEntries(removals, cclaSignature.ApprovalListEntries, effectiveOn), lists)
+		} else {
+			lists = updatedApprovalLists(cclaSignature, removals)
+			lists.Entries = mergeApprovalListEntries(cclaSignature.ApprovalListEntries, nil, lists)
+		}
+		lists.ModifiedBy = authUser.UserName
+	}
+	if err = s.repo.RevokeEmployeeSignature(ctx, eclaSignature.SignatureID, companyModel.CompanyID, revocation, cclaSignature, lists); err != nil {
+		return nil, err
+	}
+	eclaSignature.SignatureApproved = revocation.Pending
+	eclaSignature.RevokedOn = revocation.RevokedOn
+	eclaSignature.RevokedBy = revocation.RevokedBy
+	eclaSignature.RevocationReason = revocation.Reason
+	eclaSignature.RevocationEffectiveDate = revocation.EffectiveDate
+
+	var removedEntries []string
+	if removals != nil {
+		for _, email := range removals.RemoveEmailApprovalList {
+			removedEntries = append(removedEntries, ApprovalListTypeEmail+" "+email)
+		}
+		for _, githubUsername := range removals.RemoveGithubUsernameApprovalList {
+			removedEntries = append(removedEntries, ApprovalListTypeGitHubUsername+" "+githubUsername)
+		}
+		if !revocation.Pending {
+			// the CLA Managers and the contributor are notified of the removal like any other approval list update
+			s.createEventLogEntries(cclaSignature, companyModel, claGroupModel, claManagerModel, removals)
+			for _, claManager := range cclaSignature.SignatureACL {
+				claManagerEmail := getBestEmail(&claManager) // nolint
+				s.sendApprovalListUpdateEmailToCLAManagers(companyModel, claGroupModel, claManager.Username, claManagerEmail, removals)
+			}
+			s.sendRequestAccessEmailToContributors(authUser.UserName, companyModel, claGroupModel, removals)
+		}
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:     events.EmployeeSignatureRevoked,
+		ProjectID:     claGroupModel.ProjectID,
+		ClaGroupModel: claGroupModel,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		LfUsername:    authUser.UserName,
+		UserModel:     claManagerModel,
+		EventData: &events.EmployeeSignatureRevokedEventData{
+			SignatureID:    eclaSignature.SignatureID,
+			UserName:       eclaSignature.SignatureReferenceName,
+			Reason:         revocation.Reason,
+			EffectiveDate:  revocation.EffectiveDate,
+			RemovedEntries: removedEntries,
+		},
+	})
+
+	if userModel != nil {
+		s.sendEmployeeSignatureRevokedEmail(ctx, claGroupModel, companyModel, userModel, revocation)
+	}
+
+	log.WithFields(f).Debugf("revoked the employee acknowledgement: %s", eclaSignature.SignatureID)
+	return eclaSignature, nil
+}
+
+// sendEmployeeSignatureRevokedEmail notifies the contributor of the revocation of their employee acknowledgement
+func (s service) sendEmployeeSignatureRevokedEmail(ctx context.Context, claGroupModel *models.ClaGroup, companyModel *models.Company, userModel *models.User, revocation *EmployeeSignatureRevocation) {
+	f := logrus.Fields{
+		"functionName":   "sendEmployeeSignatureRevokedEmail",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupModel.ProjectID,
+		"companyID":      companyModel.CompanyID,
+		"userID":         userModel.UserID,
+	}
+
+	email := getBestEmail(userModel)
+	if email == "" {
+		log.WithFields(f).Warn("no email address found for the user - skipping email")
+		return
+	}
+	recipients := []string{email}
+
+	effectiveDate := revocation.EffectiveDate
+	if t, err := utils.ParseDateTime(revocation.EffectiveDate); err == nil {
+		effectiveDate = t.UTC().Format("January 2, 2006")
+	}
+
+	params := emails.EmployeeSignatureRevokedTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     userModel.Username,
+			Project:           emails.CLAProjectParams{ExternalProjectName: claGroupModel.ProjectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyModel.CompanyName,
+			RecipientLanguage: userModel.PreferredLanguage,
+		},
+		EffectiveDate: effectiveDate,
+		// html/template escapes the reason entered by the CLA Manager
+		Reason: revocation.Reason,
+	}
+
+	subject := emails.RenderSubject(emails.EmployeeSignatureRevokedTemplateName,
+		fmt.Sprintf("EasyCLA: Your authorization to contribute to %s on behalf of %s was revoked", claGroupModel.ProjectName, companyModel.CompanyName), params)
+	body, err := emails.RenderTemplate(claGroupModel.Version, emails.EmployeeSignatureRevokedTemplateName, emails.EmployeeSignatureRevokedTemplate, params)
+	if err != nil {
+		log.WithFields(f).Warnf("rendering email template : %s failed : %v", emails.EmployeeSignatureRevokedTemplateName, err)
+		return
+	}
+
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.EmployeeSignatureRevokedTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleContributor})
+	if err != nil {
+		log.WithFields(f).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.WithFields(f).Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRevocationEffectiveDate(t *testing.T) {
+	now := time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	effectiveDate, err := ParseRevocationEffectiveDate("", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-06-15T10:00:00Z", effectiveDate)
+
+	effectiveDate, err = ParseRevocationEffectiveDate("2021-06-11", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-06-11T00:00:00Z", effectiveDate)
+
+	effectiveDate, err = ParseRevocationEffectiveDate("2021-06-15T11:00:00+02:00", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-06-15T09:00:00Z", effectiveDate)
+
+	effectiveDate, err = ParseRevocationEffectiveDate("2021-06-16", now)
+	assert.NoError(t, err, "a future effective date schedules the revocation")
+	assert.Equal(t, "2021-06-16T00:00:00Z", effectiveDate)
+
+	_, err = ParseRevocationEffectiveDate("last friday", now)
+	assert.Error(t, err)
+}
+
+func TestRevocationApprovalListRemovals(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"Jane.Doe@example.org", "john.doe@example.org", "jane@personal.example.com"},
+		DomainApprovalList:         []string{"example.org"},
+		GithubUsernameApprovalList: []string{"JaneDoe", "johndoe"},
+		GithubOrgApprovalList:      []string{"acme"},
+	}
+	user := &models.User{
+		LfEmail:        "jane.doe@example.org",
+		Emails:         []string{"jane@personal.example.com", "jane.doe@example.org"},
+		GithubUsername: "janedoe",
+	}
+
+	removals := revocationApprovalListRemovals(sig, user)
+	if assert.NotNil(t, removals) {
+		assert.Equal(t, []string{"Jane.Doe@example.org", "jane@personal.example.com"}, removals.RemoveEmailApprovalList)
+		assert.Equal(t, []string{"JaneDoe"}, removals.RemoveGithubUsernameApprovalList)
+		assert.Empty(t, removals.RemoveDomainApprovalList, "the domain entries cover other contributors")
+		assert.Empty(t, removals.RemoveGithubOrgApprovalList)
+	}
+
+	assert.Nil(t, revocationApprovalListRemovals(sig, &models.User{LfEmail: "someone@example.org"}))
+}
+
+func TestRevocationApprovalListEntries(t *testing.T) {
+	removals := &models.ApprovalList{
+		RemoveEmailApprovalList:          []string{"jane.doe@example.org"},
+		RemoveGithubUsernameApprovalList: []string{"janedoe"},
+	}
+
+	entries := revocationApprovalListEntries(removals, nil, "2021-07-01T00:00:00Z")
+	assert.Equal(t, []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "jane.doe@example.org", ExpiresOn: "2021-07-01T00:00:00Z"},
+		{Type: ApprovalListTypeGitHubUsername, Value: "janedoe", ExpiresOn: "2021-07-01T00:00:00Z"},
+	}, entries)
+}
+
+func TestRevocationApprovalListEntriesKeepTheEarlierExpiry(t *testing.T) {
+	removals := &models.ApprovalList{
+		RemoveEmailApprovalList:          []string{"jane.doe@example.org"},
+		RemoveGithubUsernameApprovalList: []string{"janedoe"},
+	}
+	existing := []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "jane.doe@example.org", ExpiresOn: "2021-06-15T23:59:59Z", AddedBy: "cla-manager"},
+		{Type: ApprovalListTypeGitHubUsername, Value: "janedoe", ExpiresOn: "2021-08-01T23:59:59Z", AddedBy: "cla-manager"},
+	}
+
+	entries := revocationApprovalListEntries(removals, existing, "2021-07-01T00:00:00Z")
+	assert.Equal(t, []*models.ApprovalListEntry{
+		{Type: ApprovalListTypeEmail, Value: "jane.doe@example.org", ExpiresOn: "2021-06-15T23:59:59Z"},
+		{Type: ApprovalListTypeGitHubUsername, Value: "janedoe", ExpiresOn: "2021-07-01T00:00:00Z"},
+	}, entries, "the email already expires before the effective date")
+
+	lists := &ApprovalLists{Emails: []string{"jane.doe@example.org"}, GitHubUsernames: []string{"janedoe"}}
+	merged := mergeApprovalListEntries(existing, entries, lists)
+	if assert.Len(t, merged, 2) {
+		assert.Equal(t, "2021-06-15T23:59:59Z", merged[0].ExpiresOn, "the revocation does not extend the approval")
+		assert.Equal(t, "2021-07-01T00:00:00Z", merged[1].ExpiresOn)
+	}
+}
@@ -3,6 +3,18 @@
 
 package signatures
 
+import "errors"
+
+// ErrApprovalListModified is returned when the approval lists of the signature were modified by another request
+// after they were loaded
+var ErrApprovalListModified = errors.New("the approval list was modified by another request, please retry")
+
+// ErrEmployeeSignatureNotFound is returned when the contributor has no active employee acknowledgement for the company
+var ErrEmployeeSignatureNotFound = errors.New("employee acknowledgement not found")
+
+// ErrEmployeeSignatureRevoked is returned when the employee acknowledgement was already revoked
+var ErrEmployeeSignatureRevoked = errors.New("the employee acknowledgement was already revoked")
+
 // NewBadRequestError returns an error that formats as the given text.
 func NewBadRequestError(text string) error {
 	return &BadRequestError{text}
@@ -192,14 +192,7 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 			githubAccessToken = ""
 		}
 
-		ghApprovalList, err := service.AddGithubOrganizationToWhitelist(ctx, params.SignatureID, params.Body, githubAccessToken)
-		if err != nil {
-			log.Warnf("error adding github organization %s using signature_id: %s to the whitelist, error: %+v",
-				*params.Body.OrganizationID, params.SignatureID, err)
-			return signatures.NewAddGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
-		}
-
-		// Create an event
+		// Load the signature for the CLA Group and company of the event
 		signatureModel, getSigErr := service.GetSignature(ctx, params.SignatureID)
 		var projectID = ""
 		var companyID = ""
@@ -211,12 +204,22 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 			projectID = signatureModel.ProjectID
 			companyID = signatureModel.SignatureReferenceID
 		}
+
+		ghApprovalList, err := service.AddGithubOrganizationToWhitelist(ctx, params.SignatureID, params.Body, githubAccessToken, claUser.LFUsername)
+		if err != nil {
+			log.Warnf("error adding github organization %s using signature_id: %s to the whitelist, error: %+v",
+				*params.Body.OrganizationID, params.SignatureID, err)
+			return signatures.NewAddGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		// Create an event
 		eventsService.LogEvent(&events.LogEventArgs{
-			EventType:  events.ApprovalListGitHubOrganizationAdded,
-			ProjectID:  projectID,
-			CompanyID:  companyID,
-			UserID:     claUser.UserID,
-			LfUsername: claUser.LFUsername,
+			EventType:   events.ApprovalListGitHubOrganizationAdded,
+			ProjectID:   projectID,
+			CompanyID:   companyID,
+			SignatureID: params.SignatureID,
+			UserID:      claUser.UserID,
+			LfUsername:  claUser.LFUsername,
 			EventData: &events.ApprovalListGitHubOrganizationAddedEventData{
 				GitHubOrganizationName: utils.StringValue(params.Body.OrganizationID),
 			},
@@ -242,7 +245,20 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 			githubAccessToken = ""
 		}
 
-		ghApprovalList, err := service.DeleteGithubOrganizationFromWhitelist(ctx, params.SignatureID, params.Body, githubAccessToken)
+		// Load the signature for the CLA Group and company of the event
+		signatureModel, getSigErr := service.GetSignature(ctx, params.SignatureID)
+		var projectID = ""
+		var companyID = ""
+		if getSigErr != nil || signatureModel == nil {
+			log.Warnf("error looking up signature using signature_id: %s, error: %+v",
+				params.SignatureID, getSigErr)
+		}
+		if signatureModel != nil {
+			projectID = signatureModel.ProjectID
+			companyID = signatureModel.SignatureReferenceID
+		}
+
+		ghApprovalList, err := service.DeleteGithubOrganizationFromWhitelist(ctx, params.SignatureID, params.Body, githubAccessToken, claUser.LFUsername)
 		if err != nil {
 			log.Warnf("error deleting github organization %s using signature_id: %s from the whitelist, error: %+v",
 				*params.Body.OrganizationID, params.SignatureID, err)
@@ -250,6 +266,64 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 		}
 
 		// Create an event
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:   events.ApprovalListGitHubOrganizationDeleted,
+			ProjectID:   projectID,
+			CompanyID:   companyID,
+			SignatureID: params.SignatureID,
+			UserID:      claUser.UserID,
+			LfUsername:  claUser.LFUsername,
+			EventData: &events.ApprovalListGitHubOrganizationDeletedEventData{
+				GitHubOrganizationName: utils.StringValue(params.Body.OrganizationID),
+			},
+		})
+
+		return signatures.NewDeleteGitHubOrgWhitelistNoContent().WithXRequestID(reqID).WithPayload(ghApprovalList)
+	})
+
+	// Retrieve GitHub Team Approval List Entries
+	api.SignaturesGetGitHubTeamApprovalListHandler = signatures.GetGitHubTeamApprovalListHandlerFunc(func(params signatures.GetGitHubTeamApprovalListParams, claUser *user.CLAUser) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
+		if err != nil {
+			log.Warnf("error retrieving session from the session store, error: %+v", err)
+			return signatures.NewGetGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		githubAccessToken, ok := session.Values["github_access_token"].(string)
+		if !ok {
+			log.Debugf("no github access token in the session - initializing to empty string")
+			githubAccessToken = ""
+		}
+
+		ghApprovalList, err := service.GetGithubTeamsFromApprovalList(ctx, params.SignatureID, githubAccessToken)
+		if err != nil {
+			log.Warnf("error fetching github team approval list entries using signature_id: %s, error: %+v",
+				params.SignatureID, err)
+			return signatures.NewGetGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		return signatures.NewGetGitHubTeamApprovalListOK().WithXRequestID(reqID).WithPayload(ghApprovalList)
+	})
+
+	// Add GitHub Team Approval List Entries
+	api.SignaturesAddGitHubTeamApprovalListHandler = signatures.AddGitHubTeamApprovalListHandlerFunc(func(params signatures.AddGitHubTeamApprovalListParams, claUser *user.CLAUser) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
+		if err != nil {
+			log.Warnf("error retrieving session from the session store, error: %+v", err)
+			return signatures.NewAddGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		githubAccessToken, ok := session.Values["github_access_token"].(string)
+		if !ok {
+			log.Debugf("no github access token in the session - initializing to empty string")
+			githubAccessToken = ""
+		}
+
+		// Load the signature for the CLA Group and company of the event
 		signatureModel, getSigErr := service.GetSignature(ctx, params.SignatureID)
 		var projectID = ""
 		var companyID = ""
@@ -262,18 +336,80 @@ func Configure(api *operations.ClaAPI, service SignatureService, sessionStore *d
 			companyID = signatureModel.SignatureReferenceID
 		}
 
+		ghApprovalList, err := service.AddGithubTeamToApprovalList(ctx, params.SignatureID, params.Body, githubAccessToken, claUser.LFUsername)
+		if err != nil {
+			log.Warnf("error adding github team %s using signature_id: %s to the approval list, error: %+v",
+				*params.Body.TeamID, params.SignatureID, err)
+			return signatures.NewAddGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		// Create an event
 		eventsService.LogEvent(&events.LogEventArgs{
-			EventType:  events.ApprovalListGitHubOrganizationDeleted,
-			ProjectID:  projectID,
-			CompanyID:  companyID,
-			UserID:     claUser.UserID,
-			LfUsername: claUser.LFUsername,
-			EventData: &events.ApprovalListGitHubOrganizationDeletedEventData{
-				GitHubOrganizationName: utils.StringValue(params.Body.OrganizationID),
+			EventType:   events.ApprovalListGitHubTeamAdded,
+			ProjectID:   projectID,
+			CompanyID:   companyID,
+			SignatureID: params.SignatureID,
+			UserID:      claUser.UserID,
+			LfUsername:  claUser.LFUsername,
+			EventData: &events.ApprovalListGitHubTeamAddedEventData{
+				GitHubTeamName: utils.StringValue(params.Body.TeamID),
 			},
 		})
 
-		return signatures.NewDeleteGitHubOrgWhitelistNoContent().WithXRequestID(reqID).WithPayload(ghApprovalList)
+		return signatures.NewAddGitHubTeamApprovalListOK().WithXRequestID(reqID).WithPayload(ghApprovalList)
+	})
+
+	// Delete GitHub Team Approval List Entries
+	api.SignaturesDeleteGitHubTeamApprovalListHandler = signatures.DeleteGitHubTeamApprovalListHandlerFunc(func(params signatures.DeleteGitHubTeamApprovalListParams, claUser *user.CLAUser) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
+		if err != nil {
+			log.Warnf("error retrieving session from the session store, error: %+v", err)
+			return signatures.NewDeleteGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		githubAccessToken, ok := session.Values["github_access_token"].(string)
+		if !ok {
+			log.Debugf("no github access token in the session - initializing to empty string")
+			githubAccessToken = ""
+		}
+
+		// Load the signature for the CLA Group and company of the event
+		signatureModel, getSigErr := service.GetSignature(ctx, params.SignatureID)
+		var projectID = ""
+		var companyID = ""
+		if getSigErr != nil || signatureModel == nil {
+			log.Warnf("error looking up signature using signature_id: %s, error: %+v",
+				params.SignatureID, getSigErr)
+		}
+		if signatureModel != nil {
+			projectID = signatureModel.ProjectID
+			companyID = signatureModel.SignatureReferenceID
+		}
+
+		ghApprovalList, err := service.DeleteGithubTeamFromApprovalList(ctx, params.SignatureID, params.Body, githubAccessToken, claUser.LFUsername)
+		if err != nil {
+			log.Warnf("error deleting github team %s using signature_id: %s from the approval list, error: %+v",
+				*params.Body.TeamID, params.SignatureID, err)
+			return signatures.NewDeleteGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(err))
+		}
+
+		// Create an event
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:   events.ApprovalListGitHubTeamDeleted,
+			ProjectID:   projectID,
+			CompanyID:   companyID,
+			SignatureID: params.SignatureID,
+			UserID:      claUser.UserID,
+			LfUsername:  claUser.LFUsername,
+			EventData: &events.ApprovalListGitHubTeamDeletedEventData{
+				GitHubTeamName: utils.StringValue(params.Body.TeamID),
+			},
+		})
+
+		return signatures.NewDeleteGitHubTeamApprovalListNoContent().WithXRequestID(reqID).WithPayload(ghApprovalList)
 	})
 
 	// Get Project Signatures
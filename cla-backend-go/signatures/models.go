@@ -3,6 +3,8 @@
 
 package signatures
 
+import "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+
 // SignatureCompanyID is a simple data model to hold the signature ID and come company details for CCLA's
 type SignatureCompanyID struct {
 	SignatureID string
@@ -10,3 +12,38 @@ type SignatureCompanyID struct {
 	CompanySFID string
 	CompanyName string
 }
+
+// ApprovalLists holds the email, domain, GitHub username, GitHub organization and GitHub team approval lists of a CCLA
+// signature along with the metadata of their entries
+type ApprovalLists struct {
+	Emails          []string
+	Domains         []string
+	GitHubUsernames []string
+	GitHubOrgs      []string
+	GitHubTeams     []string
+	Entries         []*models.ApprovalListEntry
+	// ModifiedBy is the user name recorded on the signature as the author of the update, SystemUsername if empty
+	ModifiedBy string
+}
+
+// SystemUsername is the user name recorded on the approval list updates made by EasyCLA itself, such as the removal
+// of the expired entries
+const SystemUsername = "easycla system"
+
+// DirectorySyncUsername is the user name recorded on the approval list updates pushed by the company directory
+// sync (SCIM)
+const DirectorySyncUsername = "easycla directory sync"
+
+// AutoApprovalUsername is the user name recorded on the approval list updates of the approval requests approved by
+// the auto-approval rules of the company
+const AutoApprovalUsername = "easycla auto-approval"
+
+// EmployeeSignatureRevocation holds the details of the revocation of an employee acknowledgement. A pending
+// revocation has a future effective date, the acknowledgement stays approved until then.
+type EmployeeSignatureRevocation struct {
+	RevokedBy     string
+	RevokedOn     string
+	Reason        string
+	EffectiveDate string
+	Pending       bool
+}
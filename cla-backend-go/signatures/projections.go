@@ -16,6 +16,12 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("signature_approved"),
 		expression.Name("signature_document_major_version"),
 		expression.Name("signature_document_minor_version"),
+		expression.Name("signature_resign_major_version"),
+		expression.Name("signature_resign_deadline"),
+		expression.Name("signature_revoked_on"),
+		expression.Name("signature_revoked_by"),
+		expression.Name("signature_revocation_reason"),
+		expression.Name("signature_revocation_effective_date"),
 		expression.Name("signature_reference_id"),
 		expression.Name("signature_reference_name"),       // Added to support simplified UX queries
 		expression.Name("signature_reference_name_lower"), // Added to support case insensitive UX queries
@@ -28,6 +34,8 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("domain_whitelist"),
 		expression.Name("github_whitelist"),
 		expression.Name("github_org_whitelist"),
+		expression.Name("github_team_approval_list"),
+		expression.Name("approval_list_metadata"),
 		expression.Name("user_github_username"),
 		expression.Name("user_lf_username"),
 		expression.Name("user_name"),
@@ -26,6 +26,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
@@ -49,12 +50,25 @@ const (
 // SignatureRepository interface defines the functions for the github whitelist service
 type SignatureRepository interface {
 	GetGithubOrganizationsFromWhitelist(ctx context.Context, signatureID string) ([]models.GithubOrg, error)
-	AddGithubOrganizationToWhitelist(ctx context.Context, signatureID, githubOrganizationID string) ([]models.GithubOrg, error)
-	DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID, githubOrganizationID string) ([]models.GithubOrg, error)
+	AddGithubOrganizationToWhitelist(ctx context.Context, signatureID, githubOrganizationID, modifiedBy string) ([]models.GithubOrg, error)
+	DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID, githubOrganizationID, modifiedBy string) ([]models.GithubOrg, error)
+	GetGithubTeamsFromApprovalList(ctx context.Context, signatureID string) ([]models.GithubTeam, error)
+	AddGithubTeamToApprovalList(ctx context.Context, signatureID, githubTeamID, modifiedBy string) ([]models.GithubTeam, error)
+	DeleteGithubTeamFromApprovalList(ctx context.Context, signatureID, githubTeamID, modifiedBy string) ([]models.GithubTeam, error)
 	InvalidateProjectRecord(ctx context.Context, signatureID string, projectName string) error
+	SetResignatureRequired(ctx context.Context, signatureID, majorVersion, deadline string) error
+	RevokeEmployeeSignature(ctx context.Context, signatureID, companyID string, revocation *EmployeeSignatureRevocation, cclaSignature *models.Signature, lists *ApprovalLists) error
+	ReplaceResignedCorporateSignature(ctx context.Context, resigned *models.Signature, lists *ApprovalLists, acl []string, replaced *models.Signature) error
+	ApplyDueEmployeeSignatureRevocations(ctx context.Context, now string) (int, error)
+	CreateIndividualSignature(ctx context.Context, signature *ItemIndividualSignature) error
+	CreateCorporateSignature(ctx context.Context, signature *ItemCorporateSignature) error
+	GetCorporateSignatureRecord(ctx context.Context, signatureID string) (*ItemCorporateSignature, error)
+	ReplaceCorporateSignatureEnvelope(ctx context.Context, signature *ItemCorporateSignature) error
+	MarkCorporateSignatureSigned(ctx context.Context, signatureID, companyID, signatoryName, signatoryEmail, signedOn string) error
 
 	GetSignature(ctx context.Context, signatureID string) (*models.Signature, error)
 	GetIndividualSignature(ctx context.Context, claGroupID, userID string) (*models.Signature, error)
+	GetEmployeeSignature(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error)
 	GetCorporateSignature(ctx context.Context, claGroupID, companyID string) (*models.Signature, error)
 	GetSignatureACL(ctx context.Context, signatureID string) ([]string, error)
 	GetProjectSignatures(ctx context.Context, params signatures.GetProjectSignaturesParams) (*models.Signatures, error)
@@ -66,12 +80,13 @@ type SignatureRepository interface {
 	GetCompanyIDsWithSignedCorporateSignatures(ctx context.Context, claGroupID string) ([]SignatureCompanyID, error)
 	GetUserSignatures(ctx context.Context, params signatures.GetUserSignaturesParams, pageSize int64) (*models.Signatures, error)
 	ProjectSignatures(ctx context.Context, projectID string) (*models.Signatures, error)
-	UpdateApprovalList(ctx context.Context, projectID, companyID string, params *models.ApprovalList) (*models.Signature, error)
+	ReplaceApprovalLists(ctx context.Context, sig *models.Signature, lists *ApprovalLists) (*models.Signature, error)
+	GetSignaturesWithApprovalListEntries(ctx context.Context) ([]*models.Signature, error)
 
 	AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 
-	removeColumn(ctx context.Context, signatureID, columnName string) (*models.Signature, error)
+	removeColumn(ctx context.Context, signatureID, columnName, modifiedBy string) (*models.Signature, error)
 
 	AddSigTypeSignedApprovedID(ctx context.Context, signatureID string, val string) error
 	AddUsersDetails(ctx context.Context, signatureID string, userID string) error
@@ -146,7 +161,7 @@ func (repo repository) GetGithubOrganizationsFromWhitelist(ctx context.Context,
 }
 
 // AddGithubOrganizationToWhitelist adds the specified GH organization to the whitelist
-func (repo repository) AddGithubOrganizationToWhitelist(ctx context.Context, signatureID, GitHubOrganizationID string) ([]models.GithubOrg, error) {
+func (repo repository) AddGithubOrganizationToWhitelist(ctx context.Context, signatureID, GitHubOrganizationID, modifiedBy string) ([]models.GithubOrg, error) {
 	f := logrus.Fields{
 		"functionName":         "AddGitHubOrganizationToWhitelist",
 		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
@@ -198,6 +213,7 @@ func (repo repository) AddGithubOrganizationToWhitelist(ctx context.Context, sig
 
 	// return values flag - Returns all of the attributes of the item, as they appear after the UpdateItem operation.
 	addReturnValues := "ALL_NEW" // nolint
+	_, now := utils.CurrentTime()
 
 	// Update dynamoDB table
 	input := &dynamodb.UpdateItemInput{
@@ -208,14 +224,18 @@ func (repo repository) AddGithubOrganizationToWhitelist(ctx context.Context, sig
 			},
 		},
 		ExpressionAttributeNames: map[string]*string{
-			"#L": aws.String("github_org_whitelist"),
+			"#L":  aws.String("github_org_whitelist"),
+			"#M":  aws.String("date_modified"),
+			"#MB": aws.String("approval_list_modified_by"),
 		},
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":l": {
 				L: newList,
 			},
+			":m":  {S: aws.String(now)},
+			":mb": {S: aws.String(approvalListModifiedBy(modifiedBy))},
 		},
-		UpdateExpression: aws.String("SET #L = :l"),
+		UpdateExpression: aws.String("SET #L = :l, #M = :m, #MB = :mb"),
 		ReturnValues:     &addReturnValues,
 	}
 
@@ -239,7 +259,7 @@ func (repo repository) AddGithubOrganizationToWhitelist(ctx context.Context, sig
 }
 
 // DeleteGithubOrganizationFromWhitelist removes the specified GH organization from the whitelist
-func (repo repository) DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID, GitHubOrganizationID string) ([]models.GithubOrg, error) {
+func (repo repository) DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID, GitHubOrganizationID, modifiedBy string) ([]models.GithubOrg, error) {
 	f := logrus.Fields{
 		"functionName":         "DeleteGitHubOrganizationFromWhitelist",
 		utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
@@ -287,16 +307,21 @@ func (repo repository) DeleteGithubOrganizationFromWhitelist(ctx context.Context
 		log.WithFields(f).Debugf("clearing out github org whitelist for organization: %s for signature: %s - list is empty",
 			GitHubOrganizationID, signatureID)
 		nullFlag := true
+		_, now := utils.CurrentTime()
 
 		// update dynamoDB table
 		input := &dynamodb.UpdateItemInput{
 			ExpressionAttributeNames: map[string]*string{
-				"#L": aws.String("github_org_whitelist"),
+				"#L":  aws.String("github_org_whitelist"),
+				"#M":  aws.String("date_modified"),
+				"#MB": aws.String("approval_list_modified_by"),
 			},
 			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 				":l": {
 					NULL: &nullFlag,
 				},
+				":m":  {S: aws.String(now)},
+				":mb": {S: aws.String(approvalListModifiedBy(modifiedBy))},
 			},
 			TableName: aws.String(repo.signatureTableName),
 			Key: map[string]*dynamodb.AttributeValue{
@@ -304,7 +329,7 @@ func (repo repository) DeleteGithubOrganizationFromWhitelist(ctx context.Context
 					S: aws.String(signatureID),
 				},
 			},
-			UpdateExpression: aws.String("SET #L = :l"),
+			UpdateExpression: aws.String("SET #L = :l, #M = :m, #MB = :mb"),
 		}
 
 		_, err = repo.dynamoDBClient.UpdateItem(input)
@@ -319,16 +344,21 @@ func (repo repository) DeleteGithubOrganizationFromWhitelist(ctx context.Context
 
 	// return values flag - Returns all of the attributes of the item, as they appear after the UpdateItem operation.
 	updatedReturnValues := "ALL_NEW" // nolint
+	_, now := utils.CurrentTime()
 
 	// update dynamoDB table
 	input := &dynamodb.UpdateItemInput{
 		ExpressionAttributeNames: map[string]*string{
-			"#L": aws.String("github_org_whitelist"),
+			"#L":  aws.String("github_org_whitelist"),
+			"#M":  aws.String("date_modified"),
+			"#MB": aws.String("approval_list_modified_by"),
 		},
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":l": {
 				L: newList,
 			},
+			":m":  {S: aws.String(now)},
+			":mb": {S: aws.String(approvalListModifiedBy(modifiedBy))},
 		},
 		TableName: aws.String(repo.signatureTableName),
 		Key: map[string]*dynamodb.AttributeValue{
@@ -336,7 +366,7 @@ func (repo repository) DeleteGithubOrganizationFromWhitelist(ctx context.Context
 				S: aws.String(signatureID),
 			},
 		},
-		UpdateExpression: aws.String("SET #L = :l"),
+		UpdateExpression: aws.String("SET #L = :l, #M = :m, #MB = :mb"),
 		ReturnValues:     &updatedReturnValues,
 	}
 
@@ -359,6 +389,144 @@ func (repo repository) DeleteGithubOrganizationFromWhitelist(ctx context.Context
 
 }
 
+// GetGithubTeamsFromApprovalList returns the list of GH teams stored in the approval list
+func (repo repository) GetGithubTeamsFromApprovalList(ctx context.Context, signatureID string) ([]models.GithubTeam, error) {
+	f := logrus.Fields{
+		"functionName":   "GetGithubTeamsFromApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	sig, err := repo.GetSignature(ctx, signatureID)
+	if err != nil {
+		log.WithFields(f).Warnf("error retrieving GH team approval list for signatureID: %s, error: %v", signatureID, err)
+		return nil, err
+	}
+	if sig == nil {
+		return nil, fmt.Errorf("signature not found using signatureID: %s", signatureID)
+	}
+
+	return buildTeamResponse(sig.GithubTeamApprovalList), nil
+}
+
+// AddGithubTeamToApprovalList adds the specified GH team to the approval list
+func (repo repository) AddGithubTeamToApprovalList(ctx context.Context, signatureID, githubTeamID, modifiedBy string) ([]models.GithubTeam, error) {
+	f := logrus.Fields{
+		"functionName":   "AddGithubTeamToApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+		"githubTeamID":   githubTeamID,
+	}
+
+	sig, err := repo.GetSignature(ctx, signatureID)
+	if err != nil {
+		log.WithFields(f).Warnf("error retrieving GH team approval list for signatureID: %s, error: %v", signatureID, err)
+		return nil, err
+	}
+	if sig == nil {
+		return nil, fmt.Errorf("signature not found using signatureID: %s", signatureID)
+	}
+
+	// if we find a team with the same id just return without updating the record
+	for _, teamID := range sig.GithubTeamApprovalList {
+		if strings.EqualFold(teamID, githubTeamID) {
+			log.WithFields(f).Debugf("GitHub team for signature: %s already in the list - nothing to do", signatureID)
+			return buildTeamResponse(sig.GithubTeamApprovalList), nil
+		}
+	}
+
+	updatedList := append(sig.GithubTeamApprovalList, githubTeamID)
+	err = repo.setGithubTeamApprovalList(ctx, signatureID, updatedList, modifiedBy)
+	if err != nil {
+		log.WithFields(f).Warnf("error updating GH team approval list, error: %v", err)
+		return nil, err
+	}
+
+	return buildTeamResponse(updatedList), nil
+}
+
+// DeleteGithubTeamFromApprovalList removes the specified GH team from the approval list
+func (repo repository) DeleteGithubTeamFromApprovalList(ctx context.Context, signatureID, githubTeamID, modifiedBy string) ([]models.GithubTeam, error) {
+	f := logrus.Fields{
+		"functionName":   "DeleteGithubTeamFromApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+		"githubTeamID":   githubTeamID,
+	}
+
+	sig, err := repo.GetSignature(ctx, signatureID)
+	if err != nil {
+		log.WithFields(f).Warnf("error retrieving GH team approval list for signatureID: %s, error: %v", signatureID, err)
+		return nil, err
+	}
+	if sig == nil {
+		return nil, fmt.Errorf("signature not found using signatureID: %s", signatureID)
+	}
+
+	var updatedList []string
+	for _, teamID := range sig.GithubTeamApprovalList {
+		if !strings.EqualFold(teamID, githubTeamID) {
+			updatedList = append(updatedList, teamID)
+		}
+	}
+
+	if len(updatedList) == 0 {
+		// DynamoDB does not accept an empty list - remove the column instead
+		log.WithFields(f).Debugf("clearing out github team approval list for signature: %s - list is empty", signatureID)
+		_, err = repo.removeColumn(ctx, signatureID, "github_team_approval_list", modifiedBy)
+		if err != nil {
+			return nil, err
+		}
+		return []models.GithubTeam{}, nil
+	}
+
+	err = repo.setGithubTeamApprovalList(ctx, signatureID, updatedList, modifiedBy)
+	if err != nil {
+		log.WithFields(f).Warnf("error updating GH team approval list, error: %v", err)
+		return nil, err
+	}
+
+	return buildTeamResponse(updatedList), nil
+}
+
+// setGithubTeamApprovalList saves the GH team approval list of the signature along with the user who updated it
+func (repo repository) setGithubTeamApprovalList(ctx context.Context, signatureID string, teamIDs []string, modifiedBy string) error {
+	f := logrus.Fields{
+		"functionName":   "setGithubTeamApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+	var teamList []*dynamodb.AttributeValue
+	for _, teamID := range teamIDs {
+		teamList = append(teamList, &dynamodb.AttributeValue{S: aws.String(strings.TrimSpace(teamID))})
+	}
+	_, now := utils.CurrentTime()
+
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#GT": aws.String("github_team_approval_list"),
+			"#M":  aws.String("date_modified"),
+			"#MB": aws.String("approval_list_modified_by"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":gt": {L: teamList},
+			":m":  {S: aws.String(now)},
+			":mb": {S: aws.String(approvalListModifiedBy(modifiedBy))},
+		},
+		UpdateExpression: aws.String("SET #GT = :gt, #M = :m, #MB = :mb"),
+	}
+
+	log.WithFields(f).Debugf("updating database record with GH team approval list values: %v", teamIDs)
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	return err
+}
+
 // GetSignature returns the signature for the specified signature id
 func (repo repository) GetSignature(ctx context.Context, signatureID string) (*models.Signature, error) {
 	f := logrus.Fields{
@@ -502,6 +670,100 @@ func (repo repository) GetIndividualSignature(ctx context.Context, claGroupID, u
 
 	if len(sigs) > 1 {
 		log.WithFields(f).Warnf("found multiple matching ICLA signatures - found %d total", len(sigs))
+		// a contributor re-signing a new major version has several ICLAs, the latest version is the one in effect
+		sort.SliceStable(sigs, func(i, j int) bool {
+			return signatureMajorVersion(sigs[i]) > signatureMajorVersion(sigs[j])
+		})
+	}
+
+	return sigs[0], nil
+}
+
+// GetEmployeeSignature returns the employee acknowledgement signature record for the specified CLA Group, Company and User ID
+func (repo repository) GetEmployeeSignature(ctx context.Context, claGroupID, companyID, userID string) (*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":           "GetEmployeeSignature",
+		utils.XREQUESTID:         ctx.Value(utils.XREQUESTID),
+		"tableName":              repo.signatureTableName,
+		"claGroupID":             claGroupID,
+		"companyID":              companyID,
+		"userID":                 userID,
+		"signatureType":          utils.SignatureTypeCLA,
+		"signatureReferenceType": utils.SignatureReferenceTypeUser,
+		"signatureApproved":      "true",
+		"signatureSigned":        "true",
+	}
+
+	// These are the keys we want to match for an ECLA Signature with a given CLA Group and User ID
+	condition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID)).
+		And(expression.Key("signature_reference_id").Equal(expression.Value(userID)))
+	filter := expression.Name("signature_type").Equal(expression.Value(utils.SignatureTypeCLA)).
+		And(expression.Name("signature_reference_type").Equal(expression.Value(utils.SignatureReferenceTypeUser))).
+		And(expression.Name("signature_approved").Equal(expression.Value(aws.Bool(true)))).
+		And(expression.Name("signature_signed").Equal(expression.Value(aws.Bool(true)))).
+		And(expression.Name("signature_user_ccla_company_id").Equal(expression.Value(companyID)))
+
+	builder := expression.NewBuilder().
+		WithKeyCondition(condition).
+		WithFilter(filter).
+		WithProjection(buildProjection())
+
+	// Use the nice builder to create the expression
+	expr, err := builder.Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for project ECLA signature query, error: %v", err)
+		return nil, err
+	}
+
+	// Assemble the query input parameters
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.signatureTableName),
+		Limit:                     aws.Int64(100),                             // The maximum number of items to evaluate (not necessarily the number of matching items)
+		IndexName:                 aws.String(SignatureProjectReferenceIndex), // Name of a secondary index to scan
+	}
+
+	sigs := make([]*models.Signature, 0)
+	var lastEvaluatedKey string
+
+	// Loop until we have all the records
+	for ok := true; ok; ok = lastEvaluatedKey != "" {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).Warnf("error retrieving project ECLA signature ID, error: %v", errQuery)
+			return nil, errQuery
+		}
+
+		// Convert the list of DB models to a list of response models
+		signatureList, modelErr := repo.buildProjectSignatureModels(ctx, results, claGroupID, DontLoadACLDetails)
+		if modelErr != nil {
+			log.WithFields(f).Warnf("error converting DB model to response model for signatures, error: %v",
+				modelErr)
+			return nil, modelErr
+		}
+
+		// Add to the signatures response model to the list
+		sigs = append(sigs, signatureList...)
+
+		if results.LastEvaluatedKey["signature_id"] != nil {
+			lastEvaluatedKey = *results.LastEvaluatedKey["signature_id"].S
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			lastEvaluatedKey = ""
+		}
+	}
+
+	// Didn't find a matching record
+	if len(sigs) == 0 {
+		return nil, nil
+	}
+
+	if len(sigs) > 1 {
+		log.WithFields(f).Warnf("found multiple matching ECLA signatures - found %d total", len(sigs))
 	}
 
 	return sigs[0], nil
@@ -1300,20 +1562,33 @@ func (repo repository) ProjectSignatures(ctx context.Context, projectID string)
 		IndexName:                 aws.String(indexName), // Name of a secondary index to scan
 	}
 
-	results, errQuery := repo.dynamoDBClient.Query(queryInput)
+	sigs := make([]*models.Signature, 0)
+	var lastEvaluatedKey string
 
-	if errQuery != nil {
-		log.WithFields(f).Warnf("error retrieving project signature ID for project: %s, error: %v",
-			projectID, errQuery)
-		return nil, errQuery
-	}
+	// Loop until we have all the records
+	for ok := true; ok; ok = lastEvaluatedKey != "" {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).Warnf("error retrieving project signature ID for project: %s, error: %v",
+				projectID, errQuery)
+			return nil, errQuery
+		}
 
-	// Convert the list of DB models to a list of response models
-	sigs, modelErr := repo.buildProjectSignatureModels(ctx, results, projectID, LoadACLDetails)
-	if modelErr != nil {
-		log.WithFields(f).Warnf("error converting DB model to response model for signatures with project %s, error: %v",
-			projectID, modelErr)
-		return nil, modelErr
+		// Convert the list of DB models to a list of response models
+		signatureList, modelErr := repo.buildProjectSignatureModels(ctx, results, projectID, LoadACLDetails)
+		if modelErr != nil {
+			log.WithFields(f).Warnf("error converting DB model to response model for signatures with project %s, error: %v",
+				projectID, modelErr)
+			return nil, modelErr
+		}
+		sigs = append(sigs, signatureList...)
+
+		if results.LastEvaluatedKey["signature_id"] != nil {
+			lastEvaluatedKey = *results.LastEvaluatedKey["signature_id"].S
+			queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		} else {
+			lastEvaluatedKey = ""
+		}
 	}
 
 	return &models.Signatures{
@@ -1367,47 +1642,532 @@ func (repo repository) InvalidateProjectRecord(ctx context.Context, signatureID
 	return nil
 }
 
-// GetProjectCompanyEmployeeSignatures returns a list of employee signatures for the specified project and specified company
-func (repo repository) GetProjectCompanyEmployeeSignatures(ctx context.Context, params signatures.GetProjectCompanyEmployeeSignaturesParams, pageSize int64) (*models.Signatures, error) {
+// SetResignatureRequired records that the signature must be re-signed against the major version before the deadline
+func (repo repository) SetResignatureRequired(ctx context.Context, signatureID, majorVersion, deadline string) error {
 	f := logrus.Fields{
-		"functionName":   "GetProjectCompanyEmployeeSignatures",
+		"functionName":   "SetResignatureRequired",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
-		"projectID":      params.ProjectID,
-		"companyID":      params.CompanyID,
-		"nextKey":        aws.StringValue(params.NextKey),
-		"sortOrder":      aws.StringValue(params.SortOrder),
-		"pageSize":       aws.Int64Value(params.PageSize),
+		"signatureID":    signatureID,
+		"majorVersion":   majorVersion,
+		"deadline":       deadline,
 	}
 
-	// This is the keys we want to match
-	condition := expression.Key("signature_user_ccla_company_id").Equal(expression.Value(params.CompanyID)).And(
-		expression.Key("signature_project_id").Equal(expression.Value(params.ProjectID)))
-	// Check for approved signatures
-	filter := expression.Name("signature_approved").Equal(expression.Value(aws.Bool(true))).
-		And(expression.Name("signature_signed").Equal(expression.Value(aws.Bool(true))))
+	_, now := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#V": aws.String("signature_resign_major_version"),
+			"#D": aws.String("signature_resign_deadline"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":v": {S: aws.String(majorVersion)},
+			":d": {S: aws.String(deadline)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #V = :v, #D = :d, #M = :m"),
+		TableName:        aws.String(repo.signatureTableName),
+	}
 
-	// Use the nice builder to create the expression
-	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).WithProjection(buildProjection()).Build()
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).Warnf("error updating the re-signature details for signature_id : %s error : %v ", signatureID, updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
+// RevokeEmployeeSignature records the details of the revocation of the employee acknowledgement and, unless the
+// revocation is pending, marks the acknowledgement as no longer approved. When the approval lists of the CCLA signature
+// are specified, they are replaced in the same transaction. Returns ErrEmployeeSignatureRevoked if the acknowledgement
+// is not approved anymore, ErrApprovalListModified if the approval lists were modified since they were loaded.
+func (repo repository) RevokeEmployeeSignature(ctx context.Context, signatureID, companyID string, revocation *EmployeeSignatureRevocation, cclaSignature *models.Signature, lists *ApprovalLists) error {
+	f := logrus.Fields{
+		"functionName":   "RevokeEmployeeSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+		"companyID":      companyID,
+		"revokedBy":      revocation.RevokedBy,
+		"effectiveDate":  revocation.EffectiveDate,
+		"pending":        revocation.Pending,
+	}
+
+	revocationUpdate := repo.employeeSignatureRevocationUpdate(signatureID, companyID, revocation)
+	if cclaSignature == nil || lists == nil {
+		_, updateErr := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+			TableName:                 revocationUpdate.TableName,
+			Key:                       revocationUpdate.Key,
+			ExpressionAttributeNames:  revocationUpdate.ExpressionAttributeNames,
+			ExpressionAttributeValues: revocationUpdate.ExpressionAttributeValues,
+			UpdateExpression:          revocationUpdate.UpdateExpression,
+			ConditionExpression:       revocationUpdate.ConditionExpression,
+		})
+		if updateErr != nil {
+			if aerr, ok := updateErr.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+				log.WithFields(f).Warn("employee acknowledgement is not approved anymore")
+				return ErrEmployeeSignatureRevoked
+			}
+			log.WithFields(f).Warnf("error revoking the employee acknowledgement for signature_id : %s error : %v ", signatureID, updateErr)
+			return updateErr
+		}
+		return nil
+	}
+
+	approvalListsUpdate, err := repo.approvalListsUpdate(cclaSignature, lists)
 	if err != nil {
-		log.WithFields(f).Warnf("error building expression for project signature ID query, project: %s, error: %v",
-			params.ProjectID, err)
-		return nil, err
+		log.WithFields(f).Warnf("unable to build the approval lists update, error: %v", err)
+		return err
 	}
 
-	// Assemble the query input parameters
-	queryInput := &dynamodb.QueryInput{
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		KeyConditionExpression:    expr.KeyCondition(),
-		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
-		TableName:                 aws.String(repo.signatureTableName),
-		IndexName:                 aws.String("signature-user-ccla-company-index"), // Name of a secondary index to scan
+	_, txErr := repo.dynamoDBClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{Update: revocationUpdate},
+			{Update: approvalListsUpdate},
+		},
+	})
+	if txErr != nil {
+		if canceled, ok := txErr.(*dynamodb.TransactionCanceledException); ok && len(canceled.CancellationReasons) == 2 {
+			if aws.StringValue(canceled.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+				log.WithFields(f).Warn("employee acknowledgement is not approved anymore")
+				return ErrEmployeeSignatureRevoked
+			}
+			if aws.StringValue(canceled.CancellationReasons[1].Code) == "ConditionalCheckFailed" {
+				log.WithFields(f).Warn("approval lists were modified since the signature was loaded")
+				return ErrApprovalListModified
+			}
+		}
+		log.WithFields(f).Warnf("error revoking the employee acknowledgement for signature_id : %s error : %v ", signatureID, txErr)
+		return txErr
 	}
 
-	// If we have the next key, set the exclusive start key value
-	if params.NextKey != nil {
-		log.WithFields(f).Debugf("Received a nextKey, value: %s", *params.NextKey)
+	return nil
+}
+
+// ReplaceResignedCorporateSignature moves the approval lists and the CLA Managers of the CCLA signature flagged for a
+// re-signature to the CCLA signature which re-signed it and marks the flagged signature as no longer approved, in a
+// single transaction. Returns ErrApprovalListModified if the approval lists of the new signature were modified since
+// they were loaded.
+func (repo repository) ReplaceResignedCorporateSignature(ctx context.Context, resigned *models.Signature, lists *ApprovalLists, acl []string, replaced *models.Signature) error {
+	f := logrus.Fields{
+		"functionName":        "ReplaceResignedCorporateSignature",
+		utils.XREQUESTID:      ctx.Value(utils.XREQUESTID),
+		"signatureID":         resigned.SignatureID,
+		"replacedSignatureID": replaced.SignatureID,
+	}
+
+	resignedUpdate, err := repo.approvalListsUpdate(resigned, lists)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to build the approval lists update, error: %v", err)
+		return err
+	}
+	// a transaction holds a single operation per item - the CLA Managers are set along with the approval lists
+	if len(acl) > 0 {
+		resignedUpdate.ExpressionAttributeNames["#ACL"] = aws.String("signature_acl")
+		resignedUpdate.ExpressionAttributeValues[":acl"] = &dynamodb.AttributeValue{SS: aws.StringSlice(acl)}
+		resignedUpdate.UpdateExpression = aws.String("SET #ACL = :acl, " + strings.TrimPrefix(aws.StringValue(resignedUpdate.UpdateExpression), "SET "))
+	}
+
+	_, now := utils.CurrentTime()
+	replacedUpdate := &dynamodb.Update{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(replaced.SignatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#A": aws.String("signature_approved"),
+			"#T": aws.String("sigtype_signed_approved_id"),
+			"#R": aws.String("signature_replaced_by"),
+			"#V": aws.String("signature_resign_major_version"),
+			"#D": aws.String("signature_resign_deadline"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":a":        {BOOL: aws.Bool(false)},
+			":approved": {BOOL: aws.Bool(true)},
+			":t":        {S: aws.String(fmt.Sprintf("%s#%v#%v#%s", utils.ClaTypeCCLA, true, false, replaced.SignatureReferenceID))},
+			":r":        {S: aws.String(resigned.SignatureID)},
+			":v":        {S: aws.String(replaced.ResignMajorVersion)},
+			":m":        {S: aws.String(now)},
+		},
+		UpdateExpression:    aws.String("SET #A = :a, #T = :t, #R = :r, #M = :m REMOVE #V, #D"),
+		ConditionExpression: aws.String("#A = :approved AND #V = :v"),
+	}
+
+	_, txErr := repo.dynamoDBClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{Update: resignedUpdate},
+			{Update: replacedUpdate},
+		},
+	})
+	if txErr != nil {
+		if canceled, ok := txErr.(*dynamodb.TransactionCanceledException); ok && len(canceled.CancellationReasons) == 2 {
+			if aws.StringValue(canceled.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+				log.WithFields(f).Warn("approval lists were modified since the signature was loaded")
+				return ErrApprovalListModified
+			}
+			if aws.StringValue(canceled.CancellationReasons[1].Code) == "ConditionalCheckFailed" {
+				log.WithFields(f).Debug("the flagged CCLA signature was already replaced")
+				return nil
+			}
+		}
+		log.WithFields(f).Warnf("error replacing the CCLA signature flagged for a re-signature, error: %v", txErr)
+		return txErr
+	}
+
+	return nil
+}
+
+// employeeSignatureRevocationUpdate returns the update recording the revocation of the approved employee
+// acknowledgement, the acknowledgement stays approved while the revocation is pending
+func (repo repository) employeeSignatureRevocationUpdate(signatureID, companyID string, revocation *EmployeeSignatureRevocation) *dynamodb.Update {
+	_, now := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#A": aws.String("signature_approved"),
+		"#O": aws.String("signature_revoked_on"),
+		"#B": aws.String("signature_revoked_by"),
+		"#R": aws.String("signature_revocation_reason"),
+		"#E": aws.String("signature_revocation_effective_date"),
+		"#P": aws.String("signature_revocation_pending"),
+		"#M": aws.String("date_modified"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":approved": {BOOL: aws.Bool(true)},
+		":o":        {S: aws.String(revocation.RevokedOn)},
+		":b":        {S: aws.String(revocation.RevokedBy)},
+		":r":        {S: aws.String(revocation.Reason)},
+		":e":        {S: aws.String(revocation.EffectiveDate)},
+		":m":        {S: aws.String(now)},
+	}
+	updateExpression := "SET #O = :o, #B = :b, #R = :r, #E = :e, #M = :m"
+	if revocation.Pending {
+		expressionAttributeValues[":p"] = &dynamodb.AttributeValue{BOOL: aws.Bool(true)}
+		updateExpression = updateExpression + ", #P = :p"
+	} else {
+		expressionAttributeNames["#T"] = aws.String("sigtype_signed_approved_id")
+		expressionAttributeValues[":a"] = &dynamodb.AttributeValue{BOOL: aws.Bool(false)}
+		expressionAttributeValues[":t"] = &dynamodb.AttributeValue{S: aws.String(fmt.Sprintf("%s#%v#%v#%s", utils.ClaTypeECLA, true, false, companyID))}
+		updateExpression = updateExpression + ", #A = :a, #T = :t REMOVE #P"
+	}
+
+	return &dynamodb.Update{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          aws.String(updateExpression),
+		ConditionExpression:       aws.String("#A = :approved"),
+	}
+}
+
+// ApplyDueEmployeeSignatureRevocations marks the employee acknowledgements whose pending revocation is effective at
+// the specified time as no longer approved. Returns the number of acknowledgements revoked.
+func (repo repository) ApplyDueEmployeeSignatureRevocations(ctx context.Context, now string) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "ApplyDueEmployeeSignatureRevocations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"now":            now,
+	}
+
+	filter := expression.Name("signature_revocation_pending").Equal(expression.Value(true)).
+		And(expression.Name("signature_revocation_effective_date").LessThanEqual(expression.Value(now)))
+	projection := expression.NamesList(expression.Name("signature_id"), expression.Name("signature_user_ccla_company_id"))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for the pending revocations scan, error: %v", err)
+		return 0, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+	}
+
+	var due []ItemSignature
+	for ok := true; ok; ok = scanInput.ExclusiveStartKey != nil {
+		results, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).Warnf("error scanning the pending revocations, error: %v", scanErr)
+			return 0, scanErr
+		}
+		var items []ItemSignature
+		if unmarshalErr := dynamodbattribute.UnmarshalListOfMaps(results.Items, &items); unmarshalErr != nil {
+			log.WithFields(f).Warnf("error unmarshalling the pending revocations, error: %v", unmarshalErr)
+			return 0, unmarshalErr
+		}
+		due = append(due, items...)
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	revoked := 0
+	for _, item := range due {
+		_, updateErr := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+			TableName: aws.String(repo.signatureTableName),
+			Key: map[string]*dynamodb.AttributeValue{
+				"signature_id": {
+					S: aws.String(item.SignatureID),
+				},
+			},
+			ExpressionAttributeNames: map[string]*string{
+				"#A": aws.String("signature_approved"),
+				"#T": aws.String("sigtype_signed_approved_id"),
+				"#E": aws.String("signature_revocation_effective_date"),
+				"#P": aws.String("signature_revocation_pending"),
+				"#M": aws.String("date_modified"),
+			},
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":a":        {BOOL: aws.Bool(false)},
+				":approved": {BOOL: aws.Bool(true)},
+				":t":        {S: aws.String(fmt.Sprintf("%s#%v#%v#%s", utils.ClaTypeECLA, true, false, item.SignatureUserCompanyID))},
+				":pending":  {BOOL: aws.Bool(true)},
+				":now":      {S: aws.String(now)},
+			},
+			UpdateExpression:    aws.String("SET #A = :a, #T = :t, #M = :now REMOVE #P"),
+			ConditionExpression: aws.String("#A = :approved AND #P = :pending AND #E <= :now"),
+		})
+		if updateErr != nil {
+			// The next run picks the acknowledgement up again, unless it was revoked in the meantime
+			log.WithFields(f).Warnf("error applying the pending revocation of signature_id : %s error : %v ", item.SignatureID, updateErr)
+			continue
+		}
+		revoked++
+	}
+
+	return revoked, nil
+}
+
+// CreateIndividualSignature adds a new ICLA signature record, the signature ID must not exist
+func (repo repository) CreateIndividualSignature(ctx context.Context, signature *ItemIndividualSignature) error {
+	f := logrus.Fields{
+		"functionName":   "CreateIndividualSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signature.SignatureID,
+		"claGroupID":     signature.SignatureProjectID,
+		"userID":         signature.SignatureReferenceID,
+	}
+	return repo.createSignatureRecord(f, signature)
+}
+
+// CreateCorporateSignature adds a new CCLA signature record, the signature ID must not exist
+func (repo repository) CreateCorporateSignature(ctx context.Context, signature *ItemCorporateSignature) error {
+	f := logrus.Fields{
+		"functionName":   "CreateCorporateSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signature.SignatureID,
+		"claGroupID":     signature.SignatureProjectID,
+		"companyID":      signature.SignatureReferenceID,
+	}
+	return repo.createSignatureRecord(f, signature)
+}
+
+// createSignatureRecord puts the signature database model in the table
+func (repo repository) createSignatureRecord(f logrus.Fields, signature interface{}) error {
+	av, err := dynamodbattribute.MarshalMap(signature)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal the signature record, error: %v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.signatureTableName),
+		ConditionExpression: aws.String("attribute_not_exists(signature_id)"),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to create the signature record, error: %v", err)
+		return err
+	}
+
+	log.WithFields(f).Debug("created signature record")
+	return nil
+}
+
+// GetCorporateSignatureRecord returns the database model of the CCLA signature, nil if not found
+func (repo repository) GetCorporateSignatureRecord(ctx context.Context, signatureID string) (*ItemCorporateSignature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetCorporateSignatureRecord",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("error retrieving the signature record, error: %v", err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var signature ItemCorporateSignature
+	err = dynamodbattribute.UnmarshalMap(result.Item, &signature)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling the signature record, error: %v", err)
+		return nil, err
+	}
+	return &signature, nil
+}
+
+// ReplaceCorporateSignatureEnvelope stores the new envelope and document version of the pending CCLA signature when
+// the signing request is sent again, the signature must not be signed
+func (repo repository) ReplaceCorporateSignatureEnvelope(ctx context.Context, signature *ItemCorporateSignature) error {
+	f := logrus.Fields{
+		"functionName":   "ReplaceCorporateSignatureEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signature.SignatureID,
+		"envelopeID":     signature.SignatureEnvelopeID,
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signature.SignatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#S":     aws.String("signature_signed"),
+			"#EN":    aws.String("signature_envelope_id"),
+			"#U":     aws.String("signature_sign_url"),
+			"#SM":    aws.String("signature_sign_method"),
+			"#R":     aws.String("signature_return_url"),
+			"#MAJOR": aws.String("signature_document_major_version"),
+			"#MINOR": aws.String("signature_document_minor_version"),
+			"#M":     aws.String("date_modified"),
+			"#ID":    aws.String("signature_id"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":f":     {BOOL: aws.Bool(false)},
+			":en":    {S: aws.String(signature.SignatureEnvelopeID)},
+			":u":     {S: aws.String(signature.SignatureSignURL)},
+			":sm":    {S: aws.String(signature.SignatureSignMethod)},
+			":r":     {S: aws.String(signature.SignatureReturnURL)},
+			":major": {S: aws.String(signature.SignatureDocumentMajorVersion)},
+			":minor": {S: aws.String(signature.SignatureDocumentMinorVersion)},
+			":m":     {S: aws.String(signature.DateModified)},
+		},
+		UpdateExpression:    aws.String("SET #EN = :en, #U = :u, #SM = :sm, #R = :r, #MAJOR = :major, #MINOR = :minor, #M = :m"),
+		ConditionExpression: aws.String("attribute_exists(#ID) AND #S = :f"),
+		TableName:           aws.String(repo.signatureTableName),
+	}
+
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).Warnf("error replacing the envelope of the signature, error: %v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
+// MarkCorporateSignatureSigned updates the pending CCLA signature once the signatory signed it
+func (repo repository) MarkCorporateSignatureSigned(ctx context.Context, signatureID, companyID, signatoryName, signatoryEmail, signedOn string) error {
+	f := logrus.Fields{
+		"functionName":   "MarkCorporateSignatureSigned",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+		"companyID":      companyID,
+	}
+
+	_, now := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#S":  aws.String("signature_signed"),
+			"#T":  aws.String("sigtype_signed_approved_id"),
+			"#O":  aws.String("signed_on"),
+			"#N":  aws.String("signatory_name"),
+			"#E":  aws.String("signatory_email"),
+			"#M":  aws.String("date_modified"),
+			"#ID": aws.String("signature_id"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":s": {BOOL: aws.Bool(true)},
+			":f": {BOOL: aws.Bool(false)},
+			":t": {S: aws.String(fmt.Sprintf("%s#%v#%v#%s", utils.ClaTypeCCLA, true, true, companyID))},
+			":o": {S: aws.String(signedOn)},
+			":n": {S: aws.String(signatoryName)},
+			":e": {S: aws.String(signatoryEmail)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression:    aws.String("SET #S = :s, #T = :t, #O = :o, #N = :n, #E = :e, #M = :m"),
+		ConditionExpression: aws.String("attribute_exists(#ID) AND #S = :f"),
+		TableName:           aws.String(repo.signatureTableName),
+	}
+
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).Warnf("error marking the signature as signed, error: %v", updateErr)
+		return updateErr
+	}
+
+	return nil
+}
+
+// GetProjectCompanyEmployeeSignatures returns a list of employee signatures for the specified project and specified company
+func (repo repository) GetProjectCompanyEmployeeSignatures(ctx context.Context, params signatures.GetProjectCompanyEmployeeSignaturesParams, pageSize int64) (*models.Signatures, error) {
+	f := logrus.Fields{
+		"functionName":   "GetProjectCompanyEmployeeSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectID":      params.ProjectID,
+		"companyID":      params.CompanyID,
+		"nextKey":        aws.StringValue(params.NextKey),
+		"sortOrder":      aws.StringValue(params.SortOrder),
+		"pageSize":       aws.Int64Value(params.PageSize),
+	}
+
+	// This is the keys we want to match
+	condition := expression.Key("signature_user_ccla_company_id").Equal(expression.Value(params.CompanyID)).And(
+		expression.Key("signature_project_id").Equal(expression.Value(params.ProjectID)))
+	// Check for approved signatures
+	filter := expression.Name("signature_approved").Equal(expression.Value(aws.Bool(true))).
+		And(expression.Name("signature_signed").Equal(expression.Value(aws.Bool(true))))
+
+	// Use the nice builder to create the expression
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).WithProjection(buildProjection()).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for project signature ID query, project: %s, error: %v",
+			params.ProjectID, err)
+		return nil, err
+	}
+
+	// Assemble the query input parameters
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String("signature-user-ccla-company-index"), // Name of a secondary index to scan
+	}
+
+	// If we have the next key, set the exclusive start key value
+	if params.NextKey != nil {
+		log.WithFields(f).Debugf("Received a nextKey, value: %s", *params.NextKey)
 		// The primary key of the first item that this operation will evaluate.
 		// and the query key (if not the same)
 		queryInput.ExclusiveStartKey = map[string]*dynamodb.AttributeValue{
@@ -1933,141 +2693,129 @@ func (repo repository) RemoveCLAManager(ctx context.Context, signatureID, claMan
 	return sigModel, nil
 }
 
-// UpdateApprovalList updates the specified project/company signature with the updated approval list information
-func (repo repository) UpdateApprovalList(ctx context.Context, projectID, companyID string, params *models.ApprovalList) (*models.Signature, error) { // nolint
+// ReplaceApprovalLists replaces the email, domain, GitHub username, GitHub organization and GitHub team approval lists
+// and the metadata of their entries of the signature in a single update. The update is conditioned on the approval
+// lists and the metadata loaded with the signature, returns ErrApprovalListModified if they were modified in the
+// meantime.
+func (repo repository) ReplaceApprovalLists(ctx context.Context, sig *models.Signature, lists *ApprovalLists) (*models.Signature, error) {
 	f := logrus.Fields{
-		"functionName": "UpdateApprovalList",
-		"projectID":    projectID,
-		"companyID":    companyID,
+		"functionName":   "ReplaceApprovalLists",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
 	}
-	log.WithFields(f).Debug("querying database for approval list details")
 
-	signed, approved := true, true
-	pageSize := int64(10)
-	log.WithFields(f).Debugf("querying database for approval list details using company ID: %s project ID: %s, type: ccla, signed: true, approved: true",
-		companyID, projectID)
-	sortOrder := utils.SortOrderAscending
-	sigs, sigErr := repo.GetProjectCompanySignatures(ctx, companyID, projectID, &signed, &approved, nil, &sortOrder, &pageSize)
-	if sigErr != nil {
-		return nil, sigErr
+	update, err := repo.approvalListsUpdate(sig, lists)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to marshal the approval list metadata, error: %v", err)
+		return nil, err
 	}
 
-	if sigs == nil || sigs.Signatures == nil {
-		msg := fmt.Sprintf("unable to locate signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
-			companyID, projectID, signed, approved)
-		log.WithFields(f).Warn(msg)
-		return nil, errors.New(msg)
+	input := &dynamodb.UpdateItemInput{
+		TableName:                 update.TableName,
+		Key:                       update.Key,
+		ExpressionAttributeNames:  update.ExpressionAttributeNames,
+		ExpressionAttributeValues: update.ExpressionAttributeValues,
+		UpdateExpression:          update.UpdateExpression,
+		ConditionExpression:       update.ConditionExpression,
 	}
 
-	if len(sigs.Signatures) > 1 {
-		log.WithFields(f).Warnf("more than 1 CCLA signature returned for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t - expecting zero or 1 - using first record",
-			companyID, projectID, signed, approved)
+	log.WithFields(f).Debugf("replacing approval lists using update expression: %s", aws.StringValue(update.UpdateExpression))
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		if aerr, ok := updateErr.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Warn("approval lists were modified since the signature was loaded")
+			return nil, ErrApprovalListModified
+		}
+		log.WithFields(f).Warnf("error replacing approval lists, error: %v", updateErr)
+		return nil, updateErr
 	}
 
-	// Just grab and use the first one - need to figure out conflict resolution if more than one
-	sig := sigs.Signatures[0]
-	expressionAttributeNames := map[string]*string{}
-	expressionAttributeValues := map[string]*dynamodb.AttributeValue{}
-	haveAdditions := false
-	updateExpression := ""
-
-	// If we have an add or remove email list...we need to run an update for this column
-	if params.AddEmailApprovalList != nil || params.RemoveEmailApprovalList != nil {
-		columnName := "email_whitelist"
-		attrList := buildApprovalAttributeList(ctx, sig.EmailApprovalList, params.AddEmailApprovalList, params.RemoveEmailApprovalList)
-		// If no entries after consolidating all the updates, we need to remove the column
-		if attrList == nil || attrList.L == nil {
-			var rmColErr error
-			sig, rmColErr = repo.removeColumn(ctx, sig.SignatureID, columnName)
-			if rmColErr != nil {
-				msg := fmt.Sprintf("unable to remove column %s for signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
-					columnName, companyID, projectID, signed, approved)
-				log.WithFields(f).Warn(msg)
-				return nil, errors.New(msg)
-			}
-		} else {
-			haveAdditions = true
-			expressionAttributeNames["#E"] = aws.String("email_whitelist")
-			expressionAttributeValues[":e"] = attrList
-			updateExpression = updateExpression + " #E = :e, "
-		}
-	}
-
-	if params.AddDomainApprovalList != nil || params.RemoveDomainApprovalList != nil {
-		columnName := "domain_whitelist"
-		attrList := buildApprovalAttributeList(ctx, sig.DomainApprovalList, params.AddDomainApprovalList, params.RemoveDomainApprovalList)
-		// If no entries after consolidating all the updates, we need to remove the column
-		if attrList == nil || attrList.L == nil {
-			var rmColErr error
-			sig, rmColErr = repo.removeColumn(ctx, sig.SignatureID, columnName)
-			if rmColErr != nil {
-				msg := fmt.Sprintf("unable to remove column %s for signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
-					columnName, companyID, projectID, signed, approved)
-				log.WithFields(f).Warn(msg)
-				return nil, errors.New(msg)
-			}
-		} else {
-			haveAdditions = true
-			expressionAttributeNames["#D"] = aws.String(columnName)
-			expressionAttributeValues[":d"] = attrList
-			updateExpression = updateExpression + " #D = :d, "
-		}
-	}
-
-	if params.AddGithubUsernameApprovalList != nil || params.RemoveGithubUsernameApprovalList != nil {
-		columnName := "github_whitelist"
-		attrList := buildApprovalAttributeList(ctx, sig.GithubUsernameApprovalList, params.AddGithubUsernameApprovalList, params.RemoveGithubUsernameApprovalList)
-		// If no entries after consolidating all the updates, we need to remove the column
-		if attrList == nil || attrList.L == nil {
-			var rmColErr error
-			sig, rmColErr = repo.removeColumn(ctx, sig.SignatureID, columnName)
-			if rmColErr != nil {
-				msg := fmt.Sprintf("unable to remove column %s for signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
-					columnName, companyID, projectID, signed, approved)
-				log.WithFields(f).Warn(msg)
-				return nil, errors.New(msg)
-			}
+	return repo.GetSignature(ctx, sig.SignatureID)
+}
+
+// approvalListsUpdate returns the update replacing the approval lists and the entry metadata of the signature,
+// conditioned on the approval lists and the metadata loaded with the signature
+func (repo repository) approvalListsUpdate(sig *models.Signature, lists *ApprovalLists) (*dynamodb.Update, error) {
+	columns := []struct {
+		name     string
+		existing []string
+		updated  []string
+	}{
+		{name: "email_whitelist", existing: sig.EmailApprovalList, updated: lists.Emails},
+		{name: "domain_whitelist", existing: sig.DomainApprovalList, updated: lists.Domains},
+		{name: "github_whitelist", existing: sig.GithubUsernameApprovalList, updated: lists.GitHubUsernames},
+		{name: "github_org_whitelist", existing: sig.GithubOrgApprovalList, updated: lists.GitHubOrgs},
+		{name: "github_team_approval_list", existing: sig.GithubTeamApprovalList, updated: lists.GitHubTeams},
+	}
+
+	// The author of the update is recorded for the approval list changed event of the signatures table stream
+	modifiedBy := approvalListModifiedBy(lists.ModifiedBy)
+
+	_, now := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{
+		"#ID": aws.String("signature_id"),
+		"#M":  aws.String("date_modified"),
+		"#MB": aws.String("approval_list_modified_by"),
+	}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{
+		":m":    {S: aws.String(now)},
+		":mb":   {S: aws.String(modifiedBy)},
+		":zero": {N: aws.String("0")},
+	}
+	setExpressions := []string{"#M = :m", "#MB = :mb"}
+	var removeExpressions []string
+	conditions := []string{"attribute_exists(#ID)"}
+	for i, column := range columns {
+		name := fmt.Sprintf("#C%d", i)
+		expressionAttributeNames[name] = aws.String(column.name)
+
+		if len(column.updated) > 0 {
+			value := fmt.Sprintf(":c%d", i)
+			expressionAttributeValues[value] = approvalListAttribute(column.updated)
+			setExpressions = append(setExpressions, fmt.Sprintf("%s = %s", name, value))
 		} else {
-			haveAdditions = true
-			expressionAttributeNames["#G"] = aws.String(columnName)
-			expressionAttributeValues[":g"] = attrList
-			updateExpression = updateExpression + " #G = :g, "
-		}
-	}
-
-	if params.AddGithubOrgApprovalList != nil || params.RemoveGithubOrgApprovalList != nil {
-		columnName := "github_org_whitelist"
-		attrList := buildApprovalAttributeList(ctx, sig.GithubOrgApprovalList, params.AddGithubOrgApprovalList, params.RemoveGithubOrgApprovalList)
-		// If no entries after consolidating all the updates, we need to remove the column
-		if attrList == nil || attrList.L == nil {
-			var rmColErr error
-			sig, rmColErr = repo.removeColumn(ctx, sig.SignatureID, columnName)
-			if rmColErr != nil {
-				msg := fmt.Sprintf("unable to remove column %s for signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
-					columnName, companyID, projectID, signed, approved)
-				log.WithFields(f).Warn(msg)
-				return nil, errors.New(msg)
-			}
+			removeExpressions = append(removeExpressions, name)
+		}
+
+		// Only update the list if it was not modified since it was loaded
+		if len(column.existing) > 0 {
+			value := fmt.Sprintf(":o%d", i)
+			expressionAttributeValues[value] = approvalListAttribute(column.existing)
+			conditions = append(conditions, fmt.Sprintf("%s = %s", name, value))
 		} else {
-			haveAdditions = true
-			expressionAttributeNames["#GO"] = aws.String("github_org_whitelist")
-			expressionAttributeValues[":go"] = attrList
-			updateExpression = updateExpression + " #GO = :go, "
+			conditions = append(conditions, fmt.Sprintf("(attribute_not_exists(%s) OR size(%s) = :zero)", name, name))
 		}
 	}
 
-	// Ensure at least one value is set for us to update
-	if !haveAdditions {
-		log.WithFields(f).Debugf("no updates required to any of the approved list values company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t - expecting at least something to update",
-			companyID, projectID, signed, approved)
-		return sig, nil
+	// The metadata of the entries is replaced along with the lists, only if it was not modified since it was loaded
+	expressionAttributeNames["#AM"] = aws.String("approval_list_metadata")
+	if len(sig.ApprovalListEntries) > 0 {
+		existing, marshalErr := approvalListMetadataAttribute(sig.ApprovalListEntries)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		expressionAttributeValues[":oam"] = existing
+		conditions = append(conditions, "#AM = :oam")
+	} else {
+		conditions = append(conditions, "attribute_not_exists(#AM)")
+	}
+	if len(lists.Entries) > 0 {
+		metadata, marshalErr := approvalListMetadataAttribute(lists.Entries)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		expressionAttributeValues[":am"] = metadata
+		setExpressions = append(setExpressions, "#AM = :am")
+	} else {
+		removeExpressions = append(removeExpressions, "#AM")
 	}
 
-	// Remove trailing comma from the expression, if present
-	updateExpression = utils.TrimRemoveTrailingComma("SET " + updateExpression)
+	updateExpression := "SET " + strings.Join(setExpressions, ", ")
+	if len(removeExpressions) > 0 {
+		updateExpression = updateExpression + " REMOVE " + strings.Join(removeExpressions, ", ")
+	}
 
-	// Update dynamoDB table
-	input := &dynamodb.UpdateItemInput{
+	return &dynamodb.Update{
 		TableName: aws.String(repo.signatureTableName),
 		Key: map[string]*dynamodb.AttributeValue{
 			"signature_id": {
@@ -2076,51 +2824,78 @@ func (repo repository) UpdateApprovalList(ctx context.Context, projectID, compan
 		},
 		ExpressionAttributeNames:  expressionAttributeNames,
 		ExpressionAttributeValues: expressionAttributeValues,
-		UpdateExpression:          aws.String(updateExpression), //aws.String("SET #L = :l"),
+		UpdateExpression:          aws.String(updateExpression),
+		ConditionExpression:       aws.String(strings.Join(conditions, " AND ")),
+	}, nil
+}
+
+// GetSignaturesWithApprovalListEntries returns the CCLA signatures holding approval list entry metadata, such as
+// the expiry date of the entries
+func (repo repository) GetSignaturesWithApprovalListEntries(ctx context.Context) ([]*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "GetSignaturesWithApprovalListEntries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 	}
 
-	log.WithFields(f).Debugf("updating approval list for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
-		companyID, projectID, signed, approved)
+	filter := expression.Name("approval_list_metadata").AttributeExists().
+		And(expression.Name("signature_type").Equal(expression.Value(utils.SignatureTypeCCLA)))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(buildProjection()).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for the approval list entries scan, error: %v", err)
+		return nil, err
+	}
 
-	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
-	if updateErr != nil {
-		log.WithFields(f).Warnf("error updating approval lists for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t, error: %v",
-			companyID, projectID, signed, approved, updateErr)
-		return nil, updateErr
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
 	}
 
-	log.WithFields(f).Debugf("querying database for approval list details after update using company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
-		companyID, projectID, signed, approved)
+	var sigs []*models.Signature
+	for {
+		results, dbErr := repo.dynamoDBClient.Scan(scanInput)
+		if dbErr != nil {
+			log.WithFields(f).Warnf("error scanning the signatures with approval list entries, error: %v", dbErr)
+			return nil, dbErr
+		}
 
-	updatedSig, sigErr := repo.GetProjectCompanySignatures(ctx, companyID, projectID, &signed, &approved, nil, &sortOrder, &pageSize)
-	if sigErr != nil {
-		return nil, sigErr
-	}
+		signatureList, modelErr := repo.buildProjectSignatureModels(ctx, &dynamodb.QueryOutput{Items: results.Items}, "", LoadACLDetails)
+		if modelErr != nil {
+			return nil, modelErr
+		}
+		sigs = append(sigs, signatureList...)
 
-	if updatedSig == nil || updatedSig.Signatures == nil {
-		msg := fmt.Sprintf("unable to locate signature after update for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
-			companyID, projectID, signed, approved)
-		log.WithFields(f).Warn(msg)
-		return nil, errors.New(msg)
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
 	}
 
-	if len(updatedSig.Signatures) > 1 {
-		log.WithFields(f).Warnf("more than 1 CCLA signature returned after update for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t - expecting zero or 1 - using first record",
-			companyID, projectID, signed, approved)
-	}
+	log.WithFields(f).Debugf("found %d signatures with approval list entries", len(sigs))
+	return sigs, nil
+}
 
-	// Just grab and use the first one - need to figure out conflict resolution if more than one
-	return updatedSig.Signatures[0], nil
+// approvalListModifiedBy returns the user name recorded on the signature as the author of an approval list update,
+// read by the approval list changed event of the signatures table stream - SystemUsername if empty
+func approvalListModifiedBy(modifiedBy string) string {
+	if modifiedBy == "" {
+		return SystemUsername
+	}
+	return modifiedBy
 }
 
-// removeColumn is a helper function to remove a given column when we need to zero out the column value - typically the approval list
-func (repo repository) removeColumn(ctx context.Context, signatureID, columnName string) (*models.Signature, error) {
+// removeColumn is a helper function to remove a given column when we need to zero out the column value - typically the approval list,
+// the user who updated the approval lists is recorded along with the removal
+func (repo repository) removeColumn(ctx context.Context, signatureID, columnName, modifiedBy string) (*models.Signature, error) {
 	f := logrus.Fields{
 		"functionName": "removeColumn",
 		"signatureID":  signatureID,
 		"columnName":   columnName,
 	}
 	log.WithFields(f).Debug("removing column from signature")
+	_, now := utils.CurrentTime()
 
 	// Update dynamoDB table
 	input := &dynamodb.UpdateItemInput{
@@ -2132,13 +2907,14 @@ func (repo repository) removeColumn(ctx context.Context, signatureID, columnName
 		},
 		ExpressionAttributeNames: map[string]*string{
 			"#" + columnName: aws.String(columnName),
+			"#M":             aws.String("date_modified"),
+			"#MB":            aws.String("approval_list_modified_by"),
 		},
-		//ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-		//	":a": {
-		//		S: aws.String("bar"),
-		//	},
-		//},
-		UpdateExpression: aws.String("REMOVE #" + columnName), //aws.String("REMOVE github_org_whitelist"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":m":  {S: aws.String(now)},
+			":mb": {S: aws.String(approvalListModifiedBy(modifiedBy))},
+		},
+		UpdateExpression: aws.String("SET #M = :m, #MB = :mb REMOVE #" + columnName), //aws.String("REMOVE github_org_whitelist"),
 		ReturnValues:     aws.String(dynamodb.ReturnValueNone),
 	}
 
@@ -2446,21 +3222,55 @@ func (repo repository) GetClaGroupCorporateContributors(ctx context.Context, cla
 		"companyID":      aws.StringValue(companyID),
 	}
 
+	out := &models.CorporateContributorList{List: make([]*models.CorporateContributor, 0)}
+	if searchTerm != nil {
+		searchTerm = aws.String(strings.ToLower(*searchTerm))
+	}
+
+	// The revoked employee acknowledgements are no longer approved, they are returned as inactive
+	for _, approved := range []bool{true, false} {
+		if err := repo.getClaGroupCorporateContributors(ctx, claGroupID, companyID, searchTerm, approved, out); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the corporate contributors")
+			return nil, err
+		}
+	}
+	sort.Slice(out.List, func(i, j int) bool {
+		return out.List[i].Name < out.List[j].Name
+	})
+
+	return out, nil
+}
+
+// getClaGroupCorporateContributors appends the employee acknowledgements with the specified approved status to the
+// list, only the revoked acknowledgements are returned when not approved
+func (repo repository) getClaGroupCorporateContributors(ctx context.Context, claGroupID string, companyID *string, searchTerm *string, approved bool, out *models.CorporateContributorList) error {
+	f := logrus.Fields{
+		"functionName":   "getClaGroupCorporateContributors",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companyID":      aws.StringValue(companyID),
+		"approved":       approved,
+	}
+
 	condition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID))
 	if companyID != nil {
-		sortKey := fmt.Sprintf("%s#%v#%v#%v", utils.ClaTypeECLA, true, true, *companyID)
+		sortKey := fmt.Sprintf("%s#%v#%v#%v", utils.ClaTypeECLA, true, approved, *companyID)
 		condition = condition.And(expression.Key("sigtype_signed_approved_id").Equal(expression.Value(sortKey)))
 	} else {
-		sortKeyPrefix := fmt.Sprintf("%s#%v#%v", utils.ClaTypeECLA, true, true)
+		sortKeyPrefix := fmt.Sprintf("%s#%v#%v", utils.ClaTypeECLA, true, approved)
 		condition = condition.And(expression.Key("sigtype_signed_approved_id").BeginsWith(sortKeyPrefix))
 	}
 
 	// Use the builder to create the expression
-	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithProjection(buildProjection()).Build()
+	builder := expression.NewBuilder().WithKeyCondition(condition).WithProjection(buildProjection())
+	if !approved {
+		builder = builder.WithFilter(expression.Name("signature_revoked_on").AttributeExists())
+	}
+	expr, err := builder.Build()
 	if err != nil {
 		log.WithFields(f).Warnf("error building expression for get cla group icla signatures, claGroupID: %s, error: %v",
 			claGroupID, err)
-		return nil, err
+		return err
 	}
 
 	// Assemble the query input parameters
@@ -2469,23 +3279,19 @@ func (repo repository) GetClaGroupCorporateContributors(ctx context.Context, cla
 		ExpressionAttributeValues: expr.Values(),
 		KeyConditionExpression:    expr.KeyCondition(),
 		ProjectionExpression:      expr.Projection(),
+		FilterExpression:          expr.Filter(),
 		TableName:                 aws.String(repo.signatureTableName),
 		IndexName:                 aws.String(SignatureProjectIDSigTypeSignedApprovedIDIndex),
 		Limit:                     aws.Int64(HugePageSize),
 	}
 
-	out := &models.CorporateContributorList{List: make([]*models.CorporateContributor, 0)}
-	if searchTerm != nil {
-		searchTerm = aws.String(strings.ToLower(*searchTerm))
-	}
-
 	for {
 		// Make the DynamoDB Query API call
 		log.WithFields(f).Debug("querying signatures...")
 		results, queryErr := repo.dynamoDBClient.Query(queryInput)
 		if queryErr != nil {
 			log.WithFields(f).Warnf("error retrieving icla signatures for project: %s, error: %v", claGroupID, queryErr)
-			return nil, queryErr
+			return queryErr
 		}
 
 		var dbSignatures []ItemSignature
@@ -2494,7 +3300,7 @@ func (repo repository) GetClaGroupCorporateContributors(ctx context.Context, cla
 		if err != nil {
 			log.WithFields(f).Warnf("error unmarshalling icla signatures from database for cla group: %s, error: %v",
 				claGroupID, err)
-			return nil, err
+			return err
 		}
 
 		for _, sig := range dbSignatures {
@@ -2512,15 +3318,21 @@ func (repo repository) GetClaGroupCorporateContributors(ctx context.Context, cla
 			}
 			signatureVersion := fmt.Sprintf("v%s.%s", sig.SignatureDocumentMajorVersion, sig.SignatureDocumentMinorVersion)
 			out.List = append(out.List, &models.CorporateContributor{
-				GithubID:               sig.UserGithubUsername,
-				LinuxFoundationID:      sig.UserLFUsername,
-				Name:                   sig.UserName,
-				SignatureVersion:       signatureVersion,
-				Email:                  sig.UserEmail,
-				Timestamp:              sigCreatedTime,
-				UserDocusignName:       sig.UserDocusignName,
-				UserDocusignDateSigned: sig.UserDocusignDateSigned,
-				SignatureModified:      sig.DateModified,
+				GithubID:                sig.UserGithubUsername,
+				LinuxFoundationID:       sig.UserLFUsername,
+				Name:                    sig.UserName,
+				SignatureVersion:        signatureVersion,
+				Email:                   sig.UserEmail,
+				Timestamp:               sigCreatedTime,
+				UserDocusignName:        sig.UserDocusignName,
+				UserDocusignDateSigned:  sig.UserDocusignDateSigned,
+				SignatureModified:       sig.DateModified,
+				SignatureID:             sig.SignatureID,
+				UserID:                  sig.SignatureReferenceID,
+				Active:                  approved,
+				RevokedOn:               sig.SignatureRevokedOn,
+				RevocationReason:        sig.SignatureRevocationReason,
+				RevocationEffectiveDate: sig.SignatureRevocationDate,
 			})
 		}
 
@@ -2530,9 +3342,6 @@ func (repo repository) GetClaGroupCorporateContributors(ctx context.Context, cla
 		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
 		log.WithFields(f).Debug("querying next page")
 	}
-	sort.Slice(out.List, func(i, j int) bool {
-		return out.List[i].Name < out.List[j].Name
-	})
 
-	return out, nil
+	return nil
 }
@@ -0,0 +1,275 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// ResignatureRequired returns true if the signature was signed against an older document major version than
+// the one published for the CLA Group. Re-signing updates the signature version, which clears the requirement.
+func ResignatureRequired(sig *models.Signature) bool {
+	if sig.ResignMajorVersion == "" {
+		return false
+	}
+	required, err := strconv.Atoi(sig.ResignMajorVersion)
+	if err != nil {
+		return false
+	}
+	signed, err := strconv.Atoi(sig.SignatureMajorVersion)
+	if err != nil {
+		// unknown signed version - treat it as older than the required version
+		return true
+	}
+	return signed < required
+}
+
+// resignatureExpired returns true if the signature must be re-signed and the grace period is over
+func resignatureExpired(sig *models.Signature, now time.Time) bool {
+	if !ResignatureRequired(sig) {
+		return false
+	}
+	deadline, err := utils.ParseDateTime(sig.ResignDeadline)
+	if err != nil {
+		// without a valid deadline there is no grace period
+		return true
+	}
+	return now.After(deadline)
+}
+
+// isResignatureCandidate returns true for the ICLA and CCLA signatures signed against an older major version,
+// employee acknowledgements follow their company CCLA
+func isResignatureCandidate(sig *models.Signature, majorVersion int) bool {
+	if sig.ClaType != utils.ClaTypeICLA && sig.ClaType != utils.ClaTypeCCLA {
+		return false
+	}
+	signed, err := strconv.Atoi(sig.SignatureMajorVersion)
+	if err != nil {
+		return true
+	}
+	return signed < majorVersion
+}
+
+// signatureMajorVersion returns the document major version of the signature, 0 when unknown
+func signatureMajorVersion(sig *models.Signature) int {
+	version, err := strconv.Atoi(sig.SignatureMajorVersion)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// RequireResignature marks every ICLA and CCLA of the CLA Group signed against a major version older than the
+// specified one as requiring a re-signature before the deadline. Returns the number of signatures marked, the
+// signers are notified by the signatures table stream.
+func (s service) RequireResignature(ctx context.Context, claGroupModel *models.ClaGroup, majorVersion int, deadline time.Time) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "RequireResignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupModel.ProjectID,
+		"majorVersion":   majorVersion,
+		"deadline":       deadline,
+	}
+
+	result, err := s.repo.ProjectSignatures(ctx, claGroupModel.ProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group signatures")
+		return 0, err
+	}
+
+	deadlineStr := utils.TimeToString(deadline)
+	count := 0
+	for _, sig := range result.Signatures {
+		if !isResignatureCandidate(sig, majorVersion) {
+			continue
+		}
+		updateErr := s.repo.SetResignatureRequired(ctx, sig.SignatureID, strconv.Itoa(majorVersion), deadlineStr)
+		if updateErr != nil {
+			log.WithFields(f).WithError(updateErr).Warnf("unable to mark signature: %s as requiring re-signature", sig.SignatureID)
+			return count, updateErr
+		}
+		count++
+	}
+
+	log.WithFields(f).Debugf("marked %d signatures as requiring re-signature", count)
+	return count, nil
+}
+
+// ReplaceResignedCorporateSignatures replaces the CCLA signatures of the company flagged for a re-signature once the
+// company signed the new version of the CCLA: the approval lists and the CLA Managers of the flagged signatures carry
+// over to the new signature and the flagged signatures are no longer approved. Returns the number of signatures replaced.
+func (s service) ReplaceResignedCorporateSignatures(ctx context.Context, signatureID string) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "ReplaceResignedCorporateSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	resigned, err := s.repo.GetSignature(ctx, signatureID)
+	if err != nil || resigned == nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signed CCLA signature")
+		return 0, err
+	}
+	if resigned.ClaType != utils.ClaTypeCCLA || !resigned.SignatureSigned || !resigned.SignatureApproved {
+		return 0, nil
+	}
+	f["claGroupID"] = resigned.ProjectID
+	f["companyID"] = resigned.SignatureReferenceID
+
+	signed, approved := true, true
+	sortOrder := utils.SortOrderAscending
+	pageSize := int64(100)
+	companySignatures, err := s.repo.GetProjectCompanySignatures(ctx, resigned.SignatureReferenceID, resigned.ProjectID, &signed, &approved, nil, &sortOrder, &pageSize)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CCLA signatures of the company")
+		return 0, err
+	}
+
+	count := 0
+	for _, replaced := range resignedCorporateSignatures(resigned, companySignatures.Signatures) {
+		// the update is conditioned on the approval lists of the new signature, they are reloaded for each replacement
+		resigned, err = s.repo.GetSignature(ctx, signatureID)
+		if err != nil || resigned == nil {
+			log.WithFields(f).WithError(err).Warn("unable to reload the signed CCLA signature")
+			return count, err
+		}
+		replaceErr := s.repo.ReplaceResignedCorporateSignature(ctx, resigned, carriedOverApprovalLists(resigned, replaced), carriedOverACL(resigned, replaced), replaced)
+		if replaceErr != nil {
+			log.WithFields(f).WithError(replaceErr).Warnf("unable to replace the CCLA signature: %s flagged for a re-signature", replaced.SignatureID)
+			return count, replaceErr
+		}
+		count++
+	}
+
+	log.WithFields(f).Debugf("replaced %d CCLA signatures flagged for a re-signature", count)
+	return count, nil
+}
+
+// resignedCorporateSignatures returns the CCLA signatures of the company flagged for a re-signature which the
+// signature re-signs - they were signed against an older major version than the signature
+func resignedCorporateSignatures(resigned *models.Signature, companySignatures []*models.Signature) []*models.Signature {
+	var replaced []*models.Signature
+	for _, sig := range companySignatures {
+		if sig.SignatureID == resigned.SignatureID || sig.ClaType != utils.ClaTypeCCLA || !ResignatureRequired(sig) {
+			continue
+		}
+		if signatureMajorVersion(sig) >= signatureMajorVersion(resigned) {
+			continue
+		}
+		replaced = append(replaced, sig)
+	}
+	return replaced
+}
+
+// carriedOverApprovalLists returns the approval lists of the signature with the entries of the replaced signature added
+func carriedOverApprovalLists(resigned, replaced *models.Signature) *ApprovalLists {
+	lists := &ApprovalLists{
+		Emails:          updatedApprovalList(resigned.EmailApprovalList, replaced.EmailApprovalList, nil),
+		Domains:         updatedApprovalList(resigned.DomainApprovalList, replaced.DomainApprovalList, nil),
+		GitHubUsernames: updatedApprovalList(resigned.GithubUsernameApprovalList, replaced.GithubUsernameApprovalList, nil),
+		GitHubOrgs:      updatedApprovalList(resigned.GithubOrgApprovalList, replaced.GithubOrgApprovalList, nil),
+		GitHubTeams:     updatedApprovalList(resigned.GithubTeamApprovalList, replaced.GithubTeamApprovalList, nil),
+	}
+	// the metadata of the entries on the signature takes precedence over the metadata of the replaced signature
+	lists.Entries = mergeApprovalListEntries(replaced.ApprovalListEntries, resigned.ApprovalListEntries, lists)
+	return lists
+}
+
+// carriedOverACL returns the LF usernames of the CLA Managers of the signature and of the replaced signature
+func carriedOverACL(resigned, replaced *models.Signature) []string {
+	var acl []string
+	for _, manager := range append(append([]models.User{}, resigned.SignatureACL...), replaced.SignatureACL...) {
+		if manager.LfUsername != "" && !utils.StringInSlice(manager.LfUsername, acl) {
+			acl = append(acl, manager.LfUsername)
+		}
+	}
+	return acl
+}
+
+// SendResignatureEmail notifies the ICLA signer, or the CLA Managers of the CCLA, of the major version the
+// signature must be re-signed against before its deadline
+func (s service) SendResignatureEmail(ctx context.Context, claGroupModel *models.ClaGroup, sig *models.Signature) {
+	f := logrus.Fields{
+		"functionName":   "SendResignatureEmail",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupModel.ProjectID,
+		"signatureID":    sig.SignatureID,
+		"claType":        sig.ClaType,
+	}
+
+	majorVersion, err := strconv.Atoi(sig.ResignMajorVersion)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("the signature does not require a re-signature - skipping email")
+		return
+	}
+	deadline, err := utils.ParseDateTime(sig.ResignDeadline)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("the re-signature deadline is not valid - skipping email")
+		return
+	}
+
+	var recipients []models.User
+	params := emails.ResignatureRequiredTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			Project:     emails.CLAProjectParams{ExternalProjectName: claGroupModel.ProjectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName: sig.CompanyName,
+		},
+		MajorVersion: majorVersion,
+		CLAName:      "Individual",
+		Deadline:     deadline.UTC().Format("January 2, 2006 15:04 MST"),
+	}
+	recipientRole := utils.EmailRecipientRoleContributor
+	if sig.ClaType == utils.ClaTypeCCLA {
+		params.CLAName = "Corporate"
+		params.CorporateConsoleURL = utils.GetCorporateURL(claGroupModel.Version == utils.V2)
+		recipientRole = utils.EmailRecipientRoleCLAManager
+		recipients = sig.SignatureACL
+	} else {
+		userModel, err := s.usersService.GetUser(sig.SignatureReferenceID)
+		if err != nil || userModel == nil {
+			log.WithFields(f).WithError(err).Warn("unable to lookup the ICLA signer - skipping email")
+			return
+		}
+		recipients = []models.User{*userModel}
+	}
+
+	sent := 0
+	for i := range recipients {
+		email := getBestEmail(&recipients[i])
+		if email == "" {
+			continue
+		}
+		sent++
+
+		// each recipient reads the email in their preferred language
+		params.RecipientName = recipients[i].Username
+		params.RecipientLanguage = recipients[i].PreferredLanguage
+		subject := emails.RenderSubject(emails.ResignatureRequiredTemplateName,
+			fmt.Sprintf("EasyCLA: New CLA version for %s requires your signature", claGroupModel.ProjectName), params)
+		body, err := emails.RenderTemplate(claGroupModel.Version, emails.ResignatureRequiredTemplateName, emails.ResignatureRequiredTemplate, params)
+		if err != nil {
+			log.WithFields(f).Warnf("rendering email template : %s failed : %v", emails.ResignatureRequiredTemplateName, err)
+			return
+		}
+
+		err = utils.SendEmail(subject, body, []string{email}, utils.EmailMetadata{TemplateName: emails.ResignatureRequiredTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: recipientRole})
+		if err != nil {
+			log.WithFields(f).Warnf("problem sending email with subject: %s to recipient: %s, error: %+v", subject, email, err)
+		} else {
+			log.WithFields(f).Debugf("sent email with subject: %s to recipient: %s", subject, email)
+		}
+	}
+	if sent == 0 {
+		log.WithFields(f).Warn("no email address found for the signature - skipping email")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResignatureExpired(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name      string
+		signature models.Signature
+		required  bool
+		expired   bool
+	}{
+		{name: "no re-signature requested", signature: models.Signature{SignatureMajorVersion: "1"}},
+		{name: "within the grace period", signature: models.Signature{SignatureMajorVersion: "1", ResignMajorVersion: "2", ResignDeadline: "2021-03-02T00:00:00Z"}, required: true},
+		{name: "after the deadline", signature: models.Signature{SignatureMajorVersion: "1", ResignMajorVersion: "2", ResignDeadline: "2021-02-28T00:00:00Z"}, required: true, expired: true},
+		{name: "re-signed against the new version", signature: models.Signature{SignatureMajorVersion: "2", ResignMajorVersion: "2", ResignDeadline: "2021-02-28T00:00:00Z"}},
+		{name: "invalid deadline", signature: models.Signature{SignatureMajorVersion: "1", ResignMajorVersion: "2"}, required: true, expired: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.required, ResignatureRequired(&tc.signature))
+			assert.Equal(t, tc.expired, resignatureExpired(&tc.signature, now))
+		})
+	}
+}
+
+func TestIsResignatureCandidate(t *testing.T) {
+	assert.True(t, isResignatureCandidate(&models.Signature{ClaType: utils.ClaTypeICLA, SignatureMajorVersion: "1"}, 2))
+	assert.True(t, isResignatureCandidate(&models.Signature{ClaType: utils.ClaTypeCCLA, SignatureMajorVersion: "1"}, 2))
+	assert.False(t, isResignatureCandidate(&models.Signature{ClaType: utils.ClaTypeCCLA, SignatureMajorVersion: "2"}, 2))
+	assert.False(t, isResignatureCandidate(&models.Signature{ClaType: utils.ClaTypeECLA, SignatureMajorVersion: "1"}, 2))
+}
+
+func TestResignedCorporateSignatures(t *testing.T) {
+	resigned := &models.Signature{SignatureID: "new", ClaType: utils.ClaTypeCCLA, SignatureMajorVersion: "2"}
+	flagged := &models.Signature{SignatureID: "flagged", ClaType: utils.ClaTypeCCLA, SignatureMajorVersion: "1", ResignMajorVersion: "2"}
+	notFlagged := &models.Signature{SignatureID: "not-flagged", ClaType: utils.ClaTypeCCLA, SignatureMajorVersion: "1"}
+	newer := &models.Signature{SignatureID: "newer", ClaType: utils.ClaTypeCCLA, SignatureMajorVersion: "2", ResignMajorVersion: "3"}
+
+	replaced := resignedCorporateSignatures(resigned, []*models.Signature{resigned, flagged, notFlagged, newer})
+	assert.Equal(t, []*models.Signature{flagged}, replaced)
+}
+
+func TestCarriedOverApprovalLists(t *testing.T) {
+	resigned := &models.Signature{
+		EmailApprovalList:  []string{"new@example.org"},
+		DomainApprovalList: []string{"example.org"},
+		ApprovalListEntries: []*models.ApprovalListEntry{
+			{Type: ApprovalListTypeDomain, Value: "example.org", AddedBy: "manager", ExpiresOn: "2021-06-01T00:00:00Z"},
+		},
+		SignatureACL: []models.User{{LfUsername: "manager"}},
+	}
+	replaced := &models.Signature{
+		EmailApprovalList:          []string{"old@example.org", "new@example.org"},
+		DomainApprovalList:         []string{"example.org"},
+		GithubUsernameApprovalList: []string{"octocat"},
+		ApprovalListEntries: []*models.ApprovalListEntry{
+			{Type: ApprovalListTypeDomain, Value: "example.org", AddedBy: "previous-manager", ExpiresOn: "2021-05-01T00:00:00Z"},
+			{Type: ApprovalListTypeEmail, Value: "old@example.org", AddedBy: "previous-manager", ExpiresOn: "2021-07-01T00:00:00Z"},
+		},
+		SignatureACL: []models.User{{LfUsername: "previous-manager"}, {LfUsername: "manager"}},
+	}
+
+	lists := carriedOverApprovalLists(resigned, replaced)
+	assert.Equal(t, []string{"new@example.org", "old@example.org"}, lists.Emails)
+	assert.Equal(t, []string{"example.org"}, lists.Domains)
+	assert.Equal(t, []string{"octocat"}, lists.GitHubUsernames)
+	assert.Empty(t, lists.GitHubOrgs)
+	if assert.Len(t, lists.Entries, 2) {
+		assert.Equal(t, "example.org", lists.Entries[0].Value)
+		assert.Equal(t, "2021-06-01T00:00:00Z", lists.Entries[0].ExpiresOn)
+		assert.Equal(t, "old@example.org", lists.Entries[1].Value)
+		assert.Equal(t, "2021-07-01T00:00:00Z", lists.Entries[1].ExpiresOn)
+	}
+
+	assert.Equal(t, []string{"manager", "previous-manager"}, carriedOverACL(resigned, replaced))
+}
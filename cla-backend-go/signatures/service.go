@@ -9,12 +9,15 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/communitybridge/easycla/cla-backend-go/emails"
 	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
 
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 
@@ -45,11 +48,23 @@ type SignatureService interface {
 	GetCompanyIDsWithSignedCorporateSignatures(ctx context.Context, claGroupID string) ([]SignatureCompanyID, error)
 	GetUserSignatures(ctx context.Context, params signatures.GetUserSignaturesParams) (*models.Signatures, error)
 	InvalidateProjectRecords(ctx context.Context, projectID string, projectName string) (int, error)
+	RequireResignature(ctx context.Context, claGroupModel *models.ClaGroup, majorVersion int, deadline time.Time) (int, error)
+	SendResignatureEmail(ctx context.Context, claGroupModel *models.ClaGroup, sig *models.Signature)
+	ReplaceResignedCorporateSignatures(ctx context.Context, signatureID string) (int, error)
+	RevokeEmployeeSignature(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, userID, reason, effectiveDate string, removeApprovalListEntries bool) (*models.Signature, error)
 
 	GetGithubOrganizationsFromWhitelist(ctx context.Context, signatureID string, githubAccessToken string) ([]models.GithubOrg, error)
-	AddGithubOrganizationToWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
-	DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
+	AddGithubOrganizationToWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken, modifiedBy string) ([]models.GithubOrg, error)
+	DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken, modifiedBy string) ([]models.GithubOrg, error)
+	GetGithubTeamsFromApprovalList(ctx context.Context, signatureID string, githubAccessToken string) ([]models.GithubTeam, error)
+	AddGithubTeamToApprovalList(ctx context.Context, signatureID string, approvalListParams models.GhTeamApprovalList, githubAccessToken, modifiedBy string) ([]models.GithubTeam, error)
+	DeleteGithubTeamFromApprovalList(ctx context.Context, signatureID string, approvalListParams models.GhTeamApprovalList, githubAccessToken, modifiedBy string) ([]models.GithubTeam, error)
 	UpdateApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error)
+	UpdateApprovalListOnBehalfOf(ctx context.Context, updatedBy string, claGroupModel *models.ClaGroup, companyModel *models.Company, params *models.ApprovalList) (*models.Signature, error)
+	ImportApprovalListCSV(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, data []byte) (*models.Signature, *models.ApprovalList, error)
+	SimulateApprovalListUpdate(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList, loadPullRequestAuthors PullRequestAuthorsLoader) (*models.ApprovalListSimulation, error)
+	GetApprovalListCSV(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string) ([]byte, error)
+	GetApprovalListHistory(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, at time.Time) (*models.ApprovalListHistory, error)
 
 	AddCLAManager(ctx context.Context, signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(ctx context.Context, ignatureID, claManagerID string) (*models.Signature, error)
@@ -57,6 +72,10 @@ type SignatureService interface {
 	GetClaGroupICLASignatures(ctx context.Context, claGroupID string, searchTerm *string) (*models.IclaSignatures, error)
 	GetClaGroupCCLASignatures(ctx context.Context, claGroupID string) (*models.Signatures, error)
 	GetClaGroupCorporateContributors(ctx context.Context, claGroupID string, companyID *string, searchTerm *string) (*models.CorporateContributorList, error)
+
+	UserIsApproved(ctx context.Context, user *models.User, cclaSignature *models.Signature) (bool, error)
+	HasUserSigned(ctx context.Context, user *models.User, claGroupID string) (bool, bool, error)
+	GetContributorCoverage(ctx context.Context, user *models.User, claGroupModel *models.ClaGroup) (*ContributorCoverage, error)
 }
 
 type service struct {
@@ -251,7 +270,7 @@ func (s service) GetGithubOrganizationsFromWhitelist(ctx context.Context, signat
 }
 
 // AddGithubOrganizationToWhitelist adds the GH organization to the whitelist
-func (s service) AddGithubOrganizationToWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error) {
+func (s service) AddGithubOrganizationToWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken, modifiedBy string) ([]models.GithubOrg, error) {
 	organizationID := whiteListParams.OrganizationID
 
 	if signatureID == "" {
@@ -310,7 +329,7 @@ func (s service) AddGithubOrganizationToWhitelist(ctx context.Context, signature
 		}
 	}
 
-	gitHubWhiteList, err := s.repo.AddGithubOrganizationToWhitelist(ctx, signatureID, *organizationID)
+	gitHubWhiteList, err := s.repo.AddGithubOrganizationToWhitelist(ctx, signatureID, *organizationID, modifiedBy)
 	if err != nil {
 		log.Warnf("issue adding github organization to white list using signatureID: %s, gh org id: %s, error: %v",
 			signatureID, *organizationID, err)
@@ -321,7 +340,7 @@ func (s service) AddGithubOrganizationToWhitelist(ctx context.Context, signature
 }
 
 // DeleteGithubOrganizationFromWhitelist deletes the specified GH organization from the whitelist
-func (s service) DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error) {
+func (s service) DeleteGithubOrganizationFromWhitelist(ctx context.Context, signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken, modifiedBy string) ([]models.GithubOrg, error) {
 
 	// Extract the payload values
 	organizationID := whiteListParams.OrganizationID
@@ -382,7 +401,7 @@ func (s service) DeleteGithubOrganizationFromWhitelist(ctx context.Context, sign
 		}
 	}
 
-	gitHubWhiteList, err := s.repo.DeleteGithubOrganizationFromWhitelist(ctx, signatureID, *organizationID)
+	gitHubWhiteList, err := s.repo.DeleteGithubOrganizationFromWhitelist(ctx, signatureID, *organizationID, modifiedBy)
 	if err != nil {
 		return nil, err
 	}
@@ -390,8 +409,175 @@ func (s service) DeleteGithubOrganizationFromWhitelist(ctx context.Context, sign
 	return gitHubWhiteList, nil
 }
 
-// UpdateApprovalList service method
-func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error) {
+// GetGithubTeamsFromApprovalList retrieves the teams from the approval list, when authenticated with GitHub the
+// teams of the user are appended as the unselected entries
+func (s service) GetGithubTeamsFromApprovalList(ctx context.Context, signatureID string, githubAccessToken string) ([]models.GithubTeam, error) {
+	f := logrus.Fields{
+		"functionName":   "GetGithubTeamsFromApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	if signatureID == "" {
+		msg := "unable to get GitHub team approval list - signature ID is nil"
+		log.WithFields(f).Warn(msg)
+		return nil, errors.New(msg)
+	}
+
+	teamIDs, err := s.repo.GetGithubTeamsFromApprovalList(ctx, signatureID)
+	if err != nil {
+		log.WithFields(f).Warnf("error loading github teams from approval list, error: %v", err)
+		return nil, err
+	}
+
+	if githubAccessToken != "" {
+		log.WithFields(f).Debugf("already authenticated with github - scanning for user's teams...")
+
+		selectedTeams := make(map[string]struct{}, len(teamIDs))
+		for _, selectedTeam := range teamIDs {
+			selectedTeams[strings.ToLower(*selectedTeam.ID)] = struct{}{}
+		}
+
+		// Since we're logged into github, lets get the list of teams we can add.
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: githubAccessToken},
+		)
+		tc := oauth2.NewClient(utils.NewContext(), ts)
+		client := githubpkg.NewClient(tc)
+
+		opt := &githubpkg.ListOptions{
+			PerPage: 100,
+		}
+
+		for {
+			teams, resp, listErr := client.Teams.ListUserTeams(utils.NewContext(), opt)
+			if listErr != nil {
+				log.WithFields(f).WithError(listErr).Warn("unable to list the GitHub teams of the user")
+				return nil, listErr
+			}
+
+			for _, team := range teams {
+				teamID := fmt.Sprintf("%s/%s", team.GetOrganization().GetLogin(), team.GetSlug())
+				if _, ok := selectedTeams[strings.ToLower(teamID)]; ok {
+					continue
+				}
+
+				teamIDs = append(teamIDs, models.GithubTeam{ID: aws.String(teamID)})
+			}
+
+			if resp.NextPage == 0 {
+				break
+			}
+			opt.Page = resp.NextPage
+		}
+	}
+
+	return teamIDs, nil
+}
+
+// AddGithubTeamToApprovalList adds the GH team to the approval list
+func (s service) AddGithubTeamToApprovalList(ctx context.Context, signatureID string, approvalListParams models.GhTeamApprovalList, githubAccessToken, modifiedBy string) ([]models.GithubTeam, error) {
+	f := logrus.Fields{
+		"functionName":   "AddGithubTeamToApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	teamID, err := s.validateGithubTeam(ctx, signatureID, approvalListParams.TeamID, githubAccessToken)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to add GitHub team to the approval list, error: %v", err)
+		return nil, err
+	}
+
+	gitHubApprovalList, err := s.repo.AddGithubTeamToApprovalList(ctx, signatureID, teamID, modifiedBy)
+	if err != nil {
+		log.WithFields(f).Warnf("issue adding github team: %s to the approval list, error: %v", teamID, err)
+		return nil, err
+	}
+
+	return gitHubApprovalList, nil
+}
+
+// DeleteGithubTeamFromApprovalList deletes the specified GH team from the approval list
+func (s service) DeleteGithubTeamFromApprovalList(ctx context.Context, signatureID string, approvalListParams models.GhTeamApprovalList, githubAccessToken, modifiedBy string) ([]models.GithubTeam, error) {
+	f := logrus.Fields{
+		"functionName":   "DeleteGithubTeamFromApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	teamID, err := s.validateGithubTeam(ctx, signatureID, approvalListParams.TeamID, githubAccessToken)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to delete GitHub team from the approval list, error: %v", err)
+		return nil, err
+	}
+
+	gitHubApprovalList, err := s.repo.DeleteGithubTeamFromApprovalList(ctx, signatureID, teamID, modifiedBy)
+	if err != nil {
+		log.WithFields(f).Warnf("issue deleting github team: %s from the approval list, error: %v", teamID, err)
+		return nil, err
+	}
+
+	return gitHubApprovalList, nil
+}
+
+// validateGithubTeam checks the organization/team-slug format of the team and, when GH_ORG_VALIDATION is enabled,
+// that the authenticated GitHub user is a member of the team organization - returns the trimmed team ID
+func (s service) validateGithubTeam(ctx context.Context, signatureID string, teamID *string, githubAccessToken string) (string, error) {
+	if signatureID == "" {
+		return "", errors.New("signature ID is nil")
+	}
+
+	if teamID == nil {
+		return "", errors.New("team ID is nil")
+	}
+
+	organizationName, _, err := github.ParseTeamID(*teamID)
+	if err != nil {
+		return "", err
+	}
+
+	if s.githubOrgValidation {
+		// Verify the authenticated github user has access to the github organization of the team.
+		if githubAccessToken == "" {
+			return "", fmt.Errorf("not logged in using signatureID: %s, github team id: %s", signatureID, *teamID)
+		}
+
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: githubAccessToken},
+		)
+		tc := oauth2.NewClient(ctx, ts)
+		client := githubpkg.NewClient(tc)
+
+		opt := &githubpkg.ListOptions{
+			PerPage: 100,
+		}
+
+		log.Debugf("querying for user's github organizations...")
+		orgs, _, err := client.Organizations.List(ctx, "", opt)
+		if err != nil {
+			return "", err
+		}
+
+		found := false
+		for _, org := range orgs {
+			if strings.EqualFold(org.GetLogin(), organizationName) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return "", fmt.Errorf("user is not authorized for github organization id: %s", organizationName)
+		}
+	}
+
+	return strings.TrimSpace(*teamID), nil
+}
+
+// getCLAManagerSignature returns the CCLA signature of the company for the CLA Group and the user record of the
+// current user, returns a ForbiddenError if the current user is not a CLA Manager of the signature
+func (s service) getCLAManagerSignature(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string) (*models.Signature, *models.User, error) {
 	pageSize := int64(1)
 	signed, approved := true, true
 	sigModel, sigErr := s.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
@@ -399,38 +585,92 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 		msg := fmt.Sprintf("unable to locate project company signature by Company ID: %s, Project ID: %s, CLA Group ID: %s, error: %+v",
 			companyModel.CompanyID, claGroupModel.ProjectID, claGroupID, sigErr)
 		log.Warn(msg)
-		return nil, NewBadRequestError(msg)
+		return nil, nil, NewBadRequestError(msg)
 	}
 	if sigModel == nil {
 		msg := fmt.Sprintf("unable to locate signature for company ID: %s CLA Group ID: %s, type: ccla, signed: %t, approved: %t",
 			companyModel.CompanyID, claGroupID, signed, approved)
 		log.Warn(msg)
-		return nil, NewBadRequestError(msg)
+		return nil, nil, NewBadRequestError(msg)
 	}
 
 	// Ensure current user is in the Signature ACL
-	claManagers := sigModel.SignatureACL
-	if !utils.CurrentUserInACL(authUser, claManagers) {
+	if !utils.CurrentUserInACL(authUser, sigModel.SignatureACL) {
 		msg := fmt.Sprintf("EasyCLA - 403 Forbidden - CLA Manager %s / %s is not authorized to approve request for company ID: %s / %s / %s, project ID: %s / %s / %s",
 			authUser.UserName, authUser.Email,
 			companyModel.CompanyName, companyModel.CompanyExternalID, companyModel.CompanyID,
 			claGroupModel.ProjectName, claGroupModel.ProjectExternalID, claGroupModel.ProjectID)
-		return nil, NewForbiddenError(msg)
+		return nil, nil, NewForbiddenError(msg)
 	}
 
 	// Lookup the user making the request
 	userModel, userErr := s.usersService.GetUserByUserName(authUser.UserName, true)
 	if userErr != nil {
-		return nil, userErr
+		return nil, nil, userErr
+	}
+
+	return sigModel, userModel, nil
+}
+
+// UpdateApprovalList service method
+func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error) {
+	sigModel, userModel, err := s.getCLAManagerSignature(ctx, authUser, claGroupModel, companyModel, claGroupID)
+	if err != nil {
+		return nil, err
 	}
+	return s.updateApprovalList(ctx, sigModel, userModel, authUser.UserName, claGroupModel, companyModel, params)
+}
 
-	updatedSig, err := s.repo.UpdateApprovalList(ctx, claGroupModel.ProjectID, companyModel.CompanyID, params)
+// UpdateApprovalListOnBehalfOf updates the approval list of the CCLA signature of the company on behalf of an
+// automated process such as the company directory sync (SCIM) or the approval request rules - the caller is
+// responsible for authorizing the update
+func (s service) UpdateApprovalListOnBehalfOf(ctx context.Context, updatedBy string, claGroupModel *models.ClaGroup, companyModel *models.Company, params *models.ApprovalList) (*models.Signature, error) {
+	pageSize := int64(1)
+	signed, approved := true, true
+	sigModel, err := s.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupModel.ProjectID, &signed, &approved, nil, &pageSize)
 	if err != nil {
-		return updatedSig, err
+		return nil, err
+	}
+	if sigModel == nil {
+		return nil, NewBadRequestError(fmt.Sprintf("unable to locate signature for company ID: %s CLA Group ID: %s, type: ccla, signed: %t, approved: %t",
+			companyModel.CompanyID, claGroupModel.ProjectID, signed, approved))
+	}
+
+	userModel := &models.User{
+		LfUsername: updatedBy,
+		Username:   updatedBy,
+	}
+	return s.updateApprovalList(ctx, sigModel, userModel, updatedBy, claGroupModel, companyModel, params)
+}
+
+// updateApprovalList applies the approval list update to the CCLA signature on behalf of the specified user, logs
+// the events and notifies the CLA Managers and the contributors
+func (s service) updateApprovalList(ctx context.Context, sigModel *models.Signature, userModel *models.User, updatedBy string, claGroupModel *models.ClaGroup, companyModel *models.Company, params *models.ApprovalList) (*models.Signature, error) {
+	claManagers := sigModel.SignatureACL
+
+	expiresOn, err := ParseApprovalListExpiry(params.ExpiresOn, time.Now())
+	if err != nil {
+		return nil, NewBadRequestError(err.Error())
+	}
+
+	// Wildcard and regular expression entries must be well-formed and not approve whole public suffixes
+	if validationErr := ValidateApprovalList(params); validationErr != nil {
+		return nil, NewBadRequestError(validationErr.Error())
+	}
+
+	// The approval lists and the metadata of their entries, who added them and when they expire, are written in a
+	// single update conditioned on the loaded approval lists, the metadata of the removed entries is dropped
+	_, now := utils.CurrentTime()
+	lists := updatedApprovalLists(sigModel, params)
+	lists.Entries = mergeApprovalListEntries(sigModel.ApprovalListEntries, addedApprovalListEntries(params, userModel.LfUsername, now, expiresOn), lists)
+	lists.ModifiedBy = userModel.LfUsername
+	updatedSig, err := s.repo.ReplaceApprovalLists(ctx, sigModel, lists)
+	if err != nil {
+		return nil, err
 	}
 
 	// Log Events
-	s.createEventLogEntries(companyModel, claGroupModel, userModel, params)
+	s.createEventLogEntries(sigModel, companyModel, claGroupModel, userModel, params)
 
 	// Send an email to the CLA Managers
 	for _, claManager := range claManagers {
@@ -439,11 +679,158 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 	}
 
 	// Send emails to contributors if email or GH username as added/removed
-	s.sendRequestAccessEmailToContributors(authUser, companyModel, claGroupModel, params)
+	s.sendRequestAccessEmailToContributors(updatedBy, companyModel, claGroupModel, params)
 
 	return updatedSig, nil
 }
 
+// ImportApprovalListCSV applies the approval list CSV document to the CCLA signature of the company. The document
+// is validated and diffed against the current approval lists, then the approval lists are replaced in a single
+// update and one event is logged for the import. Returns the updated signature and the effective changes.
+func (s service) ImportApprovalListCSV(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, data []byte) (*models.Signature, *models.ApprovalList, error) {
+	f := logrus.Fields{
+		"functionName":   "ImportApprovalListCSV",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companyID":      companyModel.CompanyID,
+	}
+
+	entries, err := parseApprovalListCSV(data, time.Now())
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid approval list CSV")
+		return nil, nil, err
+	}
+
+	sigModel, userModel, err := s.getCLAManagerSignature(ctx, authUser, claGroupModel, companyModel, claGroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, now := utils.CurrentTime()
+	changes, lists := applyApprovalListEntries(sigModel, entries)
+	lists.Entries = mergeApprovalListEntries(sigModel.ApprovalListEntries, csvApprovalListEntries(sigModel, entries, userModel.LfUsername, now), lists)
+	lists.ModifiedBy = userModel.LfUsername
+	if !hasApprovalListChanges(changes) && approvalListEntriesEqual(sigModel.ApprovalListEntries, lists.Entries) {
+		log.WithFields(f).Debug("approval list CSV has no changes for the signature")
+		return sigModel, changes, nil
+	}
+
+	updatedSig, err := s.repo.ReplaceApprovalLists(ctx, sigModel, lists)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.WithFields(f).Debugf("imported %d approval list entries into signature: %s", len(entries), sigModel.SignatureID)
+
+	added, removed := approvalListChangeEntries(changes)
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:         events.ClaApprovalListUpdated,
+		ProjectID:         claGroupModel.ProjectID,
+		ClaGroupModel:     claGroupModel,
+		CompanyID:         companyModel.CompanyID,
+		CompanyModel:      companyModel,
+		LfUsername:        userModel.LfUsername,
+		UserID:            userModel.UserID,
+		UserModel:         userModel,
+		ExternalProjectID: claGroupModel.ProjectExternalID,
+		SignatureID:       sigModel.SignatureID,
+		EventData: &events.CLAApprovalListImportData{
+			UserName:       userModel.LfUsername,
+			UserEmail:      userModel.LfEmail,
+			UserLFID:       userModel.UserID,
+			AddedEntries:   added,
+			RemovedEntries: removed,
+		},
+	})
+
+	// Only the CLA Managers are notified of a bulk import, the contributors are not emailed one by one
+	for _, claManager := range sigModel.SignatureACL {
+		claManagerEmail := getBestEmail(&claManager) // nolint
+		s.sendApprovalListUpdateEmailToCLAManagers(companyModel, claGroupModel, claManager.Username, claManagerEmail, changes)
+	}
+
+	return updatedSig, changes, nil
+}
+
+// SimulateApprovalListUpdate evaluates the approval list update without persisting it. Returns the corporate
+// contributors of the CCLA and the pull request authors who would gain or lose the approval list coverage. The pull
+// request authors are only loaded once the user is known to be a CLA Manager of the CCLA.
+func (s service) SimulateApprovalListUpdate(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, params *models.ApprovalList, loadPullRequestAuthors PullRequestAuthorsLoader) (*models.ApprovalListSimulation, error) {
+	f := logrus.Fields{
+		"functionName":   "SimulateApprovalListUpdate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companyID":      companyModel.CompanyID,
+	}
+
+	sigModel, _, err := s.getCLAManagerSignature(ctx, authUser, claGroupModel, companyModel, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	contributors, err := s.GetClaGroupCorporateContributors(ctx, claGroupID, &companyModel.CompanyID, nil)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the corporate contributors")
+		return nil, err
+	}
+
+	// The contributors whose employee acknowledgement was revoked are not covered anymore
+	var activeContributors []*models.CorporateContributor
+	for _, contributor := range contributors.List {
+		if contributor.Active {
+			activeContributors = append(activeContributors, contributor)
+		}
+	}
+
+	// The pull request authors are best effort - the simulation still covers the corporate contributors
+	var pullRequestAuthors []*models.User
+	if loadPullRequestAuthors != nil {
+		pullRequestAuthors, err = loadPullRequestAuthors(ctx, claGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the open pull request authors")
+		}
+	}
+
+	candidates := buildSimulationCandidates(activeContributors, pullRequestAuthors)
+	log.WithFields(f).Debugf("evaluating the approval list coverage of %d contributors", len(candidates))
+	evaluator := newCoverageEvaluator(github.GetUserOrganizations, github.IsUserTeamMember)
+	return simulateApprovalListCoverage(ctx, signatureApprovalLists(sigModel), proposedApprovalLists(sigModel, params), candidates, evaluator), nil
+}
+
+// GetApprovalListCSV returns the approval lists of the CCLA signature of the company as a CSV document
+func (s service) GetApprovalListCSV(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string) ([]byte, error) {
+	sigModel, _, err := s.getCLAManagerSignature(ctx, authUser, claGroupModel, companyModel, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return approvalListCSV(sigModel)
+}
+
+// GetApprovalListHistory returns the approval lists of the CCLA signature of the company at the specified time and
+// the approval list changes recorded up to that time
+func (s service) GetApprovalListHistory(ctx context.Context, authUser *auth.User, claGroupModel *models.ClaGroup, companyModel *models.Company, claGroupID string, at time.Time) (*models.ApprovalListHistory, error) {
+	f := logrus.Fields{
+		"functionName":   "GetApprovalListHistory",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companyID":      companyModel.CompanyID,
+		"at":             utils.TimeToString(at),
+	}
+
+	sigModel, _, err := s.getCLAManagerSignature(ctx, authUser, claGroupModel, companyModel, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.eventsService.GetSignatureApprovalListEvents(sigModel.SignatureID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load the approval list change records of signature: %s", sigModel.SignatureID)
+		return nil, err
+	}
+	log.WithFields(f).Debugf("loaded %d approval list change records for signature: %s", len(records), sigModel.SignatureID)
+
+	return buildApprovalListHistory(sigModel, records, at), nil
+}
+
 // Disassociate project signatures
 func (s service) InvalidateProjectRecords(ctx context.Context, projectID string, projectName string) (int, error) {
 	f := logrus.Fields{
@@ -515,10 +902,15 @@ func buildApprovalListSummary(approvalListChanges *models.ApprovalList) string {
 	approvalListSummary += appendList(approvalListChanges.RemoveGithubUsernameApprovalList, "Removed GitHub User:")
 	approvalListSummary += appendList(approvalListChanges.AddGithubOrgApprovalList, "Added GithHub Organization:")
 	approvalListSummary += appendList(approvalListChanges.RemoveGithubOrgApprovalList, "Removed GitHub Organization:")
+	approvalListSummary += appendList(approvalListChanges.AddGithubTeamApprovalList, "Added GitHub Team:")
+	approvalListSummary += appendList(approvalListChanges.RemoveGithubTeamApprovalList, "Removed GitHub Team:")
 	approvalListSummary += "</ul>"
 	return approvalListSummary
 }
 
+// ApprovalListUpdateToCLAManagersTemplateName is the name of the email notifying the CLA Managers of an approval list update
+const ApprovalListUpdateToCLAManagersTemplateName = "ApprovalListUpdateToCLAManagersTemplate"
+
 // sendRequestAccessEmailToCLAManagers sends the request access email to the specified CLA Managers
 func (s service) sendApprovalListUpdateEmailToCLAManagers(companyModel *models.Company, claGroupModel *models.ClaGroup, recipientName, recipientAddress string, approvalListChanges *models.ApprovalList) {
 	f := logrus.Fields{
@@ -548,7 +940,7 @@ func (s service) sendApprovalListUpdateEmailToCLAManagers(companyModel *models.C
 		recipientName, projectName, companyName, projectName, buildApprovalListSummary(approvalListChanges),
 		utils.GetEmailHelpContent(claGroupModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: ApprovalListUpdateToCLAManagersTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.WithFields(f).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -615,33 +1007,38 @@ func (s service) getRemoveGitHubContributors(approvalList *models.ApprovalList)
 
 	return userModelList
 }
-func (s service) sendRequestAccessEmailToContributors(authUser *auth.User, companyModel *models.Company, claGroupModel *models.ClaGroup, approvalList *models.ApprovalList) {
+func (s service) sendRequestAccessEmailToContributors(updatedBy string, companyModel *models.Company, claGroupModel *models.ClaGroup, approvalList *models.ApprovalList) {
 	addEmailUsers := s.getAddEmailContributors(approvalList)
 	for _, user := range addEmailUsers {
-		sendRequestAccessEmailToContributorRecipient(authUser, companyModel, claGroupModel, user.Username, user.LfEmail, "added", "to",
+		sendRequestAccessEmailToContributorRecipient(updatedBy, companyModel, claGroupModel, user.Username, user.LfEmail, user.PreferredLanguage, "added", "to",
 			fmt.Sprintf("you are authorized to contribute to %s on behalf of %s", claGroupModel.ProjectName, companyModel.CompanyName))
 	}
 	removeEmailUsers := s.getRemoveEmailContributors(approvalList)
 	for _, user := range removeEmailUsers {
-		sendRequestAccessEmailToContributorRecipient(authUser, companyModel, claGroupModel, user.Username, user.LfEmail, "removed", "from",
+		sendRequestAccessEmailToContributorRecipient(updatedBy, companyModel, claGroupModel, user.Username, user.LfEmail, user.PreferredLanguage, "removed", "from",
 			fmt.Sprintf("you are no longer authorized to contribute to %s on behalf of %s ", claGroupModel.ProjectName, companyModel.CompanyName))
 	}
 	addGitHubUsers := s.getAddGitHubContributors(approvalList)
 	for _, user := range addGitHubUsers {
-		sendRequestAccessEmailToContributorRecipient(authUser, companyModel, claGroupModel, user.Username, user.LfEmail, "added", "to",
+		sendRequestAccessEmailToContributorRecipient(updatedBy, companyModel, claGroupModel, user.Username, user.LfEmail, user.PreferredLanguage, "added", "to",
 			fmt.Sprintf("you are authorized to contribute to %s on behalf of %s", claGroupModel.ProjectName, companyModel.CompanyName))
 	}
 	removeGitHubUsers := s.getRemoveGitHubContributors(approvalList)
 	for _, user := range removeGitHubUsers {
-		sendRequestAccessEmailToContributorRecipient(authUser, companyModel, claGroupModel, user.Username, user.LfEmail, "removed", "from",
+		sendRequestAccessEmailToContributorRecipient(updatedBy, companyModel, claGroupModel, user.Username, user.LfEmail, user.PreferredLanguage, "removed", "from",
 			fmt.Sprintf("you are no longer authorized to contribute to %s on behalf of %s ", claGroupModel.ProjectName, companyModel.CompanyName))
 	}
 }
 
-func (s service) createEventLogEntries(companyModel *models.Company, claGroupModel *models.ClaGroup, userModel *models.User, approvalList *models.ApprovalList) {
+func (s service) createEventLogEntries(sigModel *models.Signature, companyModel *models.Company, claGroupModel *models.ClaGroup, userModel *models.User, approvalList *models.ApprovalList) {
+	logEvent := func(args *events.LogEventArgs) {
+		args.SignatureID = sigModel.SignatureID
+		s.eventsService.LogEvent(args)
+	}
+
 	for _, value := range approvalList.AddEmailApprovalList {
 		// Send an event
-		s.eventsService.LogEvent(&events.LogEventArgs{
+		logEvent(&events.LogEventArgs{
 			EventType:         events.ClaApprovalListUpdated,
 			ProjectID:         claGroupModel.ProjectID,
 			ClaGroupModel:     claGroupModel,
@@ -661,7 +1058,7 @@ func (s service) createEventLogEntries(companyModel *models.Company, claGroupMod
 	}
 	for _, value := range approvalList.RemoveEmailApprovalList {
 		// Send an event
-		s.eventsService.LogEvent(&events.LogEventArgs{
+		logEvent(&events.LogEventArgs{
 			EventType:         events.ClaApprovalListUpdated,
 			ProjectID:         claGroupModel.ProjectID,
 			ClaGroupModel:     claGroupModel,
@@ -681,7 +1078,7 @@ func (s service) createEventLogEntries(companyModel *models.Company, claGroupMod
 	}
 	for _, value := range approvalList.AddDomainApprovalList {
 		// Send an event
-		s.eventsService.LogEvent(&events.LogEventArgs{
+		logEvent(&events.LogEventArgs{
 			EventType:         events.ClaApprovalListUpdated,
 			ProjectID:         claGroupModel.ProjectID,
 			ClaGroupModel:     claGroupModel,
@@ -701,7 +1098,7 @@ func (s service) createEventLogEntries(companyModel *models.Company, claGroupMod
 	}
 	for _, value := range approvalList.RemoveDomainApprovalList {
 		// Send an event
-		s.eventsService.LogEvent(&events.LogEventArgs{
+		logEvent(&events.LogEventArgs{
 			EventType:         events.ClaApprovalListUpdated,
 			ProjectID:         claGroupModel.ProjectID,
 			ClaGroupModel:     claGroupModel,
@@ -721,7 +1118,7 @@ func (s service) createEventLogEntries(companyModel *models.Company, claGroupMod
 	}
 	for _, value := range approvalList.AddGithubUsernameApprovalList {
 		// Send an event
-		s.eventsService.LogEvent(&events.LogEventArgs{
+		logEvent(&events.LogEventArgs{
 			EventType:         events.ClaApprovalListUpdated,
 			ProjectID:         claGroupModel.ProjectID,
 			ClaGroupModel:     claGroupModel,
@@ -741,7 +1138,7 @@ func (s service) createEventLogEntries(companyModel *models.Company, claGroupMod
 	}
 	for _, value := range approvalList.RemoveGithubUsernameApprovalList {
 		// Send an event
-		s.eventsService.LogEvent(&events.LogEventArgs{
+		logEvent(&events.LogEventArgs{
 			EventType:         events.ClaApprovalListUpdated,
 			ProjectID:         claGroupModel.ProjectID,
 			ClaGroupModel:     claGroupModel,
@@ -761,7 +1158,7 @@ func (s service) createEventLogEntries(companyModel *models.Company, claGroupMod
 	}
 	for _, value := range approvalList.AddGithubOrgApprovalList {
 		// Send an event
-		s.eventsService.LogEvent(&events.LogEventArgs{
+		logEvent(&events.LogEventArgs{
 			EventType:         events.ClaApprovalListUpdated,
 			ProjectID:         claGroupModel.ProjectID,
 			ClaGroupModel:     claGroupModel,
@@ -781,7 +1178,7 @@ func (s service) createEventLogEntries(companyModel *models.Company, claGroupMod
 	}
 	for _, value := range approvalList.RemoveGithubOrgApprovalList {
 		// Send an event
-		s.eventsService.LogEvent(&events.LogEventArgs{
+		logEvent(&events.LogEventArgs{
 			EventType:         events.ClaApprovalListUpdated,
 			ProjectID:         claGroupModel.ProjectID,
 			ClaGroupModel:     claGroupModel,
@@ -799,6 +1196,46 @@ func (s service) createEventLogEntries(companyModel *models.Company, claGroupMod
 			},
 		})
 	}
+	for _, value := range approvalList.AddGithubTeamApprovalList {
+		// Send an event
+		logEvent(&events.LogEventArgs{
+			EventType:         events.ClaApprovalListUpdated,
+			ProjectID:         claGroupModel.ProjectID,
+			ClaGroupModel:     claGroupModel,
+			CompanyID:         companyModel.CompanyID,
+			CompanyModel:      companyModel,
+			LfUsername:        userModel.LfUsername,
+			UserID:            userModel.UserID,
+			UserModel:         userModel,
+			ExternalProjectID: claGroupModel.ProjectExternalID,
+			EventData: &events.CLAApprovalListAddGitHubTeamData{
+				UserName:               userModel.LfUsername,
+				UserEmail:              userModel.LfEmail,
+				UserLFID:               userModel.UserID,
+				ApprovalListGitHubTeam: value,
+			},
+		})
+	}
+	for _, value := range approvalList.RemoveGithubTeamApprovalList {
+		// Send an event
+		logEvent(&events.LogEventArgs{
+			EventType:         events.ClaApprovalListUpdated,
+			ProjectID:         claGroupModel.ProjectID,
+			ClaGroupModel:     claGroupModel,
+			CompanyID:         companyModel.CompanyID,
+			CompanyModel:      companyModel,
+			LfUsername:        userModel.LfUsername,
+			UserID:            userModel.UserID,
+			UserModel:         userModel,
+			ExternalProjectID: claGroupModel.ProjectExternalID,
+			EventData: &events.CLAApprovalListRemoveGitHubTeamData{
+				UserName:               userModel.LfUsername,
+				UserEmail:              userModel.LfEmail,
+				UserLFID:               userModel.UserID,
+				ApprovalListGitHubTeam: value,
+			},
+		})
+	}
 }
 
 func (s service) GetClaGroupICLASignatures(ctx context.Context, claGroupID string, searchTerm *string) (*models.IclaSignatures, error) {
@@ -819,26 +1256,35 @@ func (s service) GetClaGroupCorporateContributors(ctx context.Context, claGroupI
 }
 
 // sendRequestAccessEmailToContributors sends the request access email to the specified contributors
-func sendRequestAccessEmailToContributorRecipient(authUser *auth.User, companyModel *models.Company, claGroupModel *models.ClaGroup, recipientName, recipientAddress, addRemove, toFrom, authorizedString string) {
+func sendRequestAccessEmailToContributorRecipient(updatedBy string, companyModel *models.Company, claGroupModel *models.ClaGroup, recipientName, recipientAddress, recipientLanguage, addRemove, toFrom, authorizedString string) {
 	companyName := companyModel.CompanyName
 	projectName := claGroupModel.ProjectName
 
+	params := emails.ApprovalListUpdateToContributorTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName:     recipientName,
+			Project:           emails.CLAProjectParams{ExternalProjectName: projectName, FoundationSFID: claGroupModel.FoundationSFID},
+			CompanyName:       companyName,
+			RecipientLanguage: recipientLanguage,
+		},
+		UpdatedBy:        updatedBy,
+		AddRemove:        addRemove,
+		ToFrom:           toFrom,
+		AuthorizedString: authorizedString,
+	}
+
 	// subject string, body string, recipients []string
-	subject := fmt.Sprintf("EasyCLA: Approval List Update for %s on %s", companyName, projectName)
+	subject := emails.RenderSubject(emails.ApprovalListUpdateToContributorTemplateName,
+		fmt.Sprintf("EasyCLA: Approval List Update for %s on %s", companyName, projectName), params)
 	recipients := []string{recipientAddress}
-	body := fmt.Sprintf(`
-<p>Hello %s,</p>
-<p>This is a notification email from EasyCLA regarding the project %s.</p>
-<p>You have been %s %s the Approval List of %s for %s by CLA Manager %s. This means that %s on behalf of %s.</p>
-<p>If you had previously submitted a pull request to EasyCLA Test Group that had failed, 
-you can now go back to it and follow the link to verify with your organization.</p>
-%s
-%s`,
-		recipientName, projectName, addRemove, toFrom,
-		companyName, projectName, authUser.UserName, authorizedString, projectName,
-		utils.GetEmailHelpContent(claGroupModel.Version == utils.V2), utils.GetEmailSignOffContent())
+	body, err := emails.RenderTemplate(claGroupModel.Version, emails.ApprovalListUpdateToContributorTemplateName,
+		emails.ApprovalListUpdateToContributorTemplate, params)
+	if err != nil {
+		log.Warnf("rendering email template : %s failed : %v", emails.ApprovalListUpdateToContributorTemplateName, err)
+		return
+	}
 
-	err := utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.ApprovalListUpdateToContributorTemplateName, CLAGroupID: claGroupModel.ProjectID, RecipientRole: utils.EmailRecipientRoleContributor})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -846,6 +1292,41 @@ you can now go back to it and follow the link to verify with your organization.<
 	}
 }
 
+// UserIsApproved returns true if the user is on one of the approval lists of the specified CCLA signature
+func (s service) UserIsApproved(ctx context.Context, user *models.User, cclaSignature *models.Signature) (bool, error) {
+	f := logrus.Fields{
+		"functionName":   "UserIsApproved",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         user.UserID,
+		"signatureID":    cclaSignature.SignatureID,
+	}
+
+	listType, err := matchApprovalList(ctx, user, cclaSignature, github.GetUserOrganizations, github.IsUserTeamMember)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to check the approval lists for the user")
+		return false, err
+	}
+	if listType == "" {
+		return false, nil
+	}
+
+	log.WithFields(f).Debugf("found user in the %s approval list", listType)
+	return true, nil
+}
+
+// HasUserSigned determines if the user is authorized to contribute to the specified CLA Group. The first return
+// value is true if the user has a signed ICLA, or a signed employee acknowledgement under an approved company CCLA.
+// The second return value is true if the user is on the approval list of their company CCLA but has not yet
+// confirmed their affiliation with the company. ICLAs and CCLAs that were not re-signed by the deadline after a
+// new major version was published no longer count.
+func (s service) HasUserSigned(ctx context.Context, user *models.User, claGroupID string) (bool, bool, error) {
+	coverage, err := s.evaluateCoverage(ctx, user, claGroupID)
+	if err != nil {
+		return false, false, err
+	}
+	return coverage.Covered, coverage.MissingAction == MissingActionConfirmAffiliation, nil
+}
+
 // getBestEmail is a helper function to return the best email address for the user model
 func getBestEmail(userModel *models.User) string {
 	if userModel.LfEmail != "" {
@@ -3,6 +3,8 @@
 
 package template
 
+import "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+
 // DBProjectModel data model
 type DBProjectModel struct {
 	DateCreated                      string                   `dynamodbav:"date_created"`
@@ -33,3 +35,22 @@ type DBProjectDocumentModel struct {
 	DocumentMinorVersion    string `dynamodbav:"document_minor_version"`
 	DocumentCreationDate    string `dynamodbav:"document_creation_date"`
 }
+
+// DBTemplateModel is the data model of a template version uploaded for a CLA Group
+type DBTemplateModel struct {
+	TemplateID           string              `dynamodbav:"template_id"`
+	TemplateVersion      string              `dynamodbav:"template_version"`
+	ClaGroupID           string              `dynamodbav:"cla_group_id"`
+	Name                 string              `dynamodbav:"template_name"`
+	Description          string              `dynamodbav:"template_description"`
+	TemplateMajorVersion int64               `dynamodbav:"template_major_version"`
+	TemplateMinorVersion int64               `dynamodbav:"template_minor_version"`
+	IclaHTMLBody         string              `dynamodbav:"icla_html_body"`
+	CclaHTMLBody         string              `dynamodbav:"ccla_html_body"`
+	MetaFields           []*models.MetaField `dynamodbav:"meta_fields"`
+	IclaFields           []*models.Field     `dynamodbav:"icla_fields"`
+	CclaFields           []*models.Field     `dynamodbav:"ccla_fields"`
+	DateCreated          string              `dynamodbav:"date_created"`
+	DateModified         string              `dynamodbav:"date_modified"`
+	Version              string              `dynamodbav:"version"`
+}
@@ -26,6 +26,8 @@ import (
 var (
 	// ErrTemplateNotFound error
 	ErrTemplateNotFound = errors.New("template not found")
+	// ErrCLAGroupNotFound error
+	ErrCLAGroupNotFound = errors.New("cla group not found")
 )
 
 var (
@@ -37,8 +39,9 @@ var (
 
 // Repository interface functions
 type Repository interface {
-	GetTemplates(ctx context.Context) ([]models.Template, error)
+	GetTemplates(ctx context.Context, claGroupID string) ([]models.Template, error)
 	GetTemplate(templateID string) (models.Template, error)
+	CreateTemplateVersion(ctx context.Context, claGroupID string, template models.Template) error
 	GetCLAGroup(claGroupID string) (*models.ClaGroup, error)
 	GetCLADocuments(claGroupID string, claType string) ([]models.ClaGroupDocument, error)
 	UpdateDynamoContractGroupTemplates(ctx context.Context, ContractGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool) error
@@ -105,11 +108,13 @@ func NewRepository(awsSession *session.Session, stage string) repository {
 	}
 }
 
-// GetTemplates returns a list containing all the template models
-func (r repository) GetTemplates(ctx context.Context) ([]models.Template, error) {
+// GetTemplates returns a list containing all the built-in template models and, when a CLA Group ID
+// is provided, the latest version of each template uploaded for the CLA Group
+func (r repository) GetTemplates(ctx context.Context, claGroupID string) ([]models.Template, error) {
 	f := logrus.Fields{
 		"functionName":   "GetTemplates",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
 	}
 
 	log.WithFields(f).Debug("Loading templates...")
@@ -118,6 +123,15 @@ func (r repository) GetTemplates(ctx context.Context) ([]models.Template, error)
 		templates = append(templates, template)
 	}
 
+	if claGroupID != "" {
+		uploadedTemplates, err := r.getCLAGroupTemplates(claGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem loading the templates uploaded for the CLA Group")
+			return nil, err
+		}
+		templates = append(templates, uploadedTemplates...)
+	}
+
 	// Sort the template list based on the name
 	log.WithFields(f).Debug("Sorting templates...")
 	sort.Slice(templates, func(i, j int) bool {
@@ -127,14 +141,157 @@ func (r repository) GetTemplates(ctx context.Context) ([]models.Template, error)
 	return templates, nil
 }
 
-// GetTemplate returns the template based on the template ID
+// GetTemplate returns the template based on the template ID, for uploaded templates the latest version is returned
 func (r repository) GetTemplate(templateID string) (models.Template, error) {
 	template, ok := templateMap[templateID]
-	if !ok {
+	if ok {
+		return template, nil
+	}
+
+	result, err := r.dynamoDBClient.Query(&dynamodb.QueryInput{
+		TableName:              aws.String(r.templatesTableName()),
+		KeyConditionExpression: aws.String("template_id = :template_id"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":template_id": {S: aws.String(templateID)},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int64(1),
+	})
+	if err != nil {
+		log.Warnf("error querying template with id: %s, error: %+v", templateID, err)
+		return models.Template{}, err
+	}
+	if len(result.Items) == 0 {
 		return models.Template{}, ErrTemplateNotFound
 	}
 
-	return template, nil
+	var dbModel DBTemplateModel
+	err = dynamodbattribute.UnmarshalMap(result.Items[0], &dbModel)
+	if err != nil {
+		log.Warnf("error unmarshalling template with id: %s, error: %+v", templateID, err)
+		return models.Template{}, err
+	}
+
+	return r.buildTemplateModel(dbModel), nil
+}
+
+// CreateTemplateVersion stores a new version of a template uploaded for the CLA Group, the version must not exist yet
+func (r repository) CreateTemplateVersion(ctx context.Context, claGroupID string, template models.Template) error {
+	f := logrus.Fields{
+		"functionName":   "CreateTemplateVersion",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"templateID":     template.ID,
+		"templateName":   template.Name,
+		"majorVersion":   template.TemplateMajorVersion,
+		"minorVersion":   template.TemplateMinorVersion,
+	}
+
+	_, now := utils.CurrentTime()
+	dbModel := DBTemplateModel{
+		TemplateID:           template.ID,
+		TemplateVersion:      templateVersionKey(template.TemplateMajorVersion, template.TemplateMinorVersion),
+		ClaGroupID:           claGroupID,
+		Name:                 template.Name,
+		Description:          template.Description,
+		TemplateMajorVersion: template.TemplateMajorVersion,
+		TemplateMinorVersion: template.TemplateMinorVersion,
+		IclaHTMLBody:         template.IclaHTMLBody,
+		CclaHTMLBody:         template.CclaHTMLBody,
+		MetaFields:           template.MetaFields,
+		IclaFields:           template.IclaFields,
+		CclaFields:           template.CclaFields,
+		DateCreated:          now,
+		DateModified:         now,
+		Version:              "v1",
+	}
+	item, err := dynamodbattribute.MarshalMap(dbModel)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal template")
+		return err
+	}
+
+	log.WithFields(f).Debug("Storing template version...")
+	_, err = r.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String(r.templatesTableName()),
+		Item:      item,
+		// Concurrent uploads of the same template must not overwrite each other's version
+		ConditionExpression: aws.String("attribute_not_exists(template_id) AND attribute_not_exists(template_version)"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to store template version")
+		return err
+	}
+
+	return nil
+}
+
+// getCLAGroupTemplates returns the latest version of each template uploaded for the CLA Group
+func (r repository) getCLAGroupTemplates(claGroupID string) ([]models.Template, error) {
+	latest := map[string]DBTemplateModel{}
+	var lastEvaluatedKey map[string]*dynamodb.AttributeValue
+	for {
+		result, err := r.dynamoDBClient.Query(&dynamodb.QueryInput{
+			TableName:              aws.String(r.templatesTableName()),
+			IndexName:              aws.String("cla-group-id-index"),
+			KeyConditionExpression: aws.String("cla_group_id = :cla_group_id"),
+			ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+				":cla_group_id": {S: aws.String(claGroupID)},
+			},
+			ExclusiveStartKey: lastEvaluatedKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var dbModels []DBTemplateModel
+		err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &dbModels)
+		if err != nil {
+			return nil, err
+		}
+		for _, dbModel := range dbModels {
+			if current, ok := latest[dbModel.TemplateID]; !ok || current.TemplateVersion < dbModel.TemplateVersion {
+				latest[dbModel.TemplateID] = dbModel
+			}
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	var templates []models.Template
+	for _, dbModel := range latest {
+		templates = append(templates, r.buildTemplateModel(dbModel))
+	}
+	return templates, nil
+}
+
+// buildTemplateModel maps the uploaded template database model to the API response model
+func (r repository) buildTemplateModel(dbModel DBTemplateModel) models.Template {
+	return models.Template{
+		ID:                   dbModel.TemplateID,
+		ClaGroupID:           dbModel.ClaGroupID,
+		Name:                 dbModel.Name,
+		Description:          dbModel.Description,
+		TemplateMajorVersion: dbModel.TemplateMajorVersion,
+		TemplateMinorVersion: dbModel.TemplateMinorVersion,
+		IclaHTMLBody:         dbModel.IclaHTMLBody,
+		CclaHTMLBody:         dbModel.CclaHTMLBody,
+		MetaFields:           dbModel.MetaFields,
+		IclaFields:           dbModel.IclaFields,
+		CclaFields:           dbModel.CclaFields,
+	}
+}
+
+func (r repository) templatesTableName() string {
+	return fmt.Sprintf("cla-%s-templates", r.stage)
+}
+
+// templateVersionKey is the range key of a template version, zero padded so the versions sort in order
+func templateVersionKey(majorVersion, minorVersion int64) string {
+	return fmt.Sprintf("%06d.%06d", majorVersion, minorVersion)
 }
 
 // GetCLAGroup This method belongs in the contract group package. We are leaving it here
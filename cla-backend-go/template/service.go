@@ -17,13 +17,14 @@ import (
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
-	"github.com/communitybridge/easycla/cla-backend-go/docraptor"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/renderer"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aymerick/raymond"
+	"github.com/gofrs/uuid"
 )
 
 const (
@@ -33,37 +34,40 @@ const (
 
 // Service interface
 type Service interface {
-	GetTemplates(ctx context.Context) ([]models.Template, error)
+	GetTemplates(ctx context.Context, claGroupID string) ([]models.Template, error)
+	UploadCLAGroupTemplate(ctx context.Context, claGroupID string, input *models.UploadClaGroupTemplate) (models.Template, error)
 	CreateCLAGroupTemplate(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate) (models.TemplatePdfs, error)
+	CreateCLAGroupTemplateVersion(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate, majorVersion, minorVersion int64) (models.TemplatePdfs, error)
 	CreateTemplatePreview(ctx context.Context, claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error)
 	GetCLATemplatePreview(ctx context.Context, claGroupID, claType string, watermark bool) ([]byte, error)
 }
 
 type service struct {
-	stage           string // The AWS stage (dev, staging, prod)
-	templateRepo    Repository
-	docraptorClient docraptor.Client
-	s3Client        *s3manager.Uploader
+	stage        string // The AWS stage (dev, staging, prod)
+	templateRepo Repository
+	pdfRenderer  renderer.PDFRenderer
+	s3Client     *s3manager.Uploader
 }
 
 // NewService API call
-func NewService(stage string, templateRepo Repository, docraptorClient docraptor.Client, awsSession *session.Session) service {
+func NewService(stage string, templateRepo Repository, pdfRenderer renderer.PDFRenderer, awsSession *session.Session) service {
 	return service{
-		stage:           stage,
-		templateRepo:    templateRepo,
-		docraptorClient: docraptorClient,
-		s3Client:        s3manager.NewUploader(awsSession),
+		stage:        stage,
+		templateRepo: templateRepo,
+		pdfRenderer:  pdfRenderer,
+		s3Client:     s3manager.NewUploader(awsSession),
 	}
 }
 
-// GetTemplates API call
-func (s service) GetTemplates(ctx context.Context) ([]models.Template, error) {
+// GetTemplates API call, the templates uploaded for the CLA Group are included when a CLA Group ID is provided
+func (s service) GetTemplates(ctx context.Context, claGroupID string) ([]models.Template, error) {
 	f := logrus.Fields{
 		"functionName":   "GetTemplates",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
 	}
 	log.WithFields(f).Debug("Loading templates...")
-	templates, err := s.templateRepo.GetTemplates(ctx)
+	templates, err := s.templateRepo.GetTemplates(ctx, claGroupID)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warn("problem loading templates...")
 		return nil, err
@@ -79,6 +83,84 @@ func (s service) GetTemplates(ctx context.Context) ([]models.Template, error) {
 	return templates, nil
 }
 
+// UploadCLAGroupTemplate stores the ICLA/CCLA templates provided by the CLA Group. Uploading with the ID of a
+// template already uploaded for the CLA Group creates a new major (or minor) version of it.
+func (s service) UploadCLAGroupTemplate(ctx context.Context, claGroupID string, input *models.UploadClaGroupTemplate) (models.Template, error) {
+	f := logrus.Fields{
+		"functionName":   "UploadCLAGroupTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"templateID":     input.TemplateID,
+		"templateName":   input.Name,
+	}
+
+	claGroup, err := s.templateRepo.GetCLAGroup(claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to fetch CLA group")
+		return models.Template{}, err
+	}
+	if claGroup.ProjectID == "" {
+		return models.Template{}, ErrCLAGroupNotFound
+	}
+
+	err = validateUploadedTemplate(input, claGroup.ProjectICLAEnabled, claGroup.ProjectCCLAEnabled)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("uploaded template failed validation")
+		return models.Template{}, err
+	}
+
+	template := models.Template{
+		ClaGroupID:           claGroupID,
+		Name:                 input.Name,
+		Description:          input.Description,
+		TemplateMajorVersion: 1,
+		IclaHTMLBody:         input.IclaHTMLBody,
+		CclaHTMLBody:         input.CclaHTMLBody,
+		MetaFields:           input.MetaFields,
+		IclaFields:           input.IclaFields,
+		CclaFields:           input.CclaFields,
+	}
+
+	if input.TemplateID != "" {
+		current, getErr := s.templateRepo.GetTemplate(input.TemplateID)
+		if getErr != nil {
+			log.WithFields(f).WithError(getErr).Warn("unable to fetch the template to version")
+			return models.Template{}, getErr
+		}
+		// Only the templates uploaded for this CLA group can be versioned, the built-in templates are read-only
+		if current.ClaGroupID != claGroupID {
+			return models.Template{}, ErrTemplateNotFound
+		}
+		template.ID = current.ID
+		if input.MinorRevision {
+			template.TemplateMajorVersion = current.TemplateMajorVersion
+			template.TemplateMinorVersion = current.TemplateMinorVersion + 1
+		} else {
+			template.TemplateMajorVersion = current.TemplateMajorVersion + 1
+		}
+	} else {
+		templateID, uuidErr := uuid.NewV4()
+		if uuidErr != nil {
+			log.WithFields(f).WithError(uuidErr).Warn("unable to generate template ID")
+			return models.Template{}, uuidErr
+		}
+		template.ID = templateID.String()
+	}
+
+	f["templateID"] = template.ID
+	f["majorVersion"] = template.TemplateMajorVersion
+	f["minorVersion"] = template.TemplateMinorVersion
+	log.WithFields(f).Debug("storing uploaded template...")
+	err = s.templateRepo.CreateTemplateVersion(ctx, claGroupID, template)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to store uploaded template")
+		return models.Template{}, err
+	}
+
+	return template, nil
+}
+
+// CreateTemplatePreview renders the ICLA or CCLA document of a built-in template with the provided fields
 func (s service) CreateTemplatePreview(ctx context.Context, claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error) {
 	f := logrus.Fields{
 		"functionName":   "CreateTemplatePreview",
@@ -101,6 +183,11 @@ func (s service) CreateTemplatePreview(ctx context.Context, claGroupFields *mode
 			claGroupFields.TemplateID)
 		return nil, err
 	}
+	// The preview is not tied to a CLA Group, the templates uploaded for a CLA Group are not shared with the others
+	if template.ClaGroupID != "" {
+		log.WithFields(f).Warnf("Template: %s belongs to a CLA Group, only the built-in templates can be previewed", templateID)
+		return nil, ErrTemplateNotFound
+	}
 
 	// Apply template fields
 	iclaTemplateHTML, cclaTemplateHTML, err := s.InjectProjectInformationIntoTemplate(template, claGroupFields.MetaFields)
@@ -118,7 +205,7 @@ func (s service) CreateTemplatePreview(ctx context.Context, claGroupFields *mode
 		return nil, errors.New("invalid value of template_for")
 	}
 
-	pdf, err := s.docraptorClient.CreatePDF(templateHTML, templateFor)
+	pdf, err := s.pdfRenderer.CreatePDF(templateHTML, templateFor)
 	if err != nil {
 		return nil, err
 	}
@@ -131,8 +218,23 @@ func (s service) CreateTemplatePreview(ctx context.Context, claGroupFields *mode
 	return ioutil.ReadAll(pdf)
 }
 
-// CreateCLAGroupTemplate
+// documentVersion overrides the template version of the generated CLA Group documents
+type documentVersion struct {
+	major int64
+	minor int64
+}
+
+// CreateCLAGroupTemplate generates the CLA Group documents from the template, the documents take the template version
 func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate) (models.TemplatePdfs, error) {
+	return s.createCLAGroupTemplate(ctx, claGroupID, claGroupFields, nil)
+}
+
+// CreateCLAGroupTemplateVersion generates the CLA Group documents from the template with the specified document version
+func (s service) CreateCLAGroupTemplateVersion(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate, majorVersion, minorVersion int64) (models.TemplatePdfs, error) {
+	return s.createCLAGroupTemplate(ctx, claGroupID, claGroupFields, &documentVersion{major: majorVersion, minor: minorVersion})
+}
+
+func (s service) createCLAGroupTemplate(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate, version *documentVersion) (models.TemplatePdfs, error) {
 	f := logrus.Fields{
 		"functionName":   "CreateCLAGroupTemplate",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -156,6 +258,15 @@ func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 			claGroupFields.TemplateID)
 		return models.TemplatePdfs{}, err
 	}
+	// Templates uploaded for a CLA Group are not shared with the other CLA Groups
+	if template.ClaGroupID != "" && template.ClaGroupID != claGroupID {
+		log.WithFields(f).Warnf("Template: %s belongs to another CLA Group - returning empty template PDFs", claGroupFields.TemplateID)
+		return models.TemplatePdfs{}, ErrTemplateNotFound
+	}
+	if version != nil {
+		template.TemplateMajorVersion = version.major
+		template.TemplateMinorVersion = version.minor
+	}
 
 	// Apply template fields
 	iclaTemplateHTML, cclaTemplateHTML, err := s.InjectProjectInformationIntoTemplate(template, claGroupFields.MetaFields)
@@ -179,9 +290,9 @@ func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		// Invoke the go routine - any errors will be handled below
 		eg.Go(func() error {
 			log.WithFields(f).Debugf("Creating PDF for %s", claTypeICLA)
-			iclaPdf, iclaErr := s.docraptorClient.CreatePDF(iclaTemplateHTML, claTypeICLA)
+			iclaPdf, iclaErr := s.pdfRenderer.CreatePDF(iclaTemplateHTML, claTypeICLA)
 			if iclaErr != nil {
-				log.WithFields(f).WithError(iclaErr).Warn("Problem generating ICLA template via docraptor client - returning empty template PDFs")
+				log.WithFields(f).WithError(iclaErr).Warn("Problem generating ICLA template via the pdf renderer - returning empty template PDFs")
 				return err
 			}
 			defer func() {
@@ -206,9 +317,9 @@ func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		// Invoke the go routine - any errors will be handled below
 		eg.Go(func() error {
 			log.WithFields(f).Debugf("Creating PDF for %s", claTypeCCLA)
-			cclaPdf, cclaErr := s.docraptorClient.CreatePDF(cclaTemplateHTML, claTypeCCLA)
+			cclaPdf, cclaErr := s.pdfRenderer.CreatePDF(cclaTemplateHTML, claTypeCCLA)
 			if cclaErr != nil {
-				log.WithFields(f).WithError(cclaErr).Warn("Problem generating CCLA template via docraptor client - returning empty template PDFs")
+				log.WithFields(f).WithError(cclaErr).Warn("Problem generating CCLA template via the pdf renderer - returning empty template PDFs")
 				return err
 			}
 			defer func() {
@@ -372,13 +483,13 @@ func (s service) InjectProjectInformationIntoTemplate(template models.Template,
 
 		if val.Name == metaField.Name && val.TemplateVariable == metaField.TemplateVariable {
 			if metaField.Value == "" {
-				return "", "", fmt.Errorf("bad request: template field value of variable %s cannot be empty", metaField.TemplateVariable)
+				return "", "", fmt.Errorf("%w: template field value of variable %s cannot be empty", ErrInvalidTemplateFields, metaField.TemplateVariable)
 			}
 			metaFieldsMap[metaField.TemplateVariable] = metaField.Value
 		}
 	}
 	if len(template.MetaFields) != len(metaFieldsMap) {
-		return "", "", errors.New("bad request: required fields for template were not found")
+		return "", "", fmt.Errorf("%w: required fields for template were not found", ErrInvalidTemplateFields)
 	}
 
 	iclaTemplateHTML, err := raymond.Render(template.IclaHTMLBody, metaFieldsMap)
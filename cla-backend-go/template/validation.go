@@ -0,0 +1,225 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxTemplateHTMLSize keeps the uploaded template record well below the DynamoDB item size limit
+const maxTemplateHTMLSize = 150 * 1024
+
+var (
+	// ErrInvalidTemplate is wrapped by the errors returned when an uploaded template fails validation
+	ErrInvalidTemplate = errors.New("invalid template")
+
+	// ErrInvalidTemplateFields is wrapped by the errors returned when the template fields of a CLA Group are missing
+	ErrInvalidTemplateFields = errors.New("invalid template fields")
+
+	// templateVariableRegex matches the {{VARIABLE}} placeholders of the template bodies
+	templateVariableRegex = regexp.MustCompile(`{{\s*([A-Za-z0-9_]+)\s*}}`)
+
+	// templateElements are the elements an uploaded template may use, the document markup and the formatting
+	templateElements = atomSet(
+		atom.Html, atom.Head, atom.Body, atom.Title, atom.Meta, atom.Style,
+		atom.A, atom.Abbr, atom.Article, atom.B, atom.Blockquote, atom.Br, atom.Caption, atom.Center, atom.Code,
+		atom.Col, atom.Colgroup, atom.Dd, atom.Div, atom.Dl, atom.Dt, atom.Em, atom.Font, atom.Footer,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Header, atom.Hr, atom.I, atom.Img, atom.Li,
+		atom.Main, atom.Ol, atom.P, atom.Pre, atom.S, atom.Section, atom.Small, atom.Span, atom.Strong, atom.Sub,
+		atom.Sup, atom.Table, atom.Tbody, atom.Td, atom.Tfoot, atom.Th, atom.Thead, atom.Tr, atom.U, atom.Ul,
+	)
+
+	// templateAttributes are the attributes an uploaded template may use, no event handler is allowed
+	templateAttributes = map[string]bool{
+		"id": true, "class": true, "style": true, "title": true, "lang": true, "dir": true, "name": true,
+		"href": true, "src": true, "alt": true, "width": true, "height": true, "align": true, "valign": true,
+		"border": true, "cellpadding": true, "cellspacing": true, "colspan": true, "rowspan": true, "span": true,
+		"start": true, "type": true, "bgcolor": true, "color": true, "face": true, "size": true, "charset": true,
+		"media": true,
+	}
+
+	// templateURLAttributes are the attributes holding a URL the PDF renderers load or link to
+	templateURLAttributes = map[string]bool{"href": true, "src": true}
+
+	// cssURLRegex matches the URLs loaded by the style sheets
+	cssURLRegex = regexp.MustCompile(`(?i)url\(\s*['"]?([^'")]*)`)
+)
+
+func invalidTemplateError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidTemplate, fmt.Sprintf(format, args...))
+}
+
+// validateUploadedTemplate checks that the uploaded template can be used like the built-in templates: every
+// placeholder in the HTML bodies is a declared meta field, and every signing field anchor is present in its body
+func validateUploadedTemplate(input *models.UploadClaGroupTemplate, iclaEnabled, cclaEnabled bool) error {
+	if strings.TrimSpace(input.Name) == "" {
+		return invalidTemplateError("template name is required")
+	}
+	if iclaEnabled && strings.TrimSpace(input.IclaHTMLBody) == "" {
+		return invalidTemplateError("ICLA HTML body is required, the CLA group has ICLAs enabled")
+	}
+	if cclaEnabled && strings.TrimSpace(input.CclaHTMLBody) == "" {
+		return invalidTemplateError("CCLA HTML body is required, the CLA group has CCLAs enabled")
+	}
+	if len(input.IclaHTMLBody)+len(input.CclaHTMLBody) > maxTemplateHTMLSize {
+		return invalidTemplateError("the HTML bodies exceed the maximum size of %d bytes", maxTemplateHTMLSize)
+	}
+
+	declared := map[string]bool{}
+	names := map[string]bool{}
+	for _, metaField := range input.MetaFields {
+		if metaField == nil || metaField.Name == "" || metaField.TemplateVariable == "" {
+			return invalidTemplateError("meta fields require a name and a template variable")
+		}
+		if !templateVariableRegex.MatchString("{{" + metaField.TemplateVariable + "}}") {
+			return invalidTemplateError("meta field template variable %s may only contain letters, digits and underscores", metaField.TemplateVariable)
+		}
+		if names[metaField.Name] || declared[metaField.TemplateVariable] {
+			return invalidTemplateError("duplicate meta field %s", metaField.Name)
+		}
+		names[metaField.Name] = true
+		declared[metaField.TemplateVariable] = true
+	}
+
+	used := map[string]bool{}
+	for _, body := range []string{input.IclaHTMLBody, input.CclaHTMLBody} {
+		for _, match := range templateVariableRegex.FindAllStringSubmatch(body, -1) {
+			if !declared[match[1]] {
+				return invalidTemplateError("template variable %s is not declared as a meta field", match[1])
+			}
+			used[match[1]] = true
+		}
+	}
+	for variable := range declared {
+		if !used[variable] {
+			return invalidTemplateError("meta field template variable %s is not used in the template", variable)
+		}
+	}
+
+	if err := validateTemplateHTML(claTypeICLA, input.IclaHTMLBody); err != nil {
+		return err
+	}
+	if err := validateTemplateHTML(claTypeCCLA, input.CclaHTMLBody); err != nil {
+		return err
+	}
+
+	if err := validateSigningFields(claTypeICLA, input.IclaFields, input.IclaHTMLBody); err != nil {
+		return err
+	}
+	return validateSigningFields(claTypeCCLA, input.CclaFields, input.CclaHTMLBody)
+}
+
+// validateTemplateHTML rejects the content of the HTML body the PDF renderers would run or load from the renderer
+// host: only the allowed elements and attributes may be used, and the documents and style sheets may only load
+// https URLs of public hosts
+func validateTemplateHTML(claType, body string) error {
+	tokenizer := html.NewTokenizer(strings.NewReader(body))
+	inStyle := false
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return invalidTemplateError("%s HTML body could not be parsed: %v", claType, err)
+			}
+			return nil
+		case html.TextToken:
+			if inStyle {
+				if err := validateTemplateCSS(claType, string(tokenizer.Text())); err != nil {
+					return err
+				}
+			}
+		case html.EndTagToken:
+			inStyle = false
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if !templateElements[token.DataAtom] {
+				return invalidTemplateError("%s HTML body may not contain %s elements", claType, token.Data)
+			}
+			inStyle = token.DataAtom == atom.Style
+			for _, attr := range token.Attr {
+				if !templateAttributes[attr.Key] || (token.DataAtom == atom.Meta && attr.Key != "charset") {
+					return invalidTemplateError("%s HTML body may not use the %s attribute of %s elements", claType, attr.Key, token.Data)
+				}
+				if templateURLAttributes[attr.Key] && !isTemplateURLAllowed(attr.Val) {
+					return invalidTemplateError("%s HTML body may only link to https URLs of public hosts, found %s=%q", claType, attr.Key, attr.Val)
+				}
+				if attr.Key == "style" {
+					if err := validateTemplateCSS(claType, attr.Val); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
+
+// validateTemplateCSS rejects the style sheets importing other style sheets, running expressions or loading URLs other
+// than the https URLs of public hosts
+func validateTemplateCSS(claType, css string) error {
+	lower := strings.ToLower(css)
+	if strings.Contains(lower, "@import") || strings.Contains(lower, "expression(") || strings.Contains(lower, "\\") {
+		return invalidTemplateError("%s HTML body may not use CSS imports, expressions or escapes", claType)
+	}
+	for _, match := range cssURLRegex.FindAllStringSubmatch(css, -1) {
+		if !isTemplateURLAllowed(match[1]) || strings.HasPrefix(strings.TrimSpace(match[1]), "#") {
+			return invalidTemplateError("%s HTML body style sheets may only load https URLs of public hosts, found %q", claType, match[1])
+		}
+	}
+	return nil
+}
+
+// atomSet returns the set of the elements
+func atomSet(atoms ...atom.Atom) map[atom.Atom]bool {
+	set := make(map[atom.Atom]bool, len(atoms))
+	for _, a := range atoms {
+		set[a] = true
+	}
+	return set
+}
+
+// isTemplateURLAllowed returns true for the absolute https URLs of public hosts and the links to a fragment of the
+// document - a relative URL resolves against the file the renderer prints and the renderer must not reach the hosts
+// of its network
+func isTemplateURLAllowed(value string) bool {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "#") {
+		return true
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, "https") && utils.IsPublicHost(u.Hostname())
+}
+
+// validateSigningFields checks the signing fields of one CLA type, the e-sign tabs are positioned by the anchors
+func validateSigningFields(claType string, fields []*models.Field, body string) error {
+	if len(fields) > 0 && body == "" {
+		return invalidTemplateError("%s signing fields provided without a %s HTML body", claType, claType)
+	}
+	ids := map[string]bool{}
+	for _, field := range fields {
+		if field == nil || field.ID == "" || field.AnchorString == "" {
+			return invalidTemplateError("%s signing fields require an id and an anchor string", claType)
+		}
+		if ids[field.ID] {
+			return invalidTemplateError("duplicate %s signing field %s", claType, field.ID)
+		}
+		ids[field.ID] = true
+		if !strings.Contains(body, field.AnchorString) {
+			return invalidTemplateError("%s signing field %s anchor %q not found in the HTML body", claType, field.ID, field.AnchorString)
+		}
+	}
+	return nil
+}
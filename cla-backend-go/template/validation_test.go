@@ -0,0 +1,143 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testUploadedTemplate() *models.UploadClaGroupTemplate {
+	return &models.UploadClaGroupTemplate{
+		Name:         "Foundation Style",
+		IclaHTMLBody: "<p>{{PROJECT_NAME}} Individual CLA</p><p>Full name:</p>",
+		CclaHTMLBody: "<p>{{ PROJECT_NAME }} Corporate CLA for {{CONTACT_EMAIL}}</p><p>Corporation name:</p>",
+		MetaFields: []*models.MetaField{
+			{Name: "Project Name", TemplateVariable: "PROJECT_NAME"},
+			{Name: "Contact Email Address", TemplateVariable: "CONTACT_EMAIL"},
+		},
+		IclaFields: []*models.Field{{ID: "full_name", AnchorString: "Full name:"}},
+		CclaFields: []*models.Field{{ID: "corporation_name", AnchorString: "Corporation name:"}},
+	}
+}
+
+func TestValidateUploadedTemplate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(input *models.UploadClaGroupTemplate)
+		valid  bool
+	}{
+		{name: "valid template", modify: func(input *models.UploadClaGroupTemplate) {}, valid: true},
+		{name: "missing name", modify: func(input *models.UploadClaGroupTemplate) { input.Name = " " }},
+		{name: "missing ccla body", modify: func(input *models.UploadClaGroupTemplate) { input.CclaHTMLBody = "" }},
+		{name: "undeclared variable", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += "{{PROJECT_ENTITY_NAME}}"
+		}},
+		{name: "unused meta field", modify: func(input *models.UploadClaGroupTemplate) {
+			input.MetaFields = append(input.MetaFields, &models.MetaField{Name: "Project Entity Name", TemplateVariable: "PROJECT_ENTITY_NAME"})
+		}},
+		{name: "duplicate meta field", modify: func(input *models.UploadClaGroupTemplate) {
+			input.MetaFields = append(input.MetaFields, &models.MetaField{Name: "Project Name", TemplateVariable: "PROJECT_NAME"})
+		}},
+		{name: "invalid template variable", modify: func(input *models.UploadClaGroupTemplate) {
+			input.MetaFields[0].TemplateVariable = "PROJECT NAME"
+		}},
+		{name: "anchor missing from body", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaFields[0].AnchorString = "Signature:"
+		}},
+		{name: "duplicate signing field", modify: func(input *models.UploadClaGroupTemplate) {
+			input.CclaFields = append(input.CclaFields, &models.Field{ID: "corporation_name", AnchorString: "Corporation name:"})
+		}},
+		{name: "https links", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<a href="https://example.org/cla">CLA</a><img src="https://example.org/logo.png"><a href="#terms">Terms</a>`
+		}, valid: true},
+		{name: "http url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<img src="http://example.org/logo.png">`
+		}},
+		{name: "metadata service url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.CclaHTMLBody += `<img src="https://169.254.169.254/latest/meta-data/">`
+		}},
+		{name: "private network url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<a href="https://10.0.0.12:8443/admin">admin</a>`
+		}},
+		{name: "style sheet localhost url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<style>p { background: url(https://localhost/internal.png) }</style>`
+		}},
+		{name: "script", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += "<script>document.title = 'x'</script>"
+		}},
+		{name: "iframe", modify: func(input *models.UploadClaGroupTemplate) {
+			input.CclaHTMLBody += `<IFRAME src="https://example.org"></IFRAME>`
+		}},
+		{name: "file url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<img src="file:///etc/passwd">`
+		}},
+		{name: "javascript url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.CclaHTMLBody += `<a href="&#106;avascript:alert(1)">link</a>`
+		}},
+		{name: "relative url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<img src="../../etc/passwd">`
+		}},
+		{name: "data url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<object data="data:text/html;base64,PHNjcmlwdD4=">`
+		}},
+		{name: "styled document", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody = `<html><head><meta charset="utf-8"><title>ICLA</title>
+<style>body { font-family: serif; background: url("https://example.org/bg.png"); }</style></head>
+<body><p style="color: #333">{{PROJECT_NAME}} Individual CLA</p><table border="1"><tr><td colspan="2">Full name:</td></tr></table></body></html>`
+		}, valid: true},
+		{name: "event handler", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<img src="https://example.org/logo.png" onerror="alert(1)">`
+		}},
+		{name: "form", modify: func(input *models.UploadClaGroupTemplate) {
+			input.CclaHTMLBody += `<form action="https://example.org"><input name="x"></form>`
+		}},
+		{name: "meta refresh", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody = `<meta http-equiv="refresh" content="0;url=file:///etc/passwd">` + input.IclaHTMLBody
+		}},
+		{name: "style sheet file url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<style>p { background: url(file:///etc/passwd) }</style>`
+		}},
+		{name: "style attribute file url", modify: func(input *models.UploadClaGroupTemplate) {
+			input.CclaHTMLBody += `<div style="background-image: url('/etc/passwd')"></div>`
+		}},
+		{name: "style sheet import", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<style>@import "https://example.org/print.css";</style>`
+		}},
+		{name: "style sheet escape", modify: func(input *models.UploadClaGroupTemplate) {
+			input.IclaHTMLBody += `<style>p { background: u\72l(file:///etc/passwd) }</style>`
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := testUploadedTemplate()
+			tc.modify(input)
+			err := validateUploadedTemplate(input, true, true)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrInvalidTemplate), "expected an invalid template error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateUploadedTemplateCCLAOnly(t *testing.T) {
+	input := testUploadedTemplate()
+	input.IclaHTMLBody = ""
+	input.IclaFields = nil
+	assert.NoError(t, validateUploadedTemplate(input, false, true))
+
+	input.IclaFields = []*models.Field{{ID: "full_name", AnchorString: "Full name:"}}
+	assert.Error(t, validateUploadedTemplate(input, false, true), "signing fields require a body")
+}
+
+func TestTemplateVersionKeySortsInOrder(t *testing.T) {
+	assert.True(t, templateVersionKey(2, 0) < templateVersionKey(10, 0))
+	assert.True(t, templateVersionKey(2, 9) < templateVersionKey(2, 10))
+}
@@ -364,6 +364,33 @@ func TestGitHubOrg(t *testing.T) {
 	}
 }
 
+func TestGitHubTeam(t *testing.T) {
+	validGitHubTeam := []string{
+		"linuxfoundation/maintainers",
+		"kubernetes/sig-release",
+		"user-123/team_name",
+	}
+	inValidGitHubTeam := []string{
+		"linuxfoundation",
+		"linuxfoundation/",
+		"/maintainers",
+		"li/maintainers", // org too short
+		"linuxfoundation/maintainers/leads",
+		"linuxfoundation/sig release",
+		"linuxfoundation/sig.release",
+	}
+
+	for _, team := range validGitHubTeam {
+		msg, valid := utils.ValidGitHubTeam(team)
+		assert.True(t, valid, fmt.Sprintf("valid GitHub Team %s %s", team, msg))
+	}
+
+	for _, team := range inValidGitHubTeam {
+		msg, valid := utils.ValidGitHubTeam(team)
+		assert.False(t, valid, fmt.Sprintf("invalid GitHub Team %s %s", team, msg))
+	}
+}
+
 // TestGetPathFromURL tests for getting the path for a URL
 func TestGetPathFromURL(t *testing.T) {
 	input := "https://cla-signature-files-dev.s3.amazonaws.com/contract-group/66b97366-a298-4625-965e-0c292c39f9a2/template/ccla-2020-09-25T22-37-51Z.pdf"
@@ -15,6 +15,9 @@ type CLAUser struct {
 	ProjectIDs     []string
 	ClaIDs         []string
 	CompanyIDs     []string
+	// VerifiedEmails are the emails of the session verified by the identity provider, or by GitHub once the session
+	// holds a GitHub access token, unlike the emails of the user record which the user can update
+	VerifiedEmails []string
 }
 
 // Provider data model
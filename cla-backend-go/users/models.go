@@ -5,18 +5,22 @@ package users
 
 // DBUser data model
 type DBUser struct {
-	UserID             string   `json:"user_id"`
-	UserExternalID     string   `json:"user_external_id"`
-	LFEmail            string   `json:"lf_email"`
-	Admin              bool     `json:"admin"`
-	LFUsername         string   `json:"lf_username"`
-	DateCreated        string   `json:"date_created"`
-	DateModified       string   `json:"date_modified"`
-	UserName           string   `json:"user_name"`
-	Version            string   `json:"version"`
-	UserEmails         []string `json:"user_emails"`
-	UserGithubID       string   `json:"user_github_id"`
-	UserCompanyID      string   `json:"user_company_id"`
-	UserGithubUsername string   `json:"user_github_username"`
-	Note               string   `json:"note"`
+	UserID                      string            `json:"user_id"`
+	UserExternalID              string            `json:"user_external_id"`
+	LFEmail                     string            `json:"lf_email"`
+	Admin                       bool              `json:"admin"`
+	LFUsername                  string            `json:"lf_username"`
+	DateCreated                 string            `json:"date_created"`
+	DateModified                string            `json:"date_modified"`
+	UserName                    string            `json:"user_name"`
+	Version                     string            `json:"version"`
+	UserEmails                  []string          `json:"user_emails"`
+	UserGithubID                string            `json:"user_github_id"`
+	UserCompanyID               string            `json:"user_company_id"`
+	UserGithubUsername          string            `json:"user_github_username"`
+	UserGitlabID                string            `json:"user_gitlab_id"`
+	UserGitlabUsername          string            `json:"user_gitlab_username"`
+	Note                        string            `json:"note"`
+	UserPreferredLanguage       string            `json:"user_preferred_language"`
+	UserNotificationPreferences map[string]string `json:"user_notification_preferences"`
 }
@@ -0,0 +1,101 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package users
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+)
+
+// notification categories, the keys of the user_notification_preferences map
+const (
+	NotificationCategoryCLAManagerRequests   = "claManagerRequests"
+	NotificationCategoryApprovalListRequests = "approvalListRequests"
+	NotificationCategoryRepositoryEvents     = "repositoryEvents"
+)
+
+// notification frequencies
+const (
+	NotificationFrequencyImmediate = "immediate"
+	NotificationFrequencyDaily     = "daily"
+	NotificationFrequencyWeekly    = "weekly"
+	NotificationFrequencyOff       = "off"
+)
+
+// NotificationFrequency returns how often the user receives the notifications of the category, immediate when the
+// user has no preference
+func NotificationFrequency(user *models.User, category string) string {
+	if user == nil || user.NotificationPreferences == nil {
+		return NotificationFrequencyImmediate
+	}
+
+	var frequency string
+	switch category {
+	case NotificationCategoryCLAManagerRequests:
+		frequency = user.NotificationPreferences.ClaManagerRequests
+	case NotificationCategoryApprovalListRequests:
+		frequency = user.NotificationPreferences.ApprovalListRequests
+	case NotificationCategoryRepositoryEvents:
+		frequency = user.NotificationPreferences.RepositoryEvents
+	}
+	if frequency == "" {
+		return NotificationFrequencyImmediate
+	}
+	return frequency
+}
+
+// NotifyImmediately returns true if the notifications of the category are sent to the user as they happen, false
+// when they are part of a digest or turned off
+func NotifyImmediately(user *models.User, category string) bool {
+	return NotificationFrequency(user, category) == NotificationFrequencyImmediate
+}
+
+// NotificationsOff returns true if the user turned the notifications of the category off, the notifications
+// missing from the digests are sent immediately to the users preferring a digest
+func NotificationsOff(user *models.User, category string) bool {
+	return NotificationFrequency(user, category) == NotificationFrequencyOff
+}
+
+// convertDBNotificationPreferences converts the user_notification_preferences map into the service model
+func convertDBNotificationPreferences(preferences map[string]string) *models.NotificationPreferences {
+	if len(preferences) == 0 {
+		return nil
+	}
+	return &models.NotificationPreferences{
+		ClaManagerRequests:   preferences[NotificationCategoryCLAManagerRequests],
+		ApprovalListRequests: preferences[NotificationCategoryApprovalListRequests],
+		RepositoryEvents:     preferences[NotificationCategoryRepositoryEvents],
+	}
+}
+
+// mergeNotificationPreferences returns the existing preferences updated with the categories set in the update, the
+// categories not set keep their existing frequency
+func mergeNotificationPreferences(existing, update *models.NotificationPreferences) map[string]string {
+	merged := map[string]string{}
+	for _, preferences := range []*models.NotificationPreferences{existing, update} {
+		if preferences == nil {
+			continue
+		}
+		for category, frequency := range map[string]string{
+			NotificationCategoryCLAManagerRequests:   preferences.ClaManagerRequests,
+			NotificationCategoryApprovalListRequests: preferences.ApprovalListRequests,
+			NotificationCategoryRepositoryEvents:     preferences.RepositoryEvents,
+		} {
+			if frequency != "" {
+				merged[category] = frequency
+			}
+		}
+	}
+	return merged
+}
+
+// notificationPreferencesAttributeValue returns the preferences as a DynamoDB map attribute
+func notificationPreferencesAttributeValue(preferences map[string]string) *dynamodb.AttributeValue {
+	value := map[string]*dynamodb.AttributeValue{}
+	for category, frequency := range preferences {
+		value[category] = &dynamodb.AttributeValue{S: aws.String(frequency)}
+	}
+	return &dynamodb.AttributeValue{M: value}
+}
@@ -5,6 +5,7 @@ package users
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -40,6 +41,7 @@ type UserRepository interface {
 	GetUserByUserName(userName string, fullMatch bool) (*models.User, error)
 	GetUserByEmail(userEmail string) (*models.User, error)
 	GetUserByGitHubUsername(gitHubUsername string) (*models.User, error)
+	GetUserByGitLabUsername(gitLabUsername string) (*models.User, error)
 	SearchUsers(searchField string, searchTerm string, fullMatch bool) (*models.Users, error)
 }
 
@@ -100,6 +102,18 @@ func (repo repository) CreateUser(user *models.User) (*models.User, error) {
 		}
 	}
 
+	if user.GitlabID != "" {
+		attributes["user_gitlab_id"] = &dynamodb.AttributeValue{
+			S: aws.String(user.GitlabID),
+		}
+	}
+
+	if user.GitlabUsername != "" {
+		attributes["user_gitlab_username"] = &dynamodb.AttributeValue{
+			S: aws.String(user.GitlabUsername),
+		}
+	}
+
 	if user.LfEmail != "" {
 		attributes["lf_email"] = &dynamodb.AttributeValue{
 			S: aws.String(user.LfEmail),
@@ -313,6 +327,37 @@ func (repo repository) Save(user *models.UserUpdate) (*models.User, error) {
 		updateExpression = updateExpression + " #GI = :gi, "
 	}
 
+	if user.GitlabUsername != "" && oldUserModel.GitlabUsername != user.GitlabUsername {
+		log.WithFields(f).Debugf("building query - adding user_gitlab_username: %s", user.GitlabUsername)
+		expressionAttributeNames["#GLU"] = aws.String("user_gitlab_username")
+		expressionAttributeValues[":glu"] = &dynamodb.AttributeValue{S: aws.String(user.GitlabUsername)}
+		updateExpression = updateExpression + " #GLU = :glu, "
+	}
+
+	if user.GitlabID != "" && oldUserModel.GitlabID != user.GitlabID {
+		log.WithFields(f).Debugf("building query - adding user_gitlab_id: %s", user.GitlabID)
+		expressionAttributeNames["#GLI"] = aws.String("user_gitlab_id")
+		expressionAttributeValues[":gli"] = &dynamodb.AttributeValue{S: aws.String(user.GitlabID)}
+		updateExpression = updateExpression + " #GLI = :gli, "
+	}
+
+	if user.PreferredLanguage != "" && oldUserModel.PreferredLanguage != user.PreferredLanguage {
+		log.WithFields(f).Debugf("building query - adding user_preferred_language: %s", user.PreferredLanguage)
+		expressionAttributeNames["#PL"] = aws.String("user_preferred_language")
+		expressionAttributeValues[":pl"] = &dynamodb.AttributeValue{S: aws.String(user.PreferredLanguage)}
+		updateExpression = updateExpression + " #PL = :pl, "
+	}
+
+	if user.NotificationPreferences != nil {
+		preferences := mergeNotificationPreferences(oldUserModel.NotificationPreferences, user.NotificationPreferences)
+		if !reflect.DeepEqual(preferences, mergeNotificationPreferences(oldUserModel.NotificationPreferences, nil)) {
+			log.WithFields(f).Debugf("building query - adding user_notification_preferences: %+v", preferences)
+			expressionAttributeNames["#NP"] = aws.String("user_notification_preferences")
+			expressionAttributeValues[":np"] = notificationPreferencesAttributeValue(preferences)
+			updateExpression = updateExpression + " #NP = :np, "
+		}
+	}
+
 	log.Debugf("building query - updating date_modified: %s", updatedDateTime.Format(time.RFC3339))
 	expressionAttributeNames["#D"] = aws.String("date_modified")
 	expressionAttributeValues[":d"] = &dynamodb.AttributeValue{S: aws.String(updatedDateTime.Format(time.RFC3339))}
@@ -748,6 +793,55 @@ func (repo repository) GetUserByGitHubUsername(gitHubUsername string) (*models.U
 	return convertDBUserModel(dbUserModels[0]), nil
 }
 
+// GetUserByGitLabUsername fetches the user record by gitlab username
+func (repo repository) GetUserByGitLabUsername(gitLabUsername string) (*models.User, error) {
+	f := logrus.Fields{
+		"functionName":   "users.repository.GetUserByGitLabUsername",
+		"gitLabUsername": gitLabUsername,
+	}
+	// This is the key we want to match
+	condition := expression.Key("user_gitlab_username").Equal(expression.Value(gitLabUsername))
+
+	// Use the nice builder to create the expression
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithProjection(buildUserProjection()).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("error building expression for user_gitlab_username : %s, error: %v", gitLabUsername, err)
+		return nil, err
+	}
+
+	// Assemble the query input parameters
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.tableName),
+		IndexName:                 aws.String("gitlab-username-index"),
+	}
+
+	// Make the DynamoDB Query API call
+	result, err := repo.dynamoDBClient.Query(queryInput)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("error retrieving user by user_gitlab_username: %s, error: %+v", gitLabUsername, err)
+		return nil, err
+	}
+
+	var dbUserModels []DBUser
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &dbUserModels)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("error unmarshalling user record from database for user_gitlab_username: %s, error: %+v", gitLabUsername, err)
+		return nil, err
+	}
+
+	if len(dbUserModels) == 0 {
+		return nil, errors.NotFound("user not found when searching by user_gitlab_username: %s", gitLabUsername)
+	} else if len(dbUserModels) > 1 {
+		log.WithFields(f).Warnf("retrieved %d results for the user_gitlab_username query when we should return 0 or 1", len(dbUserModels))
+	}
+
+	return convertDBUserModel(dbUserModels[0]), nil
+}
+
 func (repo repository) SearchUsers(searchField string, searchTerm string, fullMatch bool) (*models.Users, error) {
 	f := logrus.Fields{
 		"functionName": "users.repository.SearchUsers",
@@ -855,20 +949,24 @@ func (repo repository) SearchUsers(searchField string, searchTerm string, fullMa
 // convertDBUserModel translates a dyanamoDB data model into a service response model
 func convertDBUserModel(user DBUser) *models.User {
 	return &models.User{
-		UserID:         user.UserID,
-		UserExternalID: user.UserExternalID,
-		Admin:          user.Admin,
-		LfEmail:        user.LFEmail,
-		LfUsername:     user.LFUsername,
-		DateCreated:    user.DateCreated,
-		DateModified:   user.DateModified,
-		Username:       user.UserName,
-		Version:        user.Version,
-		Emails:         user.UserEmails,
-		GithubID:       user.UserGithubID,
-		CompanyID:      user.UserCompanyID,
-		GithubUsername: user.UserGithubUsername,
-		Note:           user.Note,
+		UserID:                  user.UserID,
+		UserExternalID:          user.UserExternalID,
+		Admin:                   user.Admin,
+		LfEmail:                 user.LFEmail,
+		LfUsername:              user.LFUsername,
+		DateCreated:             user.DateCreated,
+		DateModified:            user.DateModified,
+		Username:                user.UserName,
+		Version:                 user.Version,
+		Emails:                  user.UserEmails,
+		GithubID:                user.UserGithubID,
+		CompanyID:               user.UserCompanyID,
+		GithubUsername:          user.UserGithubUsername,
+		GitlabID:                user.UserGitlabID,
+		GitlabUsername:          user.UserGitlabUsername,
+		Note:                    user.Note,
+		PreferredLanguage:       user.UserPreferredLanguage,
+		NotificationPreferences: convertDBNotificationPreferences(user.UserNotificationPreferences),
 	}
 }
 
@@ -885,10 +983,14 @@ func buildUserProjection() expression.ProjectionBuilder {
 		expression.Name("user_emails"),
 		expression.Name("user_github_username"),
 		expression.Name("user_github_id"),
+		expression.Name("user_gitlab_username"),
+		expression.Name("user_gitlab_id"),
 		expression.Name("date_created"),
 		expression.Name("date_modified"),
 		expression.Name("version"),
 		expression.Name("note"),
+		expression.Name("user_preferred_language"),
+		expression.Name("user_notification_preferences"),
 	)
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
 )
 
 // Service interface for users
@@ -63,6 +64,14 @@ func (s service) CreateUser(user *models.User, claUser *user.CLAUser) (*models.U
 
 // Save saves/updates the user record
 func (s service) Save(user *models.UserUpdate, claUser *user.CLAUser) (*models.User, error) {
+	if user.PreferredLanguage != "" {
+		preferredLanguage, err := utils.NormalizeLanguageTag(user.PreferredLanguage)
+		if err != nil {
+			return nil, err
+		}
+		user.PreferredLanguage = preferredLanguage
+	}
+
 	userModel, err := s.repo.Save(user)
 	if err != nil {
 		return nil, err
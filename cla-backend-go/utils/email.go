@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
@@ -18,9 +19,45 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 )
 
+// email transports selectable in the configuration
+const (
+	EmailTransportSNS  = "sns"
+	EmailTransportSMTP = "smtp"
+	EmailTransportFile = "file"
+)
+
+// roles of the email recipients
+const (
+	EmailRecipientRoleCLAManager         = "cla-manager"
+	EmailRecipientRoleCLAManagerDesignee = "cla-manager-designee"
+	EmailRecipientRoleCompanyManager     = "company-manager"
+	EmailRecipientRoleCompanyAdmin       = "company-admin"
+	EmailRecipientRoleProjectManager     = "project-manager"
+	EmailRecipientRoleContributor        = "contributor"
+	EmailRecipientRoleRequester          = "requester"
+)
+
+// EmailMetadata describes an email independently of its content, the transports pass it along with the message
+type EmailMetadata struct {
+	// TemplateName identifies the email
+	TemplateName string
+	// CLAGroupID the email is about, empty for the company level emails
+	CLAGroupID string
+	// RecipientRole is one of the EmailRecipientRole values
+	RecipientRole string
+}
+
+// Email is an email handed to the email sender
+type Email struct {
+	Subject    string
+	Body       string
+	Recipients []string
+	Metadata   EmailMetadata
+}
+
 // EmailSender contains method to send email
 type EmailSender interface {
-	SendEmail(subject string, body string, recipients []string) error
+	SendEmail(subject string, body string, recipients []string, metadata EmailMetadata) error
 }
 
 var emailSender EmailSender
@@ -35,14 +72,50 @@ func GetEmailSender() EmailSender {
 	return emailSender
 }
 
-// MockEmailSender useful when working with tests
-type MockEmailSender struct{}
+// SetEmailSenderFromConfig sets up the email sender selected in the configuration, SNS is the default
+func SetEmailSenderFromConfig(awsSession *session.Session, cfg config.Config) error {
+	switch cfg.EmailTransport.Type {
+	case "", EmailTransportSNS:
+		SetSnsEmailSender(awsSession, cfg.SNSEventTopicARN, cfg.SenderEmailAddress)
+	case EmailTransportSMTP:
+		sender, err := NewSMTPEmailSender(cfg.EmailTransport.SMTP, cfg.SenderEmailAddress)
+		if err != nil {
+			return err
+		}
+		emailSender = sender
+	case EmailTransportFile:
+		sender, err := NewFileEmailSender(cfg.EmailTransport.Directory, cfg.SenderEmailAddress)
+		if err != nil {
+			return err
+		}
+		emailSender = sender
+	default:
+		return fmt.Errorf("unsupported email transport type : %s", cfg.EmailTransport.Type)
+	}
+	return nil
+}
 
-// SendEmail does nothing
-func (m *MockEmailSender) SendEmail(subject string, body string, recipients []string) error {
+// MockEmailSender useful when working with tests, records the emails instead of sending them
+type MockEmailSender struct {
+	lock   sync.Mutex
+	emails []*Email
+}
+
+// SendEmail records the email
+func (m *MockEmailSender) SendEmail(subject string, body string, recipients []string, metadata EmailMetadata) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.emails = append(m.emails, &Email{Subject: subject, Body: body, Recipients: recipients, Metadata: metadata})
 	return nil
 }
 
+// Emails returns the emails recorded so far
+func (m *MockEmailSender) Emails() []*Email {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]*Email(nil), m.emails...)
+}
+
 type snsEmail struct {
 	snsClient          *sns.SNS
 	snsEventTopicARN   string
@@ -59,14 +132,22 @@ func SetSnsEmailSender(awsSession *session.Session, snsEventTopicARN string, sen
 }
 
 // SendEmail sends an email to the specified recipients
-func (s *snsEmail) SendEmail(subject string, body string, recipients []string) error {
+func (s *snsEmail) SendEmail(subject string, body string, recipients []string, metadata EmailMetadata) error {
 	f := logrus.Fields{
-		"functionName": "utils.SendEmail",
-		"subject":      subject,
-		"recipients":   strings.Join(recipients, ","),
+		"functionName":  "utils.SendEmail",
+		"subject":       subject,
+		"recipients":    strings.Join(recipients, ","),
+		"templateName":  metadata.TemplateName,
+		"claGroupID":    metadata.CLAGroupID,
+		"recipientRole": metadata.RecipientRole,
 	}
 	event := CreateEventWrapper("cla-email-event")
-	event.Data = ToEmailTemplateEvent(&s.senderEmailAddress, recipients, &subject, &body, "EasyCLA System Email Template")
+	emailEvent := ToEmailTemplateEvent(&s.senderEmailAddress, recipients, &subject, &body, "EasyCLA System Email Template")
+	// the system template only renders the body, the metadata is informative for the consumers of the topic
+	emailEvent.Parameters["EASYCLA_TEMPLATE_NAME"] = metadata.TemplateName
+	emailEvent.Parameters["EASYCLA_CLA_GROUP_ID"] = metadata.CLAGroupID
+	emailEvent.Parameters["EASYCLA_RECIPIENT_ROLE"] = metadata.RecipientRole
+	event.Data = emailEvent
 
 	b, err := event.MarshalBinary()
 	if err != nil {
@@ -91,11 +172,11 @@ func (s *snsEmail) SendEmail(subject string, body string, recipients []string) e
 }
 
 // SendEmail function send email. It uses emailSender interface.
-func SendEmail(subject string, body string, recipients []string) error {
+func SendEmail(subject string, body string, recipients []string, metadata EmailMetadata) error {
 	if emailSender == nil {
 		return errors.New("email sender not set")
 	}
-	return emailSender.SendEmail(subject, body, recipients)
+	return emailSender.SendEmail(subject, body, recipients, metadata)
 }
 
 // GetCorporateURL returns the corporate URL based on the specified flag
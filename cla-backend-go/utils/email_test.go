@@ -0,0 +1,138 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func readEmailMessage(t *testing.T, msg []byte) (*mail.Message, string) {
+	message, err := mail.ReadMessage(bytes.NewReader(msg))
+	if err != nil {
+		t.Fatalf("parsing the email message failed : %v", err)
+	}
+	body, err := ioutil.ReadAll(quotedprintable.NewReader(message.Body))
+	if err != nil {
+		t.Fatalf("decoding the email body failed : %v", err)
+	}
+	return message, strings.ReplaceAll(string(body), "\r\n", "\n")
+}
+
+func TestBuildEmailMessage(t *testing.T) {
+	body := "<p>Hello José,</p>\n<p>" + strings.Repeat("This is a notification email from EasyCLA. ", 5) + "</p>"
+	email := &Email{
+		Subject:    "EasyCLA: Approval Request for contributor: José\r\nBcc: someone@example.org",
+		Body:       body,
+		Recipients: []string{"cla.manager@example.org", "Jane Doe <jane.doe@example.org>"},
+		Metadata: EmailMetadata{
+			TemplateName:  "V2ContributorApprovalRequestTemplate",
+			CLAGroupID:    "cla-group-1",
+			RecipientRole: EmailRecipientRoleCLAManager,
+		},
+	}
+
+	msg, err := BuildEmailMessage("admin@example.org", email, time.Date(2021, 6, 15, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	message, decodedBody := readEmailMessage(t, msg)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, email.Subject, subject)
+	assert.Empty(t, message.Header.Get("Bcc"), "the line breaks of the subject are encoded")
+	assert.Equal(t, "<admin@example.org>", message.Header.Get("From"))
+	assert.Equal(t, "<cla.manager@example.org>, \"Jane Doe\" <jane.doe@example.org>", message.Header.Get("To"))
+	assert.Equal(t, "Tue, 15 Jun 2021 10:00:00 +0000", message.Header.Get("Date"))
+	assert.Equal(t, "V2ContributorApprovalRequestTemplate", message.Header.Get(EmailTemplateNameHeader))
+	assert.Equal(t, "cla-group-1", message.Header.Get(EmailCLAGroupIDHeader))
+	assert.Equal(t, EmailRecipientRoleCLAManager, message.Header.Get(EmailRecipientRoleHeader))
+	assert.Equal(t, "text/html; charset=UTF-8", message.Header.Get("Content-Type"))
+	assert.Equal(t, body, decodedBody)
+
+	_, err = BuildEmailMessage("admin@example.org", &Email{Subject: "subject", Body: body, Recipients: []string{"not an email"}}, time.Now())
+	assert.Error(t, err)
+	_, err = BuildEmailMessage("admin@example.org", &Email{Subject: "subject", Body: body}, time.Now())
+	assert.Error(t, err)
+}
+
+func TestFileEmailSender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "email-test")
+	if err != nil {
+		t.Fatalf("creating temp dir failed : %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint
+
+	sender, err := NewFileEmailSender(filepath.Join(dir, "emails"), "")
+	assert.NoError(t, err)
+	err = sender.SendEmail("EasyCLA: Approval List Update", "<p>Hello</p>", []string{"jane.doe@example.org"}, EmailMetadata{
+		TemplateName:  "ApprovalListUpdateToContributorTemplate",
+		CLAGroupID:    "cla-group-1",
+		RecipientRole: EmailRecipientRoleContributor,
+	})
+	assert.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "emails", "*-ApprovalListUpdateToContributorTemplate-*.eml"))
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		msg, readErr := ioutil.ReadFile(files[0])
+		assert.NoError(t, readErr)
+		message, body := readEmailMessage(t, msg)
+		assert.Equal(t, "<"+fileEmailDefaultSender+">", message.Header.Get("From"))
+		assert.Equal(t, "cla-group-1", message.Header.Get(EmailCLAGroupIDHeader))
+		assert.Equal(t, EmailRecipientRoleContributor, message.Header.Get(EmailRecipientRoleHeader))
+		assert.Equal(t, "<p>Hello</p>", body)
+	}
+}
+
+func TestSendEmailWithMockEmailSender(t *testing.T) {
+	previous := GetEmailSender()
+	defer SetEmailSender(previous)
+
+	mock := &MockEmailSender{}
+	SetEmailSender(mock)
+	metadata := EmailMetadata{TemplateName: "RequestToAuthorizeTemplate", CLAGroupID: "cla-group-1", RecipientRole: EmailRecipientRoleCLAManager}
+	assert.NoError(t, SendEmail("EasyCLA: Request to Authorize", "<p>Hello</p>", []string{"cla.manager@example.org"}, metadata))
+
+	emails := mock.Emails()
+	if assert.Len(t, emails, 1) {
+		assert.Equal(t, metadata, emails[0].Metadata)
+		assert.Equal(t, []string{"cla.manager@example.org"}, emails[0].Recipients)
+	}
+}
+
+func TestSetEmailSenderFromConfig(t *testing.T) {
+	previous := GetEmailSender()
+	defer SetEmailSender(previous)
+
+	dir, err := ioutil.TempDir("", "email-test")
+	if err != nil {
+		t.Fatalf("creating temp dir failed : %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint
+
+	assert.NoError(t, SetEmailSenderFromConfig(nil, config.Config{EmailTransport: config.EmailTransport{Type: EmailTransportFile, Directory: dir}}))
+	assert.IsType(t, &fileEmail{}, GetEmailSender())
+
+	assert.NoError(t, SetEmailSenderFromConfig(nil, config.Config{
+		SenderEmailAddress: "admin@example.org",
+		EmailTransport:     config.EmailTransport{Type: EmailTransportSMTP, SMTP: config.SMTP{Host: "localhost"}},
+	}))
+	if assert.IsType(t, &smtpEmail{}, GetEmailSender()) {
+		assert.Equal(t, "localhost:25", GetEmailSender().(*smtpEmail).address)
+	}
+
+	assert.Error(t, SetEmailSenderFromConfig(nil, config.Config{EmailTransport: config.EmailTransport{Type: EmailTransportSMTP}}))
+	assert.Error(t, SetEmailSenderFromConfig(nil, config.Config{EmailTransport: config.EmailTransport{Type: "ses"}}))
+}
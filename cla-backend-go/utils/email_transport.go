@@ -0,0 +1,196 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// headers carrying the email metadata in the messages of the SMTP and file transports
+const (
+	EmailTemplateNameHeader  = "X-EasyCLA-Template"
+	EmailCLAGroupIDHeader    = "X-EasyCLA-CLA-Group"
+	EmailRecipientRoleHeader = "X-EasyCLA-Recipient-Role"
+)
+
+// BuildEmailMessage renders the email as an RFC 5322 HTML message, with the metadata in the X-EasyCLA headers
+func BuildEmailMessage(sender string, email *Email, date time.Time) ([]byte, error) {
+	if len(email.Recipients) == 0 {
+		return nil, errors.New("no recipients")
+	}
+	from, err := mail.ParseAddress(sender)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender email address %s : %v", sender, err)
+	}
+	to := make([]string, len(email.Recipients))
+	for i, recipient := range email.Recipients {
+		address, parseErr := mail.ParseAddress(recipient)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid recipient email address %s : %v", recipient, parseErr)
+		}
+		to[i] = address.String()
+	}
+
+	var msg bytes.Buffer
+	writeHeader := func(name, value string) {
+		if value != "" {
+			// Q-encoding escapes the line breaks along with the non ascii characters
+			msg.WriteString(name + ": " + mime.QEncoding.Encode("utf-8", value) + "\r\n")
+		}
+	}
+	msg.WriteString("From: " + from.String() + "\r\n")
+	msg.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	writeHeader("Subject", email.Subject)
+	msg.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("Message-ID: <" + uuid.New().String() + "@easycla>\r\n")
+	writeHeader(EmailTemplateNameHeader, email.Metadata.TemplateName)
+	writeHeader(EmailCLAGroupIDHeader, email.Metadata.CLAGroupID)
+	writeHeader(EmailRecipientRoleHeader, email.Metadata.RecipientRole)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	msg.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&msg)
+	if _, err = w.Write([]byte(email.Body)); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+type smtpEmail struct {
+	address            string
+	auth               smtp.Auth
+	senderEmailAddress string
+}
+
+// NewSMTPEmailSender returns an email sender delivering the emails to an SMTP server
+func NewSMTPEmailSender(smtpConfig config.SMTP, senderEmailAddress string) (EmailSender, error) {
+	if smtpConfig.Host == "" {
+		return nil, errors.New("missing smtp host")
+	}
+	if senderEmailAddress == "" {
+		return nil, errors.New("missing sender email address")
+	}
+	port := smtpConfig.Port
+	if port == 0 {
+		port = 25
+	}
+	var auth smtp.Auth
+	if smtpConfig.Username != "" {
+		auth = smtp.PlainAuth("", smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
+	}
+	return &smtpEmail{
+		address:            net.JoinHostPort(smtpConfig.Host, strconv.Itoa(port)),
+		auth:               auth,
+		senderEmailAddress: senderEmailAddress,
+	}, nil
+}
+
+// SendEmail sends an email to the specified recipients
+func (s *smtpEmail) SendEmail(subject string, body string, recipients []string, metadata EmailMetadata) error {
+	f := logrus.Fields{
+		"functionName":  "utils.smtpEmail.SendEmail",
+		"subject":       subject,
+		"recipients":    strings.Join(recipients, ","),
+		"templateName":  metadata.TemplateName,
+		"claGroupID":    metadata.CLAGroupID,
+		"recipientRole": metadata.RecipientRole,
+		"smtpServer":    s.address,
+	}
+	msg, err := BuildEmailMessage(s.senderEmailAddress, &Email{Subject: subject, Body: body, Recipients: recipients, Metadata: metadata}, time.Now())
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build the email message")
+		return err
+	}
+
+	// smtp.SendMail upgrades the connection with STARTTLS when the server supports it
+	err = smtp.SendMail(s.address, s.auth, s.senderEmailAddress, recipients, msg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to send the email to the smtp server")
+		return err
+	}
+	log.WithFields(f).Debug("sent the email to the smtp server")
+	return nil
+}
+
+// fileEmailDefaultSender is the sender of the .eml files when the configuration has no sender email address
+const fileEmailDefaultSender = "easycla@localhost"
+
+type fileEmail struct {
+	directory          string
+	senderEmailAddress string
+}
+
+// NewFileEmailSender returns an email sender writing the emails as .eml files to the directory, the directory is
+// created when missing. Useful to run EasyCLA without SNS and to inspect the rendered emails.
+func NewFileEmailSender(directory string, senderEmailAddress string) (EmailSender, error) {
+	if directory == "" {
+		return nil, errors.New("missing email directory")
+	}
+	if err := os.MkdirAll(directory, 0750); err != nil {
+		return nil, err
+	}
+	if senderEmailAddress == "" {
+		senderEmailAddress = fileEmailDefaultSender
+	}
+	return &fileEmail{
+		directory:          directory,
+		senderEmailAddress: senderEmailAddress,
+	}, nil
+}
+
+var emailFileNameRegex = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// SendEmail writes the email to a new .eml file named after the current time and the template name
+func (s *fileEmail) SendEmail(subject string, body string, recipients []string, metadata EmailMetadata) error {
+	f := logrus.Fields{
+		"functionName":  "utils.fileEmail.SendEmail",
+		"subject":       subject,
+		"recipients":    strings.Join(recipients, ","),
+		"templateName":  metadata.TemplateName,
+		"claGroupID":    metadata.CLAGroupID,
+		"recipientRole": metadata.RecipientRole,
+	}
+	now := time.Now().UTC()
+	msg, err := BuildEmailMessage(s.senderEmailAddress, &Email{Subject: subject, Body: body, Recipients: recipients, Metadata: metadata}, now)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to build the email message")
+		return err
+	}
+
+	name := emailFileNameRegex.ReplaceAllString(metadata.TemplateName, "_")
+	if name == "" {
+		name = "email"
+	}
+	fileName := filepath.Join(s.directory, fmt.Sprintf("%s-%s-%s.eml", now.Format("20060102T150405.000000000Z"), name, uuid.New().String()[:8]))
+	if err = ioutil.WriteFile(fileName, msg, 0600); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to write the email file: %s", fileName)
+		return err
+	}
+	log.WithFields(f).Debugf("wrote the email to: %s", fileName)
+	return nil
+}
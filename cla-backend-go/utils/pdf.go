@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
@@ -32,3 +33,30 @@ func WatermarkPdf(pdf []byte, text string) ([]byte, error) {
 
 	return b.Bytes(), nil
 }
+
+// StampPdf adds the given lines of text at the bottom left corner of every page of the pdf blob and returns the
+// new one, the stamp is drawn on top of the page content
+func StampPdf(pdf []byte, lines []string) ([]byte, error) {
+	readSeek := bytes.NewReader(pdf)
+	var b bytes.Buffer
+	outWriter := bufio.NewWriter(&b)
+
+	// on top means it's a stamp
+	onTop := true
+	wm, err := pdfcpu.ParseTextWatermarkDetails(strings.Join(lines, "\n"), "font:Helvetica, points:8, scale:1 abs, pos:bl, off:30 20, rot:0, op:1", onTop)
+	if err != nil {
+		return nil, err
+	}
+
+	err = api.AddWatermarks(readSeek, outWriter, nil, wm, nil)
+	if err != nil {
+		return nil, fmt.Errorf("applying stamp failed : %w", err)
+	}
+
+	err = outWriter.Flush()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// ErrNonPublicAddress is returned when a connection is made to an address which is not public
+var ErrNonPublicAddress = errors.New("the host resolves to an address which is not public")
+
+// nonPublicNetworks are the private, shared and reserved IPv4 and IPv6 address ranges
+var nonPublicNetworks = []string{"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}
+
+// IsPublicIP returns false if the IP address is a loopback, link-local, multicast or unspecified address or belongs
+// to a private network
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, cidr := range nonPublicNetworks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err == nil && network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPublicHost returns false for an empty host, the localhost names and the IP addresses which are not public. A
+// host name may still resolve to an address which is not public, the connections check it with PublicDialControl.
+func IsPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return false
+	}
+	return true
+}
+
+// PublicDialControl is the net.Dialer control function refusing to connect to the resolved addresses which are not
+// public, whatever the host name resolves to
+func PublicDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+	}
+	return nil
+}
@@ -4,7 +4,9 @@
 package utils
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 )
 
 // ValidCompanyName is a routine to indicate if the regex is a valid company name
@@ -28,3 +30,30 @@ func ValidWebsite(website string) bool {
 	r := regexp.MustCompile(`^(?:http(s)?:\/\/)?[\w.-]+(?:\.[\w\.-]+)+[\w\-\._~:/?#[\]@!\$&'\(\)\*\+,;=.]+$`)
 	return r.MatchString(website)
 }
+
+var languageTagRegex = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// NormalizeLanguageTag validates a language tag such as zh-CN, ja or zh_hans_cn and returns it in the canonical
+// BCP 47 case, such as zh-Hans-CN
+func NormalizeLanguageTag(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if !languageTagRegex.MatchString(tag) {
+		return "", fmt.Errorf("invalid language tag: %s, expecting a tag such as en, ja or zh-CN", tag)
+	}
+
+	subtags := strings.FieldsFunc(tag, func(r rune) bool { return r == '-' || r == '_' })
+	subtags[0] = strings.ToLower(subtags[0])
+	for i := 1; i < len(subtags); i++ {
+		switch len(subtags[i]) {
+		case 2:
+			// region
+			subtags[i] = strings.ToUpper(subtags[i])
+		case 4:
+			// script
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + strings.ToLower(subtags[i][1:])
+		default:
+			subtags[i] = strings.ToLower(subtags[i])
+		}
+	}
+	return strings.Join(subtags, "-"), nil
+}
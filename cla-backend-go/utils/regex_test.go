@@ -0,0 +1,29 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLanguageTag(t *testing.T) {
+	for tag, expected := range map[string]string{
+		"en":         "en",
+		"FR":         "fr",
+		"pt-br":      "pt-BR",
+		"zh_hans_cn": "zh-Hans-CN",
+		" ja-JP ":    "ja-JP",
+	} {
+		normalized, err := NormalizeLanguageTag(tag)
+		assert.NoError(t, err, tag)
+		assert.Equal(t, expected, normalized, tag)
+	}
+
+	for _, tag := range []string{"", "e", "english language", "en--US", "en-<b>"} {
+		_, err := NormalizeLanguageTag(tag)
+		assert.Error(t, err, tag)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// TokenLength is the number of random bytes of the API tokens
+	TokenLength = 32
+	// bearerPrefix is the authentication scheme of the API tokens in the Authorization header
+	bearerPrefix = "Bearer "
+	// tokenScopeSeparator separates the scope of a scoped API token from its random part
+	tokenScopeSeparator = "."
+)
+
+// GenerateToken returns a new hex encoded random API token
+func GenerateToken() (string, error) {
+	buf := make([]byte, TokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// GenerateScopedToken returns a new API token carrying its scope (e.g. the CLA Group ID), the scope is checked
+// against the requested resource before the token itself
+func GenerateScopedToken(scope string) (string, error) {
+	token, err := GenerateToken()
+	if err != nil {
+		return "", err
+	}
+	return scope + tokenScopeSeparator + token, nil
+}
+
+// TokenScope returns the scope of a scoped API token, empty if the token has no scope
+func TokenScope(token string) string {
+	idx := strings.LastIndex(token, tokenScopeSeparator)
+	if idx <= 0 {
+		return ""
+	}
+	return token[:idx]
+}
+
+// BearerToken returns the token of the bearer Authorization header value, empty if the header is not a bearer token
+func BearerToken(authorization string) string {
+	token := strings.TrimSpace(authorization)
+	if len(token) < len(bearerPrefix) || !strings.EqualFold(token[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(token[len(bearerPrefix):])
+}
+
+// HashToken returns the hex encoded SHA-256 hash of the token, only the hash of the API tokens is stored
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// TokenMatchesHash returns true if the token matches the stored token hash, an empty token or hash never matches
+func TokenMatchesHash(token, tokenHash string) bool {
+	if token == "" || tokenHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashToken(token)), []byte(tokenHash)) == 1
+}
@@ -0,0 +1,47 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenMatchesHash(t *testing.T) {
+	token, err := GenerateToken()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, token, 2*TokenLength)
+
+	tokenHash := HashToken(token)
+	assert.NotEqual(t, token, tokenHash)
+	assert.True(t, TokenMatchesHash(token, tokenHash))
+	assert.False(t, TokenMatchesHash(token+"0", tokenHash))
+	assert.False(t, TokenMatchesHash("", HashToken("")))
+	assert.False(t, TokenMatchesHash(token, ""))
+}
+
+func TestScopedToken(t *testing.T) {
+	token, err := GenerateScopedToken("b1e86e26-d8c8-4fd8-9f8d-5c723d5dac9f")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "b1e86e26-d8c8-4fd8-9f8d-5c723d5dac9f", TokenScope(token))
+	assert.Equal(t, "", TokenScope("2b1f0e7c9d4a"))
+	assert.Equal(t, "", TokenScope(".2b1f0e7c9d4a"))
+}
+
+func TestBearerToken(t *testing.T) {
+	for authorization, expected := range map[string]string{
+		"Bearer 2b1f0e7c9d4a":      "2b1f0e7c9d4a",
+		" bearer  2b1f0e7c9d4a ":   "2b1f0e7c9d4a",
+		"Basic dXNlcjpwYXNzd29yZA": "",
+		"Bearer":                   "",
+		"":                         "",
+	} {
+		assert.Equal(t, expected, BearerToken(authorization), authorization)
+	}
+}
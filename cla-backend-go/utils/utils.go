@@ -288,6 +288,26 @@ func ValidGitHubOrg(githubOrg string) (string, bool) {
 	return "", true
 }
 
+// ValidGitHubTeam tests the specified GitHub team string in the organization/team-slug format, returns true if
+// valid, returns false otherwise
+func ValidGitHubTeam(githubTeam string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(githubTeam), "/")
+	if len(parts) != 2 {
+		return fmt.Sprintf("invalid GitHub team: %s - expecting the organization/team-slug format", githubTeam), false
+	}
+
+	if msg, valid := ValidGitHubOrg(parts[0]); !valid {
+		return msg, false
+	}
+
+	re := regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+	if !re.MatchString(parts[1]) {
+		return fmt.Sprintf("invalid GitHub team slug: %s", parts[1]), false
+	}
+
+	return "", true
+}
+
 // IsUUIDv4 returns true if the specified ID is in the UUIDv4 format, otherwise returns false
 func IsUUIDv4(id string) bool {
 	value, err := uuid.FromString(id)
@@ -25,7 +25,9 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/cla_group"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	v1Template "github.com/communitybridge/easycla/cla-backend-go/template"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/membership"
 	v2ProjectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
 	v2ProjectServiceClient "github.com/communitybridge/easycla/cla-backend-go/v2/project-service/client/project"
 	v2ProjectServiceModels "github.com/communitybridge/easycla/cla-backend-go/v2/project-service/models"
@@ -33,7 +35,7 @@ import (
 )
 
 // Configure configures the cla group api
-func Configure(api *operations.EasyclaAPI, service Service, v1ProjectService v1Project.Service, projectClaGroupsRepo projects_cla_groups.Repository, eventsService events.Service) { //nolint
+func Configure(api *operations.EasyclaAPI, service Service, v1ProjectService v1Project.Service, projectClaGroupsRepo projects_cla_groups.Repository, eventsService events.Service, membershipService membership.Service) { //nolint
 
 	api.ClaGroupCreateClaGroupHandler = cla_group.CreateClaGroupHandlerFunc(func(params cla_group.CreateClaGroupParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -222,6 +224,184 @@ func Configure(api *operations.EasyclaAPI, service Service, v1ProjectService v1P
 		return cla_group.NewDeleteClaGroupNoContent().WithXRequestID(reqID)
 	})
 
+	api.ClaGroupPublishClaGroupMajorVersionHandler = cla_group.PublishClaGroupMajorVersionHandlerFunc(func(params cla_group.PublishClaGroupMajorVersionParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "cla_groups.handlers.ClaGroupPublishClaGroupMajorVersionHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"authUsername":   params.XUSERNAME,
+			"authEmail":      params.XEMAIL,
+		}
+
+		claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if err != nil {
+			log.WithFields(f).Warn(err)
+			if _, ok := err.(*utils.CLAGroupNotFound); ok || err == v1Project.ErrProjectDoesNotExist {
+				return cla_group.NewPublishClaGroupMajorVersionNotFound().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:       "404",
+					Message:    fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found", params.ClaGroupID),
+					XRequestID: reqID,
+				})
+			}
+			return cla_group.NewPublishClaGroupMajorVersionInternalServerError().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code: "500",
+				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - unable to lookup CLA Group by ID: %s, error: %+v",
+					params.ClaGroupID, err),
+				XRequestID: reqID,
+			})
+		}
+
+		// Check permissions
+		if !isUserHaveAccessToCLAProject(ctx, authUser, claGroupModel.FoundationSFID, []string{claGroupModel.ProjectExternalID}, projectClaGroupsRepo) {
+			msg := fmt.Sprintf("user %s does not have access to publish a new version of the CLA Group with project scope of: %s", authUser.UserName, claGroupModel.FoundationSFID)
+			log.WithFields(f).Warn(msg)
+			return cla_group.NewPublishClaGroupMajorVersionForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.PublishMajorVersion(ctx, claGroupModel, params.Body)
+		if err != nil {
+			log.WithFields(f).Warn(err)
+			if errors.Is(err, ErrBadRequest) || errors.Is(err, v1Template.ErrInvalidTemplateFields) {
+				return cla_group.NewPublishClaGroupMajorVersionBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:       "400",
+					Message:    fmt.Sprintf("EasyCLA - 400 Bad Request - %s", err.Error()),
+					XRequestID: reqID,
+				})
+			}
+			return cla_group.NewPublishClaGroupMajorVersionInternalServerError().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code:       "500",
+				Message:    fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
+				XRequestID: reqID,
+			})
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:     events.CLAGroupMajorVersionPublished,
+			ClaGroupModel: claGroupModel,
+			LfUsername:    authUser.UserName,
+			EventData: &events.CLAGroupMajorVersionPublishedEventData{
+				MajorVersion:              result.MajorVersion,
+				ResignDeadline:            result.ResignDeadline,
+				SignaturesRequiringResign: result.SignaturesRequiringResign,
+			},
+		})
+
+		return cla_group.NewPublishClaGroupMajorVersionOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.ClaGroupCreateMembershipCheckAPIKeyHandler = cla_group.CreateMembershipCheckAPIKeyHandlerFunc(func(params cla_group.CreateMembershipCheckAPIKeyParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "cla_groups.handlers.ClaGroupCreateMembershipCheckAPIKeyHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"authUsername":   params.XUSERNAME,
+			"authEmail":      params.XEMAIL,
+		}
+
+		claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if err != nil {
+			log.WithFields(f).Warn(err)
+			if _, ok := err.(*utils.CLAGroupNotFound); ok || err == v1Project.ErrProjectDoesNotExist {
+				return cla_group.NewCreateMembershipCheckAPIKeyNotFound().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:       "404",
+					Message:    fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found", params.ClaGroupID),
+					XRequestID: reqID,
+				})
+			}
+			return cla_group.NewCreateMembershipCheckAPIKeyInternalServerError().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code: "500",
+				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - unable to lookup CLA Group by ID: %s, error: %+v",
+					params.ClaGroupID, err),
+				XRequestID: reqID,
+			})
+		}
+
+		// Check permissions
+		if !isUserHaveAccessToCLAProject(ctx, authUser, claGroupModel.FoundationSFID, []string{claGroupModel.ProjectExternalID}, projectClaGroupsRepo) {
+			msg := fmt.Sprintf("user %s does not have access to create the membership check API key of the CLA Group with project scope of: %s", authUser.UserName, claGroupModel.FoundationSFID)
+			log.WithFields(f).Warn(msg)
+			return cla_group.NewCreateMembershipCheckAPIKeyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		apiKey, err := membershipService.CreateAPIKey(ctx, params.ClaGroupID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to create the membership check API key of CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return cla_group.NewCreateMembershipCheckAPIKeyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:     events.CLAGroupMembershipCheckAPIKeyCreated,
+			ClaGroupModel: claGroupModel,
+			LfUsername:    authUser.UserName,
+			EventData:     &events.CLAGroupMembershipCheckAPIKeyCreatedEventData{},
+		})
+
+		return cla_group.NewCreateMembershipCheckAPIKeyOK().WithXRequestID(reqID).WithPayload(&models.MembershipCheckAPIKey{
+			ClaGroupID: params.ClaGroupID,
+			APIKey:     apiKey,
+		})
+	})
+
+	api.ClaGroupDeleteMembershipCheckAPIKeyHandler = cla_group.DeleteMembershipCheckAPIKeyHandlerFunc(func(params cla_group.DeleteMembershipCheckAPIKeyParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "cla_groups.handlers.ClaGroupDeleteMembershipCheckAPIKeyHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"authUsername":   params.XUSERNAME,
+			"authEmail":      params.XEMAIL,
+		}
+
+		claGroupModel, err := v1ProjectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if err != nil {
+			log.WithFields(f).Warn(err)
+			if _, ok := err.(*utils.CLAGroupNotFound); ok || err == v1Project.ErrProjectDoesNotExist {
+				return cla_group.NewDeleteMembershipCheckAPIKeyNotFound().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:       "404",
+					Message:    fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found", params.ClaGroupID),
+					XRequestID: reqID,
+				})
+			}
+			return cla_group.NewDeleteMembershipCheckAPIKeyInternalServerError().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+				Code: "500",
+				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - unable to lookup CLA Group by ID: %s, error: %+v",
+					params.ClaGroupID, err),
+				XRequestID: reqID,
+			})
+		}
+
+		// Check permissions
+		if !isUserHaveAccessToCLAProject(ctx, authUser, claGroupModel.FoundationSFID, []string{claGroupModel.ProjectExternalID}, projectClaGroupsRepo) {
+			msg := fmt.Sprintf("user %s does not have access to revoke the membership check API key of the CLA Group with project scope of: %s", authUser.UserName, claGroupModel.FoundationSFID)
+			log.WithFields(f).Warn(msg)
+			return cla_group.NewDeleteMembershipCheckAPIKeyForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		if err := membershipService.RevokeAPIKey(ctx, params.ClaGroupID); err != nil {
+			msg := fmt.Sprintf("unable to revoke the membership check API key of CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return cla_group.NewDeleteMembershipCheckAPIKeyInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:     events.CLAGroupMembershipCheckAPIKeyRevoked,
+			ClaGroupModel: claGroupModel,
+			LfUsername:    authUser.UserName,
+			EventData:     &events.CLAGroupMembershipCheckAPIKeyRevokedEventData{},
+		})
+
+		return cla_group.NewDeleteMembershipCheckAPIKeyNoContent().WithXRequestID(reqID)
+	})
+
 	api.ClaGroupEnrollProjectsHandler = cla_group.EnrollProjectsHandlerFunc(func(params cla_group.EnrollProjectsParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
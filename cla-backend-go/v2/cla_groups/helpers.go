@@ -7,9 +7,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
@@ -20,6 +22,12 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// re-signature grace period bounds when a new major version is published
+const (
+	defaultResignGracePeriodDays = 30
+	maxResignGracePeriodDays     = 365
+)
+
 // validateClaGroupInput validates the cla group input. It there is validation error then it returns the error
 // if foundation_sfid is root project i.e project without parent and if it does not have subprojects then return boolean
 // flag would be true
@@ -609,3 +617,16 @@ func isFoundationIDInList(foundationSFID string, projectsSFIDs []string) bool {
 	}
 	return false
 }
+
+// latestDocumentMajorVersion returns the highest major version of the CLA Group ICLA and CCLA documents
+func latestDocumentMajorVersion(claGroupModel *v1Models.ClaGroup) int64 {
+	var latest int64
+	documents := append(append([]v1Models.ClaGroupDocument{}, claGroupModel.ProjectIndividualDocuments...), claGroupModel.ProjectCorporateDocuments...)
+	for _, doc := range documents {
+		majorVersion, err := strconv.ParseInt(doc.DocumentMajorVersion, 10, 64)
+		if err == nil && majorVersion > latest {
+			latest = majorVersion
+		}
+	}
+	return latest
+}
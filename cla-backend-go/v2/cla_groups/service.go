@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 
@@ -40,6 +41,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// ErrBadRequest is wrapped by the errors caused by an invalid major version input, the handler responds with a 400
+var ErrBadRequest = errors.New("bad request")
+
 type service struct {
 	v1ProjectService      v1Project.Service
 	v1TemplateService     v1Template.Service
@@ -66,6 +70,7 @@ type Service interface {
 	EnableCLAService(ctx context.Context, projectSFIDList []string) error
 	DisableCLAService(ctx context.Context, projectSFIDList []string) error
 	ValidateCLAGroup(ctx context.Context, input *models.ClaGroupValidationRequest) (bool, []string)
+	PublishMajorVersion(ctx context.Context, claGroupModel *v1Models.ClaGroup, input *models.PublishClaGroupMajorVersionInput) (*models.PublishClaGroupMajorVersionOutput, error)
 }
 
 // NewService returns instance of CLA group service
@@ -1020,3 +1025,56 @@ func Find(slice []v1Models.ClaGroup, val string) (int, bool) {
 	}
 	return -1, false
 }
+
+// PublishMajorVersion generates the CLA Group documents as the next major version and requires the signers of the
+// previous major versions to re-sign within the grace period
+func (s *service) PublishMajorVersion(ctx context.Context, claGroupModel *v1Models.ClaGroup, input *models.PublishClaGroupMajorVersionInput) (*models.PublishClaGroupMajorVersionOutput, error) {
+	f := logrus.Fields{
+		"functionName":   "cla_groups.PublishMajorVersion",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupModel.ProjectID,
+	}
+
+	gracePeriodDays := int64(defaultResignGracePeriodDays)
+	if input.GracePeriodDays != nil {
+		gracePeriodDays = *input.GracePeriodDays
+	}
+	if gracePeriodDays < 0 || gracePeriodDays > maxResignGracePeriodDays {
+		return nil, fmt.Errorf("%w: grace period must be between 0 and %d days", ErrBadRequest, maxResignGracePeriodDays)
+	}
+
+	var templateFields v1Models.CreateClaGroupTemplate
+	err := copier.Copy(&templateFields, input.TemplateFields)
+	if err != nil {
+		return nil, err
+	}
+	if templateFields.TemplateID == "" {
+		log.WithFields(f).Debug("using apache style template as template_id is not passed")
+		templateFields.TemplateID = v1Template.ApacheStyleTemplateID
+	}
+
+	majorVersion := latestDocumentMajorVersion(claGroupModel) + 1
+	f["majorVersion"] = majorVersion
+	log.WithFields(f).Debug("generating the documents of the new major version...")
+	pdfUrls, err := s.v1TemplateService.CreateCLAGroupTemplateVersion(ctx, claGroupModel.ProjectID, &templateFields, majorVersion, 0)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate the documents of the new major version")
+		return nil, err
+	}
+
+	deadline := time.Now().UTC().AddDate(0, 0, int(gracePeriodDays))
+	count, err := s.signatureService.RequireResignature(ctx, claGroupModel, int(majorVersion), deadline)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to mark the signatures of the previous major versions, %d marked", count)
+		return nil, err
+	}
+
+	return &models.PublishClaGroupMajorVersionOutput{
+		ClaGroupID:                claGroupModel.ProjectID,
+		MajorVersion:              majorVersion,
+		ResignDeadline:            utils.TimeToString(deadline),
+		SignaturesRequiringResign: int64(count),
+		IclaPdfURL:                pdfUrls.IndividualPDFURL,
+		CclaPdfURL:                pdfUrls.CorporatePDFURL,
+	}, nil
+}
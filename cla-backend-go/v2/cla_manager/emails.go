@@ -6,6 +6,8 @@ package cla_manager
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path"
 	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/emails"
@@ -13,6 +15,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	easyCLAUser "github.com/communitybridge/easycla/cla-backend-go/users"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	v2AcsService "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
 	"github.com/sirupsen/logrus"
@@ -45,29 +48,32 @@ func (s *service) SendEmailToCLAManager(ctx context.Context, input *EmailToCLAMa
 		"claGroupName":              input.CLAGroupName,
 	}
 
-	subject := fmt.Sprintf("EasyCLA: Approval Request for contributor: %s", getBestUserName(input.Contributor))
-	recipients := []string{input.CLAManagerEmail}
-	body, err := emails.RenderTemplate(
-		utils.V2, emails.V2ContributorApprovalRequestTemplateName,
-		emails.V2ContributorApprovalRequestTemplate,
-		emails.V2ContributorApprovalRequestTemplateParams{
-			CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
-				RecipientName: input.CLAGroupName,
-				CompanyName:   input.CompanyName,
-				CLAGroupName:  input.CLAGroupName,
-			},
-			SigningEntityName:     input.CompanyName,
-			UserDetails:           getFormattedUserDetails(input.Contributor),
-			CorporateConsoleV2URL: input.CorporateConsoleURL,
+	// the CLA Manager reads the email in their preferred language
+	params := emails.V2ContributorApprovalRequestTemplateParams{
+		CLAManagerTemplateParams: emails.CLAManagerTemplateParams{
+			RecipientName: input.CLAManagerName,
+			CompanyName:   input.CompanyName,
+			CLAGroupName:  input.CLAGroupName,
 		},
-	)
+		SigningEntityName:     input.CompanyName,
+		UserDetails:           getFormattedUserDetails(input.Contributor),
+		CorporateConsoleV2URL: input.CorporateConsoleURL,
+	}
+	if claManager := s.easyCLAUserByEmail(input.CLAManagerEmail); claManager != nil {
+		params.RecipientLanguage = claManager.PreferredLanguage
+	}
+
+	subject := emails.RenderSubject(emails.V2ContributorApprovalRequestTemplateName,
+		fmt.Sprintf("EasyCLA: Approval Request for contributor: %s", getBestUserName(input.Contributor)), params)
+	recipients := []string{input.CLAManagerEmail}
+	body, err := emails.RenderTemplate(utils.V2, emails.V2ContributorApprovalRequestTemplateName, emails.V2ContributorApprovalRequestTemplate, params)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("rendering email template: %s", emails.V2ContributorApprovalRequestTemplateName)
 		return
 	}
 
 	log.WithFields(f).Debugf("sending email with subject: %s to recipients: %+v...", subject, recipients)
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.V2ContributorApprovalRequestTemplateName, RecipientRole: utils.EmailRecipientRoleCLAManager})
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -75,6 +81,46 @@ func (s *service) SendEmailToCLAManager(ctx context.Context, input *EmailToCLAMa
 	}
 }
 
+// notificationsOff returns true if the EasyCLA user of the email turned the notifications of the category off. The
+// v2 CLA Manager requests are not stored as pending requests, the digests don't list them, so the users preferring a
+// digest are notified immediately.
+func (s *service) notificationsOff(email, category string) bool {
+	userModel := s.easyCLAUserByEmail(email)
+	if userModel == nil {
+		return false
+	}
+	return easyCLAUser.NotificationsOff(userModel, category)
+}
+
+// easyCLAUserByEmail returns the EasyCLA user of the email, nil if there is none - the email recipients without an
+// EasyCLA user get the default notification preferences and language
+func (s *service) easyCLAUserByEmail(email string) *v1Models.User {
+	if s.easyCLAUserService == nil || email == "" {
+		return nil
+	}
+	userModel, err := s.easyCLAUserService.GetUserByEmail(email)
+	if err != nil {
+		log.WithError(err).Debugf("unable to lookup the EasyCLA user of: %s", email)
+		return nil
+	}
+	return userModel
+}
+
+// GetCorporateConsoleCLAURL returns the corporate console page of the project CLA, or the foundation CLA page when
+// the CLA is signed at the foundation level, the console home page when the foundation is not known
+func GetCorporateConsoleCLAURL(corporateConsole, foundationSFID, projectSFID string) string {
+	u, err := url.Parse(corporateConsole)
+	if err != nil || foundationSFID == "" {
+		return corporateConsole
+	}
+	if projectSFID == "" || projectSFID == foundationSFID {
+		u.Path = path.Join(u.Path, "foundation", foundationSFID, "cla")
+	} else {
+		u.Path = path.Join(u.Path, "foundation", foundationSFID, "project", projectSFID, "cla")
+	}
+	return u.String()
+}
+
 // SendEmailToOrgAdmin sends an email to the organization admin
 func (s *service) SendEmailToOrgAdmin(ctx context.Context, repository projects_cla_groups.Repository, projectService project.Service, adminEmail string, adminName string, companyName string, projectName, projectSFID string, senderEmail string, senderName string, corporateConsole string) {
 	f := logrus.Fields{
@@ -106,7 +152,7 @@ func (s *service) SendEmailToOrgAdmin(ctx context.Context, repository projects_c
 		log.WithFields(f).WithError(err).Warnf("rendering email template : %s failed : %v", emails.V2OrgAdminTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.V2OrgAdminTemplateName, RecipientRole: utils.EmailRecipientRoleCompanyAdmin})
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -144,7 +190,7 @@ func (s *service) ContributorEmailToOrgAdmin(ctx context.Context, repository pro
 		log.WithFields(f).WithError(err).Warnf("rendering template : %s failed : %v", emails.V2ContributorToOrgAdminTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.V2ContributorToOrgAdminTemplateName, RecipientRole: utils.EmailRecipientRoleCompanyAdmin})
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -180,7 +226,7 @@ func (s *service) SendEmailToCLAManagerDesigneeCorporate(ctx context.Context, re
 		log.WithFields(f).WithError(err).Warnf("rendering template : %s : failed: %v", emails.V2CLAManagerDesigneeCorporateTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.V2CLAManagerDesigneeCorporateTemplateName, RecipientRole: utils.EmailRecipientRoleCLAManagerDesignee})
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -216,7 +262,7 @@ func (s *service) SendEmailToCLAManagerDesignee(ctx context.Context, repository
 		log.WithFields(f).WithError(err).Warnf("rendering template : %s failed : %v", emails.V2ToCLAManagerDesigneeTemplateName, err)
 		return
 	}
-	err = utils.SendEmail(subject, body, recipients)
+	err = utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: emails.V2ToCLAManagerDesigneeTemplateName, RecipientRole: utils.EmailRecipientRoleCLAManagerDesignee})
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -719,6 +719,10 @@ func (s *service) CreateCLAManagerRequest(ctx context.Context, contactAdmin bool
 		}
 
 		for _, admin := range scopes.Userroles {
+			if s.notificationsOff(admin.Contact.EmailAddress, easyCLAUser.NotificationCategoryCLAManagerRequests) {
+				log.WithFields(f).Debugf("organization admin: %s turned the CLA Manager request notifications off", admin.Contact.EmailAddress)
+				continue
+			}
 			log.WithFields(f).Debugf("sending email to organization admin: %+v", admin)
 			s.SendEmailToOrgAdmin(ctx, s.projectCGRepo, s.projectService, admin.Contact.EmailAddress, admin.Contact.Name, v1CompanyModel.CompanyName, projectSF.Name, projectSF.ID, authUser.Email, authUser.UserName, LfxPortalURL)
 			// Make a note in the event log
@@ -785,6 +789,11 @@ func (s *service) CreateCLAManagerRequest(ctx context.Context, contactAdmin bool
 		},
 	})
 
+	if s.notificationsOff(userEmail, easyCLAUser.NotificationCategoryCLAManagerRequests) {
+		log.WithFields(f).Debugf("CLA Manager designee created: %+v, the designee turned the CLA Manager request notifications off", claManagerDesignee)
+		return claManagerDesignee, nil
+	}
+
 	log.WithFields(f).Debugf("sending Email to CLA Manager Designee email: %s ", userEmail)
 	designeeName := fmt.Sprintf("%s %s", lfxUser.FirstName, lfxUser.LastName)
 	s.SendEmailToCLAManagerDesigneeCorporate(ctx, s.projectCGRepo, s.projectService, corporateConsole, v1CompanyModel.CompanyName, projectSF.ID, projectSF.Name, userEmail, designeeName, authUser.Email, authUser.UserName)
@@ -1115,6 +1124,11 @@ func (s *service) NotifyCLAManagers(ctx context.Context, notifyCLAManagers *mode
 
 	log.Debugf("Sending notification emails to CLA Managers: %+v", notifyCLAManagers.List)
 	for _, claManager := range notifyCLAManagers.List {
+		// the CLA Managers preferring a digest get the request in their digest, from the event logged below
+		if !easyCLAUser.NotifyImmediately(s.easyCLAUserByEmail(claManager.Email.String()), easyCLAUser.NotificationCategoryApprovalListRequests) {
+			log.WithFields(f).Debugf("CLA Manager: %s does not receive the approval request notifications immediately", claManager.Email.String())
+			continue
+		}
 		s.SendEmailToCLAManager(ctx, &EmailToCLAManagerModel{
 			Contributor:         userModel,
 			CLAManagerName:      claManager.Name,
@@ -1125,9 +1139,51 @@ func (s *service) NotifyCLAManagers(ctx context.Context, notifyCLAManagers *mode
 		})
 	}
 
+	s.logNotifyCLAManagersEvent(ctx, notifyCLAManagers, userModel)
 	return nil
 }
 
+// logNotifyCLAManagersEvent records the approval request sent to the CLA Managers, the notification digests list the
+// approval requests of the CLA Group from these events
+func (s *service) logNotifyCLAManagersEvent(ctx context.Context, notifyCLAManagers *models.NotifyClaManagerList, contributor *v1Models.User) {
+	f := logrus.Fields{
+		"functionName":      "cla_manager.service.logNotifyCLAManagersEvent",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"companyName":       notifyCLAManagers.CompanyName,
+		"signingEntityName": notifyCLAManagers.SigningEntityName,
+		"claGroupName":      notifyCLAManagers.ClaGroupName,
+	}
+
+	claGroupModel, err := s.projectService.GetCLAGroupByName(ctx, notifyCLAManagers.ClaGroupName)
+	if err != nil || claGroupModel == nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the CLA Group of the approval request - skipping event")
+		return
+	}
+	signingEntityName := notifyCLAManagers.SigningEntityName
+	if signingEntityName == "" {
+		signingEntityName = notifyCLAManagers.CompanyName
+	}
+	companyModel, err := s.companyService.GetCompanyBySigningEntityName(ctx, signingEntityName, "")
+	if err != nil || companyModel == nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the company of the approval request - skipping event")
+		return
+	}
+
+	var claManagerEmails []string
+	for _, claManager := range notifyCLAManagers.List {
+		claManagerEmails = append(claManagerEmails, claManager.Email.String())
+	}
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType:     events.ContributorNotifyCLAManagersType,
+		ClaGroupModel: claGroupModel,
+		CompanyModel:  companyModel,
+		UserModel:     contributor,
+		EventData: &events.ContributorNotifyCLAManagersData{
+			CLAManagerEmails: claManagerEmails,
+		},
+	})
+}
+
 // getBestUserName is a helper function to extract what information we can from the user record for purposes of displaying the user's name
 func getBestUserName(model *v1Models.User) string {
 	if model.Username != "" {
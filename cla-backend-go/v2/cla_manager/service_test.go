@@ -52,3 +52,14 @@ func TestGetNonNoReplyUserEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCorporateConsoleCLAURL(t *testing.T) {
+	console := "https://corporate.v2.dev.lfcla.com"
+	assert.Equal(t, "https://corporate.v2.dev.lfcla.com/foundation/foundation-sfid/project/project-sfid/cla",
+		GetCorporateConsoleCLAURL(console, "foundation-sfid", "project-sfid"))
+	assert.Equal(t, "https://corporate.v2.dev.lfcla.com/foundation/foundation-sfid/cla",
+		GetCorporateConsoleCLAURL(console, "foundation-sfid", "foundation-sfid"))
+	assert.Equal(t, "https://corporate.v2.dev.lfcla.com/foundation/foundation-sfid/cla",
+		GetCorporateConsoleCLAURL(console, "foundation-sfid", ""))
+	assert.Equal(t, console, GetCorporateConsoleCLAURL(console, "", "project-sfid"))
+}
@@ -1681,6 +1681,9 @@ func (s *service) GetCompanyLookup(ctx context.Context, orgName string, websiteN
 	return result, nil
 }
 
+// RequestCompanyAdminTemplateName is the name of the email asking the proposed CLA Manager to start the CLA signature process
+const RequestCompanyAdminTemplateName = "RequestCompanyAdminTemplate"
+
 func (s *service) RequestCompanyAdmin(ctx context.Context, userID string, claManagerEmail string, claManagerName string, contributorName string, contributorEmail string, projectName string, companyName string, corporateLink string) error {
 	orgServices := orgService.GetClient()
 	f := logrus.Fields{
@@ -1731,7 +1734,7 @@ func (s *service) RequestCompanyAdmin(ctx context.Context, userID string, claMan
 		contributorEmail, companyName, projectName, projectName,
 		projectName, projectName, corporateLink,
 		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: RequestCompanyAdminTemplateName, RecipientRole: utils.EmailRecipientRoleCLAManagerDesignee})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
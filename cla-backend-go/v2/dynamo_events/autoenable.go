@@ -19,6 +19,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
 	"github.com/go-openapi/swag"
 	"github.com/google/go-github/v33/github"
 	"github.com/sirupsen/logrus"
@@ -44,6 +45,7 @@ func NewAutoEnableService(repositoryService repositories.Service,
 	githubOrgRepo github_organizations.Repository,
 	claRepository projects_cla_groups.Repository,
 	claService project.Service,
+	usersRepo users.UserRepository,
 ) AutoEnableService {
 	return &autoEnableServiceProvider{
 		repositoryService: repositoryService,
@@ -51,6 +53,7 @@ func NewAutoEnableService(repositoryService repositories.Service,
 		githubOrgRepo:     githubOrgRepo,
 		claRepository:     claRepository,
 		claService:        claService,
+		usersRepo:         usersRepo,
 	}
 }
 
@@ -62,6 +65,7 @@ type autoEnableServiceProvider struct {
 	githubOrgRepo     github_organizations.Repository
 	claRepository     projects_cla_groups.Repository
 	claService        project.Service
+	usersRepo         users.UserRepository
 }
 
 func (a *autoEnableServiceProvider) CreateAutoEnabledRepository(repo *github.Repository) (*models.GithubRepository, error) {
@@ -181,6 +185,9 @@ func (a *autoEnableServiceProvider) AutoEnabledForGithubOrg(f logrus.Fields, git
 	return nil
 }
 
+// AutoEnabledRepositoryTemplateName is the name of the email notifying the project managers of the repositories enabled automatically
+const AutoEnabledRepositoryTemplateName = "AutoEnabledRepositoryTemplate"
+
 func (a *autoEnableServiceProvider) NotifyCLAManagerForRepos(claGroupID string, repos []*models.GithubRepository) error {
 	if len(repos) == 0 {
 		log.Warnf("NotifyCLAManagerForRepos no repos to notify for, can't continue")
@@ -198,6 +205,13 @@ func (a *autoEnableServiceProvider) NotifyCLAManagerForRepos(claGroupID string,
 		return nil
 	}
 
+	// the managers receiving a digest get the added repositories in the digest
+	claManagers = a.immediateRecipients(claManagers)
+	if len(claManagers) == 0 {
+		log.Debugf("no cla managers of the claGroup : %s are notified immediately of the repository events", claGroupID)
+		return nil
+	}
+
 	claGroupModel, err := a.claService.GetCLAGroupByID(context.Background(), claGroupID)
 	if err != nil {
 		log.Warnf("loading claGroupModel : %s failed : %v", claGroupID, err)
@@ -212,7 +226,7 @@ func (a *autoEnableServiceProvider) NotifyCLAManagerForRepos(claGroupID string,
 	}
 
 	log.Debugf("sending email with subject : %s for claGroup : %s for recipients : %+v", subject, claGroupModel.ProjectName, recipients)
-	if err := utils.SendEmail(subject, body, recipients); err != nil {
+	if err := utils.SendEmail(subject, body, recipients, utils.EmailMetadata{TemplateName: AutoEnabledRepositoryTemplateName, CLAGroupID: claGroupID, RecipientRole: utils.EmailRecipientRoleProjectManager}); err != nil {
 		log.Warnf("sending email for subject : %s and claGroup : %s failed : %v", subject, claGroupModel.ProjectName, err)
 		return err
 	}
@@ -220,6 +234,26 @@ func (a *autoEnableServiceProvider) NotifyCLAManagerForRepos(claGroupID string,
 	return nil
 }
 
+// immediateRecipients returns the CLA managers notified of the repository events as they happen, the managers
+// without an EasyCLA user record are notified
+func (a *autoEnableServiceProvider) immediateRecipients(managers []*models.ClaManagerUser) []*models.ClaManagerUser {
+	if a.usersRepo == nil {
+		return managers
+	}
+
+	var recipients []*models.ClaManagerUser
+	for _, manager := range managers {
+		if manager.UserLFID != "" {
+			userModel, err := a.usersRepo.GetUserByLFUserName(manager.UserLFID)
+			if err == nil && !users.NotifyImmediately(userModel, users.NotificationCategoryRepositoryEvents) {
+				continue
+			}
+		}
+		recipients = append(recipients, manager)
+	}
+	return recipients
+}
+
 // autoEnabledRepositoryEmailContent prepares the email for autoEnabled repositories
 func autoEnabledRepositoryEmailContent(claGroupModel *models.ClaGroup, orgName string, managers []*models.ClaManagerUser, repos []*models.GithubRepository) (string, string, []string) {
 	claGroupName := claGroupModel.ProjectName
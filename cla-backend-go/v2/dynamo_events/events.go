@@ -5,6 +5,7 @@ package dynamo_events
 
 import (
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	v2ProjectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
@@ -13,9 +14,19 @@ import (
 
 // Event data model
 type Event struct {
-	EventID        string `json:"event_id"`
-	EventProjectID string `json:"event_project_id"`
-	EventCompanyID string `json:"event_company_id"`
+	EventID          string `json:"event_id"`
+	EventType        string `json:"event_type"`
+	EventProjectID   string `json:"event_project_id"`
+	EventProjectName string `json:"event_project_name"`
+	// EventProjectExternalID is the project SFID of the v2 events, they are logged without the CLA Group ID
+	EventProjectExternalID string `json:"event_project_external_id"`
+	EventCompanyID         string `json:"event_company_id"`
+	EventCompanyName       string `json:"event_company_name"`
+	EventLfUsername        string `json:"event_lf_username"`
+	EventTime              string `json:"event_time"`
+	EventTimeEpoch         int64  `json:"event_time_epoch"`
+	EventData              string `json:"event_data"`
+	EventSummary           string `json:"event_summary"`
 }
 
 // should be called when we insert Event
@@ -27,6 +38,15 @@ func (s *service) EventAddedEvent(event events.DynamoDBEventRecord) error {
 		return err
 	}
 	f := logrus.Fields{"event": newEvent}
+	claGroupID, claGroupName := newEvent.EventProjectID, newEvent.EventProjectName
+	if claGroupID == "" && newEvent.EventProjectExternalID != "" {
+		pcg, pcgErr := s.projectsClaGroupRepo.GetClaGroupIDForProject(newEvent.EventProjectExternalID)
+		if pcgErr != nil {
+			log.WithFields(f).WithError(pcgErr).Warn("unable to get the CLA Group of the project")
+		} else {
+			claGroupID, claGroupName = pcg.ClaGroupID, pcg.ClaGroupName
+		}
+	}
 	var foundationSFID, projectSFID, projectSFName, companySFID string
 	companyModel, err := s.companyRepo.GetCompany(ctx, newEvent.EventCompanyID)
 	if err != nil {
@@ -34,7 +54,7 @@ func (s *service) EventAddedEvent(event events.DynamoDBEventRecord) error {
 	} else {
 		companySFID = companyModel.CompanyExternalID
 	}
-	pmList, err := s.projectsClaGroupRepo.GetProjectsIdsForClaGroup(newEvent.EventProjectID)
+	pmList, err := s.projectsClaGroupRepo.GetProjectsIdsForClaGroup(claGroupID)
 	if err != nil || len(pmList) == 0 {
 		log.WithFields(f).Error("unable to get project mapping detail", err)
 	} else {
@@ -54,9 +74,33 @@ func (s *service) EventAddedEvent(event events.DynamoDBEventRecord) error {
 			projectSFName = pmList[0].ProjectName
 		}
 	}
-	err = s.eventsRepo.AddDataToEvent(newEvent.EventID, foundationSFID, projectSFID, projectSFName, companySFID, newEvent.EventProjectID)
+	err = s.eventsRepo.AddDataToEvent(newEvent.EventID, foundationSFID, projectSFID, projectSFName, companySFID, claGroupID)
 	if err != nil {
 		return err
 	}
+
+	if s.eventDispatcher != nil {
+		dispatchErr := s.eventDispatcher.DispatchEvent(ctx, &models.Event{
+			EventID:             newEvent.EventID,
+			EventType:           newEvent.EventType,
+			EventProjectID:      claGroupID,
+			EventProjectName:    claGroupName,
+			EventCompanyID:      newEvent.EventCompanyID,
+			EventCompanyName:    newEvent.EventCompanyName,
+			LfUsername:          newEvent.EventLfUsername,
+			EventTime:           newEvent.EventTime,
+			EventTimeEpoch:      newEvent.EventTimeEpoch,
+			EventData:           newEvent.EventData,
+			EventSummary:        newEvent.EventSummary,
+			EventFoundationSFID: foundationSFID,
+			EventProjectSFID:    projectSFID,
+			EventProjectSFName:  projectSFName,
+			EventCompanySFID:    companySFID,
+		})
+		if dispatchErr != nil {
+			// the deliveries are not retried from the stream, the event is not delivered to the webhooks and the chat channels
+			log.WithFields(f).WithError(dispatchErr).Warn("unable to dispatch the event to the webhooks and the chat channels")
+		}
+	}
 	return nil
 }
@@ -4,6 +4,7 @@
 package dynamo_events
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -21,6 +22,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/cla_manager"
 
 	claevent "github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
@@ -64,6 +66,34 @@ type service struct {
 	autoEnableService        *autoEnableServiceProvider
 	claManagerRequestsRepo   cla_manager.IRepository
 	approvalListRequestsRepo approval_list.IRepository
+	pullRequestChecker       PullRequestChecker
+	eventDispatcher          EventDispatcher
+	resignatureHandler       ResignatureHandler
+}
+
+// PullRequestChecker re-runs the CLA check on the open pull requests of a CLA Group
+type PullRequestChecker interface {
+	RecheckPullRequests(ctx context.Context, claGroupID string, authors *PullRequestAuthors) error
+}
+
+// PullRequestAuthors selects the commit authors whose open pull requests are re-checked after a signature change
+type PullRequestAuthors struct {
+	// UserIDs are the users whose individual or employee signature was signed or revoked
+	UserIDs []string
+	// ApprovalListChanges holds the approval list entries added to or removed from a corporate signature
+	ApprovalListChanges *models.Signature
+}
+
+// EventDispatcher delivers the CLA lifecycle events to the subscribed webhooks
+type EventDispatcher interface {
+	DispatchEvent(ctx context.Context, event *models.Event) error
+}
+
+// ResignatureHandler notifies the signers of a signature which must be re-signed against a new major version and
+// replaces the CCLA signatures flagged for a re-signature once the company signed the new version
+type ResignatureHandler interface {
+	SendResignatureEmail(ctx context.Context, claGroupModel *models.ClaGroup, sig *models.Signature)
+	ReplaceResignedCorporateSignatures(ctx context.Context, signatureID string) (int, error)
 }
 
 // Service implements DynamoDB stream event handler service
@@ -84,7 +114,10 @@ func NewService(stage string,
 	repositoryService repositories.Service,
 	gerritService gerrits.Service,
 	claManagerRequestsRepo cla_manager.IRepository,
-	approvalListRequestsRepo approval_list.IRepository) Service {
+	approvalListRequestsRepo approval_list.IRepository,
+	pullRequestChecker PullRequestChecker,
+	eventDispatcher EventDispatcher,
+	resignatureHandler ResignatureHandler) Service {
 
 	signaturesTable := fmt.Sprintf("cla-%s-signatures", stage)
 	eventsTable := fmt.Sprintf("cla-%s-events", stage)
@@ -109,6 +142,9 @@ func NewService(stage string,
 		autoEnableService:        &autoEnableServiceProvider{repositoryService: repositoryService},
 		claManagerRequestsRepo:   claManagerRequestsRepo,
 		approvalListRequestsRepo: approvalListRequestsRepo,
+		pullRequestChecker:       pullRequestChecker,
+		eventDispatcher:          eventDispatcher,
+		resignatureHandler:       resignatureHandler,
 	}
 
 	s.registerCallback(signaturesTable, Modify, s.SignatureSignedEvent)
@@ -118,6 +154,15 @@ func NewService(stage string,
 	s.registerCallback(signaturesTable, Insert, s.SignatureAddUsersDetails)
 	// Add or Remove any CLA Permissions
 	s.registerCallback(signaturesTable, Modify, s.UpdateCLAPermissions)
+	// Refresh the failing pull requests once the contributors are authorized, the click-through ICLAs are inserted signed
+	s.registerCallback(signaturesTable, Modify, s.SignatureRecheckPullRequestsEvent)
+	s.registerCallback(signaturesTable, Insert, s.SignatureRecheckPullRequestsEvent)
+	// Notify the signers once a new major version requires a re-signature
+	s.registerCallback(signaturesTable, Modify, s.SignatureResignatureRequiredEvent)
+	// Replace the CCLA signatures flagged for a re-signature once the company signed the new version
+	s.registerCallback(signaturesTable, Modify, s.SignatureResignedEvent)
+	// Record the entries added and removed by each approval list update, and who updated them
+	s.registerCallback(signaturesTable, Modify, s.SignatureApprovalListChangedEvent)
 
 	s.registerCallback(eventsTable, Insert, s.EventAddedEvent)
 
@@ -9,6 +9,9 @@ import (
 	"fmt"
 	"strings"
 
+	claEvents "github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	acs_service "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
 	organization_service "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
@@ -54,6 +57,7 @@ type Signature struct {
 	DomainWhitelist               []string `json:"domain_whitelist"`
 	GitHubWhitelist               []string `json:"github_whitelist"`
 	GitHubOrgWhitelist            []string `json:"github_org_whitelist"`
+	GitHubTeamApprovalList        []string `json:"github_team_approval_list"`
 	SignatureACL                  []string `json:"signature_acl"`
 	SigtypeSignedApprovedID       string   `json:"sigtype_signed_approved_id"`
 	UserGithubUsername            string   `json:"user_github_username"`
@@ -61,6 +65,12 @@ type Signature struct {
 	UserName                      string   `json:"user_name"`
 	UserEmail                     string   `json:"user_email"`
 	SignedOn                      string   `json:"signed_on"`
+	SignatoryName                 string   `json:"signatory_name"`
+	SignatoryEmail                string   `json:"signatory_email"`
+	SignatureSignMethod           string   `json:"signature_sign_method"`
+	SignatureResignMajorVersion   string   `json:"signature_resign_major_version"`
+	SignatureResignDeadline       string   `json:"signature_resign_deadline"`
+	ApprovalListModifiedBy        string   `json:"approval_list_modified_by"`
 }
 
 // Assign Contributor role upon CCLA or CCLA/ICLA signing
@@ -150,6 +160,12 @@ func (s *service) SignatureSignedEvent(event events.DynamoDBEventRecord) error {
 			log.WithFields(f).Warnf("failed to add signed_on date/time to signature, error: %+v", err)
 		}
 
+		// Record the signed event, it reaches the webhooks and the chat channels whichever path signed the signature
+		if eventErr := s.logSignatureSignedEvent(ctx, newSignature); eventErr != nil {
+			log.WithFields(f).WithError(eventErr).Warn("problem logging the signature signed event")
+			// Ok - don't fail for now
+		}
+
 		// If oldSigACL CCLA signature...
 		if newSignature.SignatureType == CCLASignatureType {
 			log.WithFields(f).Debugf("processing signature type: %s with %d CLA Managers...",
@@ -257,6 +273,60 @@ func (s *service) SignatureSignedEvent(event events.DynamoDBEventRecord) error {
 	return nil
 }
 
+// logSignatureSignedEvent records the individual, employee or corporate signed event of the signature signed by an
+// update, through the e-signature provider, DocuSign or the Python backend. The click-through signatures are inserted
+// signed and their event is recorded by the sign handler.
+func (s *service) logSignatureSignedEvent(ctx context.Context, sig Signature) error {
+	event := &models.Event{
+		EventProjectID: sig.SignatureProjectID,
+		LfUsername:     sig.UserLFUsername,
+		UserID:         "easycla system",
+		UserName:       "easycla system",
+	}
+	claGroupName := sig.SignatureProjectID
+	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, sig.SignatureProjectID, project.DontLoadRepoDetails)
+	if err == nil && claGroupModel != nil {
+		claGroupName = claGroupModel.ProjectName
+		event.EventProjectName = claGroupModel.ProjectName
+		event.EventProjectExternalID = claGroupModel.ProjectExternalID
+	}
+
+	signMethod := ""
+	if sig.SignatureSignMethod != "" {
+		signMethod = fmt.Sprintf(" using %s", sig.SignatureSignMethod)
+	}
+	switch {
+	case sig.SignatureType == CCLASignatureType:
+		event.EventType = claEvents.CorporateSignatureSigned
+		event.EventCompanyID = sig.SignatureReferenceID
+		event.EventCompanyName = sig.SignatureReferenceName
+		event.ContainsPII = true
+		event.EventData = fmt.Sprintf("Signature ID: %s for CLA Group ID: %s and company ID: %s was signed by: %s <%s>%s.",
+			sig.SignatureID, sig.SignatureProjectID, sig.SignatureReferenceID, sig.SignatoryName, sig.SignatoryEmail, signMethod)
+		event.EventSummary = fmt.Sprintf("The CLA Signatory %s signed the Corporate CLA of the company %s for the CLA Group %s.",
+			sig.SignatoryName, sig.SignatureReferenceName, claGroupName)
+	case sig.SignatureType == CLASignatureType && sig.SignatureUserCompanyID != "":
+		event.EventType = claEvents.EmployeeSignatureSigned
+		event.EventCompanyID = sig.SignatureUserCompanyID
+		if companyModel, companyErr := s.companyRepo.GetCompany(ctx, sig.SignatureUserCompanyID); companyErr == nil && companyModel != nil {
+			event.EventCompanyName = companyModel.CompanyName
+		}
+		event.EventData = fmt.Sprintf("Signature ID: %s for CLA Group ID: %s and company ID: %s was acknowledged by: %s%s.",
+			sig.SignatureID, sig.SignatureProjectID, sig.SignatureUserCompanyID, sig.UserName, signMethod)
+		event.EventSummary = fmt.Sprintf("The user %s acknowledged the Corporate CLA of the company %s for the CLA Group %s.",
+			sig.UserName, event.EventCompanyName, claGroupName)
+	case sig.SignatureType == CLASignatureType:
+		event.EventType = claEvents.IndividualSignatureSigned
+		event.EventData = fmt.Sprintf("Signature ID: %s for CLA Group ID: %s version %s.%s was signed by: %s%s.",
+			sig.SignatureID, sig.SignatureProjectID, sig.SignatureDocumentMajorVersion, sig.SignatureDocumentMinorVersion, sig.UserName, signMethod)
+		event.EventSummary = fmt.Sprintf("The user %s signed version %s.%s of the Individual CLA for the CLA Group %s.",
+			sig.UserName, sig.SignatureDocumentMajorVersion, sig.SignatureDocumentMinorVersion, claGroupName)
+	default:
+		return fmt.Errorf("unsupported signature type: %s", sig.SignatureType)
+	}
+	return s.eventsRepo.CreateEvent(event)
+}
+
 // SignatureAddSigTypeSignedApprovedID function should be called when new icla, ecla signature added
 func (s *service) SignatureAddSigTypeSignedApprovedID(event events.DynamoDBEventRecord) error {
 	ctx := utils.NewContext()
@@ -384,6 +454,294 @@ func (s *service) UpdateCLAPermissions(event events.DynamoDBEventRecord) error {
 	return nil
 }
 
+// SignatureRecheckPullRequestsEvent re-runs the CLA check on the open pull requests of the CLA Group authored by the
+// contributors affected by the signature change: the signer of a signed or revoked ICLA or employee acknowledgement,
+// or the contributors matching the entries added to or removed from a CCLA approval list
+func (s *service) SignatureRecheckPullRequestsEvent(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "SignatureRecheckPullRequestsEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if s.pullRequestChecker == nil {
+		return nil
+	}
+
+	// Decode the pre-update and post-update signature record details
+	var newSignature, oldSignature Signature
+	err := unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding pre-update signature, error: %+v", err)
+		return err
+	}
+	err = unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding post-update signature, error: %+v", err)
+		return err
+	}
+
+	f["id"] = newSignature.SignatureID
+	f["type"] = newSignature.SignatureType
+	f["projectID"] = newSignature.SignatureProjectID
+
+	authors := affectedPullRequestAuthors(oldSignature, newSignature)
+	if authors == nil {
+		return nil
+	}
+
+	log.WithFields(f).Debugf("re-checking pull requests - users: %+v, approval list changes: %t", authors.UserIDs, authors.ApprovalListChanges != nil)
+	return s.pullRequestChecker.RecheckPullRequests(ctx, newSignature.SignatureProjectID, authors)
+}
+
+// affectedPullRequestAuthors returns the commit authors whose authorization may have changed with the signature
+// update, nil if the update does not affect any author
+func affectedPullRequestAuthors(oldSignature, newSignature Signature) *PullRequestAuthors {
+	wasAuthorized := oldSignature.SignatureSigned && oldSignature.SignatureApproved
+	isAuthorized := newSignature.SignatureSigned && newSignature.SignatureApproved
+	if !wasAuthorized && !isAuthorized {
+		return nil
+	}
+
+	if newSignature.SignatureReferenceType == utils.SignatureReferenceTypeUser {
+		if wasAuthorized == isAuthorized {
+			return nil
+		}
+		return &PullRequestAuthors{UserIDs: []string{newSignature.SignatureReferenceID}}
+	}
+
+	var changes *models.Signature
+	if wasAuthorized != isAuthorized {
+		// every entry of the approval lists gained or lost the authorization
+		current := newSignature
+		if !isAuthorized {
+			current = oldSignature
+		}
+		changes = &models.Signature{
+			EmailApprovalList:          current.EmailWhitelist,
+			DomainApprovalList:         current.DomainWhitelist,
+			GithubUsernameApprovalList: current.GitHubWhitelist,
+			GithubOrgApprovalList:      current.GitHubOrgWhitelist,
+			GithubTeamApprovalList:     current.GitHubTeamApprovalList,
+		}
+	} else {
+		changes = &models.Signature{
+			EmailApprovalList:          changedEntries(oldSignature.EmailWhitelist, newSignature.EmailWhitelist),
+			DomainApprovalList:         changedEntries(oldSignature.DomainWhitelist, newSignature.DomainWhitelist),
+			GithubUsernameApprovalList: changedEntries(oldSignature.GitHubWhitelist, newSignature.GitHubWhitelist),
+			GithubOrgApprovalList:      changedEntries(oldSignature.GitHubOrgWhitelist, newSignature.GitHubOrgWhitelist),
+			GithubTeamApprovalList:     changedEntries(oldSignature.GitHubTeamApprovalList, newSignature.GitHubTeamApprovalList),
+		}
+	}
+
+	if len(changes.EmailApprovalList) == 0 && len(changes.DomainApprovalList) == 0 && len(changes.GithubUsernameApprovalList) == 0 &&
+		len(changes.GithubOrgApprovalList) == 0 && len(changes.GithubTeamApprovalList) == 0 {
+		return nil
+	}
+	return &PullRequestAuthors{ApprovalListChanges: changes}
+}
+
+// SignatureResignatureRequiredEvent notifies the signers when the signature is marked as requiring a re-signature
+// against a new major version of the CLA Group documents
+func (s *service) SignatureResignatureRequiredEvent(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "SignatureResignatureRequiredEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if s.resignatureHandler == nil {
+		return nil
+	}
+
+	// Decode the pre-update and post-update signature record details
+	var newSignature, oldSignature Signature
+	err := unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding pre-update signature, error: %+v", err)
+		return err
+	}
+	err = unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding post-update signature, error: %+v", err)
+		return err
+	}
+
+	if newSignature.SignatureResignMajorVersion == "" || newSignature.SignatureResignMajorVersion == oldSignature.SignatureResignMajorVersion {
+		return nil
+	}
+
+	f["id"] = newSignature.SignatureID
+	f["type"] = newSignature.SignatureType
+	f["projectID"] = newSignature.SignatureProjectID
+	f["resignMajorVersion"] = newSignature.SignatureResignMajorVersion
+
+	sig, err := s.signatureRepo.GetSignature(ctx, newSignature.SignatureID)
+	if err != nil || sig == nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signature requiring a re-signature")
+		return err
+	}
+	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, newSignature.SignatureProjectID, project.DontLoadRepoDetails)
+	if err != nil || claGroupModel == nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group of the signature requiring a re-signature")
+		return err
+	}
+
+	log.WithFields(f).Debug("notifying the signers of the re-signature requirement...")
+	s.resignatureHandler.SendResignatureEmail(ctx, claGroupModel, sig)
+	return nil
+}
+
+// SignatureResignedEvent replaces the CCLA signatures of the company flagged for a re-signature once the company
+// signed the new version of the CCLA, the approval lists and the CLA Managers carry over to the new signature
+func (s *service) SignatureResignedEvent(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "SignatureResignedEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if s.resignatureHandler == nil {
+		return nil
+	}
+
+	// Decode the pre-update and post-update signature record details
+	var newSignature, oldSignature Signature
+	err := unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding pre-update signature, error: %+v", err)
+		return err
+	}
+	err = unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding post-update signature, error: %+v", err)
+		return err
+	}
+
+	if oldSignature.SignatureSigned || !newSignature.SignatureSigned || newSignature.SignatureType != CCLASignatureType {
+		return nil
+	}
+
+	f["id"] = newSignature.SignatureID
+	f["referenceID"] = newSignature.SignatureReferenceID
+	f["projectID"] = newSignature.SignatureProjectID
+
+	count, err := s.resignatureHandler.ReplaceResignedCorporateSignatures(ctx, newSignature.SignatureID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to replace the CCLA signatures flagged for a re-signature")
+		return err
+	}
+	log.WithFields(f).Debugf("replaced %d CCLA signatures flagged for a re-signature", count)
+	return nil
+}
+
+// SignatureApprovalListChangedEvent records the entries added to and removed from the approval lists of the signature
+// along with the user who updated them - the approval list history of the signature is rebuilt from these events
+func (s *service) SignatureApprovalListChangedEvent(event events.DynamoDBEventRecord) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "SignatureApprovalListChangedEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	// Decode the pre-update and post-update signature record details
+	var newSignature, oldSignature Signature
+	err := unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding pre-update signature, error: %+v", err)
+		return err
+	}
+	err = unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding post-update signature, error: %+v", err)
+		return err
+	}
+
+	before, after := approvalListSnapshotOf(oldSignature), approvalListSnapshotOf(newSignature)
+	added, removed := approvalListSnapshotDiff(before, after), approvalListSnapshotDiff(after, before)
+	addedCount, removedCount := approvalListSnapshotSize(added), approvalListSnapshotSize(removed)
+	if addedCount == 0 && removedCount == 0 {
+		return nil
+	}
+
+	// the approval list updates record who made them on the signature, the older updates were made by the system
+	modifiedBy := newSignature.ApprovalListModifiedBy
+	if modifiedBy == "" {
+		modifiedBy = "easycla system"
+	}
+
+	f["id"] = newSignature.SignatureID
+	f["projectID"] = newSignature.SignatureProjectID
+	f["companyID"] = newSignature.SignatureReferenceID
+	f["modifiedBy"] = modifiedBy
+
+	eventErr := s.eventsRepo.CreateEvent(&models.Event{
+		ContainsPII:          true,
+		EventCompanyID:       newSignature.SignatureReferenceID,
+		EventCompanyName:     newSignature.SignatureReferenceName,
+		EventData:            fmt.Sprintf("approval list of signature: %s updated by %s - %d entries added, %d entries removed", newSignature.SignatureID, modifiedBy, addedCount, removedCount),
+		EventProjectID:       newSignature.SignatureProjectID,
+		EventSignatureID:     newSignature.SignatureID,
+		EventSummary:         fmt.Sprintf("approval list of company: %s updated by %s - %d entries added, %d entries removed", newSignature.SignatureReferenceName, modifiedBy, addedCount, removedCount),
+		EventType:            claEvents.ApprovalListChanged,
+		ApprovalListsAdded:   added,
+		ApprovalListsRemoved: removed,
+		LfUsername:           modifiedBy,
+		UserID:               "easycla system",
+		UserName:             modifiedBy,
+	})
+	if eventErr != nil {
+		log.WithFields(f).WithError(eventErr).Warn("problem logging event for the approval list update")
+		return eventErr
+	}
+
+	return nil
+}
+
+// approvalListSnapshotOf returns the approval lists of the signature record
+func approvalListSnapshotOf(sig Signature) *models.ApprovalListSnapshot {
+	return &models.ApprovalListSnapshot{
+		EmailApprovalList:          sig.EmailWhitelist,
+		DomainApprovalList:         sig.DomainWhitelist,
+		GithubUsernameApprovalList: sig.GitHubWhitelist,
+		GithubOrgApprovalList:      sig.GitHubOrgWhitelist,
+		GithubTeamApprovalList:     sig.GitHubTeamApprovalList,
+	}
+}
+
+// approvalListSnapshotDiff returns the approval list entries of the new snapshot which are not in the old one
+func approvalListSnapshotDiff(oldSnapshot, newSnapshot *models.ApprovalListSnapshot) *models.ApprovalListSnapshot {
+	return &models.ApprovalListSnapshot{
+		EmailApprovalList:          addedEntries(oldSnapshot.EmailApprovalList, newSnapshot.EmailApprovalList),
+		DomainApprovalList:         addedEntries(oldSnapshot.DomainApprovalList, newSnapshot.DomainApprovalList),
+		GithubUsernameApprovalList: addedEntries(oldSnapshot.GithubUsernameApprovalList, newSnapshot.GithubUsernameApprovalList),
+		GithubOrgApprovalList:      addedEntries(oldSnapshot.GithubOrgApprovalList, newSnapshot.GithubOrgApprovalList),
+		GithubTeamApprovalList:     addedEntries(oldSnapshot.GithubTeamApprovalList, newSnapshot.GithubTeamApprovalList),
+	}
+}
+
+// approvalListSnapshotSize returns the number of approval list entries of the snapshot
+func approvalListSnapshotSize(snapshot *models.ApprovalListSnapshot) int {
+	return len(snapshot.EmailApprovalList) + len(snapshot.DomainApprovalList) + len(snapshot.GithubUsernameApprovalList) +
+		len(snapshot.GithubOrgApprovalList) + len(snapshot.GithubTeamApprovalList)
+}
+
+// changedEntries returns the entries added to or removed from the list
+func changedEntries(oldList, newList []string) []string {
+	return append(addedEntries(oldList, newList), addedEntries(newList, oldList)...)
+}
+
+// addedEntries returns the entries of the new list which are not in the old list
+func addedEntries(oldList, newList []string) []string {
+	var added []string
+	for _, entry := range newList {
+		if !utils.StringInSlice(entry, oldList) {
+			added = append(added, entry)
+		}
+	}
+	return added
+}
+
 func (s *service) assignContributor(ctx context.Context, newSignature Signature, f logrus.Fields) error {
 	var companyID string
 	// Assign company ID based on signature type (CCLA, CCLA|ICLA)
@@ -0,0 +1,187 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	claEvents "github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAffectedPullRequestAuthors(t *testing.T) {
+	icla := Signature{SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureReferenceID: "user-alice"}
+	ccla := Signature{SignatureReferenceType: utils.SignatureReferenceTypeCompany, SignatureSigned: true, SignatureApproved: true,
+		EmailWhitelist: []string{"alice@example.org"}, GitHubWhitelist: []string{"bob"}}
+
+	t.Run("icla signed", func(t *testing.T) {
+		signed := icla
+		signed.SignatureSigned, signed.SignatureApproved = true, true
+		authors := affectedPullRequestAuthors(icla, signed)
+		if assert.NotNil(t, authors) {
+			assert.Equal(t, []string{"user-alice"}, authors.UserIDs)
+			assert.Nil(t, authors.ApprovalListChanges)
+		}
+		// revoking the signature affects the same user
+		assert.Equal(t, authors, affectedPullRequestAuthors(signed, icla))
+		// an unrelated update does not affect anyone
+		assert.Nil(t, affectedPullRequestAuthors(signed, signed))
+	})
+
+	t.Run("ccla approval list updated", func(t *testing.T) {
+		updated := ccla
+		updated.EmailWhitelist = []string{"alice@example.org", "carol@example.org"}
+		updated.GitHubWhitelist = nil
+		authors := affectedPullRequestAuthors(ccla, updated)
+		if assert.NotNil(t, authors) && assert.NotNil(t, authors.ApprovalListChanges) {
+			assert.Empty(t, authors.UserIDs)
+			assert.Equal(t, []string{"carol@example.org"}, authors.ApprovalListChanges.EmailApprovalList)
+			assert.Equal(t, []string{"bob"}, authors.ApprovalListChanges.GithubUsernameApprovalList)
+		}
+		assert.Nil(t, affectedPullRequestAuthors(ccla, ccla))
+	})
+
+	t.Run("ccla revoked", func(t *testing.T) {
+		revoked := ccla
+		revoked.SignatureApproved = false
+		authors := affectedPullRequestAuthors(ccla, revoked)
+		if assert.NotNil(t, authors) && assert.NotNil(t, authors.ApprovalListChanges) {
+			assert.Equal(t, []string{"alice@example.org"}, authors.ApprovalListChanges.EmailApprovalList)
+			assert.Equal(t, []string{"bob"}, authors.ApprovalListChanges.GithubUsernameApprovalList)
+		}
+	})
+}
+
+func TestApprovalListSnapshotDiff(t *testing.T) {
+	before := approvalListSnapshotOf(Signature{EmailWhitelist: []string{"alice@example.org"}, GitHubWhitelist: []string{"bob"}})
+	after := approvalListSnapshotOf(Signature{EmailWhitelist: []string{"alice@example.org", "carol@example.org"}, DomainWhitelist: []string{"example.com"}})
+
+	added := approvalListSnapshotDiff(before, after)
+	assert.Equal(t, []string{"carol@example.org"}, added.EmailApprovalList, "only the changed entries are recorded")
+	assert.Equal(t, []string{"example.com"}, added.DomainApprovalList)
+	assert.Equal(t, 2, approvalListSnapshotSize(added))
+
+	removed := approvalListSnapshotDiff(after, before)
+	assert.Equal(t, []string{"bob"}, removed.GithubUsernameApprovalList)
+	assert.Equal(t, 1, approvalListSnapshotSize(removed))
+
+	assert.Equal(t, 0, approvalListSnapshotSize(approvalListSnapshotDiff(after, after)))
+}
+
+// recordingPullRequestChecker records the pull request re-checks
+type recordingPullRequestChecker struct {
+	claGroupIDs []string
+	authors     []*PullRequestAuthors
+}
+
+func (c *recordingPullRequestChecker) RecheckPullRequests(ctx context.Context, claGroupID string, authors *PullRequestAuthors) error {
+	c.claGroupIDs = append(c.claGroupIDs, claGroupID)
+	c.authors = append(c.authors, authors)
+	return nil
+}
+
+func TestSignatureRecheckPullRequestsEventOnInsert(t *testing.T) {
+	checker := &recordingPullRequestChecker{}
+	s := NewService("test", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, checker, nil, nil).(*service)
+
+	// the click-through ICLAs are inserted signed, the re-check is registered for the inserts as well as the updates
+	for _, key := range []string{"cla-test-signatures:" + Insert, "cla-test-signatures:" + Modify} {
+		registered := false
+		for _, handler := range s.functions[key] {
+			if strings.Contains(runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name(), "SignatureRecheckPullRequestsEvent") {
+				registered = true
+			}
+		}
+		assert.True(t, registered, "re-check not registered for %s", key)
+	}
+
+	err := s.SignatureRecheckPullRequestsEvent(events.DynamoDBEventRecord{
+		EventName: Insert,
+		Change: events.DynamoDBStreamRecord{
+			NewImage: map[string]events.DynamoDBAttributeValue{
+				"signature_id":             events.NewStringAttribute("signature-1"),
+				"signature_project_id":     events.NewStringAttribute("cla-group-1"),
+				"signature_reference_id":   events.NewStringAttribute("user-alice"),
+				"signature_reference_type": events.NewStringAttribute(utils.SignatureReferenceTypeUser),
+				"signature_type":           events.NewStringAttribute("cla"),
+				"signature_signed":         events.NewBooleanAttribute(true),
+				"signature_approved":       events.NewBooleanAttribute(true),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cla-group-1"}, checker.claGroupIDs)
+	if assert.Len(t, checker.authors, 1) {
+		assert.Equal(t, []string{"user-alice"}, checker.authors[0].UserIDs)
+	}
+
+	// an unsigned signature inserted by the DocuSign flow is re-checked once signed
+	checker.claGroupIDs, checker.authors = nil, nil
+	err = s.SignatureRecheckPullRequestsEvent(events.DynamoDBEventRecord{
+		EventName: Insert,
+		Change: events.DynamoDBStreamRecord{
+			NewImage: map[string]events.DynamoDBAttributeValue{
+				"signature_id":             events.NewStringAttribute("signature-2"),
+				"signature_project_id":     events.NewStringAttribute("cla-group-1"),
+				"signature_reference_id":   events.NewStringAttribute("user-bob"),
+				"signature_reference_type": events.NewStringAttribute(utils.SignatureReferenceTypeUser),
+				"signature_signed":         events.NewBooleanAttribute(false),
+				"signature_approved":       events.NewBooleanAttribute(true),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, checker.authors)
+}
+
+type recordingEventsRepo struct {
+	claEvents.Repository
+	events []*models.Event
+}
+
+func (r *recordingEventsRepo) CreateEvent(event *models.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestSignatureApprovalListChangedEventGitHubOrgAdded(t *testing.T) {
+	eventsRepo := &recordingEventsRepo{}
+	s := NewService("test", nil, nil, nil, nil, eventsRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).(*service)
+
+	// the GitHub organization approval list update records its author like the other approval list updates
+	err := s.SignatureApprovalListChangedEvent(events.DynamoDBEventRecord{
+		EventName: Modify,
+		Change: events.DynamoDBStreamRecord{
+			OldImage: map[string]events.DynamoDBAttributeValue{
+				"signature_id":              events.NewStringAttribute("signature-1"),
+				"signature_reference_id":    events.NewStringAttribute("company-1"),
+				"github_org_whitelist":      events.NewListAttribute([]events.DynamoDBAttributeValue{events.NewStringAttribute("cncf")}),
+				"approval_list_modified_by": events.NewStringAttribute("previous-manager"),
+			},
+			NewImage: map[string]events.DynamoDBAttributeValue{
+				"signature_id":           events.NewStringAttribute("signature-1"),
+				"signature_reference_id": events.NewStringAttribute("company-1"),
+				"github_org_whitelist": events.NewListAttribute([]events.DynamoDBAttributeValue{
+					events.NewStringAttribute("cncf"), events.NewStringAttribute("kubernetes"),
+				}),
+				"approval_list_modified_by": events.NewStringAttribute("cla-manager"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, eventsRepo.events, 1) {
+		event := eventsRepo.events[0]
+		assert.Equal(t, claEvents.ApprovalListChanged, event.EventType)
+		assert.Equal(t, "cla-manager", event.LfUsername)
+		assert.Equal(t, []string{"kubernetes"}, event.ApprovalListsAdded.GithubOrgApprovalList)
+		assert.Empty(t, event.ApprovalListsRemoved.GithubOrgApprovalList)
+	}
+}
@@ -27,7 +27,7 @@ type ProjectService interface { //nolint
 }
 
 // Configure the Gerrit api
-func Configure(api *operations.EasyclaAPI, v1Service v1Gerrits.Service, projectService ProjectService, eventService events.Service, projectsClaGroupsRepo projects_cla_groups.Repository) {
+func Configure(api *operations.EasyclaAPI, v1Service v1Gerrits.Service, enforcementService v1Gerrits.EnforcementService, projectService ProjectService, eventService events.Service, projectsClaGroupsRepo projects_cla_groups.Repository) {
 	api.GerritsDeleteGerritHandler = gerrits.DeleteGerritHandlerFunc(
 		func(params gerrits.DeleteGerritParams, authUser *auth.User) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
@@ -77,6 +77,104 @@ func Configure(api *operations.EasyclaAPI, v1Service v1Gerrits.Service, projectS
 			return gerrits.NewDeleteGerritNoContent().WithXRequestID(reqID)
 		})
 
+	api.GerritsCreateGerritHookTokenHandler = gerrits.CreateGerritHookTokenHandlerFunc(
+		func(params gerrits.CreateGerritHookTokenParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+			gerrit, err := v1Service.GetGerrit(ctx, params.GerritID)
+			if err != nil {
+				if err == v1Gerrits.ErrGerritNotFound {
+					return gerrits.NewCreateGerritHookTokenNotFound().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+				}
+				return gerrits.NewCreateGerritHookTokenInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			if gerrit.ProjectSFID != params.ProjectSFID || gerrit.ProjectID != params.ClaGroupID {
+				return gerrits.NewCreateGerritHookTokenBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:       "400",
+					Message:    "EasyCLA - 400 Bad Request - projectSFID or claGroupID does not match with provided gerrit record",
+					XRequestID: reqID,
+				})
+			}
+			// verify user have access to the project
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				return gerrits.NewCreateGerritHookTokenForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to CreateGerritHookToken with Project scope of %s",
+						authUser.UserName, gerrit.ProjectSFID),
+					XRequestID: reqID,
+				})
+			}
+
+			token, err := v1Service.CreateHookToken(ctx, params.GerritID)
+			if err != nil {
+				return gerrits.NewCreateGerritHookTokenInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+
+			// record the event
+			eventService.LogEvent(&events.LogEventArgs{
+				EventType:  events.GerritHookTokenCreated,
+				ProjectID:  gerrit.ProjectID,
+				LfUsername: authUser.UserName,
+				EventData: &events.GerritHookTokenCreatedEventData{
+					GerritRepositoryName: gerrit.GerritName,
+				},
+			})
+
+			return gerrits.NewCreateGerritHookTokenOK().WithXRequestID(reqID).WithPayload(&models.GerritHookToken{
+				GerritID: params.GerritID,
+				Token:    token,
+			})
+		})
+
+	api.GerritsDeleteGerritHookTokenHandler = gerrits.DeleteGerritHookTokenHandlerFunc(
+		func(params gerrits.DeleteGerritHookTokenParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+			gerrit, err := v1Service.GetGerrit(ctx, params.GerritID)
+			if err != nil {
+				if err == v1Gerrits.ErrGerritNotFound {
+					return gerrits.NewDeleteGerritHookTokenNotFound().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+				}
+				return gerrits.NewDeleteGerritHookTokenInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+			if gerrit.ProjectSFID != params.ProjectSFID || gerrit.ProjectID != params.ClaGroupID {
+				return gerrits.NewDeleteGerritHookTokenBadRequest().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:       "400",
+					Message:    "EasyCLA - 400 Bad Request - projectSFID or claGroupID does not match with provided gerrit record",
+					XRequestID: reqID,
+				})
+			}
+			// verify user have access to the project
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				return gerrits.NewDeleteGerritHookTokenForbidden().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to DeleteGerritHookToken with Project scope of %s",
+						authUser.UserName, gerrit.ProjectSFID),
+					XRequestID: reqID,
+				})
+			}
+
+			if err = v1Service.RevokeHookToken(ctx, params.GerritID); err != nil {
+				return gerrits.NewDeleteGerritHookTokenInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+
+			// record the event
+			eventService.LogEvent(&events.LogEventArgs{
+				EventType:  events.GerritHookTokenRevoked,
+				ProjectID:  gerrit.ProjectID,
+				LfUsername: authUser.UserName,
+				EventData: &events.GerritHookTokenRevokedEventData{
+					GerritRepositoryName: gerrit.GerritName,
+				},
+			})
+
+			return gerrits.NewDeleteGerritHookTokenNoContent().WithXRequestID(reqID)
+		})
+
 	api.GerritsAddGerritHandler = gerrits.AddGerritHandlerFunc(
 		func(params gerrits.AddGerritParams, authUser *auth.User) middleware.Responder {
 			reqID := utils.GetRequestID(params.XREQUESTID)
@@ -222,6 +320,45 @@ func Configure(api *operations.EasyclaAPI, v1Service v1Gerrits.Service, projectS
 
 			return gerrits.NewGetGerritReposOK().WithXRequestID(reqID).WithPayload(&response)
 		})
+
+	api.GerritsCheckGerritContributorHandler = gerrits.CheckGerritContributorHandlerFunc(
+		func(params gerrits.CheckGerritContributorParams) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			// the hooks authenticate with the hook token of the gerrit instance, there is no user session
+			if !enforcementService.ValidateHookToken(ctx, params.GerritID, utils.StringValue(params.XGERRITHOOKTOKEN)) {
+				return gerrits.NewCheckGerritContributorUnauthorized().WithXRequestID(reqID).WithPayload(&models.ErrorResponse{
+					Code:       "401",
+					Message:    "EasyCLA - 401 Unauthorized - invalid gerrit hook token",
+					XRequestID: reqID,
+				})
+			}
+
+			result, err := enforcementService.CheckContributor(ctx, &v1Gerrits.ContributorCheck{
+				GerritID: params.GerritID,
+				Project:  params.GerritContributorCheckInput.Project,
+				Email:    params.GerritContributorCheckInput.Email,
+				Username: params.GerritContributorCheckInput.Username,
+			})
+			if err != nil {
+				if err == v1Gerrits.ErrGerritNotFound || err == v1Gerrits.ErrGerritProjectNotFound {
+					return gerrits.NewCheckGerritContributorNotFound().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+				}
+				if err == v1Gerrits.ErrMissingContributor {
+					return gerrits.NewCheckGerritContributorBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+				}
+				return gerrits.NewCheckGerritContributorInternalServerError().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			}
+
+			return gerrits.NewCheckGerritContributorOK().WithXRequestID(reqID).WithPayload(&models.GerritContributorCheck{
+				Allowed:    result.Allowed,
+				Reason:     result.Reason,
+				SignURL:    result.SignURL,
+				ClaGroupID: result.ClaGroupID,
+				UserID:     result.UserID,
+			})
+		})
 }
 
 type codedResponse interface {
@@ -16,6 +16,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/github_activity"
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/gofrs/uuid"
@@ -58,7 +59,7 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 				})
 			}
 
-			event, err := github.ParseWebHook(githubEvent, payload)
+			forgeEvent, err := githubutils.WebhookParser{}.ParseWebhook(githubEvent, payload)
 			if err != nil {
 				return github_activity.NewGithubActivityBadRequest().WithPayload(&models.ErrorResponse{
 					Code:    "400",
@@ -67,11 +68,13 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 			}
 
 			var processError error
-			switch event := event.(type) {
+			switch event := forgeEvent.Raw.(type) {
 			case *github.InstallationRepositoriesEvent:
 				processError = service.ProcessInstallationRepositoriesEvent(event)
 			case *github.RepositoryEvent:
 				processError = service.ProcessRepositoryEvent(event)
+			case *github.PullRequestEvent:
+				processError = service.ProcessPullRequestEvent(event)
 			default:
 				log.Warnf("unsupported event sent : %s", githubEvent)
 			}
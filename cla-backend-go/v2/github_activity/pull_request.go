@@ -0,0 +1,444 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/gofrs/uuid"
+	"github.com/google/go-github/v33/github"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// ProcessPullRequestEvent runs the CLA check for the pull request when it is opened, reopened or receives new commits
+func (s *eventHandlerService) ProcessPullRequestEvent(event *github.PullRequestEvent) error {
+	if event.Action == nil {
+		return fmt.Errorf("no action found in event payload")
+	}
+	log.Debugf("ProcessPullRequestEvent called for action : %s", *event.Action)
+
+	switch *event.Action {
+	case "opened", "reopened", "synchronize":
+		return s.handlePullRequestCheck(event)
+	default:
+		log.Debugf("ProcessPullRequestEvent no handler for action : %s", *event.Action)
+	}
+
+	return nil
+}
+
+func (s *eventHandlerService) handlePullRequestCheck(event *github.PullRequestEvent) error {
+	requestID, _ := uuid.NewV4()
+	ctx := context.WithValue(context.Background(), utils.XREQUESTID, requestID.String()) // nolint
+	f := logrus.Fields{
+		"functionName":   "handlePullRequestCheck",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if event.Repo == nil || event.Repo.GetID() == 0 {
+		return fmt.Errorf("missing repo id")
+	}
+	if event.Installation == nil || event.Installation.GetID() == 0 {
+		return fmt.Errorf("missing installation id")
+	}
+	if event.GetNumber() == 0 {
+		return fmt.Errorf("missing pull request number")
+	}
+	if event.Repo.GetOwner().GetLogin() == "" || event.Repo.GetName() == "" {
+		return fmt.Errorf("missing repo owner or name")
+	}
+
+	installationID := event.Installation.GetID()
+	repositoryID := event.Repo.GetID()
+	pullRequestID := event.GetNumber()
+	owner := event.Repo.GetOwner().GetLogin()
+	repoName := event.Repo.GetName()
+	f["repositoryName"] = event.Repo.GetFullName()
+	f["pullRequestID"] = pullRequestID
+
+	repositoryExternalID := strconv.FormatInt(repositoryID, 10)
+	repoModel, err := s.githubRepo.GetRepositoryByGithubID(ctx, repositoryExternalID, true)
+	if err != nil {
+		if _, ok := err.(*utils.GitHubRepositoryNotFound); ok {
+			log.WithFields(f).Warn("pull request event for a repository which is not enabled, nothing to do")
+			return nil
+		}
+		return fmt.Errorf("fetching the repo : %s by external id : %s failed : %v", event.Repo.GetFullName(), repositoryExternalID, err)
+	}
+	f["claGroupID"] = repoModel.RepositoryProjectID
+
+	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, repoModel.RepositoryProjectID, DontLoadRepoDetails)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load CLA group for repository")
+		return err
+	}
+
+	commitSummaries, latestSHA, err := githubutils.GetPullRequestCommitAuthors(ctx, installationID, owner, repoName, pullRequestID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load pull request commit authors")
+		return err
+	}
+	if latestSHA == "" {
+		log.WithFields(f).Warn("pull request has no commits, nothing to do")
+		return nil
+	}
+
+	_, err = s.checkPullRequest(ctx, claGroupModel, installationID, repositoryID, owner, repoName, pullRequestID, commitSummaries, latestSHA, "")
+	return err
+}
+
+// RecheckPullRequests re-runs the CLA check on the open pull requests of the CLA Group repositories which have a
+// commit by one of the specified authors. The pull request is only updated when its EasyCLA status changes. The
+// repositories are processed by a pool of RecheckWorkers concurrent workers.
+func (s *eventHandlerService) RecheckPullRequests(ctx context.Context, claGroupID string, authors *dynamo_events.PullRequestAuthors) error {
+	f := logrus.Fields{
+		"functionName":   "RecheckPullRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	if authors == nil || (len(authors.UserIDs) == 0 && authors.ApprovalListChanges == nil) {
+		log.WithFields(f).Debug("no pull request authors to re-check, nothing to do")
+		return nil
+	}
+
+	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, claGroupID, DontLoadRepoDetails)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load CLA group")
+		return err
+	}
+
+	repos, err := s.getRepositoryInstallations(ctx, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load CLA group repositories")
+		return err
+	}
+	log.WithFields(f).Debugf("re-checking open pull requests of %d repositories", len(repos))
+
+	var eg errgroup.Group
+	// a pool of concurrent workers
+	var workerTokens = make(chan struct{}, RecheckWorkers)
+	for _, repo := range repos {
+		// this is for goroutine local variables
+		repo := repo
+		// acquire a worker token to create a new goroutine
+		workerTokens <- struct{}{}
+		eg.Go(func() error {
+			defer func() {
+				<-workerTokens // release the workerToken
+			}()
+			if recheckErr := s.recheckRepositoryPullRequests(ctx, claGroupModel, repo, authors); recheckErr != nil {
+				log.WithFields(f).WithError(recheckErr).Warnf("unable to re-check pull requests of repository: %s", repo.repo.RepositoryName)
+			}
+			return nil
+		})
+	}
+
+	// Wait for the go routines to finish
+	return eg.Wait()
+}
+
+// GetOpenPullRequestAuthors returns the EasyCLA user records of the commit authors of the open pull requests of the
+// CLA Group repositories, at most RecentPullRequestsPerRepository pull requests are inspected per repository. The
+// authors without a user record are skipped.
+func (s *eventHandlerService) GetOpenPullRequestAuthors(ctx context.Context, claGroupID string) ([]*models.User, error) {
+	f := logrus.Fields{
+		"functionName":   "GetOpenPullRequestAuthors",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	repos, err := s.getRepositoryInstallations(ctx, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load CLA group repositories")
+		return nil, err
+	}
+
+	var authors []*models.User
+	seen := map[string]bool{}
+	for _, repo := range repos {
+		pullRequests, listErr := githubutils.GetOpenPullRequests(ctx, repo.installationID, repo.owner, repo.name)
+		if listErr != nil {
+			log.WithFields(f).WithError(listErr).Warnf("unable to list open pull requests of repository: %s", repo.repo.RepositoryName)
+			continue
+		}
+		if len(pullRequests) > RecentPullRequestsPerRepository {
+			pullRequests = pullRequests[:RecentPullRequestsPerRepository]
+		}
+
+		for _, pullRequest := range pullRequests {
+			summaries, _, authorsErr := githubutils.GetPullRequestCommitAuthors(ctx, repo.installationID, repo.owner, repo.name, pullRequest.GetNumber())
+			if authorsErr != nil {
+				log.WithFields(f).WithError(authorsErr).Warnf("unable to load commit authors of pull request: %s #%d", repo.repo.RepositoryName, pullRequest.GetNumber())
+				continue
+			}
+			for _, summary := range summaries {
+				if !summary.IsValid() || seen[summary.GetCommitAuthorID()] {
+					continue
+				}
+				seen[summary.GetCommitAuthorID()] = true
+
+				userModel, userErr := s.getCommitAuthorUser(summary)
+				if userErr != nil {
+					log.WithFields(f).WithError(userErr).Warnf("unable to lookup user of github user: %s", summary.GetCommitAuthorUsername())
+					continue
+				}
+				if userModel != nil {
+					authors = append(authors, userModel)
+				}
+			}
+		}
+	}
+
+	log.WithFields(f).Debugf("found %d pull request authors with a user record", len(authors))
+	return authors, nil
+}
+
+// repositoryInstallation is a CLA Group repository along with the GitHub app installation of its organization
+type repositoryInstallation struct {
+	repo           *models.GithubRepository
+	installationID int64
+	owner          string
+	name           string
+}
+
+// getRepositoryInstallations returns the CLA Group repositories with their GitHub app installation ID, the
+// repositories of the organizations without an installation are skipped
+func (s *eventHandlerService) getRepositoryInstallations(ctx context.Context, claGroupID string) ([]*repositoryInstallation, error) {
+	f := logrus.Fields{
+		"functionName":   "getRepositoryInstallations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	repos, err := s.githubRepo.GetRepositoriesByCLAGroup(ctx, claGroupID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// cache the installation ID of each organization
+	installationIDs := map[string]int64{}
+	var result []*repositoryInstallation
+	for _, repo := range repos {
+		installationID, ok := installationIDs[repo.RepositoryOrganizationName]
+		if !ok {
+			githubOrg, orgErr := s.githubOrgRepo.GetGithubOrganization(ctx, repo.RepositoryOrganizationName)
+			if orgErr != nil || githubOrg == nil {
+				log.WithFields(f).WithError(orgErr).Warnf("unable to load github organization: %s", repo.RepositoryOrganizationName)
+				continue
+			}
+			installationID = githubOrg.OrganizationInstallationID
+			installationIDs[repo.RepositoryOrganizationName] = installationID
+		}
+		if installationID == 0 {
+			log.WithFields(f).Warnf("github organization: %s has no installation id", repo.RepositoryOrganizationName)
+			continue
+		}
+
+		// the repository name is stored as the full name, i.e. organization/repository
+		nameParts := strings.Split(repo.RepositoryName, "/")
+		if len(nameParts) != 2 {
+			log.WithFields(f).Warnf("unexpected repository name format : %s", repo.RepositoryName)
+			continue
+		}
+		result = append(result, &repositoryInstallation{repo: repo, installationID: installationID, owner: nameParts[0], name: nameParts[1]})
+	}
+	return result, nil
+}
+
+func (s *eventHandlerService) recheckRepositoryPullRequests(ctx context.Context, claGroupModel *models.ClaGroup, repo *repositoryInstallation, authors *dynamo_events.PullRequestAuthors) error {
+	f := logrus.Fields{
+		"functionName":   "recheckRepositoryPullRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupModel.ProjectID,
+		"repositoryName": repo.repo.RepositoryName,
+	}
+	installationID, owner, repoName := repo.installationID, repo.owner, repo.name
+
+	repositoryID, err := strconv.ParseInt(repo.repo.RepositoryExternalID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid repository external id : %s", repo.repo.RepositoryExternalID)
+	}
+
+	pullRequests, err := githubutils.GetOpenPullRequests(ctx, installationID, owner, repoName)
+	if err != nil {
+		return err
+	}
+
+	for _, pullRequest := range pullRequests {
+		commitSummaries, latestSHA, authorsErr := githubutils.GetPullRequestCommitAuthors(ctx, installationID, owner, repoName, pullRequest.GetNumber())
+		if authorsErr != nil {
+			log.WithFields(f).WithError(authorsErr).Warnf("unable to load commit authors of pull request: %d", pullRequest.GetNumber())
+			continue
+		}
+		if latestSHA == "" || !s.hasAffectedAuthor(ctx, commitSummaries, authors) {
+			continue
+		}
+
+		state, statusErr := githubutils.GetCommitStatusState(ctx, installationID, owner, repoName, latestSHA)
+		if statusErr != nil {
+			log.WithFields(f).WithError(statusErr).Warnf("unable to load commit status of pull request: %d", pullRequest.GetNumber())
+			continue
+		}
+		if state == "" {
+			// not checked by EasyCLA yet, the pull request event will run the check
+			continue
+		}
+
+		updated, checkErr := s.checkPullRequest(ctx, claGroupModel, installationID, repositoryID, owner, repoName, pullRequest.GetNumber(), commitSummaries, latestSHA, state)
+		if checkErr != nil {
+			log.WithFields(f).WithError(checkErr).Warnf("unable to re-check pull request: %d", pullRequest.GetNumber())
+			continue
+		}
+		if updated {
+			log.WithFields(f).Debugf("pull request: %d status changed from: %s", pullRequest.GetNumber(), state)
+		}
+	}
+
+	return nil
+}
+
+// hasAffectedAuthor returns true if one of the commit authors is one of the specified users or matches one of the
+// approval list changes. A failed approval list lookup counts as a match so the pull request is re-checked.
+func (s *eventHandlerService) hasAffectedAuthor(ctx context.Context, commitSummaries []*githubutils.UserCommitSummary, authors *dynamo_events.PullRequestAuthors) bool {
+	for _, summary := range commitSummaries {
+		if !summary.IsValid() {
+			continue
+		}
+		userModel, err := s.getCommitAuthorUser(summary)
+		if err != nil || userModel == nil {
+			continue
+		}
+		if utils.StringInSlice(userModel.UserID, authors.UserIDs) {
+			return true
+		}
+		if authors.ApprovalListChanges != nil {
+			approved, approvedErr := s.signatureService.UserIsApproved(ctx, userModel, authors.ApprovalListChanges)
+			if approvedErr != nil {
+				log.WithError(approvedErr).Warnf("unable to match github user: %s against the approval list changes", summary.GetCommitAuthorUsername())
+				return true
+			}
+			if approved {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkPullRequest evaluates the commit authors of the pull request and updates the pull request status and comment.
+// When the current EasyCLA status state is set, the pull request is left untouched unless the state changes.
+// Returns true if the pull request was updated.
+func (s *eventHandlerService) checkPullRequest(ctx context.Context, claGroupModel *models.ClaGroup, installationID, repositoryID int64, owner, repoName string, pullRequestID int, commitSummaries []*githubutils.UserCommitSummary, latestSHA, currentState string) (bool, error) {
+	f := logrus.Fields{
+		"functionName":   "checkPullRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupModel.ProjectID,
+		"repositoryName": fmt.Sprintf("%s/%s", owner, repoName),
+		"pullRequestID":  pullRequestID,
+	}
+
+	var signed, missing []*githubutils.UserCommitSummary
+	for _, summary := range commitSummaries {
+		if checkErr := s.checkCommitAuthor(ctx, claGroupModel.ProjectID, summary); checkErr != nil {
+			log.WithFields(f).WithError(checkErr).Warnf("unable to check commit author for commit: %s", summary.SHA)
+		}
+		if summary.Authorized {
+			signed = append(signed, summary)
+		} else {
+			missing = append(missing, summary)
+		}
+	}
+
+	newState := forge.StatusStateSuccess
+	if len(missing) > 0 {
+		newState = forge.StatusStateFailure
+	}
+	if currentState == newState {
+		log.WithFields(f).Debugf("pull request status: %s is unchanged, leaving it untouched", currentState)
+		return false, nil
+	}
+
+	statusPoster, err := githubutils.NewForgeProvider(installationID)
+	if err != nil {
+		return false, err
+	}
+
+	signURL := githubutils.GetFullSignURL(s.claV1ApiURL, installationID, repositoryID, pullRequestID, claGroupModel.Version)
+	log.WithFields(f).Debugf("updating pull request - signed: %d, missing: %d", len(signed), len(missing))
+	err = githubutils.UpdatePullRequestComment(ctx, installationID, owner, repoName, pullRequestID, signed, missing, signURL)
+	if err != nil {
+		return false, err
+	}
+
+	status := &forge.CommitStatus{
+		State:       newState,
+		Context:     githubutils.StatusContext,
+		Description: githubutils.StatusDescriptionSigned,
+		TargetURL:   s.claLandingPage,
+	}
+	if len(missing) > 0 {
+		status.Description, status.TargetURL = githubutils.StatusDescriptionMissing, signURL
+	}
+	log.WithFields(f).Debugf("creating commit status: %s", status.State)
+	err = statusPoster.PostStatus(ctx, owner, repoName, latestSHA, status)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create commit status")
+		return false, err
+	}
+	return true, nil
+}
+
+// checkCommitAuthor resolves the EasyCLA user of the commit author and sets the authorization flags on the summary
+func (s *eventHandlerService) checkCommitAuthor(ctx context.Context, claGroupID string, summary *githubutils.UserCommitSummary) error {
+	if !summary.IsValid() {
+		return nil
+	}
+
+	userModel, err := s.getCommitAuthorUser(summary)
+	if err != nil {
+		return err
+	}
+	if userModel == nil {
+		log.Debugf("no EasyCLA user record for github user: %s", summary.GetCommitAuthorUsername())
+		return nil
+	}
+
+	signed, affiliated, err := s.signatureService.HasUserSigned(ctx, userModel, claGroupID)
+	if err != nil {
+		return err
+	}
+	summary.Authorized = signed
+	summary.Affiliated = affiliated
+	return nil
+}
+
+// getCommitAuthorUser looks up the user record by github id, then by github username
+func (s *eventHandlerService) getCommitAuthorUser(summary *githubutils.UserCommitSummary) (*models.User, error) {
+	userModel, err := s.usersRepo.GetUserByUserName(fmt.Sprintf("github:%s", summary.GetCommitAuthorID()), true)
+	if err != nil {
+		return nil, err
+	}
+	if userModel != nil || summary.GetCommitAuthorUsername() == "" {
+		return userModel, nil
+	}
+
+	userModel, err = s.usersRepo.GetUserByGitHubUsername(summary.GetCommitAuthorUsername())
+	if err != nil {
+		// the lookup by username returns an error when the user does not exist
+		log.Debugf("unable to find user by github username: %s, error: %v", summary.GetCommitAuthorUsername(), err)
+		return nil, nil
+	}
+	return userModel, nil
+}
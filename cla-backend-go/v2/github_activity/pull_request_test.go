@@ -0,0 +1,351 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github_activity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	githubutils "github.com/communitybridge/easycla/cla-backend-go/github"
+	repositoriesmock "github.com/communitybridge/easycla/cla-backend-go/repositories/mock"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-github/v33/github"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testInstallationID = 42
+	testRepositoryID   = 1001
+	testPullRequestID  = 7
+	testClaGroupID     = "b1e86e26-d8c8-4fd8-9f8d-5c723d5dac9f"
+)
+
+type fakeProjectRepo struct{}
+
+type fakeGithubOrgRepo struct{}
+
+func (fakeGithubOrgRepo) GetGithubOrganization(ctx context.Context, githubOrganizationName string) (*models.GithubOrganization, error) {
+	return &models.GithubOrganization{OrganizationName: githubOrganizationName, OrganizationInstallationID: testInstallationID}, nil
+}
+
+func (fakeProjectRepo) GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error) {
+	return &models.ClaGroup{ProjectID: claGroupID, Version: "v2"}, nil
+}
+
+// fakeUsersRepo resolves the users by github id, the embedded interface panics on any other call
+type fakeUsersRepo struct {
+	users.UserRepository
+	usersByGitHubID map[string]*models.User
+}
+
+func (r fakeUsersRepo) GetUserByUserName(userName string, fullMatch bool) (*models.User, error) {
+	return r.usersByGitHubID[userName], nil
+}
+
+func (r fakeUsersRepo) GetUserByGitHubUsername(gitHubUsername string) (*models.User, error) {
+	return nil, fmt.Errorf("user not found when searching by user_github_username: %s", gitHubUsername)
+}
+
+// fakeSignatureService reports the users in the signed list as authorized
+type fakeSignatureService struct {
+	signatures.SignatureService
+	signed map[string]bool
+}
+
+func (s fakeSignatureService) HasUserSigned(ctx context.Context, user *models.User, claGroupID string) (bool, bool, error) {
+	return s.signed[user.UserID], false, nil
+}
+
+// UserIsApproved matches the github username approval list only
+func (s fakeSignatureService) UserIsApproved(ctx context.Context, user *models.User, cclaSignature *models.Signature) (bool, error) {
+	return utils.StringInSlice(user.GithubUsername, cclaSignature.GithubUsernameApprovalList), nil
+}
+
+// githubStub is a minimal local GitHub API recording the statuses and comments posted by the CLA check
+type githubStub struct {
+	mu       sync.Mutex
+	commits  []*github.RepositoryCommit
+	statuses []*github.RepoStatus
+	comments []string
+	// combined status state of the pull request head, used by the re-check
+	headState string
+}
+
+func (g *githubStub) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/app/installations/%d/access_tokens", testInstallationID), func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]interface{}{"token": "test-token", "expires_at": time.Now().Add(time.Hour)})
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/octo/hello/pulls/%d/commits", testPullRequestID), func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, g.commits)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/octo/hello/issues/%d/comments", testPullRequestID), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeJSON(t, w, []*github.IssueComment{})
+			return
+		}
+		var comment github.IssueComment
+		readJSON(t, r, &comment)
+		g.mu.Lock()
+		g.comments = append(g.comments, comment.GetBody())
+		g.mu.Unlock()
+		writeJSON(t, w, comment)
+	})
+	mux.HandleFunc("/repos/octo/hello/pulls", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, []*github.PullRequest{{Number: github.Int(testPullRequestID), Head: &github.PullRequestBranch{SHA: github.String("sha2")}}})
+	})
+	mux.HandleFunc("/repos/octo/hello/commits/sha2/status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, &github.CombinedStatus{Statuses: []*github.RepoStatus{{Context: github.String(githubutils.StatusContext), State: github.String(g.headState)}}})
+	})
+	mux.HandleFunc("/repos/octo/hello/statuses/", func(w http.ResponseWriter, r *http.Request) {
+		var status github.RepoStatus
+		readJSON(t, r, &status)
+		g.mu.Lock()
+		g.statuses = append(g.statuses, &status)
+		g.mu.Unlock()
+		writeJSON(t, w, status)
+	})
+	return mux
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Fatalf("encoding stub response failed : %v", err)
+	}
+}
+
+func readJSON(t *testing.T, r *http.Request, v interface{}) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("reading stub request failed : %v", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("decoding stub request failed : %v", err)
+	}
+}
+
+func testCommit(sha string, githubID int64, login string) *github.RepositoryCommit {
+	return &github.RepositoryCommit{
+		SHA:    github.String(sha),
+		Author: &github.User{ID: github.Int64(githubID), Login: github.String(login)},
+		Commit: &github.Commit{Author: &github.CommitAuthor{Name: github.String(login), Email: github.String(login + "@example.org")}},
+	}
+}
+
+func setupGitHubStub(t *testing.T, stub *githubStub) func() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating app key failed : %v", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	server := httptest.NewServer(stub.handler(t))
+	githubutils.Init(1, string(keyPEM), "")
+	githubutils.SetAPIBaseURL(server.URL)
+	return func() {
+		githubutils.SetAPIBaseURL("")
+		server.Close()
+	}
+}
+
+func pullRequestEvent(action string) *github.PullRequestEvent {
+	return &github.PullRequestEvent{
+		Action:       github.String(action),
+		Number:       github.Int(testPullRequestID),
+		Installation: &github.Installation{ID: github.Int64(testInstallationID)},
+		Repo: &github.Repository{
+			ID:       github.Int64(testRepositoryID),
+			Name:     github.String("hello"),
+			FullName: github.String("octo/hello"),
+			Owner:    &github.User{Login: github.String("octo")},
+		},
+	}
+}
+
+func TestProcessPullRequestEvent(t *testing.T) {
+	testCases := []struct {
+		name            string
+		signed          map[string]bool
+		expectedState   string
+		expectedComment []string
+	}{
+		{
+			name:            "missing authorization",
+			signed:          map[string]bool{"user-alice": true},
+			expectedState:   "failure",
+			expectedComment: []string{":white_check_mark: alice (sha1)", "bob The commit (sha2) is not authorized under a signed CLA"},
+		},
+		{
+			name:          "all authors authorized",
+			signed:        map[string]bool{"user-alice": true, "user-bob": true},
+			expectedState: "success",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stub := &githubStub{commits: []*github.RepositoryCommit{
+				testCommit("sha1", 100, "alice"),
+				testCommit("sha2", 200, "bob"),
+			}}
+			teardown := setupGitHubStub(t, stub)
+			defer teardown()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			githubRepo := repositoriesmock.NewMockRepository(ctrl)
+			githubRepo.EXPECT().
+				GetRepositoryByGithubID(gomock.Any(), fmt.Sprintf("%d", testRepositoryID), true).
+				Return(&models.GithubRepository{RepositoryProjectID: testClaGroupID}, nil)
+
+			usersRepo := fakeUsersRepo{usersByGitHubID: map[string]*models.User{
+				"github:100": {UserID: "user-alice", GithubUsername: "alice"},
+				"github:200": {UserID: "user-bob", GithubUsername: "bob"},
+			}}
+
+			service := NewService(githubRepo, nil, nil, fakeProjectRepo{}, nil, usersRepo,
+				fakeSignatureService{signed: tc.signed}, "https://api.example.org", "https://easycla.example.org")
+			assert.NoError(t, service.ProcessPullRequestEvent(pullRequestEvent("opened")))
+
+			if assert.Len(t, stub.statuses, 1) {
+				assert.Equal(t, tc.expectedState, stub.statuses[0].GetState())
+				assert.Equal(t, githubutils.StatusContext, stub.statuses[0].GetContext())
+			}
+
+			if len(tc.expectedComment) == 0 {
+				assert.Empty(t, stub.comments)
+				return
+			}
+			if assert.Len(t, stub.comments, 1) {
+				for _, expected := range tc.expectedComment {
+					assert.Contains(t, stub.comments[0], expected)
+				}
+				assert.Contains(t, stub.comments[0], fmt.Sprintf("https://api.example.org/v2/repository-provider/github/sign/%d/%d/%d/#/?version=2",
+					testInstallationID, testRepositoryID, testPullRequestID))
+			}
+		})
+	}
+}
+
+func TestRecheckPullRequests(t *testing.T) {
+	bob := &dynamo_events.PullRequestAuthors{UserIDs: []string{"user-bob"}}
+	testCases := []struct {
+		name             string
+		headState        string
+		signed           map[string]bool
+		authors          *dynamo_events.PullRequestAuthors
+		expectedStatuses int
+		expectedState    string
+	}{
+		{name: "still missing authorization", headState: "failure", signed: map[string]bool{"user-alice": true}, authors: bob},
+		{name: "now authorized", headState: "failure", signed: map[string]bool{"user-alice": true, "user-bob": true}, authors: bob, expectedStatuses: 1, expectedState: "success"},
+		{name: "now authorized by the approval list", headState: "failure", signed: map[string]bool{"user-alice": true, "user-bob": true},
+			authors: &dynamo_events.PullRequestAuthors{ApprovalListChanges: &models.Signature{GithubUsernameApprovalList: []string{"bob"}}}, expectedStatuses: 1, expectedState: "success"},
+		{name: "not failing", headState: "success", signed: map[string]bool{"user-alice": true, "user-bob": true}, authors: bob},
+		{name: "authorization revoked", headState: "success", signed: map[string]bool{"user-alice": true}, authors: bob, expectedStatuses: 1, expectedState: "failure"},
+		{name: "authors not affected", headState: "failure", signed: map[string]bool{"user-alice": true, "user-bob": true},
+			authors: &dynamo_events.PullRequestAuthors{UserIDs: []string{"user-carol"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stub := &githubStub{headState: tc.headState, commits: []*github.RepositoryCommit{
+				testCommit("sha1", 100, "alice"),
+				testCommit("sha2", 200, "bob"),
+			}}
+			teardown := setupGitHubStub(t, stub)
+			defer teardown()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			githubRepo := repositoriesmock.NewMockRepository(ctrl)
+			githubRepo.EXPECT().
+				GetRepositoriesByCLAGroup(gomock.Any(), testClaGroupID, true).
+				Return([]*models.GithubRepository{{
+					RepositoryName:             "octo/hello",
+					RepositoryOrganizationName: "octo",
+					RepositoryExternalID:       fmt.Sprintf("%d", testRepositoryID),
+				}}, nil)
+
+			usersRepo := fakeUsersRepo{usersByGitHubID: map[string]*models.User{
+				"github:100": {UserID: "user-alice", GithubUsername: "alice"},
+				"github:200": {UserID: "user-bob", GithubUsername: "bob"},
+			}}
+
+			service := NewService(githubRepo, nil, nil, fakeProjectRepo{}, fakeGithubOrgRepo{}, usersRepo,
+				fakeSignatureService{signed: tc.signed}, "https://api.example.org", "https://easycla.example.org")
+			assert.NoError(t, service.RecheckPullRequests(context.Background(), testClaGroupID, tc.authors))
+
+			assert.Len(t, stub.statuses, tc.expectedStatuses)
+			for _, status := range stub.statuses {
+				assert.Equal(t, tc.expectedState, status.GetState())
+			}
+			if tc.expectedState == "failure" {
+				assert.Len(t, stub.comments, 1)
+			} else {
+				assert.Empty(t, stub.comments)
+			}
+		})
+	}
+}
+
+func TestGetOpenPullRequestAuthors(t *testing.T) {
+	stub := &githubStub{commits: []*github.RepositoryCommit{
+		testCommit("sha1", 100, "alice"),
+		testCommit("sha2", 200, "bob"),
+		testCommit("sha3", 100, "alice"),
+		testCommit("sha4", 300, "carol"),
+	}}
+	teardown := setupGitHubStub(t, stub)
+	defer teardown()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	githubRepo := repositoriesmock.NewMockRepository(ctrl)
+	githubRepo.EXPECT().
+		GetRepositoriesByCLAGroup(gomock.Any(), testClaGroupID, true).
+		Return([]*models.GithubRepository{{
+			RepositoryName:             "octo/hello",
+			RepositoryOrganizationName: "octo",
+			RepositoryExternalID:       fmt.Sprintf("%d", testRepositoryID),
+		}}, nil)
+
+	// carol has no EasyCLA user record
+	usersRepo := fakeUsersRepo{usersByGitHubID: map[string]*models.User{
+		"github:100": {UserID: "user-alice", GithubUsername: "alice"},
+		"github:200": {UserID: "user-bob", GithubUsername: "bob"},
+	}}
+
+	service := NewService(githubRepo, nil, nil, fakeProjectRepo{}, fakeGithubOrgRepo{}, usersRepo, nil, "", "")
+	authors, err := service.GetOpenPullRequestAuthors(context.Background(), testClaGroupID)
+	assert.NoError(t, err)
+	if assert.Len(t, authors, 2) {
+		assert.Equal(t, "user-alice", authors[0].UserID)
+		assert.Equal(t, "user-bob", authors[1].UserID)
+	}
+	assert.Empty(t, stub.statuses)
+	assert.Empty(t, stub.comments)
+}
+
+func TestProcessPullRequestEventIgnoredAction(t *testing.T) {
+	service := NewService(nil, nil, nil, nil, nil, nil, nil, "", "")
+	assert.NoError(t, service.ProcessPullRequestEvent(pullRequestEvent("closed")))
+}
@@ -18,31 +18,74 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/google/go-github/v33/github"
 )
 
+// constants
+const (
+	DontLoadRepoDetails = false
+	// RecentPullRequestsPerRepository is the number of most recent open pull requests inspected per repository when
+	// looking up the pull request authors
+	RecentPullRequestsPerRepository = 50
+	// RecheckWorkers is the number of repositories whose pull requests are re-checked concurrently
+	RecheckWorkers = 5
+)
+
 // Service is responsible for handling the github activity events
 type Service interface {
 	ProcessInstallationRepositoriesEvent(event *github.InstallationRepositoriesEvent) error
 	ProcessRepositoryEvent(*github.RepositoryEvent) error
+	ProcessPullRequestEvent(event *github.PullRequestEvent) error
+	RecheckPullRequests(ctx context.Context, claGroupID string, authors *dynamo_events.PullRequestAuthors) error
+	GetOpenPullRequestAuthors(ctx context.Context, claGroupID string) ([]*models.User, error)
+}
+
+// ProjectRepo contains project repo methods
+type ProjectRepo interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error)
+}
+
+// GithubOrgRepo contains the github organization repo methods
+type GithubOrgRepo interface {
+	GetGithubOrganization(ctx context.Context, githubOrganizationName string) (*models.GithubOrganization, error)
 }
 
 type eventHandlerService struct {
 	githubRepo        repositories.Repository
 	eventService      events.Service
 	autoEnableService dynamo_events.AutoEnableService
+	projectRepo       ProjectRepo
+	githubOrgRepo     GithubOrgRepo
+	usersRepo         users.UserRepository
+	signatureService  signatures.SignatureService
+	claV1ApiURL       string
+	claLandingPage    string
 }
 
 // NewService creates a new instance of the Event Handler Service
 func NewService(githubRepo repositories.Repository,
 	eventService events.Service,
-	autoEnableService dynamo_events.AutoEnableService) Service {
+	autoEnableService dynamo_events.AutoEnableService,
+	projectRepo ProjectRepo,
+	githubOrgRepo GithubOrgRepo,
+	usersRepo users.UserRepository,
+	signatureService signatures.SignatureService,
+	claV1ApiURL string,
+	claLandingPage string) Service {
 	return &eventHandlerService{
 		githubRepo:        githubRepo,
 		eventService:      eventService,
 		autoEnableService: autoEnableService,
+		projectRepo:       projectRepo,
+		githubOrgRepo:     githubOrgRepo,
+		usersRepo:         usersRepo,
+		signatureService:  signatureService,
+		claV1ApiURL:       claV1ApiURL,
+		claLandingPage:    claLandingPage,
 	}
 }
 
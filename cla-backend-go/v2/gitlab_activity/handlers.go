@@ -0,0 +1,82 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/gitlab_activity"
+	"github.com/communitybridge/easycla/cla-backend-go/gitlab"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// gitlab event header values
+const (
+	mergeRequestHook = "Merge Request Hook"
+)
+
+// tokenCheckMiddleware validates the webhook secret token sent by GitLab before the request is processed
+func tokenCheckMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !gitlab.ValidateWebhookToken(r.Header.Get("X-Gitlab-Token")) {
+			http.Error(w, "token check failure", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service) {
+	api.GitlabActivityGitlabActivityHandler = gitlab_activity.GitlabActivityHandlerFunc(
+		func(params gitlab_activity.GitlabActivityParams) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			gitlabEvent := utils.StringValue(params.XGITLABEVENT)
+			if gitlabEvent == "" {
+				return gitlab_activity.NewGitlabActivityBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: "missing gitlab event",
+				})
+			}
+
+			payload, err := params.GitlabActivityInput.MarshalJSON()
+			if err != nil {
+				return gitlab_activity.NewGitlabActivityBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: "json marshall",
+				})
+			}
+
+			var processError error
+			switch gitlabEvent {
+			case mergeRequestHook:
+				var event gitlab.MergeRequestEvent
+				if err := json.Unmarshal(payload, &event); err != nil {
+					return gitlab_activity.NewGitlabActivityBadRequest().WithPayload(&models.ErrorResponse{
+						Code:    "400",
+						Message: fmt.Sprintf("parsing event failed : %v", err),
+					})
+				}
+				processError = service.ProcessMergeRequestEvent(ctx, &event)
+			default:
+				log.Warnf("unsupported event sent : %s", gitlabEvent)
+			}
+
+			if processError != nil {
+				log.Warnf("processing event : %s failed with : %v", gitlabEvent, processError)
+			}
+
+			return gitlab_activity.NewGitlabActivityOK()
+		})
+	api.AddMiddlewareFor("POST", "/gitlab/activity", tokenCheckMiddleware)
+}
@@ -0,0 +1,139 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
+	"github.com/communitybridge/easycla/cla-backend-go/gitlab"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ProcessMergeRequestEvent runs the CLA check for the merge request when it is opened, reopened or receives new commits
+func (s *eventHandlerService) ProcessMergeRequestEvent(ctx context.Context, event *gitlab.MergeRequestEvent) error {
+	f := logrus.Fields{
+		"functionName":    "ProcessMergeRequestEvent",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"projectID":       event.Project.ID,
+		"projectName":     event.Project.PathWithNamespace,
+		"mergeRequestIID": event.ObjectAttributes.IID,
+		"action":          event.ObjectAttributes.Action,
+	}
+
+	switch event.ObjectAttributes.Action {
+	case "open", "reopen", "update":
+	default:
+		log.WithFields(f).Debug("no handler for merge request action")
+		return nil
+	}
+
+	// the status is posted on the target project, which is the project enabled in EasyCLA
+	projectID := event.ObjectAttributes.TargetProjectID
+	if projectID == 0 {
+		projectID = event.Project.ID
+	}
+	if projectID == 0 || event.ObjectAttributes.IID == 0 {
+		return fmt.Errorf("missing project id or merge request iid")
+	}
+
+	gitlabProject, err := s.gitlabProjectRepo.GetGitlabProjectByExternalID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gitlab_organizations.ErrProjectDoesNotExist) {
+			log.WithFields(f).Warn("merge request event for a project which is not enabled, nothing to do")
+			return nil
+		}
+		return err
+	}
+	if !gitlabProject.Enabled {
+		log.WithFields(f).Warn("merge request event for a project which is disabled, nothing to do")
+		return nil
+	}
+	f["claGroupID"] = gitlabProject.ClaGroupID
+
+	commits, err := gitlab.GetMergeRequestCommits(ctx, projectID, event.ObjectAttributes.IID)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		log.WithFields(f).Warn("merge request has no commits, nothing to do")
+		return nil
+	}
+
+	// the commits are listed newest first
+	latestSHA := event.ObjectAttributes.LastCommit.ID
+	if latestSHA == "" {
+		latestSHA = commits[0].ID
+	}
+
+	var missing []string
+	for _, commit := range commits {
+		authorized, checkErr := s.isCommitAuthorized(ctx, gitlabProject.ClaGroupID, commit)
+		if checkErr != nil {
+			log.WithFields(f).WithError(checkErr).Warnf("unable to check commit author for commit: %s", commit.ID)
+		}
+		if !authorized {
+			missing = append(missing, fmt.Sprintf("%s <%s> (%s)", commit.AuthorName, commit.AuthorEmail, commit.ShortID))
+		}
+	}
+
+	status := &forge.CommitStatus{
+		State:       forge.StatusStateSuccess,
+		Context:     gitlab.StatusContext,
+		Description: gitlab.StatusDescriptionSigned,
+		TargetURL:   s.claLandingPage,
+	}
+	if len(missing) > 0 {
+		log.WithFields(f).Debugf("unauthorized commits: %s", strings.Join(missing, ", "))
+		status.State, status.Description, status.TargetURL = forge.StatusStateFailure, gitlab.StatusDescriptionMissing, s.signURL(gitlabProject.ClaGroupID)
+	}
+
+	log.WithFields(f).Debugf("posting commit status: %s on commit: %s", status.State, latestSHA)
+	return s.statusPoster.PostStatus(ctx, "", strconv.FormatInt(projectID, 10), latestSHA, status)
+}
+
+// isCommitAuthorized resolves the GitLab account of the commit author, then the EasyCLA user linked to the GitLab
+// username, and checks the user's CLA authorization. The commit author email is only used to find the GitLab account:
+// an email which is not registered with GitLab, or a GitLab user without an EasyCLA user, is not authorized.
+func (s *eventHandlerService) isCommitAuthorized(ctx context.Context, claGroupID string, commit *gitlab.Commit) (bool, error) {
+	if commit.AuthorEmail == "" {
+		return false, nil
+	}
+
+	gitlabUser, err := gitlab.GetUserByEmail(ctx, commit.AuthorEmail)
+	if err != nil {
+		if errors.Is(err, gitlab.ErrNotFound) {
+			log.Debugf("no gitlab user for commit author email: %s", commit.AuthorEmail)
+			return false, nil
+		}
+		return false, err
+	}
+
+	userModel, err := s.usersRepo.GetUserByGitLabUsername(gitlabUser.Username)
+	if err != nil {
+		if openAPIErr, ok := err.(openapierrors.Error); ok && openAPIErr.Code() == http.StatusNotFound {
+			log.Debugf("no EasyCLA user record for gitlab username: %s", gitlabUser.Username)
+			return false, nil
+		}
+		return false, err
+	}
+	if userModel == nil {
+		return false, nil
+	}
+
+	signed, _, err := s.signatureService.HasUserSigned(ctx, userModel, claGroupID)
+	if err != nil {
+		return false, err
+	}
+	return signed, nil
+}
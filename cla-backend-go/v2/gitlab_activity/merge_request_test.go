@@ -0,0 +1,198 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gitlab"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/gitlab_organizations"
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testProjectID       = 278964
+	testMergeRequestIID = 5
+	testClaGroupID      = "b1e86e26-d8c8-4fd8-9f8d-5c723d5dac9f"
+)
+
+type fakeGitlabProjectRepo struct {
+	enabled map[int64]bool
+}
+
+func (r fakeGitlabProjectRepo) GetGitlabProjectByExternalID(ctx context.Context, projectExternalID int64) (*models.GitlabProject, error) {
+	enabled, ok := r.enabled[projectExternalID]
+	if !ok {
+		return nil, gitlab_organizations.ErrProjectDoesNotExist
+	}
+	return &models.GitlabProject{ProjectExternalID: projectExternalID, ClaGroupID: testClaGroupID, Enabled: enabled}, nil
+}
+
+type fakeUsersRepo map[string]*v1Models.User
+
+func (r fakeUsersRepo) GetUserByGitLabUsername(gitLabUsername string) (*v1Models.User, error) {
+	if user, ok := r[gitLabUsername]; ok {
+		return user, nil
+	}
+	return nil, openapierrors.NotFound("user not found when searching by user_gitlab_username: %s", gitLabUsername)
+}
+
+// fakeSignatureService reports the users in the signed list as authorized
+type fakeSignatureService map[string]bool
+
+func (s fakeSignatureService) HasUserSigned(ctx context.Context, user *v1Models.User, claGroupID string) (bool, bool, error) {
+	return s[user.UserID], false, nil
+}
+
+// gitlabStub is a minimal local GitLab API recording the statuses posted by the CLA check
+type gitlabStub struct {
+	mu       sync.Mutex
+	commits  []*gitlab.Commit
+	users    map[string]*gitlab.User
+	statuses map[string]*gitlab.CommitStatus
+}
+
+func setupGitlabStub(t *testing.T, stub *gitlabStub) func() {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/api/v4/projects/%d/merge_requests/%d/commits", testProjectID, testMergeRequestIID), func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(stub.commits))
+	})
+	mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		users := []*gitlab.User{}
+		if user, ok := stub.users[r.URL.Query().Get("search")]; ok {
+			users = append(users, user)
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(users))
+	})
+	mux.HandleFunc(fmt.Sprintf("/api/v4/projects/%d/statuses/", testProjectID), func(w http.ResponseWriter, r *http.Request) {
+		var status gitlab.CommitStatus
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&status))
+		stub.mu.Lock()
+		stub.statuses[r.URL.Path[len(fmt.Sprintf("/api/v4/projects/%d/statuses/", testProjectID)):]] = &status
+		stub.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		assert.NoError(t, json.NewEncoder(w).Encode(status))
+	})
+
+	server := httptest.NewServer(mux)
+	gitlab.Init(server.URL+"/api/v4", "test-token", "test-secret")
+	return func() {
+		gitlab.Init("", "", "")
+		server.Close()
+	}
+}
+
+func mergeRequestEvent(action string) *gitlab.MergeRequestEvent {
+	event := &gitlab.MergeRequestEvent{
+		ObjectKind: "merge_request",
+		Project:    gitlab.Project{ID: testProjectID, PathWithNamespace: "foundation/hello"},
+	}
+	event.ObjectAttributes.IID = testMergeRequestIID
+	event.ObjectAttributes.Action = action
+	event.ObjectAttributes.TargetProjectID = testProjectID
+	event.ObjectAttributes.LastCommit.ID = "sha2"
+	return event
+}
+
+func TestProcessMergeRequestEvent(t *testing.T) {
+	testCases := []struct {
+		name              string
+		signed            fakeSignatureService
+		expectedState     string
+		expectedTargetURL string
+	}{
+		{
+			name:              "missing authorization",
+			signed:            fakeSignatureService{"user-alice": true},
+			expectedState:     gitlab.StatusFailed,
+			expectedTargetURL: "https://contributor.example.org/#/cla/project/" + testClaGroupID,
+		},
+		{
+			name:              "all authors authorized",
+			signed:            fakeSignatureService{"user-alice": true, "user-bob": true},
+			expectedState:     gitlab.StatusSuccess,
+			expectedTargetURL: "https://easycla.example.org",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stub := &gitlabStub{
+				commits: []*gitlab.Commit{
+					{ID: "sha2", ShortID: "sha2", AuthorName: "bob", AuthorEmail: "bob@example.org"},
+					{ID: "sha1", ShortID: "sha1", AuthorName: "alice", AuthorEmail: "alice@example.org"},
+				},
+				users: map[string]*gitlab.User{
+					"alice@example.org": {ID: 1, Username: "alice"},
+					"bob@example.org":   {ID: 2, Username: "bob"},
+				},
+				statuses: map[string]*gitlab.CommitStatus{},
+			}
+			teardown := setupGitlabStub(t, stub)
+			defer teardown()
+
+			usersRepo := fakeUsersRepo{
+				"alice": {UserID: "user-alice"},
+				"bob":   {UserID: "user-bob"},
+			}
+			service := NewService(fakeGitlabProjectRepo{enabled: map[int64]bool{testProjectID: true}}, usersRepo, tc.signed,
+				"contributor.example.org", "https://easycla.example.org")
+			assert.NoError(t, service.ProcessMergeRequestEvent(context.Background(), mergeRequestEvent("open")))
+
+			if assert.Contains(t, stub.statuses, "sha2") {
+				assert.Equal(t, tc.expectedState, stub.statuses["sha2"].State)
+				assert.Equal(t, gitlab.StatusContext, stub.statuses["sha2"].Name)
+				assert.Equal(t, tc.expectedTargetURL, stub.statuses["sha2"].TargetURL)
+			}
+		})
+	}
+}
+
+func TestProcessMergeRequestEventUnknownAuthor(t *testing.T) {
+	// the email of an authorized user, which is not registered with the gitlab account of the author
+	stub := &gitlabStub{
+		commits:  []*gitlab.Commit{{ID: "sha2", AuthorName: "mallory", AuthorEmail: "alice@example.org"}},
+		users:    map[string]*gitlab.User{},
+		statuses: map[string]*gitlab.CommitStatus{},
+	}
+	teardown := setupGitlabStub(t, stub)
+	defer teardown()
+
+	usersRepo := fakeUsersRepo{"alice": {UserID: "user-alice"}}
+	service := NewService(fakeGitlabProjectRepo{enabled: map[int64]bool{testProjectID: true}}, usersRepo, fakeSignatureService{"user-alice": true}, "", "")
+	assert.NoError(t, service.ProcessMergeRequestEvent(context.Background(), mergeRequestEvent("update")))
+	if assert.Contains(t, stub.statuses, "sha2") {
+		assert.Equal(t, gitlab.StatusFailed, stub.statuses["sha2"].State)
+	}
+
+	// a gitlab user without an EasyCLA user
+	stub.users["mallory@example.org"] = &gitlab.User{ID: 3, Username: "mallory"}
+	stub.commits[0].AuthorEmail = "mallory@example.org"
+	assert.NoError(t, service.ProcessMergeRequestEvent(context.Background(), mergeRequestEvent("update")))
+	if assert.Contains(t, stub.statuses, "sha2") {
+		assert.Equal(t, gitlab.StatusFailed, stub.statuses["sha2"].State)
+	}
+}
+
+func TestProcessMergeRequestEventIgnored(t *testing.T) {
+	stub := &gitlabStub{statuses: map[string]*gitlab.CommitStatus{}}
+	teardown := setupGitlabStub(t, stub)
+	defer teardown()
+
+	service := NewService(fakeGitlabProjectRepo{enabled: map[int64]bool{}}, fakeUsersRepo{}, fakeSignatureService{}, "", "")
+	// the action is not handled
+	assert.NoError(t, service.ProcessMergeRequestEvent(context.Background(), mergeRequestEvent("merge")))
+	// the project is not enabled in EasyCLA
+	assert.NoError(t, service.ProcessMergeRequestEvent(context.Background(), mergeRequestEvent("open")))
+	assert.Empty(t, stub.statuses)
+}
@@ -0,0 +1,71 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_activity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gitlab"
+)
+
+// Service is responsible for handling the gitlab webhook events
+type Service interface {
+	ProcessMergeRequestEvent(ctx context.Context, event *gitlab.MergeRequestEvent) error
+}
+
+// GitlabProjectRepo provides the method to lookup the EasyCLA enabled gitlab project
+type GitlabProjectRepo interface {
+	GetGitlabProjectByExternalID(ctx context.Context, projectExternalID int64) (*models.GitlabProject, error)
+}
+
+// contributorConsoleCLAGroupURLPath is the contributor console page of the CLA Group, where the contributors sign
+const contributorConsoleCLAGroupURLPath = "%s/#/cla/project/%s"
+
+// UserRepo provides the method to lookup the EasyCLA user of a commit author
+type UserRepo interface {
+	GetUserByGitLabUsername(gitLabUsername string) (*v1Models.User, error)
+}
+
+// SignatureService provides the method to check the CLA authorization of a user
+type SignatureService interface {
+	HasUserSigned(ctx context.Context, user *v1Models.User, claGroupID string) (bool, bool, error)
+}
+
+type eventHandlerService struct {
+	gitlabProjectRepo     GitlabProjectRepo
+	usersRepo             UserRepo
+	signatureService      SignatureService
+	statusPoster          forge.StatusPoster
+	contributorConsoleURL string
+	claLandingPage        string
+}
+
+// NewService creates a new instance of the gitlab activity event handler service
+func NewService(gitlabProjectRepo GitlabProjectRepo, usersRepo UserRepo, signatureService SignatureService, contributorConsoleURL, claLandingPage string) Service {
+	return &eventHandlerService{
+		gitlabProjectRepo:     gitlabProjectRepo,
+		usersRepo:             usersRepo,
+		signatureService:      signatureService,
+		statusPoster:          gitlab.StatusPoster{},
+		contributorConsoleURL: contributorConsoleURL,
+		claLandingPage:        claLandingPage,
+	}
+}
+
+// signURL returns the contributor console page of the CLA Group, the CLA landing page if the console is not configured
+func (s *eventHandlerService) signURL(claGroupID string) string {
+	if s.contributorConsoleURL == "" {
+		return s.claLandingPage
+	}
+	base := strings.TrimSuffix(s.contributorConsoleURL, "/")
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		base = "https://" + base
+	}
+	return fmt.Sprintf(contributorConsoleCLAGroupURLPath, base, claGroupID)
+}
@@ -0,0 +1,291 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/gitlab_organizations"
+	"github.com/communitybridge/easycla/cla-backend-go/gitlab"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, eventService events.Service) {
+
+	api.GitlabOrganizationsGetProjectGitlabOrganizationsHandler = gitlab_organizations.GetProjectGitlabOrganizationsHandlerFunc(
+		func(params gitlab_organizations.GetProjectGitlabOrganizationsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "gitlab_organizations.handlers.GitlabOrganizationsGetProjectGitlabOrganizationsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Get Project GitLab Organizations with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewGetProjectGitlabOrganizationsForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetGitlabOrganizations(ctx, params.ProjectSFID)
+			if err != nil {
+				msg := fmt.Sprintf("failed to locate gitlab organizations by project SFID: %s, error: %+v", params.ProjectSFID, err)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewGetProjectGitlabOrganizationsBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitlab_organizations.NewGetProjectGitlabOrganizationsOK().WithPayload(result)
+		})
+
+	api.GitlabOrganizationsAddProjectGitlabOrganizationHandler = gitlab_organizations.AddProjectGitlabOrganizationHandlerFunc(
+		func(params gitlab_organizations.AddProjectGitlabOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "gitlab_organizations.handlers.GitlabOrganizationsAddProjectGitlabOrganizationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Add Project GitLab Organizations with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewAddProjectGitlabOrganizationForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			if params.Body == nil || params.Body.OrganizationName == nil {
+				msg := fmt.Sprintf("missing organization name in body: %+v", params.Body)
+				log.WithFields(f).Warn(msg)
+				return gitlab_organizations.NewAddProjectGitlabOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			f["organizationName"] = utils.StringValue(params.Body.OrganizationName)
+
+			result, err := service.AddGitlabOrganization(ctx, params.ProjectSFID, params.Body)
+			if err != nil {
+				if errors.Is(err, ErrOrganizationExists) {
+					msg := fmt.Sprintf("gitlab organization %s is already registered", utils.StringValue(params.Body.OrganizationName))
+					log.WithFields(f).Debug(msg)
+					return gitlab_organizations.NewAddProjectGitlabOrganizationConflict().WithPayload(
+						utils.ErrorResponseConflictWithError(reqID, msg, err))
+				}
+				if errors.Is(err, gitlab.ErrNotFound) {
+					msg := fmt.Sprintf("gitlab group %s not found or not accessible by EasyCLA", utils.StringValue(params.Body.OrganizationName))
+					log.WithFields(f).Debug(msg)
+					return gitlab_organizations.NewAddProjectGitlabOrganizationBadRequest().WithPayload(
+						utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("unable to add gitlab organization, error: %+v", err)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitlab_organizations.NewAddProjectGitlabOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.GitLabOrganizationAdded,
+				ExternalProjectID: params.ProjectSFID,
+				EventData: &events.GitLabOrganizationAddedEventData{
+					GitLabOrganizationName: result.OrganizationName,
+				},
+			})
+
+			return gitlab_organizations.NewAddProjectGitlabOrganizationOK().WithPayload(result)
+		})
+
+	api.GitlabOrganizationsDeleteProjectGitlabOrganizationHandler = gitlab_organizations.DeleteProjectGitlabOrganizationHandlerFunc(
+		func(params gitlab_organizations.DeleteProjectGitlabOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "gitlab_organizations.handlers.GitlabOrganizationsDeleteProjectGitlabOrganizationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"organizationID": params.OrganizationID,
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Delete Project GitLab Organizations with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewDeleteProjectGitlabOrganizationForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			org, err := service.DeleteGitlabOrganization(ctx, params.ProjectSFID, params.OrganizationID)
+			if err != nil {
+				if errors.Is(err, ErrOrganizationDoesNotExist) {
+					msg := fmt.Sprintf("gitlab organization %s not found for project SFID: %s", params.OrganizationID, params.ProjectSFID)
+					log.WithFields(f).Debug(msg)
+					return gitlab_organizations.NewDeleteProjectGitlabOrganizationNotFound().WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem deleting GitLab Organization with project SFID: %s for organization: %s", params.ProjectSFID, params.OrganizationID)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewDeleteProjectGitlabOrganizationBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.GitLabOrganizationDeleted,
+				ExternalProjectID: params.ProjectSFID,
+				EventData: &events.GitLabOrganizationDeletedEventData{
+					GitLabOrganizationName: org.OrganizationName,
+				},
+			})
+
+			return gitlab_organizations.NewDeleteProjectGitlabOrganizationNoContent()
+		})
+
+	api.GitlabOrganizationsGetProjectGitlabProjectsHandler = gitlab_organizations.GetProjectGitlabProjectsHandlerFunc(
+		func(params gitlab_organizations.GetProjectGitlabProjectsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "gitlab_organizations.handlers.GitlabOrganizationsGetProjectGitlabProjectsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Get Project GitLab Projects with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewGetProjectGitlabProjectsForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetGitlabProjects(ctx, params.ProjectSFID)
+			if err != nil {
+				msg := fmt.Sprintf("failed to locate gitlab projects by project SFID: %s, error: %+v", params.ProjectSFID, err)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewGetProjectGitlabProjectsBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitlab_organizations.NewGetProjectGitlabProjectsOK().WithPayload(result)
+		})
+
+	api.GitlabOrganizationsAddProjectGitlabProjectsHandler = gitlab_organizations.AddProjectGitlabProjectsHandlerFunc(
+		func(params gitlab_organizations.AddProjectGitlabProjectsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "gitlab_organizations.handlers.GitlabOrganizationsAddProjectGitlabProjectsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Add Project GitLab Projects with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewAddProjectGitlabProjectsForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			if params.GitlabProjectInput == nil || len(params.GitlabProjectInput.ProjectGitlabIds) == 0 {
+				msg := "missing gitlab project ids in body"
+				log.WithFields(f).Warn(msg)
+				return gitlab_organizations.NewAddProjectGitlabProjectsBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+
+			result, err := service.AddGitlabProjects(ctx, params.ProjectSFID, params.GitlabProjectInput)
+			if err != nil {
+				msg := fmt.Sprintf("unable to add gitlab projects, error: %+v", err)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitlab_organizations.NewAddProjectGitlabProjectsBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			for _, project := range result.List {
+				eventService.LogEvent(&events.LogEventArgs{
+					LfUsername:        authUser.UserName,
+					EventType:         events.GitLabProjectAdded,
+					ExternalProjectID: params.ProjectSFID,
+					ProjectID:         project.ClaGroupID,
+					EventData: &events.GitLabProjectAddedEventData{
+						GitLabProjectName: project.ProjectName,
+					},
+				})
+			}
+
+			return gitlab_organizations.NewAddProjectGitlabProjectsOK().WithPayload(result)
+		})
+
+	api.GitlabOrganizationsDeleteProjectGitlabProjectHandler = gitlab_organizations.DeleteProjectGitlabProjectHandlerFunc(
+		func(params gitlab_organizations.DeleteProjectGitlabProjectParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			f := logrus.Fields{
+				"functionName":   "gitlab_organizations.handlers.GitlabOrganizationsDeleteProjectGitlabProjectHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"projectSFID":    params.ProjectSFID,
+				"projectID":      params.ProjectID,
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Delete Project GitLab Projects with Project scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewDeleteProjectGitlabProjectForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			project, err := service.DeleteGitlabProject(ctx, params.ProjectSFID, params.ProjectID)
+			if err != nil {
+				if errors.Is(err, ErrProjectDoesNotExist) {
+					msg := fmt.Sprintf("gitlab project %s not found for project SFID: %s", params.ProjectID, params.ProjectSFID)
+					log.WithFields(f).Debug(msg)
+					return gitlab_organizations.NewDeleteProjectGitlabProjectNotFound().WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem deleting GitLab project with project SFID: %s for project: %s", params.ProjectSFID, params.ProjectID)
+				log.WithFields(f).Debug(msg)
+				return gitlab_organizations.NewDeleteProjectGitlabProjectBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			eventService.LogEvent(&events.LogEventArgs{
+				LfUsername:        authUser.UserName,
+				EventType:         events.GitLabProjectDeleted,
+				ExternalProjectID: params.ProjectSFID,
+				ProjectID:         project.ClaGroupID,
+				EventData: &events.GitLabProjectDeletedEventData{
+					GitLabProjectName: project.ProjectName,
+				},
+			})
+
+			return gitlab_organizations.NewDeleteProjectGitlabProjectNoContent()
+		})
+}
@@ -0,0 +1,65 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import "github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+
+// GitlabOrganization is data model for the gitlab organizations (groups) table
+type GitlabOrganization struct {
+	OrganizationID         string `json:"organization_id"`
+	OrganizationName       string `json:"organization_name"`
+	OrganizationNameLower  string `json:"organization_name_lower"`
+	OrganizationExternalID int64  `json:"organization_external_id"`
+	OrganizationURL        string `json:"organization_url,omitempty"`
+	OrganizationSFID       string `json:"organization_sfid"`
+	ProjectSFID            string `json:"project_sfid"`
+	DateCreated            string `json:"date_created,omitempty"`
+	DateModified           string `json:"date_modified,omitempty"`
+	Version                string `json:"version,omitempty"`
+}
+
+// GitlabProject is data model for the gitlab projects table
+type GitlabProject struct {
+	ProjectID         string `json:"project_id"`
+	ProjectName       string `json:"project_name"`
+	ProjectExternalID int64  `json:"project_external_id"`
+	ProjectURL        string `json:"project_url,omitempty"`
+	OrganizationName  string `json:"organization_name"`
+	ClaGroupID        string `json:"cla_group_id"`
+	ProjectSFID       string `json:"project_sfid"`
+	Enabled           bool   `json:"enabled"`
+	DateCreated       string `json:"date_created,omitempty"`
+	DateModified      string `json:"date_modified,omitempty"`
+	Version           string `json:"version,omitempty"`
+}
+
+func (in *GitlabOrganization) toModel() *models.GitlabOrganization {
+	return &models.GitlabOrganization{
+		OrganizationID:         in.OrganizationID,
+		OrganizationName:       in.OrganizationName,
+		OrganizationExternalID: in.OrganizationExternalID,
+		OrganizationURL:        in.OrganizationURL,
+		OrganizationSfid:       in.OrganizationSFID,
+		ProjectSFID:            in.ProjectSFID,
+		DateCreated:            in.DateCreated,
+		DateModified:           in.DateModified,
+		Version:                in.Version,
+	}
+}
+
+func (in *GitlabProject) toModel() *models.GitlabProject {
+	return &models.GitlabProject{
+		ProjectID:         in.ProjectID,
+		ProjectName:       in.ProjectName,
+		ProjectExternalID: in.ProjectExternalID,
+		ProjectURL:        in.ProjectURL,
+		OrganizationName:  in.OrganizationName,
+		ClaGroupID:        in.ClaGroupID,
+		ProjectSfid:       in.ProjectSFID,
+		Enabled:           in.Enabled,
+		DateCreated:       in.DateCreated,
+		DateModified:      in.DateModified,
+		Version:           in.Version,
+	}
+}
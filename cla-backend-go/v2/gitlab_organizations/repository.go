@@ -0,0 +1,441 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gitlab"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// indexes
+const (
+	GitlabOrgProjectSFIDIndex      = "gitlab-org-project-sfid-index"
+	GitlabOrgLowerNameIndex        = "gitlab-org-name-lower-search-index"
+	GitlabProjectExternalIDIndex   = "gitlab-project-external-id-index"
+	GitlabProjectProjectSFIDIndex  = "gitlab-project-project-sfid-index"
+	GitlabProjectOrganizationIndex = "gitlab-project-organization-name-index"
+)
+
+// errors
+var (
+	ErrOrganizationDoesNotExist = errors.New("gitlab organization does not exist in cla")
+	ErrOrganizationExists       = errors.New("gitlab organization already exists in cla")
+	ErrProjectDoesNotExist      = errors.New("gitlab project does not exist in cla")
+)
+
+// Repository interface defines the functions for the gitlab organizations and projects data model
+type Repository interface {
+	AddGitlabOrganization(ctx context.Context, parentProjectSFID string, projectSFID string, group *gitlab.Group) (*models.GitlabOrganization, error)
+	GetGitlabOrganizations(ctx context.Context, projectSFID string) (*models.GitlabOrganizations, error)
+	GetGitlabOrganization(ctx context.Context, organizationID string) (*models.GitlabOrganization, error)
+	GetGitlabOrganizationByName(ctx context.Context, organizationName string) (*models.GitlabOrganization, error)
+	DeleteGitlabOrganization(ctx context.Context, organizationID string) error
+	AddGitlabProject(ctx context.Context, projectSFID string, claGroupID string, organizationName string, project *gitlab.Project) (*models.GitlabProject, error)
+	GetGitlabProject(ctx context.Context, projectID string) (*models.GitlabProject, error)
+	GetGitlabProjectByExternalID(ctx context.Context, projectExternalID int64) (*models.GitlabProject, error)
+	GetGitlabProjects(ctx context.Context, projectSFID string) (*models.GitlabProjects, error)
+	GetGitlabProjectsByOrganization(ctx context.Context, organizationName string) (*models.GitlabProjects, error)
+	DeleteGitlabProject(ctx context.Context, projectID string) error
+}
+
+type repository struct {
+	stage                  string
+	dynamoDBClient         *dynamodb.DynamoDB
+	gitlabOrgTableName     string
+	gitlabProjectTableName string
+}
+
+// NewRepository creates a new instance of the gitlab organizations repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return repository{
+		stage:                  stage,
+		dynamoDBClient:         dynamodb.New(awsSession),
+		gitlabOrgTableName:     fmt.Sprintf("cla-%s-gitlab-orgs", stage),
+		gitlabProjectTableName: fmt.Sprintf("cla-%s-gitlab-projects", stage),
+	}
+}
+
+// AddGitlabOrganization adds the gitlab group to the project
+func (repo repository) AddGitlabOrganization(ctx context.Context, parentProjectSFID string, projectSFID string, group *gitlab.Group) (*models.GitlabOrganization, error) {
+	f := logrus.Fields{
+		"functionName":      "AddGitlabOrganization",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"parentProjectSFID": parentProjectSFID,
+		"projectSFID":       projectSFID,
+		"organizationName":  group.FullPath,
+	}
+
+	existing, err := repo.GetGitlabOrganizationByName(ctx, group.FullPath)
+	if err != nil && err != ErrOrganizationDoesNotExist {
+		log.WithFields(f).WithError(err).Warn("unable to lookup existing gitlab organization by name")
+		return nil, err
+	}
+	if existing != nil {
+		log.WithFields(f).Warnf("gitlab organization already registered with project SFID: %s", existing.ProjectSFID)
+		return nil, ErrOrganizationExists
+	}
+
+	organizationID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	_, currentTime := utils.CurrentTime()
+	org := &GitlabOrganization{
+		OrganizationID:         organizationID.String(),
+		OrganizationName:       group.FullPath,
+		OrganizationNameLower:  strings.ToLower(group.FullPath),
+		OrganizationExternalID: group.ID,
+		OrganizationURL:        group.WebURL,
+		OrganizationSFID:       parentProjectSFID,
+		ProjectSFID:            projectSFID,
+		DateCreated:            currentTime,
+		DateModified:           currentTime,
+		Version:                "v1",
+	}
+	av, err := dynamodbattribute.MarshalMap(org)
+	if err != nil {
+		log.WithFields(f).Warnf("problem marshalling the input, error: %+v", err)
+		return nil, err
+	}
+
+	log.WithFields(f).Debug("creating gitlab organization entry")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.gitlabOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("cannot put gitlab organization in dynamodb")
+		return nil, err
+	}
+
+	return org.toModel(), nil
+}
+
+// GetGitlabOrganizations returns the gitlab groups of the project
+func (repo repository) GetGitlabOrganizations(ctx context.Context, projectSFID string) (*models.GitlabOrganizations, error) {
+	f := logrus.Fields{
+		"functionName":   "GetGitlabOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+	}
+
+	orgs, err := repo.queryOrganizations(GitlabOrgProjectSFIDIndex, "project_sfid", projectSFID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem querying gitlab organizations")
+		return nil, err
+	}
+
+	out := &models.GitlabOrganizations{List: make([]*models.GitlabOrganization, 0, len(orgs))}
+	for _, org := range orgs {
+		out.List = append(out.List, org.toModel())
+	}
+	return out, nil
+}
+
+// GetGitlabOrganization returns the gitlab group by its internal ID
+func (repo repository) GetGitlabOrganization(ctx context.Context, organizationID string) (*models.GitlabOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "GetGitlabOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_id": {S: aws.String(organizationID)},
+		},
+		TableName: aws.String(repo.gitlabOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading gitlab organization")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrOrganizationDoesNotExist
+	}
+
+	var org GitlabOrganization
+	err = dynamodbattribute.UnmarshalMap(result.Item, &org)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+		return nil, err
+	}
+	return org.toModel(), nil
+}
+
+// GetGitlabOrganizationByName returns the gitlab group by its full path - case insensitive
+func (repo repository) GetGitlabOrganizationByName(ctx context.Context, organizationName string) (*models.GitlabOrganization, error) {
+	f := logrus.Fields{
+		"functionName":     "GetGitlabOrganizationByName",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+	}
+
+	orgs, err := repo.queryOrganizations(GitlabOrgLowerNameIndex, "organization_name_lower", strings.ToLower(organizationName))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem querying gitlab organization by name")
+		return nil, err
+	}
+	if len(orgs) == 0 {
+		return nil, ErrOrganizationDoesNotExist
+	}
+	if len(orgs) > 1 {
+		log.WithFields(f).Warning("more than one gitlab organization with the same name in the database")
+	}
+	return orgs[0].toModel(), nil
+}
+
+// DeleteGitlabOrganization deletes the gitlab group record
+func (repo repository) DeleteGitlabOrganization(ctx context.Context, organizationID string) error {
+	f := logrus.Fields{
+		"functionName":   "DeleteGitlabOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	log.WithFields(f).Debug("deleting gitlab organization...")
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_id": {S: aws.String(organizationID)},
+		},
+		TableName: aws.String(repo.gitlabOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error deleting gitlab organization")
+		return err
+	}
+	return nil
+}
+
+// AddGitlabProject enables EasyCLA on the gitlab project for the CLA Group
+func (repo repository) AddGitlabProject(ctx context.Context, projectSFID string, claGroupID string, organizationName string, project *gitlab.Project) (*models.GitlabProject, error) {
+	f := logrus.Fields{
+		"functionName":      "AddGitlabProject",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"projectSFID":       projectSFID,
+		"claGroupID":        claGroupID,
+		"organizationName":  organizationName,
+		"projectExternalID": project.ID,
+		"projectName":       project.PathWithNamespace,
+	}
+
+	projectID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	_, currentTime := utils.CurrentTime()
+	gitlabProject := &GitlabProject{
+		ProjectID:         projectID.String(),
+		ProjectName:       project.PathWithNamespace,
+		ProjectExternalID: project.ID,
+		ProjectURL:        project.WebURL,
+		OrganizationName:  organizationName,
+		ClaGroupID:        claGroupID,
+		ProjectSFID:       projectSFID,
+		Enabled:           true,
+		DateCreated:       currentTime,
+		DateModified:      currentTime,
+		Version:           "v1",
+	}
+	av, err := dynamodbattribute.MarshalMap(gitlabProject)
+	if err != nil {
+		log.WithFields(f).Warnf("problem marshalling the input, error: %+v", err)
+		return nil, err
+	}
+
+	log.WithFields(f).Debug("creating gitlab project entry")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.gitlabProjectTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("cannot put gitlab project in dynamodb")
+		return nil, err
+	}
+
+	return gitlabProject.toModel(), nil
+}
+
+// GetGitlabProject returns the gitlab project by its internal ID
+func (repo repository) GetGitlabProject(ctx context.Context, projectID string) (*models.GitlabProject, error) {
+	f := logrus.Fields{
+		"functionName":   "GetGitlabProject",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectID":      projectID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {S: aws.String(projectID)},
+		},
+		TableName: aws.String(repo.gitlabProjectTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading gitlab project")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrProjectDoesNotExist
+	}
+
+	var gitlabProject GitlabProject
+	err = dynamodbattribute.UnmarshalMap(result.Item, &gitlabProject)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+		return nil, err
+	}
+	return gitlabProject.toModel(), nil
+}
+
+// GetGitlabProjectByExternalID returns the gitlab project by its GitLab project ID
+func (repo repository) GetGitlabProjectByExternalID(ctx context.Context, projectExternalID int64) (*models.GitlabProject, error) {
+	f := logrus.Fields{
+		"functionName":      "GetGitlabProjectByExternalID",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"projectExternalID": projectExternalID,
+	}
+
+	projects, err := repo.queryProjects(GitlabProjectExternalIDIndex, "project_external_id", projectExternalID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem querying gitlab project by external id")
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return nil, ErrProjectDoesNotExist
+	}
+	return projects[0].toModel(), nil
+}
+
+// GetGitlabProjects returns the gitlab projects of the project
+func (repo repository) GetGitlabProjects(ctx context.Context, projectSFID string) (*models.GitlabProjects, error) {
+	f := logrus.Fields{
+		"functionName":   "GetGitlabProjects",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+	}
+
+	projects, err := repo.queryProjects(GitlabProjectProjectSFIDIndex, "project_sfid", projectSFID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem querying gitlab projects")
+		return nil, err
+	}
+	return toProjectsModel(projects), nil
+}
+
+// GetGitlabProjectsByOrganization returns the gitlab projects of the gitlab group
+func (repo repository) GetGitlabProjectsByOrganization(ctx context.Context, organizationName string) (*models.GitlabProjects, error) {
+	f := logrus.Fields{
+		"functionName":     "GetGitlabProjectsByOrganization",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+	}
+
+	projects, err := repo.queryProjects(GitlabProjectOrganizationIndex, "organization_name", organizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem querying gitlab projects by organization")
+		return nil, err
+	}
+	return toProjectsModel(projects), nil
+}
+
+// DeleteGitlabProject deletes the gitlab project record
+func (repo repository) DeleteGitlabProject(ctx context.Context, projectID string) error {
+	f := logrus.Fields{
+		"functionName":   "DeleteGitlabProject",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectID":      projectID,
+	}
+
+	log.WithFields(f).Debug("deleting gitlab project...")
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {S: aws.String(projectID)},
+		},
+		TableName: aws.String(repo.gitlabProjectTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error deleting gitlab project")
+		return err
+	}
+	return nil
+}
+
+func (repo repository) queryOrganizations(indexName, keyName string, value interface{}) ([]*GitlabOrganization, error) {
+	items, err := repo.query(repo.gitlabOrgTableName, indexName, keyName, value)
+	if err != nil {
+		return nil, err
+	}
+	var orgs []*GitlabOrganization
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &orgs)
+	if err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+func (repo repository) queryProjects(indexName, keyName string, value interface{}) ([]*GitlabProject, error) {
+	items, err := repo.query(repo.gitlabProjectTableName, indexName, keyName, value)
+	if err != nil {
+		return nil, err
+	}
+	var projects []*GitlabProject
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &projects)
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// query returns all the items of the index matching the key value, following the pagination
+func (repo repository) query(tableName, indexName, keyName string, value interface{}) ([]map[string]*dynamodb.AttributeValue, error) {
+	condition := expression.Key(keyName).Equal(expression.Value(value))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(tableName),
+		IndexName:                 aws.String(indexName),
+	}
+
+	var items []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, results.Items...)
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return items, nil
+}
+
+func toProjectsModel(projects []*GitlabProject) *models.GitlabProjects {
+	out := &models.GitlabProjects{List: make([]*models.GitlabProject, 0, len(projects))}
+	for _, project := range projects {
+		out.List = append(out.List, project.toModel())
+	}
+	return out
+}
@@ -0,0 +1,210 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gitlab"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	v2ProjectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
+	"github.com/sirupsen/logrus"
+)
+
+// Service contains functions of the GitLab organizations (groups) and projects service
+type Service interface {
+	AddGitlabOrganization(ctx context.Context, projectSFID string, input *models.CreateGitlabOrganization) (*models.GitlabOrganization, error)
+	GetGitlabOrganizations(ctx context.Context, projectSFID string) (*models.GitlabOrganizations, error)
+	DeleteGitlabOrganization(ctx context.Context, projectSFID string, organizationID string) (*models.GitlabOrganization, error)
+	AddGitlabProjects(ctx context.Context, projectSFID string, input *models.GitlabProjectInput) (*models.GitlabProjects, error)
+	GetGitlabProjects(ctx context.Context, projectSFID string) (*models.GitlabProjects, error)
+	DeleteGitlabProject(ctx context.Context, projectSFID string, projectID string) (*models.GitlabProject, error)
+}
+
+type service struct {
+	repo                  Repository
+	projectsClaGroupsRepo projects_cla_groups.Repository
+}
+
+// NewService creates a new gitlab organizations service
+func NewService(repo Repository, projectsClaGroupsRepo projects_cla_groups.Repository) Service {
+	return service{
+		repo:                  repo,
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+	}
+}
+
+// AddGitlabOrganization validates the group against the GitLab API and adds it to the project
+func (s service) AddGitlabOrganization(ctx context.Context, projectSFID string, input *models.CreateGitlabOrganization) (*models.GitlabOrganization, error) {
+	f := logrus.Fields{
+		"functionName":     "AddGitlabOrganization",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"projectSFID":      projectSFID,
+		"organizationName": utils.StringValue(input.OrganizationName),
+	}
+
+	parentProjectSFID, err := getParentProjectSFID(projectSFID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load projectSFID from the platform project service")
+		return nil, err
+	}
+	f["parentProjectSFID"] = parentProjectSFID
+
+	log.WithFields(f).Debug("loading gitlab group by full path")
+	group, err := gitlab.GetGroup(ctx, utils.StringValue(input.OrganizationName))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load gitlab group")
+		return nil, err
+	}
+
+	return s.repo.AddGitlabOrganization(ctx, parentProjectSFID, projectSFID, group)
+}
+
+// GetGitlabOrganizations returns the gitlab groups of the project
+func (s service) GetGitlabOrganizations(ctx context.Context, projectSFID string) (*models.GitlabOrganizations, error) {
+	return s.repo.GetGitlabOrganizations(ctx, projectSFID)
+}
+
+// DeleteGitlabOrganization removes the gitlab group and its projects from the project
+func (s service) DeleteGitlabOrganization(ctx context.Context, projectSFID string, organizationID string) (*models.GitlabOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "DeleteGitlabOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"organizationID": organizationID,
+	}
+
+	org, err := s.repo.GetGitlabOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if org.ProjectSFID != projectSFID {
+		log.WithFields(f).Warnf("gitlab organization belongs to project SFID: %s", org.ProjectSFID)
+		return nil, ErrOrganizationDoesNotExist
+	}
+
+	projects, err := s.repo.GetGitlabProjectsByOrganization(ctx, org.OrganizationName)
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects.List {
+		log.WithFields(f).Debugf("deleting gitlab project: %s of the organization", project.ProjectName)
+		if deleteErr := s.repo.DeleteGitlabProject(ctx, project.ProjectID); deleteErr != nil {
+			return nil, deleteErr
+		}
+	}
+
+	err = s.repo.DeleteGitlabOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// AddGitlabProjects enables EasyCLA on the gitlab projects of a registered gitlab group for the CLA Group
+func (s service) AddGitlabProjects(ctx context.Context, projectSFID string, input *models.GitlabProjectInput) (*models.GitlabProjects, error) {
+	f := logrus.Fields{
+		"functionName":           "AddGitlabProjects",
+		utils.XREQUESTID:         ctx.Value(utils.XREQUESTID),
+		"projectSFID":            projectSFID,
+		"claGroupID":             utils.StringValue(input.ClaGroupID),
+		"gitlabOrganizationName": utils.StringValue(input.GitlabOrganizationName),
+		"projectGitlabIds":       input.ProjectGitlabIds,
+	}
+
+	allMappings, err := s.projectsClaGroupsRepo.GetProjectsIdsForClaGroup(utils.StringValue(input.ClaGroupID))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to get project IDs for CLA Group")
+		return nil, err
+	}
+	var valid bool
+	for _, cgm := range allMappings {
+		if cgm.ProjectSFID == projectSFID || cgm.FoundationSFID == projectSFID {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("provided cla group id %s is not linked to project sfid %s", utils.StringValue(input.ClaGroupID), projectSFID)
+	}
+
+	org, err := s.repo.GetGitlabOrganizationByName(ctx, utils.StringValue(input.GitlabOrganizationName))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to get gitlab organization by name")
+		return nil, err
+	}
+
+	response := &models.GitlabProjects{List: make([]*models.GitlabProject, 0, len(input.ProjectGitlabIds))}
+	for _, projectGitlabID := range input.ProjectGitlabIds {
+		existing, lookupErr := s.repo.GetGitlabProjectByExternalID(ctx, projectGitlabID)
+		if lookupErr != nil && !errors.Is(lookupErr, ErrProjectDoesNotExist) {
+			return nil, lookupErr
+		}
+		if existing != nil {
+			log.WithFields(f).Warnf("gitlab project: %s is already enabled for CLA Group: %s - skipping", existing.ProjectName, existing.ClaGroupID)
+			continue
+		}
+
+		log.WithFields(f).Debugf("loading gitlab project by id: %d", projectGitlabID)
+		project, projectErr := gitlab.GetProject(ctx, projectGitlabID)
+		if projectErr != nil {
+			log.WithFields(f).WithError(projectErr).Warnf("unable to load gitlab project by id: %d", projectGitlabID)
+			return nil, projectErr
+		}
+		if !gitlab.IsProjectInGroup(project, org.OrganizationName) {
+			return nil, fmt.Errorf("gitlab project %s does not belong to the gitlab group %s", project.PathWithNamespace, org.OrganizationName)
+		}
+
+		gitlabProject, addErr := s.repo.AddGitlabProject(ctx, projectSFID, utils.StringValue(input.ClaGroupID), org.OrganizationName, project)
+		if addErr != nil {
+			return nil, addErr
+		}
+		response.List = append(response.List, gitlabProject)
+	}
+
+	return response, nil
+}
+
+// GetGitlabProjects returns the gitlab projects of the project
+func (s service) GetGitlabProjects(ctx context.Context, projectSFID string) (*models.GitlabProjects, error) {
+	return s.repo.GetGitlabProjects(ctx, projectSFID)
+}
+
+// DeleteGitlabProject removes the gitlab project from the project
+func (s service) DeleteGitlabProject(ctx context.Context, projectSFID string, projectID string) (*models.GitlabProject, error) {
+	project, err := s.repo.GetGitlabProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.ProjectSfid != projectSFID {
+		return nil, ErrProjectDoesNotExist
+	}
+
+	err = s.repo.DeleteGitlabProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// getParentProjectSFID returns the project SFID the gitlab groups are registered under - the parent project,
+// unless the project is a standalone project or directly under The Linux Foundation
+func getParentProjectSFID(projectSFID string) (string, error) {
+	psc := v2ProjectService.GetClient()
+	project, err := psc.GetProject(projectSFID)
+	if err != nil {
+		return "", err
+	}
+
+	if project.Parent == "" || (project.Foundation != nil &&
+		(project.Foundation.Name == utils.TheLinuxFoundation || project.Foundation.Name == utils.LFProjectsLLC)) {
+		return projectSFID, nil
+	}
+	return project.Parent, nil
+}
@@ -0,0 +1,51 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package membership
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	membershipOps "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/membership"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service) {
+	api.MembershipCheckMembershipHandler = membershipOps.CheckMembershipHandlerFunc(func(params membershipOps.CheckMembershipParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		f := logrus.Fields{
+			"functionName":   "v2.membership.handlers.MembershipCheckMembershipHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+		}
+
+		if err := service.Authenticate(ctx, params.ClaGroupID, params.Authorization); err != nil {
+			log.WithFields(f).Warn(err)
+			return membershipOps.NewCheckMembershipUnauthorized().WithXRequestID(reqID).WithPayload(utils.ErrorResponseUnauthorized(reqID, err.Error()))
+		}
+
+		result, err := service.CheckMembership(ctx, params.ClaGroupID, utils.StringValue(params.Email), utils.StringValue(params.GithubUsername))
+		if err != nil {
+			if err == ErrMissingContributor {
+				return membershipOps.NewCheckMembershipBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, err.Error()))
+			}
+			if _, ok := err.(*utils.CLAGroupNotFound); ok || err == project.ErrProjectDoesNotExist {
+				msg := fmt.Sprintf("CLA Group %s not found", params.ClaGroupID)
+				return membershipOps.NewCheckMembershipNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			msg := fmt.Sprintf("unable to check the membership of the contributor for CLA Group: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return membershipOps.NewCheckMembershipInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return membershipOps.NewCheckMembershipOK().WithXRequestID(reqID).WithPayload(result)
+	})
+}
@@ -0,0 +1,265 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package membership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// contributorConsoleURLPath is the contributor console page of the CLA Group for a user
+	contributorConsoleURLPath = "%s/#/cla/project/%s/user/%s"
+	// contributorConsoleCLAGroupURLPath is the contributor console page of the CLA Group for a contributor without a
+	// user record, the contributor signs in before signing
+	contributorConsoleCLAGroupURLPath = "%s/#/cla/project/%s"
+)
+
+// membership check verdict reasons
+const (
+	ReasonCoveredByICLA      = "contributor is covered by a signed ICLA"
+	ReasonCoveredByCCLA      = "contributor is on the CCLA approval list and has confirmed their affiliation"
+	ReasonSignICLA           = "contributor has no signed ICLA and is not on a CCLA approval list"
+	ReasonAffiliationMissing = "contributor is on the CCLA approval list but has not confirmed their affiliation"
+	ReasonApprovalMissing    = "the company of the contributor signed the CCLA but the contributor is not on its approval lists"
+	ReasonCompanyCCLAMissing = "contributor is not on a CCLA approval list and the CLA Group only accepts CCLAs"
+)
+
+// errors of the membership checks
+var (
+	ErrUnauthorized       = errors.New("invalid or missing membership check API key")
+	ErrMissingContributor = errors.New("email or githubUsername required")
+)
+
+// ProjectRepo contains the project repo methods used by the service
+type ProjectRepo interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*v1Models.ClaGroup, error)
+	UpdateCLAGroupMembershipCheckAPIKeyHash(ctx context.Context, claGroupID, keyHash string) error
+	GetCLAGroupMembershipCheckAPIKeyHash(ctx context.Context, claGroupID string) (string, error)
+}
+
+// UserService contains the user lookups of the membership checks
+type UserService interface {
+	GetUserByEmail(userEmail string) (*v1Models.User, error)
+	GetUserByGitHubUsername(gitHubUsername string) (*v1Models.User, error)
+}
+
+// SignatureService evaluates the CLA coverage of the contributors
+type SignatureService interface {
+	GetContributorCoverage(ctx context.Context, user *v1Models.User, claGroupModel *v1Models.ClaGroup) (*signatures.ContributorCoverage, error)
+}
+
+// Service interface defines the membership check service methods
+type Service interface {
+	CreateAPIKey(ctx context.Context, claGroupID string) (string, error)
+	RevokeAPIKey(ctx context.Context, claGroupID string) error
+	Authenticate(ctx context.Context, claGroupID, authorization string) error
+
+	CheckMembership(ctx context.Context, claGroupID, email, githubUsername string) (*models.MembershipCheck, error)
+}
+
+type service struct {
+	projectRepo             ProjectRepo
+	userService             UserService
+	signatureService        SignatureService
+	contributorConsoleV2URL string
+}
+
+// NewService returns an instance of the membership check service
+func NewService(projectRepo ProjectRepo, userService UserService, signatureService SignatureService, contributorConsoleV2URL string) Service {
+	return &service{
+		projectRepo:             projectRepo,
+		userService:             userService,
+		signatureService:        signatureService,
+		contributorConsoleV2URL: contributorConsoleV2URL,
+	}
+}
+
+// CreateAPIKey creates a new membership check API key for the CLA Group, replacing the previous API key. The API key
+// is scoped to the CLA Group. Only the hash of the API key is stored, the API key is returned once.
+func (s *service) CreateAPIKey(ctx context.Context, claGroupID string) (string, error) {
+	apiKey, err := utils.GenerateScopedToken(claGroupID)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.projectRepo.UpdateCLAGroupMembershipCheckAPIKeyHash(ctx, claGroupID, utils.HashToken(apiKey)); err != nil {
+		return "", err
+	}
+	return apiKey, nil
+}
+
+// RevokeAPIKey revokes the membership check API key of the CLA Group
+func (s *service) RevokeAPIKey(ctx context.Context, claGroupID string) error {
+	return s.projectRepo.UpdateCLAGroupMembershipCheckAPIKeyHash(ctx, claGroupID, "")
+}
+
+// Authenticate checks the bearer token of the Authorization header against the membership check API key of the
+// CLA Group, an API key scoped to another CLA Group is rejected
+func (s *service) Authenticate(ctx context.Context, claGroupID, authorization string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.membership.service.Authenticate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	apiKey := utils.BearerToken(authorization)
+	if apiKey == "" || utils.TokenScope(apiKey) != claGroupID {
+		log.WithFields(f).Debug("missing membership check API key or API key of another CLA Group")
+		return ErrUnauthorized
+	}
+
+	keyHash, err := s.projectRepo.GetCLAGroupMembershipCheckAPIKeyHash(ctx, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the membership check API key of the CLA Group")
+		return ErrUnauthorized
+	}
+	if !utils.TokenMatchesHash(apiKey, keyHash) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CheckMembership returns whether the contributor is covered by a CLA of the CLA Group. The user record of the
+// contributor is looked up by email, then by GitHub username. A contributor without a user record is not covered
+// and gets the sign URL of the CLA Group.
+func (s *service) CheckMembership(ctx context.Context, claGroupID, email, githubUsername string) (*models.MembershipCheck, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.membership.service.CheckMembership",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"email":          email,
+		"githubUsername": githubUsername,
+	}
+
+	email, githubUsername = strings.TrimSpace(email), strings.TrimSpace(githubUsername)
+	if email == "" && githubUsername == "" {
+		return nil, ErrMissingContributor
+	}
+
+	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, claGroupID, false)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group")
+		return nil, err
+	}
+
+	userModel, err := s.getContributor(email, githubUsername)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the contributor")
+		return nil, err
+	}
+	userFound := userModel != nil
+	if !userFound {
+		userModel = &v1Models.User{LfEmail: email, GithubUsername: githubUsername}
+	}
+
+	coverage, err := s.signatureService.GetContributorCoverage(ctx, userModel, claGroupModel)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to evaluate the contributor coverage")
+		return nil, err
+	}
+
+	result := buildMembershipCheck(claGroupID, email, githubUsername, coverage)
+	if userFound {
+		result.UserID = userModel.UserID
+	}
+	if !result.Covered {
+		result.SignURL = s.contributorConsoleURL(claGroupID, result.UserID)
+	}
+
+	log.WithFields(f).Debugf("contributor covered: %t - %s", result.Covered, result.Reason)
+	return result, nil
+}
+
+// getContributor looks up the user by email, then by GitHub username. Returns nil if the user does not exist.
+func (s *service) getContributor(email, githubUsername string) (*v1Models.User, error) {
+	if email != "" {
+		userModel, err := s.userService.GetUserByEmail(email)
+		if err != nil {
+			if _, ok := err.(*utils.UserNotFound); !ok {
+				return nil, err
+			}
+		}
+		if userModel != nil {
+			return userModel, nil
+		}
+	}
+
+	if githubUsername != "" {
+		userModel, err := s.userService.GetUserByGitHubUsername(githubUsername)
+		if err != nil {
+			if !isNotFound(err) {
+				return nil, err
+			}
+			return nil, nil
+		}
+		return userModel, nil
+	}
+	return nil, nil
+}
+
+// isNotFound returns true if the lookup by GitHub username failed because the user does not exist
+func isNotFound(err error) bool {
+	apiErr, ok := err.(openapierrors.Error)
+	return ok && apiErr.Code() == http.StatusNotFound
+}
+
+// contributorConsoleURL returns the contributor console URL of the CLA Group for the user, or of the CLA Group
+// itself when the user ID is not set
+func (s *service) contributorConsoleURL(claGroupID, userID string) string {
+	if s.contributorConsoleV2URL == "" {
+		return ""
+	}
+	base := strings.TrimSuffix(s.contributorConsoleV2URL, "/")
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		base = "https://" + base
+	}
+	if userID == "" {
+		return fmt.Sprintf(contributorConsoleCLAGroupURLPath, base, claGroupID)
+	}
+	return fmt.Sprintf(contributorConsoleURLPath, base, claGroupID, userID)
+}
+
+// buildMembershipCheck converts the contributor coverage into the verdict of the membership check
+func buildMembershipCheck(claGroupID, email, githubUsername string, coverage *signatures.ContributorCoverage) *models.MembershipCheck {
+	result := &models.MembershipCheck{
+		ClaGroupID:       claGroupID,
+		Email:            email,
+		GithubUsername:   githubUsername,
+		Covered:          coverage.Covered,
+		SignatureType:    coverage.SignatureType,
+		SignatureID:      coverage.SignatureID,
+		CompanyID:        coverage.CompanyID,
+		CompanyName:      coverage.CompanyName,
+		ApprovalListType: coverage.ApprovalListType,
+		MissingAction:    coverage.MissingAction,
+	}
+
+	switch {
+	case coverage.Covered && coverage.SignatureType == utils.ClaTypeICLA:
+		result.Reason = ReasonCoveredByICLA
+	case coverage.Covered:
+		result.Reason = ReasonCoveredByCCLA
+	case coverage.MissingAction == signatures.MissingActionConfirmAffiliation:
+		result.Reason = ReasonAffiliationMissing
+	case coverage.MissingAction == signatures.MissingActionRequestApproval:
+		result.Reason = ReasonApprovalMissing
+	case coverage.MissingAction == signatures.MissingActionCompanySignCCLA:
+		result.Reason = ReasonCompanyCCLAMissing
+	default:
+		result.Reason = ReasonSignICLA
+	}
+	return result
+}
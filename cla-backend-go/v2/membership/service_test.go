@@ -0,0 +1,101 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package membership
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	openapierrors "github.com/go-openapi/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMembershipCheck(t *testing.T) {
+	result := buildMembershipCheck("cla-group-1", "jane.doe@example.org", "janedoe", &signatures.ContributorCoverage{
+		Covered:       true,
+		SignatureType: utils.ClaTypeICLA,
+		SignatureID:   "signature-1",
+	})
+	assert.True(t, result.Covered)
+	assert.Equal(t, "cla-group-1", result.ClaGroupID)
+	assert.Equal(t, "signature-1", result.SignatureID)
+	assert.Equal(t, ReasonCoveredByICLA, result.Reason)
+
+	result = buildMembershipCheck("cla-group-1", "jane.doe@example.org", "", &signatures.ContributorCoverage{
+		Covered:          true,
+		SignatureType:    utils.ClaTypeCCLA,
+		CompanyName:      "Acme",
+		ApprovalListType: signatures.ApprovalListTypeDomain,
+	})
+	assert.Equal(t, ReasonCoveredByCCLA, result.Reason)
+	assert.Equal(t, "Acme", result.CompanyName)
+	assert.Equal(t, signatures.ApprovalListTypeDomain, result.ApprovalListType)
+
+	reasons := map[string]string{
+		signatures.MissingActionSignICLA:           ReasonSignICLA,
+		signatures.MissingActionConfirmAffiliation: ReasonAffiliationMissing,
+		signatures.MissingActionRequestApproval:    ReasonApprovalMissing,
+		signatures.MissingActionCompanySignCCLA:    ReasonCompanyCCLAMissing,
+	}
+	for missingAction, reason := range reasons {
+		result = buildMembershipCheck("cla-group-1", "", "janedoe", &signatures.ContributorCoverage{MissingAction: missingAction})
+		assert.False(t, result.Covered)
+		assert.Equal(t, missingAction, result.MissingAction)
+		assert.Equal(t, reason, result.Reason)
+	}
+}
+
+func TestContributorConsoleURL(t *testing.T) {
+	s := &service{contributorConsoleV2URL: "contributor.example.org/"}
+	assert.Equal(t, "https://contributor.example.org/#/cla/project/cla-group-1/user/user-1", s.contributorConsoleURL("cla-group-1", "user-1"))
+	assert.Equal(t, "https://contributor.example.org/#/cla/project/cla-group-1", s.contributorConsoleURL("cla-group-1", ""))
+
+	s = &service{contributorConsoleV2URL: "http://localhost:8100"}
+	assert.Equal(t, "http://localhost:8100/#/cla/project/cla-group-1/user/user-1", s.contributorConsoleURL("cla-group-1", "user-1"))
+}
+
+// fakeProjectRepo keeps the membership check API key hashes in memory, the embedded interface panics on any other call
+type fakeProjectRepo struct {
+	ProjectRepo
+	keyHashes map[string]string
+}
+
+func (r *fakeProjectRepo) UpdateCLAGroupMembershipCheckAPIKeyHash(ctx context.Context, claGroupID, keyHash string) error {
+	r.keyHashes[claGroupID] = keyHash
+	return nil
+}
+
+func (r *fakeProjectRepo) GetCLAGroupMembershipCheckAPIKeyHash(ctx context.Context, claGroupID string) (string, error) {
+	return r.keyHashes[claGroupID], nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	s := NewService(&fakeProjectRepo{keyHashes: map[string]string{}}, nil, nil, "")
+
+	apiKey, err := s.CreateAPIKey(ctx, "cla-group-1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	otherAPIKey, err := s.CreateAPIKey(ctx, "cla-group-2")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, s.Authenticate(ctx, "cla-group-1", "Bearer "+apiKey))
+	assert.Equal(t, ErrUnauthorized, s.Authenticate(ctx, "cla-group-1", apiKey))
+	assert.Equal(t, ErrUnauthorized, s.Authenticate(ctx, "cla-group-1", "Bearer "+otherAPIKey))
+	assert.Equal(t, ErrUnauthorized, s.Authenticate(ctx, "cla-group-2", "Bearer "+apiKey))
+
+	assert.NoError(t, s.RevokeAPIKey(ctx, "cla-group-1"))
+	assert.Equal(t, ErrUnauthorized, s.Authenticate(ctx, "cla-group-1", "Bearer "+apiKey))
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, isNotFound(openapierrors.NotFound("user not found when searching by user_github_username: %s", "janedoe")))
+	assert.False(t, isNotFound(errors.New("ProvisionedThroughputExceededException")))
+}
@@ -7,17 +7,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/go-openapi/swag"
-	githubsdk "github.com/google/go-github/v33/github"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
+	"github.com/communitybridge/easycla/cla-backend-go/forge"
 	"github.com/communitybridge/easycla/cla-backend-go/github"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -53,6 +52,7 @@ type service struct {
 	repo                  v1Repositories.Repository
 	projectsClaGroupsRepo projects_cla_groups.Repository
 	ghOrgRepo             GithubOrgRepo
+	forges                *forge.Registry
 }
 
 var (
@@ -61,12 +61,14 @@ var (
 	ErrInvalidBranchProtectionName = errors.New("invalid protection option")
 )
 
-// NewService creates a new githubOrganizations service
-func NewService(repo v1Repositories.Repository, pcgRepo projects_cla_groups.Repository, ghOrgRepo GithubOrgRepo) Service {
+// NewService creates a new githubOrganizations service, the git forge calls go through the provider
+// registered for the repository type
+func NewService(repo v1Repositories.Repository, pcgRepo projects_cla_groups.Repository, ghOrgRepo GithubOrgRepo, forges *forge.Registry) Service {
 	return &service{
 		repo:                  repo,
 		projectsClaGroupsRepo: pcgRepo,
 		ghOrgRepo:             ghOrgRepo,
+		forges:                forges,
 	}
 }
 
@@ -129,36 +131,35 @@ func (s *service) AddGithubRepositories(ctx context.Context, projectSFID string,
 	// Remove any silly duplicates that may come
 	repositoryIDList = utils.RemoveDuplicates(repositoryIDList)
 
+	provider, err := s.forges.Provider(ctx, utils.GitHubType, utils.StringValue(input.GithubOrganizationName))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the git forge provider for the organization")
+		return nil, err
+	}
+
 	var response []*v1Models.GithubRepository
 
 	// For each repository ID provided...
 	// If this is slow, may want to optimize by making separate go routines for each item in the list
 	for _, repoID := range repositoryIDList {
-		// Convert the string value to an integer
-		repoGithubID, err := strconv.ParseInt(repoID, 10, 64)
-		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to convert repository github ID %s to an integer - invalid value", repoID)
-			return nil, err
-		}
-
-		log.WithFields(f).Debugf("loading GitHub repository by external id: %d", repoGithubID)
-		ghRepo, err := github.GetRepositoryByExternalID(ctx, org.List[0].OrganizationInstallationID, repoGithubID)
+		log.WithFields(f).Debugf("loading GitHub repository by external id: %s", repoID)
+		ghRepo, err := provider.GetRepository(ctx, repoID)
 		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to load repository by external ID: %d", repoGithubID)
+			log.WithFields(f).WithError(err).Warnf("unable to load repository by external ID: %s", repoID)
 			return nil, err
 		}
-		log.WithFields(f).Debugf("loaded GitHub repository by external id: %d - url: %s", repoGithubID, utils.StringValue(ghRepo.URL))
+		log.WithFields(f).Debugf("loaded GitHub repository by external id: %s - url: %s", repoID, ghRepo.URL)
 
 		// Check if this repository exists in our database
-		log.WithFields(f).Debugf("checking if GitHub repository by name: %s exists...", utils.StringValue(ghRepo.FullName))
-		existingRepositoryModel, lookupErr := s.GetRepositoryByName(ctx, utils.StringValue(ghRepo.FullName))
+		log.WithFields(f).Debugf("checking if GitHub repository by name: %s exists...", ghRepo.FullName)
+		existingRepositoryModel, lookupErr := s.GetRepositoryByName(ctx, ghRepo.FullName)
 		if lookupErr != nil {
 			// If we have the repository not found error - this is ok - we are expecting this
 			if notFoundErr, ok := lookupErr.(*utils.GitHubRepositoryNotFound); ok {
-				log.WithFields(f).WithError(notFoundErr).Debugf("GitHub repository lookup didn't find a match for existing repository name: %s - ok to create", utils.StringValue(ghRepo.FullName))
+				log.WithFields(f).WithError(notFoundErr).Debugf("GitHub repository lookup didn't find a match for existing repository name: %s - ok to create", ghRepo.FullName)
 			} else {
 				// Some other error - not good...
-				log.WithFields(f).WithError(lookupErr).Warnf("GitHub repository lookup failed for repository name: %s", utils.StringValue(ghRepo.FullName))
+				log.WithFields(f).WithError(lookupErr).Warnf("GitHub repository lookup failed for repository name: %s", ghRepo.FullName)
 				return nil, lookupErr
 			}
 		}
@@ -166,7 +167,7 @@ func (s *service) AddGithubRepositories(ctx context.Context, projectSFID string,
 		// We already have an existing repository model with the same name
 		if existingRepositoryModel != nil {
 			if !existingRepositoryModel.Enabled {
-				msg := fmt.Sprintf("Github repository: %s previously disabled - will re-enabled... ", utils.StringValue(ghRepo.FullName))
+				msg := fmt.Sprintf("Github repository: %s previously disabled - will re-enabled... ", ghRepo.FullName)
 				log.WithFields(f).Debug(msg)
 				enabled := true
 
@@ -184,14 +185,14 @@ func (s *service) AddGithubRepositories(ctx context.Context, projectSFID string,
 				// Update Repo details in case of any changes
 				updatedRepository, updateErr := s.repo.UpdateGithubRepository(ctx, existingRepositoryModel.RepositoryID, v1Input)
 				if updateErr != nil {
-					log.WithFields(f).WithError(updateErr).Warnf("unable to update GitHub repository with name: %s, id: %s, using input: %+v", utils.StringValue(ghRepo.FullName), existingRepositoryModel.RepositoryID, v1Input)
+					log.WithFields(f).WithError(updateErr).Warnf("unable to update GitHub repository with name: %s, id: %s, using input: %+v", ghRepo.FullName, existingRepositoryModel.RepositoryID, v1Input)
 					return nil, updateErr
 				}
 
 				// Append the results to our response model
 				response = append(response, updatedRepository)
 			} else {
-				log.WithFields(f).Warnf("GitHub repository already exists with repository name: %s and is already enabled - skipping update", utils.StringValue(ghRepo.FullName))
+				log.WithFields(f).Warnf("GitHub repository already exists with repository name: %s and is already enabled - skipping update", ghRepo.FullName)
 				continue
 			}
 		} else {
@@ -199,11 +200,11 @@ func (s *service) AddGithubRepositories(ctx context.Context, projectSFID string,
 			log.WithFields(f).Debug("no existing GitHub repository configured - creating...")
 			in := &v1Models.GithubRepositoryInput{
 				RepositoryExternalID:       &repoID, // nolint
-				RepositoryName:             ghRepo.FullName,
+				RepositoryName:             aws.String(ghRepo.FullName),
 				RepositoryOrganizationName: input.GithubOrganizationName,
 				RepositoryProjectID:        input.ClaGroupID,
-				RepositoryType:             aws.String("github"),
-				RepositoryURL:              ghRepo.HTMLURL,
+				RepositoryType:             aws.String(utils.GitHubType),
+				RepositoryURL:              aws.String(ghRepo.URL),
 			}
 
 			addedModel, addErr := s.repo.AddGithubRepository(ctx, externalProjectID, projectSFID, in)
@@ -360,38 +361,32 @@ func (s *service) GetProtectedBranch(ctx context.Context, projectSFID, repositor
 		return nil, err
 	}
 
-	githubOrgName := githubRepository.RepositoryOrganizationName
-	githubRepoName := githubRepository.RepositoryName
-	githubRepoName = github.CleanGithubRepoName(githubRepoName)
-
-	githubClient, err := s.getGithubClientForOrgName(ctx, githubOrgName)
+	provider, err := s.getProvider(ctx, githubRepository)
 	if err != nil {
 		return nil, err
 	}
 
-	branchProtectionRepository := github.NewBranchProtectionRepository(githubClient.Repositories, github.EnableNonBlockingLimiter())
-	owner, branchName, err := s.getGithubOwnerBranchName(ctx, branchProtectionRepository, githubOrgName, githubRepoName)
+	forgeRepo, err := s.getForgeRepository(ctx, provider, githubRepository)
 	if err != nil {
 		return nil, err
 	}
+	owner, githubRepoName, branchName := forgeRepo.Owner, forgeRepo.Name, forgeRepo.DefaultBranch
 
 	result := &v2Models.GithubRepositoryBranchProtection{
 		BranchName: &branchName,
 	}
 
-	branchProtection, err := branchProtectionRepository.GetProtectedBranch(ctx, owner, githubRepoName, branchName)
+	branchProtection, err := provider.GetBranchProtection(ctx, owner, githubRepoName, branchName)
 	if err != nil {
-		if errors.Is(err, github.ErrBranchNotProtected) {
-			return result, nil
-		}
 		log.WithFields(f).WithError(err).Warnf("getting the github protected branch for owner : %s, repo : %s and branch : %s failed : %v", owner, githubRepoName, branchName, err)
 		return nil, err
 	}
+	if !branchProtection.Enabled {
+		return result, nil
+	}
 
 	result.ProtectionEnabled = true
-	if github.IsEnforceAdminEnabled(branchProtection) {
-		result.EnforceAdmin = true
-	}
+	result.EnforceAdmin = branchProtection.EnforceAdmin
 
 	requiredChecks := requiredBranchProtectionChecks
 	requiredChecksResult := s.getRequiredProtectedBranchCheckStatus(branchProtection, requiredChecks)
@@ -415,22 +410,18 @@ func (s *service) UpdateProtectedBranch(ctx context.Context, projectSFID, reposi
 		return nil, err
 	}
 
-	githubOrgName := githubRepository.RepositoryOrganizationName
-	githubRepoName := githubRepository.RepositoryName
-	githubRepoName = github.CleanGithubRepoName(githubRepoName)
-
-	githubClient, err := s.getGithubClientForOrgName(ctx, githubOrgName)
+	provider, err := s.getProvider(ctx, githubRepository)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("problem locating github client for organization name")
+		log.WithFields(f).WithError(err).Warn("problem locating git forge provider for organization name")
 		return nil, err
 	}
 
-	branchProtectionRepository := github.NewBranchProtectionRepository(githubClient.Repositories, github.EnableNonBlockingLimiter())
-	owner, branchName, err := s.getGithubOwnerBranchName(ctx, branchProtectionRepository, githubOrgName, githubRepoName)
+	forgeRepo, err := s.getForgeRepository(ctx, provider, githubRepository)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warn("problem locating github owner branch name")
 		return nil, err
 	}
+	owner, githubRepoName, branchName := forgeRepo.Owner, forgeRepo.Name, forgeRepo.DefaultBranch
 	f["owner"] = owner
 	f["branchName"] = branchName
 
@@ -462,7 +453,7 @@ func (s *service) UpdateProtectedBranch(ctx context.Context, projectSFID, reposi
 	}
 
 	log.WithFields(f).Debugf("enabling branch protection on repository...")
-	err = branchProtectionRepository.EnableBranchProtection(ctx, owner, githubRepoName, branchName, *input.EnforceAdmin, requiredChecks, disabledChecks)
+	err = provider.SetRequiredChecks(ctx, owner, githubRepoName, branchName, *input.EnforceAdmin, requiredChecks, disabledChecks)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warn("problem enabling github branch protection")
 		return nil, err
@@ -500,52 +491,50 @@ func (s *service) getGithubRepo(ctx context.Context, projectSFID, repositoryID s
 	return githubRepository, nil
 }
 
-func (s *service) getGithubClientForOrgName(ctx context.Context, githubOrgName string) (*githubsdk.Client, error) {
+// getProvider returns the git forge provider of the repository type, authenticated for the repository organization
+func (s *service) getProvider(ctx context.Context, repository *v1Models.GithubRepository) (forge.Provider, error) {
 	f := logrus.Fields{
-		"functionName":   "repositories.service.getGitHubClientForOrgName",
+		"functionName":   "repositories.service.getProvider",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
-		"githubOrgName":  githubOrgName,
+		"orgName":        repository.RepositoryOrganizationName,
+		"repositoryType": repository.RepositoryType,
 	}
 
-	githubOrg, err := s.ghOrgRepo.GetGithubOrganization(ctx, githubOrgName)
-	if err != nil {
-		log.WithFields(f).Warnf("fetching githubOrg %s failed, error: %v", githubOrgName, err)
-		return nil, err
+	repositoryType := repository.RepositoryType
+	if repositoryType == "" {
+		repositoryType = utils.GitHubType
 	}
 
-	githubClient, err := github.NewGithubAppClient(githubOrg.OrganizationInstallationID)
+	provider, err := s.forges.Provider(ctx, repositoryType, repository.RepositoryOrganizationName)
 	if err != nil {
-		log.WithFields(f).Warnf("creating the github client for installation id %d failed, error: %v", githubOrg.OrganizationInstallationID, err)
+		log.WithFields(f).Warnf("creating the git forge provider failed, error: %v", err)
 		return nil, err
 	}
 
-	return githubClient, nil
+	return provider, nil
 }
 
-func (s *service) getGithubOwnerBranchName(ctx context.Context, branchProtectionRepository *github.BranchProtectionRepository, githubOrgName, githubRepoName string) (string, string, error) {
-	owner, err := branchProtectionRepository.GetOwnerName(ctx, githubOrgName, githubRepoName)
+// getForgeRepository loads the repository owner and default branch from the git forge
+func (s *service) getForgeRepository(ctx context.Context, provider forge.Provider, repository *v1Models.GithubRepository) (*forge.Repository, error) {
+	orgName := repository.RepositoryOrganizationName
+	repoName := github.CleanGithubRepoName(repository.RepositoryName)
+	forgeRepo, err := provider.GetRepositoryByName(ctx, orgName, repoName)
 	if err != nil {
-		log.Warnf("getting the owner name for org : %s and repo : %s failed : %v", githubOrgName, githubRepoName, err)
-		return "", "", err
-	}
-
-	if owner == "" {
-		log.Warnf("GitHub returned empty owner name for org : %s and repo : %s", githubOrgName, githubRepoName)
-		return "", "", fmt.Errorf("empty owner name")
+		log.Warnf("loading the repository for org : %s and repo : %s failed : %v", orgName, repoName, err)
+		return nil, err
 	}
 
-	log.Debugf("getGitHubOwnerBranchName : owner of the repo : %s found : %s", owner, githubRepoName)
-	branchName, err := branchProtectionRepository.GetDefaultBranchForRepo(ctx, owner, githubRepoName)
-	if err != nil {
-		log.Warnf("getting default GitHub branch failed for owner : %s and repo : %s : %v", owner, githubRepoName, err)
-		return "", "", err
+	if forgeRepo.Owner == "" {
+		log.Warnf("git forge returned empty owner name for org : %s and repo : %s", orgName, repoName)
+		return nil, fmt.Errorf("empty owner name")
 	}
 
-	return owner, branchName, nil
+	log.Debugf("getForgeRepository : owner of the repo : %s found : %s, default branch : %s", repoName, forgeRepo.Owner, forgeRepo.DefaultBranch)
+	return forgeRepo, nil
 }
 
 // getRequiredProtectedBranchCheckStatus
-func (s *service) getRequiredProtectedBranchCheckStatus(protectedBranch *githubsdk.Protection, requiredChecks []string) []*v2Models.GithubRepositoryBranchProtectionStatusChecks {
+func (s *service) getRequiredProtectedBranchCheckStatus(protectedBranch *forge.BranchProtection, requiredChecks []string) []*v2Models.GithubRepositoryBranchProtectionStatusChecks {
 	f := logrus.Fields{
 		"functionName": "repositories.service.getRequiredProtectedBranchCheckStatus",
 	}
@@ -560,11 +549,11 @@ func (s *service) getRequiredProtectedBranchCheckStatus(protectedBranch *githubs
 		})
 		resultMap[rc] = true
 	}
-	if protectedBranch.RequiredStatusChecks == nil || len(protectedBranch.RequiredStatusChecks.Contexts) == 0 {
+	if len(protectedBranch.RequiredChecks) == 0 {
 		return result
 	}
 
-	for _, existingCheck := range protectedBranch.RequiredStatusChecks.Contexts {
+	for _, existingCheck := range protectedBranch.RequiredChecks {
 		if !resultMap[existingCheck] {
 			continue
 		}
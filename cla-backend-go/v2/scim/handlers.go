@@ -0,0 +1,276 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	scimOps "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/scim"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, companyService company.IService, eventsService events.Service) { // nolint
+	api.ScimCreateCompanyScimTokenHandler = scimOps.CreateCompanyScimTokenHandlerFunc(func(params scimOps.CreateCompanyScimTokenParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "ScimCreateCompanyScimTokenHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to lookup company by ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return scimOps.NewCreateCompanyScimTokenNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return scimOps.NewCreateCompanyScimTokenBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		if !utils.IsUserAuthorizedForOrganization(authUser, companyModel.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to create the directory sync token with Organization scope of %s",
+				authUser.UserName, companyModel.CompanyExternalID)
+			log.WithFields(f).Warn(msg)
+			return scimOps.NewCreateCompanyScimTokenForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		token, err := service.CreateToken(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to create the directory sync token of company ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return scimOps.NewCreateCompanyScimTokenInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.CompanySCIMTokenCreated,
+			CompanyID:    companyModel.CompanyID,
+			CompanyModel: companyModel,
+			LfUsername:   authUser.UserName,
+			EventData:    &events.CompanySCIMTokenCreatedEventData{},
+		})
+
+		return scimOps.NewCreateCompanyScimTokenOK().WithXRequestID(reqID).WithPayload(&models.ScimToken{
+			CompanyID: params.CompanyID,
+			Token:     token,
+		})
+	})
+
+	api.ScimDeleteCompanyScimTokenHandler = scimOps.DeleteCompanyScimTokenHandlerFunc(func(params scimOps.DeleteCompanyScimTokenParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "ScimDeleteCompanyScimTokenHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to lookup company by ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return scimOps.NewDeleteCompanyScimTokenNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return scimOps.NewDeleteCompanyScimTokenBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		if !utils.IsUserAuthorizedForOrganization(authUser, companyModel.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to revoke the directory sync token with Organization scope of %s",
+				authUser.UserName, companyModel.CompanyExternalID)
+			log.WithFields(f).Warn(msg)
+			return scimOps.NewDeleteCompanyScimTokenForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		if err = service.RevokeToken(ctx, params.CompanyID); err != nil {
+			msg := fmt.Sprintf("unable to revoke the directory sync token of company ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return scimOps.NewDeleteCompanyScimTokenInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.CompanySCIMTokenRevoked,
+			CompanyID:    companyModel.CompanyID,
+			CompanyModel: companyModel,
+			LfUsername:   authUser.UserName,
+			EventData:    &events.CompanySCIMTokenRevokedEventData{},
+		})
+
+		return scimOps.NewDeleteCompanyScimTokenNoContent().WithXRequestID(reqID)
+	})
+
+	api.ScimListScimUsersHandler = scimOps.ListScimUsersHandlerFunc(func(params scimOps.ListScimUsersParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		if err := service.Authenticate(ctx, params.CompanyID, params.Authorization); err != nil {
+			return scimResponse(ctx, http.StatusUnauthorized, err)
+		}
+
+		var startIndex, count int64
+		if params.StartIndex != nil {
+			startIndex = *params.StartIndex
+		}
+		if params.Count != nil {
+			count = *params.Count
+		}
+		response, err := service.ListUsers(ctx, params.CompanyID, params.ClaGroupID, utils.StringValue(params.Filter), startIndex, count)
+		if err != nil {
+			return scimResponse(ctx, http.StatusInternalServerError, err)
+		}
+		return scimResponse(ctx, http.StatusOK, response)
+	})
+
+	api.ScimGetScimUserHandler = scimOps.GetScimUserHandlerFunc(func(params scimOps.GetScimUserParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		if err := service.Authenticate(ctx, params.CompanyID, params.Authorization); err != nil {
+			return scimResponse(ctx, http.StatusUnauthorized, err)
+		}
+
+		user, err := service.GetUser(ctx, params.CompanyID, params.ClaGroupID, params.UserID)
+		if err != nil {
+			return scimResponse(ctx, http.StatusInternalServerError, err)
+		}
+		return scimResponse(ctx, http.StatusOK, user)
+	})
+
+	api.ScimCreateScimUserHandler = scimOps.CreateScimUserHandlerFunc(func(params scimOps.CreateScimUserParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		if err := service.Authenticate(ctx, params.CompanyID, params.Authorization); err != nil {
+			return scimResponse(ctx, http.StatusUnauthorized, err)
+		}
+
+		var user User
+		if err := decodeBody(params.Body, &user); err != nil {
+			return scimResponse(ctx, http.StatusBadRequest, err)
+		}
+		created, err := service.CreateUser(ctx, params.CompanyID, params.ClaGroupID, &user)
+		if err != nil {
+			return scimResponse(ctx, http.StatusInternalServerError, err)
+		}
+		return scimResponse(ctx, http.StatusCreated, created)
+	})
+
+	api.ScimReplaceScimUserHandler = scimOps.ReplaceScimUserHandlerFunc(func(params scimOps.ReplaceScimUserParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		if err := service.Authenticate(ctx, params.CompanyID, params.Authorization); err != nil {
+			return scimResponse(ctx, http.StatusUnauthorized, err)
+		}
+
+		var user User
+		if err := decodeBody(params.Body, &user); err != nil {
+			return scimResponse(ctx, http.StatusBadRequest, err)
+		}
+		replaced, err := service.ReplaceUser(ctx, params.CompanyID, params.ClaGroupID, params.UserID, &user)
+		if err != nil {
+			return scimResponse(ctx, http.StatusInternalServerError, err)
+		}
+		return scimResponse(ctx, http.StatusOK, replaced)
+	})
+
+	api.ScimPatchScimUserHandler = scimOps.PatchScimUserHandlerFunc(func(params scimOps.PatchScimUserParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		if err := service.Authenticate(ctx, params.CompanyID, params.Authorization); err != nil {
+			return scimResponse(ctx, http.StatusUnauthorized, err)
+		}
+
+		var patch PatchOp
+		if err := decodeBody(params.Body, &patch); err != nil {
+			return scimResponse(ctx, http.StatusBadRequest, err)
+		}
+		patched, err := service.PatchUser(ctx, params.CompanyID, params.ClaGroupID, params.UserID, &patch)
+		if err != nil {
+			return scimResponse(ctx, http.StatusInternalServerError, err)
+		}
+		return scimResponse(ctx, http.StatusOK, patched)
+	})
+
+	api.ScimDeleteScimUserHandler = scimOps.DeleteScimUserHandlerFunc(func(params scimOps.DeleteScimUserParams) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		if err := service.Authenticate(ctx, params.CompanyID, params.Authorization); err != nil {
+			return scimResponse(ctx, http.StatusUnauthorized, err)
+		}
+
+		if err := service.DeleteUser(ctx, params.CompanyID, params.ClaGroupID, params.UserID); err != nil {
+			return scimResponse(ctx, http.StatusInternalServerError, err)
+		}
+		return scimResponse(ctx, http.StatusNoContent, nil)
+	})
+}
+
+// ContentTypeMiddleware accepts the SCIM media type on the SCIM endpoints, the requests and the responses are JSON
+// documents so the media type is mapped to application/json for the content negotiation
+func ContentTypeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/scim/v2/") {
+			for _, header := range []string{runtime.HeaderContentType, runtime.HeaderAccept} {
+				if value := r.Header.Get(header); strings.Contains(value, ContentType) {
+					r.Header.Set(header, strings.ReplaceAll(value, ContentType, runtime.JSONMime))
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// decodeBody converts the free-form JSON body of the request to the SCIM message
+func decodeBody(body interface{}, target interface{}) error {
+	data, err := json.Marshal(body)
+	if err == nil {
+		err = json.Unmarshal(data, target)
+	}
+	if err != nil {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, fmt.Sprintf("invalid SCIM message: %v", err))
+	}
+	return nil
+}
+
+// scimResponse writes the payload as a SCIM message. An error is written as a SCIM error with the status code of
+// the error, or the specified status code if the error is not a SCIM error.
+func scimResponse(ctx context.Context, status int, payload interface{}) middleware.Responder {
+	if err, ok := payload.(error); ok {
+		scimErr, isScimErr := err.(*Error)
+		if !isScimErr {
+			log.WithFields(logrus.Fields{
+				"functionName":   "v2.scim.scimResponse",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			}).WithError(err).Warn("unable to process the SCIM request")
+			scimErr = NewError(status, "", "unable to process the SCIM request")
+		}
+		status, payload = scimErr.StatusCode(), scimErr
+	}
+
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		if payload == nil {
+			rw.WriteHeader(status)
+			return
+		}
+		rw.Header().Set(runtime.HeaderContentType, ContentType)
+		rw.WriteHeader(status)
+		if err := json.NewEncoder(rw).Encode(payload); err != nil {
+			log.Warnf("failed to write the SCIM response, error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// SCIM schema URNs - RFC 7643 and RFC 7644
+const (
+	UserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// ContentType is the media type of the SCIM requests and responses
+const ContentType = "application/scim+json"
+
+// User is a SCIM user resource. The company approval list only holds email addresses, so a user is identified
+// by its email address, the other attributes sent by the directory are ignored.
+type User struct {
+	Schemas  []string `json:"schemas"`
+	ID       string   `json:"id,omitempty"`
+	UserName string   `json:"userName"`
+	Emails   []Email  `json:"emails,omitempty"`
+	Active   *bool    `json:"active,omitempty"`
+	Meta     *Meta    `json:"meta,omitempty"`
+}
+
+// Email is an email address of a SCIM user
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Meta is the metadata of a SCIM resource
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+// ListResponse is the response of the SCIM user queries
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []*User  `json:"Resources"`
+}
+
+// PatchOp is a SCIM patch request
+type PatchOp struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is an operation of a SCIM patch request
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// SCIM error types - RFC 7644 section 3.12
+const (
+	ErrorTypeInvalidFilter = "invalidFilter"
+	ErrorTypeInvalidValue  = "invalidValue"
+	ErrorTypeInvalidSyntax = "invalidSyntax"
+	ErrorTypeUniqueness    = "uniqueness"
+	ErrorTypeNoTarget      = "noTarget"
+)
+
+// Error is a SCIM error response, it is also returned as an error by the service
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+	Status   string   `json:"status"`
+	status   int
+}
+
+// Error returns the error detail
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+// StatusCode returns the HTTP status code of the error
+func (e *Error) StatusCode() int {
+	return e.status
+}
+
+// NewError returns a SCIM error with the specified HTTP status code, SCIM error type and detail
+func NewError(status int, scimType, detail string) *Error {
+	return &Error{
+		Schemas:  []string{ErrorSchema},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   fmt.Sprintf("%d", status),
+		status:   status,
+	}
+}
+
+// common errors
+var (
+	ErrUnauthorized = NewError(http.StatusUnauthorized, "", "invalid or missing bearer token")
+)
@@ -0,0 +1,300 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultCount is the number of users returned by a query when the directory does not set the count
+	defaultCount = 100
+)
+
+// ProjectRepo contains the project repo methods used by the service
+type ProjectRepo interface {
+	GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*v1Models.ClaGroup, error)
+}
+
+// Service interface defines the directory sync (SCIM) service methods
+type Service interface {
+	CreateToken(ctx context.Context, companyID string) (string, error)
+	RevokeToken(ctx context.Context, companyID string) error
+	Authenticate(ctx context.Context, companyID, authorization string) error
+
+	ListUsers(ctx context.Context, companyID, claGroupID, filter string, startIndex, count int64) (*ListResponse, error)
+	GetUser(ctx context.Context, companyID, claGroupID, userID string) (*User, error)
+	CreateUser(ctx context.Context, companyID, claGroupID string, user *User) (*User, error)
+	ReplaceUser(ctx context.Context, companyID, claGroupID, userID string, user *User) (*User, error)
+	PatchUser(ctx context.Context, companyID, claGroupID, userID string, patch *PatchOp) (*User, error)
+	DeleteUser(ctx context.Context, companyID, claGroupID, userID string) error
+}
+
+type service struct {
+	companyRepo      company.IRepository
+	projectRepo      ProjectRepo
+	signatureService signatures.SignatureService
+}
+
+// NewService returns an instance of the directory sync (SCIM) service
+func NewService(companyRepo company.IRepository, projectRepo ProjectRepo, signatureService signatures.SignatureService) Service {
+	return &service{
+		companyRepo:      companyRepo,
+		projectRepo:      projectRepo,
+		signatureService: signatureService,
+	}
+}
+
+// directoryScope is the company and CLA group targeted by a directory sync request along with the CCLA signature
+// holding the email approval list
+type directoryScope struct {
+	company   *v1Models.Company
+	claGroup  *v1Models.ClaGroup
+	signature *v1Models.Signature
+}
+
+// CreateToken creates a new directory sync token for the company, replacing the previous token. The token is scoped
+// to the company. Only the hash of the token is stored, the token is returned once.
+func (s *service) CreateToken(ctx context.Context, companyID string) (string, error) {
+	token, err := utils.GenerateScopedToken(companyID)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.companyRepo.UpdateCompanySCIMTokenHash(ctx, companyID, utils.HashToken(token)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeToken revokes the directory sync token of the company
+func (s *service) RevokeToken(ctx context.Context, companyID string) error {
+	return s.companyRepo.UpdateCompanySCIMTokenHash(ctx, companyID, "")
+}
+
+// Authenticate checks the bearer token of the Authorization header against the directory sync token of the company
+func (s *service) Authenticate(ctx context.Context, companyID, authorization string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.scim.service.Authenticate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"companyID":      companyID,
+	}
+
+	token := utils.BearerToken(authorization)
+	if token == "" || utils.TokenScope(token) != companyID {
+		log.WithFields(f).Debug("missing directory sync token or token of another company")
+		return ErrUnauthorized
+	}
+
+	tokenHash, err := s.companyRepo.GetCompanySCIMTokenHash(ctx, companyID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the directory sync token of the company")
+		return ErrUnauthorized
+	}
+	if !utils.TokenMatchesHash(token, tokenHash) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// ListUsers returns the users of the company email approval list matching the filter, startIndex is 1-based
+func (s *service) ListUsers(ctx context.Context, companyID, claGroupID, filter string, startIndex, count int64) (*ListResponse, error) {
+	scope, err := s.loadScope(ctx, companyID, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := approvalListEmails(scope.signature)
+	if strings.TrimSpace(filter) != "" {
+		value, filterErr := parseFilter(filter)
+		if filterErr != nil {
+			return nil, filterErr
+		}
+		emails = filterEmails(emails, value)
+	}
+
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count < 0 {
+		count = 0
+	} else if count == 0 {
+		count = defaultCount
+	}
+	response := &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: len(emails),
+		StartIndex:   int(startIndex),
+		Resources:    []*User{},
+	}
+	for i := startIndex - 1; i < int64(len(emails)) && i < startIndex-1+count; i++ {
+		response.Resources = append(response.Resources, newUser(emails[i]))
+	}
+	response.ItemsPerPage = len(response.Resources)
+	return response, nil
+}
+
+// GetUser returns the user of the company email approval list
+func (s *service) GetUser(ctx context.Context, companyID, claGroupID, userID string) (*User, error) {
+	scope, err := s.loadScope(ctx, companyID, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	email, err := findEmail(scope.signature, userID)
+	if err != nil {
+		return nil, err
+	}
+	return newUser(email), nil
+}
+
+// CreateUser adds the email address of the user to the company email approval list. An inactive user can't be
+// stored in the approval list, the request is rejected.
+func (s *service) CreateUser(ctx context.Context, companyID, claGroupID string, user *User) (*User, error) {
+	scope, err := s.loadScope(ctx, companyID, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	email, err := userEmail(user)
+	if err != nil {
+		return nil, err
+	}
+	if !isActive(user) {
+		return nil, NewError(http.StatusBadRequest, ErrorTypeInvalidValue, fmt.Sprintf("user %s is inactive, only active users are added to the approval list", email))
+	}
+	if _, found := lookupEmail(scope.signature, email); found {
+		return nil, NewError(http.StatusConflict, ErrorTypeUniqueness, fmt.Sprintf("user %s is already in the approval list", email))
+	}
+
+	created := newUser(email)
+	err = s.updateApprovalList(ctx, scope, &v1Models.ApprovalList{AddEmailApprovalList: []string{email}})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ReplaceUser updates the email address of the user in the company email approval list, the user is removed from
+// the approval list when it is deactivated
+func (s *service) ReplaceUser(ctx context.Context, companyID, claGroupID, userID string, user *User) (*User, error) {
+	scope, err := s.loadScope(ctx, companyID, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := findEmail(scope.signature, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.replaceUser(ctx, scope, current, user)
+}
+
+// PatchUser applies the patch operations to the user, then updates the company email approval list like ReplaceUser
+func (s *service) PatchUser(ctx context.Context, companyID, claGroupID, userID string, patch *PatchOp) (*User, error) {
+	scope, err := s.loadScope(ctx, companyID, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := findEmail(scope.signature, userID)
+	if err != nil {
+		return nil, err
+	}
+	user := newUser(current)
+	if err = applyPatch(user, patch); err != nil {
+		return nil, err
+	}
+	return s.replaceUser(ctx, scope, current, user)
+}
+
+// DeleteUser removes the user from the company email approval list
+func (s *service) DeleteUser(ctx context.Context, companyID, claGroupID, userID string) error {
+	scope, err := s.loadScope(ctx, companyID, claGroupID)
+	if err != nil {
+		return err
+	}
+	current, err := findEmail(scope.signature, userID)
+	if err != nil {
+		return err
+	}
+	return s.updateApprovalList(ctx, scope, &v1Models.ApprovalList{RemoveEmailApprovalList: []string{current}})
+}
+
+// replaceUser applies the user representation sent by the directory to the approval list entry of the user
+func (s *service) replaceUser(ctx context.Context, scope *directoryScope, current string, user *User) (*User, error) {
+	if !isActive(user) {
+		if err := s.updateApprovalList(ctx, scope, &v1Models.ApprovalList{RemoveEmailApprovalList: []string{current}}); err != nil {
+			return nil, err
+		}
+		replaced := newUser(current)
+		replaced.Active = utils.Bool(false)
+		return replaced, nil
+	}
+
+	email, err := userEmail(user)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(email, current) {
+		return newUser(current), nil
+	}
+	if _, found := lookupEmail(scope.signature, email); found {
+		return nil, NewError(http.StatusConflict, ErrorTypeUniqueness, fmt.Sprintf("user %s is already in the approval list", email))
+	}
+	err = s.updateApprovalList(ctx, scope, &v1Models.ApprovalList{
+		AddEmailApprovalList:    []string{email},
+		RemoveEmailApprovalList: []string{current},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newUser(email), nil
+}
+
+// updateApprovalList updates the company email approval list through the same code path as the CLA Managers
+func (s *service) updateApprovalList(ctx context.Context, scope *directoryScope, params *v1Models.ApprovalList) error {
+	_, err := s.signatureService.UpdateApprovalListOnBehalfOf(ctx, signatures.DirectorySyncUsername, scope.claGroup, scope.company, params)
+	if err != nil {
+		if _, ok := err.(*signatures.BadRequestError); ok {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, err.Error())
+		}
+		return err
+	}
+	return nil
+}
+
+// loadScope loads the company, the CLA group and the CCLA signature targeted by the request
+func (s *service) loadScope(ctx context.Context, companyID, claGroupID string) (*directoryScope, error) {
+	companyModel, err := s.companyRepo.GetCompany(ctx, companyID)
+	if err != nil || companyModel == nil {
+		return nil, NewError(http.StatusNotFound, "", fmt.Sprintf("company %s not found", companyID))
+	}
+	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, claGroupID, false)
+	if err != nil || claGroupModel == nil {
+		return nil, NewError(http.StatusNotFound, "", fmt.Sprintf("CLA group %s not found", claGroupID))
+	}
+
+	pageSize := int64(1)
+	signed, approved := true, true
+	sigModel, err := s.signatureService.GetProjectCompanySignature(ctx, companyModel.CompanyID, claGroupModel.ProjectID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if sigModel == nil {
+		return nil, NewError(http.StatusNotFound, "", fmt.Sprintf("company %s has no signed CCLA for CLA group %s", companyID, claGroupID))
+	}
+
+	return &directoryScope{
+		company:   companyModel,
+		claGroup:  claGroupModel,
+		signature: sigModel,
+	}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCompanyRepo returns the test company, the embedded interface panics on any other call
+type fakeCompanyRepo struct {
+	company.IRepository
+}
+
+func (fakeCompanyRepo) GetCompany(ctx context.Context, companyID string) (*v1Models.Company, error) {
+	return &v1Models.Company{CompanyID: companyID}, nil
+}
+
+type fakeProjectRepo struct{}
+
+func (fakeProjectRepo) GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*v1Models.ClaGroup, error) {
+	return &v1Models.ClaGroup{ProjectID: claGroupID}, nil
+}
+
+// fakeSignatureService returns the test CCLA signature and records the approval list updates, the embedded
+// interface panics on any other call
+type fakeSignatureService struct {
+	signatures.SignatureService
+	signature *v1Models.Signature
+	updates   []*v1Models.ApprovalList
+}
+
+func (s *fakeSignatureService) GetProjectCompanySignature(ctx context.Context, companyID, projectID string, signed, approved *bool, nextKey *string, pageSize *int64) (*v1Models.Signature, error) {
+	return s.signature, nil
+}
+
+func (s *fakeSignatureService) UpdateApprovalListOnBehalfOf(ctx context.Context, updatedBy string, claGroupModel *v1Models.ClaGroup, companyModel *v1Models.Company, params *v1Models.ApprovalList) (*v1Models.Signature, error) {
+	s.updates = append(s.updates, params)
+	return s.signature, nil
+}
+
+func TestCreateUser(t *testing.T) {
+	signatureService := &fakeSignatureService{signature: &v1Models.Signature{EmailApprovalList: []string{"jane.doe@example.org"}}}
+	s := NewService(fakeCompanyRepo{}, fakeProjectRepo{}, signatureService)
+	ctx := context.Background()
+
+	created, err := s.CreateUser(ctx, "company-1", "cla-group-1", &User{UserName: "john.doe@example.org"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, userID("john.doe@example.org"), created.ID)
+	}
+	if assert.Len(t, signatureService.updates, 1) {
+		assert.Equal(t, []string{"john.doe@example.org"}, signatureService.updates[0].AddEmailApprovalList)
+	}
+
+	_, err = s.CreateUser(ctx, "company-1", "cla-group-1", &User{UserName: "jack.doe@example.org", Active: utils.Bool(false)})
+	if assert.Error(t, err, "an inactive user is rejected") {
+		assert.Equal(t, 400, err.(*Error).StatusCode())
+	}
+
+	_, err = s.CreateUser(ctx, "company-1", "cla-group-1", &User{UserName: "Jane.Doe@example.org"})
+	if assert.Error(t, err, "the user is already in the approval list") {
+		assert.Equal(t, 409, err.(*Error).StatusCode())
+	}
+	assert.Len(t, signatureService.updates, 1, "the rejected users are not stored")
+}
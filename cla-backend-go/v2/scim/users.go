@@ -0,0 +1,204 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// filterRegex matches the equality filters sent by the directories to look up a user, e.g. userName eq "jane@example.org"
+var filterRegex = regexp.MustCompile(`(?i)^\s*(userName|emails|emails\.value|emails\[type eq "work"\]\.value)\s+eq\s+"([^"]*)"\s*$`)
+
+// userID returns the SCIM identifier of the user with the email address, derived from the lower case address so
+// that the directory can address the users without the approval list storing any identifier
+func userID(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:16])
+}
+
+// newUser returns the SCIM representation of the approval list email address
+func newUser(email string) *User {
+	return &User{
+		Schemas:  []string{UserSchema},
+		ID:       userID(email),
+		UserName: email,
+		Emails:   []Email{{Value: email, Type: "work", Primary: true}},
+		Active:   utils.Bool(true),
+		Meta:     &Meta{ResourceType: "User"},
+	}
+}
+
+// isActive returns false if the directory deactivated the user
+func isActive(user *User) bool {
+	return user.Active == nil || *user.Active
+}
+
+// userEmail returns the email address of the user to add to the approval list - the primary email address, the
+// first email address or the user name
+func userEmail(user *User) (string, error) {
+	var email string
+	for _, e := range user.Emails {
+		if e.Primary {
+			email = e.Value
+			break
+		}
+	}
+	if email == "" && len(user.Emails) > 0 {
+		email = user.Emails[0].Value
+	}
+	if email == "" {
+		email = user.UserName
+	}
+	email = strings.TrimSpace(email)
+	if !utils.ValidEmail(email) {
+		return "", NewError(http.StatusBadRequest, ErrorTypeInvalidValue, fmt.Sprintf("the user has no valid email address: %s", email))
+	}
+	return email, nil
+}
+
+// approvalListEmails returns the email addresses of the approval list of the signature, the wildcard and regular
+// expression entries are not users
+func approvalListEmails(sig *v1Models.Signature) []string {
+	var emails []string
+	for _, email := range sig.EmailApprovalList {
+		if !signatures.IsApprovalListPattern(email) {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// lookupEmail returns the approval list entry matching the email address - case insensitive
+func lookupEmail(sig *v1Models.Signature, email string) (string, bool) {
+	for _, value := range approvalListEmails(sig) {
+		if strings.EqualFold(value, email) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// findEmail returns the approval list entry of the user identifier
+func findEmail(sig *v1Models.Signature, id string) (string, error) {
+	for _, value := range approvalListEmails(sig) {
+		if userID(value) == id {
+			return value, nil
+		}
+	}
+	return "", NewError(http.StatusNotFound, "", fmt.Sprintf("user %s not found", id))
+}
+
+// parseFilter returns the value of a user name or email equality filter, the other filters are not supported
+func parseFilter(filter string) (string, error) {
+	match := filterRegex.FindStringSubmatch(filter)
+	if match == nil {
+		return "", NewError(http.StatusBadRequest, ErrorTypeInvalidFilter, fmt.Sprintf("unsupported filter: %s", filter))
+	}
+	return match[2], nil
+}
+
+// filterEmails returns the email addresses equal to the value - case insensitive
+func filterEmails(emails []string, value string) []string {
+	var filtered []string
+	for _, email := range emails {
+		if strings.EqualFold(email, value) {
+			filtered = append(filtered, email)
+		}
+	}
+	return filtered
+}
+
+// applyPatch applies the add and replace operations of the patch to the active, userName and emails attributes of
+// the user, the other attributes are ignored
+func applyPatch(user *User, patch *PatchOp) error {
+	if patch == nil || len(patch.Operations) == 0 {
+		return NewError(http.StatusBadRequest, ErrorTypeInvalidSyntax, "the patch has no operations")
+	}
+	for _, operation := range patch.Operations {
+		op := strings.ToLower(operation.Op)
+		if op != "add" && op != "replace" {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, fmt.Sprintf("unsupported patch operation: %s", operation.Op))
+		}
+
+		values := map[string]interface{}{}
+		if operation.Path == "" {
+			object, ok := operation.Value.(map[string]interface{})
+			if !ok {
+				return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, "the patch operation value must be an object when the path is not set")
+			}
+			values = object
+		} else {
+			values[operation.Path] = operation.Value
+		}
+
+		for path, value := range values {
+			if err := applyPatchValue(user, path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyPatchValue sets the attribute of the user at the path
+func applyPatchValue(user *User, path string, value interface{}) error {
+	switch strings.ToLower(path) {
+	case "active":
+		active, ok := patchBool(value)
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, fmt.Sprintf("invalid active value: %v", value))
+		}
+		user.Active = utils.Bool(active)
+	case "username":
+		userName, ok := value.(string)
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, fmt.Sprintf("invalid userName value: %v", value))
+		}
+		user.UserName = userName
+		user.Emails = nil
+	case "emails":
+		data, err := json.Marshal(value)
+		var emails []Email
+		if err == nil {
+			err = json.Unmarshal(data, &emails)
+		}
+		if err != nil {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, fmt.Sprintf("invalid emails value: %v", value))
+		}
+		user.Emails = emails
+	case `emails[type eq "work"].value`, "emails.value":
+		email, ok := value.(string)
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrorTypeInvalidValue, fmt.Sprintf("invalid email value: %v", value))
+		}
+		user.Emails = []Email{{Value: email, Type: "work", Primary: true}}
+	}
+	return nil
+}
+
+// patchBool returns the boolean patch value, some directories send the booleans as strings
+func patchBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserID(t *testing.T) {
+	assert.Equal(t, userID("jane.doe@example.org"), userID(" Jane.Doe@Example.org"), "the identifier is case insensitive")
+	assert.NotEqual(t, userID("jane.doe@example.org"), userID("john.doe@example.org"))
+	assert.Len(t, userID("jane.doe@example.org"), 32)
+}
+
+func TestFindEmail(t *testing.T) {
+	sig := &v1Models.Signature{EmailApprovalList: []string{"*@corp.example.org", "Jane.Doe@example.org"}}
+
+	email, err := findEmail(sig, userID("jane.doe@example.org"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane.Doe@example.org", email, "the stored value is returned")
+
+	_, err = findEmail(sig, userID("*@corp.example.org"))
+	assert.Error(t, err, "the patterns are not users")
+	assert.Equal(t, 404, err.(*Error).StatusCode())
+
+	value, found := lookupEmail(sig, "jane.doe@EXAMPLE.org")
+	assert.True(t, found)
+	assert.Equal(t, "Jane.Doe@example.org", value)
+}
+
+func TestParseFilter(t *testing.T) {
+	value, err := parseFilter(`userName eq "jane.doe@example.org"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.org", value)
+
+	value, err = parseFilter(`emails[type eq "work"].value eq "jane.doe@example.org"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.org", value)
+
+	_, err = parseFilter(`userName sw "jane"`)
+	assert.Error(t, err)
+	assert.Equal(t, ErrorTypeInvalidFilter, err.(*Error).ScimType)
+
+	assert.Equal(t, []string{"Jane.Doe@example.org"}, filterEmails([]string{"Jane.Doe@example.org", "john.doe@example.org"}, "jane.doe@example.org"))
+}
+
+func TestUserEmail(t *testing.T) {
+	email, err := userEmail(&User{UserName: "jdoe", Emails: []Email{{Value: "jane@home.example.org"}, {Value: "jane.doe@example.org", Primary: true}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.org", email, "the primary email address")
+
+	email, err = userEmail(&User{UserName: "jane.doe@example.org"})
+	assert.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.org", email, "the user name")
+
+	_, err = userEmail(&User{UserName: "jdoe"})
+	assert.Error(t, err)
+}
+
+func TestApplyPatch(t *testing.T) {
+	var patch PatchOp
+	assert.NoError(t, json.Unmarshal([]byte(`{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations":[{"op":"Replace","path":"active","value":"False"}]}`), &patch))
+	user := newUser("jane.doe@example.org")
+	assert.NoError(t, applyPatch(user, &patch))
+	assert.False(t, isActive(user), "the boolean sent as a string is accepted")
+
+	patch = PatchOp{Operations: []PatchOperation{{Op: "replace", Value: map[string]interface{}{
+		"active": true,
+		"emails": []interface{}{map[string]interface{}{"value": "jane.smith@example.org", "primary": true}},
+	}}}}
+	assert.NoError(t, applyPatch(user, &patch))
+	assert.True(t, isActive(user))
+	email, err := userEmail(user)
+	assert.NoError(t, err)
+	assert.Equal(t, "jane.smith@example.org", email)
+
+	assert.Error(t, applyPatch(user, &PatchOp{Operations: []PatchOperation{{Op: "remove", Path: "emails"}}}))
+	assert.Error(t, applyPatch(user, &PatchOp{}))
+}
@@ -0,0 +1,321 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SignMethodClickThrough is the sign method stored on the signatures created without DocuSign
+const SignMethodClickThrough = "click_through"
+
+// click-through errors
+var (
+	ErrICLANotEnabled      = errors.New("individual license agreement is not enabled with this project")
+	ErrInvalidClickThrough = errors.New("invalid click-through signature")
+	ErrDocumentChanged     = errors.New("the individual license agreement changed since it was reviewed, please review it again")
+	ErrICLAAlreadySigned   = errors.New("user has already signed the current individual license agreement of this project")
+)
+
+// clickThroughDocument is the current ICLA document of a CLA Group with its content
+type clickThroughDocument struct {
+	claGroup *v1Models.ClaGroup
+	document v1Models.ClaGroupDocument
+	fileName string
+	content  []byte
+	hash     string
+}
+
+// documentHash returns the hex encoded SHA-256 hash of the document
+func documentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// trustedProxyHops is the number of proxies in front of the API appending to the X-Forwarded-For header after the
+// client address, the edge-optimized API Gateway appends the address of the CloudFront edge
+const trustedProxyHops = 1
+
+// clientIPAddress returns the address of the client which sent the request. The client can send X-Forwarded-For
+// entries of its own, the proxies append the address they received the request from, so the client address is the
+// entry appended by the first trusted proxy, read from the right.
+func clientIPAddress(req *http.Request) string {
+	if req == nil {
+		return ""
+	}
+	var hops []string
+	for _, forwardedFor := range req.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(forwardedFor, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	if len(hops) > trustedProxyHops {
+		return hops[len(hops)-1-trustedProxyHops]
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// validateClickThroughInput checks the contributor agreed to the document they reviewed
+func validateClickThroughInput(input *models.ClickThroughSignatureInput, currentHash string) error {
+	if input == nil || !utils.BoolValue(input.Agree) {
+		return fmt.Errorf("%w: the terms of the individual license agreement must be agreed to", ErrInvalidClickThrough)
+	}
+	if strings.TrimSpace(utils.StringValue(input.FullName)) == "" {
+		return fmt.Errorf("%w: the full name is required", ErrInvalidClickThrough)
+	}
+	if !strings.EqualFold(strings.TrimSpace(utils.StringValue(input.DocumentHash)), currentHash) {
+		return ErrDocumentChanged
+	}
+	return nil
+}
+
+// stampLines returns the signature details stamped on the signed document
+func stampLines(sig *signatures.ItemIndividualSignature) []string {
+	return []string{
+		fmt.Sprintf("Electronically signed by %s <%s> (LF ID: %s)", sig.SignatoryName, sig.UserEmail, sig.UserLFUsername),
+		fmt.Sprintf("Signed on %s from IP address %s", sig.SignedOn, sig.SignatureClientIP),
+		fmt.Sprintf("Signature ID: %s, document SHA-256: %s", sig.SignatureID, sig.SignatureDocumentHash),
+	}
+}
+
+// loadClickThroughDocument loads the current ICLA document of the CLA Group
+func (s *service) loadClickThroughDocument(ctx context.Context, claGroupID string) (*clickThroughDocument, error) {
+	f := logrus.Fields{
+		"functionName":   "loadClickThroughDocument",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	claGroupModel, err := s.projectRepo.GetCLAGroupByID(ctx, claGroupID, DontLoadRepoDetails)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup CLA Group by CLA Group ID")
+		return nil, err
+	}
+	if !claGroupModel.ProjectICLAEnabled {
+		log.WithFields(f).Warn("unable to sign the individual license agreement - ICLA is not enabled for this CLA Group")
+		return nil, ErrICLANotEnabled
+	}
+
+	currentDoc, err := project.GetCurrentDocument(ctx, claGroupModel.ProjectIndividualDocuments)
+	if err != nil || currentDoc.DocumentS3URL == "" {
+		log.WithFields(f).WithError(err).Warn("unable to determine the current ICLA document of the CLA Group")
+		return nil, ErrTemplateNotConfigured
+	}
+
+	fileName, err := utils.GetPathFromURL(currentDoc.DocumentS3URL)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem obtaining path from URL: %s", currentDoc.DocumentS3URL)
+		return nil, err
+	}
+	fileName = strings.TrimLeft(fileName, "/")
+
+	content, err := utils.DownloadFromS3(fileName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem downloading the ICLA document from s3 using filename: %s", fileName)
+		return nil, err
+	}
+
+	return &clickThroughDocument{
+		claGroup: claGroupModel,
+		document: currentDoc,
+		fileName: fileName,
+		content:  content,
+		hash:     documentHash(content),
+	}, nil
+}
+
+// GetIndividualClickThroughDocument returns the current ICLA document of the CLA Group to be reviewed before signing
+func (s *service) GetIndividualClickThroughDocument(ctx context.Context, claGroupID string) (*models.ClickThroughDocument, error) {
+	doc, err := s.loadClickThroughDocument(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	documentURL, err := utils.GetDownloadLink(doc.fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ClickThroughDocument{
+		ClaGroupID:           doc.claGroup.ProjectID,
+		ClaGroupName:         doc.claGroup.ProjectName,
+		DocumentName:         doc.document.DocumentName,
+		DocumentMajorVersion: doc.document.DocumentMajorVersion,
+		DocumentMinorVersion: doc.document.DocumentMinorVersion,
+		DocumentURL:          documentURL,
+		DocumentHash:         doc.hash,
+	}, nil
+}
+
+// SignIndividualClickThrough records the ICLA signature of the user for the reviewed document and stores the
+// document stamped with the signature details
+func (s *service) SignIndividualClickThrough(ctx context.Context, claGroupID, lfUsername, lfEmail, clientIP string, input *models.ClickThroughSignatureInput) (*models.ClickThroughSignatureOutput, error) {
+	f := logrus.Fields{
+		"functionName":   "SignIndividualClickThrough",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"lfUsername":     lfUsername,
+		"clientIP":       clientIP,
+	}
+
+	doc, err := s.loadClickThroughDocument(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateClickThroughInput(input, doc.hash); err != nil {
+		log.WithFields(f).WithError(err).Warn("invalid click-through signature")
+		return nil, err
+	}
+
+	userModel, err := s.lookupOrCreateUser(ctx, lfUsername, lfEmail, utils.StringValue(input.FullName))
+	if err != nil {
+		return nil, err
+	}
+	f["userID"] = userModel.UserID
+
+	existing, err := s.signatureRepo.GetIndividualSignature(ctx, claGroupID, userModel.UserID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the existing ICLA signature of the user")
+		return nil, err
+	}
+	if existing != nil {
+		// signing again is only needed for a new major version of the document
+		signedVersion, _ := strconv.Atoi(existing.SignatureMajorVersion)
+		currentVersion, _ := strconv.Atoi(doc.document.DocumentMajorVersion)
+		if signedVersion >= currentVersion {
+			log.WithFields(f).Warnf("user already signed the ICLA with signature: %s", existing.SignatureID)
+			return nil, ErrICLAAlreadySigned
+		}
+	}
+
+	signatureID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the signature")
+		return nil, err
+	}
+	_, now := utils.CurrentTime()
+	fullName := strings.TrimSpace(utils.StringValue(input.FullName))
+	sig := &signatures.ItemIndividualSignature{
+		SignatureID:                   signatureID.String(),
+		DateCreated:                   now,
+		DateModified:                  now,
+		SignatureApproved:             true,
+		SignatureSigned:               true,
+		SignatureDocumentMajorVersion: doc.document.DocumentMajorVersion,
+		SignatureDocumentMinorVersion: doc.document.DocumentMinorVersion,
+		SignatureReferenceID:          userModel.UserID,
+		SignatureReferenceName:        fullName,
+		SignatureReferenceNameLower:   strings.ToLower(fullName),
+		SignatureProjectID:            claGroupID,
+		SignatureReferenceType:        utils.SignatureReferenceTypeUser,
+		SignatureType:                 utils.SignatureTypeCLA,
+		UserGithubUsername:            userModel.GithubUsername,
+		UserLFUsername:                lfUsername,
+		UserName:                      fullName,
+		UserEmail:                     lfEmail,
+		SigtypeSignedApprovedID:       fmt.Sprintf("%s#%v#%v#%s", utils.ClaTypeICLA, true, true, userModel.UserID),
+		SignedOn:                      now,
+		SignatoryName:                 fullName,
+		SignatureSignMethod:           SignMethodClickThrough,
+		SignatureClientIP:             clientIP,
+		SignatureDocumentHash:         doc.hash,
+		Version:                       "v1",
+	}
+
+	stamped, err := utils.StampPdf(doc.content, stampLines(sig))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem stamping the signed ICLA document")
+		return nil, err
+	}
+	err = utils.UploadToS3(stamped, claGroupID, utils.ClaTypeICLA, userModel.UserID, sig.SignatureID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem uploading the signed ICLA document to s3")
+		return nil, err
+	}
+
+	err = s.signatureRepo.CreateIndividualSignature(ctx, sig)
+	if err != nil {
+		// the signed document of a signature which was not recorded is not kept
+		fileName := utils.SignedCLAFilename(claGroupID, utils.ClaTypeICLA, userModel.UserID, sig.SignatureID)
+		if deleteErr := utils.DeleteFromS3(fileName); deleteErr != nil {
+			log.WithFields(f).WithError(deleteErr).Warnf("problem deleting the signed ICLA document: %s from s3", fileName)
+		}
+		return nil, err
+	}
+	log.WithFields(f).Debugf("created click-through ICLA signature: %s", sig.SignatureID)
+
+	signedDocumentURL, err := utils.GetDownloadLink(utils.SignedCLAFilename(claGroupID, utils.ClaTypeICLA, userModel.UserID, sig.SignatureID))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the signed document download link")
+	}
+
+	return &models.ClickThroughSignatureOutput{
+		SignatureID:          sig.SignatureID,
+		SignedOn:             sig.SignedOn,
+		DocumentMajorVersion: sig.SignatureDocumentMajorVersion,
+		DocumentMinorVersion: sig.SignatureDocumentMinorVersion,
+		DocumentHash:         sig.SignatureDocumentHash,
+		SignedDocumentURL:    signedDocumentURL,
+	}, nil
+}
+
+// lookupOrCreateUser returns the EasyCLA user of the LF login, the user is created on the first signature
+func (s *service) lookupOrCreateUser(ctx context.Context, lfUsername, lfEmail, fullName string) (*v1Models.User, error) {
+	f := logrus.Fields{
+		"functionName":   "lookupOrCreateUser",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"lfUsername":     lfUsername,
+	}
+
+	userModel, err := s.usersService.GetUserByLFUserName(lfUsername)
+	if err != nil {
+		// only a missing user is created, a failed lookup could otherwise create a duplicate of an existing user
+		log.WithFields(f).WithError(err).Warn("unable to lookup the user by LF username")
+		return nil, err
+	}
+	if userModel != nil {
+		return userModel, nil
+	}
+
+	log.WithFields(f).Debug("user not found by LF username - creating user...")
+	_, now := utils.CurrentTime()
+	userModel, err = s.usersService.CreateUser(&v1Models.User{
+		DateCreated:  now,
+		DateModified: now,
+		Emails:       []string{lfEmail},
+		LfEmail:      lfEmail,
+		LfUsername:   lfUsername,
+		Note:         "created from click-through signature",
+		Username:     fullName,
+		Version:      "v1",
+	}, nil)
+	if err != nil || userModel == nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the user")
+		return nil, fmt.Errorf("unable to create user %s: %v", lfUsername, err)
+	}
+	return userModel, nil
+}
@@ -0,0 +1,111 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentHash(t *testing.T) {
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", documentHash([]byte("test")))
+}
+
+func TestClientIPAddress(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "/v4/sign/individual/cla-group/click-through", nil)
+	assert.NoError(t, err)
+	req.RemoteAddr = "10.0.0.12:43210"
+	assert.Equal(t, "10.0.0.12", clientIPAddress(req))
+
+	// the address appended by the CloudFront edge is not the client address
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	assert.Equal(t, "203.0.113.7", clientIPAddress(req))
+
+	// the entries sent by the client are ignored
+	req.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7, 10.0.0.1")
+	assert.Equal(t, "203.0.113.7", clientIPAddress(req))
+	req.Header.Add("X-Forwarded-For", "10.0.0.2")
+	assert.Equal(t, "10.0.0.1", clientIPAddress(req))
+
+	// without a proxy the connection address is used
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	assert.Equal(t, "10.0.0.12", clientIPAddress(req))
+
+	assert.Equal(t, "", clientIPAddress(nil))
+}
+
+func TestValidateClickThroughInput(t *testing.T) {
+	hash := documentHash([]byte("icla"))
+	input := func(agree bool, fullName, documentHash string) *models.ClickThroughSignatureInput {
+		return &models.ClickThroughSignatureInput{
+			Agree:        &agree,
+			FullName:     &fullName,
+			DocumentHash: &documentHash,
+		}
+	}
+
+	assert.NoError(t, validateClickThroughInput(input(true, "Derk Miyamoto", hash), hash))
+	assert.True(t, errors.Is(validateClickThroughInput(input(false, "Derk Miyamoto", hash), hash), ErrInvalidClickThrough))
+	assert.True(t, errors.Is(validateClickThroughInput(input(true, " ", hash), hash), ErrInvalidClickThrough))
+	assert.Equal(t, ErrDocumentChanged, validateClickThroughInput(input(true, "Derk Miyamoto", documentHash([]byte("old icla"))), hash))
+
+	fullName := "Derk Miyamoto"
+	assert.True(t, errors.Is(validateClickThroughInput(&models.ClickThroughSignatureInput{FullName: &fullName}, hash), ErrInvalidClickThrough), "agree is required")
+}
+
+// lfUsersService serves the users by LF username, the other methods are not used
+type lfUsersService struct {
+	users.Service
+	users     map[string]*v1Models.User
+	lookupErr error
+	created   []*v1Models.User
+}
+
+func (s *lfUsersService) GetUserByLFUserName(lfUserName string) (*v1Models.User, error) {
+	if s.lookupErr != nil {
+		return nil, s.lookupErr
+	}
+	return s.users[lfUserName], nil
+}
+
+func (s *lfUsersService) CreateUser(userModel *v1Models.User, claUser *user.CLAUser) (*v1Models.User, error) {
+	created := *userModel
+	created.UserID = "created-user"
+	s.created = append(s.created, &created)
+	return &created, nil
+}
+
+func TestLookupOrCreateUser(t *testing.T) {
+	ctx := context.Background()
+	usersService := &lfUsersService{users: map[string]*v1Models.User{"alice": {UserID: "user-alice", LfUsername: "alice"}}}
+	s := &service{usersService: usersService}
+
+	userModel, err := s.lookupOrCreateUser(ctx, "alice", "alice@example.org", "Alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-alice", userModel.UserID)
+	assert.Empty(t, usersService.created)
+
+	userModel, err = s.lookupOrCreateUser(ctx, "bob", "bob@example.org", "Bob")
+	assert.NoError(t, err)
+	assert.Equal(t, "created-user", userModel.UserID)
+	if assert.Len(t, usersService.created, 1) {
+		assert.Equal(t, "bob", usersService.created[0].LfUsername)
+		assert.Equal(t, "bob@example.org", usersService.created[0].LfEmail)
+	}
+
+	// a failed lookup does not create a duplicate of an existing user
+	usersService.lookupErr = errors.New("throughput exceeded")
+	userModel, err = s.lookupOrCreateUser(ctx, "alice", "alice@example.org", "Alice")
+	assert.Error(t, err)
+	assert.Nil(t, userModel)
+	assert.Len(t, usersService.created, 1)
+}
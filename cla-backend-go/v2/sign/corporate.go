@@ -0,0 +1,325 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/esign"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// corporate signature errors
+var (
+	ErrCCLAAlreadySigned       = errors.New("company has already signed CCLA with this project")
+	ErrSignatureNotFound       = errors.New("signature does not exist")
+	ErrSignatureNotPending     = errors.New("signature is not waiting for the signatory")
+	ErrSignatureDeclined       = errors.New("the signatory declined to sign the corporate license agreement")
+	ErrESignProviderNotEnabled = errors.New("corporate signatures are not collected by an e-signature provider")
+)
+
+// signMethodESign returns the sign method stored on the signatures collected by the e-signature provider
+func signMethodESign(provider esign.Provider) string {
+	return "esign:" + provider.Name()
+}
+
+// corporateSigner is the person the CCLA signing request is routed to
+type corporateSigner struct {
+	name  string
+	email string
+}
+
+// pendingCorporateSignature returns the CCLA signature record created when the signature is requested, it is
+// approved but not signed until the provider reports the envelope as completed
+func pendingCorporateSignature(signatureID string, claGroup *v1Models.ClaGroup, document v1Models.ClaGroupDocument, comp *v1Models.Company, lfUsername, returnURL, now string) *signatures.ItemCorporateSignature {
+	return &signatures.ItemCorporateSignature{
+		SignatureID:                   signatureID,
+		DateCreated:                   now,
+		DateModified:                  now,
+		SignatureApproved:             true,
+		SignatureSigned:               false,
+		SignatureDocumentMajorVersion: document.DocumentMajorVersion,
+		SignatureDocumentMinorVersion: document.DocumentMinorVersion,
+		SignatureReferenceID:          comp.CompanyID,
+		SignatureReferenceName:        comp.CompanyName,
+		SignatureReferenceNameLower:   strings.ToLower(comp.CompanyName),
+		SignatureProjectID:            claGroup.ProjectID,
+		SignatureReferenceType:        utils.SignatureReferenceTypeCompany,
+		SignatureType:                 utils.SignatureTypeCCLA,
+		SignatureACL:                  []string{lfUsername},
+		SigtypeSignedApprovedID:       fmt.Sprintf("%s#%v#%v#%s", utils.ClaTypeCCLA, false, true, comp.CompanyID),
+		SignatureReturnURL:            returnURL,
+		Version:                       "v1",
+	}
+}
+
+// blocksCorporateSignature returns true if the existing CCLA signature of the company prevents it from signing the
+// CCLA again - a CCLA flagged for a re-signature against a new major version is re-signed, the new signature replaces it
+func blocksCorporateSignature(existing *v1Models.Signature) bool {
+	return existing != nil && !signatures.ResignatureRequired(existing)
+}
+
+// requestESignCorporateSignature creates the pending CCLA signature of the company and sends the current CCLA
+// document of the CLA Group to the signatory through the e-signature provider
+func (s *service) requestESignCorporateSignature(ctx context.Context, lfUsername string, claGroup *v1Models.ClaGroup, comp *v1Models.Company, signer corporateSigner, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error) {
+	f := logrus.Fields{
+		"functionName":   "sign.requestESignCorporateSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroup.ProjectID,
+		"companyID":      comp.CompanyID,
+		"lfUsername":     lfUsername,
+		"signerEmail":    signer.email,
+		"provider":       s.esignProvider.Name(),
+	}
+
+	existing, err := s.signatureRepo.GetCorporateSignature(ctx, claGroup.ProjectID, comp.CompanyID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the existing CCLA signature of the company")
+		return nil, err
+	}
+	if blocksCorporateSignature(existing) {
+		log.WithFields(f).Warnf("company already signed the CCLA with signature: %s", existing.SignatureID)
+		return nil, ErrCCLAAlreadySigned
+	}
+
+	currentDoc, err := project.GetCurrentDocument(ctx, claGroup.ProjectCorporateDocuments)
+	if err != nil || currentDoc.DocumentS3URL == "" {
+		log.WithFields(f).WithError(err).Warn("unable to determine the current CCLA document of the CLA Group")
+		return nil, ErrTemplateNotConfigured
+	}
+
+	pending, err := s.getPendingCorporateSignature(ctx, claGroup.ProjectID, comp.CompanyID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the pending CCLA signature of the company")
+		return nil, err
+	}
+	if pending != nil {
+		out, reuseErr := s.reusePendingCorporateSignature(ctx, pending, currentDoc, signer, input)
+		if reuseErr != nil || out != nil {
+			return out, reuseErr
+		}
+	}
+	fileName, err := utils.GetPathFromURL(currentDoc.DocumentS3URL)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem obtaining path from URL: %s", currentDoc.DocumentS3URL)
+		return nil, err
+	}
+	document, err := utils.DownloadFromS3(strings.TrimLeft(fileName, "/"))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem downloading the CCLA document from s3 using filename: %s", fileName)
+		return nil, err
+	}
+
+	// the signing request is sent again on the pending signature of the company, only one CCLA signature is created
+	var signatureID string
+	if pending != nil {
+		signatureID = pending.SignatureID
+	} else {
+		newID, idErr := uuid.NewV4()
+		if idErr != nil {
+			log.WithFields(f).WithError(idErr).Warn("unable to generate a UUID for the signature")
+			return nil, idErr
+		}
+		signatureID = newID.String()
+	}
+	_, now := utils.CurrentTime()
+	sig := pendingCorporateSignature(signatureID, claGroup, currentDoc, comp, lfUsername, input.ReturnURL.String(), now)
+
+	envelope, err := s.esignProvider.CreateEnvelope(ctx, &esign.EnvelopeRequest{
+		SignatureID:  sig.SignatureID,
+		DocumentName: currentDoc.DocumentName,
+		Document:     document,
+		SignerName:   signer.name,
+		SignerEmail:  signer.email,
+		SendAsEmail:  input.SendAsEmail,
+		ReturnURL:    input.ReturnURL.String(),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the e-signature envelope")
+		return nil, err
+	}
+	sig.SignatureEnvelopeID = envelope.ID
+	sig.SignatureSignURL = envelope.SignURL
+	sig.SignatureSignMethod = signMethodESign(s.esignProvider)
+
+	if pending != nil {
+		err = s.signatureRepo.ReplaceCorporateSignatureEnvelope(ctx, sig)
+		if err != nil {
+			return nil, err
+		}
+		log.WithFields(f).Debugf("sent pending CCLA signature: %s again with envelope: %s", sig.SignatureID, envelope.ID)
+	} else {
+		err = s.signatureRepo.CreateCorporateSignature(ctx, sig)
+		if err != nil {
+			return nil, err
+		}
+		log.WithFields(f).Debugf("created pending CCLA signature: %s with envelope: %s", sig.SignatureID, envelope.ID)
+	}
+
+	return &models.CorporateSignatureOutput{
+		SignatureID: sig.SignatureID,
+		SignURL:     envelope.SignURL,
+	}, nil
+}
+
+// getPendingCorporateSignature returns the CCLA signature of the company waiting for the signatory, nil if the
+// signature was not requested
+func (s *service) getPendingCorporateSignature(ctx context.Context, claGroupID, companyID string) (*signatures.ItemCorporateSignature, error) {
+	signed, approved := false, true
+	pending, err := s.signatureRepo.GetProjectCompanySignature(ctx, companyID, claGroupID, &signed, &approved, nil, nil)
+	if err != nil || pending == nil {
+		return nil, err
+	}
+	return s.signatureRepo.GetCorporateSignatureRecord(ctx, pending.SignatureID)
+}
+
+// reusePendingCorporateSignature returns the pending CCLA signature when its envelope still waits for the same
+// signatory on the current document. Otherwise the envelope is voided and nil is returned so the signing request is
+// sent again on the pending signature.
+func (s *service) reusePendingCorporateSignature(ctx context.Context, pending *signatures.ItemCorporateSignature, currentDoc v1Models.ClaGroupDocument, signer corporateSigner, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error) {
+	f := logrus.Fields{
+		"functionName":   "sign.reusePendingCorporateSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    pending.SignatureID,
+		"envelopeID":     pending.SignatureEnvelopeID,
+		"signerEmail":    signer.email,
+	}
+
+	if pending.SignatureEnvelopeID == "" || pending.SignatureSignMethod != signMethodESign(s.esignProvider) {
+		log.WithFields(f).Warnf("pending signature was not requested through the %s provider, its signing request can not be voided", s.esignProvider.Name())
+		return nil, nil
+	}
+
+	result, err := s.esignProvider.GetEnvelope(ctx, pending.SignatureEnvelopeID)
+	if err == esign.ErrEnvelopeNotFound {
+		log.WithFields(f).Warn("envelope of the pending signature not found")
+		return nil, nil
+	}
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to check the envelope of the pending signature")
+		return nil, err
+	}
+
+	switch result.Status {
+	case esign.StatusCompleted:
+		// the signatory signed but the provider callback did not complete the signature yet
+		_, _, err = s.CompleteCorporateSignature(ctx, pending.SignatureID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrCCLAAlreadySigned
+	case esign.StatusSent:
+		sameDocument := pending.SignatureDocumentMajorVersion == currentDoc.DocumentMajorVersion &&
+			pending.SignatureDocumentMinorVersion == currentDoc.DocumentMinorVersion
+		sameDelivery := (pending.SignatureSignURL == "") == input.SendAsEmail
+		if sameDocument && sameDelivery && strings.EqualFold(result.SignerEmail, signer.email) {
+			log.WithFields(f).Debug("reusing the signing request of the pending signature")
+			return &models.CorporateSignatureOutput{
+				SignatureID: pending.SignatureID,
+				SignURL:     pending.SignatureSignURL,
+			}, nil
+		}
+		err = s.esignProvider.VoidEnvelope(ctx, pending.SignatureEnvelopeID, "the corporate license agreement signature was requested again")
+		if err != nil && err != esign.ErrEnvelopeNotFound {
+			log.WithFields(f).WithError(err).Warn("unable to void the envelope of the pending signature")
+			return nil, err
+		}
+		log.WithFields(f).Debug("voided the envelope of the pending signature")
+	}
+	return nil, nil
+}
+
+// VerifyCompletionWebhook checks that the completion callback of the signature was signed by the e-signature
+// provider, the signed payload is the signature ID of the callback
+func (s *service) VerifyCompletionWebhook(ctx context.Context, signatureID, timestamp, webhookSignature string) error {
+	if s.esignProvider == nil {
+		return ErrESignProviderNotEnabled
+	}
+	if err := s.esignProvider.VerifyWebhook(timestamp, webhookSignature, []byte(signatureID)); err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "sign.VerifyCompletionWebhook",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"signatureID":    signatureID,
+		}).Warnf("rejecting the completion callback, error: %+v", err)
+		return err
+	}
+	return nil
+}
+
+// CompleteCorporateSignature checks the envelope of the pending CCLA signature with the e-signature provider and
+// once signed stores the signed document and marks the signature as signed. The provider is the source of truth,
+// the provider callbacks are authenticated with VerifyCompletionWebhook first. Returns the signature record and
+// whether this call completed it.
+func (s *service) CompleteCorporateSignature(ctx context.Context, signatureID string) (*signatures.ItemCorporateSignature, bool, error) {
+	f := logrus.Fields{
+		"functionName":   "sign.CompleteCorporateSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+	if s.esignProvider == nil {
+		return nil, false, ErrESignProviderNotEnabled
+	}
+
+	sig, err := s.signatureRepo.GetCorporateSignatureRecord(ctx, signatureID)
+	if err != nil {
+		return nil, false, err
+	}
+	if sig == nil || sig.SignatureType != utils.SignatureTypeCCLA {
+		return nil, false, ErrSignatureNotFound
+	}
+	if sig.SignatureSigned {
+		log.WithFields(f).Debug("signature already signed")
+		return sig, false, nil
+	}
+	if sig.SignatureEnvelopeID == "" || sig.SignatureSignMethod != signMethodESign(s.esignProvider) {
+		log.WithFields(f).Warnf("signature was not requested through the %s provider", s.esignProvider.Name())
+		return nil, false, ErrSignatureNotPending
+	}
+
+	result, err := s.esignProvider.GetEnvelope(ctx, sig.SignatureEnvelopeID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to check the envelope: %s", sig.SignatureEnvelopeID)
+		return nil, false, err
+	}
+	switch result.Status {
+	case esign.StatusCompleted:
+	case esign.StatusDeclined:
+		return nil, false, ErrSignatureDeclined
+	default:
+		log.WithFields(f).Debugf("envelope: %s is %s", sig.SignatureEnvelopeID, result.Status)
+		return nil, false, ErrSignatureNotPending
+	}
+
+	err = utils.UploadToS3(result.SignedDocument, sig.SignatureProjectID, utils.ClaTypeCCLA, sig.SignatureReferenceID, sig.SignatureID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem uploading the signed CCLA document to s3")
+		return nil, false, err
+	}
+
+	signedOn := result.CompletedOn
+	if signedOn == "" {
+		_, signedOn = utils.CurrentTime()
+	}
+	err = s.signatureRepo.MarkCorporateSignatureSigned(ctx, sig.SignatureID, sig.SignatureReferenceID, result.SignerName, result.SignerEmail, signedOn)
+	if err != nil {
+		return nil, false, err
+	}
+	log.WithFields(f).Debugf("CCLA signature signed by: %s", result.SignerEmail)
+
+	sig.SignatureSigned = true
+	sig.SignedOn = signedOn
+	sig.SignatoryName = result.SignerName
+	sig.SignatoryEmail = result.SignerEmail
+	return sig, true, nil
+}
@@ -0,0 +1,154 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/esign"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// signatureRecordRepo serves the CCLA signature records used by the completion, the other methods are not used
+type signatureRecordRepo struct {
+	signatures.SignatureRepository
+	records map[string]*signatures.ItemCorporateSignature
+}
+
+func (r *signatureRecordRepo) GetCorporateSignatureRecord(ctx context.Context, signatureID string) (*signatures.ItemCorporateSignature, error) {
+	return r.records[signatureID], nil
+}
+
+func TestPendingCorporateSignature(t *testing.T) {
+	claGroup := &v1Models.ClaGroup{ProjectID: "cla-group-1"}
+	document := v1Models.ClaGroupDocument{DocumentMajorVersion: "2", DocumentMinorVersion: "1"}
+	comp := &v1Models.Company{CompanyID: "company-1", CompanyName: "Acme Corp"}
+
+	sig := pendingCorporateSignature("signature-1", claGroup, document, comp, "cla-manager", "https://corporate.example.org", "2021-03-01T00:00:00Z")
+	assert.True(t, sig.SignatureApproved)
+	assert.False(t, sig.SignatureSigned, "the signature is pending until the provider completes the envelope")
+	assert.Equal(t, utils.SignatureTypeCCLA, sig.SignatureType)
+	assert.Equal(t, utils.SignatureReferenceTypeCompany, sig.SignatureReferenceType)
+	assert.Equal(t, "company-1", sig.SignatureReferenceID)
+	assert.Equal(t, "acme corp", sig.SignatureReferenceNameLower)
+	assert.Equal(t, []string{"cla-manager"}, sig.SignatureACL)
+	assert.Equal(t, "ccla#false#true#company-1", sig.SigtypeSignedApprovedID)
+	assert.Equal(t, "2", sig.SignatureDocumentMajorVersion)
+}
+
+func TestCompleteCorporateSignatureWaitsForProvider(t *testing.T) {
+	ctx := context.Background()
+	provider := esign.NewFakeProvider(false, "secret")
+	repo := &signatureRecordRepo{records: map[string]*signatures.ItemCorporateSignature{}}
+	s := &service{signatureRepo: repo, esignProvider: provider}
+
+	newPendingSignature := func(signatureID string) string {
+		envelope, err := provider.CreateEnvelope(ctx, &esign.EnvelopeRequest{SignatureID: signatureID, Document: []byte("ccla")})
+		assert.NoError(t, err)
+		repo.records[signatureID] = &signatures.ItemCorporateSignature{
+			SignatureID:         signatureID,
+			SignatureType:       utils.SignatureTypeCCLA,
+			SignatureEnvelopeID: envelope.ID,
+			SignatureSignMethod: signMethodESign(provider),
+		}
+		return envelope.ID
+	}
+
+	newPendingSignature("signature-sent")
+	_, signedNow, err := s.CompleteCorporateSignature(ctx, "signature-sent")
+	assert.Equal(t, ErrSignatureNotPending, err, "the signatory did not sign yet")
+	assert.False(t, signedNow)
+
+	assert.NoError(t, provider.Decline(newPendingSignature("signature-declined")))
+	_, _, err = s.CompleteCorporateSignature(ctx, "signature-declined")
+	assert.Equal(t, ErrSignatureDeclined, err)
+
+	_, _, err = s.CompleteCorporateSignature(ctx, "signature-unknown")
+	assert.Equal(t, ErrSignatureNotFound, err)
+
+	repo.records["signature-signed"] = &signatures.ItemCorporateSignature{SignatureID: "signature-signed", SignatureType: utils.SignatureTypeCCLA, SignatureSigned: true}
+	sig, signedNow, err := s.CompleteCorporateSignature(ctx, "signature-signed")
+	assert.NoError(t, err, "repeated provider callbacks are accepted")
+	assert.False(t, signedNow)
+	assert.Equal(t, "signature-signed", sig.SignatureID)
+
+	_, _, err = (&service{signatureRepo: repo}).CompleteCorporateSignature(ctx, "signature-sent")
+	assert.Equal(t, ErrESignProviderNotEnabled, err)
+}
+
+func TestVerifyCompletionWebhook(t *testing.T) {
+	ctx := context.Background()
+	s := &service{esignProvider: esign.NewFakeProvider(false, "secret")}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	assert.NoError(t, s.VerifyCompletionWebhook(ctx, "signature-1", timestamp, esign.SignWebhook("secret", timestamp, []byte("signature-1"))))
+	assert.Equal(t, esign.ErrInvalidWebhookSignature, s.VerifyCompletionWebhook(ctx, "signature-2", timestamp, esign.SignWebhook("secret", timestamp, []byte("signature-1"))),
+		"the callback of another signature is rejected")
+	assert.Equal(t, esign.ErrInvalidWebhookSignature, s.VerifyCompletionWebhook(ctx, "signature-1", timestamp, ""))
+
+	assert.Equal(t, ErrESignProviderNotEnabled, (&service{}).VerifyCompletionWebhook(ctx, "signature-1", timestamp, "sha256=0"))
+}
+
+func TestReusePendingCorporateSignature(t *testing.T) {
+	ctx := context.Background()
+	provider := esign.NewFakeProvider(false, "secret")
+	s := &service{esignProvider: provider}
+	currentDoc := v1Models.ClaGroupDocument{DocumentMajorVersion: "2", DocumentMinorVersion: "1"}
+	signer := corporateSigner{name: "Derk Miyamoto", email: "derk@example.org"}
+	input := &models.CorporateSignatureInput{SendAsEmail: true}
+
+	newPendingSignature := func(signerEmail string) *signatures.ItemCorporateSignature {
+		envelope, err := provider.CreateEnvelope(ctx, &esign.EnvelopeRequest{Document: []byte("ccla"), SignerEmail: signerEmail, SendAsEmail: true})
+		assert.NoError(t, err)
+		return &signatures.ItemCorporateSignature{
+			SignatureID:                   "signature-1",
+			SignatureType:                 utils.SignatureTypeCCLA,
+			SignatureEnvelopeID:           envelope.ID,
+			SignatureSignMethod:           signMethodESign(provider),
+			SignatureDocumentMajorVersion: "2",
+			SignatureDocumentMinorVersion: "1",
+		}
+	}
+
+	pending := newPendingSignature("Derk@example.org")
+	out, err := s.reusePendingCorporateSignature(ctx, pending, currentDoc, signer, input)
+	assert.NoError(t, err)
+	if assert.NotNil(t, out, "the signing request of the same signatory is reused") {
+		assert.Equal(t, "signature-1", out.SignatureID)
+	}
+
+	pending = newPendingSignature("someone-else@example.org")
+	out, err = s.reusePendingCorporateSignature(ctx, pending, currentDoc, signer, input)
+	assert.NoError(t, err)
+	assert.Nil(t, out, "the signing request is sent again to the new signatory")
+	result, err := provider.GetEnvelope(ctx, pending.SignatureEnvelopeID)
+	assert.NoError(t, err)
+	assert.Equal(t, esign.StatusVoided, result.Status, "the previous signatory can no longer sign")
+
+	pending = newPendingSignature("derk@example.org")
+	out, err = s.reusePendingCorporateSignature(ctx, pending, v1Models.ClaGroupDocument{DocumentMajorVersion: "3", DocumentMinorVersion: "0"}, signer, input)
+	assert.NoError(t, err)
+	assert.Nil(t, out, "the signing request is sent again with the new document")
+
+	pending = newPendingSignature("derk@example.org")
+	pending.SignatureSignMethod = "docusign"
+	out, err = s.reusePendingCorporateSignature(ctx, pending, currentDoc, signer, input)
+	assert.NoError(t, err)
+	assert.Nil(t, out, "the signatures requested through the v1 API are sent again")
+}
+
+func TestBlocksCorporateSignature(t *testing.T) {
+	assert.False(t, blocksCorporateSignature(nil))
+	assert.True(t, blocksCorporateSignature(&v1Models.Signature{SignatureID: "signed", SignatureMajorVersion: "1"}))
+	// a CCLA flagged for a re-signature is re-signed, the new signature replaces it
+	assert.False(t, blocksCorporateSignature(&v1Models.Signature{SignatureID: "flagged", SignatureMajorVersion: "1", ResignMajorVersion: "2"}))
+	assert.True(t, blocksCorporateSignature(&v1Models.Signature{SignatureID: "re-signed", SignatureMajorVersion: "2", ResignMajorVersion: "2"}))
+}
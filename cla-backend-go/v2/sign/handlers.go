@@ -12,6 +12,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/sign"
@@ -21,7 +22,7 @@ import (
 )
 
 // Configure API call
-func Configure(api *operations.EasyclaAPI, service Service) {
+func Configure(api *operations.EasyclaAPI, service Service, eventsService events.Service) {
 	// Retrieve a list of available templates
 	api.SignRequestCorporateSignatureHandler = sign.RequestCorporateSignatureHandlerFunc(
 		func(params sign.RequestCorporateSignatureParams, user *auth.User) middleware.Responder {
@@ -48,7 +49,7 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 				if err == projects_cla_groups.ErrProjectNotAssociatedWithClaGroup {
 					return sign.NewRequestCorporateSignatureBadRequest().WithPayload(errorResponse(reqID, err))
 				}
-				if err == ErrCCLANotEnabled || err == ErrTemplateNotConfigured {
+				if err == ErrCCLANotEnabled || err == ErrTemplateNotConfigured || err == ErrSignerEmailRequired {
 					return sign.NewRequestCorporateSignatureBadRequest().WithPayload(errorResponse(reqID, err))
 				}
 				if _, ok := err.(*organizations.ListOrgUsrAdminScopesNotFound); ok {
@@ -66,6 +67,102 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 			}
 			return sign.NewRequestCorporateSignatureOK().WithPayload(resp)
 		})
+
+	api.SignGetIndividualClickThroughDocumentHandler = sign.GetIndividualClickThroughDocumentHandlerFunc(
+		func(params sign.GetIndividualClickThroughDocumentParams, user *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(user, params.XUSERNAME, params.XEMAIL)
+
+			resp, err := service.GetIndividualClickThroughDocument(ctx, params.ClaGroupID)
+			if err != nil {
+				if _, ok := err.(*utils.CLAGroupNotFound); ok {
+					return sign.NewGetIndividualClickThroughDocumentNotFound().WithPayload(errorResponse(reqID, err))
+				}
+				if err == ErrICLANotEnabled || err == ErrTemplateNotConfigured {
+					return sign.NewGetIndividualClickThroughDocumentBadRequest().WithPayload(errorResponse(reqID, err))
+				}
+				return sign.NewGetIndividualClickThroughDocumentInternalServerError().WithPayload(errorResponse(reqID, err))
+			}
+			return sign.NewGetIndividualClickThroughDocumentOK().WithPayload(resp)
+		})
+
+	api.SignSignIndividualClickThroughHandler = sign.SignIndividualClickThroughHandlerFunc(
+		func(params sign.SignIndividualClickThroughParams, user *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(user, params.XUSERNAME, params.XEMAIL)
+			if user.UserName == "" || user.Email == "" {
+				return sign.NewSignIndividualClickThroughUnauthorized().WithPayload(&models.ErrorResponse{
+					Code:       "401",
+					Message:    "EasyCLA - 401 Unauthorized - the LF username and email of the signer are required",
+					XRequestID: reqID,
+				})
+			}
+
+			resp, err := service.SignIndividualClickThrough(ctx, params.ClaGroupID, user.UserName, user.Email, clientIPAddress(params.HTTPRequest), params.Input)
+			if err != nil {
+				if _, ok := err.(*utils.CLAGroupNotFound); ok {
+					return sign.NewSignIndividualClickThroughNotFound().WithPayload(errorResponse(reqID, err))
+				}
+				if err == ErrDocumentChanged || err == ErrICLAAlreadySigned {
+					return sign.NewSignIndividualClickThroughConflict().WithPayload(errorResponse(reqID, err))
+				}
+				if err == ErrICLANotEnabled || err == ErrTemplateNotConfigured || errors.Is(err, ErrInvalidClickThrough) {
+					return sign.NewSignIndividualClickThroughBadRequest().WithPayload(errorResponse(reqID, err))
+				}
+				return sign.NewSignIndividualClickThroughInternalServerError().WithPayload(errorResponse(reqID, err))
+			}
+
+			// The click-through signature is inserted signed, the signatures table stream only records the
+			// signatures signed by an update
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType:  events.IndividualSignatureSigned,
+				ProjectID:  params.ClaGroupID,
+				LfUsername: user.UserName,
+				EventData: &events.IndividualSignatureSignedEventData{
+					SignatureID:  resp.SignatureID,
+					SignMethod:   SignMethodClickThrough,
+					MajorVersion: resp.DocumentMajorVersion,
+					MinorVersion: resp.DocumentMinorVersion,
+				},
+			})
+
+			return sign.NewSignIndividualClickThroughOK().WithPayload(resp)
+		})
+
+	api.SignCompleteCorporateSignatureHandler = sign.CompleteCorporateSignatureHandlerFunc(
+		func(params sign.CompleteCorporateSignatureParams) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			// the provider callback has no user session, it is signed with the webhook secret of the provider
+			err := service.VerifyCompletionWebhook(ctx, params.SignatureID, utils.StringValue(params.XESIGNTIMESTAMP), utils.StringValue(params.XESIGNSIGNATURE))
+			if err != nil {
+				if err == ErrESignProviderNotEnabled {
+					return sign.NewCompleteCorporateSignatureBadRequest().WithPayload(errorResponse(reqID, err))
+				}
+				return sign.NewCompleteCorporateSignatureUnauthorized().WithPayload(&models.ErrorResponse{
+					Code:       "401",
+					Message:    "EasyCLA - 401 Unauthorized - invalid e-signature provider webhook signature",
+					XRequestID: reqID,
+				})
+			}
+
+			sig, _, err := service.CompleteCorporateSignature(ctx, params.SignatureID)
+			if err != nil {
+				if err == ErrSignatureNotFound {
+					return sign.NewCompleteCorporateSignatureNotFound().WithPayload(errorResponse(reqID, err))
+				}
+				if err == ErrSignatureNotPending || err == ErrSignatureDeclined || err == ErrESignProviderNotEnabled {
+					return sign.NewCompleteCorporateSignatureBadRequest().WithPayload(errorResponse(reqID, err))
+				}
+				return sign.NewCompleteCorporateSignatureInternalServerError().WithPayload(errorResponse(reqID, err))
+			}
+
+			resp := &models.CorporateSignatureOutput{SignatureID: sig.SignatureID}
+			return sign.NewCompleteCorporateSignatureOK().WithPayload(resp)
+		})
 }
 
 type codedResponse interface {
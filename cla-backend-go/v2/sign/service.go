@@ -28,8 +28,11 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/esign"
 	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 )
 
@@ -42,6 +45,7 @@ const (
 var (
 	ErrCCLANotEnabled        = errors.New("corporate license agreement is not enabled with this project")
 	ErrTemplateNotConfigured = errors.New("cla template not configured for this project")
+	ErrSignerEmailRequired   = errors.New("the signatory does not have a primary email address")
 	ErrNotInOrg              error
 )
 
@@ -53,6 +57,10 @@ type ProjectRepo interface {
 // Service interface defines the sign service methods
 type Service interface {
 	RequestCorporateSignature(ctx context.Context, lfUsername string, authorizationHeader string, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error)
+	GetIndividualClickThroughDocument(ctx context.Context, claGroupID string) (*models.ClickThroughDocument, error)
+	SignIndividualClickThrough(ctx context.Context, claGroupID, lfUsername, lfEmail, clientIP string, input *models.ClickThroughSignatureInput) (*models.ClickThroughSignatureOutput, error)
+	VerifyCompletionWebhook(ctx context.Context, signatureID, timestamp, webhookSignature string) error
+	CompleteCorporateSignature(ctx context.Context, signatureID string) (*signatures.ItemCorporateSignature, bool, error)
 }
 
 // service
@@ -62,16 +70,22 @@ type service struct {
 	projectRepo          ProjectRepo
 	projectClaGroupsRepo projects_cla_groups.Repository
 	companyService       company.IService
+	signatureRepo        signatures.SignatureRepository
+	usersService         users.Service
+	esignProvider        esign.Provider
 }
 
 // NewService returns an instance of v2 project service
-func NewService(apiURL string, compRepo company.IRepository, projectRepo ProjectRepo, pcgRepo projects_cla_groups.Repository, compService company.IService) Service {
+func NewService(apiURL string, compRepo company.IRepository, projectRepo ProjectRepo, pcgRepo projects_cla_groups.Repository, compService company.IService, signatureRepo signatures.SignatureRepository, usersService users.Service, esignProvider esign.Provider) Service {
 	return &service{
 		ClaV1ApiURL:          apiURL,
 		companyRepo:          compRepo,
 		projectRepo:          projectRepo,
 		projectClaGroupsRepo: pcgRepo,
 		companyService:       compService,
+		signatureRepo:        signatureRepo,
+		usersService:         usersService,
+		esignProvider:        esignProvider,
 	}
 }
 
@@ -221,8 +235,12 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 		return nil, ErrTemplateNotConfigured
 	}
 
+	// The signatory signs the CCLA, either the authority the request is emailed to or the current user
+	var signer corporateSigner
+
 	// Email flow
 	if input.SendAsEmail {
+		signer = corporateSigner{name: input.AuthorityName, email: input.AuthorityEmail.String()}
 		log.WithFields(f).Debugf("Sending request as an email to: %s...", input.AuthorityEmail.String())
 		// this would be used only in case of cla-signatory
 		err = prepareUserForSigning(ctx, input.AuthorityEmail.String(), utils.StringValue(input.CompanySfid), utils.StringValue(input.ProjectSfid), input.SigningEntityName)
@@ -244,11 +262,16 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 
 		if userModel != nil {
 			for _, email := range userModel.Emails {
-				if email != nil && *email.IsPrimary {
+				if email != nil && email.IsPrimary != nil && *email.IsPrimary && email.EmailAddress != nil {
 					currentUserEmail = *email.EmailAddress
 				}
 			}
 		}
+		if currentUserEmail == "" {
+			log.WithFields(f).Warnf("unable to request corporate signature - no primary email for user: %s", lfUsername)
+			return nil, ErrSignerEmailRequired
+		}
+		signer = corporateSigner{name: userModel.Name, email: currentUserEmail}
 
 		err = prepareUserForSigning(ctx, currentUserEmail, utils.StringValue(input.CompanySfid), utils.StringValue(input.ProjectSfid), input.SigningEntityName)
 		if err != nil {
@@ -259,16 +282,26 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 		}
 	}
 
-	log.WithFields(f).Debug("Forwarding request to v1 API for requestCorporateSignature...")
-	out, err := requestCorporateSignature(authorizationHeader, s.ClaV1ApiURL, &requestCorporateSignatureInput{
-		ProjectID:         proj.ProjectID,
-		CompanyID:         comp.CompanyID,
-		SigningEntityName: input.SigningEntityName,
-		SendAsEmail:       input.SendAsEmail,
-		AuthorityName:     input.AuthorityName,
-		AuthorityEmail:    input.AuthorityEmail.String(),
-		ReturnURL:         input.ReturnURL.String(),
-	})
+	var out *models.CorporateSignatureOutput
+	if s.esignProvider != nil {
+		log.WithFields(f).Debugf("Requesting the corporate signature through the %s e-signature provider...", s.esignProvider.Name())
+		out, err = s.requestESignCorporateSignature(ctx, lfUsername, proj, comp, signer, input)
+	} else {
+		log.WithFields(f).Debug("Forwarding request to v1 API for requestCorporateSignature...")
+		var v1Out *requestCorporateSignatureOutput
+		v1Out, err = requestCorporateSignature(authorizationHeader, s.ClaV1ApiURL, &requestCorporateSignatureInput{
+			ProjectID:         proj.ProjectID,
+			CompanyID:         comp.CompanyID,
+			SigningEntityName: input.SigningEntityName,
+			SendAsEmail:       input.SendAsEmail,
+			AuthorityName:     input.AuthorityName,
+			AuthorityEmail:    input.AuthorityEmail.String(),
+			ReturnURL:         input.ReturnURL.String(),
+		})
+		if err == nil {
+			out = v1Out.toModel()
+		}
+	}
 	if err != nil {
 		if input.AuthorityEmail.String() != "" {
 			// remove role
@@ -287,7 +320,7 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 		log.WithFields(f).WithError(companyACLError).Warnf("Unable to add user with LFID: %s to company ACL, companyID: %s", lfUsername, *input.CompanySfid)
 	}
 
-	return out.toModel(), nil
+	return out, nil
 }
 
 func requestCorporateSignature(authToken string, apiURL string, input *requestCorporateSignatureInput) (*requestCorporateSignatureOutput, error) {
@@ -338,7 +371,7 @@ func requestCorporateSignature(authToken string, apiURL string, input *requestCo
 
 	if strings.Contains(string(responseBody), "Company has already signed CCLA with this project") {
 		log.WithFields(f).Warnf("response contains error: %+v", responseBody)
-		return nil, errors.New("company has already signed CCLA with this project")
+		return nil, ErrCCLAAlreadySigned
 	} else if strings.Contains(string(responseBody), "Contract Group does not support CCLAs.") {
 		log.WithFields(f).Warnf("response contains error: %+v", responseBody)
 		return nil, errors.New("contract Group does not support CCLAs")
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/sirupsen/logrus"
@@ -40,7 +41,7 @@ import (
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.EasyclaAPI, claGroupService project.Service, projectRepo project.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2service Service, projectClaGroupsRepo projects_cla_groups.Repository) { //nolint
+func Configure(api *operations.EasyclaAPI, claGroupService project.Service, projectRepo project.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2service Service, projectClaGroupsRepo projects_cla_groups.Repository, pullRequestAuthorService PullRequestAuthorService) { //nolint
 
 	const problemLoadingCLAGroupByID = "problem loading cla group by ID"
 	const iclaNotSupportedForCLAGroup = "individual contribution is not supported for this project"
@@ -168,6 +169,9 @@ func Configure(api *operations.EasyclaAPI, claGroupService project.Service, proj
 			if err, ok := err.(*signatureService.ForbiddenError); ok {
 				return signatures.NewUpdateApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
 			}
+			if updateErr == signatureService.ErrApprovalListModified {
+				return signatures.NewUpdateApprovalListConflict().WithXRequestID(reqID).WithPayload(utils.ErrorResponseConflictWithError(reqID, msg, updateErr))
+			}
 			return signatures.NewUpdateApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
 		}
 
@@ -185,6 +189,350 @@ func Configure(api *operations.EasyclaAPI, claGroupService project.Service, proj
 		return signatures.NewUpdateApprovalListOK().WithXRequestID(reqID).WithPayload(&v2Sig)
 	})
 
+	api.SignaturesDownloadApprovalListAsCSVHandler = signatures.DownloadApprovalListAsCSVHandlerFunc(func(params signatures.DownloadApprovalListAsCSVParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "SignaturesDownloadApprovalListAsCSVHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"projectSFID":    params.ProjectSFID,
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("User lookup for company by ID: %s failed : %v", params.CompanyID, err)
+			log.WithFields(f).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return signatures.NewDownloadApprovalListAsCSVNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return signatures.NewDownloadApprovalListAsCSVBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+		}
+
+		// Must be in the Project|Organization Scope to see this - signature ACL is double-checked in the service level when the signature is loaded
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, companyModel.CompanyExternalID, utils.DISALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to download Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanyID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewDownloadApprovalListAsCSVForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		claGroupModel, projErr := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if projErr != nil || claGroupModel == nil {
+			msg := fmt.Sprintf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewDownloadApprovalListAsCSVNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+		}
+
+		result, err := v1SignatureService.GetApprovalListCSV(ctx, authUser, claGroupModel, companyModel, params.ClaGroupID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the approval list using CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*signatureService.ForbiddenError); ok {
+				return signatures.NewDownloadApprovalListAsCSVForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+			}
+			return signatures.NewDownloadApprovalListAsCSVBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		log.WithFields(f).Debug("returning CSV response...")
+		return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+			rw.Header().Set("Content-Type", "text/csv")
+			rw.Header().Set(utils.XREQUESTID, reqID)
+			rw.WriteHeader(http.StatusOK)
+			_, err := rw.Write(result)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("error writing csv file")
+			}
+		})
+	})
+
+	api.SignaturesGetApprovalListHistoryHandler = signatures.GetApprovalListHistoryHandlerFunc(func(params signatures.GetApprovalListHistoryParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "SignaturesGetApprovalListHistoryHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"projectSFID":    params.ProjectSFID,
+			"companyID":      params.CompanyID,
+			"at":             utils.StringValue(params.At),
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("User lookup for company by ID: %s failed : %v", params.CompanyID, err)
+			log.WithFields(f).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return signatures.NewGetApprovalListHistoryNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return signatures.NewGetApprovalListHistoryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+		}
+
+		// Must be in the Project|Organization Scope to see this - signature ACL is double-checked in the service level when the signature is loaded
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, companyModel.CompanyExternalID, utils.DISALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to view the Project Company Approval List history with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanyID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewGetApprovalListHistoryForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		at, err := signatureService.ParseApprovalListHistoryTime(utils.StringValue(params.At), time.Now())
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("invalid approval list history time")
+			return signatures.NewGetApprovalListHistoryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, err.Error()))
+		}
+
+		claGroupModel, projErr := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if projErr != nil || claGroupModel == nil {
+			msg := fmt.Sprintf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewGetApprovalListHistoryNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+		}
+
+		history, err := v1SignatureService.GetApprovalListHistory(ctx, authUser, claGroupModel, companyModel, params.ClaGroupID, at)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the approval list history using CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*signatureService.ForbiddenError); ok {
+				return signatures.NewGetApprovalListHistoryForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+			}
+			return signatures.NewGetApprovalListHistoryBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		var response models.ApprovalListHistory
+		err = copier.Copy(&response, history)
+		if err != nil {
+			msg := "problem converting the approval list history"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return signatures.NewGetApprovalListHistoryInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return signatures.NewGetApprovalListHistoryOK().WithXRequestID(reqID).WithPayload(&response)
+	})
+
+	api.SignaturesImportApprovalListCSVHandler = signatures.ImportApprovalListCSVHandlerFunc(func(params signatures.ImportApprovalListCSVParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "SignaturesImportApprovalListCSVHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"projectSFID":    params.ProjectSFID,
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("User lookup for company by ID: %s failed : %v", params.CompanyID, err)
+			log.WithFields(f).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return signatures.NewImportApprovalListCSVNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return signatures.NewImportApprovalListCSVBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+		}
+
+		// Must be in the Project|Organization Scope to see this - signature ACL is double-checked in the service level when the signature is loaded
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, companyModel.CompanyExternalID, utils.DISALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to update Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanyID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewImportApprovalListCSVForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		claGroupModel, projErr := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if projErr != nil || claGroupModel == nil {
+			msg := fmt.Sprintf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewImportApprovalListCSVNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+		}
+
+		updatedSig, changes, err := v1SignatureService.ImportApprovalListCSV(ctx, authUser, claGroupModel, companyModel, params.ClaGroupID, []byte(utils.StringValue(params.Body.Csv)))
+		if err != nil {
+			msg := fmt.Sprintf("unable to import the approval list CSV using CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*signatureService.ForbiddenError); ok {
+				return signatures.NewImportApprovalListCSVForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+			}
+			if err == signatureService.ErrApprovalListModified {
+				return signatures.NewImportApprovalListCSVConflict().WithXRequestID(reqID).WithPayload(utils.ErrorResponseConflictWithError(reqID, msg, err))
+			}
+			return signatures.NewImportApprovalListCSVBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		// Convert the v1 output models to the v2 response models
+		response := models.ApprovalListCsvImportResult{
+			Signature: &models.Signature{},
+			Changes:   &models.ApprovalList{},
+		}
+		err = copier.Copy(response.Signature, updatedSig)
+		if err == nil {
+			err = copier.Copy(response.Changes, changes)
+		}
+		if err != nil {
+			msg := "unable to convert v1 to v2 approval list import result"
+			log.WithFields(f).Warn(msg)
+			return signatures.NewImportApprovalListCSVInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return signatures.NewImportApprovalListCSVOK().WithXRequestID(reqID).WithPayload(&response)
+	})
+
+	api.SignaturesSimulateApprovalListHandler = signatures.SimulateApprovalListHandlerFunc(func(params signatures.SimulateApprovalListParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "SignaturesSimulateApprovalListHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"projectSFID":    params.ProjectSFID,
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("User lookup for company by ID: %s failed : %v", params.CompanyID, err)
+			log.WithFields(f).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return signatures.NewSimulateApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return signatures.NewSimulateApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+		}
+
+		// Must be in the Project|Organization Scope to see this - signature ACL is double-checked in the service level when the signature is loaded
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, companyModel.CompanyExternalID, utils.DISALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to simulate the Project Company Approval List update with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanyID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewSimulateApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		validationError := validateApprovalListSimulationInput(reqID, params)
+		if validationError != nil {
+			log.WithFields(f).Warn("validation error of the approval list")
+			return validationError
+		}
+
+		claGroupModel, projErr := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if projErr != nil || claGroupModel == nil {
+			msg := fmt.Sprintf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewSimulateApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+		}
+
+		v1ApprovalList := v1Models.ApprovalList{}
+		err = copier.Copy(&v1ApprovalList, params.Body)
+		if err != nil {
+			msg := "unable to convert v1 to v2 approval list"
+			log.WithFields(f).Warn(msg)
+			return signatures.NewSimulateApprovalListBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		// The pull request authors are loaded by the service once the CLA Manager access is checked
+		var loadPullRequestAuthors signatureService.PullRequestAuthorsLoader
+		if pullRequestAuthorService != nil {
+			loadPullRequestAuthors = pullRequestAuthorService.GetOpenPullRequestAuthors
+		}
+		simulation, err := v1SignatureService.SimulateApprovalListUpdate(ctx, authUser, claGroupModel, companyModel, params.ClaGroupID, &v1ApprovalList, loadPullRequestAuthors)
+		if err != nil {
+			msg := fmt.Sprintf("unable to simulate the approval list update using CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*signatureService.ForbiddenError); ok {
+				return signatures.NewSimulateApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+			}
+			return signatures.NewSimulateApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		response := models.ApprovalListSimulation{}
+		err = copier.Copy(&response, simulation)
+		if err != nil {
+			msg := "unable to convert v1 to v2 approval list simulation"
+			log.WithFields(f).Warn(msg)
+			return signatures.NewSimulateApprovalListInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return signatures.NewSimulateApprovalListOK().WithXRequestID(reqID).WithPayload(&response)
+	})
+
+	// Revoke the employee acknowledgement of a contributor who left the company
+	api.SignaturesRevokeEmployeeSignatureHandler = signatures.RevokeEmployeeSignatureHandlerFunc(func(params signatures.RevokeEmployeeSignatureParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "SignaturesRevokeEmployeeSignatureHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"projectSFID":    params.ProjectSFID,
+			"companyID":      params.CompanyID,
+			"userID":         params.UserID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("User lookup for company by ID: %s failed : %v", params.CompanyID, err)
+			log.WithFields(f).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return signatures.NewRevokeEmployeeSignatureNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return signatures.NewRevokeEmployeeSignatureBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+		}
+
+		// Must be in the Project|Organization Scope to see this - signature ACL is double-checked in the service level when the signature is loaded
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(authUser, params.ProjectSFID, companyModel.CompanyExternalID, utils.DISALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to revoke the employee acknowledgements with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanyID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewRevokeEmployeeSignatureForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		claGroupModel, projErr := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if projErr != nil || claGroupModel == nil {
+			msg := fmt.Sprintf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewRevokeEmployeeSignatureNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+		}
+
+		revokedSig, err := v1SignatureService.RevokeEmployeeSignature(ctx, authUser, claGroupModel, companyModel, params.UserID,
+			utils.StringValue(params.Body.Reason), params.Body.EffectiveDate, params.Body.RemoveApprovalListEntries)
+		if err != nil {
+			msg := fmt.Sprintf("unable to revoke the employee acknowledgement of user ID: %s using CLA Group ID: %s", params.UserID, params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*signatureService.ForbiddenError); ok {
+				return signatures.NewRevokeEmployeeSignatureForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+			}
+			if _, ok := err.(*signatureService.BadRequestError); ok {
+				return signatures.NewRevokeEmployeeSignatureBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+			if err == signatureService.ErrEmployeeSignatureNotFound {
+				return signatures.NewRevokeEmployeeSignatureNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			if err == signatureService.ErrEmployeeSignatureRevoked || err == signatureService.ErrApprovalListModified {
+				return signatures.NewRevokeEmployeeSignatureConflict().WithXRequestID(reqID).WithPayload(utils.ErrorResponseConflictWithError(reqID, msg, err))
+			}
+			return signatures.NewRevokeEmployeeSignatureInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		// Convert the v1 output model to a v2 response model
+		v2Sig := models.Signature{}
+		err = copier.Copy(&v2Sig, revokedSig)
+		if err != nil {
+			msg := "unable to convert v1 to v2 signature"
+			log.WithFields(f).Warn(msg)
+			return signatures.NewRevokeEmployeeSignatureInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return signatures.NewRevokeEmployeeSignatureOK().WithXRequestID(reqID).WithPayload(&v2Sig)
+	})
+
 	// Retrieve GitHub Approval Entries
 	api.SignaturesGetGitHubOrgWhitelistHandler = signatures.GetGitHubOrgWhitelistHandlerFunc(func(params signatures.GetGitHubOrgWhitelistParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -251,14 +599,7 @@ func Configure(api *operations.EasyclaAPI, claGroupService project.Service, proj
 			return signatures.NewAddGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
 		}
 
-		ghApprovalList, err := v1SignatureService.AddGithubOrganizationToWhitelist(ctx, params.SignatureID, input, githubAccessToken)
-		if err != nil {
-			log.WithFields(f).Warnf("error adding github organization %s using signature_id: %s to the approval list, error: %+v",
-				*params.Body.OrganizationID, params.SignatureID, err)
-			return signatures.NewAddGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
-		}
-
-		// Create an event
+		// Load the signature for the CLA Group and company of the event
 		signatureModel, getSigErr := v1SignatureService.GetSignature(ctx, params.SignatureID)
 		var projectID = ""
 		var companyID = ""
@@ -271,11 +612,20 @@ func Configure(api *operations.EasyclaAPI, claGroupService project.Service, proj
 			companyID = signatureModel.SignatureReferenceID
 		}
 
+		ghApprovalList, err := v1SignatureService.AddGithubOrganizationToWhitelist(ctx, params.SignatureID, input, githubAccessToken, authUser.UserName)
+		if err != nil {
+			log.WithFields(f).Warnf("error adding github organization %s using signature_id: %s to the approval list, error: %+v",
+				*params.Body.OrganizationID, params.SignatureID, err)
+			return signatures.NewAddGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		// Create an event
 		eventsService.LogEvent(&events.LogEventArgs{
-			EventType:  events.ApprovalListGitHubOrganizationAdded,
-			ProjectID:  projectID,
-			CompanyID:  companyID,
-			LfUsername: authUser.UserName,
+			EventType:   events.ApprovalListGitHubOrganizationAdded,
+			ProjectID:   projectID,
+			CompanyID:   companyID,
+			SignatureID: params.SignatureID,
+			LfUsername:  authUser.UserName,
 			EventData: &events.ApprovalListGitHubOrganizationAddedEventData{
 				GitHubOrganizationName: utils.StringValue(params.Body.OrganizationID),
 			},
@@ -318,7 +668,20 @@ func Configure(api *operations.EasyclaAPI, claGroupService project.Service, proj
 			return signatures.NewDeleteGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
 		}
 
-		ghApprovalList, err := v1SignatureService.DeleteGithubOrganizationFromWhitelist(ctx, params.SignatureID, input, githubAccessToken)
+		// Load the signature for the CLA Group and company of the event
+		signatureModel, getSigErr := v1SignatureService.GetSignature(ctx, params.SignatureID)
+		var projectID = ""
+		var companyID = ""
+		if getSigErr != nil || signatureModel == nil {
+			log.WithFields(f).Warnf("error looking up signature using signature_id: %s, error: %+v",
+				params.SignatureID, getSigErr)
+		}
+		if signatureModel != nil {
+			projectID = signatureModel.ProjectID
+			companyID = signatureModel.SignatureReferenceID
+		}
+
+		ghApprovalList, err := v1SignatureService.DeleteGithubOrganizationFromWhitelist(ctx, params.SignatureID, input, githubAccessToken, authUser.UserName)
 		if err != nil {
 			log.WithFields(f).Warnf("error deleting github organization %s using signature_id: %s from the approval list, error: %+v",
 				*params.Body.OrganizationID, params.SignatureID, err)
@@ -326,6 +689,161 @@ func Configure(api *operations.EasyclaAPI, claGroupService project.Service, proj
 		}
 
 		// Create an event
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:   events.ApprovalListGitHubOrganizationDeleted,
+			ProjectID:   projectID,
+			CompanyID:   companyID,
+			SignatureID: params.SignatureID,
+			LfUsername:  authUser.UserName,
+			EventData: &events.ApprovalListGitHubOrganizationDeletedEventData{
+				GitHubOrganizationName: utils.StringValue(params.Body.OrganizationID),
+			},
+		})
+		var response []models.GithubOrg
+		err = copier.Copy(&response, ghApprovalList)
+		if err != nil {
+			return signatures.NewDeleteGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		return signatures.NewDeleteGitHubOrgWhitelistNoContent().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	// Retrieve GitHub Team Approval List Entries
+	api.SignaturesGetGitHubTeamApprovalListHandler = signatures.GetGitHubTeamApprovalListHandlerFunc(func(params signatures.GetGitHubTeamApprovalListParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		f := logrus.Fields{
+			"functionName":   "SignaturesGetGitHubTeamApprovalListHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"signatureID":    params.SignatureID,
+		}
+		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving session from the session store, error: %+v", err)
+			return signatures.NewGetGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		githubAccessToken, ok := session.Values["github_access_token"].(string)
+		if !ok {
+			log.WithFields(f).Debugf("no github access token in the session - initializing to empty string")
+			githubAccessToken = ""
+		}
+
+		ghApprovalList, err := v1SignatureService.GetGithubTeamsFromApprovalList(ctx, params.SignatureID, githubAccessToken)
+		if err != nil {
+			log.WithFields(f).Warnf("error fetching github team approval list entries using signature_id: %s, error: %+v",
+				params.SignatureID, err)
+			return signatures.NewGetGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		var response []models.GithubTeam
+		err = copier.Copy(&response, ghApprovalList)
+		if err != nil {
+			return signatures.NewGetGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		return signatures.NewGetGitHubTeamApprovalListOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	// Add GitHub Team Approval List Entries
+	api.SignaturesAddGitHubTeamApprovalListHandler = signatures.AddGitHubTeamApprovalListHandlerFunc(func(params signatures.AddGitHubTeamApprovalListParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		f := logrus.Fields{
+			"functionName":   "SignaturesAddGitHubTeamApprovalListHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"signatureID":    params.SignatureID,
+		}
+
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving session from the session store, error: %+v", err)
+			return signatures.NewAddGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		githubAccessToken, ok := session.Values["github_access_token"].(string)
+		if !ok {
+			log.WithFields(f).Debugf("no github access token in the session - initializing to empty string")
+			githubAccessToken = ""
+		}
+
+		input := v1Models.GhTeamApprovalList{}
+		err = copier.Copy(&input, &params.Body)
+		if err != nil {
+			return signatures.NewAddGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		// Load the signature for the CLA Group and company of the event
+		signatureModel, getSigErr := v1SignatureService.GetSignature(ctx, params.SignatureID)
+		var projectID = ""
+		var companyID = ""
+		if getSigErr != nil || signatureModel == nil {
+			log.Warnf("error looking up signature using signature_id: %s, error: %+v",
+				params.SignatureID, getSigErr)
+		}
+		if signatureModel != nil {
+			projectID = signatureModel.ProjectID
+			companyID = signatureModel.SignatureReferenceID
+		}
+
+		ghApprovalList, err := v1SignatureService.AddGithubTeamToApprovalList(ctx, params.SignatureID, input, githubAccessToken, authUser.UserName)
+		if err != nil {
+			log.WithFields(f).Warnf("error adding github team %s using signature_id: %s to the approval list, error: %+v",
+				*params.Body.TeamID, params.SignatureID, err)
+			return signatures.NewAddGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		// Create an event
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:   events.ApprovalListGitHubTeamAdded,
+			ProjectID:   projectID,
+			CompanyID:   companyID,
+			SignatureID: params.SignatureID,
+			LfUsername:  authUser.UserName,
+			EventData: &events.ApprovalListGitHubTeamAddedEventData{
+				GitHubTeamName: utils.StringValue(params.Body.TeamID),
+			},
+		})
+
+		var response []models.GithubTeam
+		err = copier.Copy(&response, ghApprovalList)
+		if err != nil {
+			return signatures.NewAddGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		return signatures.NewAddGitHubTeamApprovalListOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	// Delete GitHub Team Approval List Entries
+	api.SignaturesDeleteGitHubTeamApprovalListHandler = signatures.DeleteGitHubTeamApprovalListHandlerFunc(func(params signatures.DeleteGitHubTeamApprovalListParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		f := logrus.Fields{
+			"functionName":   "SignaturesDeleteGitHubTeamApprovalListHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"signatureID":    params.SignatureID,
+		}
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
+		if err != nil {
+			log.WithFields(f).Warnf("error retrieving session from the session store, error: %+v", err)
+			return signatures.NewDeleteGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		githubAccessToken, ok := session.Values["github_access_token"].(string)
+		if !ok {
+			log.WithFields(f).Debugf("no github access token in the session - initializing to empty string")
+			githubAccessToken = ""
+		}
+
+		input := v1Models.GhTeamApprovalList{}
+		err = copier.Copy(&input, &params.Body)
+		if err != nil {
+			return signatures.NewDeleteGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		// Load the signature for the CLA Group and company of the event
 		signatureModel, getSigErr := v1SignatureService.GetSignature(ctx, params.SignatureID)
 		var projectID = ""
 		var companyID = ""
@@ -337,22 +855,32 @@ func Configure(api *operations.EasyclaAPI, claGroupService project.Service, proj
 			projectID = signatureModel.ProjectID
 			companyID = signatureModel.SignatureReferenceID
 		}
+
+		ghApprovalList, err := v1SignatureService.DeleteGithubTeamFromApprovalList(ctx, params.SignatureID, input, githubAccessToken, authUser.UserName)
+		if err != nil {
+			log.WithFields(f).Warnf("error deleting github team %s using signature_id: %s from the approval list, error: %+v",
+				*params.Body.TeamID, params.SignatureID, err)
+			return signatures.NewDeleteGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+		}
+
+		// Create an event
 		eventsService.LogEvent(&events.LogEventArgs{
-			EventType:  events.ApprovalListGitHubOrganizationDeleted,
-			ProjectID:  projectID,
-			CompanyID:  companyID,
-			LfUsername: authUser.UserName,
-			EventData: &events.ApprovalListGitHubOrganizationDeletedEventData{
-				GitHubOrganizationName: utils.StringValue(params.Body.OrganizationID),
+			EventType:   events.ApprovalListGitHubTeamDeleted,
+			ProjectID:   projectID,
+			CompanyID:   companyID,
+			SignatureID: params.SignatureID,
+			LfUsername:  authUser.UserName,
+			EventData: &events.ApprovalListGitHubTeamDeletedEventData{
+				GitHubTeamName: utils.StringValue(params.Body.TeamID),
 			},
 		})
-		var response []models.GithubOrg
+		var response []models.GithubTeam
 		err = copier.Copy(&response, ghApprovalList)
 		if err != nil {
-			return signatures.NewDeleteGitHubOrgWhitelistBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+			return signatures.NewDeleteGitHubTeamApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
 		}
 
-		return signatures.NewDeleteGitHubOrgWhitelistNoContent().WithXRequestID(reqID).WithPayload(response)
+		return signatures.NewDeleteGitHubTeamApprovalListNoContent().WithXRequestID(reqID).WithPayload(response)
 	})
 
 	// Get Project Signatures
@@ -732,7 +1260,7 @@ func Configure(api *operations.EasyclaAPI, claGroupService project.Service, proj
 				rw.Header().Set(utils.XREQUESTID, reqID)
 				rw.WriteHeader(http.StatusOK)
 				// Just the header information - no records
-				_, writeErr := rw.Write([]byte("GitHub ID,LF_ID,Name,Email,Date Signed"))
+				_, writeErr := rw.Write([]byte(eclaSigCsvHeader))
 				if writeErr != nil {
 					log.WithFields(f).WithError(writeErr).Warn("error writing csv file")
 				}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -64,6 +65,11 @@ type Service interface {
 	GetSignedCclaZipPdf(claGroupID string) (*models.URLObject, error)
 }
 
+// PullRequestAuthorService returns the EasyCLA user records of the authors of the open pull requests of the CLA Group
+type PullRequestAuthorService interface {
+	GetOpenPullRequestAuthors(ctx context.Context, claGroupID string) ([]*v1Models.User, error)
+}
+
 // NewService creates instance of v2 signature service
 func NewService(awsSession *session.Session, signaturesBucketName string, v1ProjectService project.Service,
 	v1CompanyService company.IService,
@@ -100,6 +106,9 @@ func (s *service) GetProjectCompanySignatures(ctx context.Context, companyID, co
 	return v2SignaturesReplaceCompanyID(resp, companyID, companySFID)
 }
 
+// eclaSigCsvHeader is the header of the corporate contributors CSV document
+const eclaSigCsvHeader = `GitHub ID,LF_ID,Name,Email,Date Signed,Status,Revocation Effective Date,Revocation Reason`
+
 func eclaSigCsvLine(sig *v1Models.CorporateContributor) string {
 	var dateTime string
 	t, err := utils.ParseDateTime(sig.Timestamp)
@@ -109,7 +118,17 @@ func eclaSigCsvLine(sig *v1Models.CorporateContributor) string {
 	} else {
 		dateTime = t.Format("Jan 2,2006")
 	}
-	return fmt.Sprintf("\n%s,%s,%s,%s,\"%s\"", sig.GithubID, sig.LinuxFoundationID, sig.Name, sig.Email, dateTime)
+
+	// The revoked employee acknowledgements are listed as inactive
+	status, revocationDate := "active", ""
+	if !sig.Active {
+		status = "inactive"
+		if t, err := utils.ParseDateTime(sig.RevocationEffectiveDate); err == nil {
+			revocationDate = t.Format("Jan 2,2006")
+		}
+	}
+	return fmt.Sprintf("\n%s,%s,%s,%s,\"%s\",%s,\"%s\",\"%s\"", sig.GithubID, sig.LinuxFoundationID, sig.Name, sig.Email, dateTime,
+		status, revocationDate, strings.ReplaceAll(sig.RevocationReason, `"`, `""`))
 }
 
 func (s service) GetClaGroupCorporateContributorsCsv(ctx context.Context, claGroupID string, companyID string) ([]byte, error) {
@@ -123,7 +142,7 @@ func (s service) GetClaGroupCorporateContributorsCsv(ctx context.Context, claGro
 		return nil, errors.New("not Found")
 	}
 
-	b.WriteString(`GitHub ID,LF_ID,Name,Email,Date Signed`)
+	b.WriteString(eclaSigCsvHeader)
 	for _, sig := range result.List {
 		b.WriteString(eclaSigCsvLine(sig))
 	}
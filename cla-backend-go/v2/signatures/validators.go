@@ -8,30 +8,52 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/signatures"
+	signatureService "github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime/middleware"
 )
 
 // validateApprovalListInput is a helper function to validate the update approval list input parameters
 func validateApprovalListInput(reqID string, params signatures.UpdateApprovalListParams) middleware.Responder {
-	if !hasApprovalListUpdates(params) {
-		return signatures.NewUpdateApprovalListBadRequest().WithPayload(errorResponse(reqID, errors.New("missing approval list items")))
+	if err := validateApprovalList(params.Body); err != nil {
+		return signatures.NewUpdateApprovalListBadRequest().WithPayload(errorResponse(reqID, err))
 	}
+	return nil
+}
+
+// validateApprovalListSimulationInput is a helper function to validate the simulate approval list input parameters
+func validateApprovalListSimulationInput(reqID string, params signatures.SimulateApprovalListParams) middleware.Responder {
+	if err := validateApprovalList(params.Body); err != nil {
+		return signatures.NewSimulateApprovalListBadRequest().WithXRequestID(reqID).WithPayload(errorResponse(reqID, err))
+	}
+	return nil
+}
 
-	msg, valid := entriesAreValid(params)
+// validateApprovalList returns an error if the approval list update is empty or has an invalid entry
+func validateApprovalList(body *models.ApprovalList) error {
+	if !hasApprovalListUpdates(body) {
+		return errors.New("missing approval list items")
+	}
+
+	msg, valid := entriesAreValid(body)
 	if !valid {
-		return signatures.NewUpdateApprovalListBadRequest().WithPayload(errorResponse(reqID, errors.New(msg)))
+		return errors.New(msg)
 	}
 	return nil
 }
 
 // hasApprovalListUpdates returns true if we have something to update, otherwise returns false
-func hasApprovalListUpdates(params signatures.UpdateApprovalListParams) bool {
-	if len(params.Body.AddEmailApprovalList) > 0 || len(params.Body.RemoveEmailApprovalList) > 0 ||
-		len(params.Body.AddDomainApprovalList) > 0 || len(params.Body.RemoveDomainApprovalList) > 0 ||
-		len(params.Body.AddGithubUsernameApprovalList) > 0 || len(params.Body.RemoveGithubUsernameApprovalList) > 0 ||
-		len(params.Body.AddGithubOrgApprovalList) > 0 || len(params.Body.RemoveGithubOrgApprovalList) > 0 {
+func hasApprovalListUpdates(body *models.ApprovalList) bool {
+	if body == nil {
+		return false
+	}
+	if len(body.AddEmailApprovalList) > 0 || len(body.RemoveEmailApprovalList) > 0 ||
+		len(body.AddDomainApprovalList) > 0 || len(body.RemoveDomainApprovalList) > 0 ||
+		len(body.AddGithubUsernameApprovalList) > 0 || len(body.RemoveGithubUsernameApprovalList) > 0 ||
+		len(body.AddGithubOrgApprovalList) > 0 || len(body.RemoveGithubOrgApprovalList) > 0 ||
+		len(body.AddGithubTeamApprovalList) > 0 || len(body.RemoveGithubTeamApprovalList) > 0 {
 		return true
 	}
 
@@ -39,48 +61,47 @@ func hasApprovalListUpdates(params signatures.UpdateApprovalListParams) bool {
 }
 
 // entriesAreValid returns true if the values in the approval list are valid, returns false and a message otherwise
-func entriesAreValid(params signatures.UpdateApprovalListParams) (string, bool) {
+func entriesAreValid(body *models.ApprovalList) (string, bool) {
 	var listOfErrors []string
 	isValid := true
 	// Ensure the email address are valid
-	for _, email := range params.Body.AddEmailApprovalList {
-		if !utils.ValidEmail(email) {
+	for _, email := range body.AddEmailApprovalList {
+		if err := signatureService.ValidateEmailApprovalEntry(email); err != nil {
 			isValid = false
-			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list email %s", email))
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list email %s - %s", email, err))
 		}
 	}
-	for _, email := range params.Body.RemoveEmailApprovalList {
-		if !utils.ValidEmail(email) {
+	for _, email := range body.RemoveEmailApprovalList {
+		if !utils.ValidEmail(email) && !signatureService.IsApprovalListPattern(email) {
 			isValid = false
 			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid remove approval list email %s", email))
 		}
 	}
 
 	// Ensure the domains are valid
-	for _, domain := range params.Body.AddDomainApprovalList {
-		msg, valid := utils.ValidDomain(domain, true)
-		if !valid {
+	for _, domain := range body.AddDomainApprovalList {
+		if err := signatureService.ValidateDomainApprovalEntry(domain); err != nil {
 			isValid = false
-			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list domain %s - %s", domain, msg))
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list domain %s - %s", domain, err))
 		}
 	}
-	for _, domain := range params.Body.RemoveDomainApprovalList {
+	for _, domain := range body.RemoveDomainApprovalList {
 		msg, valid := utils.ValidDomain(domain, true)
-		if !valid {
+		if !valid && !signatureService.IsApprovalListPattern(domain) {
 			isValid = false
 			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid remove approval list domain %s - %s", domain, msg))
 		}
 	}
 
 	// Ensure the github usernames are valid
-	for _, githubUsername := range params.Body.AddGithubUsernameApprovalList {
+	for _, githubUsername := range body.AddGithubUsernameApprovalList {
 		msg, valid := utils.ValidGitHubUsername(githubUsername)
 		if !valid {
 			isValid = false
 			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list GitHub Username %s - %s", githubUsername, msg))
 		}
 	}
-	for _, githubUsername := range params.Body.RemoveGithubUsernameApprovalList {
+	for _, githubUsername := range body.RemoveGithubUsernameApprovalList {
 		msg, valid := utils.ValidGitHubUsername(githubUsername)
 		if !valid {
 			isValid = false
@@ -89,14 +110,14 @@ func entriesAreValid(params signatures.UpdateApprovalListParams) (string, bool)
 	}
 
 	// Ensure the github Organization values are valid
-	for _, githubOrg := range params.Body.AddGithubOrgApprovalList {
+	for _, githubOrg := range body.AddGithubOrgApprovalList {
 		msg, valid := utils.ValidGitHubOrg(githubOrg)
 		if !valid {
 			isValid = false
 			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list GitHub Org %s - %s", githubOrg, msg))
 		}
 	}
-	for _, githubOrg := range params.Body.RemoveGithubOrgApprovalList {
+	for _, githubOrg := range body.RemoveGithubOrgApprovalList {
 		msg, valid := utils.ValidGitHubOrg(githubOrg)
 		if !valid {
 			isValid = false
@@ -104,5 +125,21 @@ func entriesAreValid(params signatures.UpdateApprovalListParams) (string, bool)
 		}
 	}
 
+	// Ensure the github Team values are valid
+	for _, githubTeam := range body.AddGithubTeamApprovalList {
+		msg, valid := utils.ValidGitHubTeam(githubTeam)
+		if !valid {
+			isValid = false
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list GitHub Team %s - %s", githubTeam, msg))
+		}
+	}
+	for _, githubTeam := range body.RemoveGithubTeamApprovalList {
+		msg, valid := utils.ValidGitHubTeam(githubTeam)
+		if !valid {
+			isValid = false
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid remove approval list GitHub Team %s - %s", githubTeam, msg))
+		}
+	}
+
 	return strings.Join(listOfErrors, ", "), isValid
 }
@@ -5,6 +5,8 @@ package template
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
@@ -17,6 +19,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/template"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	v1Template "github.com/communitybridge/easycla/cla-backend-go/template"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime"
@@ -25,7 +28,7 @@ import (
 )
 
 // Configure API call
-func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsService v1Events.Service) {
+func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsService v1Events.Service, projectClaGroupsRepo projects_cla_groups.Repository) {
 	// Retrieve a list of available templates
 	api.TemplateGetTemplatesHandler = template.GetTemplatesHandlerFunc(func(params template.GetTemplatesParams, user *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -36,7 +39,11 @@ func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsSer
 			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 		}
 
-		templates, err := service.GetTemplates(ctx)
+		var claGroupID string
+		if params.ClaGroupID != nil {
+			claGroupID = *params.ClaGroupID
+		}
+		templates, err := service.GetTemplates(ctx, claGroupID)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warn("problem loading templates")
 			return template.NewGetTemplatesBadRequest().WithPayload(errorResponse(reqID, err))
@@ -89,6 +96,64 @@ func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsSer
 		return template.NewCreateCLAGroupTemplateOK().WithPayload(response)
 	})
 
+	api.TemplateUploadCLAGroupTemplateHandler = template.UploadCLAGroupTemplateHandlerFunc(func(params template.UploadCLAGroupTemplateParams, user *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(user, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "TemplateUploadCLAGroupTemplateHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"templateID":     params.Body.TemplateID,
+		}
+
+		// Must be in the scope of the foundation or of one of the projects of the CLA Group
+		if !isUserHaveAccessToCLAGroup(ctx, user, params.ClaGroupID, projectClaGroupsRepo) {
+			msg := fmt.Sprintf("user %s does not have access to upload the template of the CLA Group: %s", user.UserName, params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return template.NewUploadCLAGroupTemplateForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		input := &v1Models.UploadClaGroupTemplate{}
+		err := copier.Copy(input, &params.Body)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem converting template")
+			return template.NewUploadCLAGroupTemplateInternalServerError().WithPayload(errorResponse(reqID, err))
+		}
+		uploadedTemplate, err := service.UploadCLAGroupTemplate(ctx, params.ClaGroupID, input)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem uploading template")
+			if errors.Is(err, v1Template.ErrInvalidTemplate) {
+				return template.NewUploadCLAGroupTemplateBadRequest().WithPayload(errorResponse(reqID, err))
+			}
+			if err == v1Template.ErrTemplateNotFound || err == v1Template.ErrCLAGroupNotFound {
+				return template.NewUploadCLAGroupTemplateNotFound().WithPayload(errorResponse(reqID, err))
+			}
+			return template.NewUploadCLAGroupTemplateInternalServerError().WithPayload(errorResponse(reqID, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:  events.CLATemplateUploaded,
+			ProjectID:  params.ClaGroupID,
+			LfUsername: user.UserName,
+			EventData: &events.CLATemplateUploadedEventData{
+				TemplateID:   uploadedTemplate.ID,
+				TemplateName: uploadedTemplate.Name,
+				MajorVersion: uploadedTemplate.TemplateMajorVersion,
+				MinorVersion: uploadedTemplate.TemplateMinorVersion,
+			},
+		})
+
+		response := &models.Template{}
+		err = copier.Copy(response, &uploadedTemplate)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem converting template")
+			return template.NewUploadCLAGroupTemplateInternalServerError().WithPayload(errorResponse(reqID, err))
+		}
+
+		return template.NewUploadCLAGroupTemplateOK().WithPayload(response)
+	})
+
 	api.TemplateTemplatePreviewHandler = template.TemplatePreviewHandlerFunc(func(params template.TemplatePreviewParams, user *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
@@ -108,6 +173,9 @@ func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsSer
 		pdf, err := service.CreateTemplatePreview(ctx, &param, params.TemplateFor)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("Error generating PDFs from provided templates, error: %v", err)
+			if err == v1Template.ErrTemplateNotFound {
+				return writeResponse(http.StatusNotFound, runtime.JSONMime, runtime.JSONProducer(), reqID, errorResponse(reqID, err))
+			}
 			return writeResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), reqID, errorResponse(reqID, err))
 		}
 		return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
@@ -146,6 +214,42 @@ type codedResponse interface {
 	Code() string
 }
 
+// isUserHaveAccessToCLAGroup is a helper function to determine if the user has access to the foundation or to one of
+// the projects of the CLA Group
+func isUserHaveAccessToCLAGroup(ctx context.Context, authUser *auth.User, claGroupID string, projectClaGroupsRepo projects_cla_groups.Repository) bool {
+	f := logrus.Fields{
+		"functionName":   "template.handlers.isUserHaveAccessToCLAGroup",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"userName":       authUser.UserName,
+		"userEmail":      authUser.Email,
+	}
+
+	projectCLAGroups, err := projectClaGroupsRepo.GetProjectsIdsForClaGroup(claGroupID)
+	if err != nil || len(projectCLAGroups) == 0 {
+		log.WithFields(f).WithError(err).Warn("problem loading the projects of the CLA Group - returning false")
+		return false
+	}
+
+	foundationSFID := projectCLAGroups[0].FoundationSFID
+	if foundationSFID != "" && utils.IsUserAuthorizedForProjectTree(ctx, authUser, foundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+		log.WithFields(f).Debugf("user has access to the foundation tree: %s", foundationSFID)
+		return true
+	}
+
+	var projectSFIDs []string
+	for _, projectCLAGroup := range projectCLAGroups {
+		projectSFIDs = append(projectSFIDs, projectCLAGroup.ProjectSFID)
+	}
+	if utils.IsUserAuthorizedForAnyProjects(ctx, authUser, projectSFIDs, utils.ALLOW_ADMIN_SCOPE) {
+		log.WithFields(f).Debug("user has access to at least one of the projects of the CLA Group")
+		return true
+	}
+
+	log.WithFields(f).Debug("user does not have access to the foundation or the projects of the CLA Group")
+	return false
+}
+
 func errorResponse(reqID string, err error) *models.ErrorResponse {
 	code := ""
 	if e, ok := err.(codedResponse); ok {
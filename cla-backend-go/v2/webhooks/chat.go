@@ -0,0 +1,323 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
+)
+
+// chat providers
+const (
+	ProviderSlack   = "slack"
+	ProviderMSTeams = "msteams"
+)
+
+// notifications posted to the chat channels
+const (
+	NotificationApprovalRequest     = "approval_request"
+	NotificationCLAManagerRequest   = "cla_manager_request"
+	NotificationCLADesigneeAssigned = "cla_designee_assigned"
+	NotificationCCLASigned          = "ccla_signed"
+)
+
+// teamsThemeColor is the accent color of the Microsoft Teams message cards
+const teamsThemeColor = "0076D7"
+
+// errors
+var (
+	ErrChatChannelDoesNotExist = errors.New("chat channel does not exist")
+	ErrInvalidProvider         = errors.New("invalid chat provider")
+	ErrInvalidChatWebhookURL   = errors.New("invalid chat incoming webhook URL")
+	ErrInvalidNotification     = errors.New("invalid chat notification")
+	ErrPostFailed              = errors.New("chat message post failed")
+)
+
+// notificationEventTypes maps the event types posted to the chat channels to their notification, the v1 and the v2
+// CLA Manager requests are both posted as CLA Manager requests and the CCLA signed events are logged by the
+// signatures table stream whatever signed the CCLA
+var notificationEventTypes = map[string]string{
+	events.CCLAApprovalListRequestCreated:    NotificationApprovalRequest,
+	events.ClaManagerAccessRequestCreated:    NotificationCLAManagerRequest,
+	events.ContributorNotifyCompanyAdminType: NotificationCLAManagerRequest,
+	events.ContributorNotifyCLADesigneeType:  NotificationCLAManagerRequest,
+	events.ContributorAssignCLADesigneeType:  NotificationCLADesigneeAssigned,
+	events.CorporateSignatureSigned:          NotificationCCLASigned,
+}
+
+// providerHosts are the incoming webhook hosts of the providers, the hosts starting with a dot match their subdomains
+var providerHosts = map[string][]string{
+	ProviderSlack:   {"hooks.slack.com"},
+	ProviderMSTeams: {"outlook.office.com", ".webhook.office.com", ".logic.azure.com"},
+}
+
+// toChatChannelModel converts the chat channel to the response model, the incoming webhook URL is masked
+func (in *Webhook) toChatChannelModel() *models.ChatChannel {
+	return &models.ChatChannel{
+		ChannelID:     in.WebhookID,
+		OwnerType:     in.OwnerType,
+		OwnerID:       in.OwnerID,
+		Provider:      in.Provider,
+		WebhookURL:    maskWebhookURL(in.URL),
+		Notifications: in.Notifications,
+		Enabled:       in.Enabled,
+		DateCreated:   in.DateCreated,
+		DateModified:  in.DateModified,
+		Version:       in.Version,
+	}
+}
+
+// wantsNotification returns true if the chat channel receives the notification, the channels without a
+// notification list receive all the notifications
+func (in *Webhook) wantsNotification(notification string) bool {
+	if len(in.Notifications) == 0 {
+		return true
+	}
+	for _, n := range in.Notifications {
+		if n == notification {
+			return true
+		}
+	}
+	return false
+}
+
+// maskWebhookURL returns the scheme, the host and the last characters of the incoming webhook URL, anyone knowing
+// the full URL can post to the channel
+func maskWebhookURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	suffix := ""
+	if len(u.Path) > 4 {
+		suffix = u.Path[len(u.Path)-4:]
+	}
+	return fmt.Sprintf("%s://%s/...%s", u.Scheme, u.Host, suffix)
+}
+
+// validateChatChannelInput checks the provider, the incoming webhook URL and the notifications of the chat channel,
+// returns the provider and the trimmed URL
+func validateChatChannelInput(input *models.ChatChannelInput) (string, string, error) {
+	provider := strings.TrimSpace(utils.StringValue(input.Provider))
+	if _, ok := providerHosts[provider]; !ok {
+		return "", "", fmt.Errorf("%w: the provider must be one of: %s, %s", ErrInvalidProvider, ProviderSlack, ProviderMSTeams)
+	}
+
+	webhookURL := strings.TrimSpace(utils.StringValue(input.WebhookURL))
+	if err := validateChatWebhookURL(webhookURL, provider); err != nil {
+		return "", "", err
+	}
+
+	for _, notification := range input.Notifications {
+		if !isNotification(notification) {
+			return "", "", fmt.Errorf("%w: %s", ErrInvalidNotification, notification)
+		}
+	}
+	return provider, webhookURL, nil
+}
+
+// validateChatWebhookURL checks the URL is an https URL of one of the incoming webhook hosts of the provider
+func validateChatWebhookURL(webhookURL, provider string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("%w: the URL must be an https %s incoming webhook URL", ErrInvalidChatWebhookURL, provider)
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range providerHosts[provider] {
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not a %s incoming webhook host", ErrInvalidChatWebhookURL, host, provider)
+}
+
+// isNotification returns true if the notification is posted to the chat channels
+func isNotification(notification string) bool {
+	for _, known := range notificationEventTypes {
+		if notification == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Fact is a labelled value of a chat message
+type Fact struct {
+	Name  string
+	Value string
+}
+
+// Message is the content of a chat notification, rendered as a Slack or a Microsoft Teams message
+type Message struct {
+	Title      string
+	Text       string
+	Facts      []Fact
+	ActionText string
+	ActionURL  string
+}
+
+// newEventMessage returns the chat message of the notification of the event, the action links to the corporate
+// console page of the CLA
+func newEventMessage(notification string, event *v1Models.Event, corporateConsole string) *Message {
+	message := &Message{
+		Text:      event.EventSummary,
+		ActionURL: cla_manager.GetCorporateConsoleCLAURL(corporateConsole, event.EventFoundationSFID, event.EventProjectSFID),
+	}
+
+	userLabel := "Requested by"
+	switch notification {
+	case NotificationApprovalRequest:
+		message.Title = "New CCLA approval request"
+		message.ActionText = "Review the request"
+	case NotificationCLAManagerRequest:
+		message.Title = "New CLA Manager request"
+		message.ActionText = "Review the request"
+	case NotificationCLADesigneeAssigned:
+		message.Title = "CLA Manager designee assigned"
+		message.ActionText = "View the CLA"
+		userLabel = "Assigned by"
+	case NotificationCCLASigned:
+		message.Title = "Corporate CLA signed"
+		message.ActionText = "View the CLA"
+		// the summary names the signatory, the signed events are logged by the stream without a user
+		userLabel = ""
+	}
+
+	message.Facts = appendFact(message.Facts, "CLA Group", event.EventProjectName)
+	message.Facts = appendFact(message.Facts, "Company", event.EventCompanyName)
+	if userLabel != "" {
+		message.Facts = appendFact(message.Facts, userLabel, event.LfUsername)
+	}
+	return message
+}
+
+// newTestMessage returns the message posted to check the incoming webhook of the channel
+func newTestMessage(channel *Webhook, corporateConsole string) *Message {
+	return &Message{
+		Title: "EasyCLA test message",
+		Text: fmt.Sprintf("This channel receives the EasyCLA notifications of the %s: %s.",
+			strings.Replace(channel.OwnerType, "_", " ", -1), channel.OwnerID),
+		ActionText: "Open the Corporate Console",
+		ActionURL:  corporateConsole,
+	}
+}
+
+// appendFact appends the fact unless the value is empty
+func appendFact(facts []Fact, name, value string) []Fact {
+	if value == "" {
+		return facts
+	}
+	return append(facts, Fact{Name: name, Value: value})
+}
+
+// body returns the JSON body posted to the incoming webhook of the provider
+func (m *Message) body(provider string) ([]byte, error) {
+	switch provider {
+	case ProviderSlack:
+		return marshalMessage(m.slackPayload())
+	case ProviderMSTeams:
+		return marshalMessage(m.teamsPayload())
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProvider, provider)
+	}
+}
+
+// marshalMessage encodes the chat message without escaping the HTML characters, the text is escaped for the provider
+func marshalMessage(payload map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(payload); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// slackPayload returns the Slack Block Kit message, the text is the fallback of the notifications
+func (m *Message) slackPayload() map[string]interface{} {
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": m.Title},
+		},
+	}
+
+	section := map[string]interface{}{
+		"type": "section",
+		"text": map[string]interface{}{"type": "mrkdwn", "text": escapeSlack(m.Text)},
+	}
+	if len(m.Facts) > 0 {
+		fields := make([]map[string]interface{}, 0, len(m.Facts))
+		for _, fact := range m.Facts {
+			fields = append(fields, map[string]interface{}{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*%s*\n%s", escapeSlack(fact.Name), escapeSlack(fact.Value)),
+			})
+		}
+		section["fields"] = fields
+	}
+	blocks = append(blocks, section)
+
+	if m.ActionURL != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []map[string]interface{}{
+				{
+					"type": "button",
+					"text": map[string]interface{}{"type": "plain_text", "text": m.ActionText},
+					"url":  m.ActionURL,
+				},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"text":   escapeSlack(fmt.Sprintf("%s: %s", m.Title, m.Text)),
+		"blocks": blocks,
+	}
+}
+
+// teamsPayload returns the Microsoft Teams message card
+func (m *Message) teamsPayload() map[string]interface{} {
+	facts := make([]map[string]interface{}, 0, len(m.Facts))
+	for _, fact := range m.Facts {
+		facts = append(facts, map[string]interface{}{"name": fact.Name, "value": fact.Value})
+	}
+
+	payload := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    m.Title,
+		"themeColor": teamsThemeColor,
+		"title":      m.Title,
+		"sections": []map[string]interface{}{
+			{"text": m.Text, "facts": facts},
+		},
+	}
+	if m.ActionURL != "" {
+		payload["potentialAction"] = []map[string]interface{}{
+			{
+				"@type":   "OpenUri",
+				"name":    m.ActionText,
+				"targets": []map[string]interface{}{{"os": "default", "uri": m.ActionURL}},
+			},
+		}
+	}
+	return payload
+}
+
+// escapeSlack escapes the control characters of the Slack mrkdwn text
+func escapeSlack(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,394 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	chatChannelOps "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/chat_channels"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// ConfigureChatChannels setups the chat channels handlers on api with service
+func ConfigureChatChannels(api *operations.EasyclaAPI, service Service, projectService project.Service, companyService company.IService, eventsService events.Service) { // nolint
+	api.ChatChannelsCreateClaGroupChatChannelHandler = chatChannelOps.CreateClaGroupChatChannelHandlerFunc(func(params chatChannelOps.CreateClaGroupChatChannelParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.chat_handlers.ChatChannelsCreateClaGroupChatChannelHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"claGroupID":     params.ClaGroupID,
+		}
+
+		claGroupModel, err := projectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to lookup CLA Group by ID: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isCLAGroupNotFound(err) {
+				return chatChannelOps.NewCreateClaGroupChatChannelNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return chatChannelOps.NewCreateClaGroupChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		if !isUserAuthorizedForCLAGroup(ctx, authUser, claGroupModel) {
+			msg := fmt.Sprintf("user %s does not have access to create the chat channels of the CLA Group with Project scope of %s",
+				authUser.UserName, claGroupModel.FoundationSFID)
+			log.WithFields(f).Debug(msg)
+			return chatChannelOps.NewCreateClaGroupChatChannelForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		channel, err := service.CreateChatChannel(ctx, OwnerTypeCLAGroup, params.ClaGroupID, params.Body)
+		if err != nil {
+			if isChatValidationError(err) {
+				return chatChannelOps.NewCreateClaGroupChatChannelBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, err.Error(), err))
+			}
+			msg := fmt.Sprintf("unable to create the chat channel of CLA Group: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return chatChannelOps.NewCreateClaGroupChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:     events.ChatChannelCreated,
+			ClaGroupModel: claGroupModel,
+			LfUsername:    authUser.UserName,
+			EventData:     chatChannelCreatedEventData(channel),
+		})
+
+		return chatChannelOps.NewCreateClaGroupChatChannelOK().WithXRequestID(reqID).WithPayload(channel)
+	})
+
+	api.ChatChannelsListClaGroupChatChannelsHandler = chatChannelOps.ListClaGroupChatChannelsHandlerFunc(func(params chatChannelOps.ListClaGroupChatChannelsParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.chat_handlers.ChatChannelsListClaGroupChatChannelsHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"claGroupID":     params.ClaGroupID,
+		}
+
+		claGroupModel, err := projectService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to lookup CLA Group by ID: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if isCLAGroupNotFound(err) {
+				return chatChannelOps.NewListClaGroupChatChannelsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return chatChannelOps.NewListClaGroupChatChannelsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		if !isUserAuthorizedForCLAGroup(ctx, authUser, claGroupModel) {
+			msg := fmt.Sprintf("user %s does not have access to list the chat channels of the CLA Group with Project scope of %s",
+				authUser.UserName, claGroupModel.FoundationSFID)
+			log.WithFields(f).Debug(msg)
+			return chatChannelOps.NewListClaGroupChatChannelsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.GetChatChannels(ctx, OwnerTypeCLAGroup, params.ClaGroupID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the chat channels of CLA Group: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return chatChannelOps.NewListClaGroupChatChannelsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return chatChannelOps.NewListClaGroupChatChannelsOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.ChatChannelsCreateCompanyChatChannelHandler = chatChannelOps.CreateCompanyChatChannelHandlerFunc(func(params chatChannelOps.CreateCompanyChatChannelParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.chat_handlers.ChatChannelsCreateCompanyChatChannelHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to lookup company by ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return chatChannelOps.NewCreateCompanyChatChannelNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return chatChannelOps.NewCreateCompanyChatChannelBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		if !utils.IsUserAuthorizedForOrganization(authUser, companyModel.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to create the chat channels with Organization scope of %s",
+				authUser.UserName, companyModel.CompanyExternalID)
+			log.WithFields(f).Debug(msg)
+			return chatChannelOps.NewCreateCompanyChatChannelForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		channel, err := service.CreateChatChannel(ctx, OwnerTypeCompany, params.CompanyID, params.Body)
+		if err != nil {
+			if isChatValidationError(err) {
+				return chatChannelOps.NewCreateCompanyChatChannelBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, err.Error(), err))
+			}
+			msg := fmt.Sprintf("unable to create the chat channel of company: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return chatChannelOps.NewCreateCompanyChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.ChatChannelCreated,
+			CompanyID:    companyModel.CompanyID,
+			CompanyModel: companyModel,
+			LfUsername:   authUser.UserName,
+			EventData:    chatChannelCreatedEventData(channel),
+		})
+
+		return chatChannelOps.NewCreateCompanyChatChannelOK().WithXRequestID(reqID).WithPayload(channel)
+	})
+
+	api.ChatChannelsListCompanyChatChannelsHandler = chatChannelOps.ListCompanyChatChannelsHandlerFunc(func(params chatChannelOps.ListCompanyChatChannelsParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.chat_handlers.ChatChannelsListCompanyChatChannelsHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to lookup company by ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return chatChannelOps.NewListCompanyChatChannelsNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return chatChannelOps.NewListCompanyChatChannelsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		if !utils.IsUserAuthorizedForOrganization(authUser, companyModel.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to list the chat channels with Organization scope of %s",
+				authUser.UserName, companyModel.CompanyExternalID)
+			log.WithFields(f).Debug(msg)
+			return chatChannelOps.NewListCompanyChatChannelsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.GetChatChannels(ctx, OwnerTypeCompany, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the chat channels of company: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return chatChannelOps.NewListCompanyChatChannelsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return chatChannelOps.NewListCompanyChatChannelsOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.ChatChannelsUpdateChatChannelHandler = chatChannelOps.UpdateChatChannelHandlerFunc(func(params chatChannelOps.UpdateChatChannelParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.chat_handlers.ChatChannelsUpdateChatChannelHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"channelID":      params.ChannelID,
+		}
+
+		existing, err := service.GetChatChannel(ctx, params.ChannelID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the chat channel: %s", params.ChannelID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrChatChannelDoesNotExist) {
+				return chatChannelOps.NewUpdateChatChannelNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return chatChannelOps.NewUpdateChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		logArgs, authorized := authorizeChannel(ctx, authUser, projectService, companyService, existing)
+		if !authorized {
+			msg := fmt.Sprintf("user %s does not have access to update the chat channel: %s", authUser.UserName, params.ChannelID)
+			log.WithFields(f).Debug(msg)
+			return chatChannelOps.NewUpdateChatChannelForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		channel, err := service.UpdateChatChannel(ctx, params.ChannelID, params.Body)
+		if err != nil {
+			if isChatValidationError(err) {
+				return chatChannelOps.NewUpdateChatChannelBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, err.Error(), err))
+			}
+			msg := fmt.Sprintf("unable to update the chat channel: %s", params.ChannelID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return chatChannelOps.NewUpdateChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		logArgs.EventType = events.ChatChannelUpdated
+		logArgs.LfUsername = authUser.UserName
+		logArgs.EventData = &events.ChatChannelUpdatedEventData{
+			ChannelID: channel.ChannelID,
+			OwnerType: channel.OwnerType,
+			OwnerID:   channel.OwnerID,
+			Provider:  channel.Provider,
+			Enabled:   channel.Enabled,
+		}
+		eventsService.LogEvent(logArgs)
+
+		return chatChannelOps.NewUpdateChatChannelOK().WithXRequestID(reqID).WithPayload(channel)
+	})
+
+	api.ChatChannelsDeleteChatChannelHandler = chatChannelOps.DeleteChatChannelHandlerFunc(func(params chatChannelOps.DeleteChatChannelParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.chat_handlers.ChatChannelsDeleteChatChannelHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"channelID":      params.ChannelID,
+		}
+
+		existing, err := service.GetChatChannel(ctx, params.ChannelID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the chat channel: %s", params.ChannelID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrChatChannelDoesNotExist) {
+				return chatChannelOps.NewDeleteChatChannelNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return chatChannelOps.NewDeleteChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		logArgs, authorized := authorizeChannel(ctx, authUser, projectService, companyService, existing)
+		if !authorized {
+			msg := fmt.Sprintf("user %s does not have access to delete the chat channel: %s", authUser.UserName, params.ChannelID)
+			log.WithFields(f).Debug(msg)
+			return chatChannelOps.NewDeleteChatChannelForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		if err = service.DeleteChatChannel(ctx, params.ChannelID); err != nil {
+			msg := fmt.Sprintf("unable to delete the chat channel: %s", params.ChannelID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return chatChannelOps.NewDeleteChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		logArgs.EventType = events.ChatChannelDeleted
+		logArgs.LfUsername = authUser.UserName
+		logArgs.EventData = &events.ChatChannelDeletedEventData{
+			ChannelID: existing.ChannelID,
+			OwnerType: existing.OwnerType,
+			OwnerID:   existing.OwnerID,
+			Provider:  existing.Provider,
+		}
+		eventsService.LogEvent(logArgs)
+
+		return chatChannelOps.NewDeleteChatChannelNoContent().WithXRequestID(reqID)
+	})
+
+	api.ChatChannelsTestChatChannelHandler = chatChannelOps.TestChatChannelHandlerFunc(func(params chatChannelOps.TestChatChannelParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.chat_handlers.ChatChannelsTestChatChannelHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"channelID":      params.ChannelID,
+		}
+
+		existing, err := service.GetChatChannel(ctx, params.ChannelID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the chat channel: %s", params.ChannelID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrChatChannelDoesNotExist) {
+				return chatChannelOps.NewTestChatChannelNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return chatChannelOps.NewTestChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		if _, authorized := authorizeChannel(ctx, authUser, projectService, companyService, existing); !authorized {
+			msg := fmt.Sprintf("user %s does not have access to test the chat channel: %s", authUser.UserName, params.ChannelID)
+			log.WithFields(f).Debug(msg)
+			return chatChannelOps.NewTestChatChannelForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		if err = service.TestChatChannel(ctx, params.ChannelID); err != nil {
+			if errors.Is(err, ErrPostFailed) {
+				return chatChannelOps.NewTestChatChannelBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, err.Error(), err))
+			}
+			msg := fmt.Sprintf("unable to post the test message to the chat channel: %s", params.ChannelID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return chatChannelOps.NewTestChatChannelInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return chatChannelOps.NewTestChatChannelNoContent().WithXRequestID(reqID)
+	})
+}
+
+// authorizeChannel checks the user manages the CLA Group or the company owning the chat channel, returns the event
+// arguments scoping the events of the chat channel to its owner
+func authorizeChannel(ctx context.Context, authUser *auth.User, projectService project.Service, companyService company.IService, channel *models.ChatChannel) (*events.LogEventArgs, bool) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.chat_handlers.authorizeChannel",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"channelID":      channel.ChannelID,
+		"ownerType":      channel.OwnerType,
+		"ownerID":        channel.OwnerID,
+	}
+
+	if channel.OwnerType == OwnerTypeCLAGroup {
+		claGroupModel, err := projectService.GetCLAGroupByID(ctx, channel.OwnerID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to lookup the CLA Group of the chat channel")
+			return nil, false
+		}
+		return &events.LogEventArgs{ClaGroupModel: claGroupModel}, isUserAuthorizedForCLAGroup(ctx, authUser, claGroupModel)
+	}
+
+	companyModel, err := companyService.GetCompany(ctx, channel.OwnerID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup the company of the chat channel")
+		return nil, false
+	}
+	authorized := utils.IsUserAuthorizedForOrganization(authUser, companyModel.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE)
+	return &events.LogEventArgs{CompanyID: companyModel.CompanyID, CompanyModel: companyModel}, authorized
+}
+
+// isUserAuthorizedForCLAGroup returns true if the user manages the foundation or the project of the CLA Group
+func isUserAuthorizedForCLAGroup(ctx context.Context, authUser *auth.User, claGroupModel *v1Models.ClaGroup) bool {
+	return utils.IsUserAuthorizedForProjectTree(ctx, authUser, claGroupModel.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) ||
+		utils.IsUserAuthorizedForProject(ctx, authUser, claGroupModel.ProjectExternalID, utils.ALLOW_ADMIN_SCOPE)
+}
+
+// isCLAGroupNotFound returns true if the CLA Group lookup failed because the CLA Group does not exist
+func isCLAGroupNotFound(err error) bool {
+	if _, ok := err.(*utils.CLAGroupNotFound); ok {
+		return true
+	}
+	return err == project.ErrProjectDoesNotExist
+}
+
+// isChatValidationError returns true if the chat channel input was rejected
+func isChatValidationError(err error) bool {
+	return errors.Is(err, ErrInvalidProvider) || errors.Is(err, ErrInvalidChatWebhookURL) || errors.Is(err, ErrInvalidNotification)
+}
+
+func chatChannelCreatedEventData(channel *models.ChatChannel) *events.ChatChannelCreatedEventData {
+	return &events.ChatChannelCreatedEventData{
+		ChannelID: channel.ChannelID,
+		OwnerType: channel.OwnerType,
+		OwnerID:   channel.OwnerID,
+		Provider:  channel.Provider,
+	}
+}
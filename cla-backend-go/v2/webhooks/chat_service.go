@@ -0,0 +1,135 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+)
+
+// CreateChatChannel registers the chat channel of the CLA Group or of the company
+func (s *service) CreateChatChannel(ctx context.Context, ownerType, ownerID string, input *models.ChatChannelInput) (*models.ChatChannel, error) {
+	provider, webhookURL, err := validateChatChannelInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	channelID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	channel := &Webhook{
+		WebhookID:     channelID.String(),
+		OwnerType:     ownerType,
+		OwnerID:       ownerID,
+		URL:           webhookURL,
+		Provider:      provider,
+		Notifications: input.Notifications,
+		Enabled:       input.Enabled == nil || *input.Enabled,
+		DateCreated:   currentTime,
+		DateModified:  currentTime,
+		Version:       "v1",
+	}
+	if err = s.repo.PutWebhook(ctx, channel); err != nil {
+		return nil, err
+	}
+	return channel.toChatChannelModel(), nil
+}
+
+// GetChatChannels returns the chat channels of the CLA Group or of the company
+func (s *service) GetChatChannels(ctx context.Context, ownerType, ownerID string) (*models.ChatChannels, error) {
+	webhooks, err := s.repo.GetWebhooksByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.ChatChannels{List: make([]*models.ChatChannel, 0, len(webhooks))}
+	for _, webhook := range webhooks {
+		if webhook.OwnerType == ownerType && webhook.isChatChannel() {
+			out.List = append(out.List, webhook.toChatChannelModel())
+		}
+	}
+	return out, nil
+}
+
+// GetChatChannel returns the chat channel by its ID
+func (s *service) GetChatChannel(ctx context.Context, channelID string) (*models.ChatChannel, error) {
+	channel, err := s.getChatChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	return channel.toChatChannelModel(), nil
+}
+
+// UpdateChatChannel updates the provider, the incoming webhook URL, the notifications and, when set, the enabled
+// flag of the chat channel
+func (s *service) UpdateChatChannel(ctx context.Context, channelID string, input *models.ChatChannelInput) (*models.ChatChannel, error) {
+	provider, webhookURL, err := validateChatChannelInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := s.getChatChannel(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	channel.Provider = provider
+	channel.URL = webhookURL
+	channel.Notifications = input.Notifications
+	if input.Enabled != nil {
+		channel.Enabled = *input.Enabled
+	}
+	_, channel.DateModified = utils.CurrentTime()
+	if err = s.repo.PutWebhook(ctx, channel); err != nil {
+		return nil, err
+	}
+	return channel.toChatChannelModel(), nil
+}
+
+// DeleteChatChannel deletes the chat channel, the pending retries fail on their next attempt
+func (s *service) DeleteChatChannel(ctx context.Context, channelID string) error {
+	if _, err := s.getChatChannel(ctx, channelID); err != nil {
+		return err
+	}
+	return s.repo.DeleteWebhook(ctx, channelID)
+}
+
+// TestChatChannel posts a test message to the chat channel right away, returns the reason the chat service rejected
+// the message
+func (s *service) TestChatChannel(ctx context.Context, channelID string) error {
+	channel, err := s.getChatChannel(ctx, channelID)
+	if err != nil {
+		return err
+	}
+	body, err := newTestMessage(channel, s.corporateConsoleURL).body(channel.Provider)
+	if err != nil {
+		return err
+	}
+
+	// the test message is not recorded in the delivery log of the channel
+	_, err = s.post(ctx, channel, &WebhookDelivery{WebhookID: channel.WebhookID, Payload: string(body)}, time.Now())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPostFailed, err)
+	}
+	return nil
+}
+
+// getChatChannel returns the chat channel by its ID, the webhooks are not returned
+func (s *service) getChatChannel(ctx context.Context, channelID string) (*Webhook, error) {
+	webhook, err := s.repo.GetWebhook(ctx, channelID)
+	if err == ErrWebhookDoesNotExist || (err == nil && !webhook.isChatChannel()) {
+		return nil, ErrChatChannelDoesNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
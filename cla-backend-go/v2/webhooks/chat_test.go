@@ -0,0 +1,238 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const corporateConsole = "https://corporate.v2.dev.lfcla.com"
+
+func TestValidateChatChannelInput(t *testing.T) {
+	input := func(provider, webhookURL string, notifications ...string) *models.ChatChannelInput {
+		return &models.ChatChannelInput{Provider: aws.String(provider), WebhookURL: aws.String(webhookURL), Notifications: notifications}
+	}
+
+	provider, webhookURL, err := validateChatChannelInput(input(ProviderSlack, " https://hooks.slack.com/services/T000/B000/XXXX "))
+	assert.Nil(t, err)
+	assert.Equal(t, ProviderSlack, provider)
+	assert.Equal(t, "https://hooks.slack.com/services/T000/B000/XXXX", webhookURL)
+
+	_, _, err = validateChatChannelInput(input(ProviderMSTeams, "https://contoso.webhook.office.com/webhookb2/abc/IncomingWebhook/def", NotificationCCLASigned))
+	assert.Nil(t, err)
+
+	_, _, err = validateChatChannelInput(input("discord", "https://hooks.slack.com/services/T000/B000/XXXX"))
+	assert.True(t, errors.Is(err, ErrInvalidProvider))
+	_, _, err = validateChatChannelInput(input(ProviderSlack, "http://hooks.slack.com/services/T000/B000/XXXX"))
+	assert.True(t, errors.Is(err, ErrInvalidChatWebhookURL))
+	_, _, err = validateChatChannelInput(input(ProviderMSTeams, "https://hooks.slack.com/services/T000/B000/XXXX"))
+	assert.True(t, errors.Is(err, ErrInvalidChatWebhookURL))
+	_, _, err = validateChatChannelInput(input(ProviderMSTeams, "https://webhook.office.com.example.org/hook"))
+	assert.True(t, errors.Is(err, ErrInvalidChatWebhookURL))
+	_, _, err = validateChatChannelInput(input(ProviderSlack, "https://hooks.slack.com/services/T000/B000/XXXX", "signature_invalidated"))
+	assert.True(t, errors.Is(err, ErrInvalidNotification))
+}
+
+func TestMaskWebhookURL(t *testing.T) {
+	assert.Equal(t, "https://hooks.slack.com/...XXXX", maskWebhookURL("https://hooks.slack.com/services/T000/B000/XXXX"))
+	assert.Equal(t, "", maskWebhookURL("not a url"))
+}
+
+func TestDispatchEventToChatChannels(t *testing.T) {
+	server, received := newEndpoint(t, http.StatusOK)
+	defer server.Close()
+
+	repo := newFakeRepository(
+		&Webhook{WebhookID: "cla-group-slack", OwnerType: OwnerTypeCLAGroup, OwnerID: "cla-group-id", Provider: ProviderSlack, URL: server.URL + "/slack", Enabled: true},
+		&Webhook{WebhookID: "company-teams", OwnerType: OwnerTypeCompany, OwnerID: "company-id", Provider: ProviderMSTeams, URL: server.URL + "/teams", Enabled: true, Notifications: []string{NotificationCCLASigned}},
+		&Webhook{WebhookID: "disabled", OwnerType: OwnerTypeCompany, OwnerID: "company-id", Provider: ProviderSlack, URL: server.URL + "/disabled", Enabled: false},
+	)
+	s := &service{repo: repo, corporateConsoleURL: corporateConsole, httpClient: server.Client()}
+
+	event := &v1Models.Event{
+		EventID:             "event-id",
+		EventType:           events.ContributorNotifyCompanyAdminType,
+		EventProjectID:      "cla-group-id",
+		EventProjectName:    "CNCF <CLA>",
+		EventCompanyID:      "company-id",
+		EventCompanyName:    "Acme & Co",
+		EventFoundationSFID: "foundation-sfid",
+		EventProjectSFID:    "project-sfid",
+		LfUsername:          "jdoe",
+		EventSummary:        "jdoe requested the company admin to assign a CLA Manager",
+	}
+	assert.Nil(t, s.DispatchEvent(context.Background(), event))
+
+	// the chat messages are queued like the webhook deliveries
+	assert.Len(t, received(), 0)
+	if assert.Len(t, repo.deliveries, 1) {
+		for _, delivery := range repo.deliveries {
+			assert.Equal(t, "cla-group-slack", delivery.WebhookID)
+			assert.Equal(t, DeliveryStatusPending, delivery.DeliveryStatus)
+			assert.Contains(t, delivery.Payload, "New CLA Manager request")
+			assert.Contains(t, delivery.Payload, corporateConsole+"/foundation/foundation-sfid/project/project-sfid/cla")
+			assert.Contains(t, delivery.Payload, "Acme &amp; Co")
+			assert.Contains(t, delivery.Payload, "CNCF &lt;CLA&gt;")
+		}
+	}
+
+	event.EventID = "signed-event-id"
+	event.EventType = events.CorporateSignatureSigned
+	event.LfUsername = ""
+	event.EventSummary = "The CLA Signatory Derk Miyamoto signed the Corporate CLA of the company Acme & Co"
+	assert.Nil(t, s.DispatchEvent(context.Background(), event))
+	assert.Len(t, repo.deliveries, 3)
+
+	attempted, err := s.RetryDeliveries(context.Background(), time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempted)
+
+	requests := received()
+	assert.Len(t, requests, 3)
+	for _, request := range requests {
+		assert.Empty(t, request.header.Get(SignatureHeader), "the chat messages are not signed")
+		if !strings.Contains(string(request.body), "MessageCard") {
+			continue
+		}
+		var card map[string]interface{}
+		assert.Nil(t, json.Unmarshal(request.body, &card))
+		assert.Equal(t, "Corporate CLA signed", card["title"])
+		assert.Contains(t, string(request.body), "Derk Miyamoto")
+	}
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, DeliveryStatusSucceeded, delivery.DeliveryStatus)
+	}
+}
+
+func TestRetryDeliveriesRetriesTheRejectedChatMessages(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+		assert.Empty(t, r.Header.Get(SignatureHeader), "the chat messages are not signed")
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("service_unavailable")) // nolint
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	repo := newFakeRepository(
+		&Webhook{WebhookID: "company-teams", OwnerType: OwnerTypeCompany, OwnerID: "company-id", Provider: ProviderMSTeams, URL: server.URL + "/teams", Enabled: true, Notifications: []string{NotificationCCLASigned}},
+	)
+	s := &service{repo: repo, corporateConsoleURL: corporateConsole, httpClient: server.Client()}
+
+	assert.Nil(t, s.DispatchEvent(context.Background(), &v1Models.Event{
+		EventID:          "signed-event-id",
+		EventType:        events.CorporateSignatureSigned,
+		EventProjectID:   "cla-group-id",
+		EventProjectName: "CNCF",
+		EventCompanyID:   "company-id",
+		EventCompanyName: "Acme",
+		EventSummary:     "The CLA Signatory Derk Miyamoto signed the Corporate CLA of the company Acme",
+	}))
+	if !assert.Len(t, repo.deliveries, 1) {
+		return
+	}
+	var deliveryID string
+	for id := range repo.deliveries {
+		deliveryID = id
+	}
+
+	// the chat service rejects the first attempt, the message is retried once its delay is over
+	now := time.Now().Add(time.Minute)
+	attempted, err := s.RetryDeliveries(context.Background(), now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, attempted)
+	delivery := repo.deliveries[deliveryID]
+	assert.Equal(t, DeliveryStatusRetrying, delivery.DeliveryStatus)
+	assert.Equal(t, int64(http.StatusServiceUnavailable), delivery.ResponseStatusCode)
+	assert.Contains(t, delivery.Error, "service_unavailable")
+
+	attempted, err = s.RetryDeliveries(context.Background(), now)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, attempted, "the retry is not due yet")
+
+	attempted, err = s.RetryDeliveries(context.Background(), now.Add(retryDelays[0]))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, attempted)
+	delivery = repo.deliveries[deliveryID]
+	assert.Equal(t, DeliveryStatusSucceeded, delivery.DeliveryStatus)
+	assert.Equal(t, int64(2), delivery.Attempts)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, bodies, 2) {
+		assert.Equal(t, bodies[0], bodies[1], "the queued message is posted again as is")
+		assert.Contains(t, bodies[1], "MessageCard")
+		assert.Contains(t, bodies[1], "Derk Miyamoto")
+	}
+}
+
+func TestChatChannelsAreNotWebhooks(t *testing.T) {
+	repo := newFakeRepository(
+		&Webhook{WebhookID: "webhook-id", OwnerType: OwnerTypeCompany, OwnerID: "company-id", URL: "https://ci.example.org/hooks", Enabled: true},
+		&Webhook{WebhookID: "channel-id", OwnerType: OwnerTypeCompany, OwnerID: "company-id", Provider: ProviderSlack, URL: "https://hooks.slack.com/services/T000/B000/XXXX", Enabled: true},
+	)
+	s := &service{repo: repo, corporateConsoleURL: corporateConsole}
+
+	_, err := s.GetWebhook(context.Background(), "channel-id")
+	assert.Equal(t, ErrWebhookDoesNotExist, err)
+	_, err = s.GetChatChannel(context.Background(), "webhook-id")
+	assert.Equal(t, ErrChatChannelDoesNotExist, err)
+	assert.Equal(t, ErrChatChannelDoesNotExist, s.DeleteChatChannel(context.Background(), "webhook-id"))
+
+	webhooks, err := s.GetWebhooks(context.Background(), OwnerTypeCompany, "company-id")
+	assert.Nil(t, err)
+	if assert.Len(t, webhooks.List, 1) {
+		assert.Equal(t, "webhook-id", webhooks.List[0].WebhookID)
+	}
+	channels, err := s.GetChatChannels(context.Background(), OwnerTypeCompany, "company-id")
+	assert.Nil(t, err)
+	if assert.Len(t, channels.List, 1) {
+		assert.Equal(t, "channel-id", channels.List[0].ChannelID)
+		assert.Equal(t, "https://hooks.slack.com/...XXXX", channels.List[0].WebhookURL)
+	}
+}
+
+func TestTestChatChannel(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("invalid_payload")) // nolint
+	}))
+	defer server.Close()
+
+	repo := newFakeRepository(
+		&Webhook{WebhookID: "channel-id", OwnerType: OwnerTypeCompany, OwnerID: "company-id", Provider: ProviderSlack, URL: server.URL + "/slack", Enabled: true},
+	)
+	s := &service{repo: repo, corporateConsoleURL: corporateConsole, httpClient: server.Client()}
+
+	err := s.TestChatChannel(context.Background(), "channel-id")
+	assert.True(t, errors.Is(err, ErrPostFailed))
+	assert.True(t, strings.Contains(err.Error(), "invalid_payload"))
+	assert.Len(t, repo.deliveries, 0, "the test message is not recorded")
+
+	assert.Equal(t, ErrChatChannelDoesNotExist, s.TestChatChannel(context.Background(), "unknown"))
+}
@@ -0,0 +1,198 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// webhook delivery request headers
+const (
+	EventHeader     = "X-EasyCLA-Event"
+	DeliveryHeader  = "X-EasyCLA-Delivery"
+	TimestampHeader = "X-EasyCLA-Timestamp"
+	SignatureHeader = "X-EasyCLA-Signature-256"
+	userAgent       = "EasyCLA-Webhooks"
+)
+
+const (
+	// deliveryTimeout is how long an attempt waits for the endpoint response
+	deliveryTimeout = 10 * time.Second
+	// maxErrorLength is the number of characters of the attempt error kept in the delivery log
+	maxErrorLength = 512
+	// deliveryLease is how long a claimed delivery stays in flight, after which a run which stopped before recording
+	// the attempt no longer holds it
+	deliveryLease = 5 * time.Minute
+	// maxReasonLength is the number of characters of the rejected response body kept in the attempt error, the chat
+	// services state the reason in the body, e.g. invalid_payload or channel_not_found
+	maxReasonLength = 256
+)
+
+// retryDelays are the delays between the attempts of a delivery, the delivery fails once the attempts following
+// the first one are exhausted
+var retryDelays = []time.Duration{
+	1 * time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	12 * time.Hour,
+}
+
+// Sign returns the X-EasyCLA-Signature-256 header value of the payload, the hex encoded HMAC-SHA256 of the
+// X-EasyCLA-Timestamp value, a dot and the body keyed with the webhook secret. Receivers should reject the
+// timestamps too far from their clock so the payloads cannot be replayed.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + ".")) // nolint
+	mac.Write(body)                    // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newHTTPClient returns the client posting the deliveries. It does not follow the redirects and refuses to connect
+// to the addresses which are not public, whatever the webhook host name resolves to.
+func newHTTPClient() *http.Client {
+	dialer := &net.Dialer{Timeout: deliveryTimeout, Control: utils.PublicDialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   deliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// the redirect response is recorded as a failed attempt
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// MatchesEventType returns true if the event type is delivered to a webhook with the filters, all the event types
+// match an empty filter list, the filters ending with .* match the event types starting with the prefix
+func MatchesEventType(filters []string, eventType string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if filter == "*" || filter == eventType {
+			return true
+		}
+		if strings.HasSuffix(filter, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(filter, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateURL checks the webhook URL is an HTTPS URL of a public host
+func validateURL(rawURL string) error {
+	webhookURL, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if webhookURL.Scheme != "https" || webhookURL.Hostname() == "" {
+		return fmt.Errorf("%w: the URL must be an https URL", ErrInvalidURL)
+	}
+	if !utils.IsPublicHost(webhookURL.Hostname()) {
+		return fmt.Errorf("%w: the URL host must be a public host", ErrInvalidURL)
+	}
+	return nil
+}
+
+// validateEventTypes checks the event type filters are not blank
+func validateEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if strings.TrimSpace(eventType) == "" {
+			return fmt.Errorf("%w: the event types must not be blank", ErrInvalidEventType)
+		}
+	}
+	return nil
+}
+
+// queue schedules the first attempt of the pending delivery, the attempts are made by RetryDeliveries
+func queue(delivery *WebhookDelivery, now time.Time) {
+	delivery.DeliveryStatus = DeliveryStatusPending
+	delivery.NextAttemptEpoch = now.Unix()
+}
+
+// attempt posts the delivery payload to the webhook and records the outcome in the delivery, a failed attempt is
+// scheduled for a retry until the attempts are exhausted
+func (s *service) attempt(ctx context.Context, webhook *Webhook, delivery *WebhookDelivery, now time.Time) {
+	delivery.Attempts++
+	delivery.ResponseStatusCode = 0
+	delivery.Error = ""
+	delivery.DateModified = utils.TimeToString(now)
+
+	statusCode, err := s.post(ctx, webhook, delivery, now)
+	delivery.ResponseStatusCode = int64(statusCode)
+	if err == nil {
+		delivery.DeliveryStatus = DeliveryStatusSucceeded
+		delivery.NextAttemptEpoch = 0
+		return
+	}
+
+	delivery.Error = err.Error()
+	if len(delivery.Error) > maxErrorLength {
+		delivery.Error = delivery.Error[:maxErrorLength]
+	}
+	if int(delivery.Attempts) > len(retryDelays) {
+		delivery.DeliveryStatus = DeliveryStatusFailed
+		delivery.NextAttemptEpoch = 0
+		return
+	}
+	delivery.DeliveryStatus = DeliveryStatusRetrying
+	delivery.NextAttemptEpoch = now.Add(retryDelays[delivery.Attempts-1]).Unix()
+}
+
+// post sends the payload signed with the attempt timestamp, the chat messages are posted unsigned to the incoming
+// webhook of the chat channel. Returns the response status code and an error unless the endpoint answers with a 2xx
+// status code.
+func (s *service) post(ctx context.Context, webhook *Webhook, delivery *WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if !webhook.isChatChannel() {
+		req.Header.Set(EventHeader, delivery.EventType)
+		req.Header.Set(DeliveryHeader, delivery.DeliveryID)
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		// drain the body so the connection is reused
+		io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024)) // nolint
+		resp.Body.Close()                                           // nolint
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		reason, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxReasonLength)) // nolint
+		if len(bytes.TrimSpace(reason)) > 0 {
+			return resp.StatusCode, fmt.Errorf("the endpoint responded with status code %d: %s", resp.StatusCode, bytes.TrimSpace(reason))
+		}
+		return resp.StatusCode, fmt.Errorf("the endpoint responded with status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,395 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	webhookOps "github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/webhooks"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, companyService company.IService, eventsService events.Service) { // nolint
+	api.WebhooksCreateFoundationWebhookHandler = webhookOps.CreateFoundationWebhookHandlerFunc(func(params webhookOps.CreateFoundationWebhookParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksCreateFoundationWebhookHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"foundationSFID": params.FoundationSFID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to create the webhooks with Project scope of %s",
+				authUser.UserName, params.FoundationSFID)
+			log.WithFields(f).Debug(msg)
+			return webhookOps.NewCreateFoundationWebhookForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		webhook, err := service.CreateWebhook(ctx, OwnerTypeFoundation, params.FoundationSFID, params.Body)
+		if err != nil {
+			if isValidationError(err) {
+				return webhookOps.NewCreateFoundationWebhookBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, err.Error(), err))
+			}
+			msg := fmt.Sprintf("unable to create the webhook of foundation: %s", params.FoundationSFID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhookOps.NewCreateFoundationWebhookInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:         events.WebhookCreated,
+			ExternalProjectID: params.FoundationSFID,
+			LfUsername:        authUser.UserName,
+			EventData:         webhookCreatedEventData(webhook),
+		})
+
+		return webhookOps.NewCreateFoundationWebhookOK().WithXRequestID(reqID).WithPayload(webhook)
+	})
+
+	api.WebhooksListFoundationWebhooksHandler = webhookOps.ListFoundationWebhooksHandlerFunc(func(params webhookOps.ListFoundationWebhooksParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksListFoundationWebhooksHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"foundationSFID": params.FoundationSFID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to list the webhooks with Project scope of %s",
+				authUser.UserName, params.FoundationSFID)
+			log.WithFields(f).Debug(msg)
+			return webhookOps.NewListFoundationWebhooksForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.GetWebhooks(ctx, OwnerTypeFoundation, params.FoundationSFID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the webhooks of foundation: %s", params.FoundationSFID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhookOps.NewListFoundationWebhooksInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return webhookOps.NewListFoundationWebhooksOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.WebhooksCreateCompanyWebhookHandler = webhookOps.CreateCompanyWebhookHandlerFunc(func(params webhookOps.CreateCompanyWebhookParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksCreateCompanyWebhookHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to lookup company by ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return webhookOps.NewCreateCompanyWebhookNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return webhookOps.NewCreateCompanyWebhookBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		if !utils.IsUserAuthorizedForOrganization(authUser, companyModel.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to create the webhooks with Organization scope of %s",
+				authUser.UserName, companyModel.CompanyExternalID)
+			log.WithFields(f).Debug(msg)
+			return webhookOps.NewCreateCompanyWebhookForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		webhook, err := service.CreateWebhook(ctx, OwnerTypeCompany, params.CompanyID, params.Body)
+		if err != nil {
+			if isValidationError(err) {
+				return webhookOps.NewCreateCompanyWebhookBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, err.Error(), err))
+			}
+			msg := fmt.Sprintf("unable to create the webhook of company: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhookOps.NewCreateCompanyWebhookInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.WebhookCreated,
+			CompanyID:    companyModel.CompanyID,
+			CompanyModel: companyModel,
+			LfUsername:   authUser.UserName,
+			EventData:    webhookCreatedEventData(webhook),
+		})
+
+		return webhookOps.NewCreateCompanyWebhookOK().WithXRequestID(reqID).WithPayload(webhook)
+	})
+
+	api.WebhooksListCompanyWebhooksHandler = webhookOps.ListCompanyWebhooksHandlerFunc(func(params webhookOps.ListCompanyWebhooksParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksListCompanyWebhooksHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to lookup company by ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return webhookOps.NewListCompanyWebhooksNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return webhookOps.NewListCompanyWebhooksBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		if !utils.IsUserAuthorizedForOrganization(authUser, companyModel.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to list the webhooks with Organization scope of %s",
+				authUser.UserName, companyModel.CompanyExternalID)
+			log.WithFields(f).Debug(msg)
+			return webhookOps.NewListCompanyWebhooksForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.GetWebhooks(ctx, OwnerTypeCompany, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the webhooks of company: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhookOps.NewListCompanyWebhooksInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return webhookOps.NewListCompanyWebhooksOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.WebhooksUpdateWebhookHandler = webhookOps.UpdateWebhookHandlerFunc(func(params webhookOps.UpdateWebhookParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksUpdateWebhookHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"webhookID":      params.WebhookID,
+		}
+
+		existing, err := service.GetWebhook(ctx, params.WebhookID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the webhook: %s", params.WebhookID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrWebhookDoesNotExist) {
+				return webhookOps.NewUpdateWebhookNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return webhookOps.NewUpdateWebhookInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		logArgs, authorized := authorizeWebhook(ctx, authUser, companyService, existing)
+		if !authorized {
+			msg := fmt.Sprintf("user %s does not have access to update the webhook: %s", authUser.UserName, params.WebhookID)
+			log.WithFields(f).Debug(msg)
+			return webhookOps.NewUpdateWebhookForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		webhook, err := service.UpdateWebhook(ctx, params.WebhookID, params.Body)
+		if err != nil {
+			if isValidationError(err) {
+				return webhookOps.NewUpdateWebhookBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, err.Error(), err))
+			}
+			msg := fmt.Sprintf("unable to update the webhook: %s", params.WebhookID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhookOps.NewUpdateWebhookInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		logArgs.EventType = events.WebhookUpdated
+		logArgs.LfUsername = authUser.UserName
+		logArgs.EventData = &events.WebhookUpdatedEventData{
+			WebhookID: webhook.WebhookID,
+			OwnerType: webhook.OwnerType,
+			OwnerID:   webhook.OwnerID,
+			URL:       webhook.URL,
+			Enabled:   webhook.Enabled,
+		}
+		eventsService.LogEvent(logArgs)
+
+		return webhookOps.NewUpdateWebhookOK().WithXRequestID(reqID).WithPayload(webhook)
+	})
+
+	api.WebhooksDeleteWebhookHandler = webhookOps.DeleteWebhookHandlerFunc(func(params webhookOps.DeleteWebhookParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksDeleteWebhookHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"webhookID":      params.WebhookID,
+		}
+
+		existing, err := service.GetWebhook(ctx, params.WebhookID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the webhook: %s", params.WebhookID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrWebhookDoesNotExist) {
+				return webhookOps.NewDeleteWebhookNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return webhookOps.NewDeleteWebhookInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		logArgs, authorized := authorizeWebhook(ctx, authUser, companyService, existing)
+		if !authorized {
+			msg := fmt.Sprintf("user %s does not have access to delete the webhook: %s", authUser.UserName, params.WebhookID)
+			log.WithFields(f).Debug(msg)
+			return webhookOps.NewDeleteWebhookForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		if err = service.DeleteWebhook(ctx, params.WebhookID); err != nil {
+			msg := fmt.Sprintf("unable to delete the webhook: %s", params.WebhookID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhookOps.NewDeleteWebhookInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		logArgs.EventType = events.WebhookDeleted
+		logArgs.LfUsername = authUser.UserName
+		logArgs.EventData = &events.WebhookDeletedEventData{
+			WebhookID: existing.WebhookID,
+			OwnerType: existing.OwnerType,
+			OwnerID:   existing.OwnerID,
+			URL:       existing.URL,
+		}
+		eventsService.LogEvent(logArgs)
+
+		return webhookOps.NewDeleteWebhookNoContent().WithXRequestID(reqID)
+	})
+
+	api.WebhooksListWebhookDeliveriesHandler = webhookOps.ListWebhookDeliveriesHandlerFunc(func(params webhookOps.ListWebhookDeliveriesParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksListWebhookDeliveriesHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"webhookID":      params.WebhookID,
+		}
+
+		existing, err := service.GetWebhook(ctx, params.WebhookID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the webhook: %s", params.WebhookID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrWebhookDoesNotExist) {
+				return webhookOps.NewListWebhookDeliveriesNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return webhookOps.NewListWebhookDeliveriesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		if _, authorized := authorizeWebhook(ctx, authUser, companyService, existing); !authorized {
+			msg := fmt.Sprintf("user %s does not have access to the deliveries of the webhook: %s", authUser.UserName, params.WebhookID)
+			log.WithFields(f).Debug(msg)
+			return webhookOps.NewListWebhookDeliveriesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		var pageSize int64
+		if params.PageSize != nil {
+			pageSize = *params.PageSize
+		}
+		result, err := service.GetDeliveries(ctx, params.WebhookID, pageSize)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the deliveries of the webhook: %s", params.WebhookID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhookOps.NewListWebhookDeliveriesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return webhookOps.NewListWebhookDeliveriesOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.WebhooksRedeliverWebhookDeliveryHandler = webhookOps.RedeliverWebhookDeliveryHandlerFunc(func(params webhookOps.RedeliverWebhookDeliveryParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.WebhooksRedeliverWebhookDeliveryHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"authUser":       authUser.UserName,
+			"webhookID":      params.WebhookID,
+			"deliveryID":     params.DeliveryID,
+		}
+
+		existing, err := service.GetWebhook(ctx, params.WebhookID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the webhook: %s", params.WebhookID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrWebhookDoesNotExist) {
+				return webhookOps.NewRedeliverWebhookDeliveryNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			return webhookOps.NewRedeliverWebhookDeliveryInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		if _, authorized := authorizeWebhook(ctx, authUser, companyService, existing); !authorized {
+			msg := fmt.Sprintf("user %s does not have access to redeliver the deliveries of the webhook: %s", authUser.UserName, params.WebhookID)
+			log.WithFields(f).Debug(msg)
+			return webhookOps.NewRedeliverWebhookDeliveryForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := service.Redeliver(ctx, params.WebhookID, params.DeliveryID)
+		if err != nil {
+			if errors.Is(err, ErrDeliveryDoesNotExist) {
+				msg := fmt.Sprintf("webhook delivery %s not found", params.DeliveryID)
+				return webhookOps.NewRedeliverWebhookDeliveryNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+			}
+			msg := fmt.Sprintf("unable to redeliver the delivery: %s of the webhook: %s", params.DeliveryID, params.WebhookID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return webhookOps.NewRedeliverWebhookDeliveryInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return webhookOps.NewRedeliverWebhookDeliveryOK().WithXRequestID(reqID).WithPayload(result)
+	})
+}
+
+// authorizeWebhook checks the user manages the foundation or the company owning the webhook, returns the event
+// arguments scoping the events of the webhook to its owner
+func authorizeWebhook(ctx context.Context, authUser *auth.User, companyService company.IService, webhook *models.Webhook) (*events.LogEventArgs, bool) {
+	if webhook.OwnerType == OwnerTypeFoundation {
+		authorized := utils.IsUserAuthorizedForProjectTree(ctx, authUser, webhook.OwnerID, utils.ALLOW_ADMIN_SCOPE)
+		return &events.LogEventArgs{ExternalProjectID: webhook.OwnerID}, authorized
+	}
+
+	companyModel, err := companyService.GetCompany(ctx, webhook.OwnerID)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"functionName":   "v2.webhooks.handlers.authorizeWebhook",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"webhookID":      webhook.WebhookID,
+			"companyID":      webhook.OwnerID,
+		}).WithError(err).Warn("unable to lookup the company of the webhook")
+		return nil, false
+	}
+	authorized := utils.IsUserAuthorizedForOrganization(authUser, companyModel.CompanyExternalID, utils.ALLOW_ADMIN_SCOPE)
+	return &events.LogEventArgs{CompanyID: companyModel.CompanyID, CompanyModel: companyModel}, authorized
+}
+
+// isValidationError returns true if the webhook input was rejected
+func isValidationError(err error) bool {
+	return errors.Is(err, ErrInvalidURL) || errors.Is(err, ErrInvalidEventType)
+}
+
+func webhookCreatedEventData(webhook *models.Webhook) *events.WebhookCreatedEventData {
+	return &events.WebhookCreatedEventData{
+		WebhookID: webhook.WebhookID,
+		OwnerType: webhook.OwnerType,
+		OwnerID:   webhook.OwnerID,
+		URL:       webhook.URL,
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+)
+
+// webhook owner types, the chat channels are owned by a CLA Group or a company
+const (
+	OwnerTypeFoundation = "foundation"
+	OwnerTypeCLAGroup   = "cla_group"
+	OwnerTypeCompany    = "company"
+)
+
+// delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusRetrying  = "retrying"
+	DeliveryStatusFailed    = "failed"
+	// DeliveryStatusInFlight is the status of the delivery claimed by a run of the retry lambda until its lease ends
+	DeliveryStatusInFlight = "in_flight"
+)
+
+// Webhook is data model for the webhooks table, the chat channels are the webhooks with a chat provider, their
+// deliveries post the chat message of the event notification instead of the signed payload
+type Webhook struct {
+	WebhookID     string   `json:"webhook_id"`
+	OwnerType     string   `json:"owner_type"`
+	OwnerID       string   `json:"owner_id"`
+	URL           string   `json:"url"`
+	EventTypes    []string `json:"event_types"`
+	Secret        string   `json:"secret"`
+	Provider      string   `json:"provider,omitempty"`
+	Notifications []string `json:"notifications,omitempty"`
+	Enabled       bool     `json:"enabled"`
+	DateCreated   string   `json:"date_created,omitempty"`
+	DateModified  string   `json:"date_modified,omitempty"`
+	Version       string   `json:"version,omitempty"`
+}
+
+// WebhookDelivery is data model for the webhook deliveries table, the delivery log of the webhooks
+type WebhookDelivery struct {
+	DeliveryID         string `json:"delivery_id"`
+	WebhookID          string `json:"webhook_id"`
+	EventID            string `json:"event_id"`
+	EventType          string `json:"event_type"`
+	Payload            string `json:"payload"`
+	DeliveryStatus     string `json:"delivery_status"`
+	Attempts           int64  `json:"attempts"`
+	ResponseStatusCode int64  `json:"response_status_code,omitempty"`
+	Error              string `json:"error,omitempty"`
+	NextAttemptEpoch   int64  `json:"next_attempt_epoch,omitempty"`
+	RedeliveryOf       string `json:"redelivery_of,omitempty"`
+	DateCreated        string `json:"date_created,omitempty"`
+	DateModified       string `json:"date_modified,omitempty"`
+	Version            string `json:"version,omitempty"`
+}
+
+// Payload is the JSON body posted to the webhooks, a redelivery posts the same payload
+type Payload struct {
+	EventID        string `json:"eventID"`
+	EventType      string `json:"eventType"`
+	EventTime      string `json:"eventTime"`
+	EventTimeEpoch int64  `json:"eventTimeEpoch"`
+	ClaGroupID     string `json:"claGroupID,omitempty"`
+	ClaGroupName   string `json:"claGroupName,omitempty"`
+	FoundationSFID string `json:"foundationSFID,omitempty"`
+	ProjectSFID    string `json:"projectSFID,omitempty"`
+	CompanyID      string `json:"companyID,omitempty"`
+	CompanySFID    string `json:"companySFID,omitempty"`
+	CompanyName    string `json:"companyName,omitempty"`
+	LfUsername     string `json:"lfUsername,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	Data           string `json:"data,omitempty"`
+}
+
+// toModel converts the webhook to the response model, the secret is only set by the caller creating the webhook
+func (in *Webhook) toModel() *models.Webhook {
+	return &models.Webhook{
+		WebhookID:    in.WebhookID,
+		OwnerType:    in.OwnerType,
+		OwnerID:      in.OwnerID,
+		URL:          in.URL,
+		EventTypes:   in.EventTypes,
+		Enabled:      in.Enabled,
+		DateCreated:  in.DateCreated,
+		DateModified: in.DateModified,
+		Version:      in.Version,
+	}
+}
+
+// isChatChannel returns true if the webhook posts to a Slack or a Microsoft Teams channel
+func (in *Webhook) isChatChannel() bool {
+	return in.Provider != ""
+}
+
+// receives returns true if the enabled webhook is subscribed to the event type, the chat channels receive the event
+// types of their notifications
+func (in *Webhook) receives(eventType string) bool {
+	if !in.Enabled {
+		return false
+	}
+	if in.isChatChannel() {
+		notification, ok := notificationEventTypes[eventType]
+		return ok && in.wantsNotification(notification)
+	}
+	return MatchesEventType(in.EventTypes, eventType)
+}
+
+func (in *WebhookDelivery) toModel() *models.WebhookDelivery {
+	delivery := &models.WebhookDelivery{
+		DeliveryID:         in.DeliveryID,
+		WebhookID:          in.WebhookID,
+		EventID:            in.EventID,
+		EventType:          in.EventType,
+		Status:             in.DeliveryStatus,
+		Attempts:           in.Attempts,
+		ResponseStatusCode: in.ResponseStatusCode,
+		Error:              in.Error,
+		RedeliveryOf:       in.RedeliveryOf,
+		Payload:            in.Payload,
+		DateCreated:        in.DateCreated,
+		DateModified:       in.DateModified,
+	}
+	if isDue(in.DeliveryStatus) && in.NextAttemptEpoch > 0 {
+		delivery.NextAttemptTime = time.Unix(in.NextAttemptEpoch, 0).UTC().Format(time.RFC3339)
+	}
+	return delivery
+}
@@ -0,0 +1,379 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// indexes
+const (
+	WebhookOwnerIDIndex           = "webhook-owner-id-index"
+	WebhookDeliveryWebhookIDIndex = "webhook-delivery-webhook-id-index"
+	WebhookDeliveryStatusIndex    = "webhook-delivery-status-index"
+)
+
+// errors
+var (
+	ErrWebhookDoesNotExist  = errors.New("webhook does not exist")
+	ErrDeliveryDoesNotExist = errors.New("webhook delivery does not exist")
+)
+
+// Repository interface defines the functions for the webhooks and webhook deliveries data model
+type Repository interface {
+	PutWebhook(ctx context.Context, webhook *Webhook) error
+	GetWebhook(ctx context.Context, webhookID string) (*Webhook, error)
+	GetWebhooksByOwner(ctx context.Context, ownerID string) ([]*Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	PutDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	GetDelivery(ctx context.Context, deliveryID string) (*WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookID string, pageSize int64) ([]*WebhookDelivery, error)
+	GetDueDeliveries(ctx context.Context, nowEpoch int64) ([]*WebhookDelivery, error)
+	ClaimDelivery(ctx context.Context, deliveryID, deliveryStatus string, nextAttemptEpoch, leaseEpoch int64) (bool, error)
+}
+
+type repository struct {
+	stage                      string
+	dynamoDBClient             *dynamodb.DynamoDB
+	webhooksTableName          string
+	webhookDeliveriesTableName string
+}
+
+// NewRepository creates a new instance of the webhooks repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return repository{
+		stage:                      stage,
+		dynamoDBClient:             dynamodb.New(awsSession),
+		webhooksTableName:          fmt.Sprintf("cla-%s-webhooks", stage),
+		webhookDeliveriesTableName: fmt.Sprintf("cla-%s-webhook-deliveries", stage),
+	}
+}
+
+// PutWebhook creates or replaces the webhook record
+func (repo repository) PutWebhook(ctx context.Context, webhook *Webhook) error {
+	f := logrus.Fields{
+		"functionName":   "webhooks.PutWebhook",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"webhookID":      webhook.WebhookID,
+		"ownerType":      webhook.OwnerType,
+		"ownerID":        webhook.OwnerID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(webhook)
+	if err != nil {
+		log.WithFields(f).Warnf("problem marshalling the input, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.webhooksTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("cannot put webhook in dynamodb")
+		return err
+	}
+	return nil
+}
+
+// GetWebhook returns the webhook by its ID
+func (repo repository) GetWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	f := logrus.Fields{
+		"functionName":   "webhooks.GetWebhook",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"webhookID":      webhookID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"webhook_id": {S: aws.String(webhookID)},
+		},
+		TableName: aws.String(repo.webhooksTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading webhook")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrWebhookDoesNotExist
+	}
+
+	var webhook Webhook
+	err = dynamodbattribute.UnmarshalMap(result.Item, &webhook)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetWebhooksByOwner returns the webhooks of the foundation SFID or of the company ID
+func (repo repository) GetWebhooksByOwner(ctx context.Context, ownerID string) ([]*Webhook, error) {
+	f := logrus.Fields{
+		"functionName":   "webhooks.GetWebhooksByOwner",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"ownerID":        ownerID,
+	}
+
+	condition := expression.Key("owner_id").Equal(expression.Value(ownerID))
+	items, err := repo.query(repo.webhooksTableName, WebhookOwnerIDIndex, condition, 0, true)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem querying webhooks by owner")
+		return nil, err
+	}
+
+	var webhooks []*Webhook
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &webhooks)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes the webhook record, the delivery log is kept
+func (repo repository) DeleteWebhook(ctx context.Context, webhookID string) error {
+	f := logrus.Fields{
+		"functionName":   "webhooks.DeleteWebhook",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"webhookID":      webhookID,
+	}
+
+	log.WithFields(f).Debug("deleting webhook...")
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"webhook_id": {S: aws.String(webhookID)},
+		},
+		TableName: aws.String(repo.webhooksTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error deleting webhook")
+		return err
+	}
+	return nil
+}
+
+// PutDelivery creates or replaces the webhook delivery record
+func (repo repository) PutDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	f := logrus.Fields{
+		"functionName":   "webhooks.PutDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deliveryID":     delivery.DeliveryID,
+		"webhookID":      delivery.WebhookID,
+		"deliveryStatus": delivery.DeliveryStatus,
+	}
+
+	av, err := deliveryItem(delivery)
+	if err != nil {
+		log.WithFields(f).Warnf("problem marshalling the input, error: %+v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.webhookDeliveriesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("cannot put webhook delivery in dynamodb")
+		return err
+	}
+	return nil
+}
+
+// deliveryItem marshals the delivery, the succeeded and the failed deliveries are stored without next attempt so
+// the sparse status index only holds the pending, the retrying and the in flight deliveries
+func deliveryItem(delivery *WebhookDelivery) (map[string]*dynamodb.AttributeValue, error) {
+	av, err := dynamodbattribute.MarshalMap(delivery)
+	if err != nil {
+		return nil, err
+	}
+	if !isDue(delivery.DeliveryStatus) {
+		delete(av, "next_attempt_epoch")
+	}
+	return av, nil
+}
+
+// GetDelivery returns the webhook delivery by its ID
+func (repo repository) GetDelivery(ctx context.Context, deliveryID string) (*WebhookDelivery, error) {
+	f := logrus.Fields{
+		"functionName":   "webhooks.GetDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deliveryID":     deliveryID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"delivery_id": {S: aws.String(deliveryID)},
+		},
+		TableName: aws.String(repo.webhookDeliveriesTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem loading webhook delivery")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrDeliveryDoesNotExist
+	}
+
+	var delivery WebhookDelivery
+	err = dynamodbattribute.UnmarshalMap(result.Item, &delivery)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetDeliveries returns the most recent deliveries of the webhook, up to pageSize deliveries
+func (repo repository) GetDeliveries(ctx context.Context, webhookID string, pageSize int64) ([]*WebhookDelivery, error) {
+	f := logrus.Fields{
+		"functionName":   "webhooks.GetDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"webhookID":      webhookID,
+		"pageSize":       pageSize,
+	}
+
+	condition := expression.Key("webhook_id").Equal(expression.Value(webhookID))
+	items, err := repo.query(repo.webhookDeliveriesTableName, WebhookDeliveryWebhookIDIndex, condition, pageSize, false)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem querying webhook deliveries")
+		return nil, err
+	}
+
+	var deliveries []*WebhookDelivery
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &deliveries)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// isDue returns true if the deliveries of the status are attempted once their next attempt is due, the in flight
+// deliveries are attempted again when their lease ends
+func isDue(deliveryStatus string) bool {
+	return deliveryStatus == DeliveryStatusPending || deliveryStatus == DeliveryStatusRetrying || deliveryStatus == DeliveryStatusInFlight
+}
+
+// ClaimDelivery sets the delivery in flight until the lease epoch, provided it still has the loaded status and next
+// attempt. Returns false when another run claimed or recorded the delivery since it was loaded.
+func (repo repository) ClaimDelivery(ctx context.Context, deliveryID, deliveryStatus string, nextAttemptEpoch, leaseEpoch int64) (bool, error) {
+	f := logrus.Fields{
+		"functionName":     "webhooks.ClaimDelivery",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"deliveryID":       deliveryID,
+		"deliveryStatus":   deliveryStatus,
+		"nextAttemptEpoch": nextAttemptEpoch,
+		"leaseEpoch":       leaseEpoch,
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"delivery_id": {S: aws.String(deliveryID)},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("delivery_status"),
+			"#N": aws.String("next_attempt_epoch"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":status":    {S: aws.String(deliveryStatus)},
+			":next":      {N: aws.String(strconv.FormatInt(nextAttemptEpoch, 10))},
+			":in_flight": {S: aws.String(DeliveryStatusInFlight)},
+			":lease":     {N: aws.String(strconv.FormatInt(leaseEpoch, 10))},
+		},
+		ConditionExpression: aws.String("#S = :status AND #N = :next"),
+		UpdateExpression:    aws.String("SET #S = :in_flight, #N = :lease"),
+		TableName:           aws.String(repo.webhookDeliveriesTableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Debug("the delivery was claimed by another run")
+			return false, nil
+		}
+		log.WithFields(f).WithError(err).Warn("problem claiming webhook delivery")
+		return false, err
+	}
+	return true, nil
+}
+
+// GetDueDeliveries returns the pending, the retrying and the in flight deliveries with a next attempt at or before
+// the specified epoch
+func (repo repository) GetDueDeliveries(ctx context.Context, nowEpoch int64) ([]*WebhookDelivery, error) {
+	f := logrus.Fields{
+		"functionName":   "webhooks.GetDueDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"nowEpoch":       nowEpoch,
+	}
+
+	var deliveries []*WebhookDelivery
+	for _, status := range []string{DeliveryStatusPending, DeliveryStatusRetrying, DeliveryStatusInFlight} {
+		condition := expression.Key("delivery_status").Equal(expression.Value(status)).
+			And(expression.Key("next_attempt_epoch").LessThanEqual(expression.Value(nowEpoch)))
+		items, err := repo.query(repo.webhookDeliveriesTableName, WebhookDeliveryStatusIndex, condition, 0, true)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem querying the due %s webhook deliveries", status)
+			return nil, err
+		}
+
+		var page []*WebhookDelivery
+		err = dynamodbattribute.UnmarshalListOfMaps(items, &page)
+		if err != nil {
+			log.WithFields(f).Warnf("problem decoding database results, error: %+v", err)
+			return nil, err
+		}
+		deliveries = append(deliveries, page...)
+	}
+	return deliveries, nil
+}
+
+// query returns the items of the index matching the key condition, all the items following the pagination when
+// limit is zero, otherwise up to limit items
+func (repo repository) query(tableName, indexName string, condition expression.KeyConditionBuilder, limit int64, ascending bool) ([]map[string]*dynamodb.AttributeValue, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(tableName),
+		IndexName:                 aws.String(indexName),
+		ScanIndexForward:          aws.Bool(ascending),
+	}
+	if limit > 0 {
+		queryInput.Limit = aws.Int64(limit)
+	}
+
+	var items []map[string]*dynamodb.AttributeValue
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, results.Items...)
+		if len(results.LastEvaluatedKey) == 0 || (limit > 0 && int64(len(items)) >= limit) {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	if limit > 0 && int64(len(items)) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
@@ -0,0 +1,391 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// secretLength is the number of random bytes of the webhook secrets
+	secretLength = 32
+	// defaultDeliveriesPageSize is the number of deliveries returned when the page size is not set
+	defaultDeliveriesPageSize = 50
+	// maxDeliveriesPageSize is the maximum number of deliveries returned by a query
+	maxDeliveriesPageSize = 100
+)
+
+// errors
+var (
+	ErrInvalidURL       = errors.New("invalid webhook URL")
+	ErrInvalidEventType = errors.New("invalid webhook event type")
+)
+
+// Service contains the functions of the webhooks service
+type Service interface {
+	CreateWebhook(ctx context.Context, ownerType, ownerID string, input *models.WebhookInput) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context, ownerType, ownerID string) (*models.Webhooks, error)
+	GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID string, input *models.WebhookInput) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	GetDeliveries(ctx context.Context, webhookID string, pageSize int64) (*models.WebhookDeliveries, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID string) (*models.WebhookDelivery, error)
+
+	CreateChatChannel(ctx context.Context, ownerType, ownerID string, input *models.ChatChannelInput) (*models.ChatChannel, error)
+	GetChatChannels(ctx context.Context, ownerType, ownerID string) (*models.ChatChannels, error)
+	GetChatChannel(ctx context.Context, channelID string) (*models.ChatChannel, error)
+	UpdateChatChannel(ctx context.Context, channelID string, input *models.ChatChannelInput) (*models.ChatChannel, error)
+	DeleteChatChannel(ctx context.Context, channelID string) error
+	TestChatChannel(ctx context.Context, channelID string) error
+
+	DispatchEvent(ctx context.Context, event *v1Models.Event) error
+	RetryDeliveries(ctx context.Context, now time.Time) (int, error)
+}
+
+type service struct {
+	repo                Repository
+	corporateConsoleURL string
+	httpClient          *http.Client
+}
+
+// NewService creates a new webhooks service, the chat messages link to the corporate console URL
+func NewService(repo Repository, corporateConsoleURL string) Service {
+	return &service{
+		repo:                repo,
+		corporateConsoleURL: corporateConsoleURL,
+		httpClient:          newHTTPClient(),
+	}
+}
+
+// CreateWebhook registers the webhook of the foundation or of the company, the generated secret is only returned
+// by this call
+func (s *service) CreateWebhook(ctx context.Context, ownerType, ownerID string, input *models.WebhookInput) (*models.Webhook, error) {
+	webhookURL := strings.TrimSpace(utils.StringValue(input.URL))
+	if err := validateURL(webhookURL); err != nil {
+		return nil, err
+	}
+	if err := validateEventTypes(input.EventTypes); err != nil {
+		return nil, err
+	}
+
+	webhookID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, secretLength)
+	if _, err = rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	webhook := &Webhook{
+		WebhookID:    webhookID.String(),
+		OwnerType:    ownerType,
+		OwnerID:      ownerID,
+		URL:          webhookURL,
+		EventTypes:   input.EventTypes,
+		Secret:       hex.EncodeToString(buf),
+		Enabled:      input.Enabled == nil || *input.Enabled,
+		DateCreated:  currentTime,
+		DateModified: currentTime,
+		Version:      "v1",
+	}
+	if err = s.repo.PutWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	result := webhook.toModel()
+	result.Secret = webhook.Secret
+	return result, nil
+}
+
+// GetWebhooks returns the webhooks of the foundation or of the company
+func (s *service) GetWebhooks(ctx context.Context, ownerType, ownerID string) (*models.Webhooks, error) {
+	webhooks, err := s.repo.GetWebhooksByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.Webhooks{List: make([]*models.Webhook, 0, len(webhooks))}
+	for _, webhook := range webhooks {
+		if webhook.OwnerType == ownerType && !webhook.isChatChannel() {
+			out.List = append(out.List, webhook.toModel())
+		}
+	}
+	return out, nil
+}
+
+// GetWebhook returns the webhook by its ID
+func (s *service) GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	webhook, err := s.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return webhook.toModel(), nil
+}
+
+// getWebhook returns the webhook by its ID, the chat channels are not returned
+func (s *service) getWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	webhook, err := s.repo.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.isChatChannel() {
+		return nil, ErrWebhookDoesNotExist
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook updates the URL, the event type filters and, when set, the enabled flag of the webhook
+func (s *service) UpdateWebhook(ctx context.Context, webhookID string, input *models.WebhookInput) (*models.Webhook, error) {
+	webhookURL := strings.TrimSpace(utils.StringValue(input.URL))
+	if err := validateURL(webhookURL); err != nil {
+		return nil, err
+	}
+	if err := validateEventTypes(input.EventTypes); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	webhook.URL = webhookURL
+	webhook.EventTypes = input.EventTypes
+	if input.Enabled != nil {
+		webhook.Enabled = *input.Enabled
+	}
+	_, webhook.DateModified = utils.CurrentTime()
+	if err = s.repo.PutWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook.toModel(), nil
+}
+
+// DeleteWebhook deletes the webhook, the pending retries fail on their next attempt
+func (s *service) DeleteWebhook(ctx context.Context, webhookID string) error {
+	return s.repo.DeleteWebhook(ctx, webhookID)
+}
+
+// GetDeliveries returns the delivery log of the webhook, the most recent deliveries first
+func (s *service) GetDeliveries(ctx context.Context, webhookID string, pageSize int64) (*models.WebhookDeliveries, error) {
+	if pageSize <= 0 {
+		pageSize = defaultDeliveriesPageSize
+	} else if pageSize > maxDeliveriesPageSize {
+		pageSize = maxDeliveriesPageSize
+	}
+
+	deliveries, err := s.repo.GetDeliveries(ctx, webhookID, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.WebhookDeliveries{List: make([]*models.WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		out.List = append(out.List, delivery.toModel())
+	}
+	return out, nil
+}
+
+// Redeliver queues the payload of the delivery again as a new delivery of the webhook
+func (s *service) Redeliver(ctx context.Context, webhookID, deliveryID string) (*models.WebhookDelivery, error) {
+	webhook, err := s.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	original, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.WebhookID != webhookID {
+		return nil, ErrDeliveryDoesNotExist
+	}
+
+	delivery, err := s.newDelivery(webhook, original.EventID, original.EventType, original.Payload)
+	if err != nil {
+		return nil, err
+	}
+	delivery.RedeliveryOf = original.DeliveryID
+	queue(delivery, time.Now())
+	if err = s.repo.PutDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery.toModel(), nil
+}
+
+// DispatchEvent queues the deliveries of the event to the enabled webhooks of the event foundation and of the event
+// company subscribed to the event type, and of the chat notification of the event to the chat channels of the event
+// CLA Group and of the event company, the deliveries are attempted by RetryDeliveries
+func (s *service) DispatchEvent(ctx context.Context, event *v1Models.Event) error {
+	f := logrus.Fields{
+		"functionName":   "webhooks.DispatchEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventID":        event.EventID,
+		"eventType":      event.EventType,
+		"foundationSFID": event.EventFoundationSFID,
+		"claGroupID":     event.EventProjectID,
+		"companyID":      event.EventCompanyID,
+	}
+
+	var subscribed []*Webhook
+	for ownerType, ownerID := range map[string]string{
+		OwnerTypeFoundation: event.EventFoundationSFID,
+		OwnerTypeCLAGroup:   event.EventProjectID,
+		OwnerTypeCompany:    event.EventCompanyID,
+	} {
+		if ownerID == "" {
+			continue
+		}
+		webhooks, err := s.repo.GetWebhooksByOwner(ctx, ownerID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to load the webhooks of the %s: %s", ownerType, ownerID)
+			return err
+		}
+		for _, webhook := range webhooks {
+			if webhook.OwnerType == ownerType && webhook.receives(event.EventType) {
+				subscribed = append(subscribed, webhook)
+			}
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(newPayload(event))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, webhook := range subscribed {
+		body := payload
+		if webhook.isChatChannel() {
+			// the chat message is rendered once, the retries post the same message
+			message := newEventMessage(notificationEventTypes[event.EventType], event, s.corporateConsoleURL)
+			if body, err = message.body(webhook.Provider); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to render the chat message of the chat channel: %s", webhook.WebhookID)
+				continue
+			}
+		}
+		delivery, deliveryErr := s.newDelivery(webhook, event.EventID, event.EventType, string(body))
+		if deliveryErr != nil {
+			log.WithFields(f).WithError(deliveryErr).Warnf("unable to create the delivery of the webhook: %s", webhook.WebhookID)
+			continue
+		}
+		queue(delivery, now)
+		if putErr := s.repo.PutDelivery(ctx, delivery); putErr != nil {
+			log.WithFields(f).WithError(putErr).Warnf("unable to record the delivery: %s of the webhook: %s", delivery.DeliveryID, webhook.WebhookID)
+		}
+	}
+	return nil
+}
+
+// RetryDeliveries attempts the queued and the retrying deliveries due at the specified time, returns the number of
+// attempts. Each delivery is claimed before its attempt so the overlapping runs don't post it twice.
+func (s *service) RetryDeliveries(ctx context.Context, now time.Time) (int, error) {
+	f := logrus.Fields{
+		"functionName":   "webhooks.RetryDeliveries",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	deliveries, err := s.repo.GetDueDeliveries(ctx, now.Unix())
+	if err != nil {
+		return 0, err
+	}
+
+	attempted := 0
+	for _, delivery := range deliveries {
+		leaseEpoch := now.Add(deliveryLease).Unix()
+		claimed, claimErr := s.repo.ClaimDelivery(ctx, delivery.DeliveryID, delivery.DeliveryStatus, delivery.NextAttemptEpoch, leaseEpoch)
+		if claimErr != nil {
+			log.WithFields(f).WithError(claimErr).Warnf("unable to claim the delivery: %s", delivery.DeliveryID)
+			continue
+		}
+		if !claimed {
+			log.WithFields(f).Debugf("the delivery: %s was claimed by another run - skipping", delivery.DeliveryID)
+			continue
+		}
+		delivery.DeliveryStatus = DeliveryStatusInFlight
+		delivery.NextAttemptEpoch = leaseEpoch
+
+		webhook, webhookErr := s.repo.GetWebhook(ctx, delivery.WebhookID)
+		switch {
+		case webhookErr == ErrWebhookDoesNotExist:
+			abandonDelivery(delivery, "the webhook was deleted", now)
+		case webhookErr != nil:
+			log.WithFields(f).WithError(webhookErr).Warnf("unable to load the webhook: %s", delivery.WebhookID)
+			continue
+		case !webhook.Enabled:
+			abandonDelivery(delivery, "the webhook is disabled", now)
+		default:
+			s.attempt(ctx, webhook, delivery, now)
+			attempted++
+		}
+		if putErr := s.repo.PutDelivery(ctx, delivery); putErr != nil {
+			log.WithFields(f).WithError(putErr).Warnf("unable to record the delivery: %s", delivery.DeliveryID)
+		}
+	}
+	return attempted, nil
+}
+
+// newDelivery returns a new pending delivery of the payload to the webhook
+func (s *service) newDelivery(webhook *Webhook, eventID, eventType, payload string) (*WebhookDelivery, error) {
+	deliveryID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	_, currentTime := utils.CurrentTime()
+	return &WebhookDelivery{
+		DeliveryID:     deliveryID.String(),
+		WebhookID:      webhook.WebhookID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		DeliveryStatus: DeliveryStatusPending,
+		DateCreated:    currentTime,
+		DateModified:   currentTime,
+		Version:        "v1",
+	}, nil
+}
+
+// abandonDelivery fails the claimed delivery without a new attempt
+func abandonDelivery(delivery *WebhookDelivery, reason string, now time.Time) {
+	delivery.DeliveryStatus = DeliveryStatusFailed
+	delivery.NextAttemptEpoch = 0
+	delivery.Error = reason
+	delivery.DateModified = utils.TimeToString(now)
+}
+
+// newPayload converts the event to the webhook payload
+func newPayload(event *v1Models.Event) *Payload {
+	return &Payload{
+		EventID:        event.EventID,
+		EventType:      event.EventType,
+		EventTime:      event.EventTime,
+		EventTimeEpoch: event.EventTimeEpoch,
+		ClaGroupID:     event.EventProjectID,
+		ClaGroupName:   event.EventProjectName,
+		FoundationSFID: event.EventFoundationSFID,
+		ProjectSFID:    event.EventProjectSFID,
+		CompanyID:      event.EventCompanyID,
+		CompanySFID:    event.EventCompanySFID,
+		CompanyName:    event.EventCompanyName,
+		LfUsername:     event.LfUsername,
+		Summary:        event.EventSummary,
+		Data:           event.EventData,
+	}
+}
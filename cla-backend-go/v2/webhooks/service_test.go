@@ -0,0 +1,437 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRepository struct {
+	Repository
+	webhooks   map[string]*Webhook
+	deliveries map[string]*WebhookDelivery
+}
+
+func newFakeRepository(webhooks ...*Webhook) *fakeRepository {
+	repo := &fakeRepository{webhooks: map[string]*Webhook{}, deliveries: map[string]*WebhookDelivery{}}
+	for _, webhook := range webhooks {
+		repo.webhooks[webhook.WebhookID] = webhook
+	}
+	return repo
+}
+
+func (r *fakeRepository) GetWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	webhook, ok := r.webhooks[webhookID]
+	if !ok {
+		return nil, ErrWebhookDoesNotExist
+	}
+	return webhook, nil
+}
+
+func (r *fakeRepository) GetWebhooksByOwner(ctx context.Context, ownerID string) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	for _, webhook := range r.webhooks {
+		if webhook.OwnerID == ownerID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *fakeRepository) PutWebhook(ctx context.Context, webhook *Webhook) error {
+	r.webhooks[webhook.WebhookID] = webhook
+	return nil
+}
+
+func (r *fakeRepository) DeleteWebhook(ctx context.Context, webhookID string) error {
+	delete(r.webhooks, webhookID)
+	return nil
+}
+
+func (r *fakeRepository) PutDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	r.deliveries[delivery.DeliveryID] = delivery
+	return nil
+}
+
+func (r *fakeRepository) GetDelivery(ctx context.Context, deliveryID string) (*WebhookDelivery, error) {
+	delivery, ok := r.deliveries[deliveryID]
+	if !ok {
+		return nil, ErrDeliveryDoesNotExist
+	}
+	return delivery, nil
+}
+
+// GetDueDeliveries queries the deliveries like the sparse status index, which only holds the stored items with a
+// next attempt, and returns copies as loaded from the table
+func (r *fakeRepository) GetDueDeliveries(ctx context.Context, nowEpoch int64) ([]*WebhookDelivery, error) {
+	var deliveries []*WebhookDelivery
+	for _, delivery := range r.deliveries {
+		item, err := deliveryItem(delivery)
+		if err != nil {
+			return nil, err
+		}
+		if _, indexed := item["next_attempt_epoch"]; !indexed {
+			continue
+		}
+		if isDue(delivery.DeliveryStatus) && delivery.NextAttemptEpoch <= nowEpoch {
+			loaded := *delivery
+			deliveries = append(deliveries, &loaded)
+		}
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery applies the conditional update of the repository
+func (r *fakeRepository) ClaimDelivery(ctx context.Context, deliveryID, deliveryStatus string, nextAttemptEpoch, leaseEpoch int64) (bool, error) {
+	delivery, ok := r.deliveries[deliveryID]
+	if !ok || delivery.DeliveryStatus != deliveryStatus || delivery.NextAttemptEpoch != nextAttemptEpoch {
+		return false, nil
+	}
+	claimed := *delivery
+	claimed.DeliveryStatus = DeliveryStatusInFlight
+	claimed.NextAttemptEpoch = leaseEpoch
+	r.deliveries[deliveryID] = &claimed
+	return true, nil
+}
+
+// staleRepository returns the deliveries loaded before another run attempted them
+type staleRepository struct {
+	*fakeRepository
+	loaded []*WebhookDelivery
+}
+
+func (r *staleRepository) GetDueDeliveries(ctx context.Context, nowEpoch int64) ([]*WebhookDelivery, error) {
+	return r.loaded, nil
+}
+
+// receivedRequest is a request received by the test endpoint
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newEndpoint returns a TLS test server answering with the status code and the received requests
+func newEndpoint(t *testing.T, statusCode int) (*httptest.Server, func() []receivedRequest) {
+	var mu sync.Mutex
+	var received []receivedRequest
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+		mu.Lock()
+		received = append(received, receivedRequest{header: r.Header.Clone(), body: body})
+		mu.Unlock()
+		w.WriteHeader(statusCode)
+	}))
+	return server, func() []receivedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "sha256=a84f3be5b0025a8cdd7c382ee25d121a0f1261f1b82848a2b09ac7102cad0ea3",
+		Sign("key", "1623664800", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestMatchesEventType(t *testing.T) {
+	testCases := []struct {
+		filters   []string
+		eventType string
+		expected  bool
+	}{
+		{nil, "signature.individual_signed", true},
+		{[]string{"*"}, "cla_manager.added", true},
+		{[]string{"cla_manager.added"}, "cla_manager.added", true},
+		{[]string{"cla_manager.added"}, "cla_manager.removed", false},
+		{[]string{"signature.*"}, "signature.corporate_signed", true},
+		{[]string{"signature.*"}, "signatures.corporate_signed", false},
+		{[]string{"cla_manager.added", "signature.*"}, "signature.individual_signed", true},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, MatchesEventType(tc.filters, tc.eventType), "filters: %v, event type: %s", tc.filters, tc.eventType)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	assert.Nil(t, validateURL("https://ci.example.org/hooks/easycla"))
+	for _, invalidURL := range []string{
+		"",
+		"http://ci.example.org/hooks",
+		"https://localhost/hooks",
+		"https://127.0.0.1/hooks",
+		"https://10.1.2.3/hooks",
+		"https://192.168.1.1:8443/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hooks",
+		"https://100.64.0.1/hooks",
+	} {
+		assert.True(t, errors.Is(validateURL(invalidURL), ErrInvalidURL), invalidURL)
+	}
+}
+
+func TestHTTPClientRefusesNonPublicAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// the test server listens on the loopback address, as a host name resolving to it would
+	_, err := newHTTPClient().Get(server.URL) // nolint
+	assert.True(t, errors.Is(err, utils.ErrNonPublicAddress))
+}
+
+func TestHTTPClientDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer server.Close()
+
+	client := newHTTPClient()
+	client.Transport = server.Client().Transport
+	resp, err := client.Get(server.URL)
+	if assert.Nil(t, err) {
+		resp.Body.Close() // nolint
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+	}
+}
+
+func TestDispatchEvent(t *testing.T) {
+	server, received := newEndpoint(t, http.StatusOK)
+	defer server.Close()
+
+	repo := newFakeRepository(
+		&Webhook{WebhookID: "foundation-hook", OwnerType: OwnerTypeFoundation, OwnerID: "foundation-sfid", URL: server.URL, Secret: "foundation-secret", Enabled: true, EventTypes: []string{"signature.*"}},
+		&Webhook{WebhookID: "company-hook", OwnerType: OwnerTypeCompany, OwnerID: "company-id", URL: server.URL, Secret: "company-secret", Enabled: true},
+		&Webhook{WebhookID: "disabled-hook", OwnerType: OwnerTypeCompany, OwnerID: "company-id", URL: server.URL, Secret: "secret", Enabled: false},
+		&Webhook{WebhookID: "filtered-hook", OwnerType: OwnerTypeFoundation, OwnerID: "foundation-sfid", URL: server.URL, Secret: "secret", Enabled: true, EventTypes: []string{"cla_manager.added"}},
+	)
+	s := &service{repo: repo, httpClient: server.Client()}
+
+	err := s.DispatchEvent(context.Background(), &v1Models.Event{
+		EventID:             "event-id",
+		EventType:           "signature.corporate_signed",
+		EventProjectID:      "cla-group-id",
+		EventFoundationSFID: "foundation-sfid",
+		EventCompanyID:      "company-id",
+		EventSummary:        "the CCLA was signed",
+	})
+	assert.Nil(t, err)
+
+	// the deliveries are queued for the retry lambda
+	assert.Len(t, received(), 0)
+	assert.Len(t, repo.deliveries, 2)
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, DeliveryStatusPending, delivery.DeliveryStatus)
+		assert.Equal(t, int64(0), delivery.Attempts)
+	}
+
+	now := time.Now().Add(time.Minute)
+	attempted, err := s.RetryDeliveries(context.Background(), now)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempted)
+
+	requests := received()
+	assert.Len(t, requests, 2)
+	secrets := map[string]string{"foundation-hook": "foundation-secret", "company-hook": "company-secret"}
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, DeliveryStatusSucceeded, delivery.DeliveryStatus)
+		assert.Equal(t, int64(1), delivery.Attempts)
+		assert.Equal(t, int64(http.StatusOK), delivery.ResponseStatusCode)
+		assert.Contains(t, secrets, delivery.WebhookID)
+	}
+	for _, request := range requests {
+		assert.Equal(t, "signature.corporate_signed", request.header.Get(EventHeader))
+		delivery, ok := repo.deliveries[request.header.Get(DeliveryHeader)]
+		if !assert.True(t, ok) {
+			continue
+		}
+		assert.Equal(t, strconv.FormatInt(now.Unix(), 10), request.header.Get(TimestampHeader))
+		assert.Equal(t, Sign(secrets[delivery.WebhookID], request.header.Get(TimestampHeader), request.body), request.header.Get(SignatureHeader))
+
+		var payload Payload
+		assert.Nil(t, json.Unmarshal(request.body, &payload))
+		assert.Equal(t, "event-id", payload.EventID)
+		assert.Equal(t, "cla-group-id", payload.ClaGroupID)
+		assert.Equal(t, "the CCLA was signed", payload.Summary)
+	}
+}
+
+func TestDeliveryItemKeepsTheDueDeliveriesInTheStatusIndex(t *testing.T) {
+	now := time.Date(2021, 6, 14, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		status  string
+		indexed bool
+	}{
+		{DeliveryStatusPending, true},
+		{DeliveryStatusRetrying, true},
+		{DeliveryStatusInFlight, true},
+		{DeliveryStatusSucceeded, false},
+		{DeliveryStatusFailed, false},
+	}
+	for _, tc := range testCases {
+		item, err := deliveryItem(&WebhookDelivery{DeliveryID: "delivery-id", DeliveryStatus: tc.status, NextAttemptEpoch: now.Unix()})
+		assert.Nil(t, err)
+		_, indexed := item["next_attempt_epoch"]
+		assert.Equal(t, tc.indexed, indexed, "status: %s", tc.status)
+	}
+
+	// a queued delivery is returned as due by the status index
+	delivery := &WebhookDelivery{DeliveryID: "delivery-id", WebhookID: "webhook-id"}
+	queue(delivery, now)
+	repo := newFakeRepository()
+	assert.Nil(t, repo.PutDelivery(context.Background(), delivery))
+	due, err := repo.GetDueDeliveries(context.Background(), now.Unix())
+	assert.Nil(t, err)
+	assert.Len(t, due, 1)
+}
+
+func TestAttemptRetriesWithBackoff(t *testing.T) {
+	server, received := newEndpoint(t, http.StatusInternalServerError)
+	defer server.Close()
+
+	webhook := &Webhook{WebhookID: "webhook-id", URL: server.URL, Secret: "secret", Enabled: true}
+	s := &service{repo: newFakeRepository(webhook), httpClient: server.Client()}
+	delivery := &WebhookDelivery{DeliveryID: "delivery-id", WebhookID: "webhook-id", Payload: "{}", DeliveryStatus: DeliveryStatusPending}
+
+	now := time.Date(2021, 6, 14, 10, 0, 0, 0, time.UTC)
+	for i, delay := range retryDelays {
+		s.attempt(context.Background(), webhook, delivery, now)
+		assert.Equal(t, DeliveryStatusRetrying, delivery.DeliveryStatus, "attempt %d", i+1)
+		assert.Equal(t, now.Add(delay).Unix(), delivery.NextAttemptEpoch, "attempt %d", i+1)
+		assert.Equal(t, int64(http.StatusInternalServerError), delivery.ResponseStatusCode)
+		assert.NotEmpty(t, delivery.Error)
+	}
+
+	s.attempt(context.Background(), webhook, delivery, now)
+	assert.Equal(t, DeliveryStatusFailed, delivery.DeliveryStatus)
+	assert.Equal(t, int64(0), delivery.NextAttemptEpoch)
+	assert.Equal(t, int64(len(retryDelays)+1), delivery.Attempts)
+	assert.Len(t, received(), len(retryDelays)+1)
+}
+
+func TestRetryDeliveries(t *testing.T) {
+	server, received := newEndpoint(t, http.StatusNoContent)
+	defer server.Close()
+
+	now := time.Date(2021, 6, 14, 10, 0, 0, 0, time.UTC)
+	repo := newFakeRepository(
+		&Webhook{WebhookID: "webhook-id", URL: server.URL, Secret: "secret", Enabled: true},
+		&Webhook{WebhookID: "disabled-webhook-id", URL: server.URL, Secret: "secret", Enabled: false},
+	)
+	repo.deliveries = map[string]*WebhookDelivery{
+		"due":      {DeliveryID: "due", WebhookID: "webhook-id", DeliveryStatus: DeliveryStatusRetrying, Attempts: 1, NextAttemptEpoch: now.Add(-time.Minute).Unix()},
+		"not-due":  {DeliveryID: "not-due", WebhookID: "webhook-id", DeliveryStatus: DeliveryStatusRetrying, Attempts: 1, NextAttemptEpoch: now.Add(time.Minute).Unix()},
+		"deleted":  {DeliveryID: "deleted", WebhookID: "deleted-webhook-id", DeliveryStatus: DeliveryStatusRetrying, Attempts: 2, NextAttemptEpoch: now.Unix()},
+		"disabled": {DeliveryID: "disabled", WebhookID: "disabled-webhook-id", DeliveryStatus: DeliveryStatusRetrying, Attempts: 2, NextAttemptEpoch: now.Unix()},
+	}
+	s := &service{repo: repo, httpClient: server.Client()}
+
+	attempted, err := s.RetryDeliveries(context.Background(), now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Len(t, received(), 1)
+
+	assert.Equal(t, DeliveryStatusSucceeded, repo.deliveries["due"].DeliveryStatus)
+	assert.Equal(t, int64(2), repo.deliveries["due"].Attempts)
+	assert.Equal(t, DeliveryStatusRetrying, repo.deliveries["not-due"].DeliveryStatus)
+	assert.Equal(t, DeliveryStatusFailed, repo.deliveries["deleted"].DeliveryStatus)
+	assert.Equal(t, DeliveryStatusFailed, repo.deliveries["disabled"].DeliveryStatus)
+}
+
+func TestRetryDeliveriesClaimsTheDeliveries(t *testing.T) {
+	server, received := newEndpoint(t, http.StatusNoContent)
+	defer server.Close()
+
+	now := time.Date(2021, 6, 14, 10, 0, 0, 0, time.UTC)
+	repo := newFakeRepository(&Webhook{WebhookID: "webhook-id", URL: server.URL, Secret: "secret", Enabled: true})
+	repo.deliveries = map[string]*WebhookDelivery{
+		"pending":  {DeliveryID: "pending", WebhookID: "webhook-id", DeliveryStatus: DeliveryStatusPending, NextAttemptEpoch: now.Unix()},
+		"retrying": {DeliveryID: "retrying", WebhookID: "webhook-id", DeliveryStatus: DeliveryStatusRetrying, Attempts: 1, NextAttemptEpoch: now.Unix()},
+	}
+
+	// both runs load the due deliveries before either one attempts them
+	loaded, err := repo.GetDueDeliveries(context.Background(), now.Unix())
+	assert.Nil(t, err)
+	first := &service{repo: repo, httpClient: server.Client()}
+	second := &service{repo: &staleRepository{fakeRepository: repo, loaded: loaded}, httpClient: server.Client()}
+
+	attempted, err := first.RetryDeliveries(context.Background(), now)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempted)
+
+	attempted, err = second.RetryDeliveries(context.Background(), now)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, attempted)
+	assert.Len(t, received(), 2)
+	assert.Equal(t, DeliveryStatusSucceeded, repo.deliveries["pending"].DeliveryStatus)
+	assert.Equal(t, DeliveryStatusSucceeded, repo.deliveries["retrying"].DeliveryStatus)
+}
+
+func TestRetryDeliveriesReclaimsTheExpiredLeases(t *testing.T) {
+	server, received := newEndpoint(t, http.StatusNoContent)
+	defer server.Close()
+
+	now := time.Date(2021, 6, 14, 10, 0, 0, 0, time.UTC)
+	repo := newFakeRepository(&Webhook{WebhookID: "webhook-id", URL: server.URL, Secret: "secret", Enabled: true})
+	repo.deliveries = map[string]*WebhookDelivery{
+		"held":    {DeliveryID: "held", WebhookID: "webhook-id", DeliveryStatus: DeliveryStatusInFlight, NextAttemptEpoch: now.Add(time.Minute).Unix()},
+		"expired": {DeliveryID: "expired", WebhookID: "webhook-id", DeliveryStatus: DeliveryStatusInFlight, NextAttemptEpoch: now.Add(-time.Minute).Unix()},
+	}
+	s := &service{repo: repo, httpClient: server.Client()}
+
+	attempted, err := s.RetryDeliveries(context.Background(), now)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Len(t, received(), 1)
+	assert.Equal(t, DeliveryStatusInFlight, repo.deliveries["held"].DeliveryStatus)
+	assert.Equal(t, DeliveryStatusSucceeded, repo.deliveries["expired"].DeliveryStatus)
+}
+
+func TestRedeliver(t *testing.T) {
+	server, received := newEndpoint(t, http.StatusOK)
+	defer server.Close()
+
+	repo := newFakeRepository(
+		&Webhook{WebhookID: "webhook-id", URL: server.URL, Secret: "secret", Enabled: true},
+		&Webhook{WebhookID: "other-webhook-id", URL: server.URL, Secret: "secret", Enabled: true},
+	)
+	repo.deliveries["delivery-id"] = &WebhookDelivery{DeliveryID: "delivery-id", WebhookID: "webhook-id", EventID: "event-id",
+		EventType: "cla_manager.added", Payload: `{"eventID":"event-id"}`, DeliveryStatus: DeliveryStatusFailed, Attempts: 6}
+	s := &service{repo: repo, httpClient: server.Client()}
+
+	_, err := s.Redeliver(context.Background(), "other-webhook-id", "delivery-id")
+	assert.Equal(t, ErrDeliveryDoesNotExist, err)
+
+	redelivery, err := s.Redeliver(context.Background(), "webhook-id", "delivery-id")
+	assert.Nil(t, err)
+	assert.NotEqual(t, "delivery-id", redelivery.DeliveryID)
+	assert.Equal(t, "delivery-id", redelivery.RedeliveryOf)
+	assert.Equal(t, DeliveryStatusPending, redelivery.Status)
+	assert.Len(t, received(), 0)
+
+	_, err = s.RetryDeliveries(context.Background(), time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, DeliveryStatusSucceeded, repo.deliveries[redelivery.DeliveryID].DeliveryStatus)
+	assert.Equal(t, DeliveryStatusFailed, repo.deliveries["delivery-id"].DeliveryStatus)
+
+	requests := received()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, `{"eventID":"event-id"}`, string(requests[0].body))
+		assert.Equal(t, "cla_manager.added", requests[0].header.Get(EventHeader))
+	}
+}
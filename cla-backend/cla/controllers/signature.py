@@ -298,6 +298,10 @@ def update_signature(signature_id,  # pylint: disable=too-many-arguments,too-man
                 'github_org_whitelist': 'Invalid value passed in for the github org whitelist'
             }}
 
+    if any(approval_list is not None for approval_list in
+           [domain_whitelist, email_whitelist, github_whitelist, github_org_whitelist]):
+        signature.set_approval_list_modified_by(auth_user.username)
+
     event_data = update_str
     Event.create_event(
         event_data=event_data,
@@ -1104,6 +1104,9 @@ class DocuSign(signing_service_interface.SigningService):
                                                            signature_approved=True,
                                                            signature_type='company',
                                                            signature_reference_id=company_id)
+        # A signed CCLA flagged for a re-signature does not prevent the company from signing the new version, the
+        # new signature replaces it once signed
+        signatures = [sig for sig in signatures if not (sig.get_signature_signed() and sig.requires_resignature())]
 
         # Determine if we have any signed signatures matching this CCLA
         # May have some signed and/or started/not-signed due to prior bug
@@ -1442,6 +1442,10 @@ class UserModel(BaseModel):
     lf_username = UnicodeAttribute(null=True)
     lf_username_index = LFUsernameIndex()
     lf_sub = UnicodeAttribute(null=True)
+    # language of the notification emails, managed by the Go backend
+    user_preferred_language = UnicodeAttribute(null=True)
+    # notification frequency per category, managed by the Go backend
+    user_notification_preferences = MapAttribute(null=True)
 
 
 class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
@@ -2225,6 +2229,8 @@ class SignatureModel(BaseModel):  # pylint: disable=too-many-instance-attributes
     signature_project_id = UnicodeAttribute()
     signature_document_minor_version = NumberAttribute()
     signature_document_major_version = NumberAttribute()
+    # Major version the signature must be re-signed against, set once a new major version of the document is published
+    signature_resign_major_version = UnicodeAttribute(null=True)
     signature_reference_id = UnicodeAttribute()
     signature_reference_name = UnicodeAttribute(null=True)
     signature_reference_name_lower = UnicodeAttribute(null=True)
@@ -2267,6 +2273,8 @@ class SignatureModel(BaseModel):  # pylint: disable=too-many-instance-attributes
     email_whitelist = ListAttribute(null=True)
     github_whitelist = ListAttribute(null=True)
     github_org_whitelist = ListAttribute(null=True)
+    # LF username of the last approval list update, recorded on the approval list changed events
+    approval_list_modified_by = UnicodeAttribute(null=True)
 
     # Additional attributes for ICLAs
     user_email = UnicodeAttribute(null=True)
@@ -2463,6 +2471,24 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def get_signature_document_major_version(self):
         return self.model.signature_document_major_version
 
+    def get_signature_resign_major_version(self):
+        return self.model.signature_resign_major_version
+
+    def requires_resignature(self):
+        """
+        Returns True if the signature was signed against an older document major version than the one it must be
+        re-signed against - the new signature replaces it once signed.
+        """
+        if not self.model.signature_resign_major_version:
+            return False
+        try:
+            required = int(self.model.signature_resign_major_version)
+        except ValueError:
+            return False
+        if self.model.signature_document_major_version is None:
+            return True
+        return int(self.model.signature_document_major_version) < required
+
     def get_signature_type(self):
         return self.model.signature_type
 
@@ -2680,6 +2706,9 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def set_github_org_whitelist(self, github_org_whitelist):
         self.model.github_org_whitelist = [github_org.strip() for github_org in github_org_whitelist]
 
+    def set_approval_list_modified_by(self, approval_list_modified_by):
+        self.model.approval_list_modified_by = approval_list_modified_by
+
     def set_note(self, note):
         self.model.note = note
 
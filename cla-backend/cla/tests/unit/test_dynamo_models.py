@@ -5,7 +5,7 @@ from unittest.mock import Mock
 
 import pytest
 
-from cla.models.dynamo_models import User, Company
+from cla.models.dynamo_models import User, Company, Signature
 from cla import utils
 
 @pytest.fixture
@@ -29,4 +29,11 @@ def test_get_user_email(user):
     user.model.user_emails = set(["wanyaland@gmail.com"])
     assert utils.get_public_email(user) == "wanyaland@gmail.com"
 
-
+def test_signature_requires_resignature():
+    """ Test a signature signed against an older major version than the re-signature version """
+    signature = Signature(signature_document_major_version=1)
+    assert not signature.requires_resignature()
+    signature.model.signature_resign_major_version = "2"
+    assert signature.requires_resignature()
+    signature.set_signature_document_major_version(2)
+    assert not signature.requires_resignature()
@@ -128,7 +128,7 @@ export class ClaService {
   requestToBeOnCompanyApprovedList(userId, companyId, projectId, data) {
     //const url: URL = this.getV2Endpoint('/v2/user/' + userId + '/request-company-whitelist/' + companyId);
     const url: URL = this.getV3Endpoint(`/v3/company/${companyId}/ccla-whitelist-requests/${projectId}`);
-    return this.http.post(url, data);// no response .map((res) => res.json());
+    return this.http.postWithCreds(url, data);// no response .map((res) => res.json());
   }
 
   /**
@@ -228,7 +228,7 @@ export class ClaService {
    */
   postCCLAWhitelistRequest(companyID, projectID, user) {
     const url: URL = this.getV3Endpoint('/v3/company/' + companyID + '/ccla-whitelist-requests/' + projectID);
-    return this.http.post(url, user);
+    return this.http.postWithCreds(url, user);
   }
 
   getGerrit(gerritId) {
@@ -27,6 +27,10 @@ const parameters = [
   `cla-gh-oauth-secret-${program.stage}`,
   `cla-gh-access-token-${program.stage}`,
   `cla-gh-app-public-link-${program.stage}`,
+  `cla-gitlab-api-url-${program.stage}`,
+  `cla-gitlab-access-token-${program.stage}`,
+  `cla-gitlab-webhook-secret-${program.stage}`,
+  `cla-gerrit-hook-secret-${program.stage}`,
   `cla-auth0-domain-${program.stage}`,
   `cla-auth0-clientId-${program.stage}`,
   `cla-auth0-username-claim-${program.stage}`,